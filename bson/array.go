@@ -32,6 +32,12 @@ func NewArray(values ...*Value) *Array {
 	return &Array{doc: NewDocument(elems...)}
 }
 
+// NewArraySize creates an empty array with room for n values, so that appending up to n values
+// does not grow its storage.
+func NewArraySize(n int) *Array {
+	return &Array{doc: NewDocumentSize(n)}
+}
+
 // ArrayFromDocument creates an array from a *Document. The returned array
 // does not make a copy of the *Document, so any changes made to either will
 // be present in both.
@@ -44,7 +50,7 @@ func (a *Array) Len() int {
 	return len(a.doc.elems)
 }
 
-// Reset clears all elements from the array.
+// Reset clears all elements from the array, keeping its storage so that it can be reused.
 func (a *Array) Reset() {
 	a.doc.Reset()
 }
@@ -128,6 +134,23 @@ func (a *Array) Append(values ...*Value) *Array {
 	return a
 }
 
+// AppendAll is the same as Append, except it reserves room for all of the values at once and
+// indexes them together, which is much faster when appending many values. It returns a reference to
+// itself.
+func (a *Array) AppendAll(values ...*Value) *Array {
+	elems := make([]*Element, len(values))
+	backing := make([]Element, len(values))
+	for i, v := range values {
+		if v != nil {
+			backing[i].value = v
+			elems[i] = &backing[i]
+		}
+	}
+	a.doc.AppendAll(elems...)
+
+	return a
+}
+
 // Prepend adds the given values to the beginning of the array. It returns a reference to itself.
 func (a *Array) Prepend(values ...*Value) *Array {
 	a.doc.Prepend(elemsFromValues(values)...)
@@ -413,3 +413,53 @@ func ExampleArray() {
 
 	// Output: [154 0 0 0 3 48 0 52 0 0 0 2 110 97 109 101 0 16 0 0 0 109 111 110 103 111 45 103 111 45 100 114 105 118 101 114 0 2 118 101 114 115 105 111 110 0 8 0 0 0 49 50 51 52 53 54 55 0 0 3 49 0 46 0 0 0 2 116 121 112 101 0 7 0 0 0 100 97 114 119 105 110 0 2 97 114 99 104 105 116 101 99 116 117 114 101 0 6 0 0 0 97 109 100 54 52 0 0 2 50 0 8 0 0 0 103 111 49 46 57 46 50 0 3 51 0 27 0 0 0 2 110 97 109 101 0 12 0 0 0 104 101 108 108 111 45 119 111 114 108 100 0 0 0]
 }
+
+func TestArrayAppendAll(t *testing.T) {
+	values := []*Value{VC.Int32(1), VC.String("two"), VC.DocumentFromElements(EC.Int32("three", 3))}
+
+	want := NewArray(values...)
+	got := NewArraySize(len(values)).Append(values[0]).AppendAll(values[1:]...)
+	if !got.Equal(want) {
+		t.Errorf("Arrays differ. got %v; want %v", got, want)
+	}
+	v, err := got.Lookup(2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := v.MutableDocument().Lookup("three").Int32(); n != 3 {
+		t.Errorf("Unexpected value. got %d; want %d", n, 3)
+	}
+}
+
+// BenchmarkArrayAppend builds an array of 10k subdocuments.
+func BenchmarkArrayAppend(b *testing.B) {
+	const n = 10000
+	values := make([]*Value, n)
+	for i := range values {
+		values[i] = VC.DocumentFromElements(EC.Int32("i", int32(i)), EC.String("name", "ingest"))
+	}
+
+	b.Run("Append", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			arr := NewArray()
+			for _, v := range values {
+				arr.Append(v)
+			}
+		}
+	})
+	b.Run("AppendAll", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			NewArraySize(n).AppendAll(values...)
+		}
+	})
+	b.Run("AppendAll Reset", func(b *testing.B) {
+		b.ReportAllocs()
+		arr := NewArraySize(n)
+		for i := 0; i < b.N; i++ {
+			arr.Reset()
+			arr.AppendAll(values...)
+		}
+	})
+}
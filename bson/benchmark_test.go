@@ -1,6 +1,9 @@
 package bson
 
-import "testing"
+import (
+	"bytes"
+	"testing"
+)
 
 type encodetest struct {
 	Field1String  string
@@ -164,3 +167,33 @@ func BenchmarkEncodingDocumentNested(b *testing.B) {
 		_, _ = MarshalDocument(nestedInstance)
 	}
 }
+
+func BenchmarkDecodingStruct(b *testing.B) {
+	doc, err := Marshal(encodetestInstance)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("new decoder", func(b *testing.B) {
+		b.ReportAllocs()
+		var out encodetest
+		for i := 0; i < b.N; i++ {
+			if err := NewDecoder(bytes.NewReader(doc)).Decode(&out); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("reset", func(b *testing.B) {
+		b.ReportAllocs()
+		var out encodetest
+		r := bytes.NewReader(doc)
+		dec := NewDecoder(r)
+		for i := 0; i < b.N; i++ {
+			r.Reset(doc)
+			dec.Reset(r)
+			if err := dec.Decode(&out); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
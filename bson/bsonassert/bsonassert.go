@@ -0,0 +1,101 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package bsonassert provides test assertions on BSON documents that report where documents
+// differ instead of printing their bytes.
+package bsonassert
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+)
+
+// TestingT is the subset of testing.TB used by the assertions.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// FailNowT is the subset of testing.TB used by the assertions that stop the test.
+type FailNowT interface {
+	TestingT
+	FailNow()
+}
+
+type helper interface {
+	Helper()
+}
+
+// Equal asserts that the documents expected and actual are equal according to bson.Diff, and
+// reports each difference followed by both documents as indented extended JSON if they are not.
+// The documents can be a *bson.Document, a bson.Reader or a []byte holding a BSON document.
+// msgAndArgs are passed to fmt.Sprint, or to fmt.Sprintf if the first one is a format string,
+// and added to the report. It returns whether the documents are equal.
+func Equal(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	exp, err := document(expected)
+	if err != nil {
+		t.Errorf("bsonassert: invalid expected document: %v%s", err, message(msgAndArgs))
+		return false
+	}
+	act, err := document(actual)
+	if err != nil {
+		t.Errorf("bsonassert: invalid actual document: %v%s", err, message(msgAndArgs))
+		return false
+	}
+
+	diffs := bson.Diff(exp, act)
+	if len(diffs) == 0 {
+		return true
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "documents differ%s:\n", message(msgAndArgs))
+	for _, diff := range diffs {
+		fmt.Fprintf(&buf, "  %s\n", diff)
+	}
+	fmt.Fprintf(&buf, "expected: %+v\nactual:   %+v", exp, act)
+	t.Errorf("%s", buf.String())
+	return false
+}
+
+// RequireEqual is the same as Equal, except it stops the test if the documents are not equal.
+func RequireEqual(t FailNowT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+	if !Equal(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+func document(v interface{}) (*bson.Document, error) {
+	switch doc := v.(type) {
+	case nil:
+		return nil, nil
+	case *bson.Document:
+		return doc, nil
+	case bson.Reader:
+		return bson.ReadDocument(doc)
+	case []byte:
+		return bson.ReadDocument(doc)
+	}
+	return nil, fmt.Errorf("unsupported type %T", v)
+}
+
+func message(msgAndArgs []interface{}) string {
+	if len(msgAndArgs) == 0 {
+		return ""
+	}
+	if format, ok := msgAndArgs[0].(string); ok && len(msgAndArgs) > 1 {
+		return ": " + fmt.Sprintf(format, msgAndArgs[1:]...)
+	}
+	return ": " + fmt.Sprint(msgAndArgs...)
+}
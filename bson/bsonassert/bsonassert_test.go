@@ -0,0 +1,66 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonassert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	errors []string
+	failed bool
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) FailNow() {
+	r.failed = true
+}
+
+func TestEqual(t *testing.T) {
+	doc := bson.NewDocument(bson.EC.Int32("x", 1), bson.EC.String("y", "a"))
+	rdr, err := doc.MarshalBSON()
+	require.NoError(t, err)
+
+	t.Run("equal", func(t *testing.T) {
+		r := new(recorder)
+		require.True(t, Equal(r, doc, bson.Reader(rdr)))
+		require.True(t, Equal(r, rdr, doc))
+		require.Empty(t, r.errors)
+	})
+	t.Run("different", func(t *testing.T) {
+		r := new(recorder)
+		other := bson.NewDocument(bson.EC.Int32("x", 2))
+		require.False(t, Equal(r, doc, other, "find %s", "command"))
+		require.Len(t, r.errors, 1)
+
+		msg := r.errors[0]
+		require.True(t, strings.HasPrefix(msg, "documents differ: find command:\n"), msg)
+		require.Contains(t, msg, "  x: value mismatch: 1 != 2\n")
+		require.Contains(t, msg, "  y: missing \"a\"\n")
+		require.Contains(t, msg, fmt.Sprintf("expected: %+v\n", doc))
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		r := new(recorder)
+		require.False(t, Equal(r, doc, 42))
+		require.Equal(t, []string{"bsonassert: invalid actual document: unsupported type int"}, r.errors)
+	})
+	t.Run("require", func(t *testing.T) {
+		r := new(recorder)
+		RequireEqual(r, doc, doc)
+		require.False(t, r.failed)
+		RequireEqual(r, doc, bson.NewDocument())
+		require.True(t, r.failed)
+	})
+}
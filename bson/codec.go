@@ -39,6 +39,7 @@ var defaultDecimal128Codec = &Decimal128Codec{}
 var defaultMinKeyCodec = &MinKeyCodec{}
 var defaultMaxKeyCodec = &MaxKeyCodec{}
 var defaultJSONNumberCodec = &JSONNumberCodec{}
+var defaultJSONRawMessageCodec = &JSONRawMessageCodec{}
 var defaultURLCodec = &URLCodec{}
 var defaultReaderCodec = &ReaderCodec{}
 var defaultElementSliceCodec = &ElementSliceCodec{}
@@ -95,6 +96,9 @@ func (dee CodecDecodeError) Error() string {
 type EncodeContext struct {
 	*Registry
 	MinSize bool
+
+	depth    int
+	visiting map[visit]struct{}
 }
 
 // DecodeContext is the contextual information required for a Codec to decode a
@@ -102,6 +106,8 @@ type EncodeContext struct {
 type DecodeContext struct {
 	*Registry
 	Truncate bool
+
+	depth int
 }
 
 // Codec implementations handle encoding and decoding values. They can be
@@ -1794,6 +1800,117 @@ func (jnc *JSONNumberCodec) DecodeValue(dc DecodeContext, vr ValueReader, i inte
 	return nil
 }
 
+// JSONConversionError is returned by the JSONRawMessageCodec when a json.RawMessage cannot be
+// converted to or from BSON.
+type JSONConversionError struct {
+	// Path is the dotted path of keys of the field holding the json.RawMessage, which is empty
+	// when the json.RawMessage is the value being encoded or decoded.
+	Path string
+	Err  error
+}
+
+func (jce JSONConversionError) Error() string {
+	if jce.Path == "" {
+		return fmt.Sprintf("cannot convert json.RawMessage: %v", jce.Err)
+	}
+	return fmt.Sprintf("cannot convert json.RawMessage at %s: %v", jce.Path, jce.Err)
+}
+
+// prefixPath prepends key to the path of err if it is a JSONConversionError, an ErrRecursionLimit or
+// an ErrCycleDetected, so that the error identifies the field at which it happened. Other errors are
+// returned unchanged.
+func prefixPath(err error, key string) error {
+	switch e := err.(type) {
+	case JSONConversionError:
+		e.Path = prependKey(key, e.Path)
+		return e
+	case ErrRecursionLimit:
+		e.Path = prependKey(key, e.Path)
+		return e
+	case ErrCycleDetected:
+		e.Path = prependKey(key, e.Path)
+		return e
+	}
+	return err
+}
+
+func prependKey(key, path string) string {
+	if path == "" {
+		return key
+	}
+	return key + "." + path
+}
+
+// JSONRawMessageCodec is the Codec for json.RawMessage values. It encodes a json.RawMessage by
+// parsing it as extended JSON, so that {"$oid": ...} becomes an ObjectID for instance, and decodes
+// BSON values into relaxed extended JSON. A nil or empty json.RawMessage is encoded as null.
+type JSONRawMessageCodec struct{}
+
+var _ Codec = &JSONRawMessageCodec{}
+
+// jsonRawMessageKey is the key a json.RawMessage is wrapped under to be parsed or rendered as the
+// value of a document, which lets the same conversion handle objects, arrays and scalars.
+const jsonRawMessageKey = "v"
+
+// EncodeValue implements the Codec interface.
+func (jrmc *JSONRawMessageCodec) EncodeValue(ec EncodeContext, vw ValueWriter, i interface{}) error {
+	var raw json.RawMessage
+	switch t := i.(type) {
+	case json.RawMessage:
+		raw = t
+	case *json.RawMessage:
+		if t != nil {
+			raw = *t
+		}
+	default:
+		return CodecEncodeError{Codec: jrmc, Types: []interface{}{json.RawMessage(nil), (*json.RawMessage)(nil)}, Received: i}
+	}
+
+	if len(strings.TrimSpace(string(raw))) == 0 {
+		return vw.WriteNull()
+	}
+
+	doc, err := ParseExtJSONObject(`{"` + jsonRawMessageKey + `":` + string(raw) + `}`)
+	if err != nil {
+		return JSONConversionError{Err: err}
+	}
+	val, err := doc.LookupErr(jsonRawMessageKey)
+	if err != nil {
+		return JSONConversionError{Err: err}
+	}
+
+	return defaultValueCodec.encodeValue(ec, vw, val)
+}
+
+// DecodeValue implements the Codec interface.
+func (jrmc *JSONRawMessageCodec) DecodeValue(dc DecodeContext, vr ValueReader, i interface{}) error {
+	target, ok := i.(*json.RawMessage)
+	if !ok || target == nil {
+		return fmt.Errorf("%T can only be used to decode non-nil *json.RawMessage values, got %T", jrmc, i)
+	}
+
+	var val *Value
+	if err := defaultValueCodec.decodeValue(dc, vr, &val); err != nil {
+		return err
+	}
+
+	b, err := NewDocument(EC.Interface(jsonRawMessageKey, val)).MarshalBSON()
+	if err != nil {
+		return JSONConversionError{Err: err}
+	}
+	s, err := ToExtJSON(false, b)
+	if err != nil {
+		return JSONConversionError{Err: err}
+	}
+
+	prefix := `{"` + jsonRawMessageKey + `":`
+	if !strings.HasPrefix(s, prefix) || !strings.HasSuffix(s, "}") {
+		return JSONConversionError{Err: fmt.Errorf("unexpected extended JSON %s", s)}
+	}
+	*target = json.RawMessage(s[len(prefix) : len(s)-1])
+	return nil
+}
+
 // URLCodec is the Codec for url.URL values.
 type URLCodec struct{}
 
@@ -1860,6 +1977,9 @@ func (tc *TimeCodec) EncodeValue(ec EncodeContext, vw ValueWriter, i interface{}
 	case time.Time:
 		tt = t
 	case *time.Time:
+		if t == nil {
+			return vw.WriteNull()
+		}
 		tt = *t
 	default:
 		return CodecEncodeError{Codec: tc, Types: []interface{}{time.Time{}, (*time.Time)(nil)}, Received: i}
@@ -1880,7 +2000,7 @@ func (tc *TimeCodec) DecodeValue(dc DecodeContext, vr ValueReader, i interface{}
 	}
 
 	if target, ok := i.(*time.Time); ok && target != nil {
-		*target = time.Unix(dt/1000, dt%1000*1000000)
+		*target = time.Unix(dt/1000, dt%1000*1000000).UTC()
 		return nil
 	}
 
@@ -1889,7 +2009,7 @@ func (tc *TimeCodec) DecodeValue(dc DecodeContext, vr ValueReader, i interface{}
 		if tt == nil {
 			tt = new(time.Time)
 		}
-		*tt = time.Unix(dt/1000, dt%1000*1000000)
+		*tt = time.Unix(dt/1000, dt%1000*1000000).UTC()
 		*target = tt
 		return nil
 	}
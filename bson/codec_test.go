@@ -912,15 +912,15 @@ func TestProvidedCodecs(t *testing.T) {
 					nil,
 					nil,
 					llvrwNothing,
-					fmt.Errorf("%T can only encode maps with string keys", &MapCodec{}),
+					fmt.Errorf("%T can only encode maps", &MapCodec{}),
 				},
 				{
-					"wrong kind (non-string key)",
-					map[int]interface{}{},
+					"unsupported key type",
+					map[float64]interface{}{},
 					nil,
 					nil,
 					llvrwNothing,
-					fmt.Errorf("%T can only encode maps with string keys", &MapCodec{}),
+					fmt.Errorf("%T cannot process maps with keys of type float64", &MapCodec{}),
 				},
 				{
 					"WriteDocument Error",
@@ -962,15 +962,15 @@ func TestProvidedCodecs(t *testing.T) {
 					nil,
 					&llValueReaderWriter{},
 					llvrwNothing,
-					fmt.Errorf("%T can only decode settable maps with string keys", &MapCodec{}),
+					fmt.Errorf("%T can only decode settable maps", &MapCodec{}),
 				},
 				{
-					"wrong kind (non-string key)",
-					map[int]interface{}{},
+					"unsupported key type",
+					map[float64]interface{}{},
 					nil,
 					&llValueReaderWriter{},
 					llvrwNothing,
-					fmt.Errorf("%T can only decode settable maps with string keys", &MapCodec{}),
+					fmt.Errorf("%T cannot process maps with keys of type float64", &MapCodec{}),
 				},
 				{
 					"ReadDocument Error",
@@ -0,0 +1,111 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIncompleteDBRef is returned when unmarshaling a document without a $ref or an $id field into
+// a DBRef.
+var ErrIncompleteDBRef = errors.New("bson: a DBRef must have a $ref and an $id field")
+
+// DBRef is a reference to the document of a collection whose _id is ID. It is stored as a
+// document with $ref, $id and, when DB is not empty, $db fields, in that order, which is the
+// convention the DBRefs of other drivers and of the shell follow. DB is the database of the
+// collection, and is empty for references to the database of the referring document.
+type DBRef struct {
+	Collection string
+	ID         interface{}
+	DB         string
+}
+
+// MarshalBSON implements the Marshaler interface.
+func (ref DBRef) MarshalBSON() ([]byte, error) {
+	var id *Element
+	switch t := ref.ID.(type) {
+	case Binary:
+		id = EC.BinaryWithSubtype("$id", t.Data, t.Subtype)
+	default:
+		var err error
+		id, err = EC.InterfaceErr("$id", ref.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	doc := NewDocument(EC.String("$ref", ref.Collection), id)
+	if ref.DB != "" {
+		doc.Append(EC.String("$db", ref.DB))
+	}
+	return doc.MarshalBSON()
+}
+
+// UnmarshalBSON implements the Unmarshaler interface. Fields other than $ref, $id and $db, which
+// some applications add to their references, are ignored. Document and array IDs are unmarshaled
+// as a *Document and an *Array, binary IDs as a Binary, and other IDs as by Value.Interface.
+func (ref *DBRef) UnmarshalBSON(b []byte) error {
+	var out DBRef
+	var hasRef, hasID bool
+	err := Reader(b).ForEach(func(key []byte, val Value) error {
+		switch string(key) {
+		case "$ref":
+			coll, ok := val.StringValueOK()
+			if !ok {
+				return fmt.Errorf("bson: the $ref field of a DBRef must be a string, not a %v", val.Type())
+			}
+			out.Collection, hasRef = coll, true
+		case "$id":
+			id, err := dbRefID(&val)
+			if err != nil {
+				return err
+			}
+			out.ID, hasID = id, true
+		case "$db":
+			db, ok := val.StringValueOK()
+			if !ok {
+				return fmt.Errorf("bson: the $db field of a DBRef must be a string, not a %v", val.Type())
+			}
+			out.DB = db
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !hasRef || !hasID {
+		return ErrIncompleteDBRef
+	}
+
+	*ref = out
+	return nil
+}
+
+// dbRefID returns the Go value of the $id field of a DBRef, which must not refer to the bytes of
+// the document being unmarshaled, as val does.
+func dbRefID(val *Value) (interface{}, error) {
+	t, b, err := val.MarshalBSONValue()
+	if err != nil {
+		return nil, err
+	}
+	v, err := newValue(t, b)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v.Type() {
+	case TypeEmbeddedDocument:
+		return v.MutableDocument(), nil
+	case TypeArray:
+		return v.MutableArray(), nil
+	case TypeBinary:
+		subtype, data := v.Binary()
+		return Binary{Subtype: subtype, Data: data}, nil
+	}
+	return v.Interface(), nil
+}
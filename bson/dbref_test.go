@@ -0,0 +1,114 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBRef(t *testing.T) {
+	oid, err := objectid.FromHex("5b3b6b7b7b7b7b7b7b7b7b7b")
+	require.NoError(t, err)
+
+	type owner struct {
+		Name  string
+		Ref   DBRef
+		Other *DBRef
+		Refs  []DBRef
+	}
+
+	in := owner{
+		Name:  "a",
+		Ref:   DBRef{Collection: "users", ID: oid},
+		Other: &DBRef{Collection: "accounts", ID: "acct-1", DB: "billing"},
+		Refs:  []DBRef{{Collection: "tags", ID: int32(7)}},
+	}
+	want := NewDocument(
+		EC.String("name", "a"),
+		EC.SubDocumentFromElements("ref", EC.String("$ref", "users"), EC.ObjectID("$id", oid)),
+		EC.SubDocumentFromElements("other",
+			EC.String("$ref", "accounts"),
+			EC.String("$id", "acct-1"),
+			EC.String("$db", "billing"),
+		),
+		EC.ArrayFromElements("refs", VC.DocumentFromElements(EC.String("$ref", "tags"), EC.Int32("$id", 7))),
+	)
+
+	t.Run("registry", func(t *testing.T) {
+		reg := NewRegistryBuilder().Build()
+		doc, err := MarshalDocumentWithRegistry(reg, in)
+		require.NoError(t, err)
+		require.True(t, want.Equal(doc), "expected %v, got %v", want, doc)
+
+		var out owner
+		require.NoError(t, UnmarshalDocumentWithRegistry(reg, doc, &out))
+		require.Equal(t, in, out)
+	})
+	t.Run("reflection", func(t *testing.T) {
+		b, err := Marshal(in)
+		require.NoError(t, err)
+		doc, err := ReadDocument(b)
+		require.NoError(t, err)
+		require.True(t, want.Equal(doc), "expected %v, got %v", want, doc)
+
+		var out owner
+		require.NoError(t, Unmarshal(b, &out))
+		require.Equal(t, in, out)
+	})
+	t.Run("extra fields", func(t *testing.T) {
+		b, err := NewDocument(
+			EC.String("note", "legacy"),
+			EC.String("$ref", "users"),
+			EC.SubDocumentFromElements("$id", EC.Int32("org", 1), EC.Int32("n", 2)),
+			EC.String("$db", "app"),
+			EC.Boolean("archived", true),
+		).MarshalBSON()
+		require.NoError(t, err)
+
+		var ref DBRef
+		require.NoError(t, ref.UnmarshalBSON(b))
+		require.Equal(t, "users", ref.Collection)
+		require.Equal(t, "app", ref.DB)
+		id, ok := ref.ID.(*Document)
+		require.True(t, ok, "expected a *Document ID, got %T", ref.ID)
+		require.True(t, NewDocument(EC.Int32("org", 1), EC.Int32("n", 2)).Equal(id))
+
+		// the ID does not share the bytes it was unmarshaled from
+		for i := range b {
+			b[i] = 0
+		}
+		require.Equal(t, int32(2), id.Lookup("n").Int32())
+	})
+	t.Run("binary id", func(t *testing.T) {
+		ref := DBRef{Collection: "c", ID: Binary{Subtype: 4, Data: make([]byte, 16)}}
+		b, err := ref.MarshalBSON()
+		require.NoError(t, err)
+
+		var out DBRef
+		require.NoError(t, out.UnmarshalBSON(b))
+		require.Equal(t, ref, out)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, doc := range []*Document{
+			NewDocument(EC.String("$ref", "c")),
+			NewDocument(EC.Int32("$id", 1)),
+		} {
+			b, err := doc.MarshalBSON()
+			require.NoError(t, err)
+			var ref DBRef
+			require.Equal(t, ErrIncompleteDBRef, ref.UnmarshalBSON(b))
+		}
+
+		b, err := NewDocument(EC.Int32("$ref", 1), EC.Int32("$id", 1)).MarshalBSON()
+		require.NoError(t, err)
+		var ref DBRef
+		require.EqualError(t, ref.UnmarshalBSON(b), "bson: the $ref field of a DBRef must be a string, not a 32-bit integer")
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"bytes"
@@ -72,6 +73,13 @@ type Unmarshaler interface {
 	UnmarshalBSON([]byte) error
 }
 
+// ValueUnmarshaler describes a type that can unmarshal itself from a BSON value of any type. The
+// bytes of the value do not include its type byte or key. UnmarshalBSONValue must copy the bytes if
+// it wishes to retain them after returning.
+type ValueUnmarshaler interface {
+	UnmarshalBSONValue(Type, []byte) error
+}
+
 // DocumentUnmarshaler describes a type that can unmarshal itself from a bson.Document.
 type DocumentUnmarshaler interface {
 	UnmarshalBSONDocument(*Document) error
@@ -80,12 +88,22 @@ type DocumentUnmarshaler interface {
 // Decoder describes a BSON representation that can decodes itself into a value.
 type Decoder interface {
 	Decode(interface{}) error
+
+	// Reset makes the decoder read from r, keeping its options, so that one decoder can decode
+	// the documents of many readers without being allocated for each of them.
+	Reset(r io.Reader)
 }
 
 // decoder facilitates decoding a value from an io.Reader yielding a BSON document as bytes.
 type decoder struct {
-	pReader    *peekLengthReader
-	bsonReader Reader
+	pReader      *peekLengthReader
+	bsonReader   Reader
+	itr          *ReaderIterator // reused for every document decoded into a map or struct
+	maxDepth     int
+	timeLocation *time.Location
+	// nested is true for the decoders of nested documents and arrays, whose depth was checked
+	// along with the document that contains them.
+	nested bool
 }
 
 type peekLengthReader struct {
@@ -165,14 +183,79 @@ func convertToPtr(val reflect.Value) reflect.Value {
 // If the value would not fit the type and cannot be converted, it is silently skipped.
 //
 // Pointer values are initialized when necessary.
-func NewDecoder(r io.Reader) Decoder {
-	return newDecoder(r)
+func NewDecoder(r io.Reader, opts ...DecoderOption) Decoder {
+	d := newDecoder(r)
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// DecoderOption configures a Decoder.
+type DecoderOption func(*decoder)
+
+// DecoderMaxDepth makes a Decoder fail with an ErrRecursionLimit when decoding a document with more
+// than maxDepth levels of nested documents and arrays, counting the top-level document. A maxDepth
+// of 0 means DefaultMaxDepth.
+func DecoderMaxDepth(maxDepth int) DecoderOption {
+	return func(d *decoder) {
+		d.maxDepth = maxDepth
+	}
+}
+
+// LocalizeTime makes a Decoder decode BSON datetimes into time.Time values in loc. By default they
+// are decoded in UTC.
+func LocalizeTime(loc *time.Location) DecoderOption {
+	return func(d *decoder) {
+		d.timeLocation = loc
+	}
 }
 
 func newDecoder(r io.Reader) *decoder {
 	return &decoder{pReader: newPeekLengthReader(r)}
 }
 
+// iterator returns an iterator over d.bsonReader.
+func (d *decoder) iterator() (*ReaderIterator, error) {
+	if d.itr == nil {
+		itr, err := d.bsonReader.Iterator()
+		if err != nil {
+			return nil, err
+		}
+		d.itr = itr
+		return itr, nil
+	}
+
+	return d.itr, d.itr.reset(d.bsonReader)
+}
+
+// Reset implements the Decoder interface.
+func (d *decoder) Reset(r io.Reader) {
+	d.pReader.Reader = r
+	d.pReader.pos = -1
+	d.bsonReader = nil
+}
+
+// nestedDecoder returns a decoder for a document or array nested in the document of d.
+func (d *decoder) nestedDecoder(r io.Reader) *decoder {
+	nd := newDecoder(r)
+	nd.maxDepth = d.maxDepth
+	nd.timeLocation = d.timeLocation
+	nd.nested = true
+	return nd
+}
+
+// validate checks that r is a valid document that is not nested too deep.
+func (d *decoder) validate(r Reader) error {
+	if !d.nested {
+		if err := checkDepth(r, maxDepthOrDefault(d.maxDepth), nil); err != nil {
+			return err
+		}
+	}
+	_, err := r.Validate()
+	return err
+}
+
 // Decode decodes the BSON document from the underlying io.Reader into the given value.
 func (d *decoder) Decode(v interface{}) error {
 	switch t := v.(type) {
@@ -206,8 +289,7 @@ func (d *decoder) Decode(v interface{}) error {
 			return err
 		}
 
-		_, err = Reader(t).Validate()
-		return err
+		return d.validate(t)
 	case Reader:
 		length, err := d.pReader.peekLength()
 		if err != nil {
@@ -223,8 +305,7 @@ func (d *decoder) Decode(v interface{}) error {
 			return err
 		}
 
-		_, err = t.Validate()
-		return err
+		return d.validate(t)
 
 	default:
 		rval := reflect.ValueOf(v)
@@ -239,8 +320,7 @@ func (d *decoder) decodeToReader() error {
 		return err
 	}
 
-	_, err = d.bsonReader.Validate()
-	return err
+	return d.validate(d.bsonReader)
 
 }
 
@@ -324,6 +404,14 @@ func (d *decoder) getReflectValue(v *Value, containerType reflect.Type, outer re
 		containerType = containerType.Elem()
 	}
 
+	if hasHooks(containerType) && isUnmarshaler(reflect.PtrTo(containerType)) {
+		ptr := reflect.New(containerType)
+		if err := unmarshalValue(ptr, v); err != nil {
+			return val, err
+		}
+		return ptr.Elem(), nil
+	}
+
 	switch v.Type() {
 	case 0x1:
 		f := v.Double()
@@ -414,7 +502,7 @@ func (d *decoder) getReflectValue(v *Value, containerType reflect.Type, outer re
 		}
 	case 0x4:
 		if containerType == tEmpty {
-			d := newDecoder(bytes.NewBuffer(v.ReaderArray()))
+			d := d.nestedDecoder(bytes.NewBuffer(v.ReaderArray()))
 			newVal, err := d.decodeBSONArrayToSlice(tEmptySlice)
 			if err != nil {
 				return val, err
@@ -431,7 +519,7 @@ func (d *decoder) getReflectValue(v *Value, containerType reflect.Type, outer re
 		}
 
 		if containerType.Kind() == reflect.Slice {
-			d := newDecoder(bytes.NewBuffer(v.ReaderArray()))
+			d := d.nestedDecoder(bytes.NewBuffer(v.ReaderArray()))
 			newVal, err := d.decodeBSONArrayToSlice(containerType)
 			if err != nil {
 				return val, err
@@ -448,7 +536,7 @@ func (d *decoder) getReflectValue(v *Value, containerType reflect.Type, outer re
 		}
 
 		if containerType.Kind() == reflect.Array {
-			d := newDecoder(bytes.NewBuffer(v.ReaderArray()))
+			d := d.nestedDecoder(bytes.NewBuffer(v.ReaderArray()))
 			newVal, err := d.decodeBSONArrayIntoArray(containerType)
 			if err != nil {
 				return val, err
@@ -479,7 +567,7 @@ func (d *decoder) getReflectValue(v *Value, containerType reflect.Type, outer re
 			return val, err
 		}
 
-		d := NewDecoder(bytes.NewBuffer(r))
+		d := d.nestedDecoder(bytes.NewBuffer(r))
 		err = d.Decode(empty.Interface())
 		if err != nil {
 			return val, err
@@ -528,9 +616,12 @@ func (d *decoder) getReflectValue(v *Value, containerType reflect.Type, outer re
 			return val, nil
 		}
 
-		if int64(v.getUint64()) == -zeroEpochMs {
+		switch {
+		case int64(v.getUint64()) == -zeroEpochMs:
 			val = reflect.ValueOf(time.Time{})
-		} else {
+		case containerType == tTime:
+			val = reflect.ValueOf(d.time(v.DateTime()))
+		default:
 			val = reflect.ValueOf(v.DateTime())
 		}
 
@@ -713,18 +804,28 @@ func (d *decoder) getReflectValue(v *Value, containerType reflect.Type, outer re
 	return val, nil
 }
 
+// time returns the time of the BSON datetime dt, in UTC unless the decoder localizes times.
+func (d *decoder) time(dt int64) time.Time {
+	t := time.Unix(dt/1000, dt%1000*int64(time.Millisecond)).UTC()
+	if d.timeLocation != nil {
+		t = t.In(d.timeLocation)
+	}
+	return t
+}
+
 func (d *decoder) decodeIntoMap(mapVal reflect.Value) error {
 	err := d.decodeToReader()
 	if err != nil {
 		return err
 	}
 
-	itr, err := d.bsonReader.Iterator()
+	itr, err := d.iterator()
 	if err != nil {
 		return err
 	}
 
 	valType := mapVal.Type().Elem()
+	keys := newMapKeySet(mapVal.Type().Key())
 
 	for itr.Next() {
 		elem := itr.Element()
@@ -734,7 +835,10 @@ func (d *decoder) decodeIntoMap(mapVal reflect.Value) error {
 			return err
 		}
 
-		k := reflect.ValueOf(elem.Key())
+		k, err := keys.add(elem.Key())
+		if err != nil {
+			return err
+		}
 		mapVal.SetMapIndex(k, v)
 	}
 
@@ -890,13 +994,56 @@ func matchesField(key string, field string, sType reflect.Type) bool {
 	return fieldKey == key
 }
 
+// maxCachedKeys is the number of keys whose field is cached per struct type, which bounds the
+// cache when documents have arbitrary keys.
+const maxCachedKeys = 1024
+
+// fieldIndexes caches the index of the field each key is decoded into, per struct type, because
+// finding it with FieldByNameFunc and matchesField is slow and allocates.
+var fieldIndexes = struct {
+	sync.RWMutex
+	m map[reflect.Type]map[string][]int
+}{m: make(map[reflect.Type]map[string][]int)}
+
+// fieldIndex returns the index of the field of the struct type sType that key is decoded into, or
+// nil if there is none.
+func fieldIndex(sType reflect.Type, key []byte) []int {
+	fieldIndexes.RLock()
+	index, ok := fieldIndexes.m[sType][string(key)]
+	fieldIndexes.RUnlock()
+	if ok {
+		return index
+	}
+
+	k := string(key)
+	f, found := sType.FieldByNameFunc(func(field string) bool {
+		return matchesField(k, field, sType)
+	})
+	if found {
+		index = f.Index
+	}
+
+	fieldIndexes.Lock()
+	keys := fieldIndexes.m[sType]
+	if keys == nil {
+		keys = make(map[string][]int)
+		fieldIndexes.m[sType] = keys
+	}
+	if len(keys) < maxCachedKeys {
+		keys[k] = index
+	}
+	fieldIndexes.Unlock()
+
+	return index
+}
+
 func (d *decoder) decodeIntoStruct(structVal reflect.Value) error {
 	err := d.decodeToReader()
 	if err != nil {
 		return err
 	}
 
-	itr, err := d.bsonReader.Iterator()
+	itr, err := d.iterator()
 	if err != nil {
 		return err
 	}
@@ -906,10 +1053,15 @@ func (d *decoder) decodeIntoStruct(structVal reflect.Value) error {
 	for itr.Next() {
 		elem := itr.Element()
 
-		field := structVal.FieldByNameFunc(func(field string) bool {
-			return matchesField(elem.Key(), field, sType)
-		})
-		if field == zeroVal {
+		index := fieldIndex(sType, elem.keyBytes())
+		if index == nil {
+			continue
+		}
+		field := structVal.FieldByIndex(index)
+
+		// null leaves pointers nil, so that a nil *time.Time, for instance, round trips
+		if elem.value.Type() == TypeNull && field.Kind() == reflect.Ptr {
+			field.Set(reflect.Zero(field.Type()))
 			continue
 		}
 
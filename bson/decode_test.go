@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/mongodb/mongo-go-driver/bson/decimal"
@@ -3899,3 +3900,99 @@ func elementSliceEqual(t *testing.T, e1 []*Element, e2 []*Element) {
 		require.True(t, readerElementComparer(e1[i], e2[i]))
 	}
 }
+
+func TestDecodeTime(t *testing.T) {
+	when := time.Date(2018, 7, 1, 12, 30, 0, 123000000, time.UTC)
+	type times struct {
+		T time.Time
+		P *time.Time
+		N *time.Time
+	}
+	b, err := Marshal(times{T: when, P: &when})
+	require.NoError(t, err)
+
+	t.Run("UTC", func(t *testing.T) {
+		var got times
+		require.NoError(t, Unmarshal(b, &got))
+		require.Equal(t, when, got.T)
+		require.Equal(t, time.UTC, got.T.Location())
+		require.NotNil(t, got.P)
+		require.Equal(t, when, *got.P)
+		require.Nil(t, got.N)
+	})
+	t.Run("localized", func(t *testing.T) {
+		loc := time.FixedZone("UTC+2", 2*60*60)
+		var got times
+		require.NoError(t, NewDecoder(bytes.NewReader(b), LocalizeTime(loc)).Decode(&got))
+		require.True(t, when.Equal(got.T))
+		require.Equal(t, loc, got.T.Location())
+		require.Equal(t, loc, got.P.Location())
+	})
+	t.Run("null clears pointers", func(t *testing.T) {
+		got := times{N: &when}
+		require.NoError(t, Unmarshal(b, &got))
+		require.Nil(t, got.N)
+	})
+}
+
+func TestDecoderReset(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	type doc struct {
+		N int32
+		T time.Time
+	}
+	first, err := Marshal(doc{N: 1, T: time.Unix(10, 0)})
+	require.NoError(t, err)
+	second, err := Marshal(doc{N: 2, T: time.Unix(20, 0)})
+	require.NoError(t, err)
+
+	dec := NewDecoder(bytes.NewReader(first), LocalizeTime(loc))
+	var got doc
+	require.NoError(t, dec.Decode(&got))
+	require.Equal(t, int32(1), got.N)
+
+	// the decoder reads the new reader from its start and keeps its options
+	dec.Reset(bytes.NewReader(second))
+	got = doc{}
+	require.NoError(t, dec.Decode(&got))
+	require.Equal(t, int32(2), got.N)
+	require.Equal(t, loc, got.T.Location())
+}
+
+func TestDecodeStructFields(t *testing.T) {
+	type Inner struct {
+		Shared   string
+		Promoted string
+	}
+	type Other struct {
+		Shared string
+	}
+	type outer struct {
+		Inner
+		Other
+		Name  string
+		Count int32 `bson:"n"`
+		Skip  int32 `json:"skip"`
+	}
+	b, err := NewDocument(
+		EC.String("shared", "ambiguous"),
+		EC.String("promoted", "promoted"),
+		EC.String("NAME", "case insensitive"),
+		EC.Int32("n", 3),
+		EC.Int32("count", 4),
+		EC.Int32("skip", 5),
+	).MarshalBSON()
+	require.NoError(t, err)
+
+	// the fields are looked up twice, the second time in the cache
+	for i := 0; i < 2; i++ {
+		var got outer
+		require.NoError(t, Unmarshal(b, &got))
+		require.Equal(t, outer{
+			Inner: Inner{Promoted: "promoted"},
+			Name:  "case insensitive",
+			Count: 3,
+			Skip:  5,
+		}, got)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DefaultMaxDepth is the maximum number of levels of nested documents and arrays, counting the
+// top-level document, that Encoders and Decoders accept unless they are given another limit. It
+// matches the limit of the server.
+const DefaultMaxDepth = 256
+
+// ErrRecursionLimit is returned when encoding or decoding a value whose documents and arrays are
+// nested deeper than the maximum depth.
+type ErrRecursionLimit struct {
+	// Path is the dotted path of keys at which the maximum depth was exceeded.
+	Path     string
+	MaxDepth int
+}
+
+func (e ErrRecursionLimit) Error() string {
+	return fmt.Sprintf("bson: maximum depth of %d exceeded at %s", e.MaxDepth, e.Path)
+}
+
+// ErrCycleDetected is returned when encoding a value that refers back to itself, such as a struct
+// with a pointer to itself.
+type ErrCycleDetected struct {
+	// Path is the dotted path of keys at which the value was reached again.
+	Path string
+	Type reflect.Type
+}
+
+func (e ErrCycleDetected) Error() string {
+	return fmt.Sprintf("bson: cycle detected at %s: value of type %s refers to itself", e.Path, e.Type)
+}
+
+func maxDepthOrDefault(maxDepth int) int {
+	if maxDepth <= 0 {
+		return DefaultMaxDepth
+	}
+	return maxDepth
+}
+
+// visit identifies a value that is being encoded, so that reaching it again below itself can be
+// reported as a cycle.
+type visit struct {
+	typ reflect.Type
+	ptr uintptr
+	len int
+}
+
+// visitOf returns the visit of val, and false if val cannot be part of a cycle because it is
+// neither a map, a slice nor a struct reached through a pointer.
+func visitOf(val reflect.Value) (visit, bool) {
+	switch val.Kind() {
+	case reflect.Map:
+		return visit{typ: val.Type(), ptr: val.Pointer()}, true
+	case reflect.Slice:
+		return visit{typ: val.Type(), ptr: val.Pointer(), len: val.Len()}, true
+	case reflect.Struct:
+		if val.CanAddr() {
+			return visit{typ: val.Type(), ptr: val.UnsafeAddr()}, true
+		}
+	}
+	return visit{}, false
+}
+
+// enter records that the encoder descends into the document or array val under key, failing if
+// that exceeds the maximum depth or val is already being encoded further up.
+func (e *encoder) enter(key string, val reflect.Value) error {
+	e.path = append(e.path, key)
+	if maxDepth := maxDepthOrDefault(e.maxDepth); len(e.path) >= maxDepth {
+		return ErrRecursionLimit{Path: strings.Join(e.path, "."), MaxDepth: maxDepth}
+	}
+
+	v, ok := visitOf(val)
+	if !ok {
+		return nil
+	}
+	if _, cycle := e.visiting[v]; cycle {
+		return ErrCycleDetected{Path: strings.Join(e.path, "."), Type: val.Type()}
+	}
+	if e.visiting == nil {
+		e.visiting = make(map[visit]struct{})
+	}
+	e.visiting[v] = struct{}{}
+	return nil
+}
+
+// leave undoes the enter of val.
+func (e *encoder) leave(val reflect.Value) {
+	e.path = e.path[:len(e.path)-1]
+	if v, ok := visitOf(val); ok {
+		delete(e.visiting, v)
+	}
+}
+
+// enter returns the context with which a codec encodes the values of the document or array val,
+// failing if val is nested deeper than DefaultMaxDepth or is already being encoded further up. The
+// path of the error is filled in by the codecs above. The codec must call leave once it is done.
+func (ec EncodeContext) enter(val reflect.Value) (EncodeContext, error) {
+	ec.depth++
+	if ec.depth > DefaultMaxDepth {
+		return ec, ErrRecursionLimit{MaxDepth: DefaultMaxDepth}
+	}
+
+	v, ok := visitOf(val)
+	if !ok {
+		return ec, nil
+	}
+	if _, cycle := ec.visiting[v]; cycle {
+		return ec, ErrCycleDetected{Type: val.Type()}
+	}
+	if ec.visiting == nil {
+		ec.visiting = make(map[visit]struct{})
+	}
+	ec.visiting[v] = struct{}{}
+	return ec, nil
+}
+
+// leave undoes the enter of val.
+func (ec EncodeContext) leave(val reflect.Value) {
+	if v, ok := visitOf(val); ok {
+		delete(ec.visiting, v)
+	}
+}
+
+// enter returns the context with which a codec decodes the values of a document or array, failing
+// if it is nested deeper than DefaultMaxDepth.
+func (dc DecodeContext) enter() (DecodeContext, error) {
+	dc.depth++
+	if dc.depth > DefaultMaxDepth {
+		return dc, ErrRecursionLimit{MaxDepth: DefaultMaxDepth}
+	}
+	return dc, nil
+}
+
+// checkDepth returns an ErrRecursionLimit if the documents and arrays of r, which is found at path,
+// are nested deeper than maxDepth. Malformed documents are left to Validate to report.
+func checkDepth(r Reader, maxDepth int, path []string) error {
+	_, err := r.forEach(true, func(key []byte, val Value) error {
+		var nested Reader
+		switch val.Type() {
+		case TypeEmbeddedDocument:
+			nested = val.ReaderDocument()
+		case TypeArray:
+			nested = val.ReaderArray()
+		default:
+			return nil
+		}
+
+		p := append(path, string(key))
+		if len(p) >= maxDepth {
+			return ErrRecursionLimit{Path: strings.Join(p, "."), MaxDepth: maxDepth}
+		}
+		return checkDepth(nested, maxDepth, p)
+	})
+	if _, ok := err.(ErrRecursionLimit); ok {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type depthNode struct {
+	Name string
+	Next *depthNode
+}
+
+// nestedDocument returns a document with depth levels of documents under the key "a".
+func nestedDocument(depth int) *Document {
+	doc := NewDocument(EC.Int32("x", 1))
+	for i := 1; i < depth; i++ {
+		doc = NewDocument(EC.SubDocument("a", doc))
+	}
+	return doc
+}
+
+func TestEncoderDepth(t *testing.T) {
+	t.Run("cycle through a pointer", func(t *testing.T) {
+		n := &depthNode{Name: "a"}
+		n.Next = &depthNode{Name: "b", Next: n}
+
+		_, err := Marshal(n)
+		cycle, ok := err.(ErrCycleDetected)
+		require.True(t, ok, "expected an ErrCycleDetected, got %v", err)
+		require.Equal(t, "next.next", cycle.Path)
+		require.Equal(t, reflect.TypeOf(depthNode{}), cycle.Type)
+	})
+	t.Run("cycle through a map", func(t *testing.T) {
+		m := map[string]interface{}{"x": int32(1)}
+		m["self"] = []interface{}{m}
+
+		_, err := NewDocumentEncoder().EncodeDocument(m)
+		cycle, ok := err.(ErrCycleDetected)
+		require.True(t, ok, "expected an ErrCycleDetected, got %v", err)
+		require.Equal(t, "self.0", cycle.Path)
+	})
+	t.Run("shared values are not cycles", func(t *testing.T) {
+		shared := &depthNode{Name: "shared"}
+		v := struct {
+			A *depthNode
+			B *depthNode
+		}{A: shared, B: shared}
+
+		doc, err := NewDocumentEncoder().EncodeDocument(v)
+		require.NoError(t, err)
+		require.Equal(t, "shared", doc.Lookup("a", "name").StringValue())
+		require.Equal(t, "shared", doc.Lookup("b", "name").StringValue())
+	})
+	t.Run("maximum depth", func(t *testing.T) {
+		list := &depthNode{Name: "0"}
+		for i := 0; i < 4; i++ {
+			list = &depthNode{Next: list}
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, NewEncoder(&buf, EncoderMaxDepth(5)).Encode(list))
+		err := NewEncoder(&buf, EncoderMaxDepth(4)).Encode(list)
+		require.Equal(t, ErrRecursionLimit{Path: "next.next.next.next", MaxDepth: 4}, err)
+	})
+	t.Run("default maximum depth", func(t *testing.T) {
+		list := &depthNode{}
+		for i := 0; i < DefaultMaxDepth; i++ {
+			list = &depthNode{Next: list}
+		}
+
+		_, err := Marshal(list)
+		limit, ok := err.(ErrRecursionLimit)
+		require.True(t, ok, "expected an ErrRecursionLimit, got %v", err)
+		require.Equal(t, DefaultMaxDepth, limit.MaxDepth)
+		require.Equal(t, DefaultMaxDepth, len(strings.Split(limit.Path, ".")))
+	})
+}
+
+func TestDecoderDepth(t *testing.T) {
+	t.Run("within the limit", func(t *testing.T) {
+		b, err := nestedDocument(DefaultMaxDepth).MarshalBSON()
+		require.NoError(t, err)
+
+		require.NoError(t, Unmarshal(b, map[string]interface{}{}))
+	})
+	t.Run("default maximum depth", func(t *testing.T) {
+		b, err := nestedDocument(DefaultMaxDepth + 1).MarshalBSON()
+		require.NoError(t, err)
+
+		err = Unmarshal(b, map[string]interface{}{})
+		limit, ok := err.(ErrRecursionLimit)
+		require.True(t, ok, "expected an ErrRecursionLimit, got %v", err)
+		require.Equal(t, DefaultMaxDepth, limit.MaxDepth)
+		require.Equal(t, DefaultMaxDepth, len(strings.Split(limit.Path, ".")))
+	})
+	t.Run("configured maximum depth", func(t *testing.T) {
+		b, err := NewDocument(
+			EC.Int32("x", 1),
+			EC.ArrayFromElements("a", VC.DocumentFromElements(EC.SubDocumentFromElements("b", EC.Int32("c", 1)))),
+		).MarshalBSON()
+		require.NoError(t, err)
+
+		require.NoError(t, NewDecoder(bytes.NewReader(b), DecoderMaxDepth(4)).Decode(make(Reader, len(b))))
+		err = NewDecoder(bytes.NewReader(b), DecoderMaxDepth(3)).Decode(map[string]interface{}{})
+		require.Equal(t, ErrRecursionLimit{Path: "a.0.b", MaxDepth: 3}, err)
+		err = NewDecoder(bytes.NewReader(b), DecoderMaxDepth(3)).Decode(make(Reader, len(b)))
+		require.Equal(t, ErrRecursionLimit{Path: "a.0.b", MaxDepth: 3}, err)
+	})
+}
+
+// depthMap is decoded with a MapCodec at every level.
+type depthMap map[string]depthMap
+
+// nestedMaps returns a document with depth levels of empty documents under the key "a".
+func nestedMaps(depth int) *Document {
+	doc := NewDocument()
+	for i := 1; i < depth; i++ {
+		doc = NewDocument(EC.SubDocument("a", doc))
+	}
+	return doc
+}
+
+func TestRegistryCodecDepth(t *testing.T) {
+	t.Run("cycle through a pointer", func(t *testing.T) {
+		n := &depthNode{Name: "a"}
+		n.Next = &depthNode{Name: "b", Next: n}
+
+		_, err := MarshalDocumentWithRegistry(defaultRegistry, n)
+		cycle, ok := err.(ErrCycleDetected)
+		require.True(t, ok, "expected an ErrCycleDetected, got %v", err)
+		require.Equal(t, "next.next", cycle.Path)
+		require.Equal(t, reflect.TypeOf(depthNode{}), cycle.Type)
+	})
+	t.Run("cycle through a map", func(t *testing.T) {
+		m := map[string]interface{}{"x": int32(1)}
+		m["self"] = []interface{}{m}
+
+		_, err := MarshalWithRegistry(defaultRegistry, m)
+		cycle, ok := err.(ErrCycleDetected)
+		require.True(t, ok, "expected an ErrCycleDetected, got %v", err)
+		require.Equal(t, "self.0", cycle.Path)
+	})
+	t.Run("cycle through a slice", func(t *testing.T) {
+		s := []interface{}{int32(1), nil}
+		s[1] = s
+
+		_, err := MarshalWithRegistry(defaultRegistry, map[string]interface{}{"s": s})
+		cycle, ok := err.(ErrCycleDetected)
+		require.True(t, ok, "expected an ErrCycleDetected, got %v", err)
+		require.Equal(t, "s.1", cycle.Path)
+	})
+	t.Run("shared values are not cycles", func(t *testing.T) {
+		shared := map[string]interface{}{"name": "shared"}
+		v := struct {
+			A map[string]interface{}
+			B map[string]interface{}
+		}{A: shared, B: shared}
+
+		doc, err := MarshalDocumentWithRegistry(defaultRegistry, v)
+		require.NoError(t, err)
+		require.Equal(t, "shared", doc.Lookup("a", "name").StringValue())
+		require.Equal(t, "shared", doc.Lookup("b", "name").StringValue())
+	})
+	t.Run("encode maximum depth", func(t *testing.T) {
+		m := depthMap{}
+		for i := 1; i < DefaultMaxDepth; i++ {
+			m = depthMap{"a": m}
+		}
+		_, err := MarshalWithRegistry(defaultRegistry, m)
+		require.NoError(t, err)
+
+		_, err = MarshalWithRegistry(defaultRegistry, depthMap{"a": m})
+		limit, ok := err.(ErrRecursionLimit)
+		require.True(t, ok, "expected an ErrRecursionLimit, got %v", err)
+		require.Equal(t, DefaultMaxDepth, limit.MaxDepth)
+		require.Equal(t, DefaultMaxDepth, len(strings.Split(limit.Path, ".")))
+	})
+	t.Run("decode maximum depth", func(t *testing.T) {
+		var m depthMap
+		require.NoError(t, UnmarshalDocumentWithRegistry(defaultRegistry, nestedMaps(DefaultMaxDepth), &m))
+
+		err := UnmarshalDocumentWithRegistry(defaultRegistry, nestedMaps(DefaultMaxDepth+1), &m)
+		limit, ok := err.(ErrRecursionLimit)
+		require.True(t, ok, "expected an ErrRecursionLimit, got %v", err)
+		require.Equal(t, DefaultMaxDepth, limit.MaxDepth)
+		require.Equal(t, DefaultMaxDepth, len(strings.Split(limit.Path, ".")))
+	})
+}
@@ -0,0 +1,175 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// DifferenceKind is the kind of a Difference between two documents.
+type DifferenceKind uint8
+
+// These constants are the kinds of differences Diff reports.
+const (
+	// DifferenceMissing means the value is in the first document but not in the second.
+	DifferenceMissing DifferenceKind = iota + 1
+	// DifferenceExtra means the value is in the second document but not in the first.
+	DifferenceExtra
+	// DifferenceTypeMismatch means the values are of different BSON types.
+	DifferenceTypeMismatch
+	// DifferenceValueMismatch means the values are of the same BSON type but are not equal.
+	DifferenceValueMismatch
+)
+
+func (k DifferenceKind) String() string {
+	switch k {
+	case DifferenceMissing:
+		return "missing"
+	case DifferenceExtra:
+		return "extra"
+	case DifferenceTypeMismatch:
+		return "type mismatch"
+	case DifferenceValueMismatch:
+		return "value mismatch"
+	}
+	return fmt.Sprintf("DifferenceKind(%d)", uint8(k))
+}
+
+// Difference is a difference between two documents reported by Diff.
+type Difference struct {
+	// Path is the dotted path of keys, and of indexes into arrays, at which the documents differ.
+	Path string
+	Kind DifferenceKind
+	// A is the value in the first document. It is nil when Kind is DifferenceExtra.
+	A *Value
+	// B is the value in the second document. It is nil when Kind is DifferenceMissing.
+	B *Value
+}
+
+func (d Difference) String() string {
+	switch d.Kind {
+	case DifferenceMissing:
+		return fmt.Sprintf("%s: missing %s", d.Path, formatValue(d.A))
+	case DifferenceExtra:
+		return fmt.Sprintf("%s: extra %s", d.Path, formatValue(d.B))
+	case DifferenceTypeMismatch:
+		return fmt.Sprintf("%s: type mismatch: %s %s != %s %s",
+			d.Path, d.A.Type(), formatValue(d.A), d.B.Type(), formatValue(d.B))
+	}
+	return fmt.Sprintf("%s: %s: %s != %s", d.Path, d.Kind, formatValue(d.A), formatValue(d.B))
+}
+
+// Diff returns the differences between the documents a and b, or nil if they are equal. A nil
+// document is treated as empty.
+//
+// Fields are matched by key regardless of their order, and the nth field with a key that is
+// repeated is matched to the nth field with that key in the other document. Array elements are
+// matched by index. Embedded documents and arrays found under the same path in both documents are
+// compared element by element, and values of other types are compared by their bytes, so numbers
+// of different types are never equal and the doubles 0.0 and -0.0 differ.
+func Diff(a, b *Document) []Difference {
+	var aElems, bElems []*Element
+	if a != nil {
+		aElems = a.elems
+	}
+	if b != nil {
+		bElems = b.elems
+	}
+	return diffDocuments(nil, "", aElems, bElems)
+}
+
+// diffDocuments appends the differences between the fields of two documents at path to diffs.
+func diffDocuments(diffs []Difference, path string, a, b []*Element) []Difference {
+	byKey := make(map[string][]*Element, len(b))
+	for _, elem := range b {
+		byKey[elem.Key()] = append(byKey[elem.Key()], elem)
+	}
+
+	matched := make(map[string]int, len(a))
+	for _, elem := range a {
+		key := elem.Key()
+		n := matched[key]
+		matched[key]++
+		if n >= len(byKey[key]) {
+			diffs = append(diffs, Difference{Path: joinPath(path, key), Kind: DifferenceMissing, A: elem.Value()})
+			continue
+		}
+		diffs = diffValues(diffs, joinPath(path, key), elem.Value(), byKey[key][n].Value())
+	}
+
+	for _, elem := range b {
+		key := elem.Key()
+		if matched[key] > 0 {
+			matched[key]--
+			continue
+		}
+		diffs = append(diffs, Difference{Path: joinPath(path, key), Kind: DifferenceExtra, B: elem.Value()})
+	}
+	return diffs
+}
+
+// diffArrays appends the differences between the elements of two arrays at path to diffs.
+func diffArrays(diffs []Difference, path string, a, b []*Element) []Difference {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		p := joinPath(path, strconv.Itoa(i))
+		switch {
+		case i >= len(b):
+			diffs = append(diffs, Difference{Path: p, Kind: DifferenceMissing, A: a[i].Value()})
+		case i >= len(a):
+			diffs = append(diffs, Difference{Path: p, Kind: DifferenceExtra, B: b[i].Value()})
+		default:
+			diffs = diffValues(diffs, p, a[i].Value(), b[i].Value())
+		}
+	}
+	return diffs
+}
+
+// diffValues appends the differences between two values at path to diffs.
+func diffValues(diffs []Difference, path string, a, b *Value) []Difference {
+	if a.Type() != b.Type() {
+		return append(diffs, Difference{Path: path, Kind: DifferenceTypeMismatch, A: a, B: b})
+	}
+
+	switch a.Type() {
+	case TypeEmbeddedDocument:
+		return diffDocuments(diffs, path, a.MutableDocument().elems, b.MutableDocument().elems)
+	case TypeArray:
+		return diffArrays(diffs, path, a.MutableArray().doc.elems, b.MutableArray().doc.elems)
+	case TypeCodeWithScope:
+		aCode, aScope := a.ReaderJavaScriptWithScope()
+		bCode, bScope := b.ReaderJavaScriptWithScope()
+		if aCode != bCode || !bytes.Equal(aScope, bScope) {
+			diffs = append(diffs, Difference{Path: path, Kind: DifferenceValueMismatch, A: a, B: b})
+		}
+		return diffs
+	}
+
+	if !bytes.Equal(a.valueBytes(), b.valueBytes()) {
+		diffs = append(diffs, Difference{Path: path, Kind: DifferenceValueMismatch, A: a, B: b})
+	}
+	return diffs
+}
+
+// valueBytes returns the bytes of v without its type and key, or nil if v is invalid. It must not
+// be called on embedded documents, arrays or code with scope, whose bytes may be held by a
+// Document instead.
+func (v *Value) valueBytes() []byte {
+	size, err := v.valueSize()
+	if err != nil {
+		return nil
+	}
+	return v.data[v.offset : v.offset+size]
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,84 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	// summary returns the paths and kinds of diffs.
+	summary := func(diffs []Difference) []string {
+		var s []string
+		for _, diff := range diffs {
+			s = append(s, diff.Path+" "+diff.Kind.String())
+		}
+		return s
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		a := NewDocument(EC.Int32("x", 1), EC.SubDocumentFromElements("y", EC.String("z", "a")))
+		b := NewDocument(EC.SubDocumentFromElements("y", EC.String("z", "a")), EC.Int32("x", 1))
+		require.Nil(t, Diff(a, b))
+		require.Nil(t, Diff(nil, NewDocument()))
+	})
+	t.Run("kinds", func(t *testing.T) {
+		a := NewDocument(
+			EC.Int32("same", 1),
+			EC.Int32("missing", 1),
+			EC.Int32("type", 1),
+			EC.String("value", "a"),
+		)
+		b := NewDocument(
+			EC.Int32("same", 1),
+			EC.Int64("type", 1),
+			EC.String("value", "b"),
+			EC.Boolean("extra", true),
+		)
+
+		diffs := Diff(a, b)
+		require.Equal(t, []string{
+			"missing missing",
+			"type type mismatch",
+			"value value mismatch",
+			"extra extra",
+		}, summary(diffs))
+		require.Equal(t, "a", diffs[2].A.StringValue())
+		require.Equal(t, "b", diffs[2].B.StringValue())
+		require.Nil(t, diffs[0].B)
+		require.Nil(t, diffs[3].A)
+		require.Equal(t, `type: type mismatch: 32-bit integer 1 != 64-bit integer {"$numberLong": "1"}`, diffs[1].String())
+		require.Equal(t, `value: value mismatch: "a" != "b"`, diffs[2].String())
+	})
+	t.Run("nested", func(t *testing.T) {
+		a := NewDocument(EC.SubDocumentFromElements("doc",
+			EC.ArrayFromElements("arr", VC.Int32(1), VC.DocumentFromElements(EC.Int32("x", 1)), VC.Int32(3)),
+		))
+		b := NewDocument(EC.SubDocumentFromElements("doc",
+			EC.ArrayFromElements("arr", VC.Int32(1), VC.DocumentFromElements(EC.Int32("x", 2))),
+		))
+
+		require.Equal(t, []string{"doc.arr.1.x value mismatch", "doc.arr.2 missing"}, summary(Diff(a, b)))
+	})
+	t.Run("readers", func(t *testing.T) {
+		rdr, err := NewDocument(EC.SubDocumentFromElements("doc", EC.Int32("x", 1))).MarshalBSON()
+		require.NoError(t, err)
+		a, err := ReadDocument(rdr)
+		require.NoError(t, err)
+		b := NewDocument(EC.SubDocumentFromElements("doc", EC.Int32("x", 1), EC.Null("y")))
+
+		require.Equal(t, []string{"doc.y extra"}, summary(Diff(a, b)))
+	})
+	t.Run("repeated keys", func(t *testing.T) {
+		a := NewDocument(EC.Int32("x", 1), EC.Int32("x", 2))
+		b := NewDocument(EC.Int32("x", 1), EC.Int32("x", 3), EC.Int32("x", 4))
+
+		require.Equal(t, []string{"x value mismatch", "x extra"}, summary(Diff(a, b)))
+	})
+}
@@ -32,7 +32,9 @@
 // or to unmarshal into a type from an io.Reader. These types will use
 // reflection and evaluate struct tags unless the provided types implements the
 // Marshaler or Unmarshaler interfaces. The Builder and Reader types can be used
-// to implement these interfaces for types.
+// to implement these interfaces for types. Types that implement Marshaler,
+// Unmarshaler, ValueMarshaler or ValueUnmarshaler also control their own
+// representation when they are struct fields, map values or slice elements.
 //
 // The DocumentEncoder type can be used to encode a type to a Document instead
 // of an io.Writer. This is useful if some additional manipulation is required
@@ -79,6 +79,15 @@ func NewDocument(elems ...*Element) *Document {
 	return doc
 }
 
+// NewDocumentSize creates an empty Document with room for n elements, so that appending up to n
+// elements does not grow its storage.
+func NewDocumentSize(n int) *Document {
+	return &Document{
+		elems: make([]*Element, 0, n),
+		index: make([]uint32, 0, n),
+	}
+}
+
 // ReadDocument will create a Document using the provided slice of bytes. If the
 // slice of bytes is not a valid BSON document, this method will return an error.
 func ReadDocument(b []byte) (*Document, error) {
@@ -199,6 +208,77 @@ func (d *Document) Append(elems ...*Element) *Document {
 	return d
 }
 
+// AppendAll is the same as Append, except it reserves room for all of elems at once and indexes
+// them together, which is much faster when appending many elements. If a nil element is passed
+// and IgnoreNilInsert is false, this method panics without adding any of the elements.
+func (d *Document) AppendAll(elems ...*Element) *Document {
+	if d == nil {
+		panic(ErrNilDocument)
+	}
+
+	n := 0
+	for _, elem := range elems {
+		if elem == nil {
+			if d.IgnoreNilInsert {
+				continue
+			}
+			panic(ErrNilElement)
+		}
+		n++
+	}
+	d.reserve(n)
+
+	start := len(d.elems)
+	for _, elem := range elems {
+		if elem != nil {
+			d.elems = append(d.elems, elem)
+		}
+	}
+
+	key := func(pos uint32) []byte {
+		elem := d.elems[pos]
+		return elem.value.data[elem.value.start+1 : elem.value.offset]
+	}
+
+	// Append places an element before the elements with the same key that precede it in the
+	// index, so the new positions are sorted by key and then by decreasing position.
+	added := make([]uint32, 0, n)
+	for pos := len(d.elems) - 1; pos >= start; pos-- {
+		added = append(added, uint32(pos))
+	}
+	sort.SliceStable(added, func(i, j int) bool { return bytes.Compare(key(added[i]), key(added[j])) < 0 })
+
+	// merge the new positions into the index from the back, keeping the new positions before
+	// the old ones with the same key
+	i, j := len(d.index)-1, len(added)-1
+	d.index = append(d.index, added...)
+	for k := len(d.index) - 1; j >= 0; k-- {
+		if i >= 0 && bytes.Compare(key(d.index[i]), key(added[j])) >= 0 {
+			d.index[k] = d.index[i]
+			i--
+		} else {
+			d.index[k] = added[j]
+			j--
+		}
+	}
+	return d
+}
+
+// reserve grows the storage of the document, if needed, so that n more elements can be added
+// without growing it again.
+func (d *Document) reserve(n int) {
+	if cap(d.elems)-len(d.elems) < n {
+		elems := make([]*Element, len(d.elems), len(d.elems)+n)
+		copy(elems, d.elems)
+		d.elems = elems
+	}
+	if cap(d.index)-len(d.index) < n {
+		index := make([]uint32, len(d.index), len(d.index)+n)
+		copy(index, d.index)
+		d.index = index
+	}
+}
+
 // Prepend adds each element to the beginning of the document, in order. If a nil element is passed
 // as a parameter this method will panic. To change this behavior to silently
 // ignore a nil element, set IgnoreNilInsert to true on the Document.
@@ -452,6 +532,29 @@ func (d *Document) ElementAtOK(index uint) (*Element, bool) {
 	return d.ElementAt(index), true
 }
 
+// ForEach calls f with the key and the value of each element of the document, in order, and stops
+// at the first error returned by f, which ForEach then returns. The key is a view over the bytes of
+// the element and must be copied to be retained. Unlike Iterator, ForEach does not allocate.
+func (d *Document) ForEach(f func(key []byte, val Value) error) error {
+	if d == nil {
+		return ErrNilDocument
+	}
+
+	for _, elem := range d.elems {
+		_, err := elem.Validate()
+		if err != nil {
+			return err
+		}
+
+		err = f(elem.value.data[elem.value.start+1:elem.value.offset-1], *elem.value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Iterator creates an Iterator for this document and returns it.
 func (d *Document) Iterator() *Iterator {
 	if d == nil {
@@ -9,6 +9,7 @@ package bson
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
@@ -532,6 +533,32 @@ func TestDocument(t *testing.T) {
 		require.False(t, iter.Next())
 		require.NoError(t, iter.Err())
 	})
+	t.Run("ForEach", func(t *testing.T) {
+		d := NewDocument(EC.String("foo", "bar"), EC.Int32("baz", 1), EC.Null("bing"))
+
+		var keys []string
+		err := d.ForEach(func(key []byte, val Value) error {
+			keys = append(keys, string(key))
+			if string(key) == "baz" {
+				require.Equal(t, int32(1), val.Int32())
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo", "baz", "bing"}, keys)
+
+		errStop := errors.New("stop")
+		keys = keys[:0]
+		err = d.ForEach(func(key []byte, val Value) error {
+			keys = append(keys, string(key))
+			return errStop
+		})
+		require.Equal(t, errStop, err)
+		require.Equal(t, []string{"foo"}, keys)
+
+		var nilDoc *Document
+		require.Equal(t, ErrNilDocument, nilDoc.ForEach(func([]byte, Value) error { return nil }))
+	})
 	t.Run("Concat", func(t *testing.T) {
 		testCases := []struct {
 			name     string
@@ -1157,6 +1184,38 @@ func BenchmarkDocument(b *testing.B) {
 	}
 }
 
+func BenchmarkDocumentIteration(b *testing.B) {
+	doc := NewDocument()
+	for i := 0; i < 100; i++ {
+		doc.Append(EC.Int32(fmt.Sprintf("field%d", i), int32(i)))
+	}
+
+	b.Run("Iterator", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var sum int32
+			iter := doc.Iterator()
+			for iter.Next() {
+				if iter.Element().Key() != "" {
+					sum += iter.Element().Value().Int32()
+				}
+			}
+		}
+	})
+	b.Run("ForEach", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var sum int32
+			_ = doc.ForEach(func(key []byte, val Value) error {
+				if len(key) != 0 {
+					sum += val.Int32()
+				}
+				return nil
+			})
+		}
+	})
+}
+
 func valueEqual(v1, v2 *Value) bool {
 	if v1 == nil && v2 == nil {
 		return true
@@ -1211,3 +1270,53 @@ func documentComparer(d1, d2 *Document) bool {
 	}
 	return true
 }
+
+func TestDocumentAppendAll(t *testing.T) {
+	elems := []*Element{
+		EC.Int32("b", 1), EC.Int32("a", 2), EC.Int32("b", 3), EC.Int32("c", 4), EC.Int32("a", 5),
+	}
+
+	for split := 0; split <= len(elems); split++ {
+		t.Run(fmt.Sprintf("after %d appended", split), func(t *testing.T) {
+			want := NewDocument(elems...)
+			got := NewDocumentSize(len(elems)).Append(elems[:split]...).AppendAll(elems[split:]...)
+
+			if diff := cmp.Diff(got, want, cmp.AllowUnexported(Document{}, Element{}, Value{})); diff != "" {
+				t.Errorf("Documents differ: (-got +want)\n%s", diff)
+			}
+			if v := got.Lookup("a").Int32(); v != 5 {
+				t.Errorf("Unexpected value for a. got %d; want %d", v, 5)
+			}
+		})
+	}
+	t.Run("Nil Insert", func(t *testing.T) {
+		doc := NewDocument()
+		func() {
+			defer func() {
+				if r := recover(); r != ErrNilElement {
+					t.Errorf("Did not received expected error from panic. got %#v; want %#v", r, ErrNilElement)
+				}
+			}()
+			doc.AppendAll(EC.Int32("a", 1), nil)
+		}()
+		if doc.Len() != 0 {
+			t.Errorf("Elements were appended before the panic. got %d; want %d", doc.Len(), 0)
+		}
+
+		doc.IgnoreNilInsert = true
+		doc.AppendAll(EC.Int32("a", 1), nil)
+		if doc.Len() != 1 {
+			t.Errorf("Unexpected length. got %d; want %d", doc.Len(), 1)
+		}
+	})
+	t.Run("Storage", func(t *testing.T) {
+		doc := NewDocumentSize(2)
+		doc.AppendAll(EC.Int32("a", 1), EC.Int32("b", 2))
+		elems := &doc.elems[0]
+		doc.Reset()
+		doc.AppendAll(EC.Int32("c", 3), EC.Int32("d", 4))
+		if &doc.elems[0] != elems {
+			t.Errorf("Reset document did not reuse its storage")
+		}
+	})
+}
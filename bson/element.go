@@ -185,6 +185,11 @@ func (e *Element) Key() string {
 	return string(e.value.data[e.value.start+1 : e.value.offset-1])
 }
 
+// keyBytes returns the key of e without copying it.
+func (e *Element) keyBytes() []byte {
+	return e.value.data[e.value.start+1 : e.value.offset-1]
+}
+
 // WriteTo implements the io.WriterTo interface.
 func (e *Element) WriteTo(w io.Writer) (int64, error) {
 	return 0, nil
@@ -9,6 +9,7 @@ package bson
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -2625,3 +2626,65 @@ func testValidateValue(t *testing.T) {
 		}
 	})
 }
+
+func TestValueCoercion(t *testing.T) {
+	t.Run("AsInt64", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			val  *Value
+			want int64
+			err  bool
+		}{
+			{"int32", VC.Int32(-3), -3, false},
+			{"int64", VC.Int64(1 << 40), 1 << 40, false},
+			{"integral double", VC.Double(2), 2, false},
+			{"fractional double", VC.Double(2.5), 0, true},
+			{"NaN", VC.Double(math.NaN()), 0, true},
+			{"too large double", VC.Double(math.MaxInt64), 0, true},
+			{"string", VC.String("2"), 0, true},
+			{"nil", nil, 0, true},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := tc.val.AsInt64()
+				if (err != nil) != tc.err {
+					t.Fatalf("Unexpected error. got %v; want error %t", err, tc.err)
+				}
+				if got != tc.want {
+					t.Errorf("Unexpected value. got %d; want %d", got, tc.want)
+				}
+			})
+		}
+		_, err := VC.Boolean(true).AsInt64()
+		if want := (ElementTypeError{"bson.Value.AsInt64", TypeBoolean}); err != want {
+			t.Errorf("Unexpected error. got %v; want %v", err, want)
+		}
+	})
+	t.Run("AsString", func(t *testing.T) {
+		if s, err := VC.String("a").AsString(); err != nil || s != "a" {
+			t.Errorf("Unexpected result. got %q, %v; want %q, <nil>", s, err, "a")
+		}
+		if s, err := VC.Symbol("b").AsString(); err != nil || s != "b" {
+			t.Errorf("Unexpected result. got %q, %v; want %q, <nil>", s, err, "b")
+		}
+		_, err := VC.Int32(1).AsString()
+		if want := (ElementTypeError{"bson.Value.AsString", TypeInt32}); err != want {
+			t.Errorf("Unexpected error. got %v; want %v", err, want)
+		}
+	})
+	t.Run("OK accessors", func(t *testing.T) {
+		if _, _, ok := VC.Int32(1).RegexOK(); ok {
+			t.Errorf("RegexOK succeeded on an int32")
+		}
+		if p, o, ok := VC.Regex("^a", "i").RegexOK(); !ok || p != "^a" || o != "i" {
+			t.Errorf("Unexpected result. got %q, %q, %t; want %q, %q, true", p, o, ok, "^a", "i")
+		}
+		if _, ok := VC.String("a").SymbolOK(); ok {
+			t.Errorf("SymbolOK succeeded on a string")
+		}
+		if s, ok := VC.Symbol("a").SymbolOK(); !ok || s != "a" {
+			t.Errorf("Unexpected result. got %q, %t; want %q, true", s, ok, "a")
+		}
+	})
+}
@@ -7,6 +7,7 @@
 package bson
 
 import (
+	"encoding"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -31,6 +32,9 @@ var tByte = reflect.TypeOf(byte(0x00))
 var tElement = reflect.TypeOf((*Element)(nil))
 var tURL = reflect.TypeOf(url.URL{})
 var tJSONNumber = reflect.TypeOf(json.Number(""))
+var tJSONRawMessage = reflect.TypeOf(json.RawMessage(nil))
+var tTextMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+var tTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
 
 // Marshaler describes a type that can marshal a BSON representation of itself into bytes.
 type Marshaler interface {
@@ -47,9 +51,10 @@ type ElementMarshaler interface {
 	MarshalBSONElement() (*Element, error)
 }
 
-// ValueMarshaler describes a type that can marshal itself into a bson.Value.
+// ValueMarshaler describes a type that can marshal itself into a BSON value of any type. It returns
+// the type of the value and its bytes, without a type byte or key.
 type ValueMarshaler interface {
-	MarshalBSONValue() (*Value, error)
+	MarshalBSONValue() (Type, []byte, error)
 }
 
 // Encoder describes a type that can encode itself into a value.
@@ -102,24 +107,85 @@ type DocumentEncoder interface {
 	EncodeDocument(interface{}) (*Document, error)
 }
 
+// ErrSubMillisecondTime is returned by Encoders created with RejectSubMillisecondTime when encoding
+// a time.Time that BSON datetimes, which have a precision of a millisecond, cannot represent.
+type ErrSubMillisecondTime struct {
+	Time time.Time
+}
+
+func (e ErrSubMillisecondTime) Error() string {
+	return fmt.Sprintf("bson: %s cannot be encoded as a datetime without truncating it to the millisecond", e.Time)
+}
+
+// EncoderOption configures an Encoder.
+type EncoderOption func(*encoder)
+
+// EncoderMaxDepth makes an Encoder fail with an ErrRecursionLimit when encoding a value with more
+// than maxDepth levels of nested documents and arrays, counting the top-level document. A maxDepth
+// of 0 means DefaultMaxDepth.
+func EncoderMaxDepth(maxDepth int) EncoderOption {
+	return func(e *encoder) {
+		e.maxDepth = maxDepth
+	}
+}
+
+// TruncateTimeToMillisecond makes an Encoder truncate time.Time values to the millisecond, which is
+// the precision of BSON datetimes. Encoders truncate times by default; the option states that the
+// truncation is intended, and overrides a previous RejectSubMillisecondTime.
+func TruncateTimeToMillisecond() EncoderOption {
+	return func(e *encoder) {
+		e.rejectSubMillisecond = false
+	}
+}
+
+// RejectSubMillisecondTime makes an Encoder fail with an ErrSubMillisecondTime when encoding a
+// time.Time that is not a whole number of milliseconds, instead of truncating it. Times that round
+// trip through BSON then compare equal to the originals.
+func RejectSubMillisecondTime() EncoderOption {
+	return func(e *encoder) {
+		e.rejectSubMillisecond = true
+	}
+}
+
 type encoder struct {
-	w io.Writer
+	w                    io.Writer
+	maxDepth             int
+	rejectSubMillisecond bool
+
+	path     []string           // the keys leading to the value being encoded
+	visiting map[visit]struct{} // the values along path, to detect cycles
 }
 
 // NewEncoder creates an encoder that writes to w.
-func NewEncoder(w io.Writer) Encoder {
-	return &encoder{w: w}
+func NewEncoder(w io.Writer, opts ...EncoderOption) Encoder {
+	e := &encoder{w: w}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 // NewDocumentEncoder creates an encoder that encodes into a *Document.
-func NewDocumentEncoder() DocumentEncoder {
-	return &encoder{}
+func NewDocumentEncoder(opts ...EncoderOption) DocumentEncoder {
+	e := &encoder{}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 func convertTimeToInt64(t time.Time) int64 {
 	return t.Unix()*1000 + int64(t.Nanosecond()/1e6)
 }
 
+// dateTime returns the BSON datetime of t.
+func (e *encoder) dateTime(t time.Time) (int64, error) {
+	if e.rejectSubMillisecond && t.Nanosecond()%int(time.Millisecond) != 0 {
+		return 0, ErrSubMillisecondTime{Time: t}
+	}
+	return convertTimeToInt64(t), nil
+}
+
 func (e *encoder) Encode(v interface{}) error {
 	var err error
 
@@ -257,6 +323,13 @@ func (e *encoder) underlyingVal(val reflect.Value) reflect.Value {
 func (e *encoder) reflectEncode(val reflect.Value) ([]*Element, error) {
 	val = e.underlyingVal(val)
 
+	// val is the top-level document
+	e.path = e.path[:0]
+	e.visiting = nil
+	if v, ok := visitOf(val); ok {
+		e.visiting = map[visit]struct{}{v: {}}
+	}
+
 	var elems []*Element
 	var err error
 	switch val.Kind() {
@@ -436,19 +509,27 @@ func (e *encoder) encodeSliceAsArray(rval reflect.Value, minsize bool) ([]*Value
 			vals = append(vals, VC.Decimal128(t))
 			continue
 		case time.Time:
-			vals = append(vals, VC.DateTime(convertTimeToInt64(t)))
+			dt, err := e.dateTime(t)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, VC.DateTime(dt))
 			continue
 		case *time.Time:
 			if t == nil {
 				vals = append(vals, VC.Null())
-			} else {
-				vals = append(vals, VC.DateTime(convertTimeToInt64(*t)))
+				continue
+			}
+			dt, err := e.dateTime(*t)
+			if err != nil {
+				return nil, err
 			}
+			vals = append(vals, VC.DateTime(dt))
 			continue
 		}
 
 		sval = e.underlyingVal(sval)
-		val, err := e.valueFromValue(sval, minsize)
+		val, err := e.valueFromValue(strconv.Itoa(i), sval, minsize)
 		if err != nil {
 			return nil, err
 		}
@@ -526,14 +607,22 @@ func (e *encoder) encodeStruct(val reflect.Value) ([]*Element, error) {
 			elems = append(elems, EC.Decimal128(key, t))
 			continue
 		case time.Time:
-			elems = append(elems, EC.DateTime(key, convertTimeToInt64(t)))
+			dt, err := e.dateTime(t)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, EC.DateTime(key, dt))
 			continue
 		case *time.Time:
 			if t == nil {
 				elems = append(elems, EC.Null(key))
-			} else {
-				elems = append(elems, EC.DateTime(key, convertTimeToInt64(*t)))
+				continue
 			}
+			dt, err := e.dateTime(*t)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, EC.DateTime(key, dt))
 			continue
 		}
 		field = e.underlyingVal(field)
@@ -568,6 +657,18 @@ func (e *encoder) encodeStruct(val reflect.Value) ([]*Element, error) {
 	return elems, nil
 }
 
+// marshalValue returns the value that val, or a pointer to it, marshals itself into if it
+// implements ValueMarshaler or Marshaler.
+func (e *encoder) marshalValue(val reflect.Value) (*Value, bool, error) {
+	t, b, ok, err := marshalValue(val)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+
+	v, err := newValue(t, b)
+	return v, true, err
+}
+
 func (e *encoder) isZero(v reflect.Value) bool {
 	switch v.Kind() {
 	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
@@ -593,6 +694,13 @@ func (e *encoder) isZero(v reflect.Value) bool {
 }
 
 func (e *encoder) elemFromValue(key string, val reflect.Value, minsize bool) (*Element, error) {
+	if v, ok, err := e.marshalValue(val); ok {
+		if err != nil {
+			return nil, err
+		}
+		return convertValueToElem(key, v), nil
+	}
+
 	var elem *Element
 	switch val.Kind() {
 	case reflect.Interface, reflect.Ptr:
@@ -637,7 +745,11 @@ func (e *encoder) elemFromValue(key string, val reflect.Value, minsize bool) (*E
 			elem = EC.Null(key)
 			break
 		}
+		if err := e.enter(key, val); err != nil {
+			return nil, err
+		}
 		mapElems, err := e.encodeMap(val)
+		e.leave(val)
 		if err != nil {
 			return nil, err
 		}
@@ -655,7 +767,11 @@ func (e *encoder) elemFromValue(key string, val reflect.Value, minsize bool) (*E
 			elem = EC.Binary(key, val.Slice(0, val.Len()).Interface().([]byte))
 			break
 		}
+		if err := e.enter(key, val); err != nil {
+			return nil, err
+		}
 		sliceElems, err := e.encodeSliceAsArray(val, minsize)
+		e.leave(val)
 		if err != nil {
 			return nil, err
 		}
@@ -671,14 +787,30 @@ func (e *encoder) elemFromValue(key string, val reflect.Value, minsize bool) (*E
 			}
 			elem = EC.Binary(key, b)
 		default:
+			if err := e.enter(key, val); err != nil {
+				return nil, err
+			}
 			arrayElems, err := e.encodeSliceAsArray(val, minsize)
+			e.leave(val)
 			if err != nil {
 				return nil, err
 			}
 			elem = EC.ArrayFromElements(key, arrayElems...)
 		}
 	case reflect.Struct:
+		if val.Type() == tTime {
+			dt, err := e.dateTime(val.Interface().(time.Time))
+			if err != nil {
+				return nil, err
+			}
+			elem = EC.DateTime(key, dt)
+			break
+		}
+		if err := e.enter(key, val); err != nil {
+			return nil, err
+		}
 		structElems, err := e.encodeStruct(val)
+		e.leave(val)
 		if err != nil {
 			return nil, err
 		}
@@ -689,7 +821,11 @@ func (e *encoder) elemFromValue(key string, val reflect.Value, minsize bool) (*E
 	return elem, nil
 }
 
-func (e *encoder) valueFromValue(val reflect.Value, minsize bool) (*Value, error) {
+func (e *encoder) valueFromValue(key string, val reflect.Value, minsize bool) (*Value, error) {
+	if v, ok, err := e.marshalValue(val); ok {
+		return v, err
+	}
+
 	var elem *Value
 	switch val.Kind() {
 	case reflect.Interface, reflect.Ptr:
@@ -734,7 +870,11 @@ func (e *encoder) valueFromValue(val reflect.Value, minsize bool) (*Value, error
 			elem = VC.Null()
 			break
 		}
+		if err := e.enter(key, val); err != nil {
+			return nil, err
+		}
 		mapElems, err := e.encodeMap(val)
+		e.leave(val)
 		if err != nil {
 			return nil, err
 		}
@@ -752,7 +892,11 @@ func (e *encoder) valueFromValue(val reflect.Value, minsize bool) (*Value, error
 			elem = VC.Binary(val.Slice(0, val.Len()).Interface().([]byte))
 			break
 		}
+		if err := e.enter(key, val); err != nil {
+			return nil, err
+		}
 		sliceElems, err := e.encodeSliceAsArray(val, minsize)
+		e.leave(val)
 		if err != nil {
 			return nil, err
 		}
@@ -768,14 +912,30 @@ func (e *encoder) valueFromValue(val reflect.Value, minsize bool) (*Value, error
 			}
 			elem = VC.Binary(b)
 		default:
+			if err := e.enter(key, val); err != nil {
+				return nil, err
+			}
 			arrayElems, err := e.encodeSliceAsArray(val, minsize)
+			e.leave(val)
 			if err != nil {
 				return nil, err
 			}
 			elem = VC.ArrayFromValues(arrayElems...)
 		}
 	case reflect.Struct:
+		if val.Type() == tTime {
+			dt, err := e.dateTime(val.Interface().(time.Time))
+			if err != nil {
+				return nil, err
+			}
+			elem = VC.DateTime(dt)
+			break
+		}
+		if err := e.enter(key, val); err != nil {
+			return nil, err
+		}
 		structElems, err := e.encodeStruct(val)
+		e.leave(val)
 		if err != nil {
 			return nil, err
 		}
@@ -19,6 +19,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/bson/decimal"
 	"github.com/mongodb/mongo-go-driver/bson/objectid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEncoder(t *testing.T) {
@@ -1149,3 +1150,45 @@ type _impl struct {
 }
 
 func (_impl) method() {}
+
+func TestEncodeTime(t *testing.T) {
+	precise := time.Date(2018, 7, 1, 12, 30, 0, 123456789, time.UTC)
+	truncated := precise.Truncate(time.Millisecond)
+	type times struct {
+		T time.Time
+		P *time.Time
+		N *time.Time
+	}
+
+	t.Run("truncate", func(t *testing.T) {
+		for _, opts := range [][]EncoderOption{nil, {TruncateTimeToMillisecond()}, {RejectSubMillisecondTime(), TruncateTimeToMillisecond()}} {
+			doc, err := NewDocumentEncoder(opts...).EncodeDocument(times{T: precise, P: &precise})
+			require.NoError(t, err)
+			require.Equal(t, truncated, doc.Lookup("t").Time().UTC())
+			require.Equal(t, truncated, doc.Lookup("p").Time().UTC())
+			require.Equal(t, TypeNull, doc.Lookup("n").Type())
+		}
+	})
+	t.Run("reject", func(t *testing.T) {
+		enc := NewDocumentEncoder(RejectSubMillisecondTime())
+		for _, v := range []interface{}{
+			times{T: precise},
+			times{P: &precise},
+			map[string]interface{}{"t": precise},
+			struct{ A []time.Time }{A: []time.Time{truncated, precise}},
+		} {
+			_, err := enc.EncodeDocument(v)
+			require.Equal(t, ErrSubMillisecondTime{Time: precise}, err)
+		}
+
+		doc, err := enc.EncodeDocument(times{T: truncated})
+		require.NoError(t, err)
+		require.Equal(t, truncated, doc.Lookup("t").Time().UTC())
+	})
+	t.Run("map values", func(t *testing.T) {
+		doc, err := NewDocumentEncoder().EncodeDocument(map[string]interface{}{"t": truncated, "n": (*time.Time)(nil)})
+		require.NoError(t, err)
+		require.Equal(t, truncated, doc.Lookup("t").Time().UTC())
+		require.Equal(t, TypeNull, doc.Lookup("n").Type())
+	})
+}
@@ -0,0 +1,207 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// indentUnit is the indentation added for each level of nesting by the %+v verb.
+const indentUnit = "  "
+
+// Format implements the fmt.Formatter interface. The %v and %s verbs print the same text as
+// String. The %+v verb prints the document as indented extended JSON, keeping its fields in the
+// order they are stored.
+func (d *Document) Format(s fmt.State, verb rune) {
+	if d == nil {
+		formatText(s, verb, func() string { return "<nil>" }, nil)
+		return
+	}
+	formatText(s, verb, d.String, func(buf *bytes.Buffer) error {
+		return writeIndentedDocument(buf, sliceIterator(d.elems), false, "")
+	})
+}
+
+// Format implements the fmt.Formatter interface. The %v and %s verbs print the same text as
+// String. The %+v verb prints the document as indented extended JSON, keeping its fields in the
+// order they are stored.
+func (r Reader) Format(s fmt.State, verb rune) {
+	formatText(s, verb, r.String, func(buf *bytes.Buffer) error {
+		return writeIndentedDocument(buf, readerIterator(r), false, "")
+	})
+}
+
+// Format implements the fmt.Formatter interface. The %v and %s verbs print the same text as
+// String. The %+v verb prints the array as indented extended JSON.
+func (a *Array) Format(s fmt.State, verb rune) {
+	if a == nil || a.doc == nil {
+		formatText(s, verb, func() string { return "<nil>" }, nil)
+		return
+	}
+	formatText(s, verb, a.String, func(buf *bytes.Buffer) error {
+		return writeIndentedDocument(buf, sliceIterator(a.doc.elems), true, "")
+	})
+}
+
+// formatText writes str for the %v, %s and %q verbs, or the output of indented for %+v. If
+// indented is nil or fails, str is written instead.
+func formatText(s fmt.State, verb rune, str func() string, indented func(*bytes.Buffer) error) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') && indented != nil {
+			var buf bytes.Buffer
+			if err := indented(&buf); err == nil {
+				_, _ = s.Write(buf.Bytes())
+				return
+			}
+		}
+		_, _ = io.WriteString(s, str())
+	case 's':
+		_, _ = io.WriteString(s, str())
+	case 'q':
+		_, _ = io.WriteString(s, strconv.Quote(str()))
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(%s)", verb, str())
+	}
+}
+
+// elementIterator calls f for each element of a document, stopping at the first error f returns.
+type elementIterator func(f func(*Element) error) error
+
+func sliceIterator(elems []*Element) elementIterator {
+	return func(f func(*Element) error) error {
+		for _, elem := range elems {
+			if err := f(elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func readerIterator(r Reader) elementIterator {
+	return func(f func(*Element) error) error {
+		_, err := r.readElements(f)
+		return err
+	}
+}
+
+// writeIndentedDocument writes the elements of a document or, if array is true, of an array as
+// extended JSON, with each element on its own line. indent is the indentation of the line the
+// document starts on.
+func writeIndentedDocument(buf *bytes.Buffer, elems elementIterator, array bool, indent string) error {
+	open, close := byte('{'), byte('}')
+	if array {
+		open, close = '[', ']'
+	}
+
+	buf.WriteByte(open)
+	n := 0
+	err := elems(func(elem *Element) error {
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		n++
+		buf.WriteByte('\n')
+		buf.WriteString(indent + indentUnit)
+		if !array {
+			buf.WriteString(strconv.Quote(elem.Key()))
+			buf.WriteString(": ")
+		}
+		return writeIndentedValue(buf, elem.Value(), indent+indentUnit)
+	})
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		buf.WriteByte('\n')
+		buf.WriteString(indent)
+	}
+	buf.WriteByte(close)
+	return nil
+}
+
+// writeIndentedValue writes v as extended JSON. Doubles are always written with a decimal point
+// and 64-bit integers as $numberLong, so that numbers of different types can be told apart.
+func writeIndentedValue(buf *bytes.Buffer, v *Value, indent string) error {
+	switch v.Type() {
+	case TypeDouble:
+		f := v.Double()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			fmt.Fprintf(buf, `{"$numberDouble": %q}`, formatDouble(f))
+		} else {
+			buf.WriteString(formatDouble(f))
+		}
+	case TypeString:
+		buf.WriteString(strconv.Quote(v.StringValue()))
+	case TypeEmbeddedDocument:
+		return writeIndentedDocument(buf, readerIterator(v.ReaderDocument()), false, indent)
+	case TypeArray:
+		return writeIndentedDocument(buf, readerIterator(v.ReaderArray()), true, indent)
+	case TypeBinary:
+		subtype, data := v.Binary()
+		fmt.Fprintf(buf, `{"$binary": {"base64": %q, "subType": "%02x"}}`,
+			base64.StdEncoding.EncodeToString(data), subtype)
+	case TypeUndefined:
+		buf.WriteString(`{"$undefined": true}`)
+	case TypeObjectID:
+		fmt.Fprintf(buf, `{"$oid": %q}`, v.ObjectID().Hex())
+	case TypeBoolean:
+		buf.WriteString(strconv.FormatBool(v.Boolean()))
+	case TypeDateTime:
+		fmt.Fprintf(buf, `{"$date": %q}`, v.Time().UTC().Format("2006-01-02T15:04:05.999Z07:00"))
+	case TypeNull:
+		buf.WriteString("null")
+	case TypeRegex:
+		pattern, options := v.Regex()
+		fmt.Fprintf(buf, `{"$regularExpression": {"pattern": %q, "options": %q}}`, pattern, options)
+	case TypeDBPointer:
+		ns, oid := v.DBPointer()
+		fmt.Fprintf(buf, `{"$dbPointer": {"$ref": %q, "$id": {"$oid": %q}}}`, ns, oid.Hex())
+	case TypeJavaScript:
+		fmt.Fprintf(buf, `{"$code": %q}`, v.JavaScript())
+	case TypeSymbol:
+		fmt.Fprintf(buf, `{"$symbol": %q}`, v.Symbol())
+	case TypeCodeWithScope:
+		code, scope := v.ReaderJavaScriptWithScope()
+		fmt.Fprintf(buf, `{"$code": %q, "$scope": `, code)
+		if err := writeIndentedDocument(buf, readerIterator(scope), false, indent); err != nil {
+			return err
+		}
+		buf.WriteByte('}')
+	case TypeInt32:
+		buf.WriteString(strconv.FormatInt(int64(v.Int32()), 10))
+	case TypeTimestamp:
+		t, i := v.Timestamp()
+		fmt.Fprintf(buf, `{"$timestamp": {"t": %d, "i": %d}}`, t, i)
+	case TypeInt64:
+		fmt.Fprintf(buf, `{"$numberLong": "%d"}`, v.Int64())
+	case TypeDecimal128:
+		fmt.Fprintf(buf, `{"$numberDecimal": %q}`, v.Decimal128().String())
+	case TypeMinKey:
+		buf.WriteString(`{"$minKey": 1}`)
+	case TypeMaxKey:
+		buf.WriteString(`{"$maxKey": 1}`)
+	default:
+		return fmt.Errorf("bson: cannot format value of type %v", v.Type())
+	}
+	return nil
+}
+
+// formatValue returns v as indented extended JSON.
+func formatValue(v *Value) string {
+	var buf bytes.Buffer
+	if err := writeIndentedValue(&buf, v, ""); err != nil {
+		return fmt.Sprint(v.Interface())
+	}
+	return buf.String()
+}
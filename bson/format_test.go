@@ -0,0 +1,78 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	oid, err := objectid.FromHex("5b6d0f0e4d5a8b1a2c3d4e5f")
+	require.NoError(t, err)
+	doc := NewDocument(
+		EC.String("s", `a "b"`),
+		EC.SubDocumentFromElements("doc",
+			EC.Int32("i32", 1),
+			EC.Int64("i64", 2),
+			EC.ArrayFromElements("arr", VC.Double(3), VC.Double(math.Inf(-1))),
+		),
+		EC.SubDocument("empty", NewDocument()),
+		EC.ObjectID("_id", oid),
+		EC.Time("date", time.Date(2018, 8, 10, 1, 2, 3, 4000000, time.UTC)),
+		EC.Null("null"),
+		EC.Regex("re", "^a", "i"),
+	)
+	want := `{
+  "s": "a \"b\"",
+  "doc": {
+    "i32": 1,
+    "i64": {"$numberLong": "2"},
+    "arr": [
+      3.0,
+      {"$numberDouble": "-Infinity"}
+    ]
+  },
+  "empty": {},
+  "_id": {"$oid": "5b6d0f0e4d5a8b1a2c3d4e5f"},
+  "date": {"$date": "2018-08-10T01:02:03.004Z"},
+  "null": null,
+  "re": {"$regularExpression": {"pattern": "^a", "options": "i"}}
+}`
+
+	t.Run("document", func(t *testing.T) {
+		require.Equal(t, want, fmt.Sprintf("%+v", doc))
+		require.Equal(t, doc.String(), fmt.Sprintf("%v", doc))
+		require.Equal(t, doc.String(), fmt.Sprintf("%s", doc))
+	})
+	t.Run("reader", func(t *testing.T) {
+		rdr, err := doc.MarshalBSON()
+		require.NoError(t, err)
+
+		require.Equal(t, want, fmt.Sprintf("%+v", Reader(rdr)))
+		require.Equal(t, Reader(rdr).String(), fmt.Sprintf("%v", Reader(rdr)))
+	})
+	t.Run("array", func(t *testing.T) {
+		arr := NewArray(VC.Int32(1), VC.DocumentFromElements(EC.Boolean("ok", true)))
+		require.Equal(t, "[\n  1,\n  {\n    \"ok\": true\n  }\n]", fmt.Sprintf("%+v", arr))
+		require.Equal(t, "[]", fmt.Sprintf("%+v", NewArray()))
+		require.Equal(t, arr.String(), fmt.Sprintf("%v", arr))
+	})
+	t.Run("nil", func(t *testing.T) {
+		require.Equal(t, "<nil>", fmt.Sprintf("%+v", (*Document)(nil)))
+		require.Equal(t, "<nil>", fmt.Sprintf("%v", (*Array)(nil)))
+	})
+	t.Run("invalid reader", func(t *testing.T) {
+		rdr := Reader{0x05, 0x00}
+		require.Equal(t, rdr.String(), fmt.Sprintf("%+v", rdr))
+	})
+}
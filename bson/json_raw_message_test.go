@@ -0,0 +1,81 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONRawMessageCodec(t *testing.T) {
+	oid, err := objectid.FromHex("5b3b6b7b7b7b7b7b7b7b7b7b")
+	require.NoError(t, err)
+
+	type payload struct {
+		ID    string          `json:"id"`
+		Body  json.RawMessage `json:"body"`
+		Empty json.RawMessage `json:"empty,omitempty"`
+		Skip  string          `json:"-"`
+		Name  string          `bson:"title" json:"name"`
+	}
+	reg := NewRegistryBuilder().SetStructTagParser(JSONFallbackStructTagParser).Build()
+
+	t.Run("round trip", func(t *testing.T) {
+		in := payload{
+			ID:   "a",
+			Body: json.RawMessage(`{"n": 1, "tags": ["x", null], "ref": {"$oid": "5b3b6b7b7b7b7b7b7b7b7b7b"}}`),
+			Skip: "skipped",
+			Name: "t",
+		}
+		doc, err := MarshalDocumentWithRegistry(reg, in)
+		require.NoError(t, err)
+		require.Equal(t, "a", doc.Lookup("id").StringValue())
+		require.Equal(t, "t", doc.Lookup("title").StringValue())
+		require.Equal(t, int64(1), doc.Lookup("body", "n").Int64())
+		require.Equal(t, oid, doc.Lookup("body", "ref").ObjectID())
+		_, err = doc.LookupErr("empty")
+		require.Error(t, err)
+		_, err = doc.LookupErr("skip")
+		require.Error(t, err)
+
+		var out payload
+		require.NoError(t, UnmarshalDocumentWithRegistry(reg, doc, &out))
+		require.JSONEq(t, `{"n": 1, "tags": ["x", null], "ref": {"$oid": "5b3b6b7b7b7b7b7b7b7b7b7b"}}`, string(out.Body))
+		require.Equal(t, "a", out.ID)
+		require.Equal(t, "t", out.Name)
+	})
+	t.Run("scalars", func(t *testing.T) {
+		doc, err := MarshalDocumentWithRegistry(reg, map[string]json.RawMessage{"s": json.RawMessage(`"x"`), "n": nil})
+		require.NoError(t, err)
+		require.Equal(t, "x", doc.Lookup("s").StringValue())
+		require.Equal(t, TypeNull, doc.Lookup("n").Type())
+
+		var out map[string]json.RawMessage
+		require.NoError(t, UnmarshalDocumentWithRegistry(reg, doc, &out))
+		require.Equal(t, `"x"`, string(out["s"]))
+		require.Equal(t, `null`, string(out["n"]))
+	})
+	t.Run("default tags", func(t *testing.T) {
+		doc, err := MarshalDocumentWithRegistry(NewRegistryBuilder().Build(), payload{Body: json.RawMessage(`1`)})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), doc.Lookup("body").Int64())
+		_, err = doc.LookupErr("skip")
+		require.NoError(t, err)
+	})
+	t.Run("errors name the field", func(t *testing.T) {
+		type outer struct {
+			Items []payload `json:"items"`
+		}
+		_, err := MarshalDocumentWithRegistry(reg, outer{Items: []payload{{Body: json.RawMessage(`{}`)}, {Body: json.RawMessage(`{"a": [1}`)}}})
+		jce, ok := err.(JSONConversionError)
+		require.True(t, ok, "expected a JSONConversionError, got %v", err)
+		require.Equal(t, "items.1.body", jce.Path)
+	})
+}
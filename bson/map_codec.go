@@ -1,24 +1,43 @@
 package bson
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
+	"strconv"
+
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
 )
 
 var defaultMapCodec = &MapCodec{}
 
-// MapCodec is the Codec used for map values.
-type MapCodec struct{}
+// MapCodec is the Codec used for map values. The keys of maps are encoded as the keys of a
+// document: keys that implement encoding.TextMarshaler are marshaled to text, ObjectIDs are
+// written in hex and integers in base 10. Decoding parses the keys back, and fails if two keys of
+// a document decode to the same map key.
+type MapCodec struct {
+	// StringKeysOnly makes the codec fail on maps whose keys are not strings.
+	StringKeysOnly bool
+}
 
 var _ Codec = &MapCodec{}
 
 // EncodeValue implements the Codec interface.
 func (mc *MapCodec) EncodeValue(ec EncodeContext, vw ValueWriter, i interface{}) error {
 	val := reflect.ValueOf(i)
-	if val.Kind() != reflect.Map || val.Type().Key().Kind() != reflect.String {
-		return fmt.Errorf("%T can only encode maps with string keys", mc)
+	if val.Kind() != reflect.Map {
+		return fmt.Errorf("%T can only encode maps", mc)
+	}
+	if err := mc.checkKeyType(val.Type().Key(), canEncodeMapKey); err != nil {
+		return err
 	}
 
+	ec, err := ec.enter(val)
+	if err != nil {
+		return err
+	}
+	defer ec.leave(val)
+
 	dw, err := vw.WriteDocument()
 	if err != nil {
 		return err
@@ -44,19 +63,35 @@ func (mc *MapCodec) encodeValue(ec EncodeContext, dw DocumentWriter, val reflect
 		}
 	}
 
+	// keys of other types than string could be formatted as the same string
+	var names map[string]struct{}
+	if val.Type().Key().Kind() != reflect.String {
+		names = make(map[string]struct{}, val.Len())
+	}
+
 	keys := val.MapKeys()
 	for _, key := range keys {
-		if collisionFn != nil && collisionFn(key.String()) {
-			return fmt.Errorf("Key %s of inlined map conflicts with a struct field name", key)
+		name, err := mapKeyString(key)
+		if err != nil {
+			return err
+		}
+		if names != nil {
+			if _, exists := names[name]; exists {
+				return fmt.Errorf("more than one key of map %s is encoded as %q", val.Type(), name)
+			}
+			names[name] = struct{}{}
 		}
-		vw, err := dw.WriteDocumentElement(key.String())
+		if collisionFn != nil && collisionFn(name) {
+			return fmt.Errorf("Key %s of inlined map conflicts with a struct field name", name)
+		}
+		vw, err := dw.WriteDocumentElement(name)
 		if err != nil {
 			return err
 		}
 
 		err = codec.EncodeValue(ec, vw, val.MapIndex(key).Interface())
 		if err != nil {
-			return err
+			return prefixPath(err, name)
 		}
 	}
 
@@ -70,8 +105,17 @@ func (mc *MapCodec) DecodeValue(dc DecodeContext, vr ValueReader, i interface{})
 		return fmt.Errorf("%T can only be used to decode non-nil pointers to map values, got %T", mc, i)
 	}
 
-	if val.Elem().Kind() != reflect.Map || val.Elem().Type().Key().Kind() != reflect.String || !val.Elem().CanSet() {
-		return fmt.Errorf("%T can only decode settable maps with string keys", mc)
+	if val.Elem().Kind() != reflect.Map || !val.Elem().CanSet() {
+		return fmt.Errorf("%T can only decode settable maps", mc)
+	}
+	keyType := val.Elem().Type().Key()
+	if err := mc.checkKeyType(keyType, canDecodeMapKey); err != nil {
+		return err
+	}
+
+	dc, err := dc.enter()
+	if err != nil {
+		return err
 	}
 
 	dr, err := vr.ReadDocument()
@@ -90,6 +134,7 @@ func (mc *MapCodec) DecodeValue(dc DecodeContext, vr ValueReader, i interface{})
 		return err
 	}
 
+	keys := newMapKeySet(keyType)
 	for {
 		var elem reflect.Value
 		key, vr, err := dr.ReadElement()
@@ -101,14 +146,145 @@ func (mc *MapCodec) DecodeValue(dc DecodeContext, vr ValueReader, i interface{})
 		}
 		key, elem, err = dFn(dc, vr, key)
 		if err != nil {
-			return err
+			return prefixPath(err, key)
 		}
 
-		mVal.SetMapIndex(reflect.ValueOf(key), elem)
+		kVal, err := keys.add(key)
+		if err != nil {
+			return err
+		}
+		mVal.SetMapIndex(kVal, elem)
 	}
 	return err
 }
 
+// checkKeyType returns an error if maps with keys of type t cannot be encoded or decoded, as
+// reported by supported.
+func (mc *MapCodec) checkKeyType(t reflect.Type, supported func(reflect.Type) bool) error {
+	if t.Kind() == reflect.String {
+		return nil
+	}
+	if mc.StringKeysOnly {
+		return fmt.Errorf("%T can only process maps with string keys", mc)
+	}
+	if !supported(t) {
+		return fmt.Errorf("%T cannot process maps with keys of type %s", mc, t)
+	}
+	return nil
+}
+
+func canEncodeMapKey(t reflect.Type) bool {
+	return t.Kind() == reflect.String || t == tOID || t.Implements(tTextMarshaler) || isIntegerKind(t.Kind())
+}
+
+func canDecodeMapKey(t reflect.Type) bool {
+	return t.Kind() == reflect.String || t == tOID || reflect.PtrTo(t).Implements(tTextUnmarshaler) ||
+		isIntegerKind(t.Kind())
+}
+
+func isIntegerKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// mapKeyString returns the document key for the map key key.
+func mapKeyString(key reflect.Value) (string, error) {
+	switch {
+	case key.Kind() == reflect.String:
+		return key.String(), nil
+	case key.Type() == tOID:
+		return key.Interface().(objectid.ObjectID).Hex(), nil
+	case key.Type().Implements(tTextMarshaler):
+		if key.Kind() == reflect.Ptr && key.IsNil() {
+			return "", nil
+		}
+		b, err := key.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("cannot encode map key %v: %v", key, err)
+		}
+		return string(b), nil
+	}
+
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("cannot encode map key of type %s", key.Type())
+}
+
+// mapKeyValue parses the document key s as a map key of type t.
+func mapKeyValue(t reflect.Type, s string) (reflect.Value, error) {
+	switch {
+	case t.Kind() == reflect.String:
+		return reflect.ValueOf(s).Convert(t), nil
+	case t == tOID:
+		oid, err := objectid.FromHex(s)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("cannot decode %q as an ObjectID map key: %v", s, err)
+		}
+		return reflect.ValueOf(oid), nil
+	case reflect.PtrTo(t).Implements(tTextUnmarshaler):
+		key := reflect.New(t)
+		if err := key.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return reflect.Value{}, fmt.Errorf("cannot decode %q as a map key of type %s: %v", s, t, err)
+		}
+		return key.Elem(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("cannot decode %q as a map key of type %s: %v", s, t, err)
+		}
+		return reflect.ValueOf(i).Convert(t), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("cannot decode %q as a map key of type %s: %v", s, t, err)
+		}
+		return reflect.ValueOf(u).Convert(t), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot decode map key of type %s", t)
+}
+
+// mapKeySet parses the keys of a document as map keys of a type, and detects the different
+// document keys that decode to the same map key, such as "1" and "01", when that type is not a
+// string.
+type mapKeySet struct {
+	t    reflect.Type
+	seen map[interface{}]string
+}
+
+func newMapKeySet(t reflect.Type) *mapKeySet {
+	ks := &mapKeySet{t: t}
+	if t.Kind() != reflect.String {
+		ks.seen = make(map[interface{}]string)
+	}
+	return ks
+}
+
+// add returns the map key for the document key s.
+func (ks *mapKeySet) add(s string) (reflect.Value, error) {
+	key, err := mapKeyValue(ks.t, s)
+	if err != nil {
+		return key, err
+	}
+	if ks.seen != nil {
+		if prev, exists := ks.seen[key.Interface()]; exists {
+			return key, fmt.Errorf("document keys %q and %q decode to the same map key of type %s", prev, s, ks.t)
+		}
+		ks.seen[key.Interface()] = s
+	}
+	return key, nil
+}
+
 type decodeFn func(dc DecodeContext, vr ValueReader, key string) (updatedKey string, v reflect.Value, err error)
 
 // decodeFn returns a function that can be used to decode the values of a map.
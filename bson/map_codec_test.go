@@ -0,0 +1,119 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/stretchr/testify/require"
+)
+
+type stringKey string
+
+// textKey is a map key that is encoded as text.
+type textKey struct {
+	a, b string
+}
+
+func (k textKey) MarshalText() ([]byte, error) {
+	if strings.Contains(k.a, ":") {
+		return nil, errors.New("colon in key")
+	}
+	return []byte(k.a + ":" + k.b), nil
+}
+
+func (k *textKey) UnmarshalText(text []byte) error {
+	parts := strings.SplitN(string(text), ":", 2)
+	if len(parts) != 2 {
+		return errors.New("missing colon")
+	}
+	k.a, k.b = parts[0], parts[1]
+	return nil
+}
+
+func TestMapCodecKeys(t *testing.T) {
+	reg := NewRegistryBuilder().Build()
+	oid, err := objectid.FromHex("5b3b6b7b7b7b7b7b7b7b7b7b")
+	require.NoError(t, err)
+
+	t.Run("round trip", func(t *testing.T) {
+		type maps struct {
+			Ints    map[int]string
+			Uints   map[uint8]int32
+			OIDs    map[objectid.ObjectID]bool
+			Text    map[textKey]string
+			Strings map[stringKey]string
+		}
+		in := maps{
+			Ints:    map[int]string{-1: "a", 20: "b"},
+			Uints:   map[uint8]int32{255: 1},
+			OIDs:    map[objectid.ObjectID]bool{oid: true},
+			Text:    map[textKey]string{{"x", "y"}: "z"},
+			Strings: map[stringKey]string{"k": "v"},
+		}
+
+		doc, err := MarshalDocumentWithRegistry(reg, in)
+		require.NoError(t, err)
+		require.Equal(t, "a", doc.Lookup("ints", "-1").StringValue())
+		require.Equal(t, int32(1), doc.Lookup("uints", "255").Int32())
+		require.True(t, doc.Lookup("oids", "5b3b6b7b7b7b7b7b7b7b7b7b").Boolean())
+		require.Equal(t, "z", doc.Lookup("text", "x:y").StringValue())
+
+		var out maps
+		require.NoError(t, UnmarshalDocumentWithRegistry(reg, doc, &out))
+		require.Equal(t, in, out)
+	})
+	t.Run("unmarshal", func(t *testing.T) {
+		b, err := Marshal(map[int]string{1: "a", -2: "b"})
+		require.NoError(t, err)
+
+		var out map[int]string
+		out = make(map[int]string)
+		require.NoError(t, Unmarshal(b, out))
+		require.Equal(t, map[int]string{1: "a", -2: "b"}, out)
+	})
+	t.Run("overflow", func(t *testing.T) {
+		doc := NewDocument(EC.Int32("127", 1), EC.Int32("300", 2))
+
+		var out map[int8]int32
+		err := UnmarshalDocumentWithRegistry(reg, doc, &out)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `"300"`)
+	})
+	t.Run("duplicate keys", func(t *testing.T) {
+		doc := NewDocument(EC.Int32("1", 1), EC.Int32("01", 2))
+
+		var out map[int]int32
+		err := UnmarshalDocumentWithRegistry(reg, doc, &out)
+		require.EqualError(t, err, `document keys "1" and "01" decode to the same map key of type int`)
+
+		b, err := doc.MarshalBSON()
+		require.NoError(t, err)
+		require.Error(t, Unmarshal(b, make(map[int]int32)))
+	})
+	t.Run("marshal text error", func(t *testing.T) {
+		_, err := MarshalDocumentWithRegistry(reg, map[textKey]int32{{"a:", "b"}: 1})
+		require.Error(t, err)
+	})
+	t.Run("string keys only", func(t *testing.T) {
+		strict := NewRegistryBuilder().DisallowNonStringMapKeys().Build()
+
+		_, err := MarshalDocumentWithRegistry(strict, map[int]string{1: "a"})
+		require.EqualError(t, err, "*bson.MapCodec can only process maps with string keys")
+
+		var out map[int]string
+		err = UnmarshalDocumentWithRegistry(strict, NewDocument(EC.String("1", "a")), &out)
+		require.EqualError(t, err, "*bson.MapCodec can only process maps with string keys")
+
+		doc, err := MarshalDocumentWithRegistry(strict, map[string]string{"1": "a"})
+		require.NoError(t, err)
+		require.Equal(t, "a", doc.Lookup("1").StringValue())
+	})
+}
@@ -0,0 +1,187 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var tMarshaler = reflect.TypeOf((*Marshaler)(nil)).Elem()
+var tValueMarshaler = reflect.TypeOf((*ValueMarshaler)(nil)).Elem()
+var tUnmarshaler = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+var tValueUnmarshaler = reflect.TypeOf((*ValueUnmarshaler)(nil)).Elem()
+
+// MarshalerCodec is the Codec for types that implement Marshaler, ValueMarshaler, Unmarshaler or
+// ValueUnmarshaler, or whose pointers do. A Registry returns a MarshalerCodec for such types instead
+// of the codec for their kind, which the MarshalerCodec uses when a type lacks the methods for
+// encoding or for decoding. ValueMarshaler and ValueUnmarshaler are preferred over Marshaler and
+// Unmarshaler.
+type MarshalerCodec struct {
+	fallback Codec
+}
+
+var _ Codec = &MarshalerCodec{}
+
+// EncodeValue implements the Codec interface.
+func (mc *MarshalerCodec) EncodeValue(ec EncodeContext, vw ValueWriter, i interface{}) error {
+	val := reflect.ValueOf(i)
+	if val.Kind() == reflect.Ptr && val.IsNil() {
+		return vw.WriteNull()
+	}
+
+	t, b, ok, err := marshalValue(val)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if mc.fallback == nil {
+			return ErrNoCodec{Type: val.Type()}
+		}
+		return mc.fallback.EncodeValue(ec, vw, i)
+	}
+
+	v, err := newValue(t, b)
+	if err != nil {
+		return err
+	}
+	return defaultValueCodec.encodeValue(ec, vw, v)
+}
+
+// DecodeValue implements the Codec interface.
+func (mc *MarshalerCodec) DecodeValue(dc DecodeContext, vr ValueReader, i interface{}) error {
+	ptr := reflect.ValueOf(i)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return fmt.Errorf("%T can only be used to decode non-nil pointers, got %T", mc, i)
+	}
+
+	// pointer fields are decoded through a pointer to the field, and are allocated when needed
+	if ptr.Elem().Kind() == reflect.Ptr && isUnmarshaler(ptr.Elem().Type()) {
+		if vr.Type() == TypeNull {
+			ptr.Elem().Set(reflect.Zero(ptr.Elem().Type()))
+			return vr.ReadNull()
+		}
+		if ptr.Elem().IsNil() {
+			ptr.Elem().Set(reflect.New(ptr.Elem().Type().Elem()))
+		}
+		ptr = ptr.Elem()
+	}
+
+	if !isUnmarshaler(ptr.Type()) {
+		if mc.fallback == nil {
+			return ErrNoCodec{Type: ptr.Type()}
+		}
+		return mc.fallback.DecodeValue(dc, vr, i)
+	}
+
+	var v *Value
+	if err := defaultValueCodec.decodeValue(dc, vr, &v); err != nil {
+		return err
+	}
+	return unmarshalValue(ptr, v)
+}
+
+// hasHooks returns true if values of type t, or pointers to them, implement Marshaler,
+// ValueMarshaler, Unmarshaler or ValueUnmarshaler. The package's own types are encoded and decoded
+// as what they represent, so they have no hooks.
+func hasHooks(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	pt := reflect.PtrTo(t)
+	switch pt {
+	case tDocument, tArray, tElement, tValue:
+		return false
+	}
+	return pt.Implements(tMarshaler) || pt.Implements(tValueMarshaler) ||
+		isUnmarshaler(pt)
+}
+
+// isUnmarshaler returns true if t implements Unmarshaler or ValueUnmarshaler.
+func isUnmarshaler(t reflect.Type) bool {
+	if t == tDocument || t == tValue {
+		return false
+	}
+	return t.Implements(tUnmarshaler) || t.Implements(tValueUnmarshaler)
+}
+
+// marshalValue calls the MarshalBSONValue or MarshalBSON method of val, or of a pointer to it. ok is
+// false if neither has such a method, or if val is a nil pointer.
+func marshalValue(val reflect.Value) (t Type, b []byte, ok bool, err error) {
+	if !val.IsValid() || !hasHooks(val.Type()) {
+		return 0, nil, false, nil
+	}
+
+	switch {
+	case val.Kind() == reflect.Ptr:
+		if val.IsNil() {
+			return 0, nil, false, nil
+		}
+	case val.Type().Implements(tMarshaler) || val.Type().Implements(tValueMarshaler):
+	case val.CanAddr():
+		val = val.Addr()
+	default:
+		ptr := reflect.New(val.Type())
+		ptr.Elem().Set(val)
+		val = ptr
+	}
+
+	switch m := val.Interface().(type) {
+	case ValueMarshaler:
+		t, b, err = m.MarshalBSONValue()
+	case Marshaler:
+		t = TypeEmbeddedDocument
+		b, err = m.MarshalBSON()
+	default:
+		return 0, nil, false, nil
+	}
+	return t, b, true, err
+}
+
+// unmarshalValue calls the UnmarshalBSONValue or UnmarshalBSON method of ptr with v. UnmarshalBSON
+// is only called for documents, and null leaves the value ptr points to unchanged.
+func unmarshalValue(ptr reflect.Value, v *Value) error {
+	switch u := ptr.Interface().(type) {
+	case ValueUnmarshaler:
+		t, b, err := v.MarshalBSONValue()
+		if err != nil {
+			return err
+		}
+		return u.UnmarshalBSONValue(t, b)
+	case Unmarshaler:
+		switch v.Type() {
+		case TypeNull:
+			return nil
+		case TypeEmbeddedDocument:
+			_, b, err := v.MarshalBSONValue()
+			if err != nil {
+				return err
+			}
+			return u.UnmarshalBSON(b)
+		}
+		return fmt.Errorf("cannot unmarshal BSON %v into a %s, which can only unmarshal documents", v.Type(), ptr.Type())
+	}
+	return fmt.Errorf("%s does not implement bson.Unmarshaler or bson.ValueUnmarshaler", ptr.Type())
+}
+
+// newValue returns a value of type t encoded as b, which must hold exactly one valid value.
+func newValue(t Type, b []byte) (*Value, error) {
+	data := make([]byte, 0, len(b)+2)
+	data = append(data, byte(t), 0x00)
+	data = append(data, b...)
+
+	v := &Value{start: 0, offset: 2, data: data}
+	size, err := v.validate(false)
+	if err != nil {
+		return nil, err
+	}
+	if int(size) != len(b) {
+		return nil, fmt.Errorf("a %v value of %d bytes is followed by %d extra bytes", t, size, len(b)-int(size))
+	}
+	return v, nil
+}
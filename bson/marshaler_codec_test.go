@@ -0,0 +1,170 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// upperString is stored as an uppercase BSON string.
+type upperString string
+
+func (us upperString) MarshalBSONValue() (Type, []byte, error) {
+	_, b, err := VC.String(strings.ToUpper(string(us))).MarshalBSONValue()
+	return TypeString, b, err
+}
+
+func (us *upperString) UnmarshalBSONValue(t Type, b []byte) error {
+	if t != TypeString {
+		return errors.New("upperString must be a string")
+	}
+	var v Value
+	if err := v.UnmarshalBSONValue(t, b); err != nil {
+		return err
+	}
+	*us = upperString(strings.ToLower(v.StringValue()))
+	return nil
+}
+
+// point is stored as a document with an "xy" array.
+type point struct {
+	X, Y int32
+}
+
+func (p *point) MarshalBSON() ([]byte, error) {
+	return NewDocument(EC.ArrayFromElements("xy", VC.Int32(p.X), VC.Int32(p.Y))).MarshalBSON()
+}
+
+func (p *point) UnmarshalBSON(b []byte) error {
+	xy, err := Reader(b).Lookup("xy")
+	if err != nil {
+		return err
+	}
+	arr := xy.Value().MutableArray()
+	x, err := arr.Lookup(0)
+	if err != nil {
+		return err
+	}
+	y, err := arr.Lookup(1)
+	if err != nil {
+		return err
+	}
+	p.X, p.Y = x.Int32(), y.Int32()
+	return nil
+}
+
+// marshalOnly is only customized when it is encoded.
+type marshalOnly struct {
+	A int32
+}
+
+func (mo marshalOnly) MarshalBSONValue() (Type, []byte, error) {
+	_, b, err := VC.Int32(mo.A).MarshalBSONValue()
+	return TypeInt32, b, err
+}
+
+type hooks struct {
+	Name    upperString
+	Origin  point
+	Target  *point
+	Missing *point
+	Names   []upperString
+	Points  map[string]point
+	Only    marshalOnly
+}
+
+func TestMarshalerHooks(t *testing.T) {
+	in := hooks{
+		Name:   "ada",
+		Origin: point{1, 2},
+		Target: &point{3, 4},
+		Names:  []upperString{"a", "b"},
+		Points: map[string]point{"p": {5, 6}},
+		Only:   marshalOnly{7},
+	}
+	want := NewDocument(
+		EC.String("name", "ADA"),
+		EC.SubDocumentFromElements("origin", EC.ArrayFromElements("xy", VC.Int32(1), VC.Int32(2))),
+		EC.SubDocumentFromElements("target", EC.ArrayFromElements("xy", VC.Int32(3), VC.Int32(4))),
+		EC.Null("missing"),
+		EC.ArrayFromElements("names", VC.String("A"), VC.String("B")),
+		EC.SubDocumentFromElements("points",
+			EC.SubDocumentFromElements("p", EC.ArrayFromElements("xy", VC.Int32(5), VC.Int32(6))),
+		),
+		EC.Int32("only", 7),
+	)
+
+	reg := NewRegistryBuilder().Build()
+
+	t.Run("registry", func(t *testing.T) {
+		doc, err := MarshalDocumentWithRegistry(reg, in)
+		require.NoError(t, err)
+		require.True(t, want.Equal(doc), "expected %v, got %v", want, doc)
+
+		// marshalOnly is decoded by the struct codec, which needs a document
+		doc.Delete("only")
+		var out hooks
+		require.NoError(t, UnmarshalDocumentWithRegistry(reg, doc, &out))
+		in := in
+		in.Only = marshalOnly{}
+		require.Equal(t, in, out)
+	})
+	t.Run("reflection", func(t *testing.T) {
+		b, err := Marshal(in)
+		require.NoError(t, err)
+		doc, err := ReadDocument(b)
+		require.NoError(t, err)
+		require.True(t, want.Equal(doc), "expected %v, got %v", want, doc)
+
+		var out hooks
+		require.NoError(t, Unmarshal(b, &out))
+		in := in
+		in.Only = marshalOnly{}
+		require.Equal(t, in, out)
+	})
+	t.Run("not a document", func(t *testing.T) {
+		doc := NewDocument(EC.Int32("origin", 1))
+
+		var out hooks
+		err := UnmarshalDocumentWithRegistry(reg, doc, &out)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "can only unmarshal documents")
+	})
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := newValue(TypeInt32, []byte{1, 2, 3, 4, 5})
+		require.EqualError(t, err, "a 32-bit integer value of 4 bytes is followed by 1 extra bytes")
+		_, err = newValue(TypeString, []byte{1})
+		require.Error(t, err)
+	})
+}
+
+func TestValueMarshalBSONValue(t *testing.T) {
+	for _, v := range []*Value{
+		VC.String("a"),
+		VC.Int64(1),
+		VC.DocumentFromElements(EC.Int32("x", 1)),
+		VC.ArrayFromValues(VC.Null()),
+	} {
+		typ, b, err := v.MarshalBSONValue()
+		require.NoError(t, err)
+		require.Equal(t, v.Type(), typ)
+
+		var out Value
+		require.NoError(t, out.UnmarshalBSONValue(typ, b))
+		typ2, b2, err := out.MarshalBSONValue()
+		require.NoError(t, err)
+		require.Equal(t, typ, typ2)
+		require.Equal(t, b, b2)
+	}
+
+	_, _, err := (*Value)(nil).MarshalBSONValue()
+	require.Equal(t, ErrUninitializedElement, err)
+}
@@ -66,13 +66,13 @@ func NewFromIOReader(r io.Reader) (Reader, error) {
 // Validate validates the document. This method only validates the first document in
 // the slice, to validate other documents, the slice must be resliced.
 func (r Reader) Validate() (size uint32, err error) {
-	return r.readElements(func(elem *Element) error {
+	return r.forEach(true, func(_ []byte, val Value) error {
 		var err error
-		switch elem.value.Type() {
+		switch val.Type() {
 		case '\x03':
-			_, err = elem.value.ReaderDocument().Validate()
+			_, err = val.ReaderDocument().Validate()
 		case '\x04':
-			_, err = elem.value.ReaderArray().Validate()
+			_, err = val.ReaderArray().Validate()
 		}
 		return err
 	})
@@ -172,6 +172,62 @@ func (r Reader) Iterator() (*ReaderIterator, error) {
 	return NewReaderIterator(r)
 }
 
+// ForEach calls f with the key and the value of each element of the document, in order, and stops
+// at the first error returned by f, which ForEach then returns. The key and the value are views over
+// the bytes of the Reader that are only valid until r is modified, so they must be copied to be
+// retained. Unlike Iterator and Lookup, ForEach does not allocate.
+func (r Reader) ForEach(f func(key []byte, val Value) error) error {
+	_, err := r.forEach(false, f)
+	return err
+}
+
+// forEach implements ForEach and returns the size of the document. If sizeOnly is true, the values
+// are only checked to fit in the document, and nested documents are not validated.
+func (r Reader) forEach(sizeOnly bool, f func(key []byte, val Value) error) (uint32, error) {
+	if len(r) < 5 {
+		return 0, NewErrTooSmall()
+	}
+	givenLength := readi32(r[0:4])
+	if len(r) < int(givenLength) || givenLength < 0 {
+		return 0, ErrInvalidLength
+	}
+
+	var pos uint32 = 4
+	end := uint32(givenLength)
+	for {
+		if pos >= end {
+			// We've gone off the end of the buffer and we're missing
+			// a null terminator.
+			return pos, ErrInvalidReadOnlyDocument
+		}
+		if r[pos] == '\x00' {
+			// The size is always 1 larger than the position, since position is 0
+			// indexed.
+			return pos + 1, nil
+		}
+
+		start := pos
+		pos++
+		n, err := r.validateKey(pos, end)
+		pos += n
+		if err != nil {
+			return pos, err
+		}
+
+		val := Value{start: start, offset: pos, data: r}
+		n, err = val.validate(sizeOnly)
+		pos += n
+		if err != nil {
+			return pos, err
+		}
+
+		err = f(r[start+1:val.offset-1], val)
+		if err != nil {
+			return pos, err
+		}
+	}
+}
+
 // Keys returns the keys for this document. If recursive is true then this
 // method will also return the keys for subdocuments and arrays.
 //
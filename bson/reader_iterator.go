@@ -17,21 +17,30 @@ type ReaderIterator struct {
 
 // NewReaderIterator constructors a new ReaderIterator over a given Reader.
 func NewReaderIterator(r Reader) (*ReaderIterator, error) {
-	itr := new(ReaderIterator)
+	itr := &ReaderIterator{elem: &Element{value: &Value{}}}
+	if err := itr.reset(r); err != nil {
+		return nil, err
+	}
+
+	return itr, nil
+}
+
+// reset makes itr iterate over r from its first element.
+func (itr *ReaderIterator) reset(r Reader) error {
 	if len(r) < 5 {
-		return nil, NewErrTooSmall()
+		return NewErrTooSmall()
 	}
 	givenLength := readi32(r[0:4])
 	if len(r) < int(givenLength) {
-		return nil, ErrInvalidLength
+		return ErrInvalidLength
 	}
 
 	itr.r = r
 	itr.pos = 4
 	itr.end = uint32(givenLength)
-	itr.elem = &Element{value: &Value{}}
+	itr.err = nil
 
-	return itr, nil
+	return nil
 }
 
 // Next fetches the next element of the Reader, returning whether or not the next element was able
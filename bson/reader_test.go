@@ -9,6 +9,7 @@ package bson
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
@@ -36,6 +37,48 @@ func BenchmarkReaderValidate(b *testing.B) {
 
 }
 
+func BenchmarkReaderIteration(b *testing.B) {
+	doc := NewDocument()
+	for i := 0; i < 100; i++ {
+		doc.Append(EC.Int32(fmt.Sprintf("field%d", i), int32(i)))
+	}
+	rdr, err := doc.MarshalBSON()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("Iterator", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var sum int32
+			iter, err := Reader(rdr).Iterator()
+			if err != nil {
+				b.Fatal(err)
+			}
+			for iter.Next() {
+				if iter.Element().Key() != "" {
+					sum += iter.Element().Value().Int32()
+				}
+			}
+		}
+	})
+	b.Run("ForEach", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var sum int32
+			err := Reader(rdr).ForEach(func(key []byte, val Value) error {
+				if len(key) != 0 {
+					sum += val.Int32()
+				}
+				return nil
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 func TestReader(t *testing.T) {
 	t.Run("Validate", func(t *testing.T) {
 		t.Run("TooShort", func(t *testing.T) {
@@ -452,6 +495,56 @@ func TestReader(t *testing.T) {
 			})
 		}
 	})
+	t.Run("ForEach", func(t *testing.T) {
+		rdr, err := NewDocument(
+			EC.String("foo", "bar"),
+			EC.SubDocumentFromElements("baz", EC.Int32("qux", 1)),
+			EC.Null("bing"),
+		).MarshalBSON()
+		require.NoError(t, err)
+
+		var keys []string
+		err = Reader(rdr).ForEach(func(key []byte, val Value) error {
+			keys = append(keys, string(key))
+			switch string(key) {
+			case "foo":
+				require.Equal(t, "bar", val.StringValue())
+			case "baz":
+				elem, err := val.ReaderDocument().Lookup("qux")
+				require.NoError(t, err)
+				require.Equal(t, int32(1), elem.Value().Int32())
+			case "bing":
+				require.Equal(t, TypeNull, val.Type())
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo", "baz", "bing"}, keys)
+
+		errStop := errors.New("stop")
+		keys = keys[:0]
+		err = Reader(rdr).ForEach(func(key []byte, val Value) error {
+			keys = append(keys, string(key))
+			return errStop
+		})
+		require.Equal(t, errStop, err)
+		require.Equal(t, []string{"foo"}, keys)
+
+		t.Run("TooShort", func(t *testing.T) {
+			want := NewErrTooSmall()
+			got := Reader{'\x00', '\x00'}.ForEach(func([]byte, Value) error { return nil })
+			if !want.Equals(got) {
+				t.Errorf("Did not get expected error. got %v; want %v", got, want)
+			}
+		})
+		t.Run("Missing-Null-Terminator", func(t *testing.T) {
+			r := make(Reader, 9)
+			binary.LittleEndian.PutUint32(r[0:4], 9)
+			r[4], r[5], r[6], r[7], r[8] = '\x0A', 'f', 'o', 'o', '\x00'
+			got := r.ForEach(func([]byte, Value) error { return nil })
+			require.Equal(t, ErrInvalidReadOnlyDocument, got)
+		})
+	})
 	t.Run("NewFromIOReader", func(t *testing.T) {
 		testCases := []struct {
 			name       string
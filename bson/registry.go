@@ -42,28 +42,29 @@ type Registry struct {
 // NewRegistryBuilder creates a new RegistryBuilder.
 func NewRegistryBuilder() *RegistryBuilder {
 	types := map[reflect.Type]Codec{
-		tDocument:                     defaultDocumentCodec,
-		tArray:                        defaultArrayCodec,
-		tValue:                        defaultValueCodec,
-		reflect.PtrTo(tByteSlice):     defaultByteSliceCodec,
-		reflect.PtrTo(tElementSlice):  defaultElementSliceCodec,
-		reflect.PtrTo(tTime):          defaultTimeCodec,
-		reflect.PtrTo(tEmpty):         defaultEmptyInterfaceCodec,
-		reflect.PtrTo(tBinary):        defaultBinaryCodec,
-		reflect.PtrTo(tUndefined):     defaultUndefinedCodec,
-		reflect.PtrTo(tOID):           defaultObjectIDCodec,
-		reflect.PtrTo(tDateTime):      defaultDateTimeCodec,
-		reflect.PtrTo(tNull):          defaultNullCodec,
-		reflect.PtrTo(tRegex):         defaultRegexCodec,
-		reflect.PtrTo(tDBPointer):     defaultDBPointerCodec,
-		reflect.PtrTo(tCodeWithScope): defaultCodeWithScopeCodec,
-		reflect.PtrTo(tTimestamp):     defaultTimestampCodec,
-		reflect.PtrTo(tDecimal):       defaultDecimal128Codec,
-		reflect.PtrTo(tMinKey):        defaultMinKeyCodec,
-		reflect.PtrTo(tMaxKey):        defaultMaxKeyCodec,
-		reflect.PtrTo(tJSONNumber):    defaultJSONNumberCodec,
-		reflect.PtrTo(tURL):           defaultURLCodec,
-		reflect.PtrTo(tReader):        defaultReaderCodec,
+		tDocument:                      defaultDocumentCodec,
+		tArray:                         defaultArrayCodec,
+		tValue:                         defaultValueCodec,
+		reflect.PtrTo(tByteSlice):      defaultByteSliceCodec,
+		reflect.PtrTo(tElementSlice):   defaultElementSliceCodec,
+		reflect.PtrTo(tTime):           defaultTimeCodec,
+		reflect.PtrTo(tEmpty):          defaultEmptyInterfaceCodec,
+		reflect.PtrTo(tBinary):         defaultBinaryCodec,
+		reflect.PtrTo(tUndefined):      defaultUndefinedCodec,
+		reflect.PtrTo(tOID):            defaultObjectIDCodec,
+		reflect.PtrTo(tDateTime):       defaultDateTimeCodec,
+		reflect.PtrTo(tNull):           defaultNullCodec,
+		reflect.PtrTo(tRegex):          defaultRegexCodec,
+		reflect.PtrTo(tDBPointer):      defaultDBPointerCodec,
+		reflect.PtrTo(tCodeWithScope):  defaultCodeWithScopeCodec,
+		reflect.PtrTo(tTimestamp):      defaultTimestampCodec,
+		reflect.PtrTo(tDecimal):        defaultDecimal128Codec,
+		reflect.PtrTo(tMinKey):         defaultMinKeyCodec,
+		reflect.PtrTo(tMaxKey):         defaultMaxKeyCodec,
+		reflect.PtrTo(tJSONNumber):     defaultJSONNumberCodec,
+		reflect.PtrTo(tJSONRawMessage): defaultJSONRawMessageCodec,
+		reflect.PtrTo(tURL):            defaultURLCodec,
+		reflect.PtrTo(tReader):         defaultReaderCodec,
 	}
 	kinds := map[reflect.Kind]Codec{
 		reflect.Bool:    defaultBoolCodec,
@@ -128,6 +129,23 @@ func (rb *RegistryBuilder) Register(t reflect.Type, codec Codec) *RegistryBuilde
 	return rb
 }
 
+// SetStructTagParser makes the structs that have no codec of their own be encoded and decoded with a
+// StructCodec that uses p, such as JSONFallbackStructTagParser.
+func (rb *RegistryBuilder) SetStructTagParser(p StructTagParser) *RegistryBuilder {
+	sc, err := NewStructCodec(p)
+	if err != nil {
+		// p is nil, so keep the current struct codec
+		return rb
+	}
+	return rb.RegisterDefault(reflect.Struct, sc)
+}
+
+// DisallowNonStringMapKeys makes the maps that have no codec of their own fail to encode and decode
+// unless their keys are strings, instead of having their keys formatted as strings.
+func (rb *RegistryBuilder) DisallowNonStringMapKeys() *RegistryBuilder {
+	return rb.RegisterDefault(reflect.Map, &MapCodec{StringKeysOnly: true})
+}
+
 // RegisterDefault will register the provided Codec to the provided kind.
 func (rb *RegistryBuilder) RegisterDefault(kind reflect.Kind, codec Codec) *RegistryBuilder {
 	rb.kinds[kind] = codec
@@ -157,9 +175,10 @@ func (rb *RegistryBuilder) Build() *Registry {
 
 // Lookup will inspect the type registry for either the type or a pointer to the type,
 // if it doesn't find a codec it will inspect the interface registry for an interface
-// that the type satisfies, if it doesn't find a codec there it will attempt to
-// return either the default map codec or the default struct codec. If none of those
-// apply, an error will be returned.
+// that the type satisfies, if it doesn't find a codec there it will return a
+// MarshalerCodec if the type implements Marshaler, ValueMarshaler, Unmarshaler or
+// ValueUnmarshaler, or else the codec registered for its kind. If none of those apply,
+// an error will be returned.
 func (r *Registry) Lookup(t reflect.Type) (Codec, error) {
 	// We make this year so if we strip a pointer off it won't confuse user. If
 	// we did it where we return this and the user provided a pointer to the
@@ -187,16 +206,14 @@ func (r *Registry) Lookup(t reflect.Type) (Codec, error) {
 		return codec, nil
 	}
 
-	// We don't allow maps with non-string keys
-	if t.Kind() == reflect.Map && t.Key().Kind() != reflect.String {
-		return nil, ErrNoCodec{Type: t}
-	}
-
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
 
 	codec, found = r.kr.lookup(t.Kind())
+	if hasHooks(t) {
+		codec, found = &MarshalerCodec{fallback: codec}, true
+	}
 	if !found {
 		return nil, codecerr
 	}
@@ -232,8 +232,8 @@ func TestRegistry(t *testing.T) {
 				{
 					"map non-string key",
 					reflect.TypeOf(map[int]int{}),
+					fmc,
 					nil,
-					ErrNoCodec{Type: reflect.TypeOf(map[int]int{})},
 					false,
 				},
 				{
@@ -3,6 +3,7 @@ package bson
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 )
 
 var defaultSliceCodec = &SliceCodec{}
@@ -27,6 +28,12 @@ func (sc *SliceCodec) EncodeValue(ec EncodeContext, vw ValueWriter, i interface{
 
 	length := val.Len()
 
+	ec, err := ec.enter(val)
+	if err != nil {
+		return err
+	}
+	defer ec.leave(val)
+
 	aw, err := vw.WriteArray()
 	if err != nil {
 		return err
@@ -53,7 +60,7 @@ func (sc *SliceCodec) EncodeValue(ec EncodeContext, vw ValueWriter, i interface{
 
 		err = codec.EncodeValue(ec, vw, val.Index(idx).Interface())
 		if err != nil {
-			return err
+			return prefixPath(err, strconv.Itoa(idx))
 		}
 	}
 
@@ -91,6 +98,11 @@ func (sc *SliceCodec) DecodeValue(dc DecodeContext, vr ValueReader, i interface{
 
 	eType := val.Type().Elem().Elem()
 
+	dc, err := dc.enter()
+	if err != nil {
+		return err
+	}
+
 	ar, err := vr.ReadArray()
 	if err != nil {
 		return err
@@ -173,7 +185,7 @@ func (sc *SliceCodec) decodeDefault(dc DecodeContext, ar ArrayReader, eType refl
 
 		err = codec.DecodeValue(dc, vr, ptr.Interface())
 		if err != nil {
-			return nil, err
+			return nil, prefixPath(err, strconv.Itoa(len(elems)))
 		}
 		elems = append(elems, ptr.Elem())
 	}
@@ -54,6 +54,12 @@ func (sc *StructCodec) EncodeValue(r EncodeContext, vw ValueWriter, i interface{
 		return err
 	}
 
+	r, err = r.enter(val)
+	if err != nil {
+		return err
+	}
+	defer r.leave(val)
+
 	dw, err := vw.WriteDocument()
 	if err != nil {
 		return err
@@ -82,10 +88,11 @@ func (sc *StructCodec) EncodeValue(r EncodeContext, vw ValueWriter, i interface{
 			return err
 		}
 
-		ectx := EncodeContext{Registry: r.Registry, MinSize: desc.minSize}
+		ectx := r
+		ectx.MinSize = desc.minSize
 		err = codec.EncodeValue(ectx, vw2, rv.Interface())
 		if err != nil {
-			return err
+			return prefixPath(err, desc.name)
 		}
 	}
 
@@ -117,6 +124,11 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr ValueReader, i interface{
 		return err
 	}
 
+	r, err = r.enter()
+	if err != nil {
+		return err
+	}
+
 	var dFn decodeFn
 	var inlineMap reflect.Value
 	if sd.inlineMap >= 0 {
@@ -158,7 +170,7 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr ValueReader, i interface{
 
 			key, elem, err := dFn(r, vr, name)
 			if err != nil {
-				return err
+				return prefixPath(err, name)
 			}
 			inlineMap.SetMapIndex(reflect.ValueOf(key), elem)
 			continue
@@ -179,7 +191,8 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr ValueReader, i interface{
 		}
 		field = field.Addr()
 
-		dctx := DecodeContext{Registry: r.Registry, Truncate: fd.truncate}
+		dctx := r
+		dctx.Truncate = fd.truncate
 		if ec, ok := fd.codec.(*elementCodec); ok {
 			err = ec.decodeValue(dctx, vr, name, field.Interface().(**Element))
 			if err != nil {
@@ -190,8 +203,7 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr ValueReader, i interface{
 
 		err = fd.codec.DecodeValue(dctx, vr, field.Interface())
 		if err != nil {
-			fmt.Println(name)
-			return err
+			return prefixPath(err, name)
 		}
 	}
 
@@ -111,3 +111,42 @@ var DefaultStructTagParser StructTagParserFunc = func(sf reflect.StructField) (S
 
 	return st, nil
 }
+
+// JSONFallbackStructTagParser is a StructTagParser that handles the bson struct tag like the
+// DefaultStructTagParser, and falls back to the json struct tag for the fields that have no bson
+// tag, so that types annotated for encoding/json can be used as is. Only the name, the omitempty
+// flag and "-" are taken from json tags; the other json flags, such as string, are ignored.
+//
+// To use it, set it as the struct tag parser of a registry:
+//
+//     reg := bson.NewRegistryBuilder().SetStructTagParser(bson.JSONFallbackStructTagParser).Build()
+var JSONFallbackStructTagParser StructTagParserFunc = func(sf reflect.StructField) (StructTags, error) {
+	if _, ok := sf.Tag.Lookup("bson"); ok {
+		return DefaultStructTagParser(sf)
+	}
+	tag, ok := sf.Tag.Lookup("json")
+	if !ok {
+		return DefaultStructTagParser(sf)
+	}
+
+	var st StructTags
+	if tag == "-" {
+		st.Skip = true
+		return st, nil
+	}
+
+	st.Name = strings.ToLower(sf.Name)
+	for idx, str := range strings.Split(tag, ",") {
+		if idx == 0 {
+			if str != "" {
+				st.Name = str
+			}
+			continue
+		}
+		if str == "omitempty" {
+			st.OmitEmpty = true
+		}
+	}
+
+	return st, nil
+}
@@ -65,3 +65,52 @@ func TestDefaultStructTagParser(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONFallbackStructTagParser(t *testing.T) {
+	testCases := []struct {
+		name string
+		sf   reflect.StructField
+		want StructTags
+	}{
+		{
+			"json tag",
+			reflect.StructField{Name: "Foo", Tag: reflect.StructTag(`json:"bar,omitempty,string"`)},
+			StructTags{Name: "bar", OmitEmpty: true},
+		},
+		{
+			"json tag default name",
+			reflect.StructField{Name: "Foo", Tag: reflect.StructTag(`json:",omitempty"`)},
+			StructTags{Name: "foo", OmitEmpty: true},
+		},
+		{
+			"json tag dash",
+			reflect.StructField{Name: "Foo", Tag: reflect.StructTag(`json:"-"`)},
+			StructTags{Skip: true},
+		},
+		{
+			"json tag dash name",
+			reflect.StructField{Name: "Foo", Tag: reflect.StructTag(`json:"-,"`)},
+			StructTags{Name: "-"},
+		},
+		{
+			"bson tag takes precedence",
+			reflect.StructField{Name: "Foo", Tag: reflect.StructTag(`bson:"baz,minsize" json:"bar,omitempty"`)},
+			StructTags{Name: "baz", MinSize: true},
+		},
+		{
+			"no tags",
+			reflect.StructField{Name: "Foo", Tag: reflect.StructTag("")},
+			StructTags{Name: "foo"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := JSONFallbackStructTagParser(tc.sf)
+			noerr(t, err)
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Returned struct tags do not match. got %#v; want %#v", got, tc.want)
+			}
+		})
+	}
+}
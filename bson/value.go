@@ -661,6 +661,16 @@ func (v *Value) Regex() (pattern, options string) {
 	return string(v.data[pstart:pend]), string(v.data[ostart:oend])
 }
 
+// RegexOK is the same as Regex, except it returns a boolean instead of
+// panicking.
+func (v *Value) RegexOK() (pattern, options string, ok bool) {
+	if v == nil || v.offset == 0 || v.data == nil || Type(v.data[v.start]) != TypeRegex {
+		return "", "", false
+	}
+	pattern, options = v.Regex()
+	return pattern, options, true
+}
+
 // DateTimeOK is the same as DateTime, except it returns a boolean instead of
 // panicking.
 func (v *Value) DateTimeOK() (int64, bool) {
@@ -731,6 +741,15 @@ func (v *Value) Symbol() string {
 	return string(v.data[v.offset+4 : int32(v.offset)+4+l-1])
 }
 
+// SymbolOK is the same as Symbol, except it returns a boolean instead of
+// panicking.
+func (v *Value) SymbolOK() (string, bool) {
+	if v == nil || v.offset == 0 || v.data == nil || Type(v.data[v.start]) != TypeSymbol {
+		return "", false
+	}
+	return v.Symbol(), true
+}
+
 // ReaderJavaScriptWithScope returns the BSON JavaScript code with scope the Value represents, with
 // the scope being returned as a bson.Reader. It panics if the value is a BSON type other than
 // JavaScript code with scope.
@@ -903,6 +922,46 @@ func (v *Value) Decimal128OK() (decimal.Decimal128, bool) {
 	return v.Decimal128(), true
 }
 
+// AsInt64 returns the value of a BSON 32-bit integer, 64-bit integer or double as an int64. It
+// returns an ElementTypeError for values of other types, and an error for doubles that are not
+// integers or are out of the range of an int64. Use it to read numbers that servers and other
+// drivers may store with any numeric type.
+func (v *Value) AsInt64() (int64, error) {
+	if v == nil || v.offset == 0 || v.data == nil {
+		return 0, ErrUninitializedElement
+	}
+
+	switch v.Type() {
+	case TypeInt32:
+		return int64(v.Int32()), nil
+	case TypeInt64:
+		return v.Int64(), nil
+	case TypeDouble:
+		f := v.Double()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, fmt.Errorf("bson: double %v cannot be represented as an int64", f)
+		}
+		return int64(f), nil
+	}
+	return 0, ElementTypeError{"bson.Value.AsInt64", v.Type()}
+}
+
+// AsString returns the value of a BSON string or symbol. It returns an ElementTypeError for values
+// of other types.
+func (v *Value) AsString() (string, error) {
+	if v == nil || v.offset == 0 || v.data == nil {
+		return "", ErrUninitializedElement
+	}
+
+	switch v.Type() {
+	case TypeString:
+		return v.StringValue(), nil
+	case TypeSymbol:
+		return v.Symbol(), nil
+	}
+	return "", ElementTypeError{"bson.Value.AsString", v.Type()}
+}
+
 func (v *Value) asString() (string, error) {
 	var str string
 	var err error
@@ -1058,3 +1117,27 @@ func (v *Value) equal(v2 *Value) bool {
 
 	return bytes.Equal(v.data, v2.data)
 }
+
+// MarshalBSONValue implements the ValueMarshaler interface.
+func (v *Value) MarshalBSONValue() (Type, []byte, error) {
+	if v == nil || v.offset == 0 || v.data == nil {
+		return 0, nil, ErrUninitializedElement
+	}
+
+	b, err := (&Element{value: v}).MarshalBSON()
+	if err != nil {
+		return 0, nil, err
+	}
+	return v.Type(), b[v.offset-v.start:], nil
+}
+
+// UnmarshalBSONValue implements the ValueUnmarshaler interface. v is set to a copy of the value of
+// type t encoded as b.
+func (v *Value) UnmarshalBSONValue(t Type, b []byte) error {
+	nv, err := newValue(t, b)
+	if err != nil {
+		return err
+	}
+	*v = *nv
+	return nil
+}
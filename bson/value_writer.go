@@ -164,6 +164,7 @@ func (vw *valueWriter) reset(buf []byte) {
 	}
 	vw.stack = vw.stack[:1]
 	vw.stack[0] = vwState{mode: mTopLevel}
+	vw.frame = 0
 	vw.buf = buf
 }
 
@@ -9,18 +9,26 @@ package auth
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/mongodb/mongo-go-driver/core/address"
 	"github.com/mongodb/mongo-go-driver/core/command"
 	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/serverapi"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 )
 
-// AuthenticatorFactory constructs an authenticator.
+// AuthenticatorFactory constructs an authenticator for the credential of a connection string or of
+// the client options. The authMechanismProperties are in cred.Props.
 type AuthenticatorFactory func(cred *Cred) (Authenticator, error)
 
-var authFactories = make(map[string]AuthenticatorFactory)
+var (
+	authFactoriesMu sync.RWMutex
+	authFactories   = make(map[string]AuthenticatorFactory)
+)
 
 func init() {
 	RegisterAuthenticatorFactory("", newDefaultAuthenticator)
@@ -32,18 +40,50 @@ func init() {
 	RegisterAuthenticatorFactory(MongoDBX509, newMongoDBX509Authenticator)
 }
 
-// CreateAuthenticator creates an authenticator.
+// CreateAuthenticator creates an authenticator for the mechanism name using the factory registered
+// for it. Mechanism names are case insensitive.
 func CreateAuthenticator(name string, cred *Cred) (Authenticator, error) {
-	if f, ok := authFactories[name]; ok {
+	authFactoriesMu.RLock()
+	f, ok := authFactories[strings.ToUpper(name)]
+	authFactoriesMu.RUnlock()
+	if ok {
 		return f(cred)
 	}
 
 	return nil, newAuthError(fmt.Sprintf("unknown authenticator: %s", name), nil)
 }
 
-// RegisterAuthenticatorFactory registers the authenticator factory.
+// RegisterAuthenticatorFactory makes the mechanism name available to connection strings and client
+// options, which authenticate the connections of a client with the authenticators factory creates.
+// Mechanism names are case insensitive. If RegisterAuthenticatorFactory is called twice with the
+// same name or if factory is nil, it panics, so it should be called from an init function.
 func RegisterAuthenticatorFactory(name string, factory AuthenticatorFactory) {
-	authFactories[name] = factory
+	authFactoriesMu.Lock()
+	defer authFactoriesMu.Unlock()
+
+	if factory == nil {
+		panic("auth: RegisterAuthenticatorFactory factory is nil")
+	}
+	key := strings.ToUpper(name)
+	if _, dup := authFactories[key]; dup {
+		panic("auth: RegisterAuthenticatorFactory called twice for mechanism " + name)
+	}
+	authFactories[key] = factory
+}
+
+// Mechanisms returns the sorted names of the registered mechanisms.
+func Mechanisms() []string {
+	authFactoriesMu.RLock()
+	defer authFactoriesMu.RUnlock()
+
+	names := make([]string, 0, len(authFactories))
+	for name := range authFactories {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
 }
 
 // // Opener returns a connection opener that will open and authenticate the connection.
@@ -94,11 +134,15 @@ func RegisterAuthenticatorFactory(name string, factory AuthenticatorFactory) {
 // HandshakeOptions packages options that can be passed to the Handshaker()
 // function.  DBUser is optional but must be of the form <dbname.username>;
 // if non-empty, then the connection will do SASL mechanism negotiation.
+// Mechanism is the name of the mechanism Authenticator was created for, which
+// tags the instrumentation of the authentication.
 type HandshakeOptions struct {
 	AppName       string
 	Authenticator Authenticator
 	Compressors   []string
 	DBUser        string
+	Mechanism     string
+	ServerAPI     *serverapi.Options
 }
 
 // Handshaker creates a connection handshaker for the given authenticator.
@@ -108,13 +152,15 @@ func Handshaker(h connection.Handshaker, options *HandshakeOptions) connection.H
 			Client:             command.ClientDoc(options.AppName),
 			Compressors:        options.Compressors,
 			SaslSupportedMechs: options.DBUser,
+			ServerAPI:          options.ServerAPI,
 		}).Handshake(ctx, addr, rw)
 
 		if err != nil {
 			return description.Server{}, newAuthError("handshake failure", err)
 		}
 
-		err = options.Authenticator.Auth(ctx, desc, rw)
+		err = authenticate(ctx, options.Mechanism, options.Authenticator, addr,
+			description.SelectedServer{Server: desc, ServerAPI: options.ServerAPI}, rw)
 		if err != nil {
 			return description.Server{}, newAuthError("auth error", err)
 		}
@@ -127,8 +173,9 @@ func Handshaker(h connection.Handshaker, options *HandshakeOptions) connection.H
 
 // Authenticator handles authenticating a connection.
 type Authenticator interface {
-	// Auth authenticates the connection.
-	Auth(context.Context, description.Server, wiremessage.ReadWriter) error
+	// Auth authenticates the connection. Commands of the authentication conversation are sent with
+	// the server API of the given description.
+	Auth(context.Context, description.SelectedServer, wiremessage.ReadWriter) error
 }
 
 func newAuthError(msg string, inner error) error {
@@ -163,6 +210,11 @@ func (e *Error) Inner() error {
 	return e.inner
 }
 
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.inner
+}
+
 // Message returns the message.
 func (e *Error) Message() string {
 	return e.message
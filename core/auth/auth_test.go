@@ -7,12 +7,14 @@
 package auth_test
 
 import (
+	"context"
 	"testing"
 
 	"reflect"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	. "github.com/mongodb/mongo-go-driver/core/auth"
+	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 	"github.com/stretchr/testify/require"
 )
@@ -47,6 +49,42 @@ func TestCreateAuthenticator(t *testing.T) {
 	}
 }
 
+type proxyAuthenticator struct {
+	cred *Cred
+}
+
+func (*proxyAuthenticator) Auth(context.Context, description.SelectedServer, wiremessage.ReadWriter) error {
+	return nil
+}
+
+func init() {
+	RegisterAuthenticatorFactory("X-TEST-PROXY", func(cred *Cred) (Authenticator, error) {
+		return &proxyAuthenticator{cred: cred}, nil
+	})
+}
+
+func TestRegisterAuthenticatorFactory(t *testing.T) {
+	cred := &Cred{Source: "admin", Username: "user", Props: map[string]string{"TOKEN": "abc"}}
+	a, err := CreateAuthenticator("x-test-proxy", cred)
+	require.NoError(t, err)
+	require.IsType(t, &proxyAuthenticator{}, a)
+	require.Equal(t, cred, a.(*proxyAuthenticator).cred)
+	require.Contains(t, Mechanisms(), "X-TEST-PROXY")
+
+	require.Panics(t, func() {
+		RegisterAuthenticatorFactory("x-test-proxy", func(*Cred) (Authenticator, error) { return nil, nil })
+	})
+	require.Panics(t, func() { RegisterAuthenticatorFactory(SCRAMSHA1, newNoopAuthenticator) })
+	require.Panics(t, func() { RegisterAuthenticatorFactory("X-TEST-NIL", nil) })
+
+	_, err = CreateAuthenticator("X-TEST-UNKNOWN", cred)
+	require.EqualError(t, err, "unknown authenticator: X-TEST-UNKNOWN")
+}
+
+func newNoopAuthenticator(*Cred) (Authenticator, error) {
+	return &proxyAuthenticator{}, nil
+}
+
 func compareResponses(t *testing.T, wm wiremessage.WireMessage, expectedPayload *bson.Document, dbName string) {
 	switch converted := wm.(type) {
 	case wiremessage.Query:
@@ -11,8 +11,7 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 func newDefaultAuthenticator(cred *Cred) (Authenticator, error) {
@@ -28,14 +27,14 @@ type DefaultAuthenticator struct {
 }
 
 // Auth authenticates the connection.
-func (a *DefaultAuthenticator) Auth(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter) error {
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/auth.(*DefaultAuthenticator).Auth")
+func (a *DefaultAuthenticator) Auth(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) error {
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/auth.(*DefaultAuthenticator).Auth")
 	defer span.End()
 
 	var actual Authenticator
 	var err error
 
-	switch chooseAuthMechanism(desc) {
+	switch chooseAuthMechanism(desc.Server) {
 	case SCRAMSHA256:
 		actual, err = newScramSHA256Authenticator(a.Cred)
 	case SCRAMSHA1:
@@ -45,7 +44,7 @@ func (a *DefaultAuthenticator) Auth(ctx context.Context, desc description.Server
 	}
 
 	if err != nil {
-		span.SetStatus(trace.Status{Message: err.Error(), Code: int32(trace.StatusCodeInternal)})
+		span.SetStatus(observability.SpanStatus(err))
 		return newAuthError("error creating authenticator", err)
 	}
 
@@ -65,7 +64,7 @@ func chooseAuthMechanism(desc description.Server) string {
 		return SCRAMSHA1
 	}
 
-	if err := description.ScramSHA1Supported(desc.WireVersion); err == nil {
+	if desc.SupportsFeature(description.FeatureSCRAMSHA1) {
 		return SCRAMSHA1
 	}
 
@@ -42,7 +42,7 @@ type GSSAPIAuthenticator struct {
 }
 
 // Auth authenticates the connection.
-func (a *GSSAPIAuthenticator) Auth(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter) error {
+func (a *GSSAPIAuthenticator) Auth(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) error {
 	client, err := gssapi.New(desc.Addr.String(), a.Username, a.Password, a.PasswordSet, a.Props)
 
 	if err != nil {
@@ -0,0 +1,82 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+)
+
+// authenticate runs the authentication conversation of a over rw and instruments it, whatever
+// its mechanism: the conversation gets a span, and its duration and number of round trips are
+// recorded along with its failure, tagged with the mechanism and the address of the server.
+func authenticate(ctx context.Context, mechanism string, a Authenticator, addr address.Address,
+	desc description.SelectedServer, rw wiremessage.ReadWriter) error {
+
+	mechanism = mechanismName(mechanism, a, desc.Server)
+	ctx = observability.Tag(ctx,
+		tag.Upsert(observability.KeyAuthMechanism, mechanism),
+		tag.Upsert(observability.KeyServerAddress, addr.String()),
+	)
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/auth.authenticate")
+	defer span.End()
+
+	crw := &countingReadWriter{ReadWriter: rw}
+	start := time.Now()
+	err := a.Auth(ctx, desc, crw)
+
+	span.AddAttributes(
+		trace.StringAttribute("mechanism", mechanism),
+		trace.StringAttribute("address", addr.String()),
+		trace.Int64Attribute("round_trips", crw.writes),
+	)
+	observability.Record(ctx,
+		observability.MAuthLatencyMilliseconds.M(observability.SinceInMilliseconds(start)),
+		observability.MAuthRoundTrips.M(crw.writes),
+	)
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+		observability.Record(observability.Tag(ctx,
+			tag.Upsert(observability.KeyErrorCode, observability.ErrorCode(err)),
+			tag.Upsert(observability.KeyErrorCategory, observability.ErrorCategory(err)),
+		), observability.MAuthFailures.M(1))
+	}
+	return err
+}
+
+// mechanismName returns the name of the mechanism a authenticates with against the server desc,
+// given the name it was created for. The default authenticator picks its mechanism from the
+// server.
+func mechanismName(name string, a Authenticator, desc description.Server) string {
+	if _, ok := a.(*DefaultAuthenticator); ok {
+		return chooseAuthMechanism(desc)
+	}
+	if name == "" {
+		return "UNKNOWN"
+	}
+	return strings.ToUpper(name)
+}
+
+// countingReadWriter counts the commands written through it, each of which is a round trip of an
+// authentication conversation.
+type countingReadWriter struct {
+	wiremessage.ReadWriter
+	writes int64
+}
+
+func (rw *countingReadWriter) WriteWireMessage(ctx context.Context, wm wiremessage.WireMessage) error {
+	rw.writes++
+	return rw.ReadWriter.WriteWireMessage(ctx, wm)
+}
@@ -0,0 +1,94 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/address"
+	. "github.com/mongodb/mongo-go-driver/core/auth"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+func TestHandshakerInstrumentation(t *testing.T) {
+	roundTrips := &view.View{
+		Name:        "test/auth_round_trips",
+		Measure:     observability.MAuthRoundTrips,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{observability.KeyAuthMechanism},
+	}
+	failures := &view.View{
+		Name:        "test/auth_failures",
+		Measure:     observability.MAuthFailures,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{observability.KeyAuthMechanism, observability.KeyErrorCode},
+	}
+	require.NoError(t, view.Register(roundTrips, failures))
+	defer view.Unregister(roundTrips, failures)
+
+	// handshake authenticates with PLAIN, the server replying to saslStart with reply
+	handshake := func(reply *bson.Document) error {
+		resps := make(chan wiremessage.WireMessage, 2)
+		resps <- internal.MakeReply(t, bson.NewDocument(
+			bson.EC.Boolean("ismaster", true),
+			bson.EC.Int32("maxWireVersion", 6),
+			bson.EC.Int32("ok", 1),
+		))
+		resps <- internal.MakeReply(t, reply)
+		c := &internal.ChannelConn{Written: make(chan wiremessage.WireMessage, 2), ReadResp: resps}
+
+		h := Handshaker(nil, &HandshakeOptions{
+			Authenticator: &PlainAuthenticator{Username: "user", Password: "pencil"},
+			Mechanism:     "plain",
+		})
+		_, err := h.Handshake(context.Background(), address.Address("localhost:27017"), c)
+		return err
+	}
+	// rows returns the value of the rows of v by the tags they are recorded with.
+	rows := func(v *view.View) map[string]float64 {
+		data, err := view.RetrieveData(v.Name)
+		require.NoError(t, err)
+		values := make(map[string]float64)
+		for _, row := range data {
+			var key string
+			for _, tag := range row.Tags {
+				key += tag.Value + " "
+			}
+			switch d := row.Data.(type) {
+			case *view.SumData:
+				values[key] = d.Value
+			case *view.CountData:
+				values[key] = float64(d.Value)
+			}
+		}
+		return values
+	}
+
+	require.NoError(t, handshake(bson.NewDocument(
+		bson.EC.Int32("ok", 1),
+		bson.EC.Int32("conversationId", 1),
+		bson.EC.Binary("payload", []byte{}),
+		bson.EC.Boolean("done", true),
+	)))
+	require.Equal(t, map[string]float64{"PLAIN ": 1}, rows(roundTrips))
+	require.Empty(t, rows(failures))
+
+	require.Error(t, handshake(bson.NewDocument(
+		bson.EC.Int32("ok", 0),
+		bson.EC.Int32("code", 18),
+		bson.EC.String("errmsg", "Authentication failed."),
+	)))
+	require.Equal(t, map[string]float64{"PLAIN ": 2}, rows(roundTrips))
+	require.Equal(t, map[string]float64{"PLAIN 18 ": 1}, rows(failures))
+}
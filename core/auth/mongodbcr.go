@@ -18,7 +18,6 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 
 	"github.com/mongodb/mongo-go-driver/internal/observability"
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 )
@@ -48,13 +47,13 @@ type MongoDBCRAuthenticator struct {
 // Auth authenticates the connection.
 //
 // The MONGODB-CR authentication mechanism is deprecated in MongoDB 4.0.
-func (a *MongoDBCRAuthenticator) Auth(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter) error {
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "mongodbcr_auth"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/auth.(*MongoDBCRAuthenticator).Auth")
+func (a *MongoDBCRAuthenticator) Auth(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) error {
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "mongodbcr_auth"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/auth.(*MongoDBCRAuthenticator).Auth")
 	defer span.End()
 
 	// Arbiters cannot be authenticated
-	if desc.Kind == description.RSArbiter {
+	if desc.Server.Kind == description.RSArbiter {
 		span.Annotatef([]trace.Attribute{
 			trace.StringAttribute("arbiter_type", "RSA"),
 		}, "Arbiters cannot be authenticated")
@@ -67,14 +66,12 @@ func (a *MongoDBCRAuthenticator) Auth(ctx context.Context, desc description.Serv
 	}
 
 	cmd := command.Read{DB: db, Command: bson.NewDocument(bson.EC.Int32("getnonce", 1))}
-	ssdesc := description.SelectedServer{Server: desc}
 	span.Annotatef(nil, "Invoking cmd.RoundTrip")
-	rdr, err := cmd.RoundTrip(ctx, ssdesc, rw)
+	rdr, err := cmd.RoundTrip(ctx, desc, rw)
 	span.Annotatef(nil, "Finished invoking cmd.RoundTrip")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "roundtrip"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "roundtrip", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return newError(err, MONGODBCR)
 	}
 
@@ -84,9 +81,8 @@ func (a *MongoDBCRAuthenticator) Auth(ctx context.Context, desc description.Serv
 
 	err = bson.Unmarshal(rdr, &getNonceResult)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "unmarshal"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "unmarshal", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return newAuthError("unmarshal error", err)
 	}
 
@@ -100,12 +96,11 @@ func (a *MongoDBCRAuthenticator) Auth(ctx context.Context, desc description.Serv
 		),
 	}
 	span.Annotatef(nil, "Invoking cmd.RoundTrip")
-	_, err = cmd.RoundTrip(ctx, ssdesc, rw)
+	_, err = cmd.RoundTrip(ctx, desc, rw)
 	span.Annotatef(nil, "Finished invoking cmd.RoundTrip")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "roundtrip"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "roundtrip", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return newError(err, MONGODBCR)
 	}
 
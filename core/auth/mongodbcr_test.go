@@ -38,11 +38,11 @@ func TestMongoDBCRAuthenticator_Fails(t *testing.T) {
 
 	c := &internal.ChannelConn{Written: make(chan wiremessage.WireMessage, 2), ReadResp: resps}
 
-	err := authenticator.Auth(context.Background(), description.Server{
+	err := authenticator.Auth(context.Background(), description.SelectedServer{Server: description.Server{
 		WireVersion: &description.VersionRange{
 			Max: 6,
 		},
-	}, c)
+	}}, c)
 	if err == nil {
 		t.Fatalf("expected an error but got none")
 	}
@@ -73,11 +73,11 @@ func TestMongoDBCRAuthenticator_Succeeds(t *testing.T) {
 
 	c := &internal.ChannelConn{Written: make(chan wiremessage.WireMessage, 2), ReadResp: resps}
 
-	err := authenticator.Auth(context.Background(), description.Server{
+	err := authenticator.Auth(context.Background(), description.SelectedServer{Server: description.Server{
 		WireVersion: &description.VersionRange{
 			Max: 6,
 		},
-	}, c)
+	}}, c)
 	if err != nil {
 		t.Fatalf("expected no error but got \"%s\"", err)
 	}
@@ -13,9 +13,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 
 	"github.com/mongodb/mongo-go-driver/internal/observability"
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
-	"go.opencensus.io/trace"
 )
 
 // PLAIN is the mechanism name for PLAIN.
@@ -35,9 +33,9 @@ type PlainAuthenticator struct {
 }
 
 // Auth authenticates the connection.
-func (a *PlainAuthenticator) Auth(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter) error {
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "plain_auth"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/auth/(*PlainAuthenticator).Auth")
+func (a *PlainAuthenticator) Auth(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) error {
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "plain_auth"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/auth/(*PlainAuthenticator).Auth")
 	defer span.End()
 
 	err := ConductSaslConversation(ctx, desc, rw, "$external", &plainSaslClient{
@@ -45,9 +43,8 @@ func (a *PlainAuthenticator) Auth(ctx context.Context, desc description.Server,
 		password: a.Password,
 	})
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "sasl_conversation"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "sasl_conversation", err)
+		span.SetStatus(observability.SpanStatus(err))
 	}
 	return err
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/bson"
 	. "github.com/mongodb/mongo-go-driver/core/auth"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/serverapi"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 	"github.com/mongodb/mongo-go-driver/internal"
 )
@@ -39,11 +40,11 @@ func TestPlainAuthenticator_Fails(t *testing.T) {
 
 	c := &internal.ChannelConn{Written: make(chan wiremessage.WireMessage, 1), ReadResp: resps}
 
-	err := authenticator.Auth(context.Background(), description.Server{
+	err := authenticator.Auth(context.Background(), description.SelectedServer{Server: description.Server{
 		WireVersion: &description.VersionRange{
 			Max: 6,
 		},
-	}, c)
+	}}, c)
 	if err == nil {
 		t.Fatalf("expected an error but got none")
 	}
@@ -78,11 +79,11 @@ func TestPlainAuthenticator_Extra_server_message(t *testing.T) {
 
 	c := &internal.ChannelConn{Written: make(chan wiremessage.WireMessage, 1), ReadResp: resps}
 
-	err := authenticator.Auth(context.Background(), description.Server{
+	err := authenticator.Auth(context.Background(), description.SelectedServer{Server: description.Server{
 		WireVersion: &description.VersionRange{
 			Max: 6,
 		},
-	}, c)
+	}}, c)
 	if err == nil {
 		t.Fatalf("expected an error but got none")
 	}
@@ -111,11 +112,11 @@ func TestPlainAuthenticator_Succeeds(t *testing.T) {
 
 	c := &internal.ChannelConn{Written: make(chan wiremessage.WireMessage, 1), ReadResp: resps}
 
-	err := authenticator.Auth(context.Background(), description.Server{
+	err := authenticator.Auth(context.Background(), description.SelectedServer{Server: description.Server{
 		WireVersion: &description.VersionRange{
 			Max: 6,
 		},
-	}, c)
+	}}, c)
 	if err != nil {
 		t.Fatalf("expected no error but got \"%s\"", err)
 	}
@@ -132,3 +133,44 @@ func TestPlainAuthenticator_Succeeds(t *testing.T) {
 	)
 	compareResponses(t, <-c.Written, expectedCmd, "$external")
 }
+
+func TestPlainAuthenticator_ServerAPI(t *testing.T) {
+	t.Parallel()
+
+	authenticator := PlainAuthenticator{
+		Username: "user",
+		Password: "pencil",
+	}
+
+	resps := make(chan wiremessage.WireMessage, 1)
+	resps <- internal.MakeReply(t, bson.NewDocument(
+		bson.EC.Int32("ok", 1),
+		bson.EC.Int32("conversationId", 1),
+		bson.EC.Binary("payload", []byte{}),
+		bson.EC.Boolean("done", true)),
+	)
+
+	c := &internal.ChannelConn{Written: make(chan wiremessage.WireMessage, 1), ReadResp: resps}
+
+	err := authenticator.Auth(context.Background(), description.SelectedServer{
+		Server: description.Server{
+			WireVersion: &description.VersionRange{
+				Max: 6,
+			},
+		},
+		ServerAPI: serverapi.New(serverapi.Version1, serverapi.Strict(true)),
+	}, c)
+	if err != nil {
+		t.Fatalf("expected no error but got \"%s\"", err)
+	}
+
+	payload, _ := base64.StdEncoding.DecodeString("AHVzZXIAcGVuY2ls")
+	expectedCmd := bson.NewDocument(
+		bson.EC.Int32("saslStart", 1),
+		bson.EC.String("mechanism", "PLAIN"),
+		bson.EC.Binary("payload", payload),
+		bson.EC.String("apiVersion", "1"),
+		bson.EC.Boolean("apiStrict", true),
+	)
+	compareResponses(t, <-c.Written, expectedCmd, "$external")
+}
@@ -0,0 +1,202 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/address"
+	. "github.com/mongodb/mongo-go-driver/core/auth"
+	"github.com/mongodb/mongo-go-driver/core/connection"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/event"
+	"github.com/mongodb/mongo-go-driver/core/logger"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+	"github.com/xdg/scram"
+)
+
+func TestAuthRedaction(t *testing.T) {
+	const username, password = "user", "pencil-7f3a9c"
+
+	d := mongotest.New()
+	defer d.Close()
+
+	// scramHandlers answer the SASL conversation of a SCRAM mechanism like a server knowing the
+	// credentials of the user.
+	scramHandlers := func(hg scram.HashGeneratorFcn, pass string) {
+		client, err := hg.NewClientUnprepped(username, pass, "")
+		require.NoError(t, err)
+		kf := scram.KeyFactors{Salt: "salt-for-tests", Iters: 4096}
+		server, err := hg.NewServer(func(string) (scram.StoredCredentials, error) {
+			return client.GetStoredCredentials(kf), nil
+		})
+		require.NoError(t, err)
+
+		var conv *scram.ServerConversation
+		step := func(cmd *mongotest.Command) mongotest.Response {
+			_, payload := cmd.Document.Lookup("payload").Binary()
+			resp, err := conv.Step(string(payload))
+			if err != nil {
+				return mongotest.Error(18, "AuthenticationFailed", err.Error())(cmd)
+			}
+			return mongotest.OK(
+				bson.EC.Int32("conversationId", 1),
+				bson.EC.Boolean("done", conv.Done()),
+				bson.EC.Binary("payload", []byte(resp)),
+			)(cmd)
+		}
+		d.Handle("saslStart", func(cmd *mongotest.Command) mongotest.Response {
+			conv = server.NewConversation()
+			return step(cmd)
+		})
+		d.Handle("saslContinue", step)
+	}
+
+	testCases := []struct {
+		mechanism string
+		handle    func()
+		commands  []string
+	}{
+		{
+			PLAIN,
+			func() {
+				d.Handle("saslStart", mongotest.OK(
+					bson.EC.Int32("conversationId", 1),
+					bson.EC.Boolean("done", true),
+					bson.EC.Binary("payload", []byte{}),
+				))
+			},
+			[]string{"saslStart"},
+		},
+		{
+			SCRAMSHA1,
+			func() {
+				digest := fmt.Sprintf("%x", md5.Sum([]byte(username+":mongo:"+password)))
+				scramHandlers(scram.SHA1, digest)
+			},
+			[]string{"saslStart", "saslContinue"},
+		},
+		{
+			SCRAMSHA256,
+			func() { scramHandlers(scram.SHA256, password) },
+			[]string{"saslStart", "saslContinue"},
+		},
+		{
+			MONGODBCR,
+			func() {
+				d.Handle("getnonce", mongotest.OK(bson.EC.String("nonce", "2375531c32080ae8")))
+				d.Handle("authenticate", mongotest.OK())
+			},
+			[]string{"getnonce", "authenticate"},
+		},
+		{
+			MongoDBX509,
+			func() { d.Handle("authenticate", mongotest.OK()) },
+			[]string{"authenticate"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.mechanism, func(t *testing.T) {
+			tc.handle()
+
+			var mu sync.Mutex
+			var names, emitted []string
+			var documents []*bson.Document
+			monitor := &event.CommandMonitor{
+				Started: func(_ context.Context, e *event.CommandStartedEvent) {
+					mu.Lock()
+					defer mu.Unlock()
+					names = append(names, e.CommandName)
+					documents = append(documents, e.Command)
+					emitted = append(emitted, e.Command.ToExtJSON(false))
+				},
+				Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+					mu.Lock()
+					defer mu.Unlock()
+					documents = append(documents, e.Reply)
+					emitted = append(emitted, e.Reply.ToExtJSON(false))
+				},
+				Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+					mu.Lock()
+					defer mu.Unlock()
+					emitted = append(emitted, e.Failure)
+				},
+			}
+			logs := &bufferLogger{}
+
+			conn, _, err := connection.New(context.Background(), address.Address(d.Address()),
+				connection.WithDialer(func(connection.Dialer) connection.Dialer {
+					return connection.DialerFunc(d.DialContext)
+				}),
+				connection.WithMonitor(func(*event.CommandMonitor) *event.CommandMonitor { return monitor }),
+				connection.WithLogger(func(logger.Logger) logger.Logger { return logs }),
+			)
+			require.NoError(t, err)
+			defer func() { _ = conn.Close() }()
+
+			authenticator, err := CreateAuthenticator(tc.mechanism, &Cred{
+				Source:   "admin",
+				Username: username,
+				Password: password,
+			})
+			require.NoError(t, err)
+			desc := description.SelectedServer{
+				Server: description.Server{WireVersion: &description.VersionRange{Max: 6}},
+			}
+			require.NoError(t, authenticator.Auth(context.Background(), desc, conn))
+
+			mu.Lock()
+			defer mu.Unlock()
+			require.Equal(t, tc.commands, names)
+			for _, e := range emitted {
+				require.NotContains(t, e, password)
+			}
+			// binary payloads are base64 encoded in extended JSON, so the documents must be empty
+			for _, doc := range documents {
+				require.Equal(t, 0, doc.Len())
+			}
+			for _, name := range tc.commands {
+				require.Contains(t, logs.String(), name)
+			}
+			require.False(t, strings.Contains(logs.String(), password), "password logged: %s", logs.String())
+		})
+	}
+}
+
+// bufferLogger is a logger.Logger that writes every message and its keys and values to a buffer.
+type bufferLogger struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (*bufferLogger) Enabled(logger.Level, logger.Component) bool { return true }
+
+func (l *bufferLogger) Debug(msg string, keysAndValues ...interface{}) { l.log(msg, keysAndValues) }
+func (l *bufferLogger) Info(msg string, keysAndValues ...interface{})  { l.log(msg, keysAndValues) }
+func (l *bufferLogger) Warn(msg string, keysAndValues ...interface{})  { l.log(msg, keysAndValues) }
+func (l *bufferLogger) Error(msg string, keysAndValues ...interface{}) { l.log(msg, keysAndValues) }
+
+func (l *bufferLogger) log(msg string, keysAndValues []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintln(&l.buf, append([]interface{}{msg}, keysAndValues...)...)
+}
+
+func (l *bufferLogger) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buf.String()
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 
 	"github.com/mongodb/mongo-go-driver/internal/observability"
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 )
@@ -34,13 +33,13 @@ type SaslClientCloser interface {
 }
 
 // ConductSaslConversation handles running a sasl conversation with MongoDB.
-func ConductSaslConversation(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter, db string, client SaslClient) error {
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "conduct_sasl_conversation"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/auth.ConductSaslConversation")
+func ConductSaslConversation(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter, db string, client SaslClient) error {
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "conduct_sasl_conversation"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/auth.ConductSaslConversation")
 	defer span.End()
 
 	// Arbiters cannot be authenticated
-	if desc.Kind == description.RSArbiter {
+	if desc.Server.Kind == description.RSArbiter {
 		span.Annotatef([]trace.Attribute{
 			trace.StringAttribute("arbiter_type", "RSA"),
 		}, "Arbiters cannot be authenticated")
@@ -57,9 +56,8 @@ func ConductSaslConversation(ctx context.Context, desc description.Server, rw wi
 
 	mech, payload, err := client.Start()
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "client_start"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "client_start", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return newError(err, mech)
 	}
 
@@ -81,22 +79,19 @@ func ConductSaslConversation(ctx context.Context, desc description.Server, rw wi
 
 	var saslResp saslResponse
 
-	ssdesc := description.SelectedServer{Server: desc}
 	span.Annotatef(nil, "Invoking saslStartCmd.RoundTrip")
-	rdr, err := saslStartCmd.RoundTrip(ctx, ssdesc, rw)
+	rdr, err := saslStartCmd.RoundTrip(ctx, desc, rw)
 	span.Annotatef(nil, "Finished invoking saslStartCmd.RoundTrip")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "saslstartcmd_roundtrip"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "saslstartcmd_roundtrip", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return newError(err, mech)
 	}
 
 	err = bson.Unmarshal(rdr, &saslResp)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "unmarshal"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "unmarshal", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return newAuthError("unmarshall error", err)
 	}
 
@@ -104,9 +99,8 @@ func ConductSaslConversation(ctx context.Context, desc description.Server, rw wi
 
 	for {
 		if saslResp.Code != 0 {
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "auth"))
-			stats.Record(ctx, observability.MErrors.M(1))
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: "Invalid saslResponse"})
+			observability.RecordError(ctx, "auth", command.Error{Code: int32(saslResp.Code), Message: "invalid saslResponse"})
+			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeUnauthenticated), Message: "Invalid saslResponse"})
 			return newError(err, mech)
 		}
 
@@ -116,9 +110,8 @@ func ConductSaslConversation(ctx context.Context, desc description.Server, rw wi
 
 		payload, err = client.Next(saslResp.Payload)
 		if err != nil {
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "client_next"))
-			stats.Record(ctx, observability.MErrors.M(1))
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			observability.RecordError(ctx, "client_next", err)
+			span.SetStatus(observability.SpanStatus(err))
 			return newError(err, mech)
 		}
 
@@ -136,20 +129,18 @@ func ConductSaslConversation(ctx context.Context, desc description.Server, rw wi
 		}
 
 		span.Annotatef(nil, "Invoking saslContinueCmd.RoundTrip")
-		rdr, err = saslContinueCmd.RoundTrip(ctx, ssdesc, rw)
+		rdr, err = saslContinueCmd.RoundTrip(ctx, desc, rw)
 		span.Annotatef(nil, "Finished invoking saslContinueCmd.RoundTrip")
 		if err != nil {
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "saslcontinuecmd_roundtrip"))
-			stats.Record(ctx, observability.MErrors.M(1))
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			observability.RecordError(ctx, "saslcontinuecmd_roundtrip", err)
+			span.SetStatus(observability.SpanStatus(err))
 			return newError(err, mech)
 		}
 
 		err = bson.Unmarshal(rdr, &saslResp)
 		if err != nil {
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "unmarshal"))
-			stats.Record(ctx, observability.MErrors.M(1))
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			observability.RecordError(ctx, "unmarshal", err)
+			span.SetStatus(observability.SpanStatus(err))
 			return newAuthError("unmarshal error", err)
 		}
 	}
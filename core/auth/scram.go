@@ -18,8 +18,10 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 	"github.com/xdg/scram"
 	"github.com/xdg/stringprep"
+	"go.opencensus.io/tag"
 )
 
 // SCRAMSHA1 holds the mechanism name "SCRAM-SHA-1"
@@ -67,10 +69,16 @@ type ScramAuthenticator struct {
 }
 
 // Auth authenticates the connection.
-func (a *ScramAuthenticator) Auth(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter) error {
+func (a *ScramAuthenticator) Auth(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) error {
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "scram_auth"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/auth.(*ScramAuthenticator).Auth")
+	defer span.End()
+
 	adapter := &scramSaslAdapter{conversation: a.client.NewConversation(), mechanism: a.mechanism}
 	err := ConductSaslConversation(ctx, desc, rw, a.source, adapter)
 	if err != nil {
+		observability.RecordError(ctx, "sasl_conversation", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return newAuthError("sasl conversation error", err)
 	}
 	return nil
@@ -21,3 +21,10 @@ func mongoPasswordDigest(username, password string) string {
 	_, _ = io.WriteString(h, password)
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
+
+// PasswordDigest returns the digest of the password of a user that its SCRAM-SHA-1 credentials are
+// derived from, which is what servers older than MongoDB 4.0 expect user management commands to
+// send instead of the password.
+func PasswordDigest(username, password string) string {
+	return mongoPasswordDigest(username, password)
+}
@@ -15,9 +15,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 
 	"github.com/mongodb/mongo-go-driver/internal/observability"
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
-	"go.opencensus.io/trace"
 )
 
 // MongoDBX509 is the mechanism name for MongoDBX509.
@@ -33,9 +31,9 @@ type MongoDBX509Authenticator struct {
 }
 
 // Auth implements the Authenticator interface.
-func (a *MongoDBX509Authenticator) Auth(ctx context.Context, desc description.Server, rw wiremessage.ReadWriter) error {
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "mongodbx509_auth"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/auth.(*MongoDBX509Authenticator).Auth")
+func (a *MongoDBX509Authenticator) Auth(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) error {
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "mongodbx509_auth"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/auth.(*MongoDBX509Authenticator).Auth")
 	defer span.End()
 
 	authRequestDoc := bson.NewDocument(
@@ -48,17 +46,12 @@ func (a *MongoDBX509Authenticator) Auth(ctx context.Context, desc description.Se
 	}
 
 	authCmd := command.Read{DB: "$external", Command: authRequestDoc}
-	ssdesc := description.SelectedServer{Server: desc}
 	span.Annotatef(nil, "Invoking authCmd.RoundTrip")
-	_, err := authCmd.RoundTrip(ctx, ssdesc, rw)
+	_, err := authCmd.RoundTrip(ctx, desc, rw)
 	span.Annotatef(nil, "Finished invoking authCmd.RoundTrip")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "authcmd_roundtrip"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{
-			Code:    int32(trace.StatusCodeInternal),
-			Message: err.Error(),
-		})
+		observability.RecordError(ctx, "authcmd_roundtrip", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return newAuthError("round trip error", err)
 	}
 
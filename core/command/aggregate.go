@@ -21,7 +21,8 @@ import (
 
 // Aggregate represents the aggregate command.
 //
-// The aggregate command performs an aggregation.
+// The aggregate command performs an aggregation. If the collection of NS is empty, the aggregation
+// runs against the database, as needed by stages such as $currentOp and $listLocalSessions.
 type Aggregate struct {
 	NS           Namespace
 	Pipeline     *bson.Array
@@ -47,19 +48,40 @@ func (a *Aggregate) Encode(desc description.SelectedServer) (wiremessage.WireMes
 }
 
 func (a *Aggregate) encode(desc description.SelectedServer) (*Read, error) {
-	if err := a.NS.Validate(); err != nil {
+	command := bson.NewDocument()
+	if a.NS.Collection == "" {
+		// database aggregations are run with the integer 1 instead of a collection name
+		if err := a.NS.validateDB(); err != nil {
+			return nil, err
+		}
+		command.Append(bson.EC.Int32("aggregate", 1))
+	} else {
+		if err := a.NS.Validate(); err != nil {
+			return nil, err
+		}
+		command.Append(bson.EC.String("aggregate", a.NS.Collection))
+	}
+	if err := a.validateOutputStage(); err != nil {
 		return nil, err
 	}
-
-	command := bson.NewDocument()
-	command.Append(bson.EC.String("aggregate", a.NS.Collection), bson.EC.Array("pipeline", a.Pipeline))
+	command.Append(bson.EC.Array("pipeline", a.Pipeline))
 
 	cursor := bson.NewDocument()
 	command.Append(bson.EC.SubDocument("cursor", cursor))
 
 	for _, opt := range a.Opts {
+		if err := optionSupported(desc, opt); err != nil {
+			return nil, err
+		}
+
 		switch t := opt.(type) {
-		case nil, option.OptMaxAwaitTime:
+		case nil:
+			continue
+		case option.OptMaxAwaitTime:
+			// the option is sent with the getMores of the tailable cursor of a change stream
+			if !a.isChangeStream() {
+				return nil, ErrMaxAwaitTimeNotTailable
+			}
 			continue
 		case option.OptBatchSize:
 			if t == 0 && a.HasDollarOut() {
@@ -69,6 +91,10 @@ func (a *Aggregate) encode(desc description.SelectedServer) (*Read, error) {
 			if err != nil {
 				return nil, err
 			}
+		case option.OptComment:
+			if err := appendComment(desc, command, t); err != nil {
+				return nil, err
+			}
 		default:
 			err := opt.Option(command)
 			if err != nil {
@@ -78,13 +104,9 @@ func (a *Aggregate) encode(desc description.SelectedServer) (*Read, error) {
 	}
 
 	// add write concern because it won't be added by the Read command's Encode()
-	if a.WriteConcern != nil {
-		element, err := a.WriteConcern.MarshalBSONElement()
-		if err != nil {
-			return nil, err
-		}
-
-		command.Append(element)
+	err := addWriteConcern(command, a.WriteConcern)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Read{
@@ -97,29 +119,133 @@ func (a *Aggregate) encode(desc description.SelectedServer) (*Read, error) {
 	}, nil
 }
 
-// HasDollarOut returns true if the Pipeline field contains a $out stage.
-func (a *Aggregate) HasDollarOut() bool {
-	if a.Pipeline == nil {
+// isChangeStream returns true if the Pipeline field starts with a $changeStream stage.
+func (a *Aggregate) isChangeStream() bool {
+	if a.Pipeline == nil || a.Pipeline.Len() == 0 {
 		return false
 	}
-	if a.Pipeline.Len() == 0 {
+
+	val, err := a.Pipeline.Lookup(0)
+	if err != nil {
 		return false
 	}
 
+	if doc, ok := val.MutableDocumentOK(); ok {
+		elem, ok := doc.ElementAtOK(0)
+		return ok && elem.Key() == "$changeStream"
+	}
+	if rdr, ok := val.ReaderDocumentOK(); ok {
+		elem, err := rdr.ElementAt(0)
+		return err == nil && elem.Key() == "$changeStream"
+	}
+	return false
+}
+
+// HasDollarOut returns true if the Pipeline field ends with a $out or a $merge stage, which write
+// the results to a collection, whether the stage names the collection with a string or with a
+// document holding its database and name.
+func (a *Aggregate) HasDollarOut() bool {
+	_, _, ok := a.outputStage()
+	return ok
+}
+
+// HasDollarMerge returns true if the Pipeline field ends with a $merge stage.
+func (a *Aggregate) HasDollarMerge() bool {
+	name, _, ok := a.outputStage()
+	return ok && name == "$merge"
+}
+
+// outputStage returns the name and the value of the last stage of the Pipeline field if it is a
+// $out or a $merge stage. Stages can be mutable or raw documents.
+func (a *Aggregate) outputStage() (string, *bson.Value, bool) {
+	if a.Pipeline == nil || a.Pipeline.Len() == 0 {
+		return "", nil, false
+	}
+
 	val, err := a.Pipeline.Lookup(uint(a.Pipeline.Len() - 1))
 	if err != nil {
-		return false
+		return "", nil, false
 	}
 
-	doc, ok := val.MutableDocumentOK()
-	if !ok || doc.Len() != 1 {
-		return false
+	var elem *bson.Element
+	if doc, ok := val.MutableDocumentOK(); ok {
+		if doc.Len() != 1 {
+			return "", nil, false
+		}
+		elem, _ = doc.ElementAtOK(0)
+	} else if rdr, ok := val.ReaderDocumentOK(); ok {
+		if _, err = rdr.ElementAt(1); err == nil {
+			return "", nil, false
+		}
+		elem, _ = rdr.ElementAt(0)
 	}
-	elem, ok := doc.ElementAtOK(0)
+	if elem == nil || (elem.Key() != "$out" && elem.Key() != "$merge") {
+		return "", nil, false
+	}
+	return elem.Key(), elem.Value(), true
+}
+
+// validateOutputStage returns ErrInvalidOutputStage if the Pipeline field ends with a $out or a
+// $merge stage that does not name its target collection properly.
+func (a *Aggregate) validateOutputStage() error {
+	name, val, ok := a.outputStage()
 	if !ok {
+		return nil
+	}
+
+	target := val
+	if name == "$merge" {
+		// the target of $merge is either the stage value itself or its into field
+		if _, isString := val.StringValueOK(); !isString {
+			target = lookupValue(val, "into")
+		}
+	}
+	if !validOutputTarget(target) {
+		return ErrInvalidOutputStage
+	}
+	return nil
+}
+
+// validOutputTarget returns true if v names a collection of the database of the aggregation with a
+// non-empty string, or a collection of any database with a document holding non-empty db and coll
+// strings.
+func validOutputTarget(v *bson.Value) bool {
+	if v == nil {
 		return false
 	}
-	return elem.Key() == "$out"
+	if coll, ok := v.StringValueOK(); ok {
+		return coll != ""
+	}
+	for _, key := range []string{"db", "coll"} {
+		field := lookupValue(v, key)
+		if field == nil {
+			return false
+		}
+		if s, ok := field.StringValueOK(); !ok || s == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupValue returns the value of the key field of v if v is a mutable or a raw document that has
+// the field, or else nil.
+func lookupValue(v *bson.Value, key string) *bson.Value {
+	if doc, ok := v.MutableDocumentOK(); ok {
+		field, err := doc.LookupErr(key)
+		if err != nil {
+			return nil
+		}
+		return field
+	}
+	if rdr, ok := v.ReaderDocumentOK(); ok {
+		elem, err := rdr.Lookup(key)
+		if err != nil {
+			return nil
+		}
+		return elem.Value()
+	}
+	return nil
 }
 
 // Decode will decode the wire message using the provided server description. Errors during decoding
@@ -131,10 +257,10 @@ func (a *Aggregate) Decode(desc description.SelectedServer, cb CursorBuilder, wm
 		return a
 	}
 
-	return a.decode(desc, cb, rdr)
+	return a.decode(context.Background(), desc, cb, rdr)
 }
 
-func (a *Aggregate) decode(desc description.SelectedServer, cb CursorBuilder, rdr bson.Reader) *Aggregate {
+func (a *Aggregate) decode(ctx context.Context, desc description.SelectedServer, cb CursorBuilder, rdr bson.Reader) *Aggregate {
 	opts := make([]option.CursorOptioner, 0)
 	for _, opt := range a.Opts {
 		curOpt, ok := opt.(option.CursorOptioner)
@@ -147,10 +273,10 @@ func (a *Aggregate) decode(desc description.SelectedServer, cb CursorBuilder, rd
 	labels, err := getErrorLabels(&rdr)
 	a.err = err
 
-	res, err := cb.BuildCursor(rdr, a.Session, a.Clock, opts...)
+	res, err := cb.BuildCursor(ctx, rdr, a.Session, a.Clock, opts...)
 	a.result = res
 	if err != nil {
-		a.err = Error{Message: err.Error(), Labels: labels}
+		a.err = Error{Message: err.Error(), Labels: labels, Wrapped: err}
 	}
 	return a
 }
@@ -178,5 +304,5 @@ func (a *Aggregate) RoundTrip(ctx context.Context, desc description.SelectedServ
 		return nil, err
 	}
 
-	return a.decode(desc, cb, rdr).Result()
+	return a.decode(ctx, desc, cb, rdr).Result()
 }
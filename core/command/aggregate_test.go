@@ -0,0 +1,91 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/writeconcern"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateOutputStage(t *testing.T) {
+	ns := Namespace{DB: "db", Collection: "coll"}
+	match := bson.VC.DocumentFromElements(bson.EC.SubDocumentFromElements("$match", bson.EC.Int32("x", 1)))
+	stage := func(elems ...*bson.Element) *bson.Value { return bson.VC.DocumentFromElements(elems...) }
+	target := func(db, coll string) *bson.Element {
+		return bson.EC.SubDocumentFromElements("into", bson.EC.String("db", db), bson.EC.String("coll", coll))
+	}
+	raw := func(elems ...*bson.Element) *bson.Value {
+		rdr, err := bson.NewDocument(elems...).MarshalBSON()
+		require.NoError(t, err)
+		return bson.VC.DocumentFromReader(rdr)
+	}
+
+	testCases := []struct {
+		name  string
+		stage *bson.Value
+		out   bool
+		merge bool
+		err   error
+	}{
+		{"no output stage", match, false, false, nil},
+		{"$out string", stage(bson.EC.String("$out", "other")), true, false, nil},
+		{"$out document", stage(bson.EC.SubDocumentFromElements("$out",
+			bson.EC.String("db", "otherdb"), bson.EC.String("coll", "other"))), true, false, nil},
+		{"$out raw document", raw(bson.EC.SubDocumentFromElements("$out",
+			bson.EC.String("db", "otherdb"), bson.EC.String("coll", "other"))), true, false, nil},
+		{"$merge string", stage(bson.EC.String("$merge", "other")), true, true, nil},
+		{"$merge into string", stage(bson.EC.SubDocumentFromElements("$merge",
+			bson.EC.String("into", "other"), bson.EC.String("whenMatched", "replace"))), true, true, nil},
+		{"$merge into another database", stage(bson.EC.SubDocumentFromElements("$merge",
+			target("otherdb", "other"), bson.EC.String("whenMatched", "replace"))), true, true, nil},
+		{"$merge raw document", raw(bson.EC.SubDocumentFromElements("$merge", target("otherdb", "other"))), true, true, nil},
+		{"$out empty string", stage(bson.EC.String("$out", "")), true, false, ErrInvalidOutputStage},
+		{"$out without db", stage(bson.EC.SubDocumentFromElements("$out", bson.EC.String("coll", "other"))),
+			true, false, ErrInvalidOutputStage},
+		{"$out empty coll", stage(bson.EC.SubDocumentFromElements("$out",
+			bson.EC.String("db", "otherdb"), bson.EC.String("coll", ""))), true, false, ErrInvalidOutputStage},
+		{"$out db not a string", stage(bson.EC.SubDocumentFromElements("$out",
+			bson.EC.Int32("db", 1), bson.EC.String("coll", "other"))), true, false, ErrInvalidOutputStage},
+		{"$out number", stage(bson.EC.Int32("$out", 1)), true, false, ErrInvalidOutputStage},
+		{"$merge without into", stage(bson.EC.SubDocumentFromElements("$merge", bson.EC.String("on", "_id"))),
+			true, true, ErrInvalidOutputStage},
+		{"$merge into empty db", stage(bson.EC.SubDocumentFromElements("$merge", target("", "other"))),
+			true, true, ErrInvalidOutputStage},
+		{"$merge raw into empty coll", raw(bson.EC.SubDocumentFromElements("$merge", target("otherdb", ""))),
+			true, true, ErrInvalidOutputStage},
+	}
+
+	desc := description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Max: 9}}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &Aggregate{
+				NS:           ns,
+				Pipeline:     bson.NewArray(match, tc.stage),
+				WriteConcern: writeconcern.New(writeconcern.WMajority()),
+			}
+			require.Equal(t, tc.out, a.HasDollarOut())
+			require.Equal(t, tc.merge, a.HasDollarMerge())
+
+			cmd, err := a.encode(desc)
+			require.Equal(t, tc.err, err)
+			if err != nil {
+				return
+			}
+			require.Equal(t, "majority", cmd.Command.Lookup("writeConcern", "w").StringValue())
+		})
+	}
+
+	t.Run("output stage not last", func(t *testing.T) {
+		a := &Aggregate{NS: ns, Pipeline: bson.NewArray(stage(bson.EC.String("$out", "other")), match)}
+		require.False(t, a.HasDollarOut())
+		require.False(t, a.HasDollarMerge())
+	})
+}
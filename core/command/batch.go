@@ -0,0 +1,117 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+)
+
+// ErrInvalidBatch is returned when a batch of documents returned by the server is malformed.
+var ErrInvalidBatch = errors.New("invalid batch of documents")
+
+// Batch is a batch of documents returned by the server for a cursor. It reads the documents in
+// place from the BSON array of the reply that contained them, so iterating a batch does not
+// allocate. Each reply has its own buffer, so a batch and the documents read from it remain valid
+// after the cursor has moved on to other batches, unless the cursor reads its replies into pooled
+// buffers. In that case they are only valid until the cursor moves on to the next batch or is
+// closed.
+type Batch struct {
+	arr bson.Reader
+	pos int
+	err error
+}
+
+// NewBatch returns a batch reading the documents of the BSON array arr.
+func NewBatch(arr bson.Reader) *Batch {
+	if len(arr) < 5 {
+		arr = nil
+	}
+
+	return &Batch{arr: arr, pos: 4}
+}
+
+// Next returns the next document of the batch without copying it. It returns false once the batch
+// is exhausted or if the batch is malformed, in which case Err returns the error.
+func (b *Batch) Next() (bson.Reader, bool) {
+	if b == nil || b.err != nil || b.pos >= len(b.arr)-1 {
+		return nil, false
+	}
+
+	doc, next, err := b.element(b.pos)
+	if err != nil {
+		b.err = err
+		return nil, false
+	}
+
+	b.pos = next
+	return doc, true
+}
+
+// element reads the document of the array element starting at pos and returns it along with the
+// position of the next element.
+func (b *Batch) element(pos int) (bson.Reader, int, error) {
+	if bson.Type(b.arr[pos]) != bson.TypeEmbeddedDocument {
+		return nil, 0, ErrInvalidBatch
+	}
+
+	// skip the type and the index of the element, which is a cstring
+	end := bytes.IndexByte(b.arr[pos+1:], 0x00)
+	if end < 0 {
+		return nil, 0, ErrInvalidBatch
+	}
+	start := pos + 1 + end + 1
+
+	if start+4 > len(b.arr) {
+		return nil, 0, ErrInvalidBatch
+	}
+	size := int(int32(binary.LittleEndian.Uint32(b.arr[start:])))
+	if size < 5 || start+size > len(b.arr) {
+		return nil, 0, ErrInvalidBatch
+	}
+
+	return b.arr[start : start+size : start+size], start + size, nil
+}
+
+// Len returns the number of documents left in the batch.
+func (b *Batch) Len() int {
+	if b == nil || b.err != nil {
+		return 0
+	}
+
+	var n int
+	for pos := b.pos; pos < len(b.arr)-1; n++ {
+		_, next, err := b.element(pos)
+		if err != nil {
+			break
+		}
+		pos = next
+	}
+	return n
+}
+
+// Raw returns the BSON array the batch reads from, including the documents that have already been
+// read, exactly as it was returned by the server. It can be forwarded without decoding.
+func (b *Batch) Raw() bson.Reader {
+	if b == nil {
+		return nil
+	}
+
+	return b.arr
+}
+
+// Err returns the error that stopped the iteration of the batch, if any.
+func (b *Batch) Err() error {
+	if b == nil {
+		return nil
+	}
+
+	return b.err
+}
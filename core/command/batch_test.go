@@ -0,0 +1,97 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/stretchr/testify/require"
+)
+
+func marshalBatch(t testing.TB, vals ...*bson.Value) bson.Reader {
+	arr, err := bson.NewArray(vals...).MarshalBSON()
+	require.NoError(t, err)
+	return arr
+}
+
+func TestBatch(t *testing.T) {
+	t.Run("Next", func(t *testing.T) {
+		docs := []*bson.Document{
+			bson.NewDocument(bson.EC.Int32("x", 1)),
+			bson.NewDocument(bson.EC.String("y", "foo"), bson.EC.SubDocumentFromElements("z", bson.EC.Null("a"))),
+			bson.NewDocument(),
+		}
+		arr := marshalBatch(t, bson.VC.Document(docs[0]), bson.VC.Document(docs[1]), bson.VC.Document(docs[2]))
+
+		batch := NewBatch(arr)
+		require.Equal(t, 3, batch.Len())
+		for i, want := range docs {
+			doc, ok := batch.Next()
+			require.True(t, ok)
+			got, err := bson.ReadDocument(doc)
+			require.NoError(t, err)
+			require.True(t, want.Equal(got), "document %d: expected %v, got %v", i, want, got)
+			require.Equal(t, len(docs)-i-1, batch.Len())
+		}
+
+		_, ok := batch.Next()
+		require.False(t, ok)
+		require.NoError(t, batch.Err())
+		require.True(t, bytes.Equal(arr, batch.Raw()))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		for _, batch := range []*Batch{nil, NewBatch(nil), NewBatch(marshalBatch(t))} {
+			_, ok := batch.Next()
+			require.False(t, ok)
+			require.Equal(t, 0, batch.Len())
+			require.NoError(t, batch.Err())
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		valid := marshalBatch(t, bson.VC.DocumentFromElements(bson.EC.Int32("x", 1)))
+
+		testCases := []struct {
+			name string
+			arr  bson.Reader
+		}{
+			{"non-document", marshalBatch(t, bson.VC.DocumentFromElements(bson.EC.Int32("x", 1)), bson.VC.String("a"))},
+			{"truncated", valid[:len(valid)-3]},
+			{"unterminated key", bson.Reader{0x09, 0x00, 0x00, 0x00, 0x03, '0', '1', '2', 0x00}[:8]},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				batch := NewBatch(tc.arr)
+				for _, ok := batch.Next(); ok; _, ok = batch.Next() {
+				}
+				require.Equal(t, ErrInvalidBatch, batch.Err())
+				require.Equal(t, 0, batch.Len())
+			})
+		}
+	})
+}
+
+func BenchmarkBatchNext(b *testing.B) {
+	vals := make([]*bson.Value, 100)
+	for i := range vals {
+		vals[i] = bson.VC.DocumentFromElements(bson.EC.Int32("x", int32(i)), bson.EC.String("y", "foo"))
+	}
+	arr := marshalBatch(b, vals...)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		batch := Batch{arr: arr, pos: 4}
+		for _, ok := batch.Next(); ok; _, ok = batch.Next() {
+		}
+		if batch.Err() != nil {
+			b.Fatal(batch.Err())
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/option"
+)
+
+func TestBypassDocumentValidation(t *testing.T) {
+	ns := Namespace{DB: "db", Collection: "coll"}
+	bypass := option.OptBypassDocumentValidation(true)
+	filter := bson.NewDocument(bson.EC.Int32("x", 1))
+	replacement := bson.NewDocument(bson.EC.Int32("x", 2))
+
+	newServer := func(maxWireVersion int32) description.SelectedServer {
+		return description.SelectedServer{
+			Server: description.Server{
+				WireVersion:     &description.VersionRange{Max: maxWireVersion},
+				MaxBatchCount:   1000,
+				MaxDocumentSize: 16 * 1024 * 1024,
+			},
+		}
+	}
+
+	commands := []struct {
+		name   string
+		encode func(desc description.SelectedServer, set bool) (*bson.Document, error)
+	}{
+		{
+			"insert",
+			func(desc description.SelectedServer, set bool) (*bson.Document, error) {
+				i := &Insert{NS: ns, Docs: []*bson.Document{replacement}}
+				if set {
+					i.Opts = []option.InsertOptioner{bypass}
+				}
+				err := i.encode(desc)
+				if err != nil {
+					return nil, err
+				}
+				return i.batches[0].Command, nil
+			},
+		},
+		{
+			"update",
+			func(desc description.SelectedServer, set bool) (*bson.Document, error) {
+				u := &Update{
+					NS:   ns,
+					Docs: []*bson.Document{bson.NewDocument(bson.EC.SubDocument("q", filter), bson.EC.SubDocument("u", replacement))},
+				}
+				if set {
+					u.Opts = []option.UpdateOptioner{bypass}
+				}
+				err := u.encode(desc)
+				if err != nil {
+					return nil, err
+				}
+				return u.batches[0].Command, nil
+			},
+		},
+		{
+			"findAndModify",
+			func(desc description.SelectedServer, set bool) (*bson.Document, error) {
+				f := &FindOneAndReplace{NS: ns, Query: filter, Replacement: replacement}
+				if set {
+					f.Opts = []option.FindOneAndReplaceOptioner{bypass}
+				}
+				cmd, err := f.encode(desc)
+				if err != nil {
+					return nil, err
+				}
+				return cmd.Command, nil
+			},
+		},
+		{
+			"aggregate",
+			func(desc description.SelectedServer, set bool) (*bson.Document, error) {
+				a := &Aggregate{
+					NS:       ns,
+					Pipeline: bson.NewArray(bson.VC.DocumentFromElements(bson.EC.String("$out", "other"))),
+				}
+				if set {
+					a.Opts = []option.AggregateOptioner{bypass}
+				}
+				cmd, err := a.encode(desc)
+				if err != nil {
+					return nil, err
+				}
+				return cmd.Command, nil
+			},
+		},
+	}
+
+	for _, tc := range commands {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, err := tc.encode(newServer(4), true)
+			noerr(t, err)
+			if n := countKey(cmd, "bypassDocumentValidation"); n != 1 {
+				t.Fatalf("Expected 1 bypassDocumentValidation field, got %d", n)
+			}
+			if !cmd.Lookup("bypassDocumentValidation").Boolean() {
+				t.Errorf("Expected bypassDocumentValidation to be true")
+			}
+
+			// the field requires privileges, so it is only sent when the option is set
+			cmd, err = tc.encode(newServer(4), false)
+			noerr(t, err)
+			if n := countKey(cmd, "bypassDocumentValidation"); n != 0 {
+				t.Errorf("Expected no bypassDocumentValidation field, got %d", n)
+			}
+
+			if _, err = tc.encode(newServer(3), true); err == nil {
+				t.Errorf("Expected an error for a server older than 3.2")
+			}
+			if _, err = tc.encode(newServer(3), false); err != nil {
+				t.Errorf("Expected no error without the option, got %v", err)
+			}
+		})
+	}
+}
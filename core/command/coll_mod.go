@@ -0,0 +1,128 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/result"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/core/writeconcern"
+)
+
+// CollMod represents the collMod command.
+//
+// The collMod command modifies the properties of a collection or of one of its indexes. Unset
+// fields are left out of the command.
+type CollMod struct {
+	NS                           Namespace
+	Index                        *bson.Document // the key pattern or name of the index and its properties to modify
+	Validator                    *bson.Document
+	ValidationLevel              string
+	ValidationAction             string
+	ChangeStreamPreAndPostImages *bool
+	WriteConcern                 *writeconcern.WriteConcern
+	Clock                        *session.ClusterClock
+	Session                      *session.Client
+
+	result result.CollMod
+	err    error
+}
+
+// Encode will encode this command into a wire message for the given server description.
+func (cm *CollMod) Encode(desc description.SelectedServer) (wiremessage.WireMessage, error) {
+	cmd, err := cm.encode(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd.Encode(desc)
+}
+
+func (cm *CollMod) encode(desc description.SelectedServer) (*Write, error) {
+	if err := cm.NS.Validate(); err != nil {
+		return nil, err
+	}
+
+	cmd := bson.NewDocument(
+		bson.EC.String("collMod", cm.NS.Collection),
+	)
+	if cm.Index != nil {
+		cmd.Append(bson.EC.SubDocument("index", cm.Index))
+	}
+	if cm.Validator != nil {
+		cmd.Append(bson.EC.SubDocument("validator", cm.Validator))
+	}
+	if cm.ValidationLevel != "" {
+		cmd.Append(bson.EC.String("validationLevel", cm.ValidationLevel))
+	}
+	if cm.ValidationAction != "" {
+		cmd.Append(bson.EC.String("validationAction", cm.ValidationAction))
+	}
+	if cm.ChangeStreamPreAndPostImages != nil {
+		cmd.Append(bson.EC.SubDocumentFromElements("changeStreamPreAndPostImages",
+			bson.EC.Boolean("enabled", *cm.ChangeStreamPreAndPostImages),
+		))
+	}
+
+	return &Write{
+		Clock:        cm.Clock,
+		WriteConcern: cm.WriteConcern,
+		DB:           cm.NS.DB,
+		Command:      cmd,
+		Session:      cm.Session,
+	}, nil
+}
+
+// Decode will decode the wire message using the provided server description. Errors during decoding
+// are deferred until either the Result or Err methods are called.
+func (cm *CollMod) Decode(desc description.SelectedServer, wm wiremessage.WireMessage) *CollMod {
+	rdr, err := (&Write{}).Decode(desc, wm).Result()
+	if err != nil {
+		cm.err = err
+		return cm
+	}
+
+	return cm.decode(desc, rdr)
+}
+
+func (cm *CollMod) decode(desc description.SelectedServer, rdr bson.Reader) *CollMod {
+	cm.result = result.CollMod{}
+	cm.err = decodeResult(rdr, &cm.result)
+	cm.result.Raw = rdr
+	return cm
+}
+
+// Result returns the result of a decoded wire message and server description.
+func (cm *CollMod) Result() (result.CollMod, error) {
+	if cm.err != nil {
+		return result.CollMod{}, cm.err
+	}
+
+	return cm.result, nil
+}
+
+// Err returns the error set on this command.
+func (cm *CollMod) Err() error { return cm.err }
+
+// RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
+func (cm *CollMod) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (result.CollMod, error) {
+	cmd, err := cm.encode(desc)
+	if err != nil {
+		return result.CollMod{}, err
+	}
+
+	rdr, err := cmd.RoundTrip(ctx, desc, rw)
+	if err != nil {
+		return result.CollMod{}, err
+	}
+
+	return cm.decode(desc, rdr).Result()
+}
@@ -7,11 +7,16 @@
 package command
 
 import (
+	"encoding/binary"
 	"errors"
+	"fmt"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/option"
 	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
+	"github.com/mongodb/mongo-go-driver/core/serverapi"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
@@ -50,61 +55,153 @@ func DecodeError(wm wiremessage.WireMessage) error {
 	return nil
 }
 
+// ReplyClusterTime returns the $clusterTime of the reply wm as a document holding the single
+// $clusterTime element, or nil if the reply does not have one.
+func ReplyClusterTime(wm wiremessage.WireMessage) *bson.Document {
+	var rdr bson.Reader
+	switch msg := wm.(type) {
+	case wiremessage.Msg:
+		for _, section := range msg.Sections {
+			if body, ok := section.(wiremessage.SectionBody); ok {
+				rdr = body.Document
+			}
+		}
+	case wiremessage.Reply:
+		if len(msg.Documents) == 0 {
+			return nil
+		}
+		rdr = msg.Documents[0]
+	}
+	if rdr == nil {
+		return nil
+	}
+
+	return responseClusterTime(rdr)
+}
+
+// WithClusterTime returns the command wm carrying clusterTime, a document holding a single
+// $clusterTime element, unless the command already carries the same or a later cluster time or
+// clusterTime is nil. Only OP_MSG commands are changed, since every server that gossips the
+// cluster time supports OP_MSG.
+func WithClusterTime(wm wiremessage.WireMessage, clusterTime *bson.Document) wiremessage.WireMessage {
+	msg, ok := wm.(wiremessage.Msg)
+	if !ok || clusterTime == nil {
+		return wm
+	}
+
+	for i, section := range msg.Sections {
+		body, ok := section.(wiremessage.SectionBody)
+		if !ok {
+			continue
+		}
+
+		doc, ok := withClusterTime(body.Document, clusterTime)
+		if !ok {
+			return wm
+		}
+
+		sections := make([]wiremessage.Section, len(msg.Sections))
+		copy(sections, msg.Sections)
+		sections[i] = wiremessage.SectionBody{PayloadType: body.PayloadType, Document: doc}
+		msg.Sections = sections
+		msg.MsgHeader.MessageLength = 0 // the length changed, so it is recomputed when the message is written
+		return msg
+	}
+
+	return wm
+}
+
+// withClusterTime returns cmd carrying clusterTime, and whether it differs from cmd.
+func withClusterTime(cmd bson.Reader, clusterTime *bson.Document) (bson.Reader, bool) {
+	elems, err := clusterTime.MarshalBSON()
+	if err != nil || len(cmd) < 5 {
+		return nil, false
+	}
+
+	current := responseClusterTime(cmd)
+	if current == nil {
+		// append the element in place of the terminating byte of cmd and fix its length
+		elems = elems[4 : len(elems)-1]
+		doc := make(bson.Reader, 0, len(cmd)+len(elems))
+		doc = append(doc, cmd[:len(cmd)-1]...)
+		doc = append(doc, elems...)
+		doc = append(doc, 0)
+		binary.LittleEndian.PutUint32(doc, uint32(len(doc)))
+		return doc, true
+	}
+	if session.MaxClusterTime(current, clusterTime) == current {
+		return nil, false
+	}
+
+	d, err := bson.ReadDocument(cmd)
+	if err != nil {
+		return nil, false
+	}
+	d.Delete("$clusterTime")
+	d.Append(clusterTime.LookupElement("$clusterTime").Clone())
+	doc, err := d.MarshalBSON()
+	if err != nil {
+		return nil, false
+	}
+	return doc, true
+}
+
+// errCommandSucceeded stops the iteration of a reply once it is known to report success.
+var errCommandSucceeded = errors.New("command succeeded")
+
 // helper method to extract an error from a reader if there is one; first returned item is the
 // error if it exists, the second holds parsing errors
 func extractError(rdr bson.Reader) error {
 	var errmsg, codeName string
 	var code int32
 	var labels []string
-	itr, err := rdr.Iterator()
-	if err != nil {
-		return err
-	}
-
-	for itr.Next() {
-		elem := itr.Element()
-		switch elem.Key() {
+	err := rdr.ForEach(func(key []byte, val bson.Value) error {
+		switch string(key) {
 		case "ok":
-			switch elem.Value().Type() {
+			switch val.Type() {
 			case bson.TypeInt32:
-				if elem.Value().Int32() == 1 {
-					return nil
+				if val.Int32() == 1 {
+					return errCommandSucceeded
 				}
 			case bson.TypeInt64:
-				if elem.Value().Int64() == 1 {
-					return nil
+				if val.Int64() == 1 {
+					return errCommandSucceeded
 				}
 			case bson.TypeDouble:
-				if elem.Value().Double() == 1 {
-					return nil
+				if val.Double() == 1 {
+					return errCommandSucceeded
 				}
 			}
 		case "errmsg":
-			if str, okay := elem.Value().StringValueOK(); okay {
+			if str, okay := val.StringValueOK(); okay {
 				errmsg = str
 			}
 		case "codeName":
-			if str, okay := elem.Value().StringValueOK(); okay {
+			if str, okay := val.StringValueOK(); okay {
 				codeName = str
 			}
 		case "code":
-			if c, okay := elem.Value().Int32OK(); okay {
+			if c, okay := val.Int32OK(); okay {
 				code = c
 			}
 		case "errorLabels":
-			if arr, okay := elem.Value().MutableArrayOK(); okay {
-				iter, err := arr.Iterator()
-				if err != nil {
-					continue
-				}
-				for iter.Next() {
-					if str, ok := iter.Value().StringValueOK(); ok {
+			if arr, okay := val.ReaderArrayOK(); okay {
+				_ = arr.ForEach(func(_ []byte, label bson.Value) error {
+					if str, ok := label.StringValueOK(); ok {
 						labels = append(labels, str)
 					}
-				}
-
+					return nil
+				})
 			}
 		}
+		return nil
+	})
+	switch err {
+	case nil:
+	case errCommandSucceeded:
+		return nil
+	default:
+		return err
 	}
 
 	if errmsg == "" {
@@ -116,9 +213,20 @@ func extractError(rdr bson.Reader) error {
 		Message: errmsg,
 		Name:    codeName,
 		Labels:  labels,
+		// the reply may be read from a buffer that is reused once the connection is returned
+		Raw: append(bson.Reader(nil), rdr...),
 	}
 }
 
+// decodeResult decodes the reply of a command into the result v. A reply reporting that the command
+// failed is returned as an Error instead, so that every command handles failures the same way.
+func decodeResult(rdr bson.Reader, v interface{}) error {
+	if err := extractError(rdr); err != nil {
+		return err
+	}
+	return bson.Unmarshal(rdr, v)
+}
+
 func responseClusterTime(response bson.Reader) *bson.Document {
 	clusterTime, err := response.Lookup("$clusterTime")
 	if err != nil {
@@ -126,7 +234,18 @@ func responseClusterTime(response bson.Reader) *bson.Document {
 		return nil
 	}
 
-	return bson.NewDocument(clusterTime)
+	rdr, ok := clusterTime.Value().ReaderDocumentOK()
+	if !ok {
+		return nil
+	}
+
+	// the cluster time outlives the response, whose buffer may be reused
+	doc, err := bson.ReadDocument(append([]byte(nil), rdr...))
+	if err != nil {
+		return nil
+	}
+
+	return bson.NewDocument(bson.EC.SubDocument("$clusterTime", doc))
 }
 
 func updateClusterTimes(sess *session.Client, clock *session.ClusterClock, response bson.Reader) error {
@@ -160,7 +279,10 @@ func updateOperationTime(sess *session.Client, response bson.Reader) error {
 		return nil
 	}
 
-	t, i := opTimeElem.Value().Timestamp()
+	t, i, ok := opTimeElem.Value().TimestampOK()
+	if !ok {
+		return fmt.Errorf("operationTime should be a timestamp but it is a BSON %s", opTimeElem.Value().Type())
+	}
 	return sess.AdvanceOperationTime(&bson.Timestamp{
 		T: t,
 		I: i,
@@ -241,12 +363,43 @@ func addClusterTime(cmd *bson.Document, desc description.SelectedServer, sess *s
 }
 
 // add a read concern to a BSON doc representing a command
-func addReadConcern(cmd *bson.Document, desc description.SelectedServer, rc *readconcern.ReadConcern, sess *session.Client) error {
+func addReadConcern(
+	cmd *bson.Document,
+	desc description.SelectedServer,
+	rc *readconcern.ReadConcern,
+	rp *readpref.ReadPref,
+	sess *session.Client,
+) error {
+	// A read concern set by an operation option overrides the one inherited from the collection. It
+	// is removed and added back below so the session fields are merged into it.
+	if elem, err := cmd.LookupElementErr("readConcern"); err == nil {
+		cmd.Delete("readConcern")
+		if sess == nil || !sess.TransactionInProgress() {
+			rc = readconcern.New()
+			if doc, ok := elem.Value().MutableDocumentOK(); ok {
+				if level, ok := doc.Lookup("level").StringValueOK(); ok {
+					readconcern.Level(level)(rc)
+				}
+			}
+		}
+	}
+
 	// Starting transaction's read concern overrides all others
 	if sess != nil && sess.TransactionStarting() && sess.CurrentRc != nil {
 		rc = sess.CurrentRc
 	}
 
+	switch rc.GetLevel() {
+	case "linearizable":
+		if rp != nil && rp.Mode() != readpref.PrimaryMode {
+			return ErrLinearizableReadPref
+		}
+	case "snapshot":
+		if sess == nil || !(sess.TransactionStarting() || sess.TransactionInProgress()) {
+			return ErrSnapshotReadConcern
+		}
+	}
+
 	// start transaction must append afterclustertime IF causally consistent and operation time exists
 	if rc == nil && sess != nil && sess.TransactionStarting() && sess.Consistent && sess.OperationTime != nil {
 		rc = readconcern.New()
@@ -268,10 +421,6 @@ func addReadConcern(cmd *bson.Document, desc description.SelectedServer, rc *rea
 		)
 	}
 
-	if _, err := cmd.LookupElementErr(element.Key()); err != nil {
-		cmd.Delete(element.Key())
-	}
-
 	if rcDoc.Len() != 0 {
 		cmd.Append(bson.EC.SubDocument("readConcern", rcDoc))
 	}
@@ -289,7 +438,7 @@ func addWriteConcern(cmd *bson.Document, wc *writeconcern.WriteConcern) error {
 		return err
 	}
 
-	if _, err := cmd.LookupElementErr(element.Key()); err != nil {
+	if _, err := cmd.LookupElementErr(element.Key()); err == nil {
 		// doc already has write concern
 		cmd.Delete(element.Key())
 	}
@@ -298,6 +447,91 @@ func addWriteConcern(cmd *bson.Document, wc *writeconcern.WriteConcern) error {
 	return nil
 }
 
+// noMaxTimeMS holds the commands that are not bounded by a maxTimeMS derived from the operation
+// timeout. The maxTimeMS of a getMore is the await time of a tailable await cursor, and the handshake
+// and authentication commands are bounded by the connection timeouts.
+var noMaxTimeMS = map[string]struct{}{
+	"getMore":      {},
+	"killCursors":  {},
+	"authenticate": {},
+	"saslStart":    {},
+	"saslContinue": {},
+	"getnonce":     {},
+}
+
+// optionSupported returns an error if opt cannot be used with the selected server.
+func optionSupported(desc description.SelectedServer, opt option.Optioner) error {
+	switch opt.(type) {
+	case option.OptLet:
+		return desc.CheckFeature(description.FeatureLet)
+	case option.OptBypassDocumentValidation:
+		return desc.CheckFeature(description.FeatureBypassDocumentValidation)
+	case option.OptCollation:
+		return desc.CheckFeature(description.FeatureCollation)
+	case option.OptArrayFilters:
+		return desc.CheckFeature(description.FeatureArrayFilters)
+	}
+
+	return nil
+}
+
+// appendComment appends the comment opt to cmd if the selected server accepts it. Servers before
+// 4.4 only accept string comments on find and aggregate commands; other comments fail the command,
+// unless they are the default comment of the client, which is left out instead.
+func appendComment(desc description.SelectedServer, cmd *bson.Document, opt option.OptComment) error {
+	if _, ok := opt.Comment.(string); ok && cmd.Len() > 0 {
+		switch cmd.ElementAt(0).Key() {
+		case "find", "aggregate":
+			return opt.Option(cmd)
+		}
+	}
+
+	if err := desc.CheckFeature(description.FeatureComment); err != nil {
+		if opt.Default {
+			return nil
+		}
+		return err
+	}
+	return opt.Option(cmd)
+}
+
+// addMaxTimeMS bounds cmd by maxTimeMS, the time remaining for the operation. A lower maxTimeMS
+// already set on the command is kept. A maxTimeMS of 0 leaves cmd unchanged.
+func addMaxTimeMS(cmd *bson.Document, maxTimeMS int64) {
+	if maxTimeMS <= 0 || cmd.Len() == 0 {
+		return
+	}
+	if _, skip := noMaxTimeMS[cmd.ElementAt(0).Key()]; skip {
+		return
+	}
+
+	if elem, err := cmd.LookupElementErr("maxTimeMS"); err == nil {
+		if existing, ok := elem.Value().Int64OK(); ok && existing > 0 && existing <= maxTimeMS {
+			return
+		}
+		cmd.Delete("maxTimeMS")
+	}
+
+	cmd.Append(bson.EC.Int64("maxTimeMS", maxTimeMS))
+}
+
+// addServerAPI adds the fields of the declared server API to cmd. A command that already sets any
+// of them is rejected rather than sending conflicting fields.
+func addServerAPI(cmd *bson.Document, api *serverapi.Options) error {
+	if api == nil {
+		return nil
+	}
+
+	for _, key := range []string{"apiVersion", "apiStrict", "apiDeprecationErrors"} {
+		if _, err := cmd.LookupElementErr(key); err == nil {
+			return ErrServerAPIConflict
+		}
+	}
+
+	cmd.Append(api.Elements()...)
+	return nil
+}
+
 // Get the error labels from a command response
 func getErrorLabels(rdr *bson.Reader) ([]string, error) {
 	var labels []string
@@ -306,12 +540,18 @@ func getErrorLabels(rdr *bson.Reader) ([]string, error) {
 		return nil, err
 	}
 	if labelsElem != nil {
-		labelsIt, err := labelsElem.Value().ReaderArray().Iterator()
+		labelsArr, ok := labelsElem.Value().ReaderArrayOK()
+		if !ok {
+			return nil, fmt.Errorf("errorLabels should be an array but it is a BSON %s", labelsElem.Value().Type())
+		}
+		labelsIt, err := labelsArr.Iterator()
 		if err != nil {
 			return nil, err
 		}
 		for labelsIt.Next() {
-			labels = append(labels, labelsIt.Element().Value().StringValue())
+			if label, ok := labelsIt.Element().Value().StringValueOK(); ok {
+				labels = append(labels, label)
+			}
 		}
 	}
 	return labels, nil
@@ -7,8 +7,10 @@
 package command
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
@@ -65,3 +67,117 @@ func TestCommandEncode(t *testing.T) {
 		}
 	})
 }
+
+func TestExtractError(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		rdr, err := bson.NewDocument(bson.EC.Int32("n", 1), bson.EC.Double("ok", 1)).MarshalBSON()
+		noerr(t, err)
+		if err = extractError(rdr); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+	t.Run("command error", func(t *testing.T) {
+		rdr, err := bson.NewDocument(
+			bson.EC.Double("ok", 0),
+			bson.EC.String("errmsg", "not master"),
+			bson.EC.Int32("code", 10107),
+			bson.EC.String("codeName", "NotMaster"),
+			bson.EC.ArrayFromElements("errorLabels", bson.VC.String("TransientTransactionError")),
+		).MarshalBSON()
+		noerr(t, err)
+
+		cerr, ok := extractError(rdr).(Error)
+		if !ok {
+			t.Fatalf("Expected a command error. got %v", extractError(rdr))
+		}
+		if cerr.Code != 10107 || cerr.Message != "not master" || cerr.Name != "NotMaster" {
+			t.Errorf("Unexpected error. got %+v", cerr)
+		}
+		if !cerr.HasErrorLabel("TransientTransactionError") {
+			t.Errorf("Expected the error to have the TransientTransactionError label. got %v", cerr.Labels)
+		}
+	})
+	t.Run("default message", func(t *testing.T) {
+		rdr, err := bson.NewDocument(bson.EC.Double("ok", 0)).MarshalBSON()
+		noerr(t, err)
+		if err = extractError(rdr); err == nil || err.Error() != "command failed" {
+			t.Errorf("Unexpected error. got %v; want %v", err, "command failed")
+		}
+	})
+}
+
+func TestDecodeResult(t *testing.T) {
+	desc := description.SelectedServer{}
+	t.Run("drop indexes", func(t *testing.T) {
+		rdr, err := bson.NewDocument(bson.EC.Int32("nIndexesWas", 3), bson.EC.Double("ok", 1)).MarshalBSON()
+		noerr(t, err)
+		res, err := (&DropIndexes{}).decode(desc, rdr).Result()
+		noerr(t, err)
+		if res.NIndexesWas != 3 || !bytes.Equal(res.Raw, rdr) {
+			t.Errorf("Unexpected result. got %+v", res)
+		}
+	})
+	t.Run("drop collection", func(t *testing.T) {
+		rdr, err := bson.NewDocument(
+			bson.EC.String("ns", "db.coll"),
+			bson.EC.Int32("nIndexesWas", 2),
+			bson.EC.Double("ok", 1),
+		).MarshalBSON()
+		noerr(t, err)
+		res, err := (&DropCollection{}).decode(desc, rdr).Result()
+		noerr(t, err)
+		if res.NS != "db.coll" || res.NIndexesWas != 2 || !bytes.Equal(res.Raw, rdr) {
+			t.Errorf("Unexpected result. got %+v", res)
+		}
+	})
+	t.Run("drop database", func(t *testing.T) {
+		rdr, err := bson.NewDocument(bson.EC.String("dropped", "db"), bson.EC.Double("ok", 1)).MarshalBSON()
+		noerr(t, err)
+		res, err := (&DropDatabase{}).decode(desc, rdr).Result()
+		noerr(t, err)
+		if res.Dropped != "db" {
+			t.Errorf("Unexpected result. got %+v", res)
+		}
+	})
+	t.Run("command error", func(t *testing.T) {
+		rdr, err := bson.NewDocument(
+			bson.EC.Double("ok", 0),
+			bson.EC.String("errmsg", "ns not found"),
+			bson.EC.Int32("code", 26),
+		).MarshalBSON()
+		noerr(t, err)
+
+		_, err = (&DropIndexes{}).decode(desc, rdr).Result()
+		if cerr, ok := err.(Error); !ok || cerr.Code != 26 {
+			t.Errorf("Expected a command error. got %v", err)
+		}
+		_, err = (&ListDatabases{}).decode(desc, rdr).Result()
+		if cerr, ok := err.(Error); !ok || cerr.Code != 26 {
+			t.Errorf("Expected a command error. got %v", err)
+		}
+	})
+}
+
+func BenchmarkExtractError(b *testing.B) {
+	rdr, err := bson.NewDocument(
+		bson.EC.SubDocumentFromElements("cursor",
+			bson.EC.Int64("id", 0),
+			bson.EC.String("ns", "db.coll"),
+			bson.EC.ArrayFromElements("firstBatch"),
+		),
+		bson.EC.SubDocumentFromElements("$clusterTime", bson.EC.Timestamp("clusterTime", 1, 1)),
+		bson.EC.Timestamp("operationTime", 1, 1),
+		bson.EC.Double("ok", 1),
+	).MarshalBSON()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := extractError(rdr); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
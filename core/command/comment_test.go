@@ -0,0 +1,199 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/option"
+)
+
+func TestComment(t *testing.T) {
+	ns := Namespace{DB: "db", Collection: "coll"}
+	filter := bson.NewDocument(bson.EC.Int32("x", 1))
+	doc := bson.NewDocument(bson.EC.Int32("_id", 1))
+
+	newServer := func(maxWireVersion int32) description.SelectedServer {
+		return description.SelectedServer{
+			Server: description.Server{
+				WireVersion:     &description.VersionRange{Max: maxWireVersion},
+				MaxBatchCount:   1000,
+				MaxDocumentSize: 16 * 1024 * 1024,
+			},
+		}
+	}
+
+	commands := []struct {
+		name   string
+		encode func(desc description.SelectedServer, comment option.OptComment) (*bson.Document, error)
+		// stringBefore44 is whether servers before 4.4 accept string comments on the command
+		stringBefore44 bool
+	}{
+		{
+			"find",
+			func(desc description.SelectedServer, comment option.OptComment) (*bson.Document, error) {
+				cmd, err := (&Find{NS: ns, Filter: filter, Opts: []option.FindOptioner{comment}}).encode(desc)
+				if err != nil {
+					return nil, err
+				}
+				return cmd.Command, nil
+			},
+			true,
+		},
+		{
+			"aggregate",
+			func(desc description.SelectedServer, comment option.OptComment) (*bson.Document, error) {
+				cmd, err := (&Aggregate{
+					NS:       ns,
+					Pipeline: bson.NewArray(bson.VC.DocumentFromElements(bson.EC.SubDocument("$match", filter))),
+					Opts:     []option.AggregateOptioner{comment},
+				}).encode(desc)
+				if err != nil {
+					return nil, err
+				}
+				return cmd.Command, nil
+			},
+			true,
+		},
+		{
+			"insert",
+			func(desc description.SelectedServer, comment option.OptComment) (*bson.Document, error) {
+				i := &Insert{NS: ns, Docs: []*bson.Document{doc}, Opts: []option.InsertOptioner{comment}}
+				if err := i.encode(desc); err != nil {
+					return nil, err
+				}
+				return i.batches[0].Command, nil
+			},
+			false,
+		},
+		{
+			"update",
+			func(desc description.SelectedServer, comment option.OptComment) (*bson.Document, error) {
+				u := &Update{
+					NS: ns,
+					Docs: []*bson.Document{bson.NewDocument(
+						bson.EC.SubDocument("q", filter),
+						bson.EC.SubDocumentFromElements("u", bson.EC.SubDocumentFromElements("$set", bson.EC.Boolean("done", true))),
+					)},
+					Opts: []option.UpdateOptioner{comment},
+				}
+				if err := u.encode(desc); err != nil {
+					return nil, err
+				}
+				return u.batches[0].Command, nil
+			},
+			false,
+		},
+		{
+			"delete",
+			func(desc description.SelectedServer, comment option.OptComment) (*bson.Document, error) {
+				d := &Delete{
+					NS:      ns,
+					Deletes: []*bson.Document{bson.NewDocument(bson.EC.SubDocument("q", filter), bson.EC.Int32("limit", 0))},
+					Opts:    []option.DeleteOptioner{comment},
+				}
+				if err := d.encode(desc); err != nil {
+					return nil, err
+				}
+				return d.batches[0].Command, nil
+			},
+			false,
+		},
+		{
+			"findAndModify",
+			func(desc description.SelectedServer, comment option.OptComment) (*bson.Document, error) {
+				cmd, err := (&FindOneAndDelete{NS: ns, Query: filter, Opts: []option.FindOneAndDeleteOptioner{comment}}).encode(desc)
+				if err != nil {
+					return nil, err
+				}
+				return cmd.Command, nil
+			},
+			false,
+		},
+		{
+			"count",
+			func(desc description.SelectedServer, comment option.OptComment) (*bson.Document, error) {
+				cmd, err := (&Count{NS: ns, Query: filter, Opts: []option.CountOptioner{comment}}).encode(desc)
+				if err != nil {
+					return nil, err
+				}
+				return cmd.Command, nil
+			},
+			false,
+		},
+		{
+			"distinct",
+			func(desc description.SelectedServer, comment option.OptComment) (*bson.Document, error) {
+				cmd, err := (&Distinct{NS: ns, Field: "x", Query: filter, Opts: []option.DistinctOptioner{comment}}).encode(desc)
+				if err != nil {
+					return nil, err
+				}
+				return cmd.Command, nil
+			},
+			false,
+		},
+	}
+
+	strComment := option.OptComment{Comment: "report"}
+	docComment := option.OptComment{Comment: bson.NewDocument(bson.EC.String("job", "report"), bson.EC.Int32("run", 7))}
+
+	for _, tc := range commands {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, err := tc.encode(newServer(9), strComment)
+			noerr(t, err)
+			if n := countKey(cmd, "comment"); n != 1 {
+				t.Fatalf("Expected 1 comment field, got %d", n)
+			}
+			if comment := cmd.Lookup("comment").StringValue(); comment != "report" {
+				t.Errorf("Expected comment report, got %s", comment)
+			}
+
+			cmd, err = tc.encode(newServer(9), docComment)
+			noerr(t, err)
+			if run := cmd.Lookup("comment", "run").Int32(); run != 7 {
+				t.Errorf("Expected a document comment with run 7, got %d", run)
+			}
+
+			cmd, err = tc.encode(newServer(8), strComment)
+			if tc.stringBefore44 {
+				noerr(t, err)
+				if comment := cmd.Lookup("comment").StringValue(); comment != "report" {
+					t.Errorf("Expected comment report, got %s", comment)
+				}
+			} else if err == nil {
+				t.Errorf("Expected an error for a string comment on a server older than 4.4")
+			}
+
+			if _, err = tc.encode(newServer(8), docComment); err == nil {
+				t.Errorf("Expected an error for a document comment on a server older than 4.4")
+			}
+
+			defaultComment := docComment
+			defaultComment.Default = true
+			cmd, err = tc.encode(newServer(8), defaultComment)
+			noerr(t, err)
+			if n := countKey(cmd, "comment"); n != 0 {
+				t.Errorf("Expected the default comment to be left out for a server older than 4.4")
+			}
+		})
+	}
+
+	t.Run("getMore", func(t *testing.T) {
+		for _, tc := range []struct {
+			maxWireVersion int32
+			expected       int
+		}{{9, 1}, {8, 0}} {
+			cmd, err := (&GetMore{ID: 42, NS: ns, Opts: []option.CursorOptioner{docComment}}).encode(newServer(tc.maxWireVersion))
+			noerr(t, err)
+			if n := countKey(cmd.Command, "comment"); n != tc.expected {
+				t.Errorf("Expected %d comment fields for wire version %d, got %d", tc.expected, tc.maxWireVersion, n)
+			}
+		}
+	})
+}
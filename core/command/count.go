@@ -15,6 +15,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/option"
 	"github.com/mongodb/mongo-go-driver/core/readconcern"
 	"github.com/mongodb/mongo-go-driver/core/readpref"
+	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 )
@@ -31,7 +32,7 @@ type Count struct {
 	Clock       *session.ClusterClock
 	Session     *session.Client
 
-	result int64
+	result result.Count
 	err    error
 }
 
@@ -55,7 +56,15 @@ func (c *Count) encode(desc description.SelectedServer) (*Read, error) {
 		if opt == nil {
 			continue
 		}
-		err := opt.Option(command)
+		if err := optionSupported(desc, opt); err != nil {
+			return nil, err
+		}
+		var err error
+		if c, ok := opt.(option.OptComment); ok {
+			err = appendComment(desc, command, c)
+		} else {
+			err = opt.Option(command)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -96,9 +105,11 @@ func (c *Count) decode(desc description.SelectedServer, rdr bson.Reader) *Count
 
 	switch val.Value().Type() {
 	case bson.TypeInt32:
-		c.result = int64(val.Value().Int32())
+		c.result.N = int64(val.Value().Int32())
 	case bson.TypeInt64:
-		c.result = val.Value().Int64()
+		c.result.N = val.Value().Int64()
+	case bson.TypeDouble:
+		c.result.N = int64(val.Value().Double())
 	default:
 		c.err = errors.New("invalid response from server, value field is not a number")
 	}
@@ -107,9 +118,9 @@ func (c *Count) decode(desc description.SelectedServer, rdr bson.Reader) *Count
 }
 
 // Result returns the result of a decoded wire message and server description.
-func (c *Count) Result() (int64, error) {
+func (c *Count) Result() (result.Count, error) {
 	if c.err != nil {
-		return 0, c.err
+		return result.Count{}, c.err
 	}
 	return c.result, nil
 }
@@ -118,15 +129,15 @@ func (c *Count) Result() (int64, error) {
 func (c *Count) Err() error { return c.err }
 
 // RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
-func (c *Count) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (int64, error) {
+func (c *Count) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (result.Count, error) {
 	cmd, err := c.encode(desc)
 	if err != nil {
-		return 0, err
+		return result.Count{}, err
 	}
 
 	rdr, err := cmd.RoundTrip(ctx, desc, rw)
 	if err != nil {
-		return 0, err
+		return result.Count{}, err
 	}
 
 	return c.decode(desc, rdr).Result()
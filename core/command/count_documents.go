@@ -56,7 +56,12 @@ func (c *CountDocuments) Encode(desc description.SelectedServer) (wiremessage.Wi
 		case option.OptLimit:
 			continue
 		}
-		err := opt.Option(command)
+		var err error
+		if c, ok := opt.(option.OptComment); ok {
+			err = appendComment(desc, command, c)
+		} else {
+			err = opt.Option(command)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -73,7 +78,7 @@ func (c *CountDocuments) Decode(ctx context.Context, desc description.SelectedSe
 		c.err = err
 		return c
 	}
-	cur, err := cb.BuildCursor(rdr, c.Session, c.Clock)
+	cur, err := cb.BuildCursor(ctx, rdr, c.Session, c.Clock)
 	if err != nil {
 		c.err = err
 		return c
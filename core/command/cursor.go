@@ -40,6 +40,10 @@ type Cursor interface {
 	// Get the ID of the cursor.
 	ID() int64
 
+	// Get the database and collection names of the namespace the server
+	// reported for the cursor.
+	Namespace() (db, coll string)
+
 	// Get the next result from the cursor.
 	// Returns true if there were no errors and there is a next result.
 	Next(context.Context) bool
@@ -52,6 +56,21 @@ type Cursor interface {
 	// bytes to retain them.
 	DecodeBytes() (bson.Reader, error)
 
+	// Advance to the next batch of documents returned by the server. The
+	// documents of the current batch that have not been returned by Next are
+	// skipped. Returns true if there were no errors and the batch is not empty.
+	NextBatch(context.Context) bool
+
+	// Returns the documents of the current batch that have not been returned
+	// by Next, without copying them. Iterating the returned batch does not
+	// advance the cursor.
+	Batch() *Batch
+
+	// Set the number of documents requested by each subsequent getMore,
+	// overriding the batch size the cursor was created with. 0 requests the
+	// server default.
+	SetBatchSize(int32)
+
 	// Returns the error status of the cursor
 	Err() error
 
@@ -59,16 +78,21 @@ type Cursor interface {
 	Close(context.Context) error
 }
 
-// CursorBuilder is a type that can build a Cursor.
+// CursorBuilder is a type that can build a Cursor. The context is the one the command creating the
+// cursor was run with, from which the cursor inherits the operation timeout of its getMores.
 type CursorBuilder interface {
-	BuildCursor(bson.Reader, *session.Client, *session.ClusterClock, ...option.CursorOptioner) (Cursor, error)
+	BuildCursor(context.Context, bson.Reader, *session.Client, *session.ClusterClock, ...option.CursorOptioner) (Cursor, error)
 }
 
-type emptyCursor struct{}
+type emptyCursor struct{ ns Namespace }
 
 func (ec emptyCursor) ID() int64                         { return -1 }
+func (ec emptyCursor) Namespace() (string, string)       { return ec.ns.DB, ec.ns.Collection }
 func (ec emptyCursor) Next(context.Context) bool         { return false }
 func (ec emptyCursor) Decode(interface{}) error          { return nil }
 func (ec emptyCursor) DecodeBytes() (bson.Reader, error) { return nil, nil }
+func (ec emptyCursor) NextBatch(context.Context) bool    { return false }
+func (ec emptyCursor) Batch() *Batch                     { return NewBatch(nil) }
+func (ec emptyCursor) SetBatchSize(int32)                {}
 func (ec emptyCursor) Err() error                        { return nil }
 func (ec emptyCursor) Close(context.Context) error       { return nil }
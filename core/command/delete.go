@@ -21,7 +21,8 @@ import (
 // Delete represents the delete command.
 //
 // The delete command executes a delete with a given set of delete documents
-// and options.
+// and options. The delete statements are split into as many commands as needed
+// to honor the maximum batch count and message size of the server.
 type Delete struct {
 	NS           Namespace
 	Deletes      []*bson.Document
@@ -30,48 +31,100 @@ type Delete struct {
 	Clock        *session.ClusterClock
 	Session      *session.Client
 
-	result result.Delete
-	err    error
+	batches         []*Write
+	offsets         []int         // the index in Deletes of the first statement of each batch
+	result          result.Delete // the result of the last decoded batch
+	acc             result.Delete // the aggregated result of the batches that succeeded
+	err             error
+	continueOnError bool
 }
 
-// Encode will encode this command into a wire message for the given server description.
-func (d *Delete) Encode(desc description.SelectedServer) (wiremessage.WireMessage, error) {
-	cmd, err := d.encode(desc)
+// Encode will encode this command into wire messages for the given server description, one for
+// each batch of delete statements.
+func (d *Delete) Encode(desc description.SelectedServer) ([]wiremessage.WireMessage, error) {
+	err := d.encode(desc)
 	if err != nil {
 		return nil, err
 	}
 
-	return cmd.Encode(desc)
+	wms := make([]wiremessage.WireMessage, 0, len(d.batches))
+	for _, cmd := range d.batches {
+		wm, err := cmd.Encode(desc)
+		if err != nil {
+			return nil, err
+		}
+
+		wms = append(wms, wm)
+	}
+
+	return wms, nil
 }
 
-func (d *Delete) encode(desc description.SelectedServer) (*Write, error) {
+func (d *Delete) encode(desc description.SelectedServer) error {
 	if err := d.NS.Validate(); err != nil {
-		return nil, err
+		return err
 	}
 
+	for _, opt := range d.Opts {
+		if err := optionSupported(desc, opt); err != nil {
+			return err
+		}
+
+		if _, ok := opt.(option.OptCollation); ok {
+			for _, doc := range d.Deletes {
+				err := opt.Option(doc)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	batches, err := splitBatches(d.Deletes, int(desc.MaxBatchCount), int(desc.MaxDocumentSize), targetBatchSize(desc))
+	if err != nil {
+		return err
+	}
+
+	var offset int
+	for _, batch := range batches {
+		cmd, err := d.encodeBatch(batch, desc)
+		if err != nil {
+			return err
+		}
+
+		d.batches = append(d.batches, cmd)
+		d.offsets = append(d.offsets, offset)
+		offset += len(batch)
+	}
+	return nil
+}
+
+func (d *Delete) encodeBatch(docs []*bson.Document, desc description.SelectedServer) (*Write, error) {
 	command := bson.NewDocument(bson.EC.String("delete", d.NS.Collection))
 
 	arr := bson.NewArray()
-	for _, doc := range d.Deletes {
+	for _, doc := range docs {
 		arr.Append(bson.VC.Document(doc))
 	}
 	command.Append(bson.EC.Array("deletes", arr))
 
 	for _, opt := range d.Opts {
-		switch opt.(type) {
-		case nil:
-		case option.OptCollation:
-			for _, doc := range d.Deletes {
-				err := opt.Option(doc)
-				if err != nil {
-					return nil, err
-				}
+		var err error
+		switch t := opt.(type) {
+		case nil, option.OptCollation:
+			continue
+		case option.OptOrdered:
+			if !t {
+				d.continueOnError = true
 			}
+			err = opt.Option(command)
+		case option.OptComment:
+			err = appendComment(desc, command, t)
 		default:
-			err := opt.Option(command)
-			if err != nil {
-				return nil, err
-			}
+			err = opt.Option(command)
+		}
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -97,6 +150,7 @@ func (d *Delete) Decode(desc description.SelectedServer, wm wiremessage.WireMess
 }
 
 func (d *Delete) decode(desc description.SelectedServer, rdr bson.Reader) *Delete {
+	d.result = result.Delete{} // the fields of a previous batch's result are not overwritten if absent
 	d.err = bson.Unmarshal(rdr, &d.result)
 	return d
 }
@@ -112,17 +166,80 @@ func (d *Delete) Result() (result.Delete, error) {
 // Err returns the error set on this command.
 func (d *Delete) Err() error { return d.err }
 
-// RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
+// RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter. The
+// results of the batches are aggregated, and the indexes of write errors refer to the position of
+// their statement in Deletes. When a retryable write fails, the batches that succeeded are kept on
+// the command so that calling RoundTrip again resumes at the failed batch.
 func (d *Delete) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (result.Delete, error) {
-	cmd, err := d.encode(desc)
-	if err != nil {
-		return result.Delete{}, err
+	if d.batches == nil {
+		err := d.encode(desc)
+		if err != nil {
+			return result.Delete{}, err
+		}
 	}
 
-	rdr, err := cmd.RoundTrip(ctx, desc, rw)
-	if err != nil {
-		return result.Delete{}, err
+	// hold onto txnNumber, reset it when loop exits to ensure reuse of same
+	// transaction number if retry is needed
+	var txnNumber int64
+	retryWrite := d.Session != nil && d.Session.RetryWrite
+	if retryWrite {
+		txnNumber = d.Session.TxnNumber
 	}
+	offsets := d.offsets
+	for j, cmd := range d.batches {
+		rdr, err := cmd.RoundTrip(ctx, desc, rw)
+		if err != nil {
+			if retryWrite {
+				d.Session.TxnNumber = txnNumber + int64(j)
+			}
+			return d.acc, err
+		}
 
-	return d.decode(desc, rdr).Result()
+		r, err := d.decode(desc, rdr).Result()
+		if err != nil {
+			return d.acc, err
+		}
+
+		if r.WriteConcernError != nil && retryWrite {
+			// report writeconcernerror for retry, which resends this batch, so its result is not
+			// kept on the command
+			d.Session.TxnNumber = txnNumber + int64(j)
+			res := d.acc
+			addDeleteResult(&res, r, offsets[j])
+			return res, nil
+		}
+		addDeleteResult(&d.acc, r, offsets[j])
+
+		if !d.continueOnError && len(d.acc.WriteErrors) > 0 {
+			return d.acc, nil
+		}
+
+		// Increment txnNumber for each batch
+		if retryWrite {
+			d.Session.IncrementTxnNumber()
+			d.batches = d.batches[1:] // if batch encoded successfully, remove it from the slice
+			d.offsets = d.offsets[1:]
+		}
+	}
+
+	if retryWrite {
+		// if retryable write succeeded, transaction number will be incremented one extra time,
+		// so we decrement it here
+		d.Session.TxnNumber--
+	}
+
+	return d.acc, nil
+}
+
+// addDeleteResult adds the result r of the batch starting at offset to res. The indexes of write
+// errors are relative to the batch.
+func addDeleteResult(res *result.Delete, r result.Delete, offset int) {
+	for _, we := range r.WriteErrors {
+		we.Index += offset
+		res.WriteErrors = append(res.WriteErrors, we)
+	}
+	if r.WriteConcernError != nil {
+		res.WriteConcernError = r.WriteConcernError
+	}
+	res.N += r.N
 }
@@ -0,0 +1,43 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteBatches(t *testing.T) {
+	d := &Delete{
+		NS:   Namespace{DB: "db", Collection: "coll"},
+		Opts: []option.DeleteOptioner{option.OptOrdered(false)},
+	}
+	for n := 0; n < 5; n++ {
+		d.Deletes = append(d.Deletes, bson.NewDocument(
+			bson.EC.SubDocumentFromElements("q", bson.EC.Int32("x", int32(n))),
+			bson.EC.Int32("limit", 0),
+		))
+	}
+	conn := batchConn(t,
+		bson.NewDocument(bson.EC.Int32("ok", 1), bson.EC.Int32("n", 4)),
+		bson.NewDocument(bson.EC.Int32("ok", 1), bson.EC.Int32("n", 1),
+			bson.EC.ArrayFromElements("writeErrors", batchWriteError(1))),
+		bson.NewDocument(bson.EC.Int32("ok", 1), bson.EC.Int32("n", 3)),
+	)
+
+	res, err := d.RoundTrip(context.Background(), batchDesc, conn)
+	assert.NoError(t, err)
+	assert.Len(t, conn.Written, 3)
+	assert.Equal(t, 8, res.N)
+	if assert.Len(t, res.WriteErrors, 1) {
+		assert.Equal(t, 3, res.WriteErrors[0].Index)
+	}
+}
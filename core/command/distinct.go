@@ -63,7 +63,15 @@ func (d *Distinct) encode(desc description.SelectedServer) (*Read, error) {
 		if opt == nil {
 			continue
 		}
-		err := opt.Option(command)
+		if err := optionSupported(desc, opt); err != nil {
+			return nil, err
+		}
+		var err error
+		if c, ok := opt.(option.OptComment); ok {
+			err = appendComment(desc, command, c)
+		} else {
+			err = opt.Option(command)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
@@ -26,7 +27,7 @@ type DropCollection struct {
 	Clock        *session.ClusterClock
 	Session      *session.Client
 
-	result bson.Reader
+	result result.DropCollection
 	err    error
 }
 
@@ -67,14 +68,16 @@ func (dc *DropCollection) Decode(desc description.SelectedServer, wm wiremessage
 }
 
 func (dc *DropCollection) decode(desc description.SelectedServer, rdr bson.Reader) *DropCollection {
-	dc.result = rdr
+	dc.result = result.DropCollection{}
+	dc.err = decodeResult(rdr, &dc.result)
+	dc.result.Raw = rdr
 	return dc
 }
 
 // Result returns the result of a decoded wire message and server description.
-func (dc *DropCollection) Result() (bson.Reader, error) {
+func (dc *DropCollection) Result() (result.DropCollection, error) {
 	if dc.err != nil {
-		return nil, dc.err
+		return result.DropCollection{}, dc.err
 	}
 
 	return dc.result, nil
@@ -84,15 +87,15 @@ func (dc *DropCollection) Result() (bson.Reader, error) {
 func (dc *DropCollection) Err() error { return dc.err }
 
 // RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
-func (dc *DropCollection) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (bson.Reader, error) {
+func (dc *DropCollection) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (result.DropCollection, error) {
 	cmd, err := dc.encode(desc)
 	if err != nil {
-		return nil, err
+		return result.DropCollection{}, err
 	}
 
 	rdr, err := cmd.RoundTrip(ctx, desc, rw)
 	if err != nil {
-		return nil, err
+		return result.DropCollection{}, err
 	}
 
 	return dc.decode(desc, rdr).Result()
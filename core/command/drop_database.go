@@ -11,6 +11,7 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
@@ -25,7 +26,7 @@ type DropDatabase struct {
 	Clock        *session.ClusterClock
 	Session      *session.Client
 
-	result bson.Reader
+	result result.DropDatabase
 	err    error
 }
 
@@ -66,14 +67,16 @@ func (dd *DropDatabase) Decode(desc description.SelectedServer, wm wiremessage.W
 }
 
 func (dd *DropDatabase) decode(desc description.SelectedServer, rdr bson.Reader) *DropDatabase {
-	dd.result = rdr
+	dd.result = result.DropDatabase{}
+	dd.err = decodeResult(rdr, &dd.result)
+	dd.result.Raw = rdr
 	return dd
 }
 
 // Result returns the result of a decoded wire message and server description.
-func (dd *DropDatabase) Result() (bson.Reader, error) {
+func (dd *DropDatabase) Result() (result.DropDatabase, error) {
 	if dd.err != nil {
-		return nil, dd.err
+		return result.DropDatabase{}, dd.err
 	}
 
 	return dd.result, nil
@@ -83,15 +86,15 @@ func (dd *DropDatabase) Result() (bson.Reader, error) {
 func (dd *DropDatabase) Err() error { return dd.err }
 
 // RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
-func (dd *DropDatabase) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (bson.Reader, error) {
+func (dd *DropDatabase) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (result.DropDatabase, error) {
 	cmd, err := dd.encode(desc)
 	if err != nil {
-		return nil, err
+		return result.DropDatabase{}, err
 	}
 
 	rdr, err := cmd.RoundTrip(ctx, desc, rw)
 	if err != nil {
-		return nil, err
+		return result.DropDatabase{}, err
 	}
 
 	return dd.decode(desc, rdr).Result()
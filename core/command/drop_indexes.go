@@ -12,6 +12,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
@@ -28,7 +29,7 @@ type DropIndexes struct {
 	Clock        *session.ClusterClock
 	Session      *session.Client
 
-	result bson.Reader
+	result result.DropIndexes
 	err    error
 }
 
@@ -80,14 +81,16 @@ func (di *DropIndexes) Decode(desc description.SelectedServer, wm wiremessage.Wi
 }
 
 func (di *DropIndexes) decode(desc description.SelectedServer, rdr bson.Reader) *DropIndexes {
-	di.result = rdr
+	di.result = result.DropIndexes{}
+	di.err = decodeResult(rdr, &di.result)
+	di.result.Raw = rdr
 	return di
 }
 
 // Result returns the result of a decoded wire message and server description.
-func (di *DropIndexes) Result() (bson.Reader, error) {
+func (di *DropIndexes) Result() (result.DropIndexes, error) {
 	if di.err != nil {
-		return nil, di.err
+		return result.DropIndexes{}, di.err
 	}
 
 	return di.result, nil
@@ -97,16 +100,16 @@ func (di *DropIndexes) Result() (bson.Reader, error) {
 func (di *DropIndexes) Err() error { return di.err }
 
 // RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
-func (di *DropIndexes) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (bson.Reader, error) {
+func (di *DropIndexes) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (result.DropIndexes, error) {
 	cmd, err := di.encode(desc)
 	if err != nil {
-		return nil, err
+		return result.DropIndexes{}, err
 	}
 
-	di.result, err = cmd.RoundTrip(ctx, desc, rw)
+	rdr, err := cmd.RoundTrip(ctx, desc, rw)
 	if err != nil {
-		return nil, err
+		return result.DropIndexes{}, err
 	}
 
-	return di.Result()
+	return di.decode(desc, rdr).Result()
 }
@@ -30,6 +30,22 @@ var (
 	ErrDocumentTooLarge = errors.New("an inserted document is too large")
 	// ErrNonPrimaryRP occurs when a nonprimary read preference is used with a transaction.
 	ErrNonPrimaryRP = errors.New("read preference in a transaction must be primary")
+	// ErrLinearizableReadPref occurs when a linearizable read concern is used with a nonprimary read
+	// preference.
+	ErrLinearizableReadPref = errors.New("read preference must be primary for a linearizable read concern")
+	// ErrSnapshotReadConcern occurs when a snapshot read concern is used outside of a transaction.
+	ErrSnapshotReadConcern = errors.New("a snapshot read concern can only be used in a transaction")
+	// ErrMaxAwaitTimeNotTailable occurs when a maximum await time is set for an aggregation that does
+	// not open a tailable cursor, i.e. that is not a change stream.
+	ErrMaxAwaitTimeNotTailable = errors.New("maxAwaitTimeMS can only be set for a change stream aggregation")
+	// ErrServerAPIConflict occurs when a command sets apiVersion, apiStrict or apiDeprecationErrors
+	// and server API options are declared for the client.
+	ErrServerAPIConflict = errors.New("a command cannot set server API fields when server API options are declared")
+	// ErrInvalidOutputStage occurs when an aggregation ends with a $out or a $merge stage whose
+	// target is neither a non-empty collection name nor a document with non-empty db and coll strings.
+	ErrInvalidOutputStage = errors.New("the target of a $out or $merge stage must be a non-empty collection name or a document with non-empty db and coll strings")
+	// ErrNoCursor occurs when the reply to a command run for a cursor has no cursor document.
+	ErrNoCursor = errors.New("the command did not return a cursor: its reply has no cursor document")
 	// UnknownTransactionCommitResult is an error label for unknown transaction commit results.
 	UnknownTransactionCommitResult = "UnknownTransactionCommitResult"
 	// TransientTransactionError is an error label for transient errors with transactions.
@@ -70,12 +86,21 @@ func (e ResponseError) Error() string {
 	return fmt.Sprintf("%s", e.Message)
 }
 
-// Error is a command execution error from the database.
+// Unwrap returns the error that caused the response to be rejected, if any.
+func (e ResponseError) Unwrap() error { return e.Wrapped }
+
+// Error is a command execution error from the database. Errors that did not originate from the
+// database, such as network errors, are kept in Wrapped.
 type Error struct {
 	Code    int32
 	Message string
 	Labels  []string
 	Name    string
+	Wrapped error
+
+	// Raw is the reply of a command that failed with ok: 0, which may hold more details than the
+	// other fields. It is nil for errors that did not come from a reply.
+	Raw bson.Reader
 }
 
 // Error implements the error interface.
@@ -86,6 +111,12 @@ func (e Error) Error() string {
 	return e.Message
 }
 
+// Unwrap returns the underlying error, if any.
+func (e Error) Unwrap() error { return e.Wrapped }
+
+// ErrorCode returns the server error code, or 0 if the error did not come from the server.
+func (e Error) ErrorCode() int32 { return e.Code }
+
 // HasErrorLabel returns true if the error contains the specified label.
 func (e Error) HasErrorLabel(label string) bool {
 	if e.Labels != nil {
@@ -131,8 +162,9 @@ func IsWriteConcernErrorRetryable(wce *result.WriteConcernError) bool {
 	return false
 }
 
-// IsNotFound indicates if the error is from a namespace not being found.
+// IsNotFound indicates if the error is from a namespace not being found. Servers older than 3.2
+// reply without a code in that case.
 func IsNotFound(err error) bool {
-	e, ok := err.(Error)
-	return ok && (e.Code == 26)
+	var e Error
+	return errors.As(err, &e) && (e.Code == 26 || e.Code == 0 && e.Message == "ns not found")
 }
@@ -0,0 +1,65 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/connection"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorWrapping(t *testing.T) {
+	newConn := func() *internal.ChannelConn {
+		return &internal.ChannelConn{
+			T:        t,
+			Written:  make(chan wiremessage.WireMessage, 1),
+			ReadResp: make(chan wiremessage.WireMessage, 1),
+			ReadErr:  make(chan error, 1),
+		}
+	}
+
+	t.Run("network errors keep the connection error", func(t *testing.T) {
+		conn := newConn()
+		conn.ReadErr <- connection.Error{ConnectionID: "faked", Wrapped: context.DeadlineExceeded}
+
+		cmd := &Read{DB: "foo", Command: bson.NewDocument(bson.EC.Int32("ping", 1))}
+		_, err := cmd.RoundTrip(context.Background(), description.SelectedServer{}, conn)
+
+		var cerr Error
+		require.True(t, errors.As(err, &cerr))
+		require.True(t, cerr.HasErrorLabel(NetworkError))
+		var connErr connection.Error
+		require.True(t, errors.As(err, &connErr))
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+	t.Run("server errors", func(t *testing.T) {
+		conn := newConn()
+		conn.ReadResp <- internal.MakeReply(t, bson.NewDocument(
+			bson.EC.Int32("ok", 0),
+			bson.EC.Int32("code", 26),
+			bson.EC.String("errmsg", "ns not found"),
+		))
+
+		cmd := &Write{DB: "foo", Command: bson.NewDocument(bson.EC.String("drop", "bar"))}
+		_, err := cmd.RoundTrip(context.Background(), description.SelectedServer{}, conn)
+
+		var cerr Error
+		require.True(t, errors.As(err, &cerr))
+		require.Equal(t, int32(26), cerr.Code)
+		require.True(t, IsNotFound(err))
+
+		require.True(t, IsNotFound(Error{Message: "ns not found"}))
+		require.False(t, IsNotFound(Error{Code: 13, Message: "ns not found"}))
+	})
+}
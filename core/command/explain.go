@@ -0,0 +1,48 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+)
+
+// Explain returns a read command that explains this find command with the given verbosity. Options
+// that depend on the server version are checked by the server rather than by the driver.
+func (f *Find) Explain(verbosity string) (Read, error) {
+	cmd, err := f.encode(description.SelectedServer{})
+	if err != nil {
+		return Read{}, err
+	}
+
+	return explain(cmd, verbosity), nil
+}
+
+// Explain returns a read command that explains this aggregate command with the given verbosity.
+// Options that depend on the server version are checked by the server rather than by the driver.
+func (a *Aggregate) Explain(verbosity string) (Read, error) {
+	cmd, err := a.encode(description.SelectedServer{})
+	if err != nil {
+		return Read{}, err
+	}
+
+	// explain does not perform writes, so a $out stage is never acknowledged
+	cmd.Command.Delete("writeConcern")
+	return explain(cmd, verbosity), nil
+}
+
+// explain wraps the command of cmd in an explain command. The read concern is dropped since explain
+// only accepts the default one.
+func explain(cmd *Read, verbosity string) Read {
+	cmd.Command = bson.NewDocument(
+		bson.EC.SubDocument("explain", cmd.Command),
+		bson.EC.String("verbosity", verbosity),
+	)
+	cmd.ReadConcern = nil
+
+	return *cmd
+}
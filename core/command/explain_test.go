@@ -0,0 +1,78 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/writeconcern"
+)
+
+func TestExplain(t *testing.T) {
+	ns := Namespace{DB: "db", Collection: "coll"}
+
+	t.Run("find", func(t *testing.T) {
+		find := &Find{
+			NS:          ns,
+			Filter:      bson.NewDocument(bson.EC.Int32("x", 1)),
+			Opts:        []option.FindOptioner{option.OptLimit(5)},
+			ReadConcern: readconcern.Majority(),
+		}
+
+		cmd, err := find.Explain("executionStats")
+		noerr(t, err)
+
+		if cmd.DB != "db" {
+			t.Errorf("Expected database db, got %s", cmd.DB)
+		}
+		if cmd.ReadConcern != nil {
+			t.Errorf("Expected no read concern, got %v", cmd.ReadConcern)
+		}
+		if key := cmd.Command.ElementAt(0).Key(); key != "explain" {
+			t.Errorf("Expected explain to be the first field, got %s", key)
+		}
+		if verbosity := cmd.Command.Lookup("verbosity").StringValue(); verbosity != "executionStats" {
+			t.Errorf("Expected verbosity executionStats, got %s", verbosity)
+		}
+
+		inner := cmd.Command.Lookup("explain").MutableDocument()
+		if coll := inner.Lookup("find").StringValue(); coll != "coll" {
+			t.Errorf("Expected find on coll, got %s", coll)
+		}
+		if limit := inner.Lookup("limit").Int64(); limit != 5 {
+			t.Errorf("Expected limit 5, got %d", limit)
+		}
+	})
+
+	t.Run("aggregate", func(t *testing.T) {
+		agg := &Aggregate{
+			NS: ns,
+			Pipeline: bson.NewArray(
+				bson.VC.DocumentFromElements(bson.EC.SubDocumentFromElements("$match", bson.EC.Int32("x", 1))),
+				bson.VC.DocumentFromElements(bson.EC.String("$out", "other")),
+			),
+			WriteConcern: writeconcern.New(writeconcern.WMajority()),
+		}
+
+		cmd, err := agg.Explain("queryPlanner")
+		noerr(t, err)
+
+		inner := cmd.Command.Lookup("explain").MutableDocument()
+		if coll := inner.Lookup("aggregate").StringValue(); coll != "coll" {
+			t.Errorf("Expected aggregate on coll, got %s", coll)
+		}
+		if n := countKey(inner, "writeConcern"); n != 0 {
+			t.Errorf("Expected no write concern, found %d", n)
+		}
+		if verbosity := cmd.Command.Lookup("verbosity").StringValue(); verbosity != "queryPlanner" {
+			t.Errorf("Expected verbosity queryPlanner, got %s", verbosity)
+		}
+	})
+}
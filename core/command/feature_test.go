@@ -0,0 +1,106 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/option"
+)
+
+func TestUnsupportedFeature(t *testing.T) {
+	ns := Namespace{DB: "db", Collection: "coll"}
+	filter := bson.NewDocument(bson.EC.Int32("x", 1))
+	collation := option.OptCollation{Collation: &option.Collation{Locale: "fr"}}
+	arrayFilters := option.OptArrayFilters{bson.NewDocument(bson.EC.Int32("e.x", 1))}
+
+	newServer := func(maxWireVersion int32) description.SelectedServer {
+		return description.SelectedServer{
+			Server: description.Server{
+				Addr:            "localhost:27017",
+				WireVersion:     &description.VersionRange{Max: maxWireVersion},
+				MaxBatchCount:   1000,
+				MaxDocumentSize: 16 * 1024 * 1024,
+			},
+		}
+	}
+
+	commands := []struct {
+		name    string
+		feature description.Feature
+		encode  func(desc description.SelectedServer) error
+	}{
+		{
+			"find collation",
+			description.FeatureCollation,
+			func(desc description.SelectedServer) error {
+				_, err := (&Find{NS: ns, Filter: filter, Opts: []option.FindOptioner{collation}}).encode(desc)
+				return err
+			},
+		},
+		{
+			"count collation",
+			description.FeatureCollation,
+			func(desc description.SelectedServer) error {
+				_, err := (&Count{NS: ns, Query: filter, Opts: []option.CountOptioner{collation}}).encode(desc)
+				return err
+			},
+		},
+		{
+			"distinct collation",
+			description.FeatureCollation,
+			func(desc description.SelectedServer) error {
+				_, err := (&Distinct{NS: ns, Field: "x", Opts: []option.DistinctOptioner{collation}}).encode(desc)
+				return err
+			},
+		},
+		{
+			"delete collation",
+			description.FeatureCollation,
+			func(desc description.SelectedServer) error {
+				return (&Delete{
+					NS:      ns,
+					Deletes: []*bson.Document{bson.NewDocument(bson.EC.SubDocument("q", filter), bson.EC.Int32("limit", 0))},
+					Opts:    []option.DeleteOptioner{collation},
+				}).encode(desc)
+			},
+		},
+		{
+			"findAndModify arrayFilters",
+			description.FeatureArrayFilters,
+			func(desc description.SelectedServer) error {
+				_, err := (&FindOneAndUpdate{
+					NS:     ns,
+					Query:  filter,
+					Update: bson.NewDocument(bson.EC.SubDocumentFromElements("$set", bson.EC.Int32("e.$[e].y", 2))),
+					Opts:   []option.FindOneAndUpdateOptioner{arrayFilters},
+				}).encode(desc)
+				return err
+			},
+		},
+	}
+
+	for _, tc := range commands {
+		t.Run(tc.name, func(t *testing.T) {
+			required := tc.feature.MinWireVersion()
+			noerr(t, tc.encode(newServer(required)))
+
+			err := tc.encode(newServer(required - 1))
+			want := description.ErrUnsupportedServerVersion{
+				Feature:             tc.feature,
+				RequiredWireVersion: required,
+				ServerWireVersion:   required - 1,
+				ServerAddress:       "localhost:27017",
+			}
+			if err != want {
+				t.Errorf("Incorrect error. got %v; want %v", err, want)
+			}
+		})
+	}
+}
@@ -10,14 +10,14 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/csot"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/option"
 	"github.com/mongodb/mongo-go-driver/core/readconcern"
 	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // Find represents the find command.
@@ -59,20 +59,33 @@ func (f *Find) encode(desc description.SelectedServer) (*Read, error) {
 
 	var limit int64
 	var batchSize int32
+	var singleBatch bool
 	var err error
 
 	for _, opt := range f.Opts {
+		if err = optionSupported(desc, opt); err != nil {
+			return nil, err
+		}
+
 		switch t := opt.(type) {
 		case nil, option.OptMaxAwaitTime:
 			continue
 		case option.OptLimit:
 			limit = int64(t)
-			err = opt.Option(command)
+			if limit < 0 {
+				// a negative limit asks for a single batch, which the find command expresses with
+				// singleBatch rather than the sign of the limit
+				limit = -limit
+				singleBatch = true
+			}
+			err = option.OptLimit(limit).Option(command)
 		case option.OptBatchSize:
 			batchSize = int32(t)
 			err = opt.Option(command)
 		case option.OptProjection:
 			err = t.Option(command)
+		case option.OptComment:
+			err = appendComment(desc, command, t)
 		default:
 			err = opt.Option(command)
 		}
@@ -81,7 +94,7 @@ func (f *Find) encode(desc description.SelectedServer) (*Read, error) {
 		}
 	}
 
-	if limit != 0 && batchSize != 0 && limit <= int64(batchSize) {
+	if singleBatch || limit != 0 && batchSize != 0 && limit <= int64(batchSize) {
 		command.Append(bson.EC.Boolean("singleBatch", true))
 	}
 
@@ -104,26 +117,41 @@ func (f *Find) Decode(desc description.SelectedServer, cb CursorBuilder, wm wire
 		return f
 	}
 
-	return f.decode(desc, cb, rdr)
+	return f.decode(context.Background(), desc, cb, rdr)
 }
 
-func (f *Find) decode(desc description.SelectedServer, cb CursorBuilder, rdr bson.Reader) *Find {
+func (f *Find) decode(ctx context.Context, desc description.SelectedServer, cb CursorBuilder, rdr bson.Reader) *Find {
+	var tailableAwait bool
+	for _, opt := range f.Opts {
+		if ct, ok := opt.(option.OptCursorType); ok && option.CursorType(ct) == option.TailableAwait {
+			tailableAwait = true
+		}
+	}
+	if tailableAwait {
+		// a tailable await cursor can be iterated indefinitely, so each getMore gets a fresh budget
+		ctx = csot.WithIteration(ctx)
+	}
+
 	opts := make([]option.CursorOptioner, 0)
 	for _, opt := range f.Opts {
 		curOpt, ok := opt.(option.CursorOptioner)
 		if !ok {
 			continue
 		}
+		if _, ok := opt.(option.OptMaxAwaitTime); ok && !tailableAwait {
+			// only the getMores of a tailable await cursor wait for new documents
+			continue
+		}
 		opts = append(opts, curOpt)
 	}
 
 	labels, err := getErrorLabels(&rdr)
 	f.err = err
 
-	res, err := cb.BuildCursor(rdr, f.Session, f.Clock, opts...)
+	res, err := cb.BuildCursor(ctx, rdr, f.Session, f.Clock, opts...)
 	f.result = res
 	if err != nil {
-		f.err = Error{Message: err.Error(), Labels: labels}
+		f.err = Error{Message: err.Error(), Labels: labels, Wrapped: err}
 	}
 	return f
 }
@@ -142,26 +170,26 @@ func (f *Find) Err() error { return f.err }
 
 // RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
 func (f *Find) RoundTrip(ctx context.Context, desc description.SelectedServer, cb CursorBuilder, rw wiremessage.ReadWriter) (Cursor, error) {
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/command.(*Find).RoundTrip")
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/command.(*Find).RoundTrip")
 	defer span.End()
 
 	cmd, err := f.encode(desc)
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
 	rdr, err := cmd.RoundTrip(ctx, desc, rw)
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
 	span.Annotatef(nil, "Invoking Decode")
-	cur, err := f.decode(desc, cb, rdr).Result()
+	cur, err := f.decode(ctx, desc, cb, rdr).Result()
 	span.Annotatef(nil, "Finished Decode")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 	}
 	return cur, err
 }
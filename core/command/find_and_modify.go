@@ -17,20 +17,20 @@ import (
 func unmarshalFindAndModifyResult(rdr bson.Reader) (result.FindAndModify, error) {
 	var res result.FindAndModify
 
+	// the value is null or omitted if no document matched
 	val, err := rdr.Lookup("value")
 	switch {
 	case err == bson.ErrElementNotFound:
-		return result.FindAndModify{}, errors.New("invalid response from server, no value field")
 	case err != nil:
 		return result.FindAndModify{}, err
-	}
-
-	switch val.Value().Type() {
-	case bson.TypeNull:
-	case bson.TypeEmbeddedDocument:
-		res.Value = val.Value().ReaderDocument()
 	default:
-		return result.FindAndModify{}, errors.New("invalid response from server, 'value' field is not a document")
+		switch val.Value().Type() {
+		case bson.TypeNull:
+		case bson.TypeEmbeddedDocument:
+			res.Value = val.Value().ReaderDocument()
+		default:
+			return result.FindAndModify{}, errors.New("invalid response from server, 'value' field is not a document")
+		}
 	}
 
 	if val, err := rdr.Lookup("lastErrorObject", "updatedExisting"); err == nil {
@@ -41,10 +41,23 @@ func unmarshalFindAndModifyResult(rdr bson.Reader) (result.FindAndModify, error)
 	}
 
 	if val, err := rdr.Lookup("lastErrorObject", "upserted"); err == nil {
-		oid, ok := val.Value().ObjectIDOK()
-		if ok {
-			res.LastErrorObject.Upserted = oid
+		res.LastErrorObject.Upserted = val.Value().Interface()
+	}
+
+	// findAndModify reports write concern failures alongside the value rather than in a
+	// write result, so the error has to be pulled out of the top level of the reply.
+	if val, err := rdr.Lookup("writeConcernError"); err == nil {
+		doc, ok := val.Value().ReaderDocumentOK()
+		if !ok {
+			return result.FindAndModify{}, errors.New("invalid response from server, 'writeConcernError' field is not a document")
+		}
+
+		var wce result.WriteConcernError
+		if err = bson.Unmarshal(doc, &wce); err != nil {
+			return result.FindAndModify{}, err
 		}
+		res.WriteConcernError = &wce
 	}
+
 	return res, nil
 }
@@ -0,0 +1,88 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalFindAndModifyResult(t *testing.T) {
+	t.Run("write concern error with errInfo", func(t *testing.T) {
+		rdr, err := bson.NewDocument(
+			bson.EC.SubDocumentFromElements("value", bson.EC.Int32("_id", 1)),
+			bson.EC.SubDocumentFromElements("writeConcernError",
+				bson.EC.Int32("code", 64),
+				bson.EC.String("errmsg", "waiting for replication timed out"),
+				bson.EC.SubDocumentFromElements("errInfo", bson.EC.Boolean("wtimeout", true)),
+			),
+			bson.EC.Int32("ok", 1),
+		).MarshalBSON()
+		noerr(t, err)
+
+		res, err := unmarshalFindAndModifyResult(rdr)
+		noerr(t, err)
+		require.NotNil(t, res.Value)
+		require.NotNil(t, res.WriteConcernError)
+		require.Equal(t, 64, res.WriteConcernError.Code)
+		require.Equal(t, "waiting for replication timed out", res.WriteConcernError.ErrMsg)
+
+		wtimeout, err := res.WriteConcernError.ErrInfo.Lookup("wtimeout")
+		noerr(t, err)
+		require.True(t, wtimeout.Value().Boolean())
+	})
+	t.Run("no write concern error", func(t *testing.T) {
+		rdr, err := bson.NewDocument(
+			bson.EC.Null("value"),
+			bson.EC.Int32("ok", 1),
+		).MarshalBSON()
+		noerr(t, err)
+
+		res, err := unmarshalFindAndModifyResult(rdr)
+		noerr(t, err)
+		require.Nil(t, res.Value)
+		require.Nil(t, res.WriteConcernError)
+	})
+	t.Run("value omitted", func(t *testing.T) {
+		rdr, err := bson.NewDocument(bson.EC.Int32("ok", 1)).MarshalBSON()
+		noerr(t, err)
+
+		res, err := unmarshalFindAndModifyResult(rdr)
+		noerr(t, err)
+		require.Nil(t, res.Value)
+	})
+	t.Run("upserted", func(t *testing.T) {
+		rdr, err := bson.NewDocument(
+			bson.EC.SubDocumentFromElements("value", bson.EC.String("_id", "a")),
+			bson.EC.SubDocumentFromElements("lastErrorObject",
+				bson.EC.Int32("n", 1),
+				bson.EC.Boolean("updatedExisting", false),
+				bson.EC.String("upserted", "a"),
+			),
+			bson.EC.Int32("ok", 1),
+		).MarshalBSON()
+		noerr(t, err)
+
+		res, err := unmarshalFindAndModifyResult(rdr)
+		noerr(t, err)
+		require.False(t, res.LastErrorObject.UpdatedExisting)
+		require.Equal(t, "a", res.LastErrorObject.Upserted)
+	})
+	t.Run("invalid write concern error", func(t *testing.T) {
+		rdr, err := bson.NewDocument(
+			bson.EC.Null("value"),
+			bson.EC.String("writeConcernError", "oops"),
+			bson.EC.Int32("ok", 1),
+		).MarshalBSON()
+		noerr(t, err)
+
+		_, err = unmarshalFindAndModifyResult(rdr)
+		require.Error(t, err)
+	})
+}
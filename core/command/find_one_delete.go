@@ -16,8 +16,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // FindOneAndDelete represents the findOneAndDelete operation.
@@ -60,7 +59,15 @@ func (f *FindOneAndDelete) encode(desc description.SelectedServer) (*Write, erro
 		if opt == nil {
 			continue
 		}
-		err := opt.Option(command)
+		if err := optionSupported(desc, opt); err != nil {
+			return nil, err
+		}
+		var err error
+		if c, ok := opt.(option.OptComment); ok {
+			err = appendComment(desc, command, c)
+		} else {
+			err = opt.Option(command)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -105,20 +112,20 @@ func (f *FindOneAndDelete) Err() error { return f.err }
 
 // RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
 func (f *FindOneAndDelete) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (result.FindAndModify, error) {
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/command.(*FindOneAndDelete).RoundTrip")
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/command.(*FindOneAndDelete).RoundTrip")
 	defer span.End()
 
 	span.Annotatef(nil, "Encoding")
 	cmd, err := f.encode(desc)
 	span.Annotatef(nil, "Finished encoding")
 	if err != nil {
-		span.SetStatus(trace.Status{Message: err.Error(), Code: int32(trace.StatusCodeInternal)})
+		span.SetStatus(observability.SpanStatus(err))
 		return result.FindAndModify{}, err
 	}
 
 	rdr, err := cmd.RoundTrip(ctx, desc, rw)
 	if err != nil {
-		span.SetStatus(trace.Status{Message: err.Error(), Code: int32(trace.StatusCodeInternal)})
+		span.SetStatus(observability.SpanStatus(err))
 		return result.FindAndModify{}, err
 	}
 
@@ -126,7 +133,7 @@ func (f *FindOneAndDelete) RoundTrip(ctx context.Context, desc description.Selec
 	rfRes, err := f.decode(desc, rdr).Result()
 	span.Annotatef(nil, "Finished decoding")
 	if err != nil {
-		span.SetStatus(trace.Status{Message: err.Error(), Code: int32(trace.StatusCodeInternal)})
+		span.SetStatus(observability.SpanStatus(err))
 	}
 
 	return rfRes, err
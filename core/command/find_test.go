@@ -0,0 +1,96 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/bsonassert"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/option"
+)
+
+func TestFindOptions(t *testing.T) {
+	desc := description.SelectedServer{
+		Server: description.Server{WireVersion: &description.VersionRange{Max: 13}},
+	}
+	ns := Namespace{DB: "db", Collection: "coll"}
+
+	testCases := []struct {
+		name string
+		opt  option.FindOptioner
+		elem *bson.Element
+	}{
+		{"allowPartialResults", option.OptAllowPartialResults(true), bson.EC.Boolean("allowPartialResults", true)},
+		{"noCursorTimeout", option.OptNoCursorTimeout(true), bson.EC.Boolean("noCursorTimeout", true)},
+		{"oplogReplay", option.OptOplogReplay(true), bson.EC.Boolean("oplogReplay", true)},
+		{"returnKey", option.OptReturnKey(true), bson.EC.Boolean("returnKey", true)},
+		{"showRecordId", option.OptShowRecordID(true), bson.EC.Boolean("showRecordId", true)},
+		{
+			"let",
+			option.OptLet{Let: bson.NewDocument(bson.EC.Int32("x", 1))},
+			bson.EC.SubDocumentFromElements("let", bson.EC.Int32("x", 1)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			find := &Find{NS: ns, Filter: bson.NewDocument(), Opts: []option.FindOptioner{tc.opt}}
+
+			cmd, err := find.encode(desc)
+			noerr(t, err)
+
+			elem, err := cmd.Command.LookupElementErr(tc.elem.Key())
+			noerr(t, err)
+			bsonassert.Equal(t, bson.NewDocument(tc.elem), bson.NewDocument(elem))
+		})
+	}
+
+	t.Run("let requires 5.0", func(t *testing.T) {
+		find := &Find{
+			NS:     ns,
+			Filter: bson.NewDocument(),
+			Opts:   []option.FindOptioner{option.OptLet{Let: bson.NewDocument(bson.EC.Int32("x", 1))}},
+		}
+
+		old := description.SelectedServer{
+			Server: description.Server{WireVersion: &description.VersionRange{Max: 12}},
+		}
+		if _, err := find.encode(old); err == nil {
+			t.Errorf("Expected an error for a server older than 5.0")
+		}
+	})
+	t.Run("negative limit", func(t *testing.T) {
+		for _, opts := range [][]option.FindOptioner{
+			{option.OptLimit(-3)},
+			{option.OptLimit(-3), option.OptBatchSize(5)},
+		} {
+			find := &Find{NS: ns, Filter: bson.NewDocument(), Opts: opts}
+
+			cmd, err := find.encode(desc)
+			noerr(t, err)
+
+			if limit := cmd.Command.Lookup("limit").Int64(); limit != 3 {
+				t.Errorf("Expected a limit of 3, got %d", limit)
+			}
+			count := 0
+			itr := cmd.Command.Iterator()
+			for itr.Next() {
+				if itr.Element().Key() == "singleBatch" {
+					count++
+					if !itr.Element().Value().Boolean() {
+						t.Errorf("Expected singleBatch to be true")
+					}
+				}
+			}
+			if count != 1 {
+				t.Errorf("Expected singleBatch to be set once, got %d", count)
+			}
+		}
+	})
+}
@@ -51,6 +51,11 @@ func (gm *GetMore) encode(desc description.SelectedServer) (*Read, error) {
 		switch t := opt.(type) {
 		case option.OptMaxAwaitTime:
 			err = option.OptMaxTime(t).Option(cmd)
+		case option.OptComment:
+			// getMore accepts a comment as of 4.4
+			if desc.WireVersion != nil && desc.WireVersion.Includes(9) {
+				err = opt.Option(cmd)
+			}
 		default:
 			err = opt.Option(cmd)
 		}
@@ -14,6 +14,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/address"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/result"
+	"github.com/mongodb/mongo-go-driver/core/serverapi"
 	"github.com/mongodb/mongo-go-driver/core/version"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 )
@@ -26,6 +27,7 @@ type Handshake struct {
 	Client             *bson.Document
 	Compressors        []string
 	SaslSupportedMechs string
+	ServerAPI          *serverapi.Options
 
 	ismstr result.IsMaster
 	err    error
@@ -38,6 +40,7 @@ func (h *Handshake) Encode() (wiremessage.WireMessage, error) {
 		Client:             h.Client,
 		Compressors:        h.Compressors,
 		SaslSupportedMechs: h.SaslSupportedMechs,
+		ServerAPI:          h.ServerAPI,
 	}).Encode()
 	if err != nil {
 		return wm, err
@@ -16,8 +16,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // this is the amount of reserved buffer space in a message that the
@@ -39,12 +38,20 @@ type Insert struct {
 	Session      *session.Client
 
 	batches         []*Write
+	offsets         []int // the index in Docs of the first document of each batch
 	result          result.Insert
 	err             error
 	continueOnError bool
 }
 
 func (i *Insert) split(maxCount, targetBatchSize int) ([][]*bson.Document, error) {
+	return splitBatches(i.Docs, maxCount, targetBatchSize, targetBatchSize)
+}
+
+// splitBatches splits docs into batches of at most maxCount documents whose total size, once space
+// is reserved for the rest of the command, does not exceed targetBatchSize. ErrDocumentTooLarge is
+// returned if a document is larger than maxDocumentSize or cannot fit in a batch.
+func splitBatches(docs []*bson.Document, maxCount, maxDocumentSize, targetBatchSize int) ([][]*bson.Document, error) {
 	batches := [][]*bson.Document{}
 
 	if targetBatchSize > reservedCommandBufferBytes {
@@ -61,13 +68,13 @@ splitInserts:
 		size := 0
 		batch := []*bson.Document{}
 	assembleBatch:
-		for idx := startAt; idx < len(i.Docs); idx++ {
-			itsize, err := i.Docs[idx].Validate()
+		for idx := startAt; idx < len(docs); idx++ {
+			itsize, err := docs[idx].Validate()
 			if err != nil {
 				return nil, err
 			}
 
-			if int(itsize) > targetBatchSize {
+			if int(itsize) > maxDocumentSize || int(itsize) > targetBatchSize {
 				return nil, ErrDocumentTooLarge
 			}
 			if size+int(itsize) > targetBatchSize {
@@ -75,14 +82,14 @@ splitInserts:
 			}
 
 			size += int(itsize)
-			batch = append(batch, i.Docs[idx])
+			batch = append(batch, docs[idx])
 			startAt++
 			if len(batch) == maxCount {
 				break assembleBatch
 			}
 		}
 		batches = append(batches, batch)
-		if startAt == len(i.Docs) {
+		if startAt == len(docs) {
 			break splitInserts
 		}
 	}
@@ -90,6 +97,18 @@ splitInserts:
 	return batches, nil
 }
 
+// targetBatchSize returns the maximum size of the documents of a write batch sent to the server
+// described by desc. With OP_MSG the documents are sent as a document sequence rather than as part
+// of the command document, so a batch is bounded by the maximum message size instead of the maximum
+// document size.
+func targetBatchSize(desc description.SelectedServer) int {
+	if desc.WireVersion != nil && desc.WireVersion.Max >= wiremessage.OpmsgWireVersion && desc.MaxMessageSize > 0 {
+		return int(desc.MaxMessageSize)
+	}
+
+	return int(desc.MaxDocumentSize)
+}
+
 // Encode will encode this command into a wire message for the given server description.
 func (i *Insert) Encode(desc description.SelectedServer) ([]wiremessage.WireMessage, error) {
 	err := i.encode(desc)
@@ -97,7 +116,7 @@ func (i *Insert) Encode(desc description.SelectedServer) ([]wiremessage.WireMess
 		return nil, err
 	}
 
-	wms := make([]wiremessage.WireMessage, len(i.batches))
+	wms := make([]wiremessage.WireMessage, 0, len(i.batches))
 	for _, cmd := range i.batches {
 		wm, err := cmd.Encode(desc)
 		if err != nil {
@@ -124,14 +143,22 @@ func (i *Insert) encodeBatch(docs []*bson.Document, desc description.SelectedSer
 		if opt == nil {
 			continue
 		}
+		if err := optionSupported(desc, opt); err != nil {
+			return nil, err
+		}
 
-		if ordered, ok := opt.(option.OptOrdered); ok {
-			if !ordered {
+		var err error
+		switch t := opt.(type) {
+		case option.OptOrdered:
+			if !t {
 				i.continueOnError = true
 			}
+			err = opt.Option(command)
+		case option.OptComment:
+			err = appendComment(desc, command, t)
+		default:
+			err = opt.Option(command)
 		}
-
-		err := opt.Option(command)
 		if err != nil {
 			return nil, err
 		}
@@ -147,11 +174,12 @@ func (i *Insert) encodeBatch(docs []*bson.Document, desc description.SelectedSer
 }
 
 func (i *Insert) encode(desc description.SelectedServer) error {
-	batches, err := i.split(int(desc.MaxBatchCount), int(desc.MaxDocumentSize))
+	batches, err := splitBatches(i.Docs, int(desc.MaxBatchCount), int(desc.MaxDocumentSize), targetBatchSize(desc))
 	if err != nil {
 		return err
 	}
 
+	var offset int
 	for _, docs := range batches {
 		cmd, err := i.encodeBatch(docs, desc)
 		if err != nil {
@@ -159,6 +187,8 @@ func (i *Insert) encode(desc description.SelectedServer) error {
 		}
 
 		i.batches = append(i.batches, cmd)
+		i.offsets = append(i.offsets, offset)
+		offset += len(docs)
 	}
 	return nil
 }
@@ -176,6 +206,7 @@ func (i *Insert) Decode(desc description.SelectedServer, wm wiremessage.WireMess
 }
 
 func (i *Insert) decode(desc description.SelectedServer, rdr bson.Reader) *Insert {
+	i.result = result.Insert{} // the fields of a previous batch's result are not overwritten if absent
 	i.err = bson.Unmarshal(rdr, &i.result)
 	return i
 }
@@ -193,7 +224,7 @@ func (i *Insert) Err() error { return i.err }
 
 // RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
 func (i *Insert) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (result.Insert, error) {
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/command.(*Insert).RoundTrip")
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/command.(*Insert).RoundTrip")
 	defer span.End()
 
 	res := result.Insert{}
@@ -202,7 +233,7 @@ func (i *Insert) RoundTrip(ctx context.Context, desc description.SelectedServer,
 		err := i.encode(desc)
 		span.Annotatef(nil, "Finished encoding")
 		if err != nil {
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			span.SetStatus(observability.SpanStatus(err))
 			return res, err
 		}
 	}
@@ -213,10 +244,11 @@ func (i *Insert) RoundTrip(ctx context.Context, desc description.SelectedServer,
 	if i.Session != nil && i.Session.RetryWrite {
 		txnNumber = i.Session.TxnNumber
 	}
+	offsets := i.offsets
 	for j, cmd := range i.batches {
 		rdr, err := cmd.RoundTrip(ctx, desc, rw)
 		if err != nil {
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			span.SetStatus(observability.SpanStatus(err))
 			if i.Session != nil && i.Session.RetryWrite {
 				i.Session.TxnNumber = txnNumber + int64(j)
 			}
@@ -225,11 +257,15 @@ func (i *Insert) RoundTrip(ctx context.Context, desc description.SelectedServer,
 
 		r, err := i.decode(desc, rdr).Result()
 		if err != nil {
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			span.SetStatus(observability.SpanStatus(err))
 			return res, err
 		}
 
-		res.WriteErrors = append(res.WriteErrors, r.WriteErrors...)
+		// the indexes of write errors are relative to the batch
+		for _, we := range r.WriteErrors {
+			we.Index += offsets[j]
+			res.WriteErrors = append(res.WriteErrors, we)
+		}
 
 		if r.WriteConcernError != nil {
 			res.WriteConcernError = r.WriteConcernError
@@ -249,6 +285,7 @@ func (i *Insert) RoundTrip(ctx context.Context, desc description.SelectedServer,
 		if i.Session != nil && i.Session.RetryWrite {
 			i.Session.IncrementTxnNumber()
 			i.batches = i.batches[1:] // if batch encoded successfully, remove it from the slice
+			i.offsets = i.offsets[1:]
 		}
 	}
 
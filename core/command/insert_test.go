@@ -7,10 +7,14 @@
 package command
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -92,4 +96,91 @@ func TestInsertCommandSplitting(t *testing.T) {
 			t.Errorf("Expected a too large error. got %v; want %v", err, ErrDocumentTooLarge)
 		}
 	})
+	t.Run("document_larger_than_max_document_size", func(t *testing.T) {
+		docs := []*bson.Document{bson.NewDocument(bson.EC.String("a", "bcdefghijklmnopqrstuvwxyz"))}
+		_, err := splitBatches(docs, 100, 16, 100*megabyte)
+		if err != ErrDocumentTooLarge {
+			t.Errorf("Expected a too large error. got %v; want %v", err, ErrDocumentTooLarge)
+		}
+	})
+	t.Run("op_msg_batches_are_bounded_by_message_size", func(t *testing.T) {
+		i := &Insert{NS: Namespace{DB: "db", Collection: "coll"}}
+		for n := 0; n < 6; n++ {
+			i.Docs = append(i.Docs, bson.NewDocument(bson.EC.Binary("a", make([]byte, 20*kilobyte))))
+		}
+
+		desc := description.SelectedServer{Server: description.Server{
+			MaxBatchCount:   100,
+			MaxDocumentSize: 40 * kilobyte,
+			MaxMessageSize:  120 * kilobyte,
+		}}
+
+		// OP_QUERY commands embed the documents, so they must fit in a single document
+		desc.WireVersion = &description.VersionRange{Max: wiremessage.OpmsgWireVersion - 1}
+		assert.Equal(t, 40*kilobyte, targetBatchSize(desc))
+		assert.NoError(t, i.encode(desc))
+		assert.Len(t, i.batches, 6)
+
+		i.batches, i.offsets = nil, nil
+		desc.WireVersion = &description.VersionRange{Max: wiremessage.OpmsgWireVersion}
+		assert.Equal(t, 120*kilobyte, targetBatchSize(desc))
+		assert.NoError(t, i.encode(desc))
+		assert.Len(t, i.batches, 2)
+		assert.Equal(t, []int{0, 5}, i.offsets)
+
+		wm, err := i.batches[0].Encode(desc)
+		assert.NoError(t, err)
+		msg := wm.(wiremessage.Msg)
+		assert.Len(t, msg.Sections, 2)
+		seq := msg.Sections[1].(wiremessage.SectionDocumentSequence)
+		assert.Equal(t, "documents", seq.Identifier)
+		assert.Len(t, seq.Documents, 5)
+	})
+}
+
+func TestInsertWriteErrorIndexes(t *testing.T) {
+	i := &Insert{
+		NS:   Namespace{DB: "db", Collection: "coll"},
+		Opts: []option.InsertOptioner{option.OptOrdered(false)},
+	}
+	for n := 0; n < 5; n++ {
+		i.Docs = append(i.Docs, bson.NewDocument(bson.EC.Int32("_id", int32(n))))
+	}
+
+	writeError := func(index int32) *bson.Value {
+		return bson.VC.DocumentFromElements(
+			bson.EC.Int32("index", index),
+			bson.EC.Int32("code", 11000),
+			bson.EC.String("errmsg", "duplicate key"),
+		)
+	}
+	replies := []*bson.Document{
+		bson.NewDocument(bson.EC.Int32("ok", 1), bson.EC.Int32("n", 1), bson.EC.ArrayFromElements("writeErrors", writeError(1))),
+		bson.NewDocument(bson.EC.Int32("ok", 1), bson.EC.Int32("n", 2)),
+		bson.NewDocument(bson.EC.Int32("ok", 1), bson.EC.Int32("n", 0), bson.EC.ArrayFromElements("writeErrors", writeError(0))),
+	}
+
+	conn := &internal.ChannelConn{
+		T:        t,
+		Written:  make(chan wiremessage.WireMessage, len(replies)),
+		ReadResp: make(chan wiremessage.WireMessage, len(replies)),
+	}
+	for _, reply := range replies {
+		conn.ReadResp <- internal.MakeReply(t, reply)
+	}
+
+	desc := description.SelectedServer{Server: description.Server{
+		MaxBatchCount:   2,
+		MaxDocumentSize: 16 * 1024 * 1024,
+		MaxMessageSize:  48 * 1000 * 1000,
+		WireVersion:     &description.VersionRange{Max: wiremessage.OpmsgWireVersion},
+	}}
+	res, err := i.RoundTrip(context.Background(), desc, conn)
+	assert.NoError(t, err)
+	assert.Len(t, conn.Written, 3)
+	assert.Equal(t, 3, res.N)
+	if assert.Len(t, res.WriteErrors, 2) {
+		assert.Equal(t, 1, res.WriteErrors[0].Index)
+		assert.Equal(t, 4, res.WriteErrors[1].Index)
+	}
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/result"
+	"github.com/mongodb/mongo-go-driver/core/serverapi"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 )
 
@@ -25,6 +26,7 @@ type IsMaster struct {
 	Client             *bson.Document
 	Compressors        []string
 	SaslSupportedMechs string
+	ServerAPI          *serverapi.Options
 
 	err error
 	res result.IsMaster
@@ -48,6 +50,11 @@ func (im *IsMaster) Encode() (wiremessage.WireMessage, error) {
 
 	cmd.Append(bson.EC.Array("compression", array))
 
+	err := addServerAPI(cmd, im.ServerAPI)
+	if err != nil {
+		return nil, err
+	}
+
 	rdr, err := cmd.MarshalBSON()
 	if err != nil {
 		return nil, err
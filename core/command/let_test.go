@@ -0,0 +1,135 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/option"
+)
+
+func TestLet(t *testing.T) {
+	ns := Namespace{DB: "db", Collection: "coll"}
+	let := option.OptLet{Let: bson.NewDocument(bson.EC.String("target", "A"))}
+	filter := bson.NewDocument(
+		bson.EC.SubDocumentFromElements("$expr",
+			bson.EC.ArrayFromElements("$eq", bson.VC.String("$status"), bson.VC.String("$$target")),
+		),
+	)
+
+	newServer := func(maxWireVersion int32) description.SelectedServer {
+		return description.SelectedServer{
+			Server: description.Server{
+				WireVersion:     &description.VersionRange{Max: maxWireVersion},
+				MaxBatchCount:   1000,
+				MaxDocumentSize: 16 * 1024 * 1024,
+			},
+		}
+	}
+
+	commands := []struct {
+		name   string
+		encode func(desc description.SelectedServer) (*bson.Document, error)
+		stmts  string
+	}{
+		{
+			"aggregate",
+			func(desc description.SelectedServer) (*bson.Document, error) {
+				cmd, err := (&Aggregate{
+					NS:       ns,
+					Pipeline: bson.NewArray(bson.VC.DocumentFromElements(bson.EC.SubDocument("$match", filter))),
+					Opts:     []option.AggregateOptioner{let},
+				}).encode(desc)
+				if err != nil {
+					return nil, err
+				}
+				return cmd.Command, nil
+			},
+			"",
+		},
+		{
+			"update",
+			func(desc description.SelectedServer) (*bson.Document, error) {
+				u := &Update{
+					NS: ns,
+					Docs: []*bson.Document{bson.NewDocument(
+						bson.EC.SubDocument("q", filter),
+						bson.EC.SubDocumentFromElements("u", bson.EC.SubDocumentFromElements("$set", bson.EC.Boolean("done", true))),
+					)},
+					Opts: []option.UpdateOptioner{let},
+				}
+				err := u.encode(desc)
+				if err != nil {
+					return nil, err
+				}
+				return u.batches[0].Command, nil
+			},
+			"updates",
+		},
+		{
+			"delete",
+			func(desc description.SelectedServer) (*bson.Document, error) {
+				d := &Delete{
+					NS:      ns,
+					Deletes: []*bson.Document{bson.NewDocument(bson.EC.SubDocument("q", filter), bson.EC.Int32("limit", 0))},
+					Opts:    []option.DeleteOptioner{let},
+				}
+				err := d.encode(desc)
+				if err != nil {
+					return nil, err
+				}
+				return d.batches[0].Command, nil
+			},
+			"deletes",
+		},
+		{
+			"findAndModify",
+			func(desc description.SelectedServer) (*bson.Document, error) {
+				cmd, err := (&FindOneAndDelete{
+					NS:    ns,
+					Query: filter,
+					Opts:  []option.FindOneAndDeleteOptioner{let},
+				}).encode(desc)
+				if err != nil {
+					return nil, err
+				}
+				return cmd.Command, nil
+			},
+			"",
+		},
+	}
+
+	for _, tc := range commands {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, err := tc.encode(newServer(13))
+			noerr(t, err)
+
+			if n := countKey(cmd, "let"); n != 1 {
+				t.Fatalf("Expected 1 let field, got %d", n)
+			}
+			if target := cmd.Lookup("let", "target").StringValue(); target != "A" {
+				t.Errorf("Expected let target A, got %s", target)
+			}
+
+			if tc.stmts != "" {
+				iter, err := cmd.Lookup(tc.stmts).MutableArray().Iterator()
+				noerr(t, err)
+				for iter.Next() {
+					if n := countKey(iter.Value().MutableDocument(), "let"); n != 0 {
+						t.Errorf("Expected let to be set on the command only, found it on a statement")
+					}
+				}
+			}
+
+			if _, err = tc.encode(newServer(12)); err == nil {
+				t.Errorf("Expected an error for a server older than 5.0")
+			}
+		})
+	}
+}
@@ -75,10 +75,10 @@ func (lc *ListCollections) Decode(desc description.SelectedServer, cb CursorBuil
 		lc.err = err
 		return lc
 	}
-	return lc.decode(desc, cb, rdr)
+	return lc.decode(context.Background(), desc, cb, rdr)
 }
 
-func (lc *ListCollections) decode(desc description.SelectedServer, cb CursorBuilder, rdr bson.Reader) *ListCollections {
+func (lc *ListCollections) decode(ctx context.Context, desc description.SelectedServer, cb CursorBuilder, rdr bson.Reader) *ListCollections {
 
 	opts := make([]option.CursorOptioner, 0)
 	for _, opt := range lc.Opts {
@@ -92,10 +92,10 @@ func (lc *ListCollections) decode(desc description.SelectedServer, cb CursorBuil
 	labels, err := getErrorLabels(&rdr)
 	lc.err = err
 
-	res, err := cb.BuildCursor(rdr, lc.Session, lc.Clock, opts...)
+	res, err := cb.BuildCursor(ctx, rdr, lc.Session, lc.Clock, opts...)
 	lc.result = res
 	if err != nil {
-		lc.err = Error{Message: err.Error(), Labels: labels}
+		lc.err = Error{Message: err.Error(), Labels: labels, Wrapped: err}
 	}
 
 	return lc
@@ -124,5 +124,5 @@ func (lc *ListCollections) RoundTrip(ctx context.Context, desc description.Selec
 		return nil, err
 	}
 
-	return lc.decode(desc, cb, rdr).Result()
+	return lc.decode(ctx, desc, cb, rdr).Result()
 }
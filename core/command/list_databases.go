@@ -76,7 +76,8 @@ func (ld *ListDatabases) Decode(desc description.SelectedServer, wm wiremessage.
 }
 
 func (ld *ListDatabases) decode(desc description.SelectedServer, rdr bson.Reader) *ListDatabases {
-	ld.err = bson.Unmarshal(rdr, &ld.result)
+	ld.result = result.ListDatabases{}
+	ld.err = decodeResult(rdr, &ld.result)
 	return ld
 }
 
@@ -12,6 +12,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 )
@@ -20,10 +21,11 @@ import (
 //
 // The listIndexes command lists the indexes for a namespace.
 type ListIndexes struct {
-	Clock   *session.ClusterClock
-	NS      Namespace
-	Opts    []option.ListIndexesOptioner
-	Session *session.Client
+	Clock    *session.ClusterClock
+	NS       Namespace
+	Opts     []option.ListIndexesOptioner
+	ReadPref *readpref.ReadPref
+	Session  *session.Client
 
 	result Cursor
 	err    error
@@ -52,10 +54,11 @@ func (li *ListIndexes) encode(desc description.SelectedServer) (*Read, error) {
 	}
 
 	return &Read{
-		Clock:   li.Clock,
-		DB:      li.NS.DB,
-		Command: cmd,
-		Session: li.Session,
+		Clock:    li.Clock,
+		DB:       li.NS.DB,
+		ReadPref: li.ReadPref,
+		Command:  cmd,
+		Session:  li.Session,
 	}, nil
 }
 
@@ -65,17 +68,17 @@ func (li *ListIndexes) Decode(desc description.SelectedServer, cb CursorBuilder,
 	rdr, err := (&Read{}).Decode(desc, wm).Result()
 	if err != nil {
 		if IsNotFound(err) {
-			li.result = emptyCursor{}
+			li.result = emptyCursor{ns: li.NS}
 			return li
 		}
 		li.err = err
 		return li
 	}
 
-	return li.decode(desc, cb, rdr)
+	return li.decode(context.Background(), desc, cb, rdr)
 }
 
-func (li *ListIndexes) decode(desc description.SelectedServer, cb CursorBuilder, rdr bson.Reader) *ListIndexes {
+func (li *ListIndexes) decode(ctx context.Context, desc description.SelectedServer, cb CursorBuilder, rdr bson.Reader) *ListIndexes {
 	opts := make([]option.CursorOptioner, 0)
 	for _, opt := range li.Opts {
 		curOpt, ok := opt.(option.CursorOptioner)
@@ -88,10 +91,10 @@ func (li *ListIndexes) decode(desc description.SelectedServer, cb CursorBuilder,
 	labels, err := getErrorLabels(&rdr)
 	li.err = err
 
-	res, err := cb.BuildCursor(rdr, li.Session, li.Clock, opts...)
+	res, err := cb.BuildCursor(ctx, rdr, li.Session, li.Clock, opts...)
 	li.result = res
 	if err != nil {
-		li.err = Error{Message: err.Error(), Labels: labels}
+		li.err = Error{Message: err.Error(), Labels: labels, Wrapped: err}
 	}
 
 	return li
@@ -118,10 +121,10 @@ func (li *ListIndexes) RoundTrip(ctx context.Context, desc description.SelectedS
 	rdr, err := cmd.RoundTrip(ctx, desc, rw)
 	if err != nil {
 		if IsNotFound(err) {
-			return emptyCursor{}, nil
+			return emptyCursor{ns: li.NS}, nil
 		}
 		return nil, err
 	}
 
-	return li.decode(desc, cb, rdr).Result()
+	return li.decode(ctx, desc, cb, rdr).Result()
 }
@@ -0,0 +1,84 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/csot"
+	"github.com/mongodb/mongo-go-driver/core/description"
+)
+
+func TestMaxTimeMS(t *testing.T) {
+	maxTimeMS := func(t *testing.T, cmd *bson.Document, derived int64) (int64, bool) {
+		t.Helper()
+
+		wm, err := (&Read{DB: "db", Command: cmd, maxTimeMS: derived}).Encode(description.SelectedServer{})
+		noerr(t, err)
+
+		val, err := commandDocument(t, wm).LookupErr("maxTimeMS")
+		if err == bson.ErrElementNotFound {
+			return 0, false
+		}
+		noerr(t, err)
+		return val.Int64(), true
+	}
+
+	t.Run("derived from the operation timeout", func(t *testing.T) {
+		ms, ok := maxTimeMS(t, bson.NewDocument(bson.EC.String("find", "coll")), 1500)
+		if !ok || ms != 1500 {
+			t.Errorf("Expected maxTimeMS of 1500, got %d (set: %v)", ms, ok)
+		}
+	})
+	t.Run("no operation timeout", func(t *testing.T) {
+		if ms, ok := maxTimeMS(t, bson.NewDocument(bson.EC.String("find", "coll")), 0); ok {
+			t.Errorf("Expected no maxTimeMS, got %d", ms)
+		}
+	})
+	t.Run("lower explicit maxTimeMS is kept", func(t *testing.T) {
+		cmd := bson.NewDocument(bson.EC.String("find", "coll"), bson.EC.Int64("maxTimeMS", 100))
+		if ms, _ := maxTimeMS(t, cmd, 1500); ms != 100 {
+			t.Errorf("Expected maxTimeMS of 100, got %d", ms)
+		}
+	})
+	t.Run("higher explicit maxTimeMS is lowered", func(t *testing.T) {
+		cmd := bson.NewDocument(bson.EC.String("find", "coll"), bson.EC.Int64("maxTimeMS", 5000))
+		if ms, _ := maxTimeMS(t, cmd, 1500); ms != 1500 {
+			t.Errorf("Expected maxTimeMS of 1500, got %d", ms)
+		}
+	})
+	t.Run("getMore is not bounded", func(t *testing.T) {
+		cmd := bson.NewDocument(bson.EC.Int64("getMore", 1), bson.EC.String("collection", "coll"))
+		if ms, ok := maxTimeMS(t, cmd, 1500); ok {
+			t.Errorf("Expected no maxTimeMS, got %d", ms)
+		}
+	})
+	t.Run("expired timeout", func(t *testing.T) {
+		ctx, cancel := csot.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+
+		cmd := &Read{DB: "db", Command: bson.NewDocument(bson.EC.String("find", "coll"))}
+		_, err := cmd.RoundTrip(ctx, description.SelectedServer{}, nil)
+		if err != csot.ErrDeadlineExceeded {
+			t.Errorf("Expected error %v, got %v", csot.ErrDeadlineExceeded, err)
+		}
+	})
+	t.Run("less time left than the minimum round trip time", func(t *testing.T) {
+		ctx, cancel := csot.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		desc := description.SelectedServer{Server: description.Server{MinRTT: 200 * time.Millisecond}}
+		cmd := &Read{DB: "db", Command: bson.NewDocument(bson.EC.String("find", "coll"))}
+		_, err := cmd.RoundTrip(ctx, desc, nil)
+		if err != csot.ErrDeadlineExceeded {
+			t.Errorf("Expected error %v, got %v", csot.ErrDeadlineExceeded, err)
+		}
+	})
+}
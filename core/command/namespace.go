@@ -8,6 +8,7 @@ package command
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 )
 
@@ -77,3 +78,46 @@ func (ns *Namespace) validateCollection() error {
 
 	return nil
 }
+
+// invalidDatabaseChars are the characters no server accepts in database names.
+const invalidDatabaseChars = "\x00/\\. \""
+
+// nonPortableDatabaseChars are the characters servers running on Windows reject in database names.
+const nonPortableDatabaseChars = "*<>:|?"
+
+// ValidateDatabaseName returns an error describing why name cannot be the name of a database on
+// any server: it is empty, or it contains '\0', '/', '\\', '.', ' ', '"', '*', '<', '>', ':', '|'
+// or '?'.
+func ValidateDatabaseName(name string) error {
+	if err := ValidateNonPortableDatabaseName(name); err != nil {
+		return err
+	}
+	if i := strings.IndexAny(name, nonPortableDatabaseChars); i >= 0 {
+		return fmt.Errorf("invalid database name %q: cannot contain %q", name, name[i])
+	}
+	return nil
+}
+
+// ValidateNonPortableDatabaseName is like ValidateDatabaseName, but accepts the characters only
+// servers running on Windows reject: '*', '<', '>', ':', '|' and '?'.
+func ValidateNonPortableDatabaseName(name string) error {
+	if name == "" {
+		return errors.New("invalid database name: cannot be empty")
+	}
+	if i := strings.IndexAny(name, invalidDatabaseChars); i >= 0 {
+		return fmt.Errorf("invalid database name %q: cannot contain %q", name, name[i])
+	}
+	return nil
+}
+
+// ValidateCollectionName returns an error describing why name cannot be the name of a collection:
+// it is empty or contains '\0'. Names containing dots, such as "system.profile", are valid.
+func ValidateCollectionName(name string) error {
+	if name == "" {
+		return errors.New("invalid collection name: cannot be empty")
+	}
+	if strings.IndexByte(name, 0) >= 0 {
+		return fmt.Errorf("invalid collection name %q: cannot contain %q", name, byte(0))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDatabaseName(t *testing.T) {
+	for _, name := range []string{"db", "my_db-1", "$external", "admin", "données"} {
+		require.NoError(t, ValidateDatabaseName(name), name)
+		require.NoError(t, ValidateNonPortableDatabaseName(name), name)
+	}
+
+	require.Error(t, ValidateDatabaseName(""))
+	require.Error(t, ValidateNonPortableDatabaseName(""))
+
+	for _, c := range []string{"\x00", "/", "\\", ".", " ", "\""} {
+		for _, name := range []string{c, "a" + c, c + "b", "a" + c + "b"} {
+			err := ValidateDatabaseName(name)
+			require.Error(t, err, "%q", name)
+			require.Contains(t, err.Error(), "cannot contain")
+			require.Error(t, ValidateNonPortableDatabaseName(name), "%q", name)
+		}
+	}
+
+	for _, c := range []string{"*", "<", ">", ":", "|", "?"} {
+		for _, name := range []string{c, "a" + c, c + "b", "a" + c + "b"} {
+			err := ValidateDatabaseName(name)
+			require.Error(t, err, "%q", name)
+			require.Contains(t, err.Error(), "cannot contain")
+			require.NoError(t, ValidateNonPortableDatabaseName(name), "%q", name)
+		}
+	}
+}
+
+func TestValidateCollectionName(t *testing.T) {
+	for _, name := range []string{"coll", "system.profile", "a.b.c", "a b", "a/b", "a$b", "*<>:|?\"\\"} {
+		require.NoError(t, ValidateCollectionName(name), name)
+	}
+
+	for _, name := range []string{"", "\x00", "a\x00", "\x00b", "a\x00b"} {
+		require.Error(t, ValidateCollectionName(name), "%q", name)
+	}
+}
@@ -12,6 +12,13 @@ import (
 )
 
 func decodeCommandOpMsg(msg wiremessage.Msg) (bson.Reader, error) {
+	// most replies consist of a single body, which is used as is rather than being copied
+	if len(msg.Sections) == 1 {
+		if body, ok := msg.Sections[0].(wiremessage.SectionBody); ok {
+			return checkCommandOpMsg(body.Document)
+		}
+	}
+
 	var mainDoc bson.Document
 
 	for _, section := range msg.Sections {
@@ -42,15 +49,16 @@ func decodeCommandOpMsg(msg wiremessage.Msg) (bson.Reader, error) {
 		return nil, err
 	}
 
-	rdr := bson.Reader(byteArray)
-	_, err = rdr.Validate()
+	return checkCommandOpMsg(bson.Reader(byteArray))
+}
+
+func checkCommandOpMsg(rdr bson.Reader) (bson.Reader, error) {
+	_, err := rdr.Validate()
 	if err != nil {
 		return nil, NewCommandResponseError("malformed OP_MSG: invalid document", err)
 	}
 
+	// the reply is returned along with a command error so that its cluster time is gossiped
 	err = extractError(rdr)
-	if err != nil {
-		return nil, err
-	}
-	return rdr, nil
+	return rdr, err
 }
@@ -0,0 +1,128 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCommandOpMsg(t *testing.T) {
+	marshal := func(doc *bson.Document) bson.Reader {
+		rdr, err := doc.MarshalBSON()
+		require.NoError(t, err)
+		return rdr
+	}
+
+	t.Run("single body is not copied", func(t *testing.T) {
+		body := marshal(bson.NewDocument(bson.EC.Int32("ok", 1), bson.EC.Int32("n", 3)))
+		rdr, err := decodeCommandOpMsg(wiremessage.Msg{Sections: []wiremessage.Section{wiremessage.SectionBody{Document: body}}})
+		require.NoError(t, err)
+		require.Equal(t, body, rdr)
+		require.True(t, &body[0] == &rdr[0], "expected the body to be returned as is")
+	})
+	t.Run("document sequences are merged", func(t *testing.T) {
+		body := marshal(bson.NewDocument(bson.EC.Int32("ok", 1)))
+		doc := marshal(bson.NewDocument(bson.EC.Int32("x", 1)))
+		rdr, err := decodeCommandOpMsg(wiremessage.Msg{Sections: []wiremessage.Section{
+			wiremessage.SectionBody{Document: body},
+			wiremessage.SectionDocumentSequence{Identifier: "docs", Documents: []bson.Reader{doc}},
+		}})
+		require.NoError(t, err)
+
+		elem, err := rdr.Lookup("docs", "0", "x")
+		require.NoError(t, err)
+		require.Equal(t, int32(1), elem.Value().Int32())
+	})
+	t.Run("command errors are returned", func(t *testing.T) {
+		body := marshal(bson.NewDocument(bson.EC.Int32("ok", 0), bson.EC.String("errmsg", "failed")))
+		rdr, err := decodeCommandOpMsg(wiremessage.Msg{Sections: []wiremessage.Section{wiremessage.SectionBody{Document: body}}})
+		require.Error(t, err)
+		require.Equal(t, body, rdr, "expected the reply to be returned along with the error")
+	})
+}
+
+func TestResponseClusterTime(t *testing.T) {
+	response, err := bson.NewDocument(
+		bson.EC.Int32("ok", 1),
+		bson.EC.SubDocumentFromElements("$clusterTime", bson.EC.Timestamp("clusterTime", 10, 1)),
+	).MarshalBSON()
+	require.NoError(t, err)
+
+	clusterTime := responseClusterTime(response)
+	for i := range response {
+		response[i] = 0 // the cluster time must not reference the response, whose buffer may be reused
+	}
+
+	ts, i := clusterTime.Lookup("$clusterTime", "clusterTime").Timestamp()
+	require.Equal(t, uint32(10), ts)
+	require.Equal(t, uint32(1), i)
+}
+
+func TestReplyClusterTime(t *testing.T) {
+	response, err := bson.NewDocument(
+		bson.EC.Int32("ok", 1),
+		bson.EC.SubDocumentFromElements("$clusterTime", bson.EC.Timestamp("clusterTime", 10, 1)),
+	).MarshalBSON()
+	require.NoError(t, err)
+
+	for _, wm := range []wiremessage.WireMessage{
+		wiremessage.Msg{Sections: []wiremessage.Section{wiremessage.SectionBody{Document: response}}},
+		wiremessage.Reply{Documents: []bson.Reader{response}},
+	} {
+		ts, i := ReplyClusterTime(wm).Lookup("$clusterTime", "clusterTime").Timestamp()
+		require.Equal(t, uint32(10), ts)
+		require.Equal(t, uint32(1), i)
+	}
+	require.Nil(t, ReplyClusterTime(wiremessage.Reply{}))
+}
+
+func TestWithClusterTime(t *testing.T) {
+	clusterTime := func(ts uint32) *bson.Document {
+		return bson.NewDocument(bson.EC.SubDocumentFromElements("$clusterTime", bson.EC.Timestamp("clusterTime", ts, 1)))
+	}
+	msg := func(elems ...*bson.Element) wiremessage.Msg {
+		body, err := bson.NewDocument(elems...).MarshalBSON()
+		require.NoError(t, err)
+		return wiremessage.Msg{
+			MsgHeader: wiremessage.Header{MessageLength: 100},
+			Sections:  []wiremessage.Section{wiremessage.SectionBody{Document: body}},
+		}
+	}
+	sent := func(wm wiremessage.WireMessage) uint32 {
+		body := wm.(wiremessage.Msg).Sections[0].(wiremessage.SectionBody).Document
+		_, err := body.Validate()
+		require.NoError(t, err)
+		elem, err := body.Lookup("$clusterTime", "clusterTime")
+		require.NoError(t, err)
+		ts, _ := elem.Value().Timestamp()
+		return ts
+	}
+
+	t.Run("added", func(t *testing.T) {
+		wm := WithClusterTime(msg(bson.EC.Int32("ping", 1)), clusterTime(10))
+		require.Equal(t, uint32(10), sent(wm))
+		require.Equal(t, int32(0), wm.(wiremessage.Msg).MsgHeader.MessageLength)
+	})
+	t.Run("later cluster time is kept", func(t *testing.T) {
+		wm := msg(bson.EC.Int32("ping", 1), clusterTime(20).ElementAt(0))
+		require.Equal(t, uint32(20), sent(WithClusterTime(wm, clusterTime(10))))
+	})
+	t.Run("earlier cluster time is replaced", func(t *testing.T) {
+		orig := msg(bson.EC.Int32("ping", 1), clusterTime(10).ElementAt(0))
+		wm := WithClusterTime(orig, clusterTime(20))
+		require.Equal(t, uint32(20), sent(wm))
+		require.Equal(t, uint32(10), sent(orig), "expected the original message to be left unchanged")
+	})
+	t.Run("legacy commands are unchanged", func(t *testing.T) {
+		wm := wiremessage.Query{FullCollectionName: "admin.$cmd"}
+		require.Equal(t, wm, WithClusterTime(wm, clusterTime(10)))
+	})
+}
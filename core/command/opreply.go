@@ -35,9 +35,7 @@ func decodeCommandOpReply(reply wiremessage.Reply) (bson.Reader, error) {
 		}
 	}
 
+	// the reply is returned along with a command error so that its cluster time is gossiped
 	err = extractError(rdr)
-	if err != nil {
-		return nil, err
-	}
-	return rdr, nil
+	return rdr, err
 }
@@ -8,10 +8,11 @@ package command
 
 import (
 	"context"
-
 	"fmt"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/csot"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/readconcern"
 	"github.com/mongodb/mongo-go-driver/core/readpref"
@@ -28,8 +29,9 @@ type Read struct {
 	Clock       *session.ClusterClock
 	Session     *session.Client
 
-	result bson.Reader
-	err    error
+	maxTimeMS int64 // derived from the operation timeout by RoundTrip
+	result    bson.Reader
+	err       error
 }
 
 func (r *Read) createReadPref(kind description.ServerKind) *bson.Document {
@@ -96,7 +98,7 @@ func (r *Read) encodeOpMsg(desc description.SelectedServer, cmd *bson.Document)
 		Sections:  make([]wiremessage.Section, 0),
 	}
 
-	readPrefDoc := r.createReadPref(desc.Server.Kind)
+	readPrefDoc := r.opMsgReadPref(desc)
 	fullDocRdr, err := opmsgAddGlobals(cmd, r.DB, readPrefDoc)
 	if err != nil {
 		return nil, err
@@ -113,6 +115,23 @@ func (r *Read) encodeOpMsg(desc description.SelectedServer, cmd *bson.Document)
 	return msg, nil
 }
 
+// opMsgReadPref returns the $readPreference to send with r when encoding it as an OP_MSG, or nil if
+// none needs to be sent. A primary read preference is the default of mongos and replica set members,
+// so it is omitted. A server connected to directly must run reads whatever its type, so it is sent
+// primaryPreferred instead, which is how OP_MSG conveys the slaveOk flag of OP_QUERY.
+func (r *Read) opMsgReadPref(desc description.SelectedServer) *bson.Document {
+	primary := r.ReadPref == nil || r.ReadPref.Mode() == readpref.PrimaryMode
+
+	switch {
+	case desc.Kind == description.Single && desc.Server.Kind != description.Mongos && primary:
+		return bson.NewDocument(bson.EC.String("mode", "primaryPreferred"))
+	case primary:
+		return nil
+	}
+
+	return r.createReadPref(desc.Server.Kind)
+}
+
 func (r *Read) slaveOK(desc description.SelectedServer) wiremessage.QueryFlag {
 	if desc.Kind == description.Single && desc.Server.Kind != description.Mongos {
 		return wiremessage.SlaveOK
@@ -193,7 +212,7 @@ func (r *Read) decodeOpReply(wm wiremessage.WireMessage) {
 // Encode will encode this command into a wire message for the given server description.
 func (r *Read) Encode(desc description.SelectedServer) (wiremessage.WireMessage, error) {
 	cmd := r.Command.Copy()
-	err := addReadConcern(cmd, desc, r.ReadConcern, r.Session)
+	err := addReadConcern(cmd, desc, r.ReadConcern, r.ReadPref, r.Session)
 	if err != nil {
 		return nil, err
 	}
@@ -208,6 +227,13 @@ func (r *Read) Encode(desc description.SelectedServer) (wiremessage.WireMessage,
 		return nil, nil
 	}
 
+	addMaxTimeMS(cmd, r.maxTimeMS)
+
+	err = addServerAPI(cmd, desc.ServerAPI)
+	if err != nil {
+		return nil, err
+	}
+
 	if desc.WireVersion == nil || desc.WireVersion.Max < wiremessage.OpmsgWireVersion {
 		return r.encodeOpQuery(desc, cmd)
 	}
@@ -254,33 +280,57 @@ func (r *Read) Err() error {
 
 // RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
 func (r *Read) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (bson.Reader, error) {
+	reply, err := r.RoundTripReply(ctx, desc, rw)
+	return reply.Document, err
+}
+
+// RoundTripReply is the same as RoundTrip, but returns the reply along with the server it came
+// from, the duration of the round trip and the request ID of the command. Those are returned along
+// with the error when the command fails.
+func (r *Read) RoundTripReply(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (Reply, error) {
+	reply := Reply{Server: desc.Addr}
+
+	var err error
+	r.maxTimeMS, err = csot.MaxTimeMS(ctx, desc.MinRTT)
+	if err != nil {
+		return reply, err
+	}
+
 	wm, err := r.Encode(desc)
 	if err != nil {
-		return nil, err
+		return reply, err
 	}
+	reply.RequestID = requestID(wm)
 
+	start := time.Now()
 	err = rw.WriteWireMessage(ctx, wm)
 	if err != nil {
 		if _, ok := err.(Error); ok {
-			return nil, err
+			return reply, err
 		}
 		// Connection errors are transient
-		return nil, Error{Message: err.Error(), Labels: []string{TransientTransactionError, NetworkError}}
+		return reply, Error{Message: err.Error(), Labels: []string{TransientTransactionError, NetworkError}, Wrapped: err}
 	}
 	wm, err = rw.ReadWireMessage(ctx)
+	reply.Duration = time.Since(start)
+	if id := responseTo(wm); id != 0 {
+		reply.RequestID = id
+	}
 	if err != nil {
 		if _, ok := err.(Error); ok {
-			return nil, err
+			return reply, err
 		}
 		// Connection errors are transient
-		return nil, Error{Message: err.Error(), Labels: []string{TransientTransactionError, NetworkError}}
+		return reply, Error{Message: err.Error(), Labels: []string{TransientTransactionError, NetworkError}, Wrapped: err}
 	}
 
 	if r.Session != nil {
 		err = r.Session.UpdateUseTime()
 		if err != nil {
-			return nil, err
+			return reply, err
 		}
 	}
-	return r.Decode(desc, wm).Result()
+
+	reply.Document, err = r.Decode(desc, wm).Result()
+	return reply, err
 }
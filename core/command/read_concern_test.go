@@ -0,0 +1,125 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/uuid"
+)
+
+func TestReadConcern(t *testing.T) {
+	desc := description.SelectedServer{
+		Server: description.Server{WireVersion: &description.VersionRange{Max: 6}},
+	}
+	ns := Namespace{DB: "db", Collection: "coll"}
+
+	newSession := func(t *testing.T) *session.Client {
+		t.Helper()
+
+		id, err := uuid.New()
+		noerr(t, err)
+		sess, err := session.NewClientSession(session.NewPool(nil), id, session.Explicit)
+		noerr(t, err)
+		return sess
+	}
+
+	readConcern := func(t *testing.T, find *Find) *bson.Document {
+		t.Helper()
+
+		wm, err := find.Encode(desc)
+		noerr(t, err)
+
+		doc := commandDocument(t, wm)
+		if n := countKey(doc, "readConcern"); n != 1 {
+			t.Fatalf("Expected 1 readConcern field, got %d", n)
+		}
+		return doc.Lookup("readConcern").MutableDocument()
+	}
+
+	t.Run("operation option overrides collection", func(t *testing.T) {
+		rc := readConcern(t, &Find{
+			NS:          ns,
+			Filter:      bson.NewDocument(),
+			ReadConcern: readconcern.Local(),
+			Opts:        []option.FindOptioner{option.OptReadConcern{ReadConcern: readconcern.Majority()}},
+		})
+
+		if level := rc.Lookup("level").StringValue(); level != "majority" {
+			t.Errorf("Expected level majority, got %s", level)
+		}
+	})
+
+	t.Run("afterClusterTime is merged into operation option", func(t *testing.T) {
+		sess := newSession(t)
+		sess.OperationTime = &bson.Timestamp{T: 10, I: 1}
+
+		rc := readConcern(t, &Find{
+			NS:      ns,
+			Filter:  bson.NewDocument(),
+			Session: sess,
+			Opts:    []option.FindOptioner{option.OptReadConcern{ReadConcern: readconcern.Majority()}},
+		})
+
+		if level := rc.Lookup("level").StringValue(); level != "majority" {
+			t.Errorf("Expected level majority, got %s", level)
+		}
+		if ts, err := rc.LookupErr("afterClusterTime"); err != nil {
+			t.Errorf("Expected afterClusterTime, got error %v", err)
+		} else if tsT, tsI := ts.Timestamp(); tsT != 10 || tsI != 1 {
+			t.Errorf("Expected afterClusterTime {10 1}, got {%d %d}", tsT, tsI)
+		}
+	})
+
+	t.Run("linearizable requires primary", func(t *testing.T) {
+		_, err := (&Find{
+			NS:          ns,
+			Filter:      bson.NewDocument(),
+			ReadConcern: readconcern.Linearizable(),
+			ReadPref:    readpref.Secondary(),
+		}).Encode(desc)
+		if err != ErrLinearizableReadPref {
+			t.Errorf("Expected error %v, got %v", ErrLinearizableReadPref, err)
+		}
+
+		rc := readConcern(t, &Find{
+			NS:          ns,
+			Filter:      bson.NewDocument(),
+			ReadConcern: readconcern.Linearizable(),
+			ReadPref:    readpref.Primary(),
+		})
+		if level := rc.Lookup("level").StringValue(); level != "linearizable" {
+			t.Errorf("Expected level linearizable, got %s", level)
+		}
+	})
+
+	t.Run("snapshot requires transaction", func(t *testing.T) {
+		_, err := (&Find{
+			NS:          ns,
+			Filter:      bson.NewDocument(),
+			ReadConcern: readconcern.Snapshot(),
+		}).Encode(desc)
+		if err != ErrSnapshotReadConcern {
+			t.Errorf("Expected error %v, got %v", ErrSnapshotReadConcern, err)
+		}
+
+		sess := newSession(t)
+		noerr(t, sess.StartTransaction())
+		sess.CurrentRc = readconcern.Snapshot()
+
+		rc := readConcern(t, &Find{NS: ns, Filter: bson.NewDocument(), Session: sess})
+		if level := rc.Lookup("level").StringValue(); level != "snapshot" {
+			t.Errorf("Expected level snapshot, got %s", level)
+		}
+	})
+}
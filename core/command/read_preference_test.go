@@ -0,0 +1,145 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/stretchr/testify/require"
+)
+
+// sentCommand marshals wm and reads back the command it carries, along with the slaveOk flag if wm
+// is an OP_QUERY.
+func sentCommand(t *testing.T, wm wiremessage.WireMessage) (bson.Reader, bool) {
+	t.Helper()
+
+	b, err := wm.MarshalWireMessage()
+	require.NoError(t, err)
+
+	header, err := wiremessage.ReadHeader(b, 0)
+	require.NoError(t, err)
+
+	switch header.OpCode {
+	case wiremessage.OpQuery:
+		var q wiremessage.Query
+		require.NoError(t, q.UnmarshalWireMessage(b))
+		return q.Query, q.Flags&wiremessage.SlaveOK != 0
+	case wiremessage.OpMsg:
+		var m wiremessage.Msg
+		require.NoError(t, m.UnmarshalWireMessage(b))
+		rdr, err := m.GetMainDocument()
+		require.NoError(t, err)
+		doc, err := rdr.MarshalBSON()
+		require.NoError(t, err)
+		return doc, false
+	}
+
+	t.Fatalf("unexpected opcode %v", header.OpCode)
+	return nil, false
+}
+
+// sentReadPrefMode returns the mode of the $readPreference sent with cmd, or "" if none is sent.
+func sentReadPrefMode(cmd bson.Reader) string {
+	elem, err := cmd.Lookup("$readPreference", "mode")
+	if err != nil {
+		return ""
+	}
+	return elem.Value().StringValue()
+}
+
+func TestReadPreferenceEncoding(t *testing.T) {
+	ns := Namespace{DB: "db", Collection: "coll"}
+	commands := []struct {
+		name   string
+		encode func(rp *readpref.ReadPref, desc description.SelectedServer) (wiremessage.WireMessage, error)
+	}{
+		{"find", func(rp *readpref.ReadPref, desc description.SelectedServer) (wiremessage.WireMessage, error) {
+			return (&Find{NS: ns, ReadPref: rp}).Encode(desc)
+		}},
+		{"aggregate", func(rp *readpref.ReadPref, desc description.SelectedServer) (wiremessage.WireMessage, error) {
+			return (&Aggregate{NS: ns, Pipeline: bson.NewArray(), ReadPref: rp}).Encode(desc)
+		}},
+		{"distinct", func(rp *readpref.ReadPref, desc description.SelectedServer) (wiremessage.WireMessage, error) {
+			return (&Distinct{NS: ns, Field: "x", ReadPref: rp}).Encode(desc)
+		}},
+		{"count", func(rp *readpref.ReadPref, desc description.SelectedServer) (wiremessage.WireMessage, error) {
+			return (&Count{NS: ns, Query: bson.NewDocument(), ReadPref: rp}).Encode(desc)
+		}},
+		{"listIndexes", func(rp *readpref.ReadPref, desc description.SelectedServer) (wiremessage.WireMessage, error) {
+			return (&ListIndexes{NS: ns, ReadPref: rp}).Encode(desc)
+		}},
+	}
+
+	server := func(topology description.TopologyKind, kind description.ServerKind, wireVersion int32) description.SelectedServer {
+		return description.SelectedServer{
+			Server: description.Server{
+				Kind:        kind,
+				WireVersion: &description.VersionRange{Min: 0, Max: wireVersion},
+			},
+			Kind: topology,
+		}
+	}
+	tagged := readpref.Secondary(readpref.WithTags("dc", "ny"))
+
+	testCases := []struct {
+		name     string
+		desc     description.SelectedServer
+		rp       *readpref.ReadPref
+		mode     string
+		slaveOK  bool
+		hasQuery bool // whether the OP_QUERY command is wrapped in $query
+	}{
+		// OP_MSG
+		{"mongos primary", server(description.Sharded, description.Mongos, 6), readpref.Primary(), "", false, false},
+		{"mongos default", server(description.Sharded, description.Mongos, 6), nil, "", false, false},
+		{"mongos secondary", server(description.Sharded, description.Mongos, 6), readpref.Secondary(), "secondary", false, false},
+		{"mongos nearest", server(description.Sharded, description.Mongos, 6), readpref.Nearest(), "nearest", false, false},
+		{"direct mongos primary", server(description.Single, description.Mongos, 6), readpref.Primary(), "", false, false},
+		{"primary", server(description.ReplicaSetWithPrimary, description.RSPrimary, 6), readpref.Primary(), "", false, false},
+		{"secondary", server(description.ReplicaSetWithPrimary, description.RSSecondary, 6), readpref.Secondary(), "secondary", false, false},
+		{"direct secondary default", server(description.Single, description.RSSecondary, 6), nil, "primaryPreferred", false, false},
+		{"direct secondary", server(description.Single, description.RSSecondary, 6), readpref.Secondary(), "secondary", false, false},
+		{"direct standalone primary", server(description.Single, description.Standalone, 6), readpref.Primary(), "primaryPreferred", false, false},
+
+		// OP_QUERY
+		{"legacy mongos primary", server(description.Sharded, description.Mongos, 5), readpref.Primary(), "", false, false},
+		{"legacy mongos secondaryPreferred", server(description.Sharded, description.Mongos, 5), readpref.SecondaryPreferred(), "", true, false},
+		{"legacy mongos secondary", server(description.Sharded, description.Mongos, 5), readpref.Secondary(), "secondary", true, true},
+		{"legacy mongos tagged secondary", server(description.Sharded, description.Mongos, 5), tagged, "secondary", true, true},
+		{"legacy primary", server(description.ReplicaSetWithPrimary, description.RSPrimary, 5), readpref.Primary(), "", false, false},
+		{"legacy secondary", server(description.ReplicaSetWithPrimary, description.RSSecondary, 5), readpref.Secondary(), "", true, false},
+		{"legacy direct secondary", server(description.Single, description.RSSecondary, 5), nil, "", true, false},
+		{"legacy direct standalone", server(description.Single, description.Standalone, 5), readpref.Primary(), "", true, false},
+	}
+
+	for _, cmd := range commands {
+		for _, tc := range testCases {
+			t.Run(cmd.name+"/"+tc.name, func(t *testing.T) {
+				wm, err := cmd.encode(tc.rp, tc.desc)
+				require.NoError(t, err)
+
+				sent, slaveOK := sentCommand(t, wm)
+				require.Equal(t, tc.mode, sentReadPrefMode(sent))
+				require.Equal(t, tc.slaveOK, slaveOK)
+
+				name := sent
+				if tc.hasQuery {
+					elem, err := sent.Lookup("$query")
+					require.NoError(t, err)
+					name = elem.Value().ReaderDocument()
+				}
+				first, err := name.ElementAt(0)
+				require.NoError(t, err)
+				require.Equal(t, cmd.name, first.Key())
+			})
+		}
+	}
+}
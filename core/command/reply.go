@@ -0,0 +1,61 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+)
+
+// Reply is the reply to a command along with information about the round trip that produced it.
+type Reply struct {
+	// Document is the reply. It is nil if the command failed, in which case the reply, if the server
+	// sent one, is the Raw field of the Error.
+	Document bson.Reader
+
+	// Server is the address of the server the command was sent to.
+	Server address.Address
+
+	// Duration is the time between sending the command and receiving its reply.
+	Duration time.Duration
+
+	// RequestID is the request ID of the wire message the command was sent in. Connections assign
+	// request IDs as they send wire messages, so it is taken from the responseTo of the reply
+	// once one was read.
+	RequestID int32
+}
+
+// requestID returns the request ID of a wire message encoding a command, or 0 if the wire message
+// is of a type commands are not encoded in.
+func requestID(wm wiremessage.WireMessage) int32 {
+	switch t := wm.(type) {
+	case wiremessage.Msg:
+		return t.MsgHeader.RequestID
+	case wiremessage.Query:
+		return t.MsgHeader.RequestID
+	case wiremessage.Command:
+		return t.MsgHeader.RequestID
+	}
+	return 0
+}
+
+// responseTo returns the request ID a reply wire message responds to, or 0 if the wire message is
+// not a reply.
+func responseTo(wm wiremessage.WireMessage) int32 {
+	switch t := wm.(type) {
+	case wiremessage.Msg:
+		return t.MsgHeader.ResponseTo
+	case wiremessage.Reply:
+		return t.MsgHeader.ResponseTo
+	case wiremessage.CommandReply:
+		return t.MsgHeader.ResponseTo
+	}
+	return 0
+}
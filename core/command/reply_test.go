@@ -0,0 +1,85 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTripReply(t *testing.T) {
+	desc := description.SelectedServer{Server: description.Server{Addr: address.Address("db1:27017")}}
+	roundTrip := func(t *testing.T, resp *bson.Document) (Reply, error) {
+		conn := &internal.ChannelConn{
+			T:        t,
+			Written:  make(chan wiremessage.WireMessage, 1),
+			ReadResp: make(chan wiremessage.WireMessage, 1),
+			ReadErr:  make(chan error, 1),
+		}
+		conn.ReadResp <- internal.MakeReply(t, resp)
+
+		cmd := &Read{DB: "foo", Command: bson.NewDocument(bson.EC.Int32("buildInfo", 1))}
+		reply, err := cmd.RoundTripReply(context.Background(), desc, conn)
+
+		query, ok := (<-conn.Written).(wiremessage.Query)
+		require.True(t, ok)
+		require.Equal(t, query.MsgHeader.RequestID, reply.RequestID)
+		return reply, err
+	}
+
+	t.Run("success", func(t *testing.T) {
+		reply, err := roundTrip(t, bson.NewDocument(bson.EC.Int32("ok", 1), bson.EC.String("version", "4.0.0")))
+		require.NoError(t, err)
+		require.Equal(t, address.Address("db1:27017"), reply.Server)
+
+		version, err := reply.Document.Lookup("version")
+		require.NoError(t, err)
+		require.Equal(t, "4.0.0", version.Value().StringValue())
+	})
+	t.Run("failure", func(t *testing.T) {
+		resp := bson.NewDocument(
+			bson.EC.Int32("ok", 0),
+			bson.EC.String("errmsg", "no such command"),
+			bson.EC.Int32("code", 59),
+			bson.EC.SubDocumentFromElements("details", bson.EC.Int32("x", 1)),
+		)
+		reply, err := roundTrip(t, resp)
+		require.Nil(t, reply.Document)
+		require.Equal(t, address.Address("db1:27017"), reply.Server)
+
+		cerr, ok := err.(Error)
+		require.True(t, ok)
+		require.Equal(t, int32(59), cerr.Code)
+		raw, err := bson.ReadDocument(cerr.Raw)
+		require.NoError(t, err)
+		require.True(t, resp.Equal(raw), "expected %v, got %v", resp, raw)
+	})
+	t.Run("request ID of the connection", func(t *testing.T) {
+		conn := &internal.ChannelConn{
+			T:        t,
+			Written:  make(chan wiremessage.WireMessage, 1),
+			ReadResp: make(chan wiremessage.WireMessage, 1),
+			ReadErr:  make(chan error, 1),
+		}
+		// connections assign their own request IDs, which replies respond to
+		resp := internal.MakeReply(t, bson.NewDocument(bson.EC.Int32("ok", 1))).(wiremessage.Reply)
+		resp.MsgHeader.ResponseTo = 42
+		conn.ReadResp <- resp
+
+		cmd := &Read{DB: "foo", Command: bson.NewDocument(bson.EC.Int32("buildInfo", 1))}
+		reply, err := cmd.RoundTripReply(context.Background(), desc, conn)
+		require.NoError(t, err)
+		require.Equal(t, int32(42), reply.RequestID)
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/serverapi"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+)
+
+// commandDocument returns the command document sent in wm.
+func commandDocument(t *testing.T, wm wiremessage.WireMessage) *bson.Document {
+	t.Helper()
+
+	var doc *bson.Document
+	var err error
+	switch converted := wm.(type) {
+	case wiremessage.Query:
+		doc, err = bson.ReadDocument(converted.Query)
+	case wiremessage.Msg:
+		doc, err = converted.GetMainDocument()
+	default:
+		t.Fatalf("Unexpected wiremessage type %T", wm)
+	}
+	noerr(t, err)
+
+	return doc
+}
+
+func countKey(doc *bson.Document, key string) int {
+	var n int
+	iter := doc.Iterator()
+	for iter.Next() {
+		if iter.Element().Key() == key {
+			n++
+		}
+	}
+	return n
+}
+
+func TestServerAPI(t *testing.T) {
+	api := serverapi.New(serverapi.Version1, serverapi.Strict(true), serverapi.DeprecationErrors(false))
+	opQuery := description.SelectedServer{ServerAPI: api}
+	opMsg := description.SelectedServer{
+		Server:    description.Server{WireVersion: &description.VersionRange{Max: wiremessage.OpmsgWireVersion}},
+		ServerAPI: api,
+	}
+	ns := Namespace{DB: "db", Collection: "coll"}
+
+	encoders := []struct {
+		name   string
+		encode func(desc description.SelectedServer) (wiremessage.WireMessage, error)
+	}{
+		{"read", func(desc description.SelectedServer) (wiremessage.WireMessage, error) {
+			return (&Read{DB: "db", Command: bson.NewDocument(bson.EC.String("find", "coll"))}).Encode(desc)
+		}},
+		{"write", func(desc description.SelectedServer) (wiremessage.WireMessage, error) {
+			return (&Write{DB: "db", Command: bson.NewDocument(bson.EC.String("insert", "coll"))}).Encode(desc)
+		}},
+		{"getMore", func(desc description.SelectedServer) (wiremessage.WireMessage, error) {
+			return (&GetMore{ID: 1, NS: ns}).Encode(desc)
+		}},
+		{"killCursors", func(desc description.SelectedServer) (wiremessage.WireMessage, error) {
+			return (&KillCursors{NS: ns, IDs: []int64{1}}).Encode(desc)
+		}},
+		{"isMaster", func(desc description.SelectedServer) (wiremessage.WireMessage, error) {
+			return (&IsMaster{ServerAPI: desc.ServerAPI}).Encode()
+		}},
+		{"handshake", func(desc description.SelectedServer) (wiremessage.WireMessage, error) {
+			return (&Handshake{Client: ClientDoc("app"), ServerAPI: desc.ServerAPI}).Encode()
+		}},
+	}
+
+	for _, enc := range encoders {
+		for _, desc := range []description.SelectedServer{opQuery, opMsg} {
+			t.Run(enc.name, func(t *testing.T) {
+				wm, err := enc.encode(desc)
+				noerr(t, err)
+				doc := commandDocument(t, wm)
+
+				for _, key := range []string{"apiVersion", "apiStrict", "apiDeprecationErrors"} {
+					if n := countKey(doc, key); n != 1 {
+						t.Errorf("Expected %s to be sent once. got %d in %v", key, n, doc)
+					}
+				}
+				if v := doc.Lookup("apiVersion").StringValue(); v != "1" {
+					t.Errorf("Incorrect apiVersion. got %s; want 1", v)
+				}
+				if !doc.Lookup("apiStrict").Boolean() || doc.Lookup("apiDeprecationErrors").Boolean() {
+					t.Errorf("Incorrect apiStrict or apiDeprecationErrors in %v", doc)
+				}
+			})
+		}
+	}
+
+	t.Run("only version", func(t *testing.T) {
+		cmd := &Read{DB: "db", Command: bson.NewDocument(bson.EC.Int32("ping", 1))}
+		wm, err := cmd.Encode(description.SelectedServer{ServerAPI: serverapi.New(serverapi.Version1)})
+		noerr(t, err)
+		doc := commandDocument(t, wm)
+
+		if countKey(doc, "apiVersion") != 1 || countKey(doc, "apiStrict") != 0 || countKey(doc, "apiDeprecationErrors") != 0 {
+			t.Errorf("Expected only apiVersion to be sent. got %v", doc)
+		}
+	})
+
+	t.Run("not declared", func(t *testing.T) {
+		cmd := &Read{DB: "db", Command: bson.NewDocument(bson.EC.Int32("ping", 1), bson.EC.String("apiVersion", "1"))}
+		wm, err := cmd.Encode(description.SelectedServer{})
+		noerr(t, err)
+
+		if n := countKey(commandDocument(t, wm), "apiVersion"); n != 1 {
+			t.Errorf("Expected the command's apiVersion to be sent as is. got %d", n)
+		}
+	})
+
+	t.Run("conflicts with command", func(t *testing.T) {
+		for _, key := range []string{"apiVersion", "apiStrict", "apiDeprecationErrors"} {
+			cmd := &Read{DB: "db", Command: bson.NewDocument(bson.EC.Int32("ping", 1), bson.EC.String(key, "1"))}
+			_, err := cmd.Encode(opMsg)
+			if err != ErrServerAPIConflict {
+				t.Errorf("Expected %v for a command setting %s. got %v", ErrServerAPIConflict, key, err)
+			}
+		}
+	})
+}
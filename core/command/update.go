@@ -20,7 +20,9 @@ import (
 
 // Update represents the update command.
 //
-// The update command updates a set of documents with the database.
+// The update command updates a set of documents with the database. The update statements of Docs
+// are split into as many commands as needed to honor the maximum batch count and message size of
+// the server.
 type Update struct {
 	Clock        *session.ClusterClock
 	NS           Namespace
@@ -29,46 +31,101 @@ type Update struct {
 	WriteConcern *writeconcern.WriteConcern
 	Session      *session.Client
 
-	result result.Update
-	err    error
+	batches         []*Write
+	offsets         []int         // the index in Docs of the first statement of each batch
+	result          result.Update // the result of the last decoded batch
+	acc             result.Update // the aggregated result of the batches that succeeded
+	err             error
+	continueOnError bool
 }
 
-// Encode will encode this command into a wire message for the given server description.
-func (u *Update) Encode(desc description.SelectedServer) (wiremessage.WireMessage, error) {
-	encoded, err := u.encode(desc)
+// Encode will encode this command into wire messages for the given server description, one for
+// each batch of update statements.
+func (u *Update) Encode(desc description.SelectedServer) ([]wiremessage.WireMessage, error) {
+	err := u.encode(desc)
 	if err != nil {
 		return nil, err
 	}
-	return encoded.Encode(desc)
+
+	wms := make([]wiremessage.WireMessage, 0, len(u.batches))
+	for _, cmd := range u.batches {
+		wm, err := cmd.Encode(desc)
+		if err != nil {
+			return nil, err
+		}
+
+		wms = append(wms, wm)
+	}
+
+	return wms, nil
 }
 
-func (u *Update) encode(desc description.SelectedServer) (*Write, error) {
-	command := bson.NewDocument(bson.EC.String("update", u.NS.Collection))
-	vals := make([]*bson.Value, 0, len(u.Docs))
+func (u *Update) encode(desc description.SelectedServer) error {
 	docs := make([]*bson.Document, 0, len(u.Docs)) // copy of all the documents
 	for _, doc := range u.Docs {
-		newDoc := doc.Copy()
-		docs = append(docs, newDoc)
-		vals = append(vals, bson.VC.Document(newDoc))
+		docs = append(docs, doc.Copy())
 	}
-	command.Append(bson.EC.ArrayFromElements("updates", vals...))
 
 	for _, opt := range u.Opts {
+		if err := optionSupported(desc, opt); err != nil {
+			return err
+		}
+
 		switch opt.(type) {
-		case nil:
-			continue
 		case option.OptUpsert, option.OptCollation, option.OptArrayFilters:
 			for _, doc := range docs {
 				err := opt.Option(doc)
 				if err != nil {
-					return nil, err
+					return err
 				}
 			}
-		default:
-			err := opt.Option(command)
-			if err != nil {
-				return nil, err
+		}
+	}
+
+	batches, err := splitBatches(docs, int(desc.MaxBatchCount), int(desc.MaxDocumentSize), targetBatchSize(desc))
+	if err != nil {
+		return err
+	}
+
+	var offset int
+	for _, batch := range batches {
+		cmd, err := u.encodeBatch(batch, desc)
+		if err != nil {
+			return err
+		}
+
+		u.batches = append(u.batches, cmd)
+		u.offsets = append(u.offsets, offset)
+		offset += len(batch)
+	}
+	return nil
+}
+
+func (u *Update) encodeBatch(docs []*bson.Document, desc description.SelectedServer) (*Write, error) {
+	command := bson.NewDocument(bson.EC.String("update", u.NS.Collection))
+	vals := make([]*bson.Value, 0, len(docs))
+	for _, doc := range docs {
+		vals = append(vals, bson.VC.Document(doc))
+	}
+	command.Append(bson.EC.ArrayFromElements("updates", vals...))
+
+	for _, opt := range u.Opts {
+		var err error
+		switch t := opt.(type) {
+		case nil, option.OptUpsert, option.OptCollation, option.OptArrayFilters:
+			continue
+		case option.OptOrdered:
+			if !t {
+				u.continueOnError = true
 			}
+			err = opt.Option(command)
+		case option.OptComment:
+			err = appendComment(desc, command, t)
+		default:
+			err = opt.Option(command)
+		}
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -96,6 +153,7 @@ func (u *Update) Decode(desc description.SelectedServer, wm wiremessage.WireMess
 }
 
 func (u *Update) decode(desc description.SelectedServer, rdr bson.Reader) *Update {
+	u.result = result.Update{} // the fields of a previous batch's result are not overwritten if absent
 	u.err = bson.Unmarshal(rdr, &u.result)
 	return u
 }
@@ -111,17 +169,85 @@ func (u *Update) Result() (result.Update, error) {
 // Err returns the error set on this command.
 func (u *Update) Err() error { return u.err }
 
-// RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
+// RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter. The
+// results of the batches are aggregated, and the indexes of write errors and upserted documents
+// refer to the position of their statement in Docs. When a retryable write fails, the batches that
+// succeeded are kept on the command so that calling RoundTrip again resumes at the failed batch.
 func (u *Update) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (result.Update, error) {
-	cmd, err := u.encode(desc)
-	if err != nil {
-		return result.Update{}, err
+	if u.batches == nil {
+		err := u.encode(desc)
+		if err != nil {
+			return result.Update{}, err
+		}
 	}
 
-	rdr, err := cmd.RoundTrip(ctx, desc, rw)
-	if err != nil {
-		return result.Update{}, err
+	// hold onto txnNumber, reset it when loop exits to ensure reuse of same
+	// transaction number if retry is needed
+	var txnNumber int64
+	retryWrite := u.Session != nil && u.Session.RetryWrite
+	if retryWrite {
+		txnNumber = u.Session.TxnNumber
 	}
+	offsets := u.offsets
+	for j, cmd := range u.batches {
+		rdr, err := cmd.RoundTrip(ctx, desc, rw)
+		if err != nil {
+			if retryWrite {
+				u.Session.TxnNumber = txnNumber + int64(j)
+			}
+			return u.acc, err
+		}
+
+		r, err := u.decode(desc, rdr).Result()
+		if err != nil {
+			return u.acc, err
+		}
+
+		if r.WriteConcernError != nil && retryWrite {
+			// report writeconcernerror for retry, which resends this batch, so its result is not
+			// kept on the command
+			u.Session.TxnNumber = txnNumber + int64(j)
+			res := u.acc
+			addUpdateResult(&res, r, offsets[j])
+			return res, nil
+		}
+		addUpdateResult(&u.acc, r, offsets[j])
 
-	return u.decode(desc, rdr).Result()
+		if !u.continueOnError && len(u.acc.WriteErrors) > 0 {
+			return u.acc, nil
+		}
+
+		// Increment txnNumber for each batch
+		if retryWrite {
+			u.Session.IncrementTxnNumber()
+			u.batches = u.batches[1:] // if batch encoded successfully, remove it from the slice
+			u.offsets = u.offsets[1:]
+		}
+	}
+
+	if retryWrite {
+		// if retryable write succeeded, transaction number will be incremented one extra time,
+		// so we decrement it here
+		u.Session.TxnNumber--
+	}
+
+	return u.acc, nil
+}
+
+// addUpdateResult adds the result r of the batch starting at offset to res. The indexes of write
+// errors and upserted documents are relative to the batch.
+func addUpdateResult(res *result.Update, r result.Update, offset int) {
+	for _, we := range r.WriteErrors {
+		we.Index += offset
+		res.WriteErrors = append(res.WriteErrors, we)
+	}
+	for _, upserted := range r.Upserted {
+		upserted.Index += int64(offset)
+		res.Upserted = append(res.Upserted, upserted)
+	}
+	if r.WriteConcernError != nil {
+		res.WriteConcernError = r.WriteConcernError
+	}
+	res.MatchedCount += r.MatchedCount
+	res.ModifiedCount += r.ModifiedCount
 }
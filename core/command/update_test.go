@@ -0,0 +1,124 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+// batchConn returns a connection that replies to the commands written to it with replies.
+func batchConn(t *testing.T, replies ...*bson.Document) *internal.ChannelConn {
+	conn := &internal.ChannelConn{
+		T:        t,
+		Written:  make(chan wiremessage.WireMessage, len(replies)),
+		ReadResp: make(chan wiremessage.WireMessage, len(replies)),
+	}
+	for _, reply := range replies {
+		conn.ReadResp <- internal.MakeReply(t, reply)
+	}
+	return conn
+}
+
+func batchWriteError(index int32) *bson.Value {
+	return bson.VC.DocumentFromElements(
+		bson.EC.Int32("index", index),
+		bson.EC.Int32("code", 11000),
+		bson.EC.String("errmsg", "duplicate key"),
+	)
+}
+
+var batchDesc = description.SelectedServer{Server: description.Server{
+	MaxBatchCount:   2,
+	MaxDocumentSize: 16 * 1024 * 1024,
+	MaxMessageSize:  48 * 1000 * 1000,
+	WireVersion:     &description.VersionRange{Max: wiremessage.OpmsgWireVersion},
+}}
+
+func TestUpdateBatches(t *testing.T) {
+	statements := func() []*bson.Document {
+		var docs []*bson.Document
+		for n := 0; n < 5; n++ {
+			docs = append(docs, bson.NewDocument(
+				bson.EC.SubDocumentFromElements("q", bson.EC.Int32("_id", int32(n))),
+				bson.EC.SubDocumentFromElements("u", bson.EC.SubDocumentFromElements("$set", bson.EC.Int32("x", 1))),
+			))
+		}
+		return docs
+	}
+
+	t.Run("unordered", func(t *testing.T) {
+		u := &Update{
+			NS:   Namespace{DB: "db", Collection: "coll"},
+			Docs: statements(),
+			Opts: []option.UpdateOptioner{option.OptOrdered(false), option.OptUpsert(true)},
+		}
+		conn := batchConn(t,
+			bson.NewDocument(bson.EC.Int32("ok", 1), bson.EC.Int32("n", 1), bson.EC.Int32("nModified", 1),
+				bson.EC.ArrayFromElements("writeErrors", batchWriteError(1))),
+			bson.NewDocument(bson.EC.Int32("ok", 1), bson.EC.Int32("n", 2), bson.EC.Int32("nModified", 1),
+				bson.EC.ArrayFromElements("upserted", bson.VC.DocumentFromElements(
+					bson.EC.Int32("index", 1), bson.EC.Int32("_id", 3)))),
+			bson.NewDocument(bson.EC.Int32("ok", 1), bson.EC.Int32("n", 0), bson.EC.Int32("nModified", 0),
+				bson.EC.ArrayFromElements("writeErrors", batchWriteError(0))),
+		)
+
+		res, err := u.RoundTrip(context.Background(), batchDesc, conn)
+		assert.NoError(t, err)
+		assert.Len(t, conn.Written, 3)
+		assert.Equal(t, int64(3), res.MatchedCount)
+		assert.Equal(t, int64(2), res.ModifiedCount)
+		if assert.Len(t, res.WriteErrors, 2) {
+			assert.Equal(t, 1, res.WriteErrors[0].Index)
+			assert.Equal(t, 4, res.WriteErrors[1].Index)
+		}
+		if assert.Len(t, res.Upserted, 1) {
+			assert.Equal(t, int64(3), res.Upserted[0].Index)
+		}
+
+		// the upsert option is set on the statements rather than the command
+		wm := <-conn.Written
+		msg := wm.(wiremessage.Msg)
+		cmd, err := msg.Sections[0].(wiremessage.SectionBody).Document.Lookup("ordered")
+		assert.NoError(t, err)
+		assert.False(t, cmd.Value().Boolean())
+		seq := msg.Sections[1].(wiremessage.SectionDocumentSequence)
+		assert.Equal(t, "updates", seq.Identifier)
+		if assert.Len(t, seq.Documents, 2) {
+			elem, err := seq.Documents[0].Lookup("upsert")
+			assert.NoError(t, err)
+			assert.True(t, elem.Value().Boolean())
+		}
+		assert.Equal(t, 2, u.Docs[0].Len())
+	})
+	t.Run("ordered", func(t *testing.T) {
+		u := &Update{
+			NS:   Namespace{DB: "db", Collection: "coll"},
+			Docs: statements(),
+		}
+		conn := batchConn(t,
+			bson.NewDocument(bson.EC.Int32("ok", 1), bson.EC.Int32("n", 2), bson.EC.Int32("nModified", 2)),
+			bson.NewDocument(bson.EC.Int32("ok", 1), bson.EC.Int32("n", 0), bson.EC.Int32("nModified", 0),
+				bson.EC.ArrayFromElements("writeErrors", batchWriteError(0))),
+		)
+
+		res, err := u.RoundTrip(context.Background(), batchDesc, conn)
+		assert.NoError(t, err)
+		assert.Len(t, conn.Written, 2)
+		assert.Equal(t, int64(2), res.MatchedCount)
+		if assert.Len(t, res.WriteErrors, 1) {
+			assert.Equal(t, 2, res.WriteErrors[0].Index)
+		}
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"errors"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/csot"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
@@ -28,8 +29,9 @@ type Write struct {
 	Clock        *session.ClusterClock
 	Session      *session.Client
 
-	result bson.Reader
-	err    error
+	maxTimeMS int64 // derived from the operation timeout by RoundTrip
+	result    bson.Reader
+	err       error
 }
 
 // Encode c as OP_MSG
@@ -66,7 +68,7 @@ func (w *Write) encodeOpMsg(desc description.SelectedServer, cmd *bson.Document)
 	}
 
 	// flags
-	if !writeconcern.AckWrite(w.WriteConcern) {
+	if !w.WriteConcern.Acknowledged() {
 		msg.FlagBits |= wiremessage.MoreToCome
 	}
 
@@ -124,7 +126,7 @@ func (w *Write) Encode(desc description.SelectedServer) (wiremessage.WireMessage
 	var err error
 	if w.Session != nil && w.Session.TransactionStarting() {
 		// Starting transactions have a read concern, even in writes.
-		err = addReadConcern(cmd, desc, nil, w.Session)
+		err = addReadConcern(cmd, desc, nil, nil, w.Session)
 		if err != nil {
 			return nil, err
 		}
@@ -134,7 +136,7 @@ func (w *Write) Encode(desc description.SelectedServer) (wiremessage.WireMessage
 		return nil, err
 	}
 
-	if !writeconcern.AckWrite(w.WriteConcern) {
+	if !w.WriteConcern.Acknowledged() {
 		// unack write with explicit session --> raise an error
 		// unack write with implicit session --> do not send session ID (implicit session shouldn't have been created
 		// in the first place)
@@ -159,6 +161,13 @@ func (w *Write) Encode(desc description.SelectedServer) (wiremessage.WireMessage
 		return nil, err
 	}
 
+	addMaxTimeMS(cmd, w.maxTimeMS)
+
+	err = addServerAPI(cmd, desc.ServerAPI)
+	if err != nil {
+		return nil, err
+	}
+
 	if desc.WireVersion == nil || desc.WireVersion.Max < wiremessage.OpmsgWireVersion {
 		return w.encodeOpQuery(desc, cmd)
 	}
@@ -184,7 +193,7 @@ func (w *Write) Decode(desc description.SelectedServer, wm wiremessage.WireMessa
 
 	_ = updateClusterTimes(w.Session, w.Clock, w.result)
 
-	if writeconcern.AckWrite(w.WriteConcern) {
+	if w.WriteConcern.Acknowledged() {
 		// don't update session operation time for unacknowledged write
 		_ = updateOperationTime(w.Session, w.result)
 	}
@@ -207,6 +216,12 @@ func (w *Write) Err() error {
 
 // RoundTrip handles the execution of this command using the provided wiremessage.ReadWriteCloser.
 func (w *Write) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (bson.Reader, error) {
+	var err error
+	w.maxTimeMS, err = csot.MaxTimeMS(ctx, desc.MinRTT)
+	if err != nil {
+		return nil, err
+	}
+
 	wm, err := w.Encode(desc)
 	if err != nil {
 		return nil, err
@@ -218,7 +233,7 @@ func (w *Write) RoundTrip(ctx context.Context, desc description.SelectedServer,
 			return nil, err
 		}
 		// Connection errors are transient
-		return nil, Error{Message: err.Error(), Labels: []string{TransientTransactionError, NetworkError}}
+		return nil, Error{Message: err.Error(), Labels: []string{TransientTransactionError, NetworkError}, Wrapped: err}
 	}
 
 	if msg, ok := wm.(wiremessage.Msg); ok {
@@ -234,7 +249,7 @@ func (w *Write) RoundTrip(ctx context.Context, desc description.SelectedServer,
 			return nil, err
 		}
 		// Connection errors are transient
-		return nil, Error{Message: err.Error(), Labels: []string{TransientTransactionError, NetworkError}}
+		return nil, Error{Message: err.Error(), Labels: []string{TransientTransactionError, NetworkError}, Wrapped: err}
 	}
 
 	if w.Session != nil {
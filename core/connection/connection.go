@@ -16,7 +16,6 @@ package connection
 import (
 	"context"
 	"crypto/tls"
-	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -29,9 +28,9 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/compressor"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/event"
+	"github.com/mongodb/mongo-go-driver/core/logger"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 
@@ -87,15 +86,12 @@ type HandshakerFunc func(context.Context, address.Address, wiremessage.ReadWrite
 
 // Handshake implements the Handshaker interface.
 func (hf HandshakerFunc) Handshake(ctx context.Context, addr address.Address, rw wiremessage.ReadWriter) (description.Server, error) {
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/connection.(HandshakerFunc).Handshake")
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/connection.(HandshakerFunc).Handshake")
 	defer span.End()
 
 	ds, err := hf(ctx, addr, rw)
 	if err != nil {
-		span.SetStatus(trace.Status{
-			Code:    int32(trace.StatusCodeInternal),
-			Message: err.Error(),
-		})
+		span.SetStatus(observability.SpanStatus(err))
 	}
 	return ds, err
 }
@@ -117,23 +113,36 @@ type connection struct {
 	readTimeout      time.Duration
 	uncompressBuf    []byte // buffer to uncompress messages
 	writeTimeout     time.Duration
-	readBuf          []byte
-	writeBuf         []byte
 	wireMessageBuf   []byte // buffer to store uncompressed wire message before compressing
+	logger           logger.Logger
+	pooledReplies    bool // whether reply documents are read into pooled buffers
+	redactValues     bool // whether the values of the documents of monitored commands are redacted
+
+	// requestIDPrefix holds the high bits of the request IDs of the connection, and requestID
+	// counts the wire messages sent on it in the low bits.
+	requestIDPrefix int32
+	requestID       int32
 }
 
+// requestIDBits is the number of low bits of a request ID counting the wire messages sent on a
+// connection. The bits above them hold the request ID prefix.
+const requestIDBits = 24
+
+// MaxRequestIDPrefix is the largest request ID prefix, which keeps request IDs positive.
+const MaxRequestIDPrefix = 1<<(31-requestIDBits) - 1
+
 // New opens a connection to a given Addr
 //
 // The server description returned is nil if there was no handshaker provided.
 func New(ctx context.Context, addr address.Address, opts ...Option) (Connection, *description.Server, error) {
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/connection.New")
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/connection.New")
 	defer span.End()
 
 	span.Annotatef(nil, "Invoking newConfig")
 	cfg, err := newConfig(opts...)
 	span.Annotatef(nil, "Finished invoking newConfig")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, nil, err
 	}
 
@@ -141,7 +150,9 @@ func New(ctx context.Context, addr address.Address, opts ...Option) (Connection,
 	nc, err := cfg.dialer.DialContext(ctx, addr.Network(), addr.String())
 	span.Annotatef(nil, "Finished invoking Config.Dialer.DialContext")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
+		logger.Log(cfg.logger, logger.LevelWarn, logger.ComponentConnection, "Connection failed",
+			"address", addr.String(), "error", err)
 		return nil, nil, err
 	}
 
@@ -151,7 +162,9 @@ func New(ctx context.Context, addr address.Address, opts ...Option) (Connection,
 		nc, err = configureTLS(ctx, nc, addr, tlsConfig)
 		span.Annotatef(nil, "Finished configuring TLS")
 		if err != nil {
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			span.SetStatus(observability.SpanStatus(err))
+			logger.Log(cfg.logger, logger.LevelWarn, logger.ComponentConnection, "Connection failed",
+				"address", addr.String(), "error", err)
 			return nil, nil, err
 		}
 	}
@@ -179,10 +192,12 @@ func New(ctx context.Context, addr address.Address, opts ...Option) (Connection,
 		lifetimeDeadline: lifetimeDeadline,
 		readTimeout:      cfg.readTimeout,
 		writeTimeout:     cfg.writeTimeout,
-		readBuf:          make([]byte, 256),
 		uncompressBuf:    make([]byte, 256),
-		writeBuf:         make([]byte, 0, 256),
 		wireMessageBuf:   make([]byte, 256),
+		logger:           cfg.logger,
+		pooledReplies:    cfg.pooledReplies,
+		redactValues:     cfg.redactValues,
+		requestIDPrefix:  int32(cfg.requestIDPrefix) << requestIDBits,
 	}
 
 	c.bumpIdleDeadline()
@@ -190,12 +205,16 @@ func New(ctx context.Context, addr address.Address, opts ...Option) (Connection,
 	var desc *description.Server
 	if cfg.handshaker != nil {
 		span.Annotatef(nil, "Invoking handshaker.Handshake")
+		start := time.Now()
 		d, err := cfg.handshaker.Handshake(ctx, c.addr, c)
 		span.Annotatef(nil, "Finished invoking handshaker.Handshake")
 		if err != nil {
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			span.SetStatus(observability.SpanStatus(err))
+			logger.Log(cfg.logger, logger.LevelWarn, logger.ComponentConnection, "Connection failed",
+				"address", addr.String(), "connectionID", id, "error", err)
 			return nil, nil, err
 		}
+		observability.Record(ctx, observability.MHandshakeLatencyMilliseconds.M(observability.SinceInMilliseconds(start)))
 
 		if len(d.Compression) > 0 {
 		clientMethodLoop:
@@ -218,10 +237,24 @@ func New(ctx context.Context, addr address.Address, opts ...Option) (Connection,
 	}
 
 	c.cmdMonitor = cfg.cmdMonitor // attach the command monitor later to avoid monitoring auth
+	if cfg.logger.Enabled(logger.LevelDebug, logger.ComponentCommand) {
+		c.cmdMonitor = logMonitor(cfg.logger, c.cmdMonitor)
+	}
+
+	logger.Log(cfg.logger, logger.LevelDebug, logger.ComponentConnection, "Connection created",
+		"address", addr.String(), "connectionID", id)
 	return c, desc, nil
 }
 
-func configureTLS(ctx context.Context, nc net.Conn, addr address.Address, config *TLSConfig) (net.Conn, error) {
+func configureTLS(ctx context.Context, nc net.Conn, addr address.Address, config *TLSConfig) (_ net.Conn, err error) {
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/connection.configureTLS")
+	defer func() {
+		if err != nil {
+			span.SetStatus(observability.SpanStatus(err))
+		}
+		span.End()
+	}()
+
 	if !config.InsecureSkipVerify {
 		hostname := addr.String()
 		colonPos := strings.LastIndex(hostname, ":")
@@ -234,6 +267,7 @@ func configureTLS(ctx context.Context, nc net.Conn, addr address.Address, config
 	}
 
 	client := tls.Client(nc, config.Config)
+	span.AddAttributes(trace.StringAttribute("server_name", config.ServerName))
 
 	errChan := make(chan error, 1)
 	go func() {
@@ -241,12 +275,16 @@ func configureTLS(ctx context.Context, nc net.Conn, addr address.Address, config
 	}()
 
 	select {
-	case err := <-errChan:
+	case err = <-errChan:
 		if err != nil {
 			return nil, err
 		}
 	case <-ctx.Done():
-		return nil, errors.New("server connection cancelled/timeout during TLS handshake")
+		return nil, Error{
+			ConnectionID: addr.String(),
+			Wrapped:      ctx.Err(),
+			message:      "server connection cancelled/timeout during TLS handshake",
+		}
 	}
 	return client, nil
 }
@@ -276,6 +314,49 @@ func canCompress(cmd string) bool {
 	return true
 }
 
+// stampRequestID returns wm with the next request ID of the connection, along with that ID, so that
+// the request IDs of the wire messages sent on a connection increase one by one, following its
+// prefix. Wire messages other than requests are returned unchanged, with an ID of 0.
+func (c *connection) stampRequestID(wm wiremessage.WireMessage) (wiremessage.WireMessage, int32) {
+	switch t := wm.(type) {
+	case wiremessage.Msg:
+		t.MsgHeader.RequestID = c.nextRequestID()
+		return t, t.MsgHeader.RequestID
+	case wiremessage.Query:
+		t.MsgHeader.RequestID = c.nextRequestID()
+		return t, t.MsgHeader.RequestID
+	case wiremessage.Command:
+		t.MsgHeader.RequestID = c.nextRequestID()
+		return t, t.MsgHeader.RequestID
+	case wiremessage.GetMore:
+		t.MsgHeader.RequestID = c.nextRequestID()
+		return t, t.MsgHeader.RequestID
+	case wiremessage.KillCursors:
+		t.MsgHeader.RequestID = c.nextRequestID()
+		return t, t.MsgHeader.RequestID
+	case wiremessage.Insert:
+		t.MsgHeader.RequestID = c.nextRequestID()
+		return t, t.MsgHeader.RequestID
+	case wiremessage.Update:
+		t.MsgHeader.RequestID = c.nextRequestID()
+		return t, t.MsgHeader.RequestID
+	case wiremessage.Delete:
+		t.MsgHeader.RequestID = c.nextRequestID()
+		return t, t.MsgHeader.RequestID
+	}
+	return wm, 0
+}
+
+// nextRequestID returns the next request ID of the connection. The count wraps around to 1 once
+// it no longer fits below the prefix.
+func (c *connection) nextRequestID() int32 {
+	c.requestID = (c.requestID + 1) & (1<<requestIDBits - 1)
+	if c.requestID == 0 {
+		c.requestID = 1
+	}
+	return c.requestIDPrefix | c.requestID
+}
+
 func (c *connection) compressMessage(wm wiremessage.WireMessage) (wiremessage.WireMessage, error) {
 	var requestID int32
 	var responseTo int32
@@ -380,15 +461,6 @@ func (c *connection) uncompressMessage(compressed wiremessage.Compressed) ([]byt
 	return fullMessage, origHeader.OpCode, nil
 }
 
-func canMonitor(cmd string) bool {
-	if cmd == "authenticate" || cmd == "saslStart" || cmd == "saslContinue" || cmd == "getnonce" || cmd == "createUser" ||
-		cmd == "updateUser" || cmd == "copydbgetnonce" || cmd == "copydbsaslstart" || cmd == "copydb" {
-		return false
-	}
-
-	return true
-}
-
 func (c *connection) commandStartedEvent(ctx context.Context, wm wiremessage.WireMessage) error {
 	if c.cmdMonitor == nil || c.cmdMonitor.Started == nil {
 		return nil
@@ -415,7 +487,9 @@ func (c *connection) commandStartedEvent(ctx context.Context, wm wiremessage.Wir
 
 		cmdElem := cmd.ElementAt(0)
 		if cmdElem.Key() == "$query" {
-			cmd = cmdElem.Value().MutableDocument()
+			if query, ok := cmdElem.Value().MutableDocumentOK(); ok {
+				cmd = query
+			}
 		}
 	case wiremessage.Msg:
 		cmd, err = converted.GetMainDocument()
@@ -438,15 +512,12 @@ func (c *connection) commandStartedEvent(ctx context.Context, wm wiremessage.Wir
 			return err
 		}
 
-		startedEvent.DatabaseName = dbVal.StringValue()
+		startedEvent.DatabaseName, _ = dbVal.StringValueOK()
 		startedEvent.RequestID = int64(converted.MsgHeader.RequestID)
 	}
 
-	startedEvent.Command = cmd
 	startedEvent.CommandName = cmd.ElementAt(0).Key()
-	if !canMonitor(startedEvent.CommandName) {
-		startedEvent.Command = emptyDoc
-	}
+	startedEvent.Command = event.RedactCommand(startedEvent.CommandName, cmd, c.redactValues)
 
 	c.cmdMonitor.Started(ctx, startedEvent)
 
@@ -477,7 +548,7 @@ func (c *connection) commandStartedEvent(ctx context.Context, wm wiremessage.Wir
 	return nil
 }
 
-func processReply(reply *bson.Document) (bool, string) {
+func processReply(reply *bson.Document) (bool, int32, string) {
 	iter := reply.Iterator()
 	var success bool
 	var errmsg string
@@ -513,11 +584,10 @@ func processReply(reply *bson.Document) (bool, string) {
 	}
 
 	if success {
-		return true, ""
+		return true, 0, ""
 	}
 
-	fullErrMsg := fmt.Sprintf("Error code %d: %s", errCode, errmsg)
-	return false, fullErrMsg
+	return false, errCode, errmsg
 }
 
 func (c *connection) commandFinishedEvent(ctx context.Context, wm wiremessage.WireMessage) error {
@@ -544,7 +614,7 @@ func (c *connection) commandFinishedEvent(ctx context.Context, wm wiremessage.Wi
 
 	cmdMetadata := c.commandMap[requestID]
 	delete(c.commandMap, requestID)
-	success, errmsg := processReply(reply)
+	success, errCode, errmsg := processReply(reply)
 
 	if (success && c.cmdMonitor.Succeeded == nil) || (!success && c.cmdMonitor.Failed == nil) {
 		return nil
@@ -558,7 +628,7 @@ func (c *connection) commandFinishedEvent(ctx context.Context, wm wiremessage.Wi
 	}
 
 	if success {
-		if !canMonitor(finishedEvent.CommandName) {
+		if event.IsSensitiveCommand(finishedEvent.CommandName) {
 			successEvent := &event.CommandSucceededEvent{
 				Reply:                emptyDoc,
 				CommandFinishedEvent: finishedEvent,
@@ -579,8 +649,19 @@ func (c *connection) commandFinishedEvent(ctx context.Context, wm wiremessage.Wi
 			}
 		}
 
+		if c.pooledReplies {
+			// the reply can be returned to the pool while the monitor still holds the event
+			b, err := reply.MarshalBSON()
+			if err != nil {
+				return err
+			}
+			if reply, err = bson.ReadDocument(b); err != nil {
+				return err
+			}
+		}
+
 		successEvent := &event.CommandSucceededEvent{
-			Reply:                reply,
+			Reply:                event.RedactReply(finishedEvent.CommandName, reply, c.redactValues),
 			CommandFinishedEvent: finishedEvent,
 		}
 
@@ -589,7 +670,7 @@ func (c *connection) commandFinishedEvent(ctx context.Context, wm wiremessage.Wi
 	}
 
 	failureEvent := &event.CommandFailedEvent{
-		Failure:              errmsg,
+		Failure:              fmt.Sprintf("Error code %d: %s", errCode, event.RedactFailure(errmsg, c.redactValues)),
 		CommandFinishedEvent: finishedEvent,
 	}
 
@@ -632,10 +713,9 @@ func (c *connection) WriteWireMessage(ctx context.Context, wm wiremessage.WireMe
 			message:      "failed to set write deadline",
 		}
 	}
+	defer c.watchCancel(ctx)()
 
-	// Truncate the write buffer
-	c.writeBuf = c.writeBuf[:0]
-
+	wm, requestID := c.stampRequestID(wm)
 	messageToWrite := wm
 	// Compress if possible
 	if c.compressor != nil {
@@ -650,7 +730,9 @@ func (c *connection) WriteWireMessage(ctx context.Context, wm wiremessage.WireMe
 		messageToWrite = compressed
 	}
 
-	c.writeBuf, err = messageToWrite.AppendWireMessage(c.writeBuf)
+	// the message is built into a buffer from the pool, which is returned once it has been written
+	writeBuf, err := messageToWrite.AppendWireMessage(wiremessage.GetBuffer(messageToWrite.Len()))
+	defer wiremessage.PutBuffer(writeBuf)
 	if err != nil {
 		return Error{
 			ConnectionID: c.id,
@@ -659,17 +741,35 @@ func (c *connection) WriteWireMessage(ctx context.Context, wm wiremessage.WireMe
 		}
 	}
 
-	nw, err := c.conn.Write(c.writeBuf)
-	stats.Record(ctx, observability.MWrites.M(1), observability.MBytesWritten.M(int64(nw)))
+	nw, err := c.conn.Write(writeBuf)
+	observability.Record(ctx, observability.MWrites.M(1), observability.MBytesWritten.M(int64(nw)))
 	if err != nil {
 		c.Close()
 		return Error{
 			ConnectionID: c.id,
-			Wrapped:      err,
+			Wrapped:      contextOrNetError(ctx, err),
 			message:      "unable to write wire message to network",
 		}
 	}
 
+	if observability.Enabled() {
+		uncompressed := int64(nw)
+		if compressed, ok := messageToWrite.(wiremessage.Compressed); ok {
+			uncompressed = int64(compressed.UncompressedSize) + 16 // add 16 for the original header
+		}
+		observability.Record(ctx, observability.MBytesSent.M(int64(nw)), observability.MBytesSentUncompressed.M(uncompressed))
+		observability.AddOperationAttributes(ctx,
+			trace.StringAttribute("connection_id", c.id),
+			trace.Int64Attribute("request_id", int64(requestID)),
+		)
+		observability.AnnotateOperation(ctx, []trace.Attribute{
+			trace.Int64Attribute("bytes", int64(nw)),
+			trace.Int64Attribute("uncompressed_bytes", uncompressed),
+			trace.Int64Attribute("request_id", int64(requestID)),
+		}, "Sent wire message")
+	}
+	observability.DebugSent(ctx, c.addr)
+
 	c.bumpIdleDeadline()
 	err = c.commandStartedEvent(ctx, wm)
 	if err != nil {
@@ -686,13 +786,14 @@ func (c *connection) ReadWireMessage(ctx context.Context) (wiremessage.WireMessa
 		}
 	}
 
-	ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyMethod, "readwiremessage"))
+	opCtx := ctx
+	ctx = observability.Tag(ctx, tag.Upsert(observability.KeyMethod, "readwiremessage"))
 	select {
 	case <-ctx.Done():
 		// We close the connection because we don't know if there
 		// is an unread message on the wire.
 		c.Close()
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "read"))
+		observability.RecordError(ctx, "read", ctx.Err())
 		return nil, Error{
 			ConnectionID: c.id,
 			Wrapped:      ctx.Err(),
@@ -711,29 +812,29 @@ func (c *connection) ReadWireMessage(ctx context.Context) (wiremessage.WireMessa
 	}
 
 	if err := c.conn.SetReadDeadline(deadline); err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "set_read_deadline"))
+		observability.RecordError(ctx, "set_read_deadline", err)
 		return nil, Error{
 			ConnectionID: c.id,
-			Wrapped:      ctx.Err(),
+			Wrapped:      err,
 			message:      "failed to set read deadline",
 		}
 	}
+	defer c.watchCancel(ctx)()
 
 	var sizeBuf [4]byte
 	var n, nr int64
 	ni, err := io.ReadFull(c.conn, sizeBuf[:])
 	nr += 1
 	defer func() {
-		stats.Record(ctx, observability.MReads.M(nr), observability.MBytesRead.M(n))
+		observability.Record(ctx, observability.MReads.M(nr), observability.MBytesRead.M(n))
 	}()
 
 	if err != nil {
 		c.Close()
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "read"))
-		stats.Record(ctx, observability.MErrors.M(1))
+		observability.RecordError(ctx, "read", contextOrNetError(ctx, err))
 		return nil, Error{
 			ConnectionID: c.id,
-			Wrapped:      err,
+			Wrapped:      contextOrNetError(ctx, err),
 			message:      "unable to decode message length",
 		}
 	}
@@ -741,35 +842,30 @@ func (c *connection) ReadWireMessage(ctx context.Context) (wiremessage.WireMessa
 
 	size := readInt32(sizeBuf[:], 0)
 
-	// Isn't the best reuse, but resizing a []byte to be larger
-	// is difficult.
-	if cap(c.readBuf) > int(size) {
-		c.readBuf = c.readBuf[:size]
-	} else {
-		c.readBuf = make([]byte, size)
-	}
+	// The message is read into a buffer from the pool. The documents of the message are copied
+	// when it is unmarshaled, so the buffer is returned once the message has been decoded.
+	readBuf := wiremessage.GetBuffer(int(size))[:size]
+	defer wiremessage.PutBuffer(readBuf)
 
-	c.readBuf[0], c.readBuf[1], c.readBuf[2], c.readBuf[3] = sizeBuf[0], sizeBuf[1], sizeBuf[2], sizeBuf[3]
+	readBuf[0], readBuf[1], readBuf[2], readBuf[3] = sizeBuf[0], sizeBuf[1], sizeBuf[2], sizeBuf[3]
 
-	ni, err = io.ReadFull(c.conn, c.readBuf[4:])
+	ni, err = io.ReadFull(c.conn, readBuf[4:])
 	nr += 1
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "read"))
-		stats.Record(ctx, observability.MErrors.M(1))
+		observability.RecordError(ctx, "read", contextOrNetError(ctx, err))
 		c.Close()
 		return nil, Error{
 			ConnectionID: c.id,
-			Wrapped:      err,
+			Wrapped:      contextOrNetError(ctx, err),
 			message:      "unable to read full message",
 		}
 	}
 	n += int64(ni)
 
-	hdr, err := wiremessage.ReadHeader(c.readBuf, 0)
+	hdr, err := wiremessage.ReadHeader(readBuf, 0)
 	if err != nil {
 		c.Close()
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "read"))
-		stats.Record(ctx, observability.MErrors.M(1))
+		observability.RecordError(ctx, "read", err)
 		return nil, Error{
 			ConnectionID: c.id,
 			Wrapped:      err,
@@ -781,12 +877,12 @@ func (c *connection) ReadWireMessage(ctx context.Context) (wiremessage.WireMessa
 	//      https://github.com/mongodb/mongo-go-driver/blob/de03a35e8661ae6df623f41ec8f616ffd8ef6131/core/wiremessage/header.go#L41-L51
 	n += 16
 
-	messageToDecode := c.readBuf
+	messageToDecode := readBuf
 	opcodeToCheck := hdr.OpCode
 
 	if hdr.OpCode == wiremessage.OpCompressed {
 		var compressed wiremessage.Compressed
-		err := compressed.UnmarshalWireMessage(c.readBuf)
+		err := compressed.UnmarshalWireMessage(readBuf)
 		if err != nil {
 			defer c.Close()
 			return nil, Error{
@@ -813,11 +909,15 @@ func (c *connection) ReadWireMessage(ctx context.Context) (wiremessage.WireMessa
 	switch opcodeToCheck {
 	case wiremessage.OpReply:
 		var r wiremessage.Reply
-		err := r.UnmarshalWireMessage(messageToDecode)
+		var err error
+		if c.pooledReplies {
+			err = r.UnmarshalWireMessagePooled(messageToDecode)
+		} else {
+			err = r.UnmarshalWireMessage(messageToDecode)
+		}
 		if err != nil {
 			c.Close()
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "unmarshal"))
-			stats.Record(ctx, observability.MErrors.M(1))
+			observability.RecordError(ctx, "unmarshal", err)
 			return nil, Error{
 				ConnectionID: c.id,
 				Wrapped:      err,
@@ -827,7 +927,12 @@ func (c *connection) ReadWireMessage(ctx context.Context) (wiremessage.WireMessa
 		wm = r
 	case wiremessage.OpMsg:
 		var reply wiremessage.Msg
-		err := reply.UnmarshalWireMessage(messageToDecode)
+		var err error
+		if c.pooledReplies {
+			err = reply.UnmarshalWireMessagePooled(messageToDecode)
+		} else {
+			err = reply.UnmarshalWireMessage(messageToDecode)
+		}
 		if err != nil {
 			c.Close()
 			return nil, Error{
@@ -839,14 +944,26 @@ func (c *connection) ReadWireMessage(ctx context.Context) (wiremessage.WireMessa
 		wm = reply
 	default:
 		c.Close()
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "read"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		return nil, Error{
+		err = Error{
 			ConnectionID: c.id,
 			message:      fmt.Sprintf("opcode %s not implemented", hdr.OpCode),
 		}
+		observability.RecordError(ctx, "read", err)
+		return nil, err
+	}
+
+	if observability.Enabled() {
+		received, uncompressed := int64(len(readBuf)), int64(len(messageToDecode))
+		observability.Record(opCtx, observability.MBytesReceived.M(received), observability.MBytesReceivedUncompressed.M(uncompressed))
+		observability.AnnotateOperation(opCtx, []trace.Attribute{
+			trace.Int64Attribute("bytes", received),
+			trace.Int64Attribute("uncompressed_bytes", uncompressed),
+			trace.Int64Attribute("documents", replyDocuments(wm)),
+		}, "Received wire message")
 	}
 
+	observability.DebugReply(opCtx, replyDocument(wm))
+
 	c.bumpIdleDeadline()
 	err = c.commandFinishedEvent(ctx, wm)
 	if err != nil {
@@ -856,6 +973,119 @@ func (c *connection) ReadWireMessage(ctx context.Context) (wiremessage.WireMessa
 	return wm, nil
 }
 
+// replyDocument returns the command reply document of a reply, or nil if it has none.
+func replyDocument(wm wiremessage.WireMessage) bson.Reader {
+	switch reply := wm.(type) {
+	case wiremessage.Reply:
+		if len(reply.Documents) > 0 {
+			return reply.Documents[0]
+		}
+	case wiremessage.Msg:
+		for _, section := range reply.Sections {
+			if body, ok := section.(wiremessage.SectionBody); ok {
+				return body.Document
+			}
+		}
+	}
+	return nil
+}
+
+// replyDocuments returns the number of documents in a reply. The documents of a cursor batch are
+// counted instead of the command reply containing them.
+func replyDocuments(wm wiremessage.WireMessage) int64 {
+	var docs []bson.Reader
+	switch reply := wm.(type) {
+	case wiremessage.Reply:
+		docs = reply.Documents
+	case wiremessage.Msg:
+		for _, section := range reply.Sections {
+			switch s := section.(type) {
+			case wiremessage.SectionBody:
+				docs = append(docs, s.Document)
+			case wiremessage.SectionDocumentSequence:
+				docs = append(docs, s.Documents...)
+			}
+		}
+	}
+
+	var n int64
+	for _, doc := range docs {
+		n += batchLen(doc)
+	}
+	return n
+}
+
+// batchLen returns the number of documents in the cursor batch of a command reply, or 1 if the
+// document is not a cursor reply.
+func batchLen(doc bson.Reader) int64 {
+	for _, key := range []string{"firstBatch", "nextBatch"} {
+		elem, err := doc.Lookup("cursor", key)
+		if err != nil {
+			continue
+		}
+		arr, ok := elem.Value().ReaderArrayOK()
+		if !ok {
+			break
+		}
+		itr, err := arr.Iterator()
+		if err != nil {
+			break
+		}
+		var n int64
+		for itr.Next() {
+			n++
+		}
+		return n
+	}
+	return 1
+}
+
+// watchCancel interrupts the socket operation in progress if ctx is canceled before the returned
+// function is called, by moving the deadline of the socket to the past. The operation then fails
+// and closes the connection, since there is no way to abandon a message that is partially written
+// or read, and the connection is discarded instead of being returned to the pool. The returned
+// function must be called once the operation is over, and waits for the watcher to stop so that it
+// cannot move the deadline of a later operation.
+func (c *connection) watchCancel(ctx context.Context) func() {
+	done := ctx.Done()
+	if done == nil {
+		return func() {}
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-done:
+			_ = c.conn.SetDeadline(aLongTimeAgo)
+		case <-stop:
+		}
+	}()
+	return func() {
+		close(stop)
+		<-stopped
+	}
+}
+
+// aLongTimeAgo is a deadline in the past, which makes blocked socket operations return at once.
+var aLongTimeAgo = time.Unix(1, 0)
+
+// contextOrNetError returns the context's error if the context is done, since an expired context
+// deadline is then the reason the socket operation failed. Otherwise err is returned unchanged.
+func contextOrNetError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	// The socket deadline is set from the context deadline, so the socket can time out a moment
+	// before the context's own timer fires.
+	if dl, ok := ctx.Deadline(); ok && !time.Now().Before(dl) {
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
 func (c *connection) bumpIdleDeadline() {
 	if c.idleTimeout > 0 {
 		c.idleDeadline = time.Now().Add(c.idleTimeout)
@@ -864,6 +1094,8 @@ func (c *connection) bumpIdleDeadline() {
 
 func (c *connection) Close() error {
 	c.dead = true
+	logger.Log(c.logger, logger.LevelDebug, logger.ComponentConnection, "Connection closed",
+		"address", c.addr.String(), "connectionID", c.id)
 	err := c.conn.Close()
 	if err != nil {
 		return Error{
@@ -8,9 +8,22 @@ package connection
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net"
+	"reflect"
 	"sync"
 	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/event"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 )
 
 // bootstrapConnection creates a listener that will listen for a single connection
@@ -87,3 +100,388 @@ func (d *dialer) lenclosed() int {
 	defer d.Unlock()
 	return len(d.closed)
 }
+
+func TestConnectionErrorWrapping(t *testing.T) {
+	t.Run("read honors context deadline", func(t *testing.T) {
+		done := make(chan struct{})
+		defer close(done)
+		addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+			<-done
+			_ = nc.Close()
+		})
+
+		conn, _, err := New(context.Background(), address.Address(addr.String()))
+		if err != nil {
+			t.Fatalf("Unexpected error while creating connection: %v", err)
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = conn.ReadWireMessage(ctx)
+
+		var connErr Error
+		if !errors.As(err, &connErr) {
+			t.Fatalf("Expected a connection.Error but got %T: %v", err, err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected error to wrap context.DeadlineExceeded. got %v", err)
+		}
+	})
+	t.Run("read is interrupted by cancellation", func(t *testing.T) {
+		done := make(chan struct{})
+		defer close(done)
+		addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+			<-done
+			_ = nc.Close()
+		})
+
+		conn, _, err := New(context.Background(), address.Address(addr.String()))
+		if err != nil {
+			t.Fatalf("Unexpected error while creating connection: %v", err)
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+		start := time.Now()
+		_, err = conn.ReadWireMessage(ctx)
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected error to wrap context.Canceled. got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Expected the read to return soon after cancellation, took %v", elapsed)
+		}
+		if conn.Alive() || !conn.Expired() {
+			t.Errorf("Expected the connection to be closed after an interrupted read")
+		}
+	})
+	t.Run("write is interrupted by cancellation", func(t *testing.T) {
+		// writes to a pipe block until the other end reads, which it never does
+		client, server := net.Pipe()
+		defer server.Close()
+
+		conn, _, err := New(context.Background(), address.Address("localhost:27017"), WithDialer(func(Dialer) Dialer {
+			return DialerFunc(func(context.Context, string, string) (net.Conn, error) { return client, nil })
+		}))
+		if err != nil {
+			t.Fatalf("Unexpected error creating connection: %v", err)
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+		cmd := wiremessage.Msg{
+			MsgHeader: wiremessage.Header{RequestID: 1},
+			Sections:  []wiremessage.Section{wiremessage.SectionBody{Document: bson.Reader(mustMarshal(t, bson.NewDocument(bson.EC.String("find", "coll"))))}},
+		}
+		err = conn.WriteWireMessage(ctx, cmd)
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected error to wrap context.Canceled. got %v", err)
+		}
+		if conn.Alive() {
+			t.Errorf("Expected the connection to be closed after an interrupted write")
+		}
+	})
+	t.Run("completed operation keeps the connection usable", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer server.Close()
+		go func() {
+			var size [4]byte
+			_, _ = io.ReadFull(server, size[:])
+			_, _ = io.ReadFull(server, make([]byte, readInt32(size[:], 0)-4))
+		}()
+
+		conn, _, err := New(context.Background(), address.Address("localhost:27017"), WithDialer(func(Dialer) Dialer {
+			return DialerFunc(func(context.Context, string, string) (net.Conn, error) { return client, nil })
+		}))
+		if err != nil {
+			t.Fatalf("Unexpected error creating connection: %v", err)
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cmd := wiremessage.Msg{
+			MsgHeader: wiremessage.Header{RequestID: 1},
+			Sections:  []wiremessage.Section{wiremessage.SectionBody{Document: bson.Reader(mustMarshal(t, bson.NewDocument(bson.EC.String("find", "coll"))))}},
+		}
+		if err = conn.WriteWireMessage(ctx, cmd); err != nil {
+			t.Fatalf("Unexpected error writing: %v", err)
+		}
+		cancel()
+		if !conn.Alive() {
+			t.Errorf("Expected the connection to stay open when the context is canceled after the write")
+		}
+	})
+	t.Run("network error unwraps", func(t *testing.T) {
+		inner := errors.New("connection reset")
+		var err error = NetworkError{ConnectionID: "foo", Wrapped: inner}
+		if !errors.Is(err, inner) {
+			t.Errorf("Expected NetworkError to unwrap to %v", inner)
+		}
+	})
+}
+
+func TestConnectionWireMessageSizes(t *testing.T) {
+	observability.Configure(observability.Options{
+		TraceSampler: func(string) trace.Sampler { return trace.AlwaysSample() },
+	})
+	defer observability.Configure(observability.Options{})
+	spans := &spanRecorder{}
+	trace.RegisterExporter(spans)
+	defer trace.UnregisterExporter(spans)
+
+	keys := []tag.Key{observability.KeyMethod, observability.KeyCommandName}
+	sent := &view.View{Name: "test/bytes_sent", Measure: observability.MBytesSent, Aggregation: view.Sum(), TagKeys: keys}
+	received := &view.View{Name: "test/bytes_received", Measure: observability.MBytesReceived, Aggregation: view.Sum(), TagKeys: keys}
+	if err := view.Register(sent, received); err != nil {
+		t.Fatalf("Unexpected error registering views: %v", err)
+	}
+	defer view.Unregister(sent, received)
+
+	client, server := net.Pipe()
+	reply := wiremessage.Msg{
+		MsgHeader: wiremessage.Header{ResponseTo: 1},
+		Sections: []wiremessage.Section{wiremessage.SectionBody{Document: bson.Reader(mustMarshal(t, bson.NewDocument(
+			bson.EC.SubDocumentFromElements("cursor",
+				bson.EC.ArrayFromElements("firstBatch", bson.VC.Int32(1), bson.VC.Int32(2), bson.VC.Int32(3)),
+			),
+		)))}},
+	}
+	replyBytes, err := reply.MarshalWireMessage()
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling reply: %v", err)
+	}
+	go func() {
+		var size [4]byte
+		_, _ = io.ReadFull(server, size[:])
+		_, _ = io.ReadFull(server, make([]byte, readInt32(size[:], 0)-4))
+		_, _ = server.Write(replyBytes)
+	}()
+
+	conn, _, err := New(context.Background(), address.Address("localhost:27017"), WithDialer(func(Dialer) Dialer {
+		return DialerFunc(func(context.Context, string, string) (net.Conn, error) { return client, nil })
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error creating connection: %v", err)
+	}
+	defer conn.Close()
+
+	ctx := observability.TagNamespace(context.Background(), "db", "coll", "find")
+	ctx, op := observability.StartOperation(ctx, "find", "test/find")
+	cmd := wiremessage.Msg{
+		MsgHeader: wiremessage.Header{RequestID: 1},
+		Sections:  []wiremessage.Section{wiremessage.SectionBody{Document: bson.Reader(mustMarshal(t, bson.NewDocument(bson.EC.String("find", "coll"))))}},
+	}
+	if err = conn.WriteWireMessage(ctx, cmd); err != nil {
+		t.Fatalf("Unexpected error writing: %v", err)
+	}
+	if _, err = conn.ReadWireMessage(ctx); err != nil {
+		t.Fatalf("Unexpected error reading: %v", err)
+	}
+	op.End(nil)
+
+	for _, tc := range []struct {
+		view string
+		want float64
+	}{
+		{sent.Name, float64(cmd.Len())},
+		{received.Name, float64(len(replyBytes))},
+	} {
+		rows, err := view.RetrieveData(tc.view)
+		if err != nil {
+			t.Fatalf("Unexpected error retrieving %s: %v", tc.view, err)
+		}
+		if len(rows) != 1 {
+			t.Fatalf("Expected 1 row for %s. got %d", tc.view, len(rows))
+		}
+		if got := rows[0].Data.(*view.SumData).Value; got != tc.want {
+			t.Errorf("Incorrect %s. got %v; want %v", tc.view, got, tc.want)
+		}
+		if len(rows[0].Tags) != 2 || rows[0].Tags[0].Value != "find" || rows[0].Tags[1].Value != "find" {
+			t.Errorf("Incorrect tags for %s. got %v", tc.view, rows[0].Tags)
+		}
+	}
+
+	var annotations []trace.Annotation
+	spans.Lock()
+	for _, s := range spans.spans {
+		if s.Name == "test/find" {
+			annotations = s.Annotations
+		}
+	}
+	spans.Unlock()
+	if len(annotations) != 2 || annotations[0].Message != "Sent wire message" || annotations[1].Message != "Received wire message" {
+		t.Fatalf("Incorrect annotations on the operation span. got %v", annotations)
+	}
+	if docs := annotations[1].Attributes["documents"]; docs != int64(3) {
+		t.Errorf("Incorrect document count. got %v; want %v", docs, 3)
+	}
+}
+
+func TestConnectionRequestIDs(t *testing.T) {
+	// echo answers every wire message with a reply responding to it, and sends the request IDs it
+	// read on ids.
+	echo := func(server net.Conn, ids chan<- int32) {
+		for {
+			var header [16]byte
+			if _, err := io.ReadFull(server, header[:]); err != nil {
+				close(ids)
+				return
+			}
+			if _, err := io.ReadFull(server, make([]byte, readInt32(header[:], 0)-16)); err != nil {
+				close(ids)
+				return
+			}
+			id := readInt32(header[:], 4)
+			ids <- id
+			reply, _ := wiremessage.Msg{
+				MsgHeader: wiremessage.Header{ResponseTo: id},
+				Sections:  []wiremessage.Section{wiremessage.SectionBody{Document: bson.Reader(mustMarshal(t, bson.NewDocument(bson.EC.Int32("ok", 1))))}},
+			}.MarshalWireMessage()
+			_, _ = server.Write(reply)
+		}
+	}
+	connect := func(t *testing.T, opts ...Option) (*connection, <-chan int32) {
+		client, server := net.Pipe()
+		ids := make(chan int32, 10)
+		go echo(server, ids)
+		opts = append(opts, WithDialer(func(Dialer) Dialer {
+			return DialerFunc(func(context.Context, string, string) (net.Conn, error) { return client, nil })
+		}))
+		conn, _, err := New(context.Background(), address.Address("localhost:27017"), opts...)
+		if err != nil {
+			t.Fatalf("Unexpected error creating connection: %v", err)
+		}
+		return conn.(*connection), ids
+	}
+	roundTrip := func(t *testing.T, conn *connection, ctx context.Context) int32 {
+		cmd := wiremessage.Msg{
+			// the request ID the command was encoded with is replaced by the one of the connection
+			MsgHeader: wiremessage.Header{RequestID: wiremessage.NextRequestID()},
+			Sections:  []wiremessage.Section{wiremessage.SectionBody{Document: bson.Reader(mustMarshal(t, bson.NewDocument(bson.EC.Int32("ping", 1), bson.EC.String("$db", "admin"))))}},
+		}
+		if err := conn.WriteWireMessage(ctx, cmd); err != nil {
+			t.Fatalf("Unexpected error writing: %v", err)
+		}
+		reply, err := conn.ReadWireMessage(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error reading: %v", err)
+		}
+		return reply.(wiremessage.Msg).MsgHeader.ResponseTo
+	}
+
+	t.Run("per connection", func(t *testing.T) {
+		var mu sync.Mutex
+		var started, succeeded []int64
+		monitor := &event.CommandMonitor{
+			Started: func(_ context.Context, e *event.CommandStartedEvent) {
+				mu.Lock()
+				started = append(started, e.RequestID)
+				mu.Unlock()
+			},
+			Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+				mu.Lock()
+				succeeded = append(succeeded, e.RequestID)
+				mu.Unlock()
+			},
+		}
+		withMonitor := WithMonitor(func(*event.CommandMonitor) *event.CommandMonitor { return monitor })
+
+		first, firstIDs := connect(t, withMonitor)
+		defer first.Close()
+		second, secondIDs := connect(t, withMonitor)
+		defer second.Close()
+
+		for _, want := range []int32{1, 2, 3} {
+			if got := roundTrip(t, first, context.Background()); got != want {
+				t.Errorf("Incorrect responseTo. got %d; want %d", got, want)
+			}
+			if got := <-firstIDs; got != want {
+				t.Errorf("Incorrect request ID sent. got %d; want %d", got, want)
+			}
+		}
+		if got := roundTrip(t, second, context.Background()); got != 1 {
+			t.Errorf("Incorrect responseTo on a new connection. got %d; want 1", got)
+		}
+		<-secondIDs
+
+		mu.Lock()
+		defer mu.Unlock()
+		want := []int64{1, 2, 3, 1}
+		if !reflect.DeepEqual(started, want) || !reflect.DeepEqual(succeeded, want) {
+			t.Errorf("Incorrect event request IDs. got started %v and succeeded %v; want %v", started, succeeded, want)
+		}
+	})
+	t.Run("prefix", func(t *testing.T) {
+		conn, ids := connect(t, WithRequestIDPrefix(func(uint8) uint8 { return 5 }))
+		defer conn.Close()
+
+		if got, want := roundTrip(t, conn, context.Background()), int32(5<<24|1); got != want {
+			t.Errorf("Incorrect responseTo. got %d; want %d", got, want)
+		}
+		<-ids
+
+		// the count wraps around without touching the prefix
+		conn.requestID = 1<<24 - 1
+		if got, want := roundTrip(t, conn, context.Background()), int32(5<<24|1); got != want {
+			t.Errorf("Incorrect responseTo after wrapping around. got %d; want %d", got, want)
+		}
+		<-ids
+
+		if _, err := newConfig(WithRequestIDPrefix(func(uint8) uint8 { return MaxRequestIDPrefix + 1 })); err == nil {
+			t.Errorf("Expected an error for a prefix greater than %d", MaxRequestIDPrefix)
+		}
+	})
+	t.Run("span", func(t *testing.T) {
+		observability.Configure(observability.Options{
+			TraceSampler: func(string) trace.Sampler { return trace.AlwaysSample() },
+		})
+		defer observability.Configure(observability.Options{})
+		spans := &spanRecorder{}
+		trace.RegisterExporter(spans)
+		defer trace.UnregisterExporter(spans)
+
+		conn, ids := connect(t)
+		defer conn.Close()
+		ctx, op := observability.StartOperation(context.Background(), "ping", "test/ping")
+		roundTrip(t, conn, ctx)
+		roundTrip(t, conn, ctx)
+		op.End(nil)
+		<-ids
+		<-ids
+
+		spans.Lock()
+		defer spans.Unlock()
+		for _, s := range spans.spans {
+			if s.Name != "test/ping" {
+				continue
+			}
+			if s.Attributes["request_id"] != int64(2) || s.Attributes["connection_id"] != conn.id {
+				t.Errorf("Incorrect span attributes. got %v", s.Attributes)
+			}
+			var sent []interface{}
+			for _, a := range s.Annotations {
+				if a.Message == "Sent wire message" {
+					sent = append(sent, a.Attributes["request_id"])
+				}
+			}
+			if !reflect.DeepEqual(sent, []interface{}{int64(1), int64(2)}) {
+				t.Errorf("Incorrect request IDs of the sent wire messages. got %v", sent)
+			}
+			return
+		}
+		t.Fatalf("No operation span was recorded")
+	})
+}
+
+func mustMarshal(t *testing.T, doc *bson.Document) []byte {
+	t.Helper()
+	b, err := doc.MarshalBSON()
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling document: %v", err)
+	}
+	return b
+}
@@ -6,7 +6,10 @@
 
 package connection
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Error represents a connection error.
 type Error struct {
@@ -24,6 +27,9 @@ func (e Error) Error() string {
 	return fmt.Sprintf("connection(%s) %s", e.ConnectionID, e.message)
 }
 
+// Unwrap returns the underlying error.
+func (e Error) Unwrap() error { return e.Wrapped }
+
 // NetworkError represents an error that occurred while reading from or writing
 // to a network socket.
 type NetworkError struct {
@@ -35,7 +41,31 @@ func (ne NetworkError) Error() string {
 	return fmt.Sprintf("connection(%s): %s", ne.ConnectionID, ne.Wrapped.Error())
 }
 
+// Unwrap returns the underlying error.
+func (ne NetworkError) Unwrap() error { return ne.Wrapped }
+
 // PoolError is an error returned from a Pool method.
 type PoolError string
 
 func (pe PoolError) Error() string { return string(pe) }
+
+// WaitQueueTimeoutError is returned from an attempt to check out a connection of a pool that waited
+// longer than the wait queue timeout for a connection to be returned to the pool. It reports the
+// statistics of the pool at the time of the timeout.
+type WaitQueueTimeoutError struct {
+	Address string
+	Timeout time.Duration
+
+	// InUse is the number of connections checked out of the pool, and MaxPoolSize the maximum.
+	InUse       uint64
+	MaxPoolSize uint64
+
+	// WaitQueueLength is the number of checkouts that were waiting for a connection, including
+	// the one that timed out.
+	WaitQueueLength uint64
+}
+
+func (e WaitQueueTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s waiting for a connection to %s: %d of %d connections in use, %d checkouts waiting",
+		e.Timeout, e.Address, e.InUse, e.MaxPoolSize, e.WaitQueueLength)
+}
@@ -0,0 +1,70 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package connection
+
+import (
+	"context"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/core/event"
+	"github.com/mongodb/mongo-go-driver/core/logger"
+)
+
+// logMonitor returns a command monitor that logs every command at debug level before passing the
+// event on to m, which may be nil. Commands are logged as they are reported to monitors, so the
+// contents of authentication and other security sensitive commands are redacted.
+func logMonitor(l logger.Logger, m *event.CommandMonitor) *event.CommandMonitor {
+	if m == nil {
+		m = &event.CommandMonitor{}
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			if l.Enabled(logger.LevelDebug, logger.ComponentCommand) {
+				logger.Log(l, logger.LevelDebug, logger.ComponentCommand, "Command started",
+					"commandName", e.CommandName,
+					"databaseName", e.DatabaseName,
+					"requestID", e.RequestID,
+					"connectionID", e.ConnectionID,
+					"command", e.Command.ToExtJSON(false),
+				)
+			}
+
+			if m.Started != nil {
+				m.Started(ctx, e)
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			if l.Enabled(logger.LevelDebug, logger.ComponentCommand) {
+				logger.Log(l, logger.LevelDebug, logger.ComponentCommand, "Command succeeded",
+					"commandName", e.CommandName,
+					"requestID", e.RequestID,
+					"connectionID", e.ConnectionID,
+					"duration", time.Duration(e.DurationNanos),
+					"reply", e.Reply.ToExtJSON(false),
+				)
+			}
+
+			if m.Succeeded != nil {
+				m.Succeeded(ctx, e)
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			logger.Log(l, logger.LevelDebug, logger.ComponentCommand, "Command failed",
+				"commandName", e.CommandName,
+				"requestID", e.RequestID,
+				"connectionID", e.ConnectionID,
+				"duration", time.Duration(e.DurationNanos),
+				"failure", e.Failure,
+			)
+
+			if m.Failed != nil {
+				m.Failed(ctx, e)
+			}
+		},
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package connection
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/logger"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+)
+
+type logEntry struct {
+	msg string
+	kvs map[string]interface{}
+}
+
+type recordingLogger struct {
+	mu       sync.Mutex
+	disabled logger.Component
+	entries  []logEntry
+}
+
+func (rl *recordingLogger) Enabled(_ logger.Level, component logger.Component) bool {
+	return component != rl.disabled
+}
+
+func (rl *recordingLogger) Debug(msg string, kvs ...interface{}) { rl.record(msg, kvs) }
+func (rl *recordingLogger) Info(msg string, kvs ...interface{})  { rl.record(msg, kvs) }
+func (rl *recordingLogger) Warn(msg string, kvs ...interface{})  { rl.record(msg, kvs) }
+func (rl *recordingLogger) Error(msg string, kvs ...interface{}) { rl.record(msg, kvs) }
+
+func (rl *recordingLogger) record(msg string, kvs []interface{}) {
+	m := make(map[string]interface{})
+	for i := 0; i+1 < len(kvs); i += 2 {
+		m[kvs[i].(string)] = kvs[i+1]
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.entries = append(rl.entries, logEntry{msg: msg, kvs: m})
+}
+
+func (rl *recordingLogger) messages() []string {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	var msgs []string
+	for _, e := range rl.entries {
+		msgs = append(msgs, e.msg)
+	}
+	return msgs
+}
+
+func TestConnectionLogging(t *testing.T) {
+	sendCommand := func(t *testing.T, l logger.Logger, cmd *bson.Document, opts ...Option) {
+		client, server := net.Pipe()
+		go func() {
+			var size [4]byte
+			_, _ = io.ReadFull(server, size[:])
+			_, _ = io.ReadFull(server, make([]byte, readInt32(size[:], 0)-4))
+		}()
+
+		opts = append([]Option{
+			WithDialer(func(Dialer) Dialer {
+				return DialerFunc(func(context.Context, string, string) (net.Conn, error) { return client, nil })
+			}),
+			WithLogger(func(logger.Logger) logger.Logger { return l }),
+		}, opts...)
+		conn, _, err := New(context.Background(), address.Address("localhost:27017"), opts...)
+		if err != nil {
+			t.Fatalf("Unexpected error creating connection: %v", err)
+		}
+
+		wm := wiremessage.Msg{
+			MsgHeader: wiremessage.Header{RequestID: 1},
+			Sections:  []wiremessage.Section{wiremessage.SectionBody{Document: bson.Reader(mustMarshal(t, cmd))}},
+		}
+		if err = conn.WriteWireMessage(context.Background(), wm); err != nil {
+			t.Fatalf("Unexpected error writing: %v", err)
+		}
+		if err = conn.Close(); err != nil {
+			t.Fatalf("Unexpected error closing: %v", err)
+		}
+	}
+
+	t.Run("logs lifecycle and commands", func(t *testing.T) {
+		rl := &recordingLogger{}
+		sendCommand(t, rl, bson.NewDocument(bson.EC.String("find", "coll"), bson.EC.String("$db", "db")))
+
+		want := []string{"Connection created", "Command started", "Connection closed"}
+		if got := rl.messages(); !equalStrings(got, want) {
+			t.Fatalf("Incorrect messages. got %v; want %v", got, want)
+		}
+		started := rl.entries[1].kvs
+		if started["component"] != "command" || started["commandName"] != "find" || started["databaseName"] != "db" {
+			t.Errorf("Incorrect key-value pairs for command: %v", started)
+		}
+	})
+
+	t.Run("redacts sensitive commands", func(t *testing.T) {
+		rl := &recordingLogger{}
+		sendCommand(t, rl, bson.NewDocument(bson.EC.Int32("saslStart", 1), bson.EC.String("payload", "secret"), bson.EC.String("$db", "admin")))
+
+		started := rl.entries[1].kvs
+		if started["commandName"] != "saslStart" || started["command"] != "{}" {
+			t.Errorf("Expected redacted command. got %v", started)
+		}
+	})
+
+	t.Run("redacts document values", func(t *testing.T) {
+		rl := &recordingLogger{}
+		sendCommand(t, rl, bson.NewDocument(
+			bson.EC.String("find", "coll"),
+			bson.EC.SubDocumentFromElements("filter", bson.EC.String("ssn", "078-05-1120")),
+			bson.EC.String("$db", "db"),
+		), WithRedactDocumentValues(func(bool) bool { return true }))
+
+		started := rl.entries[1].kvs
+		want := `{"find":"coll","filter":{"ssn":"REDACTED"},"$db":"db"}`
+		if started["command"] != want {
+			t.Errorf("Expected redacted values. got %v; want %v", started["command"], want)
+		}
+	})
+
+	t.Run("nil logger", func(t *testing.T) {
+		sendCommand(t, nil, bson.NewDocument(bson.EC.String("find", "coll"), bson.EC.String("$db", "db")))
+	})
+
+	t.Run("skips disabled components", func(t *testing.T) {
+		rl := &recordingLogger{disabled: logger.ComponentCommand}
+		sendCommand(t, rl, bson.NewDocument(bson.EC.String("find", "coll"), bson.EC.String("$db", "db")))
+
+		want := []string{"Connection created", "Connection closed"}
+		if got := rl.messages(); !equalStrings(got, want) {
+			t.Fatalf("Incorrect messages. got %v; want %v", got, want)
+		}
+	})
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -7,25 +7,35 @@
 package connection
 
 import (
+	"fmt"
 	"net"
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/compressor"
 	"github.com/mongodb/mongo-go-driver/core/event"
+	"github.com/mongodb/mongo-go-driver/core/logger"
 )
 
 type config struct {
-	appName        string
-	connectTimeout time.Duration
-	dialer         Dialer
-	handshaker     Handshaker
-	idleTimeout    time.Duration
-	lifeTimeout    time.Duration
-	cmdMonitor     *event.CommandMonitor
-	readTimeout    time.Duration
-	writeTimeout   time.Duration
-	tlsConfig      *TLSConfig
-	compressors    []compressor.Compressor
+	appName          string
+	connectTimeout   time.Duration
+	dialer           Dialer
+	handshaker       Handshaker
+	idleTimeout      time.Duration
+	lifeTimeout      time.Duration
+	cmdMonitor       *event.CommandMonitor
+	readTimeout      time.Duration
+	writeTimeout     time.Duration
+	tlsConfig        *TLSConfig
+	compressors      []compressor.Compressor
+	logger           logger.Logger
+	pooledReplies    bool
+	redactValues     bool
+	requestIDPrefix  uint8
+	minPoolSize      uint64
+	maxConnecting    uint64
+	poolMonitor      *event.PoolMonitor
+	waitQueueTimeout time.Duration
 }
 
 func newConfig(opts ...Option) (*config, error) {
@@ -34,6 +44,8 @@ func newConfig(opts ...Option) (*config, error) {
 		dialer:         nil,
 		idleTimeout:    10 * time.Minute,
 		lifeTimeout:    30 * time.Minute,
+		logger:         logger.Nop,
+		maxConnecting:  2,
 	}
 
 	for _, opt := range opts {
@@ -112,6 +124,73 @@ func WithLifeTimeout(fn func(time.Duration) time.Duration) Option {
 	}
 }
 
+// WithLogger configures the logger used to log the connection lifecycle and, at debug level, the
+// commands sent over the connection. A nil logger disables logging.
+func WithLogger(fn func(logger.Logger) logger.Logger) Option {
+	return func(c *config) error {
+		c.logger = fn(c.logger)
+		if c.logger == nil {
+			c.logger = logger.Nop
+		}
+		return nil
+	}
+}
+
+// WithMaxConnecting configures the maximum number of connections a pool establishes concurrently
+// while filling itself up to its minimum size. The default is 2. It is only used by pools.
+func WithMaxConnecting(fn func(uint64) uint64) Option {
+	return func(c *config) error {
+		c.maxConnecting = fn(c.maxConnecting)
+		return nil
+	}
+}
+
+// WithMinPoolSize configures the number of idle connections a pool establishes as soon as it is
+// connected, so that the first operations do not have to wait for connections to be dialed and
+// handshaked. It is capped by the maximum number of idle connections of the pool and is only used
+// by pools.
+func WithMinPoolSize(fn func(uint64) uint64) Option {
+	return func(c *config) error {
+		c.minPoolSize = fn(c.minPoolSize)
+		return nil
+	}
+}
+
+// WithPooledReplies configures whether the documents of replies are read into buffers from the
+// pool of wire message buffers instead of freshly allocated ones. The owner of a reply document can
+// then return it to the pool with wiremessage.PutBuffer once it is no longer used.
+func WithPooledReplies(fn func(bool) bool) Option {
+	return func(c *config) error {
+		c.pooledReplies = fn(c.pooledReplies)
+		return nil
+	}
+}
+
+// WithRedactDocumentValues configures whether the values of the documents of the commands and
+// replies reported to the command monitor and logged are redacted. The keys are kept and every
+// value is replaced by event.RedactedValue, except for the command name, the database and the
+// status of replies. The documents of commands carrying credentials are always fully redacted.
+func WithRedactDocumentValues(fn func(bool) bool) Option {
+	return func(c *config) error {
+		c.redactValues = fn(c.redactValues)
+		return nil
+	}
+}
+
+// WithRequestIDPrefix configures the prefix of the request IDs of the wire messages sent on a
+// connection, which occupies their high bits. Processes given different prefixes send wire messages
+// with different request IDs, so the request IDs in the logs of a server tell them apart. The
+// prefix must be at most MaxRequestIDPrefix.
+func WithRequestIDPrefix(fn func(uint8) uint8) Option {
+	return func(c *config) error {
+		c.requestIDPrefix = fn(c.requestIDPrefix)
+		if c.requestIDPrefix > MaxRequestIDPrefix {
+			return fmt.Errorf("request ID prefix %d is greater than the maximum of %d", c.requestIDPrefix, MaxRequestIDPrefix)
+		}
+		return nil
+	}
+}
+
 // WithReadTimeout configures the maximum read time for a connection.
 func WithReadTimeout(fn func(time.Duration) time.Duration) Option {
 	return func(c *config) error {
@@ -128,6 +207,17 @@ func WithWriteTimeout(fn func(time.Duration) time.Duration) Option {
 	}
 }
 
+// WithMonitoringTimeouts configures the read and write timeouts of a connection to be its connect
+// timeout, overriding any socket timeout. It is used by the dedicated connections of server
+// monitors, and must come after the options that configure the connect timeout.
+func WithMonitoringTimeouts() Option {
+	return func(c *config) error {
+		c.readTimeout = c.connectTimeout
+		c.writeTimeout = c.connectTimeout
+		return nil
+	}
+}
+
 // WithTLSConfig configures the TLS options for a connection.
 func WithTLSConfig(fn func(*TLSConfig) *TLSConfig) Option {
 	return func(c *config) error {
@@ -136,6 +226,15 @@ func WithTLSConfig(fn func(*TLSConfig) *TLSConfig) Option {
 	}
 }
 
+// WithPoolMonitor configures a monitor for the events of a connection pool. It is only used by
+// pools.
+func WithPoolMonitor(fn func(*event.PoolMonitor) *event.PoolMonitor) Option {
+	return func(c *config) error {
+		c.poolMonitor = fn(c.poolMonitor)
+		return nil
+	}
+}
+
 // WithMonitor configures a event for command monitoring.
 func WithMonitor(fn func(*event.CommandMonitor) *event.CommandMonitor) Option {
 	return func(c *config) error {
@@ -143,3 +242,13 @@ func WithMonitor(fn func(*event.CommandMonitor) *event.CommandMonitor) Option {
 		return nil
 	}
 }
+
+// WithWaitQueueTimeout configures the maximum amount of time a checkout waits for a connection of a
+// full pool to be returned, after which it fails with a WaitQueueTimeoutError. The default is 0,
+// which waits until the context of the checkout is done. It is only used by pools.
+func WithWaitQueueTimeout(fn func(time.Duration) time.Duration) Option {
+	return func(c *config) error {
+		c.waitQueueTimeout = fn(c.waitQueueTimeout)
+		return nil
+	}
+}
@@ -10,13 +10,17 @@ import (
 	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
         "golang.org/x/sync/semaphore"
 
 	"github.com/mongodb/mongo-go-driver/core/address"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/event"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 
+	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 )
 
@@ -47,7 +51,9 @@ const (
 // Pool is used to pool Connections to a server.
 type Pool interface {
 	// Get must return a nil *description.Server if the returned connection is
-	// not a newly dialed connection.
+	// not a newly dialed connection. Implementations may also return a nil
+	// description for a newly dialed connection if the description from an
+	// earlier handshake is still current.
 	Get(context.Context) (Connection, *description.Server, error)
 	// Connect handles the initialization of a Pool and allow Connections to be
 	// retrieved and pooled. Implementations must return an error if Connect is
@@ -75,6 +81,26 @@ type pool struct {
 	capacity   uint64
 	inflight   map[uint64]*pooledConnection
 
+	// minSize is the number of idle connections established when the pool is connected, at most
+	// maxConnecting at a time.
+	minSize       uint64
+	maxConnecting uint64
+	// described is the generation of the last handshake description returned by Get. The
+	// immutable parts of the description of a server only change with its generation, so the
+	// descriptions of later connections of the same or older generations are not returned.
+	described uint64
+
+	// waitQueueTimeout bounds the time a checkout waits for a slot, if positive.
+	waitQueueTimeout time.Duration
+	monitor          *event.PoolMonitor
+
+	// statsCtx is tagged with the pool's address and used to record the gauges below, which are
+	// shared with the pools of other clients connected to the same address.
+	statsCtx context.Context
+	open     *observability.Gauge
+	inUse    *observability.Gauge
+	waiting  *observability.Gauge
+
 	sync.Mutex
 }
 
@@ -85,6 +111,19 @@ func NewPool(addr address.Address, size, capacity uint64, opts ...Option) (Pool,
 	if size > capacity {
 		return nil, ErrSizeLargerThanCapacity
 	}
+	cfg, err := newConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	minSize := cfg.minPoolSize
+	if minSize > size {
+		minSize = size
+	}
+	maxConnecting := cfg.maxConnecting
+	if maxConnecting == 0 {
+		maxConnecting = 1
+	}
+
 	p := &pool{
 		address:    addr,
 		conns:      make(chan *pooledConnection, size),
@@ -94,6 +133,17 @@ func NewPool(addr address.Address, size, capacity uint64, opts ...Option) (Pool,
 		capacity:   capacity,
 		inflight:   make(map[uint64]*pooledConnection),
 		opts:       opts,
+
+		minSize:       minSize,
+		maxConnecting: maxConnecting,
+
+		waitQueueTimeout: cfg.waitQueueTimeout,
+		monitor:          cfg.poolMonitor,
+
+		statsCtx: observability.Tag(context.Background(), tag.Upsert(observability.KeyServerAddress, addr.String())),
+		open:     observability.NewSharedGauge(observability.MConnectionsOpen, addr.String()),
+		inUse:    observability.NewSharedGauge(observability.MConnectionsInUse, addr.String()),
+		waiting:  observability.NewSharedGauge(observability.MConnectionsWaitQueue, addr.String()),
 	}
 	return p, nil
 }
@@ -107,10 +157,70 @@ func (p *pool) Connect(ctx context.Context) error {
 	if !atomic.CompareAndSwapInt32(&p.connected, disconnected, connected) {
 		return ErrPoolConnected
 	}
-	atomic.AddUint64(&p.generation, 1)
+	g := atomic.AddUint64(&p.generation, 1)
+	if p.minSize > 0 {
+		go p.fill(g)
+	}
 	return nil
 }
 
+// fill establishes idle connections of generation g until the pool holds minSize of them, dialing
+// at most maxConnecting connections concurrently.
+func (p *pool) fill(g uint64) {
+	remaining := int64(p.minSize)
+	workers := p.maxConnecting
+	if workers > p.minSize {
+		workers = p.minSize
+	}
+
+	var wg sync.WaitGroup
+	for i := uint64(0); i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for atomic.AddInt64(&remaining, -1) >= 0 {
+				if !p.fillOne(g) {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// fillOne establishes an idle connection of generation g. It returns false if the connection was
+// not established because the pool was disconnected or cleared, every slot of the pool is taken,
+// or dialing failed.
+func (p *pool) fillOne(g uint64) bool {
+	if atomic.LoadInt32(&p.connected) != connected || p.isExpired(g) {
+		return false
+	}
+	// Holding a slot while dialing counts the connection towards the capacity of the pool and makes
+	// Disconnect wait for it. The pool is busy if no slot is free, so there is no need to fill it.
+	if !p.sem.TryAcquire(1) {
+		return false
+	}
+	defer p.sem.Release(1)
+
+	c, _, err := New(p.statsCtx, p.address, p.opts...)
+	if err != nil {
+		return false
+	}
+
+	pc := &pooledConnection{
+		Connection: c,
+		p:          p,
+		generation: g,
+		id:         atomic.AddUint64(&p.nextid, 1),
+	}
+	p.Lock()
+	p.inflight[pc.id] = pc
+	p.open.Add(p.statsCtx, 1)
+	p.Unlock()
+
+	return p.returnConnection(pc) == nil
+}
+
 func (p *pool) Disconnect(ctx context.Context) error {
 	if !atomic.CompareAndSwapInt32(&p.connected, connected, disconnecting) {
 		return ErrPoolDisconnected
@@ -154,19 +264,88 @@ loop:
 }
 
 func (p *pool) Get(ctx context.Context) (Connection, *description.Server, error) {
-	ctx, span := trace.StartSpan(ctx, "mongo-go-driver/core/connnection/(*pool).Get")
+	ctx = observability.Tag(ctx, tag.Upsert(observability.KeyServerAddress, p.address.String()))
+	ctx, span := observability.StartSpan(ctx, "mongo-go-driver/core/connnection/(*pool).Get")
 	defer span.End()
 
 	if atomic.LoadInt32(&p.connected) != connected {
+		p.checkOutFailed(ErrPoolClosed)
 		return nil, nil, ErrPoolClosed
 	}
 
-	err := p.sem.Acquire(ctx, 1)
-	if err != nil {
+	if err := p.wait(ctx); err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+		p.checkOutFailed(err)
 		return nil, nil, err
 	}
 
-	return p.get(ctx)
+	c, desc, err := p.get(ctx)
+	if err != nil {
+		p.checkOutFailed(err)
+	}
+	return c, desc, err
+}
+
+// checkOutFailed counts a checkout that failed with err and reports it to the pool monitor.
+func (p *pool) checkOutFailed(err error) {
+	reason := event.ReasonConnectionError
+	switch err.(type) {
+	case PoolError:
+		reason = event.ReasonPoolClosed
+	case WaitQueueTimeoutError:
+		reason = event.ReasonTimeout
+	}
+	if err == context.Canceled || err == context.DeadlineExceeded {
+		reason = event.ReasonTimeout
+	}
+
+	observability.Record(observability.Tag(p.statsCtx, tag.Upsert(observability.KeyPart, reason)),
+		observability.MConnectionCheckOutFailures.M(1))
+	if p.monitor != nil && p.monitor.Event != nil {
+		p.monitor.Event(&event.PoolEvent{
+			Type:    event.ConnectionCheckOutFailed,
+			Address: p.address.String(),
+			Reason:  reason,
+		})
+	}
+}
+
+// wait acquires a slot in the pool, blocking until one is available if the pool is at capacity.
+func (p *pool) wait(ctx context.Context) error {
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/connection.(*pool).wait")
+	defer span.End()
+
+	if p.sem.TryAcquire(1) {
+		span.AddAttributes(trace.BoolAttribute("waited", false))
+		return nil
+	}
+	span.AddAttributes(trace.BoolAttribute("waited", true))
+
+	waitCtx := ctx
+	if p.waitQueueTimeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, p.waitQueueTimeout)
+		defer cancel()
+	}
+
+	p.waiting.Add(p.statsCtx, 1)
+	err := p.sem.Acquire(waitCtx, 1)
+	waiting := p.waiting.Value()
+	p.waiting.Add(p.statsCtx, -1)
+	if err != nil && ctx.Err() == nil {
+		// only the wait queue timeout expired
+		err = WaitQueueTimeoutError{
+			Address:         p.address.String(),
+			Timeout:         p.waitQueueTimeout,
+			InUse:           uint64(p.inUse.Value()),
+			MaxPoolSize:     p.capacity,
+			WaitQueueLength: uint64(waiting),
+		}
+	}
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+	}
+	return err
 }
 
 func (p *pool) get(ctx context.Context) (Connection, *description.Server, error) {
@@ -178,7 +357,7 @@ func (p *pool) get(ctx context.Context) (Connection, *description.Server, error)
 			return p.get(ctx)
 		}
 
-		return &acquired{Connection: c, sem: p.sem}, nil, nil
+		return p.acquire(c), nil, nil
 	case <-ctx.Done():
 		p.sem.Release(1)
 		return nil, nil, ctx.Err()
@@ -204,16 +383,38 @@ func (p *pool) get(ctx context.Context) (Connection, *description.Server, error)
 		}
 		defer p.Unlock()
 		p.inflight[pc.id] = pc
-		return &acquired{Connection: pc, sem: p.sem}, desc, nil
+		p.open.Add(p.statsCtx, 1)
+		if desc != nil {
+			if g <= p.described {
+				desc = nil
+			} else {
+				p.described = g
+			}
+		}
+		return p.acquire(pc), desc, nil
 	}
 }
 
+func (p *pool) acquire(pc *pooledConnection) *acquired {
+	p.inUse.Add(p.statsCtx, 1)
+	return &acquired{Connection: pc, p: p}
+}
+
+// release returns a slot to the pool once an acquired connection is closed.
+func (p *pool) release() {
+	p.inUse.Add(p.statsCtx, -1)
+	p.sem.Release(1)
+}
+
 func (p *pool) closeConnection(pc *pooledConnection) error {
 	if !atomic.CompareAndSwapInt32(&pc.closed, 0, 1) {
 		return nil
 	}
 	p.Lock()
-	delete(p.inflight, pc.id)
+	if _, ok := p.inflight[pc.id]; ok {
+		delete(p.inflight, pc.id)
+		p.open.Add(p.statsCtx, -1)
+	}
 	p.Unlock()
 	return pc.Connection.Close()
 }
@@ -254,7 +455,7 @@ func (pc *pooledConnection) Expired() bool {
 type acquired struct {
 	Connection
 
-	sem *semaphore.Weighted
+	p *pool
 	sync.Mutex
 }
 
@@ -283,7 +484,7 @@ func (a *acquired) Close() error {
 		return nil
 	}
 	err := a.Connection.Close()
-	a.sem.Release(1)
+	a.p.release()
 	a.Connection = nil
 	return err
 }
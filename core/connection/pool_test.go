@@ -17,6 +17,11 @@ import (
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/event"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"go.opencensus.io/trace"
 )
 
 func TestPool(t *testing.T) {
@@ -262,8 +267,102 @@ func TestPool(t *testing.T) {
 				t.Errorf("Should be able to connect to pool after disconnect. got %v; want <nil>", err)
 			}
 		})
+		t.Run("fills the pool up to its minimum size", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 3, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			var handshaking, maxHandshaking int32
+			hs := HandshakerFunc(func(context.Context, address.Address, wiremessage.ReadWriter) (description.Server, error) {
+				n := atomic.AddInt32(&handshaking, 1)
+				for {
+					max := atomic.LoadInt32(&maxHandshaking)
+					if n <= max || atomic.CompareAndSwapInt32(&maxHandshaking, max, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&handshaking, -1)
+				return description.Server{}, nil
+			})
+			d := newdialer(&net.Dialer{})
+			P, err := NewPool(address.Address(addr.String()), 3, 3,
+				WithDialer(func(Dialer) Dialer { return d }),
+				WithHandshaker(func(Handshaker) Handshaker { return hs }),
+				WithMinPoolSize(func(uint64) uint64 { return 5 }),
+				WithMaxConnecting(func(uint64) uint64 { return 2 }),
+			)
+			noerr(t, err)
+			p := P.(*pool)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			deadline := time.Now().Add(3 * time.Second)
+			for len(p.conns) < 3 && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			if len(p.conns) != 3 {
+				t.Errorf("Should have filled the pool. got %d; want %d", len(p.conns), 3)
+			}
+			if max := atomic.LoadInt32(&maxHandshaking); max > 2 {
+				t.Errorf("Should not establish more than maxConnecting connections at a time. got %d; want <= %d", max, 2)
+			}
+			c, desc, err := p.Get(context.Background())
+			noerr(t, err)
+			if desc != nil {
+				t.Errorf("Should not return a description for an idle connection. got %v; want <nil>", desc)
+			}
+			if d.lenopened() != 3 {
+				t.Errorf("Should not dial connections when the pool is filled. got %d; want %d", d.lenopened(), 3)
+			}
+			noerr(t, c.Close())
+			err = p.Disconnect(context.Background())
+			noerr(t, err)
+			if d.lenclosed() != 3 {
+				t.Errorf("Should have closed 3 connections. got %d; want %d", d.lenclosed(), 3)
+			}
+		})
 	})
 	t.Run("Get", func(t *testing.T) {
+		t.Run("returns the handshake description once per generation", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 3, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			hs := HandshakerFunc(func(_ context.Context, addr address.Address, _ wiremessage.ReadWriter) (description.Server, error) {
+				return description.Server{Addr: addr}, nil
+			})
+			d := newdialer(&net.Dialer{})
+			p, err := NewPool(address.Address(addr.String()), 3, 3,
+				WithDialer(func(Dialer) Dialer { return d }),
+				WithHandshaker(func(Handshaker) Handshaker { return hs }),
+			)
+			noerr(t, err)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			c1, desc, err := p.Get(context.Background())
+			noerr(t, err)
+			if desc == nil {
+				t.Errorf("Should return the description of the first connection. got <nil>")
+			}
+			c2, desc, err := p.Get(context.Background())
+			noerr(t, err)
+			if desc != nil {
+				t.Errorf("Should not return the description again for the same generation. got %v; want <nil>", desc)
+			}
+			noerr(t, p.Drain())
+			c3, desc, err := p.Get(context.Background())
+			noerr(t, err)
+			if desc == nil {
+				t.Errorf("Should return the description of the first connection of a new generation. got <nil>")
+			}
+			for _, c := range []Connection{c1, c2, c3} {
+				noerr(t, c.Close())
+			}
+		})
 		t.Run("return context error when already cancelled", func(t *testing.T) {
 			cleanup := make(chan struct{})
 			addr := bootstrapConnections(t, 3, func(nc net.Conn) {
@@ -341,6 +440,37 @@ func TestPool(t *testing.T) {
 			noerr(t, err)
 			close(cleanup)
 		})
+		t.Run("tracks open and in use connections", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			addr := bootstrapConnections(t, 2, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			p, err := NewPool(address.Address(addr.String()), 1, 2, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			pl := p.(*pool)
+			c1, _, err := p.Get(context.Background())
+			noerr(t, err)
+			c2, _, err := p.Get(context.Background())
+			noerr(t, err)
+			if open, inUse := pl.open.Value(), pl.inUse.Value(); open != 2 || inUse != 2 {
+				t.Errorf("Incorrect gauges. got open=%d inUse=%d; want open=2 inUse=2", open, inUse)
+			}
+			noerr(t, c1.Close())
+			noerr(t, c2.Close())
+			// the pool only keeps one idle connection, so the second is closed on return
+			if open, inUse := pl.open.Value(), pl.inUse.Value(); open != 1 || inUse != 0 {
+				t.Errorf("Incorrect gauges. got open=%d inUse=%d; want open=1 inUse=0", open, inUse)
+			}
+			noerr(t, p.Disconnect(context.Background()))
+			if open := pl.open.Value(); open != 0 {
+				t.Errorf("Incorrect open gauge after disconnect. got %d; want %d", open, 0)
+			}
+			close(cleanup)
+		})
 		t.Run("closes expired connections", func(t *testing.T) {
 			cleanup := make(chan struct{})
 			addr := bootstrapConnections(t, 2, func(nc net.Conn) {
@@ -537,6 +667,58 @@ func TestPool(t *testing.T) {
 			wg.Wait()
 			close(cleanup)
 		})
+		t.Run("Wait queue timeout", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			var events []*event.PoolEvent
+			p, err := NewPool(address.Address(addr.String()), 1, 1,
+				WithDialer(func(Dialer) Dialer { return d }),
+				WithWaitQueueTimeout(func(time.Duration) time.Duration { return 10 * time.Millisecond }),
+				WithPoolMonitor(func(*event.PoolMonitor) *event.PoolMonitor {
+					return &event.PoolMonitor{Event: func(e *event.PoolEvent) { events = append(events, e) }}
+				}),
+			)
+			noerr(t, err)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			conn, _, err := p.Get(context.Background())
+			noerr(t, err)
+
+			_, _, err = p.Get(context.Background())
+			wqerr, ok := err.(WaitQueueTimeoutError)
+			if !ok {
+				t.Fatalf("Expected a WaitQueueTimeoutError but got: %v", err)
+			}
+			want := WaitQueueTimeoutError{
+				Address:         addr.String(),
+				Timeout:         10 * time.Millisecond,
+				InUse:           1,
+				MaxPoolSize:     1,
+				WaitQueueLength: 1,
+			}
+			if wqerr != want {
+				t.Errorf("Unexpected error. got %+v; want %+v", wqerr, want)
+			}
+			if len(events) != 1 || events[0].Type != event.ConnectionCheckOutFailed || events[0].Reason != event.ReasonTimeout {
+				t.Errorf("Expected a ConnectionCheckOutFailed event with reason timeout, got %+v", events)
+			}
+
+			// the deadline of the checkout is not a wait queue timeout
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, _, err = p.Get(ctx)
+			if err != context.Canceled {
+				t.Errorf("Expected context.Canceled but got: %v", err)
+			}
+
+			err = conn.Close()
+			noerr(t, err)
+			close(cleanup)
+		})
 		t.Run("Does not leak permit from failure to dial connection", func(t *testing.T) {
 			cleanup := make(chan struct{})
 			addr := bootstrapConnections(t, 0, func(nc net.Conn) {
@@ -593,6 +775,47 @@ func TestPool(t *testing.T) {
 				p.(*pool).sem.Release(int64(p.(*pool).capacity))
 			}
 		})
+		t.Run("records whether checkout waited for the pool", func(t *testing.T) {
+			observability.Configure(observability.Options{
+				TraceSampler: func(string) trace.Sampler { return trace.AlwaysSample() },
+			})
+			defer observability.Configure(observability.Options{})
+			spans := &spanRecorder{}
+			trace.RegisterExporter(spans)
+			defer trace.UnregisterExporter(spans)
+
+			cleanup := make(chan struct{})
+			addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			p, err := NewPool(address.Address(addr.String()), 1, 1, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			c, _, err := p.Get(context.Background())
+			noerr(t, err)
+			done := make(chan error)
+			go func() {
+				c, _, err := p.Get(context.Background())
+				if err == nil {
+					err = c.Close()
+				}
+				done <- err
+			}()
+			for p.(*pool).waiting.Value() == 0 {
+				runtime.Gosched()
+			}
+			noerr(t, c.Close())
+			noerr(t, <-done)
+			close(cleanup)
+
+			waited := spans.attributes("mongo-go/core/connection.(*pool).wait", "waited")
+			if len(waited) != 2 || waited[0] != false || waited[1] != true {
+				t.Errorf("Incorrect waited attributes. got %v; want %v", waited, []interface{}{false, true})
+			}
+		})
 		t.Run("Get does not acquire multiple permits", func(t *testing.T) {
 			cleanup := make(chan struct{})
 			addr := bootstrapConnections(t, 2, func(nc net.Conn) {
@@ -677,3 +900,29 @@ func TestPool(t *testing.T) {
 		})
 	})
 }
+
+// spanRecorder is a trace.Exporter that keeps the spans it is given.
+type spanRecorder struct {
+	sync.Mutex
+	spans []*trace.SpanData
+}
+
+func (r *spanRecorder) ExportSpan(s *trace.SpanData) {
+	r.Lock()
+	defer r.Unlock()
+	r.spans = append(r.spans, s)
+}
+
+// attributes returns the values of the given attribute on the spans with the given name, in the
+// order they ended.
+func (r *spanRecorder) attributes(name, key string) []interface{} {
+	r.Lock()
+	defer r.Unlock()
+	var values []interface{}
+	for _, s := range r.spans {
+		if s.Name == name {
+			values = append(values, s.Attributes[key])
+		}
+	}
+	return values
+}
@@ -54,10 +54,14 @@ type ConnString struct {
 	MaxConnIdleTime                    time.Duration
 	MaxConnIdleTimeSet                 bool
 	MaxConnLifeTime                    time.Duration
+	MaxConnecting                      uint16
+	MaxConnectingSet                   bool
 	MaxConnsPerHost                    uint16
 	MaxConnsPerHostSet                 bool
 	MaxIdleConnsPerHost                uint16
 	MaxIdleConnsPerHostSet             bool
+	MinPoolSize                        uint16
+	MinPoolSizeSet                     bool
 	Password                           string
 	PasswordSet                        bool
 	ReadConcernLevel                   string
@@ -82,6 +86,10 @@ type ConnString struct {
 	SSLInsecureSet                     bool
 	SSLCaFile                          string
 	SSLCaFileSet                       bool
+	Timeout                            time.Duration
+	TimeoutSet                         bool
+	WaitQueueTimeout                   time.Duration
+	WaitQueueTimeoutSet                bool
 	WString                            string
 	WNumber                            int
 	WNumberSet                         bool
@@ -94,12 +102,84 @@ type ConnString struct {
 
 	Options        map[string][]string
 	UnknownOptions map[string][]string
+
+	// Warnings describes the parts of the connection string that were ignored, such as unrecognized
+	// options, deprecated options and options specified more than once.
+	Warnings []string
+}
+
+// Error is returned by Parse when a connection string is invalid.
+type Error struct {
+	// Offset is the byte offset of the invalid part of the connection string, or -1 if the invalid
+	// part does not come from the connection string itself, such as an option of a TXT record.
+	Offset int
+
+	// Option is the name of the option whose value is invalid, or empty if the error is not about the
+	// value of an option.
+	Option string
+
+	// Value is the invalid value of Option.
+	Value string
+
+	// Err describes the error. It can be nil if Option is set.
+	Err error
+}
+
+func (e *Error) Error() string {
+	var msg string
+	switch {
+	case e.Option != "" && e.Err != nil:
+		msg = fmt.Sprintf("invalid value for %s \"%s\": %s", e.Option, e.Value, e.Err)
+	case e.Option != "":
+		msg = fmt.Sprintf("invalid value for %s: \"%s\"", e.Option, e.Value)
+	default:
+		msg = e.Err.Error()
+	}
+
+	if e.Offset >= 0 {
+		msg += fmt.Sprintf(" at offset %d", e.Offset)
+	}
+	return msg
+}
+
+// Unwrap returns the error describing the Error.
+func (e *Error) Unwrap() error { return e.Err }
+
+// errorAt returns an Error at the given offset of the connection string.
+func errorAt(offset int, format string, args ...interface{}) error {
+	return &Error{Offset: offset, Err: fmt.Errorf(format, args...)}
 }
 
 func (u *ConnString) String() string {
 	return u.Original
 }
 
+// WriteConcern returns the write concern specified by the w, journal and wtimeoutMS options, or nil
+// if none of them was specified.
+func (u *ConnString) WriteConcern() *writeconcern.WriteConcern {
+	var opts []writeconcern.Option
+
+	if len(u.WString) > 0 {
+		opts = append(opts, writeconcern.WTagSet(u.WString))
+	} else if u.WNumberSet {
+		opts = append(opts, writeconcern.W(u.WNumber))
+	}
+
+	if u.JSet {
+		opts = append(opts, writeconcern.J(u.J))
+	}
+
+	if u.WTimeoutSet {
+		opts = append(opts, writeconcern.WTimeout(u.WTimeout))
+	}
+
+	if len(opts) == 0 {
+		return nil
+	}
+
+	return writeconcern.New(opts...)
+}
+
 // ConnectMode informs the driver on how to connect
 // to the server.
 type ConnectMode uint8
@@ -114,10 +194,25 @@ type parser struct {
 	ConnString
 }
 
+// option is a key=value pair of the options of a connection string, along with its offset in the
+// connection string, or -1 if it comes from a TXT record.
+type option struct {
+	pair   string
+	offset int
+}
+
+// warnf adds a warning to the connection string.
+func (p *parser) warnf(format string, args ...interface{}) {
+	p.Warnings = append(p.Warnings, fmt.Sprintf(format, args...))
+}
+
 func (p *parser) parse(original string) error {
 	p.Original = original
 	uri := original
 
+	// offset returns the offset in the connection string of rest, a suffix of it
+	offset := func(rest string) int { return len(original) - len(rest) }
+
 	var err error
 	var isSRV bool
 	if strings.HasPrefix(uri, "mongodb+srv://") {
@@ -128,69 +223,84 @@ func (p *parser) parse(original string) error {
 		// remove the scheme
 		uri = uri[10:]
 	} else {
-		return fmt.Errorf("scheme must be \"mongodb\" or \"mongodb+srv\"")
+		return errorAt(0, "scheme must be \"mongodb\" or \"mongodb+srv\"")
 	}
 
-	if idx := strings.Index(uri, "@"); idx != -1 {
+	// an @ sign in the options does not end the user info
+	beforeQuery := uri
+	if idx := strings.IndexByte(uri, '?'); idx != -1 {
+		beforeQuery = uri[:idx]
+	}
+	if idx := strings.Index(beforeQuery, "@"); idx != -1 {
+		userInfoOffset := offset(uri)
 		userInfo := uri[:idx]
 		uri = uri[idx+1:]
 
 		username := userInfo
 		var password string
+		passwordOffset := userInfoOffset
 
 		if idx := strings.Index(userInfo, ":"); idx != -1 {
 			username = userInfo[:idx]
 			password = userInfo[idx+1:]
+			passwordOffset += idx + 1
 			p.PasswordSet = true
 		}
 
-		if len(username) > 1 {
-			if strings.Contains(username, "/") {
-				return fmt.Errorf("unescaped slash in username")
-			}
+		if idx := strings.Index(username, "/"); idx != -1 {
+			return errorAt(userInfoOffset+idx, "unescaped slash in username")
 		}
 
-		p.Username, err = url.QueryUnescape(username)
+		// user info is percent-encoded, so a plus sign is not a space
+		p.Username, err = url.PathUnescape(username)
 		if err != nil {
-			return internal.WrapErrorf(err, "invalid username")
+			return &Error{Offset: userInfoOffset, Err: internal.WrapErrorf(err, "invalid username")}
 		}
-		if len(password) > 1 {
-			if strings.Contains(password, ":") {
-				return fmt.Errorf("unescaped colon in password")
-			}
-			if strings.Contains(password, "/") {
-				return fmt.Errorf("unescaped slash in password")
-			}
-			p.Password, err = url.QueryUnescape(password)
-			if err != nil {
-				return internal.WrapErrorf(err, "invalid password")
+		if idx := strings.IndexAny(password, ":/"); idx != -1 {
+			if password[idx] == ':' {
+				return errorAt(passwordOffset+idx, "unescaped colon in password")
 			}
+			return errorAt(passwordOffset+idx, "unescaped slash in password")
+		}
+		p.Password, err = url.PathUnescape(password)
+		if err != nil {
+			return &Error{Offset: passwordOffset, Err: internal.WrapErrorf(err, "invalid password")}
 		}
 	}
 
 	// fetch the hosts field
 	hosts := uri
+	hostsOffset := offset(uri)
 	if idx := strings.IndexAny(uri, "/?@"); idx != -1 {
 		if uri[idx] == '@' {
-			return fmt.Errorf("unescaped @ sign in user info")
+			return errorAt(hostsOffset+idx, "unescaped @ sign in user info")
 		}
 		if uri[idx] == '?' {
-			return fmt.Errorf("must have a / before the query ?")
+			return errorAt(hostsOffset+idx, "must have a / before the query ?")
 		}
 		hosts = uri[:idx]
 	}
 
-	var connectionArgsFromTXT []string
+	var connectionArgsFromTXT []option
 	parsedHosts := strings.Split(hosts, ",")
+	hostOffsets := make([]int, len(parsedHosts))
+	for i, next := 0, hostsOffset; i < len(parsedHosts); i++ {
+		hostOffsets[i] = next
+		next += len(parsedHosts[i]) + 1
+	}
 
 	if isSRV {
-		parsedHosts = strings.Split(hosts, ",")
 		if len(parsedHosts) != 1 {
-			return fmt.Errorf("URI with SRV must include one and only one hostname")
+			return errorAt(hostOffsets[1]-1, "URI with SRV must include one and only one hostname")
 		}
 		parsedHosts, err = fetchSeedlistFromSRV(parsedHosts[0])
 		if err != nil {
-			return err
+			return &Error{Offset: hostsOffset, Err: err}
+		}
+		// the hosts of the seedlist are not part of the connection string
+		hostOffsets = make([]int, len(parsedHosts))
+		for i := range hostOffsets {
+			hostOffsets[i] = -1
 		}
 
 		// error ignored because finding a TXT record should not be
@@ -208,13 +318,16 @@ func (p *parser) parse(original string) error {
 			return errors.New("multiple records from TXT not supported")
 		}
 		if len(recordsFromTXT) > 0 {
-			connectionArgsFromTXT = strings.FieldsFunc(recordsFromTXT[0], func(r rune) bool { return r == ';' || r == '&' })
+			pairs := strings.FieldsFunc(recordsFromTXT[0], func(r rune) bool { return r == ';' || r == '&' })
 
-			err := validateTXTResult(connectionArgsFromTXT)
+			err := validateTXTResult(pairs)
 			if err != nil {
 				return err
 			}
 
+			for _, pair := range pairs {
+				connectionArgsFromTXT = append(connectionArgsFromTXT, option{pair: pair, offset: -1})
+			}
 		}
 
 		// SSL is enabled by default for SRV, but can be manually disabled with "ssl=false".
@@ -222,31 +335,34 @@ func (p *parser) parse(original string) error {
 		p.SSLSet = true
 	}
 
-	for _, host := range parsedHosts {
-		err = p.addHost(host)
+	for i, host := range parsedHosts {
+		err = p.addHost(host, hostOffsets[i])
 		if err != nil {
-			return internal.WrapErrorf(err, "invalid host \"%s\"", host)
+			return err
 		}
 	}
 	if len(p.Hosts) == 0 {
-		return fmt.Errorf("must have at least 1 host")
+		return errorAt(hostsOffset, "must have at least 1 host")
 	}
 
 	uri = uri[len(hosts):]
 
 	extractedDatabase, err := extractDatabaseFromURI(uri)
 	if err != nil {
-		return err
+		return &Error{Offset: offset(uri), Err: err}
 	}
 
 	uri = extractedDatabase.uri
 	p.Database = extractedDatabase.db
 
-	connectionArgsFromQueryString, err := extractQueryArgsFromURI(uri)
+	connectionArgsFromQueryString, err := extractQueryArgsFromURI(uri, offset(uri))
+	if err != nil {
+		return err
+	}
 	connectionArgPairs := append(connectionArgsFromTXT, connectionArgsFromQueryString...)
 
-	for _, pair := range connectionArgPairs {
-		err = p.addOption(pair)
+	for _, opt := range connectionArgPairs {
+		err = p.addOption(opt)
 		if err != nil {
 			return err
 		}
@@ -262,16 +378,17 @@ func (p *parser) parse(original string) error {
 		return err
 	}
 
-	// Check for invalid write concern (i.e. w=0 and j=true)
-	if p.WNumberSet && p.WNumber == 0 && p.JSet && p.J {
-		return writeconcern.ErrInconsistent
-	}
-
 	// If WTimeout was set from manual options passed in, set WTImeoutSet to true.
 	if p.WTimeoutSetFromOption {
 		p.WTimeoutSet = true
 	}
 
+	// Check for invalid write concern (i.e. w=0 and j=true)
+	err = p.WriteConcern().Validate()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -310,15 +427,15 @@ func (p *parser) setDefaultAuthParams(dbName string) error {
 				p.AuthSource = "admin"
 			}
 		}
-	case "":
+	default:
+		// the mechanisms applications register with the auth package default like the password
+		// based ones, and fail when the client is created if no mechanism has the name
 		if p.AuthSource == "" {
 			p.AuthSource = dbName
 			if p.AuthSource == "" {
 				p.AuthSource = "admin"
 			}
 		}
-	default:
-		return fmt.Errorf("invalid auth mechanism")
 	}
 	return nil
 }
@@ -381,9 +498,6 @@ func (p *parser) validateAuth() error {
 		if p.AuthMechanismProperties != nil {
 			return fmt.Errorf("SCRAM-SHA-256 cannot have mechanism properties")
 		}
-	case "":
-	default:
-		return fmt.Errorf("invalid auth mechanism")
 	}
 	return nil
 }
@@ -416,13 +530,36 @@ func fetchSeedlistFromSRV(host string) ([]string, error) {
 	return parsedHosts, nil
 }
 
-func (p *parser) addHost(host string) error {
+// addHost adds a host found at the given offset of the connection string. Hosts are host names,
+// IPv4 addresses, IPv6 literals in brackets or paths of unix domain sockets, optionally followed by
+// a port.
+func (p *parser) addHost(host string, offset int) error {
 	if host == "" {
 		return nil
 	}
 	host, err := url.QueryUnescape(host)
 	if err != nil {
-		return internal.WrapErrorf(err, "invalid host \"%s\"", host)
+		return &Error{Offset: offset, Err: internal.WrapErrorf(err, "invalid host \"%s\"", host)}
+	}
+
+	if strings.HasPrefix(host, "[") {
+		end := strings.IndexByte(host, ']')
+		if end == -1 {
+			return errorAt(offset, "invalid host \"%s\": missing ']' in IPv6 literal", host)
+		}
+		ip := host[1:end]
+		if idx := strings.IndexByte(ip, '%'); idx != -1 {
+			// remove the zone
+			ip = ip[:idx]
+		}
+		if net.ParseIP(ip) == nil || !strings.Contains(ip, ":") {
+			return errorAt(offset, "invalid host \"%s\": invalid IPv6 literal", host)
+		}
+		if rest := host[end+1:]; rest != "" && rest[0] != ':' {
+			return errorAt(offset, "invalid host \"%s\": unexpected characters after IPv6 literal", host)
+		}
+	} else if strings.Count(host, ":") > 1 && !strings.HasSuffix(host, ".sock") {
+		return errorAt(offset, "invalid host \"%s\": IPv6 literals must be enclosed in brackets", host)
 	}
 
 	_, port, err := net.SplitHostPort(host)
@@ -430,40 +567,75 @@ func (p *parser) addHost(host string) error {
 	// a port to exist.
 	if err != nil {
 		if addrError, ok := err.(*net.AddrError); !ok || addrError.Err != "missing port in address" {
-			return err
+			return &Error{Offset: offset, Err: internal.WrapErrorf(err, "invalid host \"%s\"", host)}
 		}
 	}
 
 	if port != "" {
 		d, err := strconv.Atoi(port)
 		if err != nil {
-			return internal.WrapErrorf(err, "port must be an integer")
+			return errorAt(offset, "invalid host \"%s\": port must be an integer", host)
 		}
 		if d <= 0 || d >= 65536 {
-			return fmt.Errorf("port must be in the range [1, 65535]")
+			return errorAt(offset, "invalid host \"%s\": port must be in the range [1, 65535]", host)
 		}
 	}
 	p.Hosts = append(p.Hosts, host)
 	return nil
 }
 
-func (p *parser) addOption(pair string) error {
-	kv := strings.SplitN(pair, "=", 2)
+// booleanOptions are the boolean options, which cannot be specified more than once with different
+// values.
+var booleanOptions = map[string]struct{}{
+	"journal":     {},
+	"retrywrites": {},
+	"ssl":         {},
+	"sslinsecure": {},
+}
+
+// multiValueOptions are the options that can be specified more than once, each value adding to the
+// previous ones.
+var multiValueOptions = map[string]struct{}{
+	"readpreferencetags": {},
+}
+
+func (p *parser) addOption(opt option) error {
+	kv := strings.SplitN(opt.pair, "=", 2)
 	if len(kv) != 2 || kv[0] == "" {
-		return fmt.Errorf("invalid option")
+		return errorAt(opt.offset, "invalid option \"%s\"", opt.pair)
 	}
 
 	key, err := url.QueryUnescape(kv[0])
 	if err != nil {
-		return internal.WrapErrorf(err, "invalid option key \"%s\"", kv[0])
+		return &Error{Offset: opt.offset, Err: internal.WrapErrorf(err, "invalid option key \"%s\"", kv[0])}
 	}
 
+	valueOffset := opt.offset
+	if valueOffset >= 0 {
+		valueOffset += len(kv[0]) + 1
+	}
 	value, err := url.QueryUnescape(kv[1])
 	if err != nil {
-		return internal.WrapErrorf(err, "invalid option value \"%s\"", kv[1])
+		return &Error{Offset: valueOffset, Option: key, Value: kv[1], Err: err}
+	}
+
+	// invalid returns the error for an invalid value of the option
+	invalid := func() error {
+		return &Error{Offset: valueOffset, Option: key, Value: value}
 	}
 
 	lowerKey := strings.ToLower(key)
+	if previous, ok := p.Options[lowerKey]; ok {
+		if _, ok := multiValueOptions[lowerKey]; !ok {
+			last := previous[len(previous)-1]
+			if _, ok := booleanOptions[lowerKey]; ok && !strings.EqualFold(last, value) {
+				return &Error{Offset: valueOffset, Option: key, Value: value,
+					Err: fmt.Errorf("conflicts with the earlier value \"%s\"", last)}
+			}
+			p.warnf("option %s is specified more than once, the last value is used", key)
+		}
+	}
+
 	switch lowerKey {
 	case "appname":
 		p.AppName = value
@@ -475,7 +647,8 @@ func (p *parser) addOption(pair string) error {
 		for _, pair := range pairs {
 			kv := strings.SplitN(pair, ":", 2)
 			if len(kv) != 2 || kv[0] == "" {
-				return fmt.Errorf("invalid authMechanism property")
+				return &Error{Offset: valueOffset, Option: key, Value: value,
+					Err: fmt.Errorf("invalid property \"%s\"", pair)}
 			}
 			p.AuthMechanismProperties[kv[0]] = kv[1]
 		}
@@ -483,8 +656,8 @@ func (p *parser) addOption(pair string) error {
 		p.AuthSource = value
 	case "compressors":
 		compressors := strings.Split(value, ",")
-		if len(compressors) < 1 {
-			return fmt.Errorf("must have at least 1 compressor")
+		if value == "" {
+			return invalid()
 		}
 		p.Compressors = compressors
 	case "connect":
@@ -493,21 +666,21 @@ func (p *parser) addOption(pair string) error {
 		case "direct", "single":
 			p.Connect = SingleConnect
 		default:
-			return fmt.Errorf("invalid 'connect' value: %s", value)
+			return invalid()
 		}
 
 		p.ConnectSet = true
 	case "connecttimeoutms":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 		p.ConnectTimeout = time.Duration(n) * time.Millisecond
 		p.ConnectTimeoutSet = true
 	case "heartbeatintervalms", "heartbeatfrequencyms":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 		p.HeartbeatInterval = time.Duration(n) * time.Millisecond
 		p.HeartbeatIntervalSet = true
@@ -518,64 +691,83 @@ func (p *parser) addOption(pair string) error {
 		case "false":
 			p.J = false
 		default:
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 
 		p.JSet = true
 	case "localthresholdms":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 		p.LocalThreshold = time.Duration(n) * time.Millisecond
 		p.LocalThresholdSet = true
+	case "maxconnecting":
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return invalid()
+		}
+		p.MaxConnecting = uint16(n)
+		p.MaxConnectingSet = true
 	case "maxconnsperhost":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 		p.MaxConnsPerHost = uint16(n)
 		p.MaxConnsPerHostSet = true
 	case "maxidleconnsperhost":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 		p.MaxIdleConnsPerHost = uint16(n)
 		p.MaxIdleConnsPerHostSet = true
 	case "maxidletimems":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 		p.MaxConnIdleTime = time.Duration(n) * time.Millisecond
 		p.MaxConnIdleTimeSet = true
 	case "maxlifetimems":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 		p.MaxConnLifeTime = time.Duration(n) * time.Millisecond
 	case "maxpoolsize":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 		p.MaxConnsPerHost = uint16(n)
 		p.MaxConnsPerHostSet = true
 		p.MaxIdleConnsPerHost = uint16(n)
 		p.MaxIdleConnsPerHostSet = true
+	case "minpoolsize":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return invalid()
+		}
+		p.MinPoolSize = uint16(n)
+		p.MinPoolSizeSet = true
 	case "readconcernlevel":
 		p.ReadConcernLevel = value
 	case "readpreference":
 		p.ReadPreference = value
 	case "readpreferencetags":
 		tags := make(map[string]string)
+		if value == "" {
+			// an empty tag set matches any server
+			p.ReadPreferenceTagSets = append(p.ReadPreferenceTagSets, tags)
+			break
+		}
 		items := strings.Split(value, ",")
 		for _, item := range items {
 			parts := strings.Split(item, ":")
 			if len(parts) != 2 {
-				return fmt.Errorf("invalid value for %s: %s", key, value)
+				return invalid()
 			}
 			tags[parts[0]] = parts[1]
 		}
@@ -583,25 +775,33 @@ func (p *parser) addOption(pair string) error {
 	case "maxstaleness":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 		p.MaxStaleness = time.Duration(n) * time.Second
 		p.MaxStalenessSet = true
 	case "replicaset":
 		p.ReplicaSet = value
 	case "retrywrites":
-		p.RetryWrites = value == "true"
+		switch value {
+		case "true":
+			p.RetryWrites = true
+		case "false":
+			p.RetryWrites = false
+		default:
+			return invalid()
+		}
+
 		p.RetryWritesSet = true
 	case "serverselectiontimeoutms":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 		p.ServerSelectionTimeout = time.Duration(n) * time.Millisecond
 	case "sockettimeoutms":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 		p.SocketTimeout = time.Duration(n) * time.Millisecond
 	case "ssl":
@@ -611,7 +811,7 @@ func (p *parser) addOption(pair string) error {
 		case "false":
 			p.SSL = false
 		default:
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 
 		p.SSLSet = true
@@ -630,7 +830,7 @@ func (p *parser) addOption(pair string) error {
 		case "false":
 			p.SSLInsecure = false
 		default:
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 
 		p.SSLInsecureSet = true
@@ -639,10 +839,24 @@ func (p *parser) addOption(pair string) error {
 		p.SSLSet = true
 		p.SSLCaFile = value
 		p.SSLCaFileSet = true
+	case "timeoutms":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return invalid()
+		}
+		p.Timeout = time.Duration(n) * time.Millisecond
+		p.TimeoutSet = true
+	case "waitqueuetimeoutms":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return invalid()
+		}
+		p.WaitQueueTimeout = time.Duration(n) * time.Millisecond
+		p.WaitQueueTimeoutSet = true
 	case "w":
 		if w, err := strconv.Atoi(value); err == nil {
 			if w < 0 {
-				return fmt.Errorf("invalid value for %s: %s", key, value)
+				return invalid()
 			}
 
 			p.WNumber = w
@@ -657,24 +871,25 @@ func (p *parser) addOption(pair string) error {
 	case "wtimeoutms":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 		p.WTimeout = time.Duration(n) * time.Millisecond
 		p.WTimeoutSet = true
 	case "wtimeout":
+		p.warnf("option %s is deprecated, use wtimeoutMS instead", key)
 		// Defer to wtimeoutms, but not to a manually-set option.
 		if p.WTimeoutSet {
 			break
 		}
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 		p.WTimeout = time.Duration(n) * time.Millisecond
 	case "zlibcompressionlevel":
 		level, err := strconv.Atoi(value)
 		if err != nil || (level < -1 || level > 9) {
-			return fmt.Errorf("invalid value for %s: %s", key, value)
+			return invalid()
 		}
 
 		if level == -1 {
@@ -682,6 +897,7 @@ func (p *parser) addOption(pair string) error {
 		}
 		p.ZlibLevel = level
 	default:
+		p.warnf("unrecognized option %s is ignored", key)
 		if p.UnknownOptions == nil {
 			p.UnknownOptions = make(map[string][]string)
 		}
@@ -737,21 +953,28 @@ func validateTXTResult(paramsFromTXT []string) error {
 	return nil
 }
 
-func extractQueryArgsFromURI(uri string) ([]string, error) {
+// extractQueryArgsFromURI returns the options of the query string uri, which starts at the given
+// offset of the connection string.
+func extractQueryArgsFromURI(uri string, offset int) ([]option, error) {
 	if len(uri) == 0 {
 		return nil, nil
 	}
 
 	if uri[0] != '?' {
-		return nil, errors.New("must have a ? separator between path and query")
+		return nil, errorAt(offset, "must have a ? separator between path and query")
 	}
 
-	uri = uri[1:]
-	if len(uri) == 0 {
-		return nil, nil
+	var opts []option
+	for start, i := 1, 1; i <= len(uri); i++ {
+		if i < len(uri) && uri[i] != ';' && uri[i] != '&' {
+			continue
+		}
+		if i > start {
+			opts = append(opts, option{pair: uri[start:i], offset: offset + start})
+		}
+		start = i + 1
 	}
-	return strings.FieldsFunc(uri, func(r rune) bool { return r == ';' || r == '&' }), nil
-
+	return opts, nil
 }
 
 type extractedDatabase struct {
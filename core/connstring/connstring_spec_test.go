@@ -33,6 +33,7 @@ type testCase struct {
 	Description string
 	URI         string
 	Valid       bool
+	Warning     bool
 	Hosts       []host
 	Auth        *auth
 	Options     map[string]interface{}
@@ -109,6 +110,7 @@ func runTest(t *testing.T, filename string, test *testCase) {
 		}
 
 		require.Equal(t, test.URI, cs.Original)
+		require.Equal(t, test.Warning, len(cs.Warnings) > 0, "warnings: %v", cs.Warnings)
 
 		if test.Hosts != nil {
 			require.Equal(t, hostsToStrings(test.Hosts), cs.Hosts)
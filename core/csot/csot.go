@@ -0,0 +1,159 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package csot implements client-side operation timeouts.
+//
+// An operation timeout is a single budget covering everything an operation does: server selection,
+// connection checkout, every attempt of a retried command and, for operations returning a cursor,
+// the getMores of the cursor. It is carried by a context created with WithTimeout or WithDeadline,
+// and the maxTimeMS of each command is derived from the time remaining.
+package csot
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"time"
+)
+
+// ErrDeadlineExceeded is returned when an operation does not complete before its timeout. It
+// satisfies errors.Is(err, context.DeadlineExceeded).
+var ErrDeadlineExceeded error = deadlineExceeded{}
+
+type deadlineExceeded struct{}
+
+func (deadlineExceeded) Error() string { return "operation exceeded its timeout" }
+
+func (deadlineExceeded) Is(target error) bool { return target == context.DeadlineExceeded }
+
+func (deadlineExceeded) Timeout() bool { return true }
+
+// TimeoutError is returned when an operation fails because its timeout expired. It wraps the error
+// that caused the operation to fail and satisfies both errors.Is(err, ErrDeadlineExceeded) and
+// errors.Is(err, context.DeadlineExceeded).
+type TimeoutError struct {
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *TimeoutError) Error() string {
+	return ErrDeadlineExceeded.Error() + ": " + e.Wrapped.Error()
+}
+
+// Unwrap returns the error that caused the operation to fail.
+func (e *TimeoutError) Unwrap() error { return e.Wrapped }
+
+// Is reports whether target is ErrDeadlineExceeded or context.DeadlineExceeded.
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrDeadlineExceeded || target == context.DeadlineExceeded
+}
+
+// Timeout implements the net.Error Timeout method.
+func (e *TimeoutError) Timeout() bool { return true }
+
+type timeoutKey struct{}
+
+type iterationKey struct{}
+
+// WithTimeout returns a copy of ctx carrying an operation timeout of d. It overrides the timeout
+// configured for the client. A timeout of 0 means the operation is not bounded by a timeout, even
+// if the client has one.
+func WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	ctx = context.WithValue(ctx, timeoutKey{}, d)
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, d)
+}
+
+// WithDeadline returns a copy of ctx carrying an operation timeout that expires at deadline, such as
+// the remaining budget of a cursor.
+func WithDeadline(ctx context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	ctx = context.WithValue(ctx, timeoutKey{}, time.Until(deadline))
+	return context.WithDeadline(ctx, deadline)
+}
+
+// Timeout returns the operation timeout carried by ctx, if any.
+func Timeout(ctx context.Context) (time.Duration, bool) {
+	d, ok := ctx.Value(timeoutKey{}).(time.Duration)
+	return d, ok
+}
+
+// WithIteration returns a copy of ctx marking that a cursor created with it applies the operation
+// timeout to each of its getMores, rather than to its whole lifetime. Tailable await cursors are
+// iterated this way.
+func WithIteration(ctx context.Context) context.Context {
+	return context.WithValue(ctx, iterationKey{}, true)
+}
+
+// Iteration reports whether ctx was marked with WithIteration.
+func Iteration(ctx context.Context) bool {
+	it, _ := ctx.Value(iterationKey{}).(bool)
+	return it
+}
+
+// MaxTimeMS returns the maxTimeMS to send with a command run with ctx to a server with the given
+// round trip time, leaving the server time to reply before the deadline. It returns 0 if ctx has no
+// operation timeout or no deadline, and ErrDeadlineExceeded if too little time is left to send the
+// command at all.
+func MaxTimeMS(ctx context.Context, rtt time.Duration) (int64, error) {
+	if _, ok := Timeout(ctx); !ok {
+		return 0, nil
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, nil
+	}
+
+	remaining := time.Until(deadline)
+	if rtt > 0 {
+		remaining -= rtt
+	}
+	if remaining <= 0 {
+		return 0, ErrDeadlineExceeded
+	}
+
+	return int64(math.Ceil(float64(remaining) / float64(time.Millisecond))), nil
+}
+
+// coder is implemented by server errors.
+type coder interface {
+	ErrorCode() int32
+}
+
+// maxTimeMSExpired is the code of the error returned by the server when maxTimeMS expires.
+const maxTimeMSExpired = 50
+
+// Wrap returns err as a *TimeoutError if ctx carries an operation timeout and err was caused by it
+// expiring: the deadline of ctx passed, a network operation timed out or the server reported that
+// maxTimeMS expired. Other errors are returned unchanged.
+func Wrap(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := Timeout(ctx); !ok {
+		return err
+	}
+
+	var te *TimeoutError
+	if errors.As(err, &te) || err == ErrDeadlineExceeded {
+		return err
+	}
+
+	var c coder
+	var ne net.Error
+	switch {
+	case ctx.Err() == context.DeadlineExceeded,
+		errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &ne) && ne.Timeout(),
+		errors.As(err, &c) && c.ErrorCode() == maxTimeMSExpired:
+		return &TimeoutError{Wrapped: err}
+	}
+
+	return err
+}
@@ -0,0 +1,102 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package csot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type codeError int32
+
+func (e codeError) Error() string    { return "server error" }
+func (e codeError) ErrorCode() int32 { return int32(e) }
+
+func TestErrors(t *testing.T) {
+	if !errors.Is(ErrDeadlineExceeded, context.DeadlineExceeded) {
+		t.Errorf("Expected ErrDeadlineExceeded to be context.DeadlineExceeded")
+	}
+
+	err := error(&TimeoutError{Wrapped: codeError(maxTimeMSExpired)})
+	if !errors.Is(err, ErrDeadlineExceeded) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected TimeoutError to be ErrDeadlineExceeded and context.DeadlineExceeded")
+	}
+	if !errors.Is(err, codeError(maxTimeMSExpired)) {
+		t.Errorf("Expected TimeoutError to wrap the server error")
+	}
+}
+
+func TestMaxTimeMS(t *testing.T) {
+	t.Run("no operation timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		ms, err := MaxTimeMS(ctx, 0)
+		if ms != 0 || err != nil {
+			t.Errorf("Expected 0 and no error, got %d and %v", ms, err)
+		}
+	})
+	t.Run("timeout of 0", func(t *testing.T) {
+		ctx, cancel := WithTimeout(context.Background(), 0)
+		defer cancel()
+
+		ms, err := MaxTimeMS(ctx, 0)
+		if ms != 0 || err != nil {
+			t.Errorf("Expected 0 and no error, got %d and %v", ms, err)
+		}
+	})
+	t.Run("remaining time less round trip time", func(t *testing.T) {
+		ctx, cancel := WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		ms, err := MaxTimeMS(ctx, 10*time.Second)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if ms <= 49000 || ms > 50000 {
+			t.Errorf("Expected maxTimeMS close to 50000, got %d", ms)
+		}
+	})
+	t.Run("expired", func(t *testing.T) {
+		ctx, cancel := WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		_, err := MaxTimeMS(ctx, 2*time.Second)
+		if err != ErrDeadlineExceeded {
+			t.Errorf("Expected error %v, got %v", ErrDeadlineExceeded, err)
+		}
+	})
+}
+
+func TestWrap(t *testing.T) {
+	ctx, cancel := WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	other := errors.New("other")
+	if err := Wrap(ctx, other); err != other {
+		t.Errorf("Expected unrelated error to be returned unchanged, got %v", err)
+	}
+	if err := Wrap(context.Background(), codeError(maxTimeMSExpired)); err != codeError(maxTimeMSExpired) {
+		t.Errorf("Expected error without an operation timeout to be returned unchanged, got %v", err)
+	}
+
+	err := Wrap(ctx, codeError(maxTimeMSExpired))
+	if _, ok := err.(*TimeoutError); !ok {
+		t.Errorf("Expected a *TimeoutError, got %T", err)
+	}
+	if Wrap(ctx, err) != err {
+		t.Errorf("Expected a *TimeoutError not to be wrapped again")
+	}
+
+	expired, cancel := WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if err := Wrap(expired, other); !errors.Is(err, ErrDeadlineExceeded) {
+		t.Errorf("Expected error of an expired operation to be a timeout, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package deployment defines the interfaces operations are dispatched through.
+//
+// The dispatch package runs operations against a Deployment rather than a concrete topology, so
+// the transport can be wrapped, e.g. to instrument server selection or to add a circuit breaker,
+// or replaced by a fake in tests. *topology.Topology implements Deployment and the servers it
+// selects implement Server.
+package deployment
+
+import (
+	"context"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/connection"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/logger"
+)
+
+// Deployment is a set of servers that operations can be run against.
+type Deployment interface {
+	// SelectServer returns a server suitable for the given selector, blocking until one is
+	// available or ctx is done.
+	SelectServer(context.Context, description.ServerSelector) (Server, error)
+	// SupportsSessions reports whether the deployment supports sessions, in which case operations
+	// run without an explicit session use an implicit one.
+	SupportsSessions() bool
+}
+
+// Server is a server selected from a Deployment.
+type Server interface {
+	// Connection checks out a connection to the server. The connection must be closed once it is
+	// no longer used.
+	Connection(context.Context) (connection.Connection, error)
+	// Description returns the description of the server, along with the kind of the deployment it
+	// was selected from, which determines how commands are encoded for it.
+	Description() description.SelectedServer
+
+	// BuildCursor builds the cursor returned by commands that return one. The cursor runs its
+	// getMores against the server.
+	command.CursorBuilder
+}
+
+// Timeouter is implemented by deployments that bound operations by a default timeout.
+type Timeouter interface {
+	// Timeout returns the default operation timeout, or 0 if operations are only bounded by their
+	// context.
+	Timeout() time.Duration
+}
+
+// Logged is implemented by deployments that log the operations run against them, such as the
+// retries of commands.
+type Logged interface {
+	Logger() logger.Logger
+}
+
+// Timeout returns the default operation timeout of d if it implements Timeouter, and 0 otherwise.
+func Timeout(d Deployment) time.Duration {
+	if t, ok := d.(Timeouter); ok {
+		return t.Timeout()
+	}
+	return 0
+}
+
+// Logger returns the logger of d if it implements Logged, and a logger that logs nothing
+// otherwise.
+func Logger(d Deployment) logger.Logger {
+	if l, ok := d.(Logged); ok {
+		return l.Logger()
+	}
+	return logger.Nop
+}
@@ -8,26 +8,126 @@ package description
 
 import (
 	"fmt"
+
+	"github.com/mongodb/mongo-go-driver/core/address"
+)
+
+// Feature is a capability of servers that only some versions of the server have.
+type Feature uint8
+
+// These are the features the driver checks the servers it sends commands to for.
+const (
+	FeatureSCRAMSHA1 Feature = iota + 1
+	FeatureBypassDocumentValidation
+	FeatureCollation
+	FeatureMaxStaleness
+	FeatureArrayFilters
+	FeatureSCRAMSHA256
+	FeatureComment
+	FeatureLet
 )
 
+// features holds the name and the minimum wire version of each feature.
+var features = map[Feature]struct {
+	name        string
+	wireVersion int32
+}{
+	FeatureSCRAMSHA1:                {"SCRAM-SHA-1", 3},
+	FeatureBypassDocumentValidation: {"the bypassDocumentValidation option", 4},
+	FeatureCollation:                {"collation", 5},
+	FeatureMaxStaleness:             {"max staleness", 5},
+	FeatureArrayFilters:             {"the arrayFilters option", 6},
+	FeatureSCRAMSHA256:              {"SCRAM-SHA-256", 7},
+	FeatureComment:                  {"comments of any type on every command", 9},
+	FeatureLet:                      {"the let option", 13},
+}
+
+// serverVersions maps the wire versions features require to the server version that introduced
+// them.
+var serverVersions = map[int32]string{
+	3:  "3.0",
+	4:  "3.2",
+	5:  "3.4",
+	6:  "3.6",
+	7:  "4.0",
+	9:  "4.4",
+	13: "5.0",
+}
+
+// String implements the fmt.Stringer interface.
+func (f Feature) String() string {
+	if info, ok := features[f]; ok {
+		return info.name
+	}
+	return fmt.Sprintf("Feature(%d)", uint8(f))
+}
+
+// MinWireVersion returns the wire version of the first server version that supports f.
+func (f Feature) MinWireVersion() int32 {
+	return features[f].wireVersion
+}
+
+// ErrUnsupportedServerVersion is returned when an operation uses a feature the server it was sent
+// to does not support.
+type ErrUnsupportedServerVersion struct {
+	Feature             Feature
+	RequiredWireVersion int32
+	ServerWireVersion   int32
+	ServerAddress       address.Address // empty when the server is not known
+}
+
+func (e ErrUnsupportedServerVersion) Error() string {
+	server := "the server"
+	if e.ServerAddress != "" {
+		server = fmt.Sprintf("the server at %s", e.ServerAddress)
+	}
+	return fmt.Sprintf("%s is only supported for servers %s or newer (wire version %d), and %s has wire version %d",
+		e.Feature, serverVersions[e.RequiredWireVersion], e.RequiredWireVersion, server, e.ServerWireVersion)
+}
+
+// featureSupported returns an ErrUnsupportedServerVersion if wireVersion is older than the
+// minimum wire version of f. An unknown wire version is assumed to support f.
+func featureSupported(f Feature, wireVersion *VersionRange, addr address.Address) error {
+	if wireVersion == nil || wireVersion.Max >= f.MinWireVersion() {
+		return nil
+	}
+	return ErrUnsupportedServerVersion{
+		Feature:             f,
+		RequiredWireVersion: f.MinWireVersion(),
+		ServerWireVersion:   wireVersion.Max,
+		ServerAddress:       addr,
+	}
+}
+
+// CommentSupported returns an error if the given server version does not support
+// comments of any type on every command. Older servers only accept string comments
+// on find and aggregate commands.
+func CommentSupported(wireVersion *VersionRange) error {
+	return featureSupported(FeatureComment, wireVersion, "")
+}
+
+// LetSupported returns an error if the given server version does not support
+// the let option of the find command.
+func LetSupported(wireVersion *VersionRange) error {
+	return featureSupported(FeatureLet, wireVersion, "")
+}
+
+// BypassDocumentValidationSupported returns an error if the given server version does not
+// support the bypassDocumentValidation option of write commands.
+func BypassDocumentValidationSupported(wireVersion *VersionRange) error {
+	return featureSupported(FeatureBypassDocumentValidation, wireVersion, "")
+}
+
 // MaxStalenessSupported returns an error if the given server version
 // does not support max staleness.
 func MaxStalenessSupported(wireVersion *VersionRange) error {
-	if wireVersion != nil && wireVersion.Max < 5 {
-		return fmt.Errorf("max staleness is only supported for servers 3.4 or newer")
-	}
-
-	return nil
+	return featureSupported(FeatureMaxStaleness, wireVersion, "")
 }
 
 // ScramSHA1Supported returns an error if the given server version
 // does not support scram-sha-1.
 func ScramSHA1Supported(wireVersion *VersionRange) error {
-	if wireVersion != nil && wireVersion.Max < 3 {
-		return fmt.Errorf("SCRAM-SHA-1 is only supported for servers 3.0 or newer")
-	}
-
-	return nil
+	return featureSupported(FeatureSCRAMSHA1, wireVersion, "")
 }
 
 // SessionsSupported returns true of the given server version indicates that it supports sessions.
@@ -44,3 +44,29 @@ func TestMaxStaleness(t *testing.T) {
 		})
 	}
 }
+
+func TestFeature(t *testing.T) {
+	server := Server{Addr: "localhost:27017", WireVersion: &VersionRange{Max: 6}}
+
+	require.True(t, server.SupportsFeature(FeatureArrayFilters))
+	require.NoError(t, server.CheckFeature(FeatureArrayFilters))
+	require.True(t, Server{}.SupportsFeature(FeatureLet), "expected a server with an unknown wire version to support every feature")
+
+	require.False(t, server.SupportsFeature(FeatureSCRAMSHA256))
+	err := server.CheckFeature(FeatureSCRAMSHA256)
+	require.Equal(t, ErrUnsupportedServerVersion{
+		Feature:             FeatureSCRAMSHA256,
+		RequiredWireVersion: 7,
+		ServerWireVersion:   6,
+		ServerAddress:       "localhost:27017",
+	}, err)
+	require.EqualError(t, err, "SCRAM-SHA-256 is only supported for servers 4.0 or newer (wire version 7), and the server at localhost:27017 has wire version 6")
+
+	err = LetSupported(&VersionRange{Max: 9})
+	require.EqualError(t, err, "the let option is only supported for servers 5.0 or newer (wire version 13), and the server has wire version 9")
+
+	for f := FeatureSCRAMSHA1; f <= FeatureLet; f++ {
+		_, ok := serverVersions[f.MinWireVersion()]
+		require.True(t, ok, "no server version for the wire version of %v", f)
+	}
+}
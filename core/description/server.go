@@ -13,6 +13,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/bson/objectid"
 	"github.com/mongodb/mongo-go-driver/core/address"
 	"github.com/mongodb/mongo-go-driver/core/result"
+	"github.com/mongodb/mongo-go-driver/core/serverapi"
 	"github.com/mongodb/mongo-go-driver/core/tag"
 )
 
@@ -23,6 +24,9 @@ const UnsetRTT = -1 * time.Millisecond
 type SelectedServer struct {
 	Server
 	Kind TopologyKind
+
+	// ServerAPI is the server API declared for commands sent to the server, if any.
+	ServerAPI *serverapi.Options
 }
 
 // Server represents a description of a server. This is created from an isMaster
@@ -43,6 +47,8 @@ type Server struct {
 	MaxDocumentSize       uint32
 	MaxMessageSize        uint32
 	Members               []address.Address
+	MinRTT                time.Duration // the minimum of the recent round trip times, 0 if unknown
+	OperationCount        int64         // operations in progress, set by the topology when describing it and selecting
 	ReadOnly              bool
 	SessionTimeoutMinutes uint32
 	SetName               string
@@ -135,6 +141,17 @@ func (s Server) SetAverageRTT(rtt time.Duration) Server {
 	return s
 }
 
+// SupportsFeature returns true if the server supports f. A server whose wire version is not known
+// yet is assumed to support every feature.
+func (s Server) SupportsFeature(f Feature) bool {
+	return s.CheckFeature(f) == nil
+}
+
+// CheckFeature returns an ErrUnsupportedServerVersion if the server does not support f.
+func (s Server) CheckFeature(f Feature) error {
+	return featureSupported(f, s.WireVersion, s.Addr)
+}
+
 // DataBearing returns true if the server is a data bearing server.
 func (s Server) DataBearing() bool {
 	return s.Kind == RSPrimary ||
@@ -10,9 +10,10 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/result"
-	"github.com/mongodb/mongo-go-driver/core/topology"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // AbortTransaction handles the full cycle dispatch and execution of abortting a transaction
@@ -20,13 +21,19 @@ import (
 func AbortTransaction(
 	ctx context.Context,
 	cmd command.AbortTransaction,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
-) (result.TransactionResult, error) {
+) (_ result.TransactionResult, err error) {
+	ctx = observability.TagNamespace(ctx, "admin", "", "abortTransaction")
+	ctx, op := observability.StartOperation(ctx, "abort_transaction", "mongo-go/core/dispatch.AbortTransaction")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
 	res, err := abortTransaction(ctx, cmd, topo, selector, nil)
 	if cerr, ok := err.(command.Error); ok && err != nil {
 		// Retry if appropriate
 		if cerr.Retryable() {
+			logRetry(ctx, topo, "abortTransaction", cerr, nil)
 			res, err = abortTransaction(ctx, cmd, topo, selector, cerr)
 		}
 	}
@@ -36,7 +43,7 @@ func AbortTransaction(
 func abortTransaction(
 	ctx context.Context,
 	cmd command.AbortTransaction,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	oldErr error,
 ) (result.TransactionResult, error) {
@@ -10,15 +10,12 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
 
 	"github.com/mongodb/mongo-go-driver/internal/observability"
-	"go.opencensus.io/stats"
-	"go.opencensus.io/tag"
-	"go.opencensus.io/trace"
 )
 
 // Aggregate handles the full cycle dispatch and execution of an aggregate command against the provided
@@ -26,29 +23,29 @@ import (
 func Aggregate(
 	ctx context.Context,
 	cmd command.Aggregate,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	readSelector, writeSelector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
-) (command.Cursor, error) {
+) (_ command.Cursor, err error) {
 
-	ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyMethod, "aggregate"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/dispatch.Aggregate")
-	defer span.End()
+	ctx = observability.TagNamespace(ctx, cmd.NS.DB, cmd.NS.Collection, "aggregate")
+	ctx, op := observability.StartOperation(ctx, "aggregate", "mongo-go/core/dispatch.Aggregate")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
+	span := op.Span()
 
 	dollarOut := cmd.HasDollarOut()
 
-	var ss *topology.SelectedServer
-	var err error
+	var ss deployment.Server
 	switch dollarOut {
 	case true:
 		span.Annotatef(nil, "Invoking topology.SelectServer")
 		ss, err = topo.SelectServer(ctx, writeSelector)
 		span.Annotatef(nil, "Finished invoking topology.SelectServer")
 		if err != nil {
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "topo_selectserver"))
-			stats.Record(ctx, observability.MErrors.M(1))
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			op.Fail("topo_selectserver", err)
 			return nil, err
 		}
 	case false:
@@ -56,9 +53,7 @@ func Aggregate(
 		ss, err = topo.SelectServer(ctx, readSelector)
 		span.Annotatef(nil, "Finished invoking topology.SelectServer")
 		if err != nil {
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "topo_selectserver"))
-			stats.Record(ctx, observability.MErrors.M(1))
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			op.Fail("topo_selectserver", err)
 			return nil, err
 		}
 	}
@@ -66,9 +61,7 @@ func Aggregate(
 	desc := ss.Description()
 	conn, err := ss.Connection(ctx)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "connection"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		op.Fail("connection", err)
 		return nil, err
 	}
 	defer conn.Close()
@@ -83,9 +76,7 @@ func Aggregate(
 	if cmd.Session == nil && topo.SupportsSessions() {
 		cmd.Session, err = session.NewClientSession(pool, clientID, session.Implicit)
 		if err != nil {
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "session_newclientsession"))
-			stats.Record(ctx, observability.MErrors.M(1))
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			op.Fail("session_newclientsession", err)
 			return nil, err
 		}
 	}
@@ -0,0 +1,59 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dispatch
+
+import (
+	"context"
+
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/result"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/uuid"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+)
+
+// CollMod handles the full cycle dispatch and execution of a collMod command against the provided
+// topology.
+func CollMod(
+	ctx context.Context,
+	cmd command.CollMod,
+	topo deployment.Deployment,
+	selector description.ServerSelector,
+	clientID uuid.UUID,
+	pool *session.Pool,
+) (_ result.CollMod, err error) {
+
+	ctx = observability.TagNamespace(ctx, cmd.NS.DB, cmd.NS.Collection, "collMod")
+	ctx, op := observability.StartOperation(ctx, "coll_mod", "mongo-go/core/dispatch.CollMod")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
+
+	ss, err := topo.SelectServer(ctx, selector)
+	if err != nil {
+		return result.CollMod{}, err
+	}
+
+	conn, err := ss.Connection(ctx)
+	if err != nil {
+		return result.CollMod{}, err
+	}
+	defer conn.Close()
+
+	// If no explicit session and deployment supports sessions, start implicit session.
+	if cmd.Session == nil && topo.SupportsSessions() {
+		cmd.Session, err = session.NewClientSession(pool, clientID, session.Implicit)
+		if err != nil {
+			return result.CollMod{}, err
+		}
+		defer cmd.Session.EndSession()
+	}
+
+	return cmd.RoundTrip(ctx, ss.Description(), conn)
+}
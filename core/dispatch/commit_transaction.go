@@ -10,9 +10,10 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/result"
-	"github.com/mongodb/mongo-go-driver/core/topology"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // CommitTransaction handles the full cycle dispatch and execution of committing a transaction
@@ -20,19 +21,25 @@ import (
 func CommitTransaction(
 	ctx context.Context,
 	cmd command.CommitTransaction,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
-) (result.TransactionResult, error) {
+) (_ result.TransactionResult, err error) {
+	ctx = observability.TagNamespace(ctx, "admin", "", "commitTransaction")
+	ctx, op := observability.StartOperation(ctx, "commit_transaction", "mongo-go/core/dispatch.CommitTransaction")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
 	res, err := commitTransaction(ctx, cmd, topo, selector, nil)
 	if cerr, ok := err.(command.Error); ok && err != nil {
 		// Retry if appropriate
 		if cerr.Retryable() {
+			logRetry(ctx, topo, "commitTransaction", cerr, nil)
 			res, err = commitTransaction(ctx, cmd, topo, selector, cerr)
 			if cerr2, ok := err.(command.Error); ok && err != nil {
 				// Retry failures also get label
 				cerr2.Labels = append(cerr2.Labels, command.UnknownTransactionCommitResult)
 			} else if err != nil {
-				err = command.Error{Message: err.Error(), Labels: []string{command.UnknownTransactionCommitResult}}
+				err = command.Error{Message: err.Error(), Labels: []string{command.UnknownTransactionCommitResult}, Wrapped: err}
 			}
 		}
 	}
@@ -42,7 +49,7 @@ func CommitTransaction(
 func commitTransaction(
 	ctx context.Context,
 	cmd command.CommitTransaction,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	oldErr error,
 ) (result.TransactionResult, error) {
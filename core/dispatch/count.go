@@ -10,12 +10,12 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // Count handles the full cycle dispatch and execution of a count command against the provided
@@ -23,21 +23,24 @@ import (
 func Count(
 	ctx context.Context,
 	cmd command.Count,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
-) (int64, error) {
+) (_ result.Count, err error) {
 
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/dispatch.Count")
-	defer span.End()
+	ctx = observability.TagNamespace(ctx, cmd.NS.DB, cmd.NS.Collection, "count")
+	ctx, op := observability.StartOperation(ctx, "count", "mongo-go/core/dispatch.Count")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
+	span := op.Span()
 
 	span.Annotatef(nil, "Invoking topology.SelectServer")
 	ss, err := topo.SelectServer(ctx, selector)
 	span.Annotatef(nil, "Finished invoking topology.SelectServer")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-		return 0, err
+		return result.Count{}, err
 	}
 
 	desc := ss.Description()
@@ -45,14 +48,13 @@ func Count(
 	conn, err := ss.Connection(ctx)
 	span.Annotatef(nil, "Finished creating Connection")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-		return 0, err
+		return result.Count{}, err
 	}
 	defer conn.Close()
 
 	rp, err := getReadPrefBasedOnTransaction(cmd.ReadPref, cmd.Session)
 	if err != nil {
-		return 0, err
+		return result.Count{}, err
 	}
 	cmd.ReadPref = rp
 
@@ -60,14 +62,10 @@ func Count(
 	if cmd.Session == nil && topo.SupportsSessions() {
 		cmd.Session, err = session.NewClientSession(pool, clientID, session.Implicit)
 		if err != nil {
-			return 0, err
+			return result.Count{}, err
 		}
 		defer cmd.Session.EndSession()
 	}
 
-	cur, err := cmd.RoundTrip(ctx, desc, conn)
-	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-	}
-	return cur, err
+	return cmd.RoundTrip(ctx, desc, conn)
 }
@@ -10,10 +10,11 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // CountDocuments handles the full cycle dispatch and execution of a countDocuments command against the provided
@@ -21,11 +22,17 @@ import (
 func CountDocuments(
 	ctx context.Context,
 	cmd command.CountDocuments,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
-) (int64, error) {
+) (_ int64, err error) {
+
+	ctx = observability.TagNamespace(ctx, cmd.NS.DB, cmd.NS.Collection, "aggregate")
+	ctx, op := observability.StartOperation(ctx, "count_documents", "mongo-go/core/dispatch.CountDocuments")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
 
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
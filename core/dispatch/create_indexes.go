@@ -10,13 +10,12 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // CreateIndexes handles the full cycle dispatch and execution of a createIndexes
@@ -24,20 +23,23 @@ import (
 func CreateIndexes(
 	ctx context.Context,
 	cmd command.CreateIndexes,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
-) (result.CreateIndexes, error) {
+) (_ result.CreateIndexes, err error) {
 
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/dispatch.CreateIndexes")
-	defer span.End()
+	ctx = observability.TagNamespace(ctx, cmd.NS.DB, cmd.NS.Collection, "createIndexes")
+	ctx, op := observability.StartOperation(ctx, "create_indexes", "mongo-go/core/dispatch.CreateIndexes")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
+	span := op.Span()
 
 	span.Annotatef(nil, "Invoking topology.SelectServer")
 	ss, err := topo.SelectServer(ctx, selector)
 	span.Annotatef(nil, "Finished invoking topology.SelectServer")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return result.CreateIndexes{}, err
 	}
 
@@ -45,7 +47,6 @@ func CreateIndexes(
 	conn, err := ss.Connection(ctx)
 	span.Annotatef(nil, "Finished creating Connection")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return result.CreateIndexes{}, err
 	}
 	defer conn.Close()
@@ -62,8 +63,5 @@ func CreateIndexes(
 	span.Annotatef(nil, "Invoking cmd.RoundTrip")
 	ci, err := cmd.RoundTrip(ctx, ss.Description(), conn)
 	span.Annotatef(nil, "Finished invoking cmd.RoundTrip")
-	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-	}
 	return ci, err
 }
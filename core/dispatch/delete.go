@@ -10,17 +10,13 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
-	"github.com/mongodb/mongo-go-driver/core/writeconcern"
 
 	"github.com/mongodb/mongo-go-driver/internal/observability"
-	"go.opencensus.io/stats"
-	"go.opencensus.io/tag"
-	"go.opencensus.io/trace"
 )
 
 // Delete handles the full cycle dispatch and execution of a delete command against the provided
@@ -28,29 +24,30 @@ import (
 func Delete(
 	ctx context.Context,
 	cmd command.Delete,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
 	retryWrite bool,
-) (result.Delete, error) {
+) (_ result.Delete, err error) {
 
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/dispatch.Delete")
-	ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyMethod, "delete"))
-	defer span.End()
+	ctx = observability.TagNamespace(ctx, cmd.NS.DB, cmd.NS.Collection, "delete")
+	ctx, op := observability.StartOperation(ctx, "delete", "mongo-go/core/dispatch.Delete")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
+	span := op.Span()
 
 	span.Annotatef(nil, "Invoking topology.SelectServer")
 	ss, err := topo.SelectServer(ctx, selector)
 	span.Annotatef(nil, "Finished invoking topology.SelectServer")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "connection"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		op.Fail("connection", err)
 		return result.Delete{}, err
 	}
 
 	// If no explicit session and deployment supports sessions, start implicit session.
-	if cmd.Session == nil && topo.SupportsSessions() && writeconcern.AckWrite(cmd.WriteConcern) {
+	if cmd.Session == nil && topo.SupportsSessions() && cmd.WriteConcern.Acknowledged() {
 		cmd.Session, err = session.NewClientSession(pool, clientID, session.Implicit)
 		if err != nil {
 			return result.Delete{}, err
@@ -63,13 +60,13 @@ func Delete(
 		if cmd.Session != nil {
 			cmd.Session.RetryWrite = false // explicitly set to false to prevent encoding transaction number
 		}
-		return delete(ctx, span, cmd, ss, nil)
+		return delete(ctx, op, &cmd, ss, nil)
 	}
 
 	cmd.Session.RetryWrite = retryWrite
 	cmd.Session.IncrementTxnNumber()
 
-	res, originalErr := delete(ctx, span, cmd, ss, nil)
+	res, originalErr := delete(ctx, op, &cmd, ss, nil)
 
 	// Retry if appropriate
 	if cerr, ok := originalErr.(command.Error); ok && cerr.Retryable() ||
@@ -81,44 +78,40 @@ func Delete(
 			return res, originalErr
 		}
 
-		return delete(ctx, span, cmd, ss, cerr)
+		logRetry(ctx, topo, "delete", originalErr, res.WriteConcernError)
+		return delete(ctx, op, &cmd, ss, cerr)
 	}
 	return res, originalErr
 }
 
 func delete(
 	ctx context.Context,
-	span *trace.Span,
-	cmd command.Delete,
-	ss *topology.SelectedServer,
+	op *observability.Operation,
+	cmd *command.Delete,
+	ss deployment.Server,
 	oldErr error,
 ) (result.Delete, error) {
+	span := op.Span()
 	desc := ss.Description()
 
 	span.Annotatef(nil, "Creating ss.Connection")
 	conn, err := ss.Connection(ctx)
 	span.Annotatef(nil, "Finished creating ss.Connection")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "connection"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		op.Fail("connection", err)
 		if oldErr != nil {
 			return result.Delete{}, oldErr
 		}
 		return result.Delete{}, err
 	}
 
-	if !writeconcern.AckWrite(cmd.WriteConcern) {
+	if !cmd.WriteConcern.Acknowledged() {
 		go func() {
 			defer func() { _ = recover() }()
 			defer conn.Close()
 
 			_, _ = cmd.RoundTrip(ctx, desc, conn)
 		}()
-
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "write"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: "Unacknowledged write"})
 		return result.Delete{}, command.ErrUnacknowledgedWrite
 	}
 	defer conn.Close()
@@ -126,9 +119,7 @@ func delete(
 	di, err := cmd.RoundTrip(ctx, desc, conn)
 	span.Annotatef(nil, "Finished invoking cmd.RoundTrip")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "delete"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		op.Fail("delete", err)
 	}
 	return di, err
 }
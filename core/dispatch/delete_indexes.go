@@ -9,14 +9,13 @@ package dispatch
 import (
 	"context"
 
-	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // DropIndexes handles the full cycle dispatch and execution of a dropIndexes
@@ -24,29 +23,31 @@ import (
 func DropIndexes(
 	ctx context.Context,
 	cmd command.DropIndexes,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
-) (bson.Reader, error) {
+) (_ result.DropIndexes, err error) {
 
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/dispatch.DropIndexes")
-	defer span.End()
+	ctx = observability.TagNamespace(ctx, cmd.NS.DB, cmd.NS.Collection, "dropIndexes")
+	ctx, op := observability.StartOperation(ctx, "drop_indexes", "mongo-go/core/dispatch.DropIndexes")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
+	span := op.Span()
 
 	span.Annotatef(nil, "Invoking topology.SelectServer")
 	ss, err := topo.SelectServer(ctx, selector)
 	span.Annotatef(nil, "Finished invoking topology.SelectServer")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-		return nil, err
+		return result.DropIndexes{}, err
 	}
 
 	span.Annotatef(nil, "Invoking ss.Connection")
 	conn, err := ss.Connection(ctx)
 	span.Annotatef(nil, "Finished invoking ss.Connection")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-		return nil, err
+		return result.DropIndexes{}, err
 	}
 	defer conn.Close()
 
@@ -54,7 +55,7 @@ func DropIndexes(
 	if cmd.Session == nil && topo.SupportsSessions() {
 		cmd.Session, err = session.NewClientSession(pool, clientID, session.Implicit)
 		if err != nil {
-			return nil, err
+			return result.DropIndexes{}, err
 		}
 		defer cmd.Session.EndSession()
 	}
@@ -62,9 +63,6 @@ func DropIndexes(
 	span.Annotatef(nil, "Invoking cmd.RoundTrip")
 	dri, err := cmd.RoundTrip(ctx, ss.Description(), conn)
 	span.Annotatef(nil, "Finished invoking cmd.RoundTrip")
-	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-	}
 	return dri, err
 
 }
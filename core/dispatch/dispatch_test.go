@@ -0,0 +1,291 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/connection"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/topology"
+	"github.com/mongodb/mongo-go-driver/core/uuid"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+func TestDispatchInstrumentation(t *testing.T) {
+	// The topology is never connected, so every operation fails at server selection.
+	topo, err := topology.New()
+	require.NoError(t, err)
+
+	selector := description.WriteSelector()
+	ns := command.Namespace{DB: "db", Collection: "coll"}
+	var id uuid.UUID
+
+	testCases := []struct {
+		method      string
+		commandName string
+		run         func(ctx context.Context) error
+	}{
+		{"abort_transaction", "abortTransaction", func(ctx context.Context) error {
+			_, err := AbortTransaction(ctx, command.AbortTransaction{}, topo, selector)
+			return err
+		}},
+		{"aggregate", "aggregate", func(ctx context.Context) error {
+			_, err := Aggregate(ctx, command.Aggregate{NS: ns}, topo, selector, selector, id, nil)
+			return err
+		}},
+		{"commit_transaction", "commitTransaction", func(ctx context.Context) error {
+			_, err := CommitTransaction(ctx, command.CommitTransaction{}, topo, selector)
+			return err
+		}},
+		{"count", "count", func(ctx context.Context) error {
+			_, err := Count(ctx, command.Count{NS: ns}, topo, selector, id, nil)
+			return err
+		}},
+		{"count_documents", "aggregate", func(ctx context.Context) error {
+			_, err := CountDocuments(ctx, command.CountDocuments{NS: ns}, topo, selector, id, nil)
+			return err
+		}},
+		{"create_indexes", "createIndexes", func(ctx context.Context) error {
+			_, err := CreateIndexes(ctx, command.CreateIndexes{NS: ns}, topo, selector, id, nil)
+			return err
+		}},
+		{"delete", "delete", func(ctx context.Context) error {
+			_, err := Delete(ctx, command.Delete{NS: ns}, topo, selector, id, nil, false)
+			return err
+		}},
+		{"drop_indexes", "dropIndexes", func(ctx context.Context) error {
+			_, err := DropIndexes(ctx, command.DropIndexes{NS: ns}, topo, selector, id, nil)
+			return err
+		}},
+		{"distinct", "distinct", func(ctx context.Context) error {
+			_, err := Distinct(ctx, command.Distinct{NS: ns}, topo, selector, id, nil)
+			return err
+		}},
+		{"drop_collection", "drop", func(ctx context.Context) error {
+			_, err := DropCollection(ctx, command.DropCollection{DB: "db", Collection: "coll"}, topo, selector, id, nil)
+			return err
+		}},
+		{"drop_database", "dropDatabase", func(ctx context.Context) error {
+			_, err := DropDatabase(ctx, command.DropDatabase{DB: "db"}, topo, selector, id, nil)
+			return err
+		}},
+		{"end_sessions", "endSessions", func(ctx context.Context) error {
+			_, errs := EndSessions(ctx, command.EndSessions{}, topo, selector)
+			return errs[0]
+		}},
+		{"find", "find", func(ctx context.Context) error {
+			_, err := Find(ctx, command.Find{NS: ns}, topo, selector, id, nil)
+			return err
+		}},
+		{"find_one_and_delete", "findAndModify", func(ctx context.Context) error {
+			_, err := FindOneAndDelete(ctx, command.FindOneAndDelete{NS: ns}, topo, selector, id, nil, false)
+			return err
+		}},
+		{"find_one_and_replace", "findAndModify", func(ctx context.Context) error {
+			_, err := FindOneAndReplace(ctx, command.FindOneAndReplace{NS: ns}, topo, selector, id, nil, false)
+			return err
+		}},
+		{"find_one_and_update", "findAndModify", func(ctx context.Context) error {
+			_, err := FindOneAndUpdate(ctx, command.FindOneAndUpdate{NS: ns}, topo, selector, id, nil, false)
+			return err
+		}},
+		{"insert", "insert", func(ctx context.Context) error {
+			_, err := Insert(ctx, command.Insert{NS: ns}, topo, selector, id, nil, false)
+			return err
+		}},
+		{"list_collections", "listCollections", func(ctx context.Context) error {
+			_, err := ListCollections(ctx, command.ListCollections{DB: "db"}, topo, selector, id, nil)
+			return err
+		}},
+		{"list_databases", "listDatabases", func(ctx context.Context) error {
+			_, err := ListDatabases(ctx, command.ListDatabases{}, topo, selector, id, nil)
+			return err
+		}},
+		{"list_indexes", "listIndexes", func(ctx context.Context) error {
+			_, err := ListIndexes(ctx, command.ListIndexes{NS: ns}, topo, selector, id, nil)
+			return err
+		}},
+		{"read", "ping", func(ctx context.Context) error {
+			cmd := command.Read{DB: "db", Command: bson.NewDocument(bson.EC.Int32("ping", 1))}
+			_, err := Read(ctx, cmd, topo, selector, id, nil)
+			return err
+		}},
+		{"update", "update", func(ctx context.Context) error {
+			_, err := Update(ctx, command.Update{NS: ns}, topo, selector, id, nil, false)
+			return err
+		}},
+		{"write", "ping", func(ctx context.Context) error {
+			cmd := command.Write{DB: "db", Command: bson.NewDocument(bson.EC.Int32("ping", 1))}
+			_, err := Write(ctx, cmd, topo, selector, id, nil)
+			return err
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.method, func(t *testing.T) {
+			keys := []tag.Key{observability.KeyMethod, observability.KeyCommandName}
+			calls := &view.View{Name: "test/calls", Measure: observability.MCalls, Aggregation: view.Count(), TagKeys: keys}
+			errs := &view.View{Name: "test/errors", Measure: observability.MErrors, Aggregation: view.Count(), TagKeys: keys}
+			latency := &view.View{Name: "test/latency", Measure: observability.MRoundTripLatencyMilliseconds, Aggregation: view.Count(), TagKeys: keys}
+			require.NoError(t, view.Register(calls, errs, latency))
+			defer view.Unregister(calls, errs, latency)
+
+			require.Error(t, tc.run(context.Background()))
+
+			want := []tag.Tag{
+				{Key: observability.KeyCommandName, Value: tc.commandName},
+				{Key: observability.KeyMethod, Value: tc.method},
+			}
+			for _, v := range []*view.View{calls, errs, latency} {
+				rows, err := view.RetrieveData(v.Name)
+				require.NoError(t, err)
+				require.Len(t, rows, 1, "expected one row for %s", v.Name)
+				require.Equal(t, want, rows[0].Tags, "unexpected tags for %s", v.Name)
+				require.Equal(t, int64(1), rows[0].Data.(*view.CountData).Value, "unexpected count for %s", v.Name)
+			}
+		})
+	}
+}
+
+func TestDispatchErrorPart(t *testing.T) {
+	topo, err := topology.New()
+	require.NoError(t, err)
+
+	errs := &view.View{
+		Name:        "test/errors_by_part",
+		Measure:     observability.MErrors,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{observability.KeyMethod, observability.KeyPart},
+	}
+	calls := &view.View{
+		Name:        "test/calls_by_part",
+		Measure:     observability.MCalls,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{observability.KeyMethod, observability.KeyPart},
+	}
+	require.NoError(t, view.Register(errs, calls))
+	defer view.Unregister(errs, calls)
+
+	cmd := command.Aggregate{NS: command.Namespace{DB: "db", Collection: "coll"}}
+	_, err = Aggregate(context.Background(), cmd, topo, description.WriteSelector(), description.WriteSelector(), uuid.UUID{}, nil)
+	require.Error(t, err)
+
+	rows, err := view.RetrieveData(errs.Name)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, []tag.Tag{
+		{Key: observability.KeyMethod, Value: "aggregate"},
+		{Key: observability.KeyPart, Value: "topo_selectserver"},
+	}, rows[0].Tags)
+
+	// the part is only attributed to the error, not to the call itself
+	rows, err = view.RetrieveData(calls.Name)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, []tag.Tag{{Key: observability.KeyMethod, Value: "aggregate"}}, rows[0].Tags)
+}
+
+type fakeDeployment struct {
+	timeout  time.Duration
+	selected int
+	selector description.ServerSelector
+	deadline bool
+}
+
+func (d *fakeDeployment) SelectServer(ctx context.Context, selector description.ServerSelector) (deployment.Server, error) {
+	d.selected++
+	d.selector = selector
+	_, d.deadline = ctx.Deadline()
+	return fakeServer{}, nil
+}
+
+func (d *fakeDeployment) SupportsSessions() bool { return false }
+
+func (d *fakeDeployment) Timeout() time.Duration { return d.timeout }
+
+var errFakeConnection = errors.New("no connection")
+
+type fakeServer struct{}
+
+func (fakeServer) Connection(context.Context) (connection.Connection, error) {
+	return nil, errFakeConnection
+}
+
+func (fakeServer) Description() description.SelectedServer {
+	return description.SelectedServer{Kind: description.Single}
+}
+
+func (fakeServer) BuildCursor(context.Context, bson.Reader, *session.Client, *session.ClusterClock, ...option.CursorOptioner) (command.Cursor, error) {
+	return nil, errors.New("no cursor")
+}
+
+func TestDispatchDeployment(t *testing.T) {
+	ns := command.Namespace{DB: "db", Collection: "coll"}
+
+	t.Run("runs against the selected server", func(t *testing.T) {
+		d := &fakeDeployment{}
+		_, err := Find(context.Background(), command.Find{NS: ns}, d, description.WriteSelector(), uuid.UUID{}, nil)
+		require.Equal(t, errFakeConnection, err)
+		require.Equal(t, 1, d.selected)
+		require.False(t, d.deadline)
+	})
+	t.Run("applies the deployment timeout", func(t *testing.T) {
+		d := &fakeDeployment{timeout: time.Minute}
+		_, err := Find(context.Background(), command.Find{NS: ns}, d, description.WriteSelector(), uuid.UUID{}, nil)
+		require.Equal(t, errFakeConnection, err)
+		require.True(t, d.deadline)
+	})
+}
+
+// namedSelector is a server selector told apart from others by its name.
+type namedSelector string
+
+func (namedSelector) SelectServer(_ description.Topology, servers []description.Server) ([]description.Server, error) {
+	return servers, nil
+}
+
+func TestDispatchAggregateSelector(t *testing.T) {
+	ns := command.Namespace{DB: "db", Collection: "coll"}
+	readSelector, writeSelector := namedSelector("read"), namedSelector("write")
+	stage := func(elems ...*bson.Element) *bson.Value { return bson.VC.DocumentFromElements(elems...) }
+	target := func(key string) *bson.Element {
+		return bson.EC.SubDocumentFromElements(key, bson.EC.String("db", "otherdb"), bson.EC.String("coll", "other"))
+	}
+
+	testCases := []struct {
+		name     string
+		stage    *bson.Value
+		selector description.ServerSelector
+	}{
+		{"no output stage", stage(bson.EC.SubDocument("$match", bson.NewDocument())), readSelector},
+		{"$out string", stage(bson.EC.String("$out", "other")), writeSelector},
+		{"$out document", stage(target("$out")), writeSelector},
+		{"$merge into another database", stage(bson.EC.SubDocumentFromElements("$merge", target("into"))), writeSelector},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &fakeDeployment{}
+			cmd := command.Aggregate{NS: ns, Pipeline: bson.NewArray(tc.stage)}
+			_, err := Aggregate(context.Background(), cmd, d, readSelector, writeSelector, uuid.UUID{}, nil)
+			require.Equal(t, errFakeConnection, err)
+			require.Equal(t, tc.selector, d.selector)
+		})
+	}
+}
@@ -10,13 +10,12 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // Distinct handles the full cycle dispatch and execution of a distinct command against the provided
@@ -24,20 +23,23 @@ import (
 func Distinct(
 	ctx context.Context,
 	cmd command.Distinct,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
-) (result.Distinct, error) {
+) (_ result.Distinct, err error) {
 
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/dispatch.Distinct")
-	defer span.End()
+	ctx = observability.TagNamespace(ctx, cmd.NS.DB, cmd.NS.Collection, "distinct")
+	ctx, op := observability.StartOperation(ctx, "distinct", "mongo-go/core/dispatch.Distinct")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
+	span := op.Span()
 
 	span.Annotatef(nil, "Invoking topology.SelectServer")
 	ss, err := topo.SelectServer(ctx, selector)
 	span.Annotatef(nil, "Finished invoking topology.SelectServer")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return result.Distinct{}, err
 	}
 
@@ -46,7 +48,6 @@ func Distinct(
 	conn, err := ss.Connection(ctx)
 	span.Annotatef(nil, "Finished invoking ss.Connection")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return result.Distinct{}, err
 	}
 	defer conn.Close()
@@ -69,9 +70,6 @@ func Distinct(
 	span.Annotatef(nil, "Invoking cmd.RoundTrip")
 	di, err := cmd.RoundTrip(ctx, desc, conn)
 	span.Annotatef(nil, "Finished invoking cmd.RoundTrip")
-	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-	}
 	return di, err
 
 }
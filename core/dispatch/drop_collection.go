@@ -9,12 +9,13 @@ package dispatch
 import (
 	"context"
 
-	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // DropCollection handles the full cycle dispatch and execution of a dropCollection
@@ -22,20 +23,26 @@ import (
 func DropCollection(
 	ctx context.Context,
 	cmd command.DropCollection,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
-) (bson.Reader, error) {
+) (_ result.DropCollection, err error) {
+
+	ctx = observability.TagNamespace(ctx, cmd.DB, cmd.Collection, "drop")
+	ctx, op := observability.StartOperation(ctx, "drop_collection", "mongo-go/core/dispatch.DropCollection")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
 
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
-		return nil, err
+		return result.DropCollection{}, err
 	}
 
 	conn, err := ss.Connection(ctx)
 	if err != nil {
-		return nil, err
+		return result.DropCollection{}, err
 	}
 	defer conn.Close()
 
@@ -43,7 +50,7 @@ func DropCollection(
 	if cmd.Session == nil && topo.SupportsSessions() {
 		cmd.Session, err = session.NewClientSession(pool, clientID, session.Implicit)
 		if err != nil {
-			return nil, err
+			return result.DropCollection{}, err
 		}
 		defer cmd.Session.EndSession()
 	}
@@ -9,12 +9,13 @@ package dispatch
 import (
 	"context"
 
-	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // DropDatabase handles the full cycle dispatch and execution of a dropDatabase
@@ -22,20 +23,26 @@ import (
 func DropDatabase(
 	ctx context.Context,
 	cmd command.DropDatabase,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
-) (bson.Reader, error) {
+) (_ result.DropDatabase, err error) {
+
+	ctx = observability.TagNamespace(ctx, cmd.DB, "", "dropDatabase")
+	ctx, op := observability.StartOperation(ctx, "drop_database", "mongo-go/core/dispatch.DropDatabase")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
 
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
-		return nil, err
+		return result.DropDatabase{}, err
 	}
 
 	conn, err := ss.Connection(ctx)
 	if err != nil {
-		return nil, err
+		return result.DropDatabase{}, err
 	}
 	defer conn.Close()
 
@@ -43,7 +50,7 @@ func DropDatabase(
 	if cmd.Session == nil && topo.SupportsSessions() {
 		cmd.Session, err = session.NewClientSession(pool, clientID, session.Implicit)
 		if err != nil {
-			return nil, err
+			return result.DropDatabase{}, err
 		}
 		defer cmd.Session.EndSession()
 	}
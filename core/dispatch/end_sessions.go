@@ -10,14 +10,11 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/result"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 
 	"github.com/mongodb/mongo-go-driver/internal/observability"
-	"go.opencensus.io/stats"
-	"go.opencensus.io/tag"
-	"go.opencensus.io/trace"
 )
 
 // EndSessions handles the full cycle dispatch and execution of an endSessions command against the provided
@@ -25,36 +22,30 @@ import (
 func EndSessions(
 	ctx context.Context,
 	cmd command.EndSessions,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 ) ([]result.EndSessions, []error) {
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "command"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/dispatch.Command")
-	defer span.End()
+	ctx = observability.TagNamespace(ctx, "admin", "", "endSessions")
+	ctx, op := observability.StartOperation(ctx, "end_sessions", "mongo-go/core/dispatch.EndSessions")
+	defer op.End(nil)
 
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "topo_selectserver"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		op.Fail("topo_selectserver", err)
 		return nil, []error{err}
 	}
 
 	conn, err := ss.Connection(ctx)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "connection"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		op.Fail("connection", err)
 		return nil, []error{err}
 	}
+	defer conn.Close()
 
 	br, errs := cmd.RoundTrip(ctx, ss.Description(), conn)
 	if len(errs) != 0 {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "roundtrip"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: errs[0].Error()})
+		op.Fail("roundtrip", errs[0])
 	}
 	return br, errs
-
 }
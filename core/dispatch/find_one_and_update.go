@@ -10,14 +10,12 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
-	"github.com/mongodb/mongo-go-driver/core/writeconcern"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // FindOneAndUpdate handles the full cycle dispatch and execution of a FindOneAndUpdate command against the provided
@@ -25,21 +23,24 @@ import (
 func FindOneAndUpdate(
 	ctx context.Context,
 	cmd command.FindOneAndUpdate,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
 	retryWrite bool,
-) (result.FindAndModify, error) {
+) (_ result.FindAndModify, err error) {
 
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/dispatch.FindOneAndUpdate")
-	defer span.End()
+	ctx = observability.TagNamespace(ctx, cmd.NS.DB, cmd.NS.Collection, "findAndModify")
+	ctx, op := observability.StartOperation(ctx, "find_one_and_update", "mongo-go/core/dispatch.FindOneAndUpdate")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
+	span := op.Span()
 
 	span.Annotatef(nil, "Invoking topology.SelectServer")
 	ss, err := topo.SelectServer(ctx, selector)
 	span.Annotatef(nil, "Finished invoking topology.SelectServer")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return result.FindAndModify{}, err
 	}
 
@@ -57,24 +58,26 @@ func FindOneAndUpdate(
 		if cmd.Session != nil {
 			cmd.Session.RetryWrite = false // explicitly set to false to prevent encoding transaction number
 		}
-		return findOneAndUpdate(ctx, span, cmd, ss, nil)
+		return findOneAndUpdate(ctx, op, cmd, ss, nil)
 	}
 
 	cmd.Session.RetryWrite = retryWrite
 	cmd.Session.IncrementTxnNumber()
 
-	res, originalErr := findOneAndUpdate(ctx, span, cmd, ss, nil)
+	res, originalErr := findOneAndUpdate(ctx, op, cmd, ss, nil)
 
 	// Retry if appropriate
-	if cerr, ok := originalErr.(command.Error); ok && cerr.Retryable() {
+	if cerr, ok := originalErr.(command.Error); ok && cerr.Retryable() ||
+		res.WriteConcernError != nil && command.IsWriteConcernErrorRetryable(res.WriteConcernError) {
 		ss, err := topo.SelectServer(ctx, selector)
 
 		// Return original error if server selection fails or new server does not support retryable writes
 		if err != nil || !retrySupported(topo, ss.Description(), cmd.Session, cmd.WriteConcern) {
-			return result.FindAndModify{}, originalErr
+			return res, originalErr
 		}
 
-		return findOneAndUpdate(ctx, span, cmd, ss, cerr)
+		logRetry(ctx, topo, "findAndModify", originalErr, res.WriteConcernError)
+		return findOneAndUpdate(ctx, op, cmd, ss, cerr)
 	}
 
 	return res, originalErr
@@ -82,32 +85,30 @@ func FindOneAndUpdate(
 
 func findOneAndUpdate(
 	ctx context.Context,
-	span *trace.Span,
+	op *observability.Operation,
 	cmd command.FindOneAndUpdate,
-	ss *topology.SelectedServer,
+	ss deployment.Server,
 	oldErr error,
 ) (result.FindAndModify, error) {
+	span := op.Span()
 	desc := ss.Description()
 	span.Annotatef(nil, "Invoking ss.Connection")
 	conn, err := ss.Connection(ctx)
 	span.Annotatef(nil, "Finished invoking ss.Connection")
 	if err != nil {
 		if oldErr != nil {
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: oldErr.Error()})
 			return result.FindAndModify{}, oldErr
 		}
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return result.FindAndModify{}, err
 	}
 
-	if !writeconcern.AckWrite(cmd.WriteConcern) {
+	if !cmd.WriteConcern.Acknowledged() {
 		go func() {
 			defer func() { _ = recover() }()
 			defer conn.Close()
 
 			_, _ = cmd.RoundTrip(ctx, desc, conn)
 		}()
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: "Unackwnowledge write"})
 		return result.FindAndModify{}, command.ErrUnacknowledgedWrite
 	}
 	defer conn.Close()
@@ -115,8 +116,5 @@ func findOneAndUpdate(
 	span.Annotatef(nil, "Invoking cmd.RoundTrip")
 	fim, err := cmd.RoundTrip(ctx, desc, conn)
 	span.Annotatef(nil, "Finished invoking cmd.RoundTrip")
-	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-	}
 	return fim, err
 }
@@ -10,14 +10,12 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
-	"github.com/mongodb/mongo-go-driver/core/writeconcern"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // Insert handles the full cycle dispatch and execution of an insert command against the provided
@@ -25,21 +23,24 @@ import (
 func Insert(
 	ctx context.Context,
 	cmd command.Insert,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
 	retryWrite bool,
-) (result.Insert, error) {
+) (_ result.Insert, err error) {
 
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/dispatch.Insert")
-	defer span.End()
+	ctx = observability.TagNamespace(ctx, cmd.NS.DB, cmd.NS.Collection, "insert")
+	ctx, op := observability.StartOperation(ctx, "insert", "mongo-go/core/dispatch.Insert")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
+	span := op.Span()
 
 	span.Annotatef(nil, "Invoking topology.SelectServer")
 	ss, err := topo.SelectServer(ctx, selector)
 	span.Annotatef(nil, "Finished invoking topology.SelectServer")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return result.Insert{}, err
 	}
 
@@ -57,14 +58,14 @@ func Insert(
 		if cmd.Session != nil {
 			cmd.Session.RetryWrite = false // explicitly set to false to prevent encoding transaction number
 		}
-		return insert(ctx, span, cmd, ss, nil)
+		return insert(ctx, op, cmd, ss, nil)
 	}
 
 	// TODO figure out best place to put retry write.  Command shouldn't have to know about this field.
 	cmd.Session.RetryWrite = retryWrite
 	cmd.Session.IncrementTxnNumber()
 
-	res, originalErr := insert(ctx, span, cmd, ss, nil)
+	res, originalErr := insert(ctx, op, cmd, ss, nil)
 
 	// Retry if appropriate
 	if cerr, ok := originalErr.(command.Error); ok && cerr.Retryable() ||
@@ -76,7 +77,8 @@ func Insert(
 			return res, originalErr
 		}
 
-		return insert(ctx, span, cmd, ss, cerr)
+		logRetry(ctx, topo, "insert", originalErr, res.WriteConcernError)
+		return insert(ctx, op, cmd, ss, cerr)
 	}
 
 	return res, originalErr
@@ -84,11 +86,12 @@ func Insert(
 
 func insert(
 	ctx context.Context,
-	span *trace.Span,
+	op *observability.Operation,
 	cmd command.Insert,
-	ss *topology.SelectedServer,
+	ss deployment.Server,
 	oldErr error,
 ) (result.Insert, error) {
+	span := op.Span()
 	desc := ss.Description()
 	conn, err := ss.Connection(ctx)
 	if err != nil {
@@ -98,15 +101,13 @@ func insert(
 		return result.Insert{}, err
 	}
 
-	if !writeconcern.AckWrite(cmd.WriteConcern) {
+	if !cmd.WriteConcern.Acknowledged() {
 		go func() {
 			defer func() { _ = recover() }()
 			defer conn.Close()
 
 			_, _ = cmd.RoundTrip(ctx, desc, conn)
 		}()
-
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: "Unacknowledged write"})
 		return result.Insert{}, command.ErrUnacknowledgedWrite
 	}
 	defer conn.Close()
@@ -114,8 +115,5 @@ func insert(
 	span.Annotatef(nil, "Invoking command.RoundTrip")
 	ri, err := cmd.RoundTrip(ctx, desc, conn)
 	span.Annotatef(nil, "Finished invoking command.RoundTrip")
-	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-	}
 	return ri, err
 }
@@ -10,12 +10,11 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // ListCollections handles the full cycle dispatch and execution of a listCollections command against the provided
@@ -23,20 +22,23 @@ import (
 func ListCollections(
 	ctx context.Context,
 	cmd command.ListCollections,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
-) (command.Cursor, error) {
+) (_ command.Cursor, err error) {
 
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/dispatch.ListCollections")
-	defer span.End()
+	ctx = observability.TagNamespace(ctx, cmd.DB, "", "listCollections")
+	ctx, op := observability.StartOperation(ctx, "list_collections", "mongo-go/core/dispatch.ListCollections")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
+	span := op.Span()
 
 	span.Annotatef(nil, "Invoking topology.SelectServer")
 	ss, err := topo.SelectServer(ctx, selector)
 	span.Annotatef(nil, "Finished invoking topology.SelectServer")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return nil, err
 	}
 
@@ -44,7 +46,6 @@ func ListCollections(
 	conn, err := ss.Connection(ctx)
 	span.Annotatef(nil, "Finished invoking ss.Connection")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return nil, err
 	}
 	defer conn.Close()
@@ -66,8 +67,5 @@ func ListCollections(
 	span.Annotatef(nil, "Invoking cmd.RoundTrip")
 	cur, err := cmd.RoundTrip(ctx, ss.Description(), ss, conn)
 	span.Annotatef(nil, "Finished invoking cmd.RoundTrip")
-	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-	}
 	return cur, err
 }
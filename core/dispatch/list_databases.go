@@ -10,13 +10,12 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // ListDatabases handles the full cycle dispatch and execution of a listDatabases command against the provided
@@ -24,20 +23,23 @@ import (
 func ListDatabases(
 	ctx context.Context,
 	cmd command.ListDatabases,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
-) (result.ListDatabases, error) {
+) (_ result.ListDatabases, err error) {
 
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/dispatch.ListDatabases")
-	defer span.End()
+	ctx = observability.TagNamespace(ctx, "admin", "", "listDatabases")
+	ctx, op := observability.StartOperation(ctx, "list_databases", "mongo-go/core/dispatch.ListDatabases")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
+	span := op.Span()
 
 	span.Annotatef(nil, "Invoking topology.SelectServer")
 	ss, err := topo.SelectServer(ctx, selector)
 	span.Annotatef(nil, "Finished invoking topology.SelectServer")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return result.ListDatabases{}, err
 	}
 
@@ -45,7 +47,6 @@ func ListDatabases(
 	conn, err := ss.Connection(ctx)
 	span.Annotatef(nil, "Finished invoking ss.Connection")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return result.ListDatabases{}, err
 	}
 	defer conn.Close()
@@ -62,8 +63,5 @@ func ListDatabases(
 	span.Annotatef(nil, "Invoking cmd.RoundTrip")
 	cur, err := cmd.RoundTrip(ctx, ss.Description(), conn)
 	span.Annotatef(nil, "Finished invoking cmd.RoundTrip")
-	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-	}
 	return cur, err
 }
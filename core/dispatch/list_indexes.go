@@ -10,12 +10,11 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // ListIndexes handles the full cycle dispatch and execution of a listIndexes command against the provided
@@ -23,29 +22,37 @@ import (
 func ListIndexes(
 	ctx context.Context,
 	cmd command.ListIndexes,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
-) (command.Cursor, error) {
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/dispatch.ListeIndexes")
-	defer span.End()
+) (_ command.Cursor, err error) {
+	ctx = observability.TagNamespace(ctx, cmd.NS.DB, cmd.NS.Collection, "listIndexes")
+	ctx, op := observability.StartOperation(ctx, "list_indexes", "mongo-go/core/dispatch.ListIndexes")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
+	span := op.Span()
 
 	span.Annotatef(nil, "Invoking topology.SelectServer")
 	ss, err := topo.SelectServer(ctx, selector)
 	span.Annotatef(nil, "Finished invoking topology.SelectServer")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return nil, err
 	}
 
 	conn, err := ss.Connection(ctx)
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return nil, err
 	}
 	defer conn.Close()
 
+	rp, err := getReadPrefBasedOnTransaction(cmd.ReadPref, cmd.Session)
+	if err != nil {
+		return nil, err
+	}
+	cmd.ReadPref = rp
+
 	// If no explicit session and deployment supports sessions, start implicit session.
 	if cmd.Session == nil && topo.SupportsSessions() {
 		cmd.Session, err = session.NewClientSession(pool, clientID, session.Implicit)
@@ -57,8 +64,5 @@ func ListIndexes(
 	span.Annotatef(nil, "Invoking cmd.RoundTrip")
 	cur, err := cmd.RoundTrip(ctx, ss.Description(), ss, conn)
 	span.Annotatef(nil, "Finished invoking cmd.RoundTrip")
-	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-	}
 	return cur, err
 }
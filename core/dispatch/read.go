@@ -11,11 +11,12 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // Read handles the full cycle dispatch and execution of a read command against the provided
@@ -23,20 +24,41 @@ import (
 func Read(
 	ctx context.Context,
 	cmd command.Read,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
 ) (bson.Reader, error) {
+	reply, err := ReadWithInfo(ctx, cmd, topo, selector, clientID, pool)
+	return reply.Document, err
+}
+
+// ReadWithInfo is the same as Read, but returns the reply along with the server it came from, the
+// duration of the round trip and the request ID of the command. When the command fails with ok: 0,
+// the error is a command.Error holding the reply.
+func ReadWithInfo(
+	ctx context.Context,
+	cmd command.Read,
+	topo deployment.Deployment,
+	selector description.ServerSelector,
+	clientID uuid.UUID,
+	pool *session.Pool,
+) (_ command.Reply, err error) {
+
+	ctx = observability.TagNamespace(ctx, cmd.DB, "", commandName(cmd.Command))
+	ctx, op := observability.StartOperation(ctx, "read", "mongo-go/core/dispatch.Read")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
 
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
-		return nil, err
+		return command.Reply{}, err
 	}
 
 	conn, err := ss.Connection(ctx)
 	if err != nil {
-		return nil, err
+		return command.Reply{}, err
 	}
 	defer conn.Close()
 
@@ -46,7 +68,7 @@ func Read(
 		err = checkTransactionReadPref(cmd.ReadPref)
 
 		if err != nil {
-			return nil, err
+			return command.Reply{}, err
 		}
 	}
 
@@ -54,12 +76,12 @@ func Read(
 	if cmd.Session == nil && topo.SupportsSessions() {
 		cmd.Session, err = session.NewClientSession(pool, clientID, session.Implicit)
 		if err != nil {
-			return nil, err
+			return command.Reply{}, err
 		}
 		defer cmd.Session.EndSession()
 	}
 
-	return cmd.RoundTrip(ctx, ss.Description(), conn)
+	return cmd.RoundTripReply(ctx, ss.Description(), conn)
 }
 
 func getReadPrefBasedOnTransaction(current *readpref.ReadPref, sess *session.Client) (*readpref.ReadPref, error) {
@@ -83,3 +105,17 @@ func checkTransactionReadPref(pref *readpref.ReadPref) error {
 	}
 	return nil
 }
+
+// commandName returns the name of a generic command, which is the key of its first element.
+func commandName(cmd *bson.Document) string {
+	if cmd == nil {
+		return ""
+	}
+
+	elem, ok := cmd.ElementAtOK(0)
+	if !ok {
+		return ""
+	}
+
+	return elem.Key()
+}
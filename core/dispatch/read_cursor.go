@@ -0,0 +1,82 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dispatch
+
+import (
+	"context"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/uuid"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+)
+
+// ReadCursor handles the full cycle dispatch and execution of a read command that returns a
+// cursor against the provided topology. The cursor runs its getMores against the server that ran
+// the command. It returns command.ErrNoCursor if the reply has no cursor document.
+func ReadCursor(
+	ctx context.Context,
+	cmd command.Read,
+	topo deployment.Deployment,
+	selector description.ServerSelector,
+	clientID uuid.UUID,
+	pool *session.Pool,
+) (_ command.Cursor, err error) {
+
+	ctx = observability.TagNamespace(ctx, cmd.DB, "", commandName(cmd.Command))
+	ctx, op := observability.StartOperation(ctx, "read_cursor", "mongo-go/core/dispatch.ReadCursor")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
+
+	ss, err := topo.SelectServer(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := ss.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if cmd.Session != nil && cmd.Session.TransactionRunning() {
+		err = checkTransactionReadPref(cmd.ReadPref)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// If no explicit session and deployment supports sessions, start implicit session. The cursor
+	// ends it once it is exhausted or closed.
+	if cmd.Session == nil && topo.SupportsSessions() {
+		cmd.Session, err = session.NewClientSession(pool, clientID, session.Implicit)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err != nil {
+				cmd.Session.EndSession()
+			}
+		}()
+	}
+
+	rdr, err := cmd.RoundTrip(ctx, ss.Description(), conn)
+	if err != nil {
+		return nil, err
+	}
+
+	cur, err := rdr.Lookup("cursor")
+	if err != nil || cur.Value().Type() != bson.TypeEmbeddedDocument {
+		return nil, command.ErrNoCursor
+	}
+
+	return ss.BuildCursor(ctx, rdr, cmd.Session, cmd.Clock)
+}
@@ -10,17 +10,13 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
-	"github.com/mongodb/mongo-go-driver/core/writeconcern"
 
 	"github.com/mongodb/mongo-go-driver/internal/observability"
-	"go.opencensus.io/stats"
-	"go.opencensus.io/tag"
-	"go.opencensus.io/trace"
 )
 
 // Update handles the full cycle dispatch and execution of an update command against the provided
@@ -28,22 +24,22 @@ import (
 func Update(
 	ctx context.Context,
 	cmd command.Update,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
 	retryWrite bool,
-) (result.Update, error) {
+) (_ result.Update, err error) {
 
-	ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyMethod, "update"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/dispatch.Update")
-	defer span.End()
+	ctx = observability.TagNamespace(ctx, cmd.NS.DB, cmd.NS.Collection, "update")
+	ctx, op := observability.StartOperation(ctx, "update", "mongo-go/core/dispatch.Update")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
 
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "connect"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		op.Fail("connect", err)
 		return result.Update{}, err
 	}
 
@@ -61,13 +57,13 @@ func Update(
 		if cmd.Session != nil {
 			cmd.Session.RetryWrite = false // explicitly set to false to prevent encoding transaction number
 		}
-		return update(ctx, span, cmd, ss, nil)
+		return update(ctx, op, &cmd, ss, nil)
 	}
 
 	cmd.Session.RetryWrite = retryWrite
 	cmd.Session.IncrementTxnNumber()
 
-	res, originalErr := update(ctx, span, cmd, ss, nil)
+	res, originalErr := update(ctx, op, &cmd, ss, nil)
 
 	// Retry if appropriate
 	if cerr, ok := originalErr.(command.Error); ok && cerr.Retryable() ||
@@ -79,7 +75,8 @@ func Update(
 			return res, originalErr
 		}
 
-		return update(ctx, span, cmd, ss, cerr)
+		logRetry(ctx, topo, "update", originalErr, res.WriteConcernError)
+		return update(ctx, op, &cmd, ss, cerr)
 	}
 	return res, originalErr
 
@@ -87,38 +84,32 @@ func Update(
 
 func update(
 	ctx context.Context,
-	span *trace.Span,
-	cmd command.Update,
-	ss *topology.SelectedServer,
+	op *observability.Operation,
+	cmd *command.Update,
+	ss deployment.Server,
 	oldErr error,
 ) (result.Update, error) {
+	span := op.Span()
 	desc := ss.Description()
 
 	span.Annotatef(nil, "Starting ss.Connection")
 	conn, err := ss.Connection(ctx)
 	span.Annotatef(nil, "Finished invoking ss.Connection")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "connection"))
-		stats.Record(ctx, observability.MErrors.M(1))
+		op.Fail("connection", err)
 		if oldErr != nil {
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: oldErr.Error()})
 			return result.Update{}, oldErr
 		}
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return result.Update{}, err
 	}
 
-	if !writeconcern.AckWrite(cmd.WriteConcern) {
+	if !cmd.WriteConcern.Acknowledged() {
 		go func() {
 			defer func() { _ = recover() }()
 			defer conn.Close()
 
 			_, _ = cmd.RoundTrip(ctx, desc, conn)
 		}()
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "write"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: "Unacknowledged writes"})
-
 		return result.Update{}, command.ErrUnacknowledgedWrite
 	}
 	defer conn.Close()
@@ -127,11 +118,9 @@ func update(
 	ures, err := cmd.RoundTrip(ctx, desc, conn)
 	span.Annotatef(nil, "Finished invoking cmd.RoundTrip")
 	if err == nil {
-		stats.Record(ctx, observability.MUpdates.M(1))
+		observability.Record(ctx, observability.MUpdates.M(1))
 	} else {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "update"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		op.Fail("update", err)
 	}
 	return ures, err
 }
@@ -11,11 +11,15 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/csot"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/logger"
+	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/mongodb/mongo-go-driver/core/session"
-	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // Write handles the full cycle dispatch and execution of a write command against the provided
@@ -23,11 +27,17 @@ import (
 func Write(
 	ctx context.Context,
 	cmd command.Write,
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
-) (bson.Reader, error) {
+) (_ bson.Reader, err error) {
+
+	ctx = observability.TagNamespace(ctx, cmd.DB, "", commandName(cmd.Command))
+	ctx, op := observability.StartOperation(ctx, "write", "mongo-go/core/dispatch.Write")
+	defer func() { endOperation(op, err) }()
+	ctx, endTimeout := withTimeout(ctx, topo)
+	defer endTimeout(&err)
 
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
@@ -40,14 +50,13 @@ func Write(
 		return nil, err
 	}
 
-	if !writeconcern.AckWrite(cmd.WriteConcern) {
+	if !cmd.WriteConcern.Acknowledged() {
 		go func() {
 			defer func() { _ = recover() }()
 			defer conn.Close()
 
 			_, _ = cmd.RoundTrip(ctx, desc, conn)
 		}()
-
 		return nil, command.ErrUnacknowledgedWrite
 	}
 	defer conn.Close()
@@ -67,7 +76,7 @@ func Write(
 // Retryable writes are supported if the server supports sessions, the operation is not
 // within a transaction, and the write is acknowledged
 func retrySupported(
-	topo *topology.Topology,
+	topo deployment.Deployment,
 	desc description.SelectedServer,
 	sess *session.Client,
 	wc *writeconcern.WriteConcern,
@@ -75,5 +84,55 @@ func retrySupported(
 	return topo.SupportsSessions() &&
 		description.SessionsSupported(desc.WireVersion) &&
 		!(sess.TransactionInProgress() || sess.TransactionStarting()) &&
-		writeconcern.AckWrite(wc)
+		wc.Acknowledged()
+}
+
+// logRetry logs that the named command is being retried because it failed with err or with the
+// retryable write concern error wce, and counts the retry in the operation debug of ctx. Either
+// error may be nil.
+func logRetry(ctx context.Context, topo deployment.Deployment, commandName string, err error, wce *result.WriteConcernError) {
+	observability.DebugRetry(ctx)
+
+	l := deployment.Logger(topo)
+	if !l.Enabled(logger.LevelInfo, logger.ComponentRetry) {
+		return
+	}
+
+	kvs := []interface{}{"commandName", commandName}
+	if err != nil {
+		kvs = append(kvs, "error", err)
+	}
+	if wce != nil {
+		kvs = append(kvs, "writeConcernErrorCode", wce.Code, "writeConcernErrorMessage", wce.ErrMsg)
+	}
+
+	logger.Log(l, logger.LevelInfo, logger.ComponentRetry, "Retrying command", kvs...)
+}
+
+// endOperation ends the instrumentation of an operation. Unacknowledged writes are not recorded as
+// errors.
+func endOperation(op *observability.Operation, err error) {
+	if err == command.ErrUnacknowledgedWrite {
+		err = nil
+	}
+	op.End(err)
+}
+
+// withTimeout bounds ctx by the default operation timeout of topo, unless ctx already carries an
+// operation timeout. The returned function must be deferred with the error of the operation, which
+// it reports as a *csot.TimeoutError if the timeout caused it.
+func withTimeout(ctx context.Context, topo deployment.Deployment) (context.Context, func(*error)) {
+	cancel := context.CancelFunc(func() {})
+	if _, ok := csot.Timeout(ctx); !ok && deployment.Timeout(topo) > 0 {
+		ctx, cancel = csot.WithTimeout(ctx, deployment.Timeout(topo))
+	}
+
+	return ctx, func(err *error) {
+		*err = csot.Wrap(ctx, *err)
+
+		// an unacknowledged write is still being sent with ctx, which expires with the timeout
+		if *err != command.ErrUnacknowledgedWrite {
+			cancel()
+		}
+	}
 }
@@ -69,3 +69,30 @@ type CommandMonitor struct {
 	Succeeded func(context.Context, *CommandSucceededEvent)
 	Failed    func(context.Context, *CommandFailedEvent)
 }
+
+// The types of PoolEvent.
+const (
+	// ConnectionCheckOutFailed is the type of the event generated when a connection cannot be
+	// checked out of a pool.
+	ConnectionCheckOutFailed = "ConnectionCheckOutFailed"
+)
+
+// The reasons a connection cannot be checked out of a pool, reported by ConnectionCheckOutFailed
+// events.
+const (
+	ReasonPoolClosed      = "poolClosed"
+	ReasonTimeout         = "timeout"
+	ReasonConnectionError = "connectionError"
+)
+
+// PoolEvent represents an event generated by the connection pool of a server.
+type PoolEvent struct {
+	Type    string
+	Address string
+	Reason  string
+}
+
+// PoolMonitor represents a monitor that is triggered for connection pool events.
+type PoolMonitor struct {
+	Event func(*PoolEvent)
+}
@@ -0,0 +1,126 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package event
+
+import (
+	"github.com/mongodb/mongo-go-driver/bson"
+)
+
+// RedactedValue replaces the values of the documents redacted by RedactCommand and RedactReply
+// when document values are redacted.
+const RedactedValue = "REDACTED"
+
+// sensitiveCommands are the commands carrying credentials.
+var sensitiveCommands = map[string]struct{}{
+	"authenticate":    {},
+	"saslStart":       {},
+	"saslContinue":    {},
+	"getnonce":        {},
+	"createUser":      {},
+	"updateUser":      {},
+	"copydbgetnonce":  {},
+	"copydbsaslstart": {},
+	"copydb":          {},
+}
+
+// IsSensitiveCommand returns whether the command named name carries credentials, like the commands
+// of the authentication conversations and those creating or updating users. The command documents
+// and replies of sensitive commands must never be attached to events, spans or log messages.
+func IsSensitiveCommand(name string) bool {
+	_, ok := sensitiveCommands[name]
+	return ok
+}
+
+// isSensitiveHandshake returns whether doc is the command or reply of a handshake named name that
+// carries a speculative authentication conversation.
+func isSensitiveHandshake(name string, doc *bson.Document) bool {
+	if name != "isMaster" && name != "ismaster" && name != "hello" {
+		return false
+	}
+	_, err := doc.LookupErr("speculativeAuthenticate")
+	return err == nil
+}
+
+// RedactCommand returns the document of the command named name to attach to events, spans and log
+// messages in place of cmd. The document of a sensitive command is empty. Otherwise, if values is
+// set, it is a copy of cmd keeping every key, nested ones included, with the values replaced by
+// RedactedValue, except for the command name and the database; if values is not set, it is cmd.
+func RedactCommand(name string, cmd *bson.Document, values bool) *bson.Document {
+	if IsSensitiveCommand(name) || isSensitiveHandshake(name, cmd) {
+		return bson.NewDocument()
+	}
+	if !values {
+		return cmd
+	}
+
+	return redactDocument(cmd, func(i int, key string) bool { return i == 0 || key == "$db" })
+}
+
+// RedactReply returns the reply to the command named name to attach to events, spans and log
+// messages in place of reply. The reply to a sensitive command is empty. Otherwise, if values is
+// set, it is a copy of reply keeping every key, nested ones included, with the values replaced by
+// RedactedValue, except for the ok, code and codeName fields; if values is not set, it is reply.
+func RedactReply(name string, reply *bson.Document, values bool) *bson.Document {
+	if IsSensitiveCommand(name) || isSensitiveHandshake(name, reply) {
+		return bson.NewDocument()
+	}
+	if !values {
+		return reply
+	}
+
+	return redactDocument(reply, func(_ int, key string) bool {
+		return key == "ok" || key == "code" || key == "codeName"
+	})
+}
+
+// RedactFailure returns the error message of a failed command to attach to events, spans and log
+// messages in place of errmsg. If values is set, the message is RedactedValue, as it may quote the
+// values of the command.
+func RedactFailure(errmsg string, values bool) string {
+	if values {
+		return RedactedValue
+	}
+	return errmsg
+}
+
+// redactDocument returns a copy of doc with every value replaced by RedactedValue, except for those
+// of the top-level elements for which keep returns true.
+func redactDocument(doc *bson.Document, keep func(i int, key string) bool) *bson.Document {
+	redacted := bson.NewDocumentSize(doc.Len())
+	iter := doc.Iterator()
+	for i := 0; iter.Next(); i++ {
+		elem := iter.Element()
+		if keep != nil && keep(i, elem.Key()) {
+			redacted.Append(elem.Clone())
+			continue
+		}
+		redacted.Append(bson.EC.Interface(elem.Key(), redactValue(elem.Value())))
+	}
+	return redacted
+}
+
+// redactValue returns v with every value it holds replaced by RedactedValue. The keys of documents
+// and the lengths of arrays are kept.
+func redactValue(v *bson.Value) *bson.Value {
+	switch v.Type() {
+	case bson.TypeEmbeddedDocument:
+		return bson.VC.Document(redactDocument(v.MutableDocument(), nil))
+	case bson.TypeArray:
+		arr := v.MutableArray()
+		redacted := bson.NewArraySize(arr.Len())
+		for i := 0; i < arr.Len(); i++ {
+			elem, err := arr.Lookup(uint(i))
+			if err != nil {
+				continue
+			}
+			redacted.Append(redactValue(elem))
+		}
+		return bson.VC.Array(redacted)
+	default:
+		return bson.VC.String(RedactedValue)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package event
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	find := bson.NewDocument(
+		bson.EC.String("find", "coll"),
+		bson.EC.SubDocumentFromElements("filter",
+			bson.EC.String("name", "Ada"),
+			bson.EC.SubDocumentFromElements("age", bson.EC.Int32("$gt", 30)),
+		),
+		bson.EC.ArrayFromElements("projection", bson.VC.String("name"), bson.VC.Int32(1)),
+		bson.EC.String("$db", "db"),
+	)
+
+	t.Run("sensitive commands", func(t *testing.T) {
+		for _, name := range []string{"saslStart", "saslContinue", "authenticate", "getnonce", "createUser", "updateUser"} {
+			cmd := bson.NewDocument(bson.EC.Int32(name, 1), bson.EC.String("pwd", "secret"))
+			require.Equal(t, 0, RedactCommand(name, cmd, false).Len(), name)
+			require.Equal(t, 0, RedactReply(name, bson.NewDocument(bson.EC.Int32("ok", 1)), false).Len(), name)
+		}
+	})
+	t.Run("speculative authentication", func(t *testing.T) {
+		hello := bson.NewDocument(
+			bson.EC.Int32("hello", 1),
+			bson.EC.SubDocumentFromElements("speculativeAuthenticate", bson.EC.Int32("saslStart", 1)),
+		)
+		require.Equal(t, 0, RedactCommand("hello", hello, false).Len())
+		require.Equal(t, 0, RedactReply("hello", hello, false).Len())
+
+		plain := bson.NewDocument(bson.EC.Int32("isMaster", 1))
+		require.Equal(t, plain, RedactCommand("isMaster", plain, false))
+	})
+	t.Run("values kept", func(t *testing.T) {
+		require.Equal(t, find, RedactCommand("find", find, false))
+		require.Equal(t, "bad", RedactFailure("bad", false))
+	})
+	t.Run("values redacted", func(t *testing.T) {
+		want := bson.NewDocument(
+			bson.EC.String("find", "coll"),
+			bson.EC.SubDocumentFromElements("filter",
+				bson.EC.String("name", RedactedValue),
+				bson.EC.SubDocumentFromElements("age", bson.EC.String("$gt", RedactedValue)),
+			),
+			bson.EC.ArrayFromElements("projection", bson.VC.String(RedactedValue), bson.VC.String(RedactedValue)),
+			bson.EC.String("$db", "db"),
+		)
+		require.True(t, want.Equal(RedactCommand("find", find, true)), RedactCommand("find", find, true).ToExtJSON(false))
+		require.Equal(t, "Ada", find.Lookup("filter", "name").StringValue(), "the command must not be modified")
+
+		reply := bson.NewDocument(
+			bson.EC.Int32("ok", 0),
+			bson.EC.String("errmsg", "duplicate key: Ada"),
+			bson.EC.Int32("code", 11000),
+			bson.EC.String("codeName", "DuplicateKey"),
+		)
+		want = bson.NewDocument(
+			bson.EC.Int32("ok", 0),
+			bson.EC.String("errmsg", RedactedValue),
+			bson.EC.Int32("code", 11000),
+			bson.EC.String("codeName", "DuplicateKey"),
+		)
+		require.True(t, want.Equal(RedactReply("find", reply, true)), RedactReply("find", reply, true).ToExtJSON(false))
+		require.Equal(t, RedactedValue, RedactFailure("duplicate key: Ada", true))
+	})
+}
@@ -41,7 +41,7 @@ func TestCommandAggregate(t *testing.T) {
 		}
 	})
 	t.Run("Multiple Batches", func(t *testing.T) {
-		server, err := testutil.Topology(t).SelectServer(context.Background(), description.WriteSelector())
+		server, err := testutil.Topology(t).SelectServerLegacy(context.Background(), description.WriteSelector())
 		noerr(t, err)
 		conn, err := server.Connection(context.Background())
 		noerr(t, err)
@@ -93,7 +93,7 @@ func TestCommandAggregate(t *testing.T) {
 		}
 	})
 	t.Run("AllowDiskUse", func(t *testing.T) {
-		server, err := testutil.Topology(t).SelectServer(context.Background(), description.WriteSelector())
+		server, err := testutil.Topology(t).SelectServerLegacy(context.Background(), description.WriteSelector())
 		noerr(t, err)
 		conn, err := server.Connection(context.Background())
 		noerr(t, err)
@@ -166,7 +166,7 @@ func TestAggregatePassesMaxAwaitTimeMSThroughToGetMore(t *testing.T) {
 	dbName := fmt.Sprintf("mongo-go-driver-%d-agg", os.Getpid())
 	colName := testutil.ColName(t)
 
-	server, err := testutil.MonitoredTopology(t, dbName, monitor).SelectServer(context.Background(), description.WriteSelector())
+	server, err := testutil.MonitoredTopology(t, dbName, monitor).SelectServerLegacy(context.Background(), description.WriteSelector())
 	noerr(t, err)
 
 	versionCmd := bson.NewDocument(bson.EC.Int32("serverStatus", 1))
@@ -22,7 +22,7 @@ import (
 )
 
 func TestTailableCursorLoopsUntilDocsAvailable(t *testing.T) {
-	server, err := testutil.Topology(t).SelectServer(context.Background(), description.WriteSelector())
+	server, err := testutil.Topology(t).SelectServerLegacy(context.Background(), description.WriteSelector())
 	noerr(t, err)
 
 	// create capped collection
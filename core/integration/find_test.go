@@ -47,7 +47,7 @@ func TestFindPassesMaxAwaitTimeMSThroughToGetMore(t *testing.T) {
 	dbName := fmt.Sprintf("mongo-go-driver-%d-find", os.Getpid())
 	colName := testutil.ColName(t)
 
-	server, err := testutil.MonitoredTopology(t, dbName, monitor).SelectServer(context.Background(), description.WriteSelector())
+	server, err := testutil.MonitoredTopology(t, dbName, monitor).SelectServerLegacy(context.Background(), description.WriteSelector())
 	noerr(t, err)
 
 	// create capped collection
@@ -26,7 +26,7 @@ func TestCommandListCollections(t *testing.T) {
 		}
 	}
 	t.Run("InvalidDatabaseName", func(t *testing.T) {
-		server, err := testutil.Topology(t).SelectServer(context.Background(), description.WriteSelector())
+		server, err := testutil.Topology(t).SelectServerLegacy(context.Background(), description.WriteSelector())
 		noerr(t, err)
 		conn, err := server.Connection(context.Background())
 		noerr(t, err)
@@ -53,7 +53,7 @@ func TestCommandListCollections(t *testing.T) {
 		}
 	})
 	t.Run("SingleBatch", func(t *testing.T) {
-		server, err := testutil.Topology(t).SelectServer(context.Background(), description.WriteSelector())
+		server, err := testutil.Topology(t).SelectServerLegacy(context.Background(), description.WriteSelector())
 		noerr(t, err)
 		conn, err := server.Connection(context.Background())
 		noerr(t, err)
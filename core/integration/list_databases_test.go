@@ -25,7 +25,7 @@ func TestListDatabases(t *testing.T) {
 			t.FailNow()
 		}
 	}
-	server, err := testutil.Topology(t).SelectServer(context.Background(), description.WriteSelector())
+	server, err := testutil.Topology(t).SelectServerLegacy(context.Background(), description.WriteSelector())
 	noerr(t, err)
 	conn, err := server.Connection(context.Background())
 	noerr(t, err)
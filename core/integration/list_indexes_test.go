@@ -26,7 +26,7 @@ func TestCommandListIndexes(t *testing.T) {
 		}
 	}
 	t.Run("InvalidDatabaseName", func(t *testing.T) {
-		server, err := testutil.Topology(t).SelectServer(context.Background(), description.WriteSelector())
+		server, err := testutil.Topology(t).SelectServerLegacy(context.Background(), description.WriteSelector())
 		noerr(t, err)
 		conn, err := server.Connection(context.Background())
 		noerr(t, err)
@@ -55,7 +55,7 @@ func TestCommandListIndexes(t *testing.T) {
 		}
 	})
 	t.Run("InvalidCollectionName", func(t *testing.T) {
-		server, err := testutil.Topology(t).SelectServer(context.Background(), description.WriteSelector())
+		server, err := testutil.Topology(t).SelectServerLegacy(context.Background(), description.WriteSelector())
 		noerr(t, err)
 		conn, err := server.Connection(context.Background())
 		noerr(t, err)
@@ -84,7 +84,7 @@ func TestCommandListIndexes(t *testing.T) {
 		}
 	})
 	t.Run("SingleBatch", func(t *testing.T) {
-		server, err := testutil.Topology(t).SelectServer(context.Background(), description.WriteSelector())
+		server, err := testutil.Topology(t).SelectServerLegacy(context.Background(), description.WriteSelector())
 		noerr(t, err)
 		conn, err := server.Connection(context.Background())
 		noerr(t, err)
@@ -125,7 +125,7 @@ func TestCommandListIndexes(t *testing.T) {
 		}
 	})
 	t.Run("MultipleBatch", func(t *testing.T) {
-		server, err := testutil.Topology(t).SelectServer(context.Background(), description.WriteSelector())
+		server, err := testutil.Topology(t).SelectServerLegacy(context.Background(), description.WriteSelector())
 		noerr(t, err)
 		conn, err := server.Connection(context.Background())
 		noerr(t, err)
@@ -24,7 +24,7 @@ import (
 )
 
 func createServerConn(t *testing.T) (*topology.SelectedServer, connection.Connection) {
-	server, err := testutil.Topology(t).SelectServer(context.Background(), description.WriteSelector())
+	server, err := testutil.Topology(t).SelectServerLegacy(context.Background(), description.WriteSelector())
 	noerr(t, err)
 	conn, err := server.Connection(context.Background())
 	noerr(t, err)
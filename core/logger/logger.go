@@ -0,0 +1,108 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package logger defines the interface through which the driver logs what it is doing.
+//
+// The driver does not log by default. A Logger is configured on a client with the
+// clientopt.Logger option, or on a topology with topology.WithLogger, and is passed down to the
+// servers and connections the topology creates.
+//
+// Every message carries a "component" key identifying the part of the driver that logged it,
+// followed by key-value pairs describing the event.
+package logger
+
+// Level is the severity of a log message.
+type Level int
+
+// These constants are the supported levels, from the most to the least verbose.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String implements the fmt.Stringer interface.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Component is the part of the driver a log message comes from.
+type Component string
+
+// These constants are the components that log messages.
+const (
+	// ComponentConnection logs connections being created, failing and closing.
+	ComponentConnection Component = "connection"
+	// ComponentServerSelection logs server selection attempts and their outcome.
+	ComponentServerSelection Component = "serverSelection"
+	// ComponentTopology logs server and topology description changes and heartbeat failures.
+	ComponentTopology Component = "topology"
+	// ComponentCommand logs every command sent and its outcome at LevelDebug. The contents of
+	// authentication and other security sensitive commands are redacted.
+	ComponentCommand Component = "command"
+	// ComponentRetry logs operations being retried.
+	ComponentRetry Component = "retry"
+)
+
+// Logger receives the log messages of the driver. keysAndValues alternates keys, which are
+// strings, and values.
+type Logger interface {
+	// Enabled reports whether messages of the given level from the given component are logged.
+	// It is called before a message is built, so messages that are not logged cost nothing.
+	Enabled(level Level, component Component) bool
+
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// Nop is a Logger that discards every message. It is the default.
+var Nop Logger = nop{}
+
+type nop struct{}
+
+func (nop) Enabled(Level, Component) bool { return false }
+func (nop) Debug(string, ...interface{})  {}
+func (nop) Info(string, ...interface{})   {}
+func (nop) Warn(string, ...interface{})   {}
+func (nop) Error(string, ...interface{})  {}
+
+// Log logs msg from component at the given level if it is enabled. The component is added as the
+// first key-value pair. Callers whose key-value pairs are expensive to compute should check
+// Enabled first.
+func Log(l Logger, level Level, component Component, msg string, keysAndValues ...interface{}) {
+	if l == nil || !l.Enabled(level, component) {
+		return
+	}
+
+	kvs := make([]interface{}, 0, len(keysAndValues)+2)
+	kvs = append(kvs, "component", string(component))
+	kvs = append(kvs, keysAndValues...)
+
+	switch level {
+	case LevelDebug:
+		l.Debug(msg, kvs...)
+	case LevelInfo:
+		l.Info(msg, kvs...)
+	case LevelWarn:
+		l.Warn(msg, kvs...)
+	default:
+		l.Error(msg, kvs...)
+	}
+}
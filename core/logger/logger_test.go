@@ -0,0 +1,63 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package logger
+
+import (
+	"reflect"
+	"testing"
+)
+
+type entry struct {
+	level Level
+	msg   string
+	kvs   []interface{}
+}
+
+type recorder struct {
+	min     Level
+	entries []entry
+}
+
+func (r *recorder) Enabled(level Level, _ Component) bool { return level >= r.min }
+
+func (r *recorder) Debug(msg string, kvs ...interface{}) { r.record(LevelDebug, msg, kvs) }
+func (r *recorder) Info(msg string, kvs ...interface{})  { r.record(LevelInfo, msg, kvs) }
+func (r *recorder) Warn(msg string, kvs ...interface{})  { r.record(LevelWarn, msg, kvs) }
+func (r *recorder) Error(msg string, kvs ...interface{}) { r.record(LevelError, msg, kvs) }
+
+func (r *recorder) record(level Level, msg string, kvs []interface{}) {
+	r.entries = append(r.entries, entry{level: level, msg: msg, kvs: kvs})
+}
+
+func TestLog(t *testing.T) {
+	r := &recorder{min: LevelInfo}
+
+	Log(r, LevelDebug, ComponentConnection, "dropped", "key", "value")
+	Log(r, LevelInfo, ComponentConnection, "info", "key", "value")
+	Log(r, LevelWarn, ComponentRetry, "warn")
+	Log(r, LevelError, ComponentTopology, "error", "key", 1)
+
+	want := []entry{
+		{LevelInfo, "info", []interface{}{"component", "connection", "key", "value"}},
+		{LevelWarn, "warn", []interface{}{"component", "retry"}},
+		{LevelError, "error", []interface{}{"component", "topology", "key", 1}},
+	}
+	if !reflect.DeepEqual(r.entries, want) {
+		t.Errorf("Incorrect entries. got %v; want %v", r.entries, want)
+	}
+}
+
+func TestLogNop(t *testing.T) {
+	for _, level := range []Level{LevelDebug, LevelInfo, LevelWarn, LevelError} {
+		if Nop.Enabled(level, ComponentCommand) {
+			t.Errorf("Nop should not be enabled for %s", level)
+		}
+	}
+
+	// A nil Logger is treated as Nop.
+	Log(nil, LevelError, ComponentCommand, "error")
+}
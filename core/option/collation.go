@@ -6,7 +6,11 @@
 
 package option
 
-import "github.com/mongodb/mongo-go-driver/bson"
+import (
+	"fmt"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+)
 
 // Collation allows users to specify language-specific rules for string comparison, such as
 // rules for lettercase and accent marks.
@@ -54,3 +58,50 @@ func (co *Collation) toDocument() *bson.Document {
 func (co *Collation) MarshalBSONDocument() (*bson.Document, error) {
 	return co.toDocument(), nil
 }
+
+// MarshalBSON implements the bson.Marshaler interface, so that a Collation is marshaled with the
+// field names the server expects wherever it appears.
+func (co *Collation) MarshalBSON() ([]byte, error) {
+	return co.toDocument().MarshalBSON()
+}
+
+// UnmarshalBSON implements the bson.Unmarshaler interface. Fields the document does not contain
+// are reset to their zero values.
+func (co *Collation) UnmarshalBSON(b []byte) error {
+	*co = Collation{}
+	return bson.Reader(b).ForEach(func(key []byte, val bson.Value) error {
+		var err error
+		switch string(key) {
+		case "locale":
+			co.Locale, err = val.AsString()
+		case "caseLevel":
+			co.CaseLevel, err = collationBool(val)
+		case "caseFirst":
+			co.CaseFirst, err = val.AsString()
+		case "strength":
+			var strength int64
+			strength, err = val.AsInt64()
+			co.Strength = int(strength)
+		case "numericOrdering":
+			co.NumericOrdering, err = collationBool(val)
+		case "alternate":
+			co.Alternate, err = val.AsString()
+		case "maxVariable":
+			co.MaxVariable, err = val.AsString()
+		case "backwards":
+			co.Backwards, err = collationBool(val)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid collation field %s: %v", key, err)
+		}
+		return nil
+	})
+}
+
+func collationBool(val bson.Value) (bool, error) {
+	b, ok := val.BooleanOK()
+	if !ok {
+		return false, fmt.Errorf("expected a boolean, got %v", val.Type())
+	}
+	return b, nil
+}
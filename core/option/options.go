@@ -9,14 +9,21 @@ package option
 import (
 	"time"
 
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
 	"strconv"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
 )
 
+// ErrNaturalSortWithKeys is returned when a sort orders documents by $natural along with other
+// keys, which the server does not support.
+var ErrNaturalSortWithKeys = errors.New("a $natural sort cannot be combined with other sort keys")
+
 // Optioner is the interface implemented by types that can be used as options
 // to a command.
 type Optioner interface {
@@ -186,26 +193,40 @@ var (
 	_ AggregateOptioner         = (*OptComment)(nil)
 	_ AggregateOptioner         = (*OptMaxTime)(nil)
 	_ AggregateOptioner         = (*OptMaxAwaitTime)(nil)
+	_ AggregateOptioner         = (*OptReadConcern)(nil)
+	_ AggregateOptioner         = (*OptReadPreference)(nil)
 	_ CountOptioner             = (*OptCollation)(nil)
+	_ CountOptioner             = (*OptComment)(nil)
 	_ CountOptioner             = (*OptHint)(nil)
 	_ CountOptioner             = (*OptLimit)(nil)
 	_ CountOptioner             = (*OptMaxTime)(nil)
+	_ CountOptioner             = (*OptReadConcern)(nil)
+	_ CountOptioner             = (*OptReadPreference)(nil)
 	_ CountOptioner             = (*OptSkip)(nil)
 	_ CreateIndexesOptioner     = (*OptMaxTime)(nil)
 	_ CursorOptioner            = OptBatchSize(0)
+	_ CursorOptioner            = OptComment{}
 	_ CursorOptioner            = (*OptMaxAwaitTime)(nil)
+	_ CursorOptioner            = OptPrefetch(false)
 	_ DeleteOptioner            = (*OptCollation)(nil)
+	_ DeleteOptioner            = (*OptComment)(nil)
+	_ DeleteOptioner            = (*OptOrdered)(nil)
 	_ DistinctOptioner          = (*OptCollation)(nil)
 	_ DistinctOptioner          = (*OptMaxTime)(nil)
 	_ DistinctOptioner          = (*OptCollation)(nil)
+	_ DistinctOptioner          = (*OptComment)(nil)
 	_ DistinctOptioner          = (*OptMaxTime)(nil)
+	_ DistinctOptioner          = (*OptReadConcern)(nil)
+	_ DistinctOptioner          = (*OptReadPreference)(nil)
 	_ DropIndexesOptioner       = (*OptMaxTime)(nil)
 	_ FindOneAndDeleteOptioner  = (*OptCollation)(nil)
+	_ FindOneAndDeleteOptioner  = (*OptComment)(nil)
 	_ FindOneAndDeleteOptioner  = (*OptMaxTime)(nil)
 	_ FindOneAndDeleteOptioner  = (*OptProjection)(nil)
 	_ FindOneAndDeleteOptioner  = (*OptSort)(nil)
 	_ FindOneAndReplaceOptioner = (*OptBypassDocumentValidation)(nil)
 	_ FindOneAndReplaceOptioner = (*OptCollation)(nil)
+	_ FindOneAndReplaceOptioner = (*OptComment)(nil)
 	_ FindOneAndReplaceOptioner = (*OptMaxTime)(nil)
 	_ FindOneAndReplaceOptioner = (*OptProjection)(nil)
 	_ FindOneAndReplaceOptioner = (*OptReturnDocument)(nil)
@@ -214,6 +235,7 @@ var (
 	_ FindOneAndUpdateOptioner  = (*OptArrayFilters)(nil)
 	_ FindOneAndUpdateOptioner  = (*OptBypassDocumentValidation)(nil)
 	_ FindOneAndUpdateOptioner  = (*OptCollation)(nil)
+	_ FindOneAndUpdateOptioner  = (*OptComment)(nil)
 	_ FindOneAndUpdateOptioner  = (*OptMaxTime)(nil)
 	_ FindOneAndUpdateOptioner  = (*OptProjection)(nil)
 	_ FindOneAndUpdateOptioner  = (*OptReturnDocument)(nil)
@@ -225,6 +247,7 @@ var (
 	_ FindOptioner              = OptCursorType(0)
 	_ FindOptioner              = (*OptComment)(nil)
 	_ FindOptioner              = (*OptHint)(nil)
+	_ FindOptioner              = (*OptLet)(nil)
 	_ FindOptioner              = (*OptLimit)(nil)
 	_ FindOptioner              = (*OptMaxAwaitTime)(nil)
 	_ FindOptioner              = (*OptMaxScan)(nil)
@@ -232,7 +255,10 @@ var (
 	_ FindOptioner              = (*OptMin)(nil)
 	_ FindOptioner              = (*OptNoCursorTimeout)(nil)
 	_ FindOptioner              = (*OptOplogReplay)(nil)
+	_ FindOptioner              = OptPrefetch(false)
 	_ FindOptioner              = (*OptProjection)(nil)
+	_ FindOptioner              = (*OptReadConcern)(nil)
+	_ FindOptioner              = (*OptReadPreference)(nil)
 	_ FindOptioner              = (*OptReturnKey)(nil)
 	_ FindOptioner              = (*OptShowRecordID)(nil)
 	_ FindOptioner              = (*OptSkip)(nil)
@@ -244,6 +270,7 @@ var (
 	_ FindOneOptioner           = OptCursorType(0)
 	_ FindOneOptioner           = (*OptComment)(nil)
 	_ FindOneOptioner           = (*OptHint)(nil)
+	_ FindOneOptioner           = (*OptLet)(nil)
 	_ FindOneOptioner           = (*OptMaxAwaitTime)(nil)
 	_ FindOneOptioner           = (*OptMaxScan)(nil)
 	_ FindOneOptioner           = (*OptMaxTime)(nil)
@@ -251,16 +278,21 @@ var (
 	_ FindOneOptioner           = (*OptNoCursorTimeout)(nil)
 	_ FindOneOptioner           = (*OptOplogReplay)(nil)
 	_ FindOneOptioner           = (*OptProjection)(nil)
+	_ FindOneOptioner           = (*OptReadConcern)(nil)
+	_ FindOneOptioner           = (*OptReadPreference)(nil)
 	_ FindOneOptioner           = (*OptReturnKey)(nil)
 	_ FindOneOptioner           = (*OptShowRecordID)(nil)
 	_ FindOneOptioner           = (*OptSkip)(nil)
 	_ FindOneOptioner           = (*OptSnapshot)(nil)
 	_ FindOneOptioner           = (*OptSort)(nil)
 	_ InsertManyOptioner        = (*OptBypassDocumentValidation)(nil)
+	_ InsertManyOptioner        = (*OptComment)(nil)
 	_ InsertManyOptioner        = (*OptOrdered)(nil)
 	_ InsertOneOptioner         = (*OptBypassDocumentValidation)(nil)
 	_ InsertOptioner            = (*OptBypassDocumentValidation)(nil)
 	_ InsertOptioner            = (*OptOrdered)(nil)
+	_ InsertOneOptioner         = (*OptComment)(nil)
+	_ InsertOptioner            = (*OptComment)(nil)
 	_ InsertOneOptioner         = (*OptBypassDocumentValidation)(nil)
 	_ InsertOptioner            = (*OptBypassDocumentValidation)(nil)
 	_ InsertOptioner            = (*OptOrdered)(nil)
@@ -270,16 +302,21 @@ var (
 	_ ListIndexesOptioner       = (*OptMaxTime)(nil)
 	_ ReplaceOptioner           = (*OptBypassDocumentValidation)(nil)
 	_ ReplaceOptioner           = (*OptCollation)(nil)
+	_ ReplaceOptioner           = (*OptComment)(nil)
 	_ ReplaceOptioner           = (*OptUpsert)(nil)
 	_ UpdateOptioner            = (*OptUpsert)(nil)
 	_ UpdateOptioner            = (*OptArrayFilters)(nil)
 	_ UpdateOptioner            = (*OptBypassDocumentValidation)(nil)
 	_ UpdateOptioner            = (*OptCollation)(nil)
+	_ UpdateOptioner            = (*OptComment)(nil)
+	_ UpdateOptioner            = (*OptOrdered)(nil)
 	_ ChangeStreamOptioner      = (*OptBatchSize)(nil)
 	_ ChangeStreamOptioner      = (*OptCollation)(nil)
 	_ ChangeStreamOptioner      = (*OptFullDocument)(nil)
+	_ ChangeStreamOptioner      = (*OptFullDocumentBeforeChange)(nil)
 	_ ChangeStreamOptioner      = (*OptMaxAwaitTime)(nil)
 	_ ChangeStreamOptioner      = (*OptResumeAfter)(nil)
+	_ ChangeStreamOptioner      = (*OptShowExpandedEvents)(nil)
 )
 
 // OptAllowDiskUse is for internal use.
@@ -457,21 +494,42 @@ func (opt OptCollation) String() string {
 }
 
 // OptComment is for internal use.
-type OptComment string
+type OptComment struct {
+	Comment interface{}
+	// Default is set for the comment configured on a client, which is left out of the commands a
+	// server cannot accept it with instead of failing them.
+	Default bool
+}
 
 // Option implements the Optioner interface.
 func (opt OptComment) Option(d *bson.Document) error {
-	d.Append(bson.EC.String("comment", string(opt)))
+	elem, err := bson.EC.InterfaceErr("comment", opt.Comment)
+	if err != nil {
+		return err
+	}
+	d.Append(elem)
 	return nil
 }
 
-func (OptComment) aggregateOption() {}
-func (OptComment) findOption()      {}
-func (OptComment) findOneOption()   {}
+func (OptComment) aggregateOption()         {}
+func (OptComment) countOption()             {}
+func (OptComment) cursorOption()            {}
+func (OptComment) deleteOption()            {}
+func (OptComment) distinctOption()          {}
+func (OptComment) findOption()              {}
+func (OptComment) findOneOption()           {}
+func (OptComment) findOneAndDeleteOption()  {}
+func (OptComment) findOneAndReplaceOption() {}
+func (OptComment) findOneAndUpdateOption()  {}
+func (OptComment) insertManyOption()        {}
+func (OptComment) insertOption()            {}
+func (OptComment) insertOneOption()         {}
+func (OptComment) replaceOption()           {}
+func (OptComment) updateOption()            {}
 
 // String implements the Stringer interface.
 func (opt OptComment) String() string {
-	return "OptComment: " + string(opt)
+	return fmt.Sprintf("OptComment: %v", opt.Comment)
 }
 
 // OptCursorType is for internal use.
@@ -512,16 +570,39 @@ func (opt OptFullDocument) String() string {
 	return "OptFullDocument: " + string(opt)
 }
 
+// OptFullDocumentBeforeChange is for internal use.
+type OptFullDocumentBeforeChange string
+
+// Option implements the Optioner interface.
+func (opt OptFullDocumentBeforeChange) Option(d *bson.Document) error {
+	d.Append(bson.EC.String("fullDocumentBeforeChange", string(opt)))
+	return nil
+}
+
+func (OptFullDocumentBeforeChange) changeStreamOption() {}
+
+// String implements the Stringer interface.
+func (opt OptFullDocumentBeforeChange) String() string {
+	return "OptFullDocumentBeforeChange: " + string(opt)
+}
+
 // OptHint is for internal use.
 type OptHint struct{ Hint interface{} }
 
 // Option implements the Optioner interface.
 func (opt OptHint) Option(d *bson.Document) error {
 	switch t := (opt).Hint.(type) {
+	case nil:
 	case string:
 		d.Append(bson.EC.String("hint", t))
 	case *bson.Document:
 		d.Append(bson.EC.SubDocument("hint", t))
+	default:
+		doc, err := TransformDocument(t)
+		if err != nil {
+			return err
+		}
+		d.Append(bson.EC.SubDocument("hint", doc))
 	}
 	return nil
 }
@@ -536,6 +617,37 @@ func (opt OptHint) String() string {
 	return "OptHint"
 }
 
+// OptLet is for internal use.
+type OptLet struct {
+	Let interface{}
+}
+
+// Option implements the Optioner interface.
+func (opt OptLet) Option(d *bson.Document) error {
+	doc, err := TransformDocument(opt.Let)
+	if err != nil {
+		return err
+	}
+
+	d.Append(bson.EC.SubDocument("let", doc))
+	return nil
+}
+
+func (OptLet) aggregateOption()         {}
+func (OptLet) deleteOption()            {}
+func (OptLet) findOption()              {}
+func (OptLet) findOneOption()           {}
+func (OptLet) findOneAndDeleteOption()  {}
+func (OptLet) findOneAndReplaceOption() {}
+func (OptLet) findOneAndUpdateOption()  {}
+func (OptLet) replaceOption()           {}
+func (OptLet) updateOption()            {}
+
+// String implements the Stringer interface.
+func (opt OptLet) String() string {
+	return "OptLet"
+}
+
 // OptLimit is for internal use.
 type OptLimit int64
 
@@ -710,12 +822,31 @@ func (opt OptOrdered) Option(d *bson.Document) error {
 
 func (OptOrdered) insertManyOption() {}
 func (OptOrdered) insertOption()     {}
+func (OptOrdered) deleteOption()     {}
+func (OptOrdered) updateOption()     {}
 
 // String implements the Stringer interface.
 func (opt OptOrdered) String() string {
 	return "OptOrdered: " + strconv.FormatBool(bool(opt))
 }
 
+// OptPrefetch is for internal use.
+type OptPrefetch bool
+
+// Option implements the Optioner interface. Prefetching is done by the cursor, so it adds no
+// field here.
+func (opt OptPrefetch) Option(d *bson.Document) error {
+	return nil
+}
+
+func (OptPrefetch) cursorOption() {}
+func (OptPrefetch) findOption()   {}
+
+// String implements the Stringer interface.
+func (opt OptPrefetch) String() string {
+	return "OptPrefetch: " + strconv.FormatBool(bool(opt))
+}
+
 // OptProjection is for internal use.
 type OptProjection struct {
 	Projection interface{}
@@ -771,6 +902,53 @@ func (opt OptFields) String() string {
 	return "OptFields"
 }
 
+// OptReadConcern is for internal use.
+type OptReadConcern struct{ ReadConcern *readconcern.ReadConcern }
+
+// Option implements the Optioner interface. The read concern is merged with the session fields,
+// such as afterClusterTime, when the command is encoded.
+func (opt OptReadConcern) Option(d *bson.Document) error {
+	elem, err := opt.ReadConcern.MarshalBSONElement()
+	if err != nil {
+		return err
+	}
+
+	d.Delete(elem.Key())
+	d.Append(elem)
+	return nil
+}
+
+func (OptReadConcern) aggregateOption() {}
+func (OptReadConcern) countOption()     {}
+func (OptReadConcern) distinctOption()  {}
+func (OptReadConcern) findOption()      {}
+func (OptReadConcern) findOneOption()   {}
+
+// String implements the Stringer interface.
+func (opt OptReadConcern) String() string {
+	return "OptReadConcern: " + opt.ReadConcern.GetLevel()
+}
+
+// OptReadPreference is for internal use.
+type OptReadPreference struct{ ReadPreference *readpref.ReadPref }
+
+// Option implements the Optioner interface. The read preference is applied when the server the
+// command is sent to is selected, and by the command itself, so it adds no field here.
+func (opt OptReadPreference) Option(d *bson.Document) error {
+	return nil
+}
+
+func (OptReadPreference) aggregateOption() {}
+func (OptReadPreference) countOption()     {}
+func (OptReadPreference) distinctOption()  {}
+func (OptReadPreference) findOption()      {}
+func (OptReadPreference) findOneOption()   {}
+
+// String implements the Stringer interface.
+func (opt OptReadPreference) String() string {
+	return "OptReadPreference"
+}
+
 // OptResumeAfter is for internal use.
 type OptResumeAfter struct{ ResumeAfter *bson.Document }
 
@@ -823,6 +1001,22 @@ func (opt OptReturnKey) String() string {
 	return "OptReturnKey: " + strconv.FormatBool(bool(opt))
 }
 
+// OptShowExpandedEvents is for internal use.
+type OptShowExpandedEvents bool
+
+// Option implements the Optioner interface.
+func (opt OptShowExpandedEvents) Option(d *bson.Document) error {
+	d.Append(bson.EC.Boolean("showExpandedEvents", bool(opt)))
+	return nil
+}
+
+func (OptShowExpandedEvents) changeStreamOption() {}
+
+// String implements the Stringer interface.
+func (opt OptShowExpandedEvents) String() string {
+	return "OptShowExpandedEvents: " + strconv.FormatBool(bool(opt))
+}
+
 // OptShowRecordID is for internal use.
 type OptShowRecordID bool
 
@@ -886,6 +1080,9 @@ func (opt OptSort) Option(d *bson.Document) error {
 	if err != nil {
 		return err
 	}
+	if doc.Len() > 1 && doc.LookupElement("$natural") != nil {
+		return ErrNaturalSortWithKeys
+	}
 
 	d.Append(bson.EC.SubDocument("sort", doc))
 	return nil
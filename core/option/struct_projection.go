@@ -0,0 +1,130 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package option
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+)
+
+var (
+	tUnmarshaler         = reflect.TypeOf((*bson.Unmarshaler)(nil)).Elem()
+	tDocumentUnmarshaler = reflect.TypeOf((*bson.DocumentUnmarshaler)(nil)).Elem()
+)
+
+// StructProjection is a projection that includes exactly the fields a value of the struct type of
+// Value is decoded from. Its fields are named by the bson struct tags of the type, as parsed by the
+// decoder: fields tagged "-" are skipped, inline structs contribute their own fields, and the fields
+// of nested structs are included with dotted paths. The _id field is excluded unless the struct
+// decodes it.
+type StructProjection struct {
+	Value interface{}
+}
+
+// MarshalBSONDocument implements the bson.DocumentMarshaler interface.
+func (sp StructProjection) MarshalBSONDocument() (*bson.Document, error) {
+	t := reflect.TypeOf(sp.Value)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot derive a projection from type %v, a struct is required", reflect.TypeOf(sp.Value))
+	}
+
+	var fields []string
+	if !projectFields(t, "", map[reflect.Type]bool{}, &fields) {
+		// the struct captures every field of the document in an inline map
+		return bson.NewDocument(), nil
+	}
+
+	doc := bson.NewDocument()
+	hasID := false
+	for _, field := range fields {
+		hasID = hasID || field == "_id"
+		doc.Append(bson.EC.Int32(field, 1))
+	}
+	if !hasID {
+		doc.Append(bson.EC.Int32("_id", 0))
+	}
+	return doc, nil
+}
+
+// projectFields appends the paths of the fields of t to fields, prefixing each with prefix. It
+// returns false if t has an inline map, in which case every field of the document is needed to
+// decode it. seen holds the struct types being visited to stop recursive types.
+func projectFields(t reflect.Type, prefix string, seen map[reflect.Type]bool, fields *[]string) bool {
+	seen[t] = true
+	defer delete(seen, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		tags, err := bson.DefaultStructTagParser(sf)
+		if err != nil || tags.Skip {
+			continue
+		}
+
+		if tags.Inline {
+			switch sf.Type.Kind() {
+			case reflect.Map:
+				return false
+			case reflect.Struct:
+				if !projectFields(sf.Type, prefix, seen, fields) {
+					return false
+				}
+				continue
+			}
+		}
+
+		path := prefix + tags.Name
+		nested := sf.Type
+		for nested.Kind() == reflect.Ptr {
+			nested = nested.Elem()
+		}
+		if nested.Kind() == reflect.Slice || nested.Kind() == reflect.Array {
+			nested = nested.Elem()
+			for nested.Kind() == reflect.Ptr {
+				nested = nested.Elem()
+			}
+		}
+		if isSubdocument(nested) && !seen[nested] {
+			var sub []string
+			if projectFields(nested, path+".", seen, &sub) && len(sub) > 0 {
+				*fields = append(*fields, sub...)
+				continue
+			}
+		}
+		*fields = append(*fields, path)
+	}
+	return true
+}
+
+// isSubdocument returns true if values of t are decoded field by field from a subdocument, as
+// opposed to being a struct the driver or the type itself decodes as a single value.
+func isSubdocument(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	if reflect.PtrTo(t).Implements(tUnmarshaler) || reflect.PtrTo(t).Implements(tDocumentUnmarshaler) {
+		return false
+	}
+
+	pkg := t.PkgPath()
+	switch {
+	case pkg == "time", pkg == "net/url":
+		return false
+	case strings.HasPrefix(pkg, "github.com/mongodb/mongo-go-driver/bson"):
+		return false
+	}
+	return true
+}
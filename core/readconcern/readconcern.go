@@ -62,12 +62,21 @@ func New(options ...Option) *ReadConcern {
 	return concern
 }
 
+// GetLevel returns the level of the read concern, or the empty string if the server default is
+// used.
+func (rc *ReadConcern) GetLevel() string {
+	if rc == nil {
+		return ""
+	}
+	return rc.level
+}
+
 // MarshalBSONElement implements the bson.ElementMarshaler interface.
 func (rc *ReadConcern) MarshalBSONElement() (*bson.Element, error) {
 	doc := bson.NewDocument()
 
-	if len(rc.level) > 0 {
-		doc.Append(bson.EC.String("level", rc.level))
+	if level := rc.GetLevel(); len(level) > 0 {
+		doc.Append(bson.EC.String("level", level))
 	}
 
 	return bson.EC.SubDocument("readConcern", doc), nil
@@ -41,12 +41,19 @@ type Update struct {
 	MatchedCount  int64 `bson:"n"`
 	ModifiedCount int64 `bson:"nModified"`
 	Upserted      []struct {
-		ID interface{} `bson:"_id"`
+		Index int64       `bson:"index"`
+		ID    interface{} `bson:"_id"`
 	} `bson:"upserted"`
 	WriteErrors       []WriteError       `bson:"writeErrors"`
 	WriteConcernError *WriteConcernError `bson:"writeConcernError"`
 }
 
+// Count is a result from a count command.
+type Count struct {
+	// N is the number of documents counted.
+	N int64
+}
+
 // Distinct is a result from a Distinct command.
 type Distinct struct {
 	Values []interface{}
@@ -59,6 +66,7 @@ type FindAndModify struct {
 		UpdatedExisting bool
 		Upserted        interface{}
 	}
+	WriteConcernError *WriteConcernError `bson:"writeConcernError"`
 }
 
 // WriteError is an error from a write operation that is not a write concern
@@ -69,11 +77,42 @@ type WriteError struct {
 	ErrMsg string
 }
 
-// WriteConcernError is an error related to a write concern.
+// WriteConcernError is an error related to a write concern. ErrInfo holds the
+// raw errInfo document sent by the server, which describes why the write concern
+// could not be satisfied.
 type WriteConcernError struct {
-	Code    int
-	ErrMsg  string
-	ErrInfo bson.Reader
+	Code    int         `bson:"code"`
+	ErrMsg  string      `bson:"errmsg"`
+	ErrInfo bson.Reader `bson:"errInfo"`
+}
+
+// DropIndexes is a result of a dropIndexes command. Raw holds the reply it is decoded from.
+type DropIndexes struct {
+	NIndexesWas int32       `bson:"nIndexesWas"`
+	Raw         bson.Reader `bson:"-"`
+}
+
+// CollMod is a result of a collMod command. The old and new values of the properties of an index
+// are only reported for the properties that were modified. Raw holds the reply it is decoded from.
+type CollMod struct {
+	ExpireAfterSecondsOld *int64      `bson:"expireAfterSeconds_old"`
+	ExpireAfterSecondsNew *int64      `bson:"expireAfterSeconds_new"`
+	HiddenOld             *bool       `bson:"hidden_old"`
+	HiddenNew             *bool       `bson:"hidden_new"`
+	Raw                   bson.Reader `bson:"-"`
+}
+
+// DropCollection is a result of a drop command. Raw holds the reply it is decoded from.
+type DropCollection struct {
+	NS          string      `bson:"ns"`
+	NIndexesWas int32       `bson:"nIndexesWas"`
+	Raw         bson.Reader `bson:"-"`
+}
+
+// DropDatabase is a result of a dropDatabase command. Raw holds the reply it is decoded from.
+type DropDatabase struct {
+	Dropped string      `bson:"dropped"`
+	Raw     bson.Reader `bson:"-"`
 }
 
 // ListDatabases is the result from a listDatabases command.
@@ -147,9 +186,10 @@ type KillCursors struct {
 
 // CreateIndexes is a result of a CreateIndexes command.
 type CreateIndexes struct {
-	CreatedCollectionAutomatically bool `bson:"createdCollectionAutomatically"`
-	IndexesBefore                  int  `bson:"numIndexesBefore"`
-	IndexesAfter                   int  `bson:"numIndexesAfter"`
+	CreatedCollectionAutomatically bool               `bson:"createdCollectionAutomatically"`
+	IndexesBefore                  int                `bson:"numIndexesBefore"`
+	IndexesAfter                   int                `bson:"numIndexesAfter"`
+	WriteConcernError              *WriteConcernError `bson:"writeConcernError"`
 }
 
 // TransactionResult holds the result of committing or aborting a transaction.
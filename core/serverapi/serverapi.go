@@ -0,0 +1,70 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package serverapi declares the version of the server API, also known as the Stable API, that
+// commands are written against. Servers starting with MongoDB 5.0 reject commands and behaviors
+// that are not part of a declared API version when it is declared as strict.
+package serverapi
+
+import "github.com/mongodb/mongo-go-driver/bson"
+
+// Version1 is version 1 of the server API.
+const Version1 = "1"
+
+// Options are the server API options sent with every command.
+type Options struct {
+	version           string
+	strict            *bool
+	deprecationErrors *bool
+}
+
+// Option is an option to provide when creating server API Options.
+type Option func(*Options)
+
+// New constructs server API options declaring the given version.
+func New(version string, options ...Option) *Options {
+	opts := &Options{version: version}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts
+}
+
+// Strict requests that the server rejects commands that are not part of the declared API version.
+func Strict(strict bool) Option {
+	return func(opts *Options) {
+		opts.strict = &strict
+	}
+}
+
+// DeprecationErrors requests that the server returns errors for commands that are deprecated in
+// the declared API version.
+func DeprecationErrors(deprecationErrors bool) Option {
+	return func(opts *Options) {
+		opts.deprecationErrors = &deprecationErrors
+	}
+}
+
+// Version returns the declared API version.
+func (opts *Options) Version() string {
+	return opts.version
+}
+
+// Elements returns the elements to add to a command document. Strict and DeprecationErrors are
+// only included if they were set.
+func (opts *Options) Elements() []*bson.Element {
+	elems := []*bson.Element{bson.EC.String("apiVersion", opts.version)}
+	if opts.strict != nil {
+		elems = append(elems, bson.EC.Boolean("apiStrict", *opts.strict))
+	}
+	if opts.deprecationErrors != nil {
+		elems = append(elems, bson.EC.Boolean("apiDeprecationErrors", *opts.deprecationErrors))
+	}
+
+	return elems
+}
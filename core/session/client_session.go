@@ -7,6 +7,7 @@
 package session
 
 import (
+	"context"
 	"errors"
 
 	"github.com/mongodb/mongo-go-driver/bson"
@@ -14,6 +15,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // ErrSessionEnded is returned when a client session is used after a call to endSession().
@@ -97,12 +99,18 @@ func getClusterTime(clusterTime *bson.Document) (uint32, uint32) {
 		return 0, 0
 	}
 
-	timestampVal, err := clusterTimeVal.MutableDocument().LookupErr("clusterTime")
+	clusterTimeDoc, ok := clusterTimeVal.MutableDocumentOK()
+	if !ok {
+		return 0, 0
+	}
+
+	timestampVal, err := clusterTimeDoc.LookupErr("clusterTime")
 	if err != nil {
 		return 0, 0
 	}
 
-	return timestampVal.Timestamp()
+	t, i, _ := timestampVal.TimestampOK()
+	return t, i
 }
 
 // MaxClusterTime compares 2 clusterTime documents and returns the document representing the highest cluster time.
@@ -146,6 +154,7 @@ func NewClientSession(pool *Pool, clientID uuid.UUID, sessionType Type, opts ...
 	}
 
 	c.Server = servSess
+	observability.SessionsActive.Add(context.Background(), 1)
 
 	return c, nil
 }
@@ -197,6 +206,7 @@ func (c *Client) EndSession() {
 
 	c.Terminated = true
 	c.pool.ReturnSession(c.Server)
+	observability.SessionsActive.Add(context.Background(), -1)
 
 	return
 }
@@ -261,7 +271,7 @@ func (c *Client) StartTransaction(opts ...ClientOptioner) error {
 		c.CurrentWc = c.transactionWc
 	}
 
-	if !writeconcern.AckWrite(c.CurrentWc) {
+	if !c.CurrentWc.Acknowledged() {
 		c.clearTransactionOpts()
 		return ErrUnackWCUnsupported
 	}
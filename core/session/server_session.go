@@ -13,6 +13,7 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
+	"github.com/mongodb/mongo-go-driver/internal/clock"
 )
 
 var rander = rand.Reader
@@ -22,6 +23,8 @@ type Server struct {
 	SessionID *bson.Document
 	TxnNumber int64
 	LastUsed  time.Time
+
+	clock clock.Clock // the clock of the pool of the session
 }
 
 // returns whether or not a session has expired given a timeout in minutes
@@ -30,17 +33,17 @@ func (ss *Server) expired(timeoutMinutes uint32) bool {
 	if timeoutMinutes <= 0 {
 		return true
 	}
-	timeUnused := time.Since(ss.LastUsed).Minutes()
-	return timeUnused > float64(timeoutMinutes-1)
+	timeUnused := ss.clock.Now().Sub(ss.LastUsed)
+	return timeUnused > time.Duration(timeoutMinutes-1)*time.Minute
 }
 
 // update the last used time for this session.
 // must be called whenever this server session is used to send a command to the server.
 func (ss *Server) updateUseTime() {
-	ss.LastUsed = time.Now()
+	ss.LastUsed = ss.clock.Now()
 }
 
-func newServerSession() (*Server, error) {
+func newServerSession(c clock.Clock) (*Server, error) {
 	id, err := uuid.New()
 	if err != nil {
 		return nil, err
@@ -52,7 +55,8 @@ func newServerSession() (*Server, error) {
 
 	return &Server{
 		SessionID: idDoc,
-		LastUsed:  time.Now(),
+		LastUsed:  c.Now(),
+		clock:     c,
 	}, nil
 }
 
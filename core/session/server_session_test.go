@@ -10,13 +10,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mongodb/mongo-go-driver/internal/clock"
 	"github.com/stretchr/testify/require"
 )
 
 func TestServerSession(t *testing.T) {
 
 	t.Run("Expired", func(t *testing.T) {
-		sess, err := newServerSession()
+		sess, err := newServerSession(clock.Real)
 		require.Nil(t, err, "Unexpected error")
 		if !sess.expired(0) {
 			t.Errorf("session should be expired")
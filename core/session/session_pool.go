@@ -8,9 +8,11 @@ package session
 
 import (
 	"sync"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/internal/clock"
 )
 
 // Node represents a server session in a linked list
@@ -27,12 +29,13 @@ type Pool struct {
 	tail     *Node
 	timeout  uint32
 	mutex    sync.Mutex // mutex to protect list and sessionTimeout
+	clock    clock.Clock
 
 	checkedOut int // number of sessions checked out of pool
 }
 
 func (p *Pool) createServerSession() (*Server, error) {
-	s, err := newServerSession()
+	s, err := newServerSession(p.getClock())
 	if err != nil {
 		return nil, err
 	}
@@ -41,61 +44,65 @@ func (p *Pool) createServerSession() (*Server, error) {
 	return s, nil
 }
 
+// getClock returns the clock of the pool, which is the system clock unless the pool is created with
+// NewPoolWithClock.
+func (p *Pool) getClock() clock.Clock {
+	if p.clock == nil {
+		return clock.Real
+	}
+	return p.clock
+}
+
 // NewPool creates a new server session pool
 func NewPool(descChan <-chan description.Topology) *Pool {
+	return NewPoolWithClock(descChan, nil)
+}
+
+// NewPoolWithClock creates a new server session pool whose sessions expire, and which is swept,
+// according to the given clock, or to the system clock if it is nil.
+func NewPoolWithClock(descChan <-chan description.Topology, c clock.Clock) *Pool {
 	p := &Pool{
 		descChan: descChan,
+		clock:    c,
 	}
 
 	return p
 }
 
-// assumes caller has mutex to protect the pool
+// updateTimeout takes the session timeout of the latest description of the topology, which tracks
+// the logicalSessionTimeoutMinutes the servers report. Assumes caller has mutex to protect the pool.
 func (p *Pool) updateTimeout() {
 	select {
-	case newDesc := <-p.descChan:
-		p.timeout = newDesc.SessionTimeoutMinutes
+	case newDesc, ok := <-p.descChan:
+		if ok {
+			p.timeout = newDesc.SessionTimeoutMinutes
+		}
 	default:
 		// no new description waiting
 	}
 }
 
-// GetSession retrieves an unexpired session from the pool.
+// GetSession retrieves an unexpired session from the pool. Sessions that have expired, or would
+// expire within a minute, are discarded rather than handed out, since the server may have already
+// forgotten them.
 func (p *Pool) GetSession() (*Server, error) {
 	p.mutex.Lock() // prevent changing the linked list while seeing if sessions have expired
 	defer p.mutex.Unlock()
 
-	// empty pool
-	if p.head == nil && p.tail == nil {
-		return p.createServerSession()
-	}
-
 	p.updateTimeout()
 	for p.head != nil {
 		// pull session from head of queue and return if it is valid for at least 1 more minute
-		if p.head.expired(p.timeout) {
-			p.head = p.head.next
+		node := p.head
+		p.remove(node)
+		if node.expired(p.timeout) {
 			continue
 		}
 
-		// found unexpired session
-		session := p.head.Server
-		if p.head.next != nil {
-			p.head.next.prev = nil
-		}
-		if p.tail == p.head {
-			p.tail = nil
-			p.head = nil
-		} else {
-			p.head = p.head.next
-		}
-
 		p.checkedOut++
-		return session, nil
+		return node.Server, nil
 	}
 
 	// no valid session found
-	p.tail = nil // empty list
 	return p.createServerSession()
 }
 
@@ -113,10 +120,7 @@ func (p *Pool) ReturnSession(ss *Server) {
 	// check sessions at end of queue for expired
 	// stop checking after hitting the first valid session
 	for p.tail != nil && p.tail.expired(p.timeout) {
-		if p.tail.prev != nil {
-			p.tail.prev.next = nil
-		}
-		p.tail = p.tail.prev
+		p.remove(p.tail)
 	}
 
 	// session expired
@@ -126,8 +130,7 @@ func (p *Pool) ReturnSession(ss *Server) {
 
 	newNode := &Node{
 		Server: ss,
-		next:   nil,
-		prev:   nil,
+		next:   p.head,
 	}
 
 	// empty list
@@ -138,11 +141,56 @@ func (p *Pool) ReturnSession(ss *Server) {
 	}
 
 	// at least 1 valid session in list
-	newNode.next = p.head
 	p.head.prev = newNode
 	p.head = newNode
 }
 
+// Prune discards the sessions of the pool that have expired or would expire within a minute.
+func (p *Pool) Prune() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.updateTimeout()
+	for node := p.head; node != nil; {
+		next := node.next
+		if node.expired(p.timeout) {
+			p.remove(node)
+		}
+		node = next
+	}
+}
+
+// Sweep prunes the pool every interval until done is closed, so that the sessions of a pool left
+// idle do not linger past their expiration.
+func (p *Pool) Sweep(interval time.Duration, done <-chan struct{}) {
+	ticker := p.getClock().NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			p.Prune()
+		case <-done:
+			return
+		}
+	}
+}
+
+// remove unlinks node from the list. Assumes caller has mutex to protect the pool.
+func (p *Pool) remove(node *Node) {
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		p.head = node.next
+	}
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		p.tail = node.prev
+	}
+	node.next, node.prev = nil, nil
+}
+
 // IDSlice returns a slice of session IDs for each session in the pool
 func (p *Pool) IDSlice() []*bson.Document {
 	p.mutex.Lock()
@@ -8,8 +8,10 @@ package session
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/internal/testutil/fakeclock"
 	"github.com/mongodb/mongo-go-driver/internal/testutil/helpers"
 )
 
@@ -68,4 +70,131 @@ func TestSessionPool(t *testing.T) {
 			t.Errorf("Expired sessions not removed!")
 		}
 	})
+
+	t.Run("TestStaleNotHandedOut", func(t *testing.T) {
+		clock := fakeclock.New(time.Now())
+		p := NewPoolWithClock(make(chan description.Topology), clock)
+		p.timeout = 30
+
+		first, err := p.GetSession()
+		testhelpers.RequireNil(t, err, "error getting session %s", err)
+		second, err := p.GetSession()
+		testhelpers.RequireNil(t, err, "error getting session %s", err)
+
+		p.ReturnSession(first)
+		clock.Advance(20 * time.Minute)
+		second.updateUseTime()
+		p.ReturnSession(second)
+
+		// first has been idle for 30 minutes, past the 29 minutes a pooled session is kept
+		clock.Advance(10 * time.Minute)
+		sess, err := p.GetSession()
+		testhelpers.RequireNil(t, err, "error getting session %s", err)
+		if sess.SessionID != second.SessionID {
+			t.Errorf("session ID mismatch. got %s expected %s", sess.SessionID, second.SessionID)
+		}
+
+		sess, err = p.GetSession()
+		testhelpers.RequireNil(t, err, "error getting session %s", err)
+		if sess.SessionID == first.SessionID {
+			t.Errorf("stale session handed out")
+		}
+		if p.head != nil || p.tail != nil {
+			t.Errorf("stale session left in the pool")
+		}
+	})
+
+	t.Run("TestPrune", func(t *testing.T) {
+		clock := fakeclock.New(time.Now())
+		p := NewPoolWithClock(make(chan description.Topology), clock)
+		p.timeout = 30
+
+		sessions := make([]*Server, 3)
+		for i := range sessions {
+			var err error
+			sessions[i], err = p.GetSession()
+			testhelpers.RequireNil(t, err, "error getting session %s", err)
+		}
+		for i, sess := range sessions {
+			clock.Advance(time.Duration(i*10) * time.Minute)
+			sess.updateUseTime()
+			p.ReturnSession(sess)
+		}
+
+		// the first two sessions are used 30 and 20 minutes before the sweep
+		clock.Advance(20 * time.Minute)
+		p.Prune()
+		ids := p.IDSlice()
+		if len(ids) != 1 || ids[0] != sessions[2].SessionID {
+			t.Errorf("unexpected sessions after prune: %v", ids)
+		}
+
+		clock.Advance(10 * time.Minute)
+		p.Prune()
+		if ids = p.IDSlice(); len(ids) != 0 {
+			t.Errorf("unexpected sessions after prune: %v", ids)
+		}
+	})
+
+	t.Run("TestSweep", func(t *testing.T) {
+		clock := fakeclock.New(time.Now())
+		p := NewPoolWithClock(make(chan description.Topology), clock)
+		p.timeout = 30
+
+		sess, err := p.GetSession()
+		testhelpers.RequireNil(t, err, "error getting session %s", err)
+		p.ReturnSession(sess)
+
+		done := make(chan struct{})
+		stopped := make(chan struct{})
+		go func() {
+			p.Sweep(time.Minute, done)
+			close(stopped)
+		}()
+
+		// the session expires while the pool is idle, and is pruned by the next sweep
+		clock.BlockUntil(1)
+		clock.Advance(30 * time.Minute)
+		deadline := time.After(5 * time.Second)
+		for len(p.IDSlice()) != 0 {
+			select {
+			case <-deadline:
+				t.Fatalf("expired session not swept")
+			case <-time.After(time.Millisecond):
+			}
+		}
+
+		close(done)
+		select {
+		case <-stopped:
+		case <-deadline:
+			t.Fatalf("sweep not stopped")
+		}
+		if n := clock.Waiters(); n != 0 {
+			t.Errorf("ticker of the sweep not stopped")
+		}
+	})
+
+	t.Run("TestTimeoutUpdated", func(t *testing.T) {
+		clock := fakeclock.New(time.Now())
+		descChan := make(chan description.Topology, 1)
+		p := NewPoolWithClock(descChan, clock)
+		p.timeout = 30
+
+		sess, err := p.GetSession()
+		testhelpers.RequireNil(t, err, "error getting session %s", err)
+		p.ReturnSession(sess)
+
+		// the servers now forget sessions idle for 10 minutes
+		clock.Advance(15 * time.Minute)
+		descChan <- description.Topology{SessionTimeoutMinutes: 10}
+		got, err := p.GetSession()
+		testhelpers.RequireNil(t, err, "error getting session %s", err)
+		if got.SessionID == sess.SessionID {
+			t.Errorf("session expired by the new timeout handed out")
+		}
+		if p.timeout != 10 {
+			t.Errorf("timeout mismatch. got %d expected %d", p.timeout, 10)
+		}
+	})
 }
@@ -8,16 +8,17 @@ package topology
 
 import (
 	"context"
+	"errors"
 	"net"
 
 	"strings"
+	"sync/atomic"
 
 	"github.com/mongodb/mongo-go-driver/core/command"
 	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
-
-	"go.opencensus.io/trace"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 )
 
 // sconn is a wrapper around a connection.Connection. This type is returned by
@@ -25,15 +26,19 @@ import (
 // error is returned, the pool on the server can be cleared.
 type sconn struct {
 	connection.Connection
-	s  *Server
-	id uint64
+	s          *Server
+	id         uint64
+	closed     int32              // set once the connection is closed, so the operation is only ended once
+	generation uint64             // the pool generation of the server when the connection was checked out
+	desc       description.Server // the description of the server when the connection was checked out
 }
 
 var notMasterCodes = []int32{10107, 13435}
 var recoveringCodes = []int32{11600, 11602, 13436, 189, 91}
+var shutdownCodes = []int32{11600, 91}
 
 func (sc *sconn) ReadWireMessage(ctx context.Context) (wiremessage.WireMessage, error) {
-	ctx, span := trace.StartSpan(ctx, "mongo-go-driver/core/topology/(*sconn).ReadWireMessage")
+	ctx, span := observability.StartSpan(ctx, "mongo-go-driver/core/topology/(*sconn).ReadWireMessage")
 	defer span.End()
 
 	wm, err := sc.Connection.ReadWireMessage(ctx)
@@ -42,43 +47,93 @@ func (sc *sconn) ReadWireMessage(ctx context.Context) (wiremessage.WireMessage,
 	} else {
 		e := command.DecodeError(wm)
 		sc.processErr(e)
+		if clock := sc.s.cfg.clock; clock != nil {
+			if clusterTime := command.ReplyClusterTime(wm); clusterTime != nil {
+				clock.AdvanceClusterTime(clusterTime)
+			}
+		}
 	}
 	return wm, err
 }
 
 func (sc *sconn) WriteWireMessage(ctx context.Context, wm wiremessage.WireMessage) error {
-	ctx, span := trace.StartSpan(ctx, "mongo-go-driver/core/topology/(*sconn).WriteWireMessage")
+	ctx, span := observability.StartSpan(ctx, "mongo-go-driver/core/topology/(*sconn).WriteWireMessage")
 	defer span.End()
 
+	// every command gossips the cluster time, including those that are not sent in a session
+	if clock := sc.s.cfg.clock; clock != nil && description.SessionsSupported(sc.s.Description().WireVersion) {
+		wm = command.WithClusterTime(wm, clock.GetClusterTime())
+	}
+
 	err := sc.Connection.WriteWireMessage(ctx, wm)
 	sc.processErr(err)
 	return err
 }
 
+// Close returns the connection to the pool of the server and ends the operation that used it.
+func (sc *sconn) Close() error {
+	if atomic.CompareAndSwapInt32(&sc.closed, 0, 1) {
+		sc.s.operations.Add(sc.s.statsCtx, -1)
+	}
+	return sc.Connection.Close()
+}
+
 func (sc *sconn) processErr(err error) {
-	// TODO(GODRIVER-524) handle the rest of sdam error handling
-	// Invalidate server description if not master or node recovering error occurs
-	if cerr, ok := err.(command.Error); ok && (isRecoveringError(cerr) || isNotMasterError(cerr)) {
-		desc := sc.s.Description()
-		desc.Kind = description.Unknown
-
-		// updates description to unknown and clears the connection pool
-		sc.s.updateDescription(desc, false)
+	if err != nil {
+		sc.s.processError(err, sc.generation, sc.desc)
 	}
+}
 
-	ne, ok := err.(connection.NetworkError)
-	if !ok {
+// processError applies the SDAM error handling rules to an error returned by a connection to the
+// server, so that every operation handles state changes of the server the same way. "Not master"
+// and "node is recovering" errors and network errors other than timeouts mark the server Unknown
+// and request an immediate check of it. They also clear the connection pool, except for "node is
+// recovering" errors not caused by a shutdown from servers of version 4.2 or newer, which keep
+// their connections open across state changes. The errors of connections checked out before the
+// pool was last cleared are ignored. generation and desc are the pool generation and description
+// of the server when the connection was checked out.
+func (s *Server) processError(err error, generation uint64, desc description.Server) {
+	if generation < atomic.LoadUint64(&s.poolGeneration) {
 		return
 	}
 
-	if netErr, ok := ne.Wrapped.(net.Error); ok && netErr.Timeout() {
-		return
+	var cerr command.Error
+	switch {
+	case errors.As(err, &cerr) && isRecoveringError(cerr):
+		keepsConns := desc.WireVersion != nil && desc.WireVersion.Max >= 8
+		s.markUnknown(err, isShutdownError(cerr) || !keepsConns)
+	case errors.As(err, &cerr) && isNotMasterError(cerr):
+		s.markUnknown(err, true)
+	case isNetworkError(err):
+		s.markUnknown(err, true)
 	}
-	if ne.Wrapped == context.Canceled || ne.Wrapped == context.DeadlineExceeded {
-		return
+}
+
+// markUnknown marks the server Unknown because of err, optionally clearing its connection pool, and
+// requests an immediate check of the server.
+func (s *Server) markUnknown(err error, clearPool bool) {
+	desc := description.Server{Addr: s.address, LastError: err}
+	if clearPool {
+		s.updateDescription(desc, false)
+	} else {
+		s.setDescription(desc)
+	}
+	s.RequestImmediateCheck()
+}
+
+// isNetworkError reports whether err is a network error other than a timeout or the cancellation
+// of the operation, which say nothing about the server.
+func isNetworkError(err error) bool {
+	var ne connection.NetworkError
+	if !errors.As(err, &ne) {
+		return false
 	}
 
-	_ = sc.s.Drain()
+	var netErr net.Error
+	if errors.As(ne.Wrapped, &netErr) && netErr.Timeout() {
+		return false
+	}
+	return !errors.Is(ne.Wrapped, context.Canceled) && !errors.Is(ne.Wrapped, context.DeadlineExceeded)
 }
 
 func isRecoveringError(err command.Error) bool {
@@ -87,7 +142,7 @@ func isRecoveringError(err command.Error) bool {
 			return true
 		}
 	}
-	return strings.Contains(err.Error(), "node is recovering")
+	return strings.Contains(err.Error(), "node is recovering") || strings.Contains(err.Error(), "not master or secondary")
 }
 
 func isNotMasterError(err command.Error) bool {
@@ -98,3 +153,12 @@ func isNotMasterError(err command.Error) bool {
 	}
 	return strings.Contains(err.Error(), "not master")
 }
+
+func isShutdownError(err command.Error) bool {
+	for _, c := range shutdownCodes {
+		if c == err.Code {
+			return true
+		}
+	}
+	return false
+}
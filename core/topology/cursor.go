@@ -11,26 +11,60 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/connection"
+	"github.com/mongodb/mongo-go-driver/core/csot"
 	"github.com/mongodb/mongo-go-driver/core/option"
 	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 )
 
+// ErrCursorServerUnavailable is returned by the getMores and killCursors of a cursor once the
+// server the cursor was created on has left the topology or has been reconnected. Cursors only
+// exist on the server that created them, so they are never retargeted to another server.
+var ErrCursorServerUnavailable = errors.New("the server the cursor was created on is no longer available")
+
 type cursor struct {
 	clientSession *session.Client
 	clock         *session.ClusterClock
 	namespace     command.Namespace
-	current       int
-	batch         *bson.Array
+	reply         bson.Reader // the reply holding the current batch, if it is pooled
+	batch         *command.Batch
+	doc           bson.Reader
+	batchReturned bool // whether NextBatch has returned the current batch
 	id            int64
 	err           error
 	server        *Server
-	opts          []option.CursorOptioner
+	generation    uint64                  // the generation of server when the cursor was created
+	opts          []option.CursorOptioner // the options sent with getMores, except the batch size
+	batchSize     int32                   // the batch size requested by getMores, 0 for the server default
+
+	// Whether the next batch is requested as soon as the current one starts being iterated, and the
+	// getMore requesting it, if any. Only one getMore is in flight at a time.
+	prefetch   bool
+	prefetched *prefetchedGetMore
+
+	// The decoder of Decode and the reader it reads the current document from, which are reused
+	// for every document.
+	decoder   bson.Decoder
+	docReader *bytes.Reader
+
+	// The operation timeout inherited from the command that created the cursor. Unless the cursor
+	// is iterated per getMore, its getMores share the budget that remains until deadline.
+	timeout    time.Duration
+	timeoutSet bool
+	iteration  bool
+	deadline   time.Time
 }
 
-func newCursor(result bson.Reader, clientSession *session.Client, clock *session.ClusterClock, server *Server, opts ...option.CursorOptioner) (command.Cursor, error) {
+func newCursor(ctx context.Context, result bson.Reader, clientSession *session.Client, clock *session.ClusterClock, server *Server, opts ...option.CursorOptioner) (command.Cursor, error) {
 	cur, err := result.Lookup("cursor")
 	if err != nil {
 		return nil, err
@@ -47,19 +81,42 @@ func newCursor(result bson.Reader, clientSession *session.Client, clock *session
 	c := &cursor{
 		clientSession: clientSession,
 		clock:         clock,
-		current:       -1,
 		server:        server,
-		opts:          opts,
+	}
+	if server != nil {
+		c.generation = atomic.LoadUint64(&server.generation)
+	}
+	for _, opt := range opts {
+		if bs, ok := opt.(option.OptBatchSize); ok {
+			c.batchSize = int32(bs)
+			continue
+		}
+		if p, ok := opt.(option.OptPrefetch); ok {
+			// an explicit session may be used by the application while the getMore is in flight,
+			// and sessions are not safe for concurrent use
+			c.prefetch = bool(p) && (clientSession == nil || clientSession.SessionType == session.Implicit)
+			continue
+		}
+		c.opts = append(c.opts, opt)
+	}
+	if server != nil && server.cfg.pooledReplies {
+		c.reply = result
+	}
+	c.timeout, c.timeoutSet = csot.Timeout(ctx)
+	if c.timeoutSet {
+		c.iteration = csot.Iteration(ctx)
+		c.deadline, _ = ctx.Deadline()
 	}
 	var ok bool
 	for itr.Next() {
 		elem = itr.Element()
 		switch elem.Key() {
 		case "firstBatch":
-			c.batch, ok = elem.Value().MutableArrayOK()
+			arr, ok := elem.Value().ReaderArrayOK()
 			if !ok {
 				return nil, fmt.Errorf("firstBatch should be an array but it is a BSON %s", elem.Value().Type())
 			}
+			c.batch = command.NewBatch(arr)
 		case "ns":
 			if elem.Value().Type() != bson.TypeString {
 				return nil, fmt.Errorf("namespace should be a string but it is a BSON %s", elem.Value().Type())
@@ -81,10 +138,58 @@ func newCursor(result bson.Reader, clientSession *session.Client, clock *session
 	// close session if everything fits in first batch
 	if c.id == 0 {
 		c.closeImplicitSession()
+	} else {
+		observability.CursorsOpen.Add(context.Background(), 1)
 	}
 	return c, nil
 }
 
+// withTimeout returns a copy of ctx bounded by the operation timeout of the cursor: the remaining
+// budget of the cursor, or a fresh timeout if the cursor is iterated per getMore.
+func (c *cursor) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	switch {
+	case !c.timeoutSet:
+		return ctx, func() {}
+	case c.iteration || c.deadline.IsZero():
+		return csot.WithTimeout(ctx, c.timeout)
+	default:
+		return csot.WithDeadline(ctx, c.deadline)
+	}
+}
+
+// releaseReply returns the reply holding the current batch to the pool, if replies are pooled.
+// The documents of the batch must no longer be used afterwards.
+func (c *cursor) releaseReply() {
+	c.batch = command.NewBatch(nil)
+	c.doc = nil
+	if c.reply != nil {
+		wiremessage.PutBuffer(c.reply)
+		c.reply = nil
+	}
+}
+
+// connection returns a connection to the server the cursor was created on.
+func (c *cursor) connection(ctx context.Context) (connection.Connection, error) {
+	if !c.server.available(c.generation) {
+		return nil, ErrCursorServerUnavailable
+	}
+	conn, err := c.server.Connection(ctx)
+	if err == ErrServerClosed {
+		return nil, ErrCursorServerUnavailable
+	}
+	return conn, err
+}
+
+// abandon marks the cursor as exhausted after its server became unavailable, which took the
+// server-side cursor with it.
+func (c *cursor) abandon() {
+	if c.id != 0 {
+		observability.CursorsOpen.Add(context.Background(), -1)
+	}
+	c.id = 0
+	c.closeImplicitSession()
+}
+
 // close the associated session if it's implicit
 func (c *cursor) closeImplicitSession() {
 	if c.clientSession != nil && c.clientSession.SessionType == session.Implicit {
@@ -96,47 +201,104 @@ func (c *cursor) ID() int64 {
 	return c.id
 }
 
+func (c *cursor) Namespace() (string, string) {
+	return c.namespace.DB, c.namespace.Collection
+}
+
 func (c *cursor) Next(ctx context.Context) bool {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	c.current++
-	if c.current < c.batch.Len() {
+	if c.nextDoc() {
+		c.prefetchNext(ctx)
 		return true
 	}
 
-	c.getMore(ctx)
-
 	// call the getMore command in a loop until at least one document is returned in the next batch
-	for c.batch.Len() == 0 {
-		if c.err != nil || (c.id == 0 && c.batch.Len() == 0) {
+	for c.err == nil {
+		c.getMore(ctx)
+		if c.nextDoc() {
+			c.prefetchNext(ctx)
+			return true
+		}
+		if c.id == 0 {
 			return false
 		}
+	}
 
-		c.getMore(ctx)
+	return false
+}
+
+// nextDoc moves to the next document of the current batch, if any.
+func (c *cursor) nextDoc() bool {
+	doc, ok := c.batch.Next()
+	if !ok {
+		if err := c.batch.Err(); err != nil && c.err == nil {
+			c.err = err
+		}
+		return false
 	}
 
+	c.doc = doc
 	return true
 }
 
+func (c *cursor) NextBatch(ctx context.Context) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if !c.batchReturned && c.batch.Len() > 0 {
+		c.batchReturned = true
+		c.prefetchNext(ctx)
+		return true
+	}
+
+	for c.err == nil {
+		c.getMore(ctx)
+		if c.err == nil && c.batch.Len() > 0 {
+			c.batchReturned = true
+			c.prefetchNext(ctx)
+			return true
+		}
+		if c.id == 0 {
+			return false
+		}
+	}
+
+	return false
+}
+
+func (c *cursor) Batch() *command.Batch {
+	if c.batch == nil {
+		return command.NewBatch(nil)
+	}
+
+	batch := *c.batch
+	return &batch
+}
+
 func (c *cursor) Decode(v interface{}) error {
 	br, err := c.DecodeBytes()
 	if err != nil {
 		return err
 	}
-	return bson.NewDecoder(bytes.NewReader(br)).Decode(v)
+	if c.decoder == nil {
+		c.docReader = bytes.NewReader(br)
+		c.decoder = bson.NewDecoder(c.docReader)
+	} else {
+		c.docReader.Reset(br)
+		c.decoder.Reset(c.docReader)
+	}
+	return c.decoder.Decode(v)
 }
 
 func (c *cursor) DecodeBytes() (bson.Reader, error) {
-	br, err := c.batch.Lookup(uint(c.current))
-	if err != nil {
-		return nil, err
-	}
-	if br.Type() != bson.TypeEmbeddedDocument {
-		return nil, errors.New("Non-Document in batch of documents for cursor")
+	if c.doc == nil {
+		return nil, errors.New("cursor is not positioned on a document")
 	}
-	return br.ReaderDocument(), nil
+	return c.doc, nil
 }
 
 func (c *cursor) Err() error {
@@ -144,9 +306,50 @@ func (c *cursor) Err() error {
 }
 
 func (c *cursor) Close(ctx context.Context) error {
+	ctx = observability.Tag(ctx, tag.Upsert(observability.KeyServerAddress, c.server.address.String()))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/topology.(*cursor).Close")
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("cursor_id", c.id))
+
+	c.discardPrefetched()
+	c.releaseReply()
+
 	defer c.closeImplicitSession()
-	conn, err := c.server.Connection(ctx)
+	err := c.kill(ctx)
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+	}
+	return err
+}
+
+// killCursorsTimeout bounds the killCursors of a cursor whose context was canceled.
+const killCursorsTimeout = 2 * time.Second
+
+// kill runs killCursors for the cursor on the server it was created on.
+//
+// killCursors gets a fresh budget so the cursor is cleaned up even if its budget is spent. If ctx
+// is already done, e.g. because the caller abandoned the iteration of the cursor, killCursors runs
+// detached from ctx for up to killCursorsTimeout, so that the server-side cursor does not linger
+// until it times out on the server.
+func (c *cursor) kill(ctx context.Context) error {
+	var cancel context.CancelFunc
+	detached := ctx.Err() != nil
+	switch {
+	case detached:
+		ctx, cancel = context.WithTimeout(context.Background(), killCursorsTimeout)
+		ctx = observability.Tag(ctx, tag.Upsert(observability.KeyServerAddress, c.server.address.String()))
+	case c.timeoutSet:
+		ctx, cancel = csot.WithTimeout(ctx, c.timeout)
+	default:
+		cancel = func() {}
+	}
+	defer cancel()
+
+	conn, err := c.connection(ctx)
 	if err != nil {
+		if err == ErrCursorServerUnavailable {
+			c.abandon()
+		}
 		return err
 	}
 
@@ -160,70 +363,242 @@ func (c *cursor) Close(ctx context.Context) error {
 		return err
 	}
 
+	if c.id != 0 {
+		observability.CursorsOpen.Add(context.Background(), -1)
+		if detached {
+			observability.Record(ctx, observability.MCursorsKilledCanceled.M(1))
+		}
+	}
 	c.id = 0
 	return conn.Close()
 }
 
+// getMoreResult is the outcome of a getMore.
+type getMoreResult struct {
+	reply bson.Reader // the reply, if it is pooled
+	batch *command.Batch
+	id    int64
+	err   error
+
+	unavailable bool // whether the server of the cursor is no longer available
+	canceled    bool // whether the getMore was canceled, which may have abandoned its reply
+}
+
+// prefetchedGetMore is a getMore sent ahead of the iteration of a cursor.
+type prefetchedGetMore struct {
+	cancel context.CancelFunc
+	done   chan getMoreResult
+}
+
+// detachedContext carries the values of a context without its deadline and cancelation, so that
+// a prefetched getMore outlives the call to Next that sent it.
+type detachedContext struct{ parent context.Context }
+
+func (detachedContext) Deadline() (time.Time, bool)           { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}                 { return nil }
+func (detachedContext) Err() error                            { return nil }
+func (ctx detachedContext) Value(key interface{}) interface{} { return ctx.parent.Value(key) }
+
 func (c *cursor) getMore(ctx context.Context) {
-	c.batch.Reset()
-	c.current = 0
+	c.releaseReply()
+	c.batchReturned = false
 
 	if c.id == 0 {
 		return
 	}
 
-	conn, err := c.server.Connection(ctx)
-	if err != nil {
-		c.err = err
-		return
+	var res getMoreResult
+	if c.prefetched != nil {
+		res = c.awaitPrefetched(ctx)
+	} else {
+		res = c.runGetMore(ctx, c.getMoreCommand())
+	}
+	c.applyGetMore(ctx, res)
+}
+
+// getMoreCommand returns the getMore requesting the next batch of the cursor.
+func (c *cursor) getMoreCommand() *command.GetMore {
+	opts := c.opts
+	if c.batchSize > 0 {
+		opts = append(opts[:len(opts):len(opts)], option.OptBatchSize(c.batchSize))
 	}
 
-	response, err := (&command.GetMore{
+	return &command.GetMore{
 		Clock:   c.clock,
 		ID:      c.id,
 		NS:      c.namespace,
-		Opts:    c.opts,
+		Opts:    opts,
 		Session: c.clientSession,
-	}).RoundTrip(ctx, c.server.SelectedDescription(), conn)
+	}
+}
+
+// runGetMore sends cmd to the server of the cursor. It does not modify the cursor, so that it can
+// run while the cursor is iterated.
+func (c *cursor) runGetMore(ctx context.Context, cmd *command.GetMore) (res getMoreResult) {
+	ctx = observability.Tag(ctx, tag.Upsert(observability.KeyServerAddress, c.server.address.String()))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/topology.(*cursor).getMore")
+	defer span.End()
+	batchSize := int64(0)
+	for _, opt := range cmd.Opts {
+		if bs, ok := opt.(option.OptBatchSize); ok {
+			batchSize = int64(bs)
+		}
+	}
+	span.AddAttributes(
+		trace.Int64Attribute("cursor_id", cmd.ID),
+		trace.Int64Attribute("batch_size", batchSize),
+	)
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	defer func() {
+		if res.err != nil {
+			res.canceled = ctx.Err() == context.Canceled
+			res.err = csot.Wrap(ctx, res.err)
+			span.SetStatus(observability.SpanStatus(res.err))
+			return
+		}
+		span.Annotate([]trace.Attribute{trace.Int64Attribute("documents", int64(res.batch.Len()))}, "Received batch")
+	}()
+
+	conn, err := c.connection(ctx)
+	if err != nil {
+		res.unavailable = err == ErrCursorServerUnavailable
+		res.err = err
+		return res
+	}
+
+	response, err := cmd.RoundTrip(ctx, c.server.SelectedDescription(), conn)
 	if err != nil {
 		_ = conn.Close() // The command response error is more important here
-		c.err = err
-		return
+		res.err = err
+		return res
 	}
 
 	err = conn.Close()
 	if err != nil {
-		c.err = err
-		return
+		res.err = err
+		return res
+	}
+	if c.server.cfg.pooledReplies {
+		res.reply = response
 	}
 
 	id, err := response.Lookup("cursor", "id")
 	if err != nil {
-		c.err = err
-		return
+		res.err = err
+		return res
 	}
 	var ok bool
-	c.id, ok = id.Value().Int64OK()
+	res.id, ok = id.Value().Int64OK()
+	if !ok {
+		res.err = fmt.Errorf("BSON Type %s is not %s", id.Value().Type(), bson.TypeInt64)
+		return res
+	}
+
+	batch, err := response.Lookup("cursor", "nextBatch")
+	if err != nil {
+		res.err = err
+		return res
+	}
+	arr, ok := batch.Value().ReaderArrayOK()
 	if !ok {
-		c.err = fmt.Errorf("BSON Type %s is not %s", id.Value().Type(), bson.TypeInt64)
+		res.err = fmt.Errorf("BSON Type %s is not %s", batch.Value().Type(), bson.TypeArray)
+		return res
+	}
+	res.batch = command.NewBatch(arr)
+
+	return res
+}
+
+// applyGetMore makes the batch of res the current batch of the cursor, or records its error.
+func (c *cursor) applyGetMore(ctx context.Context, res getMoreResult) {
+	c.reply = res.reply
+	if res.err != nil {
+		switch {
+		case res.unavailable:
+			c.abandon()
+		case res.canceled && c.id != 0:
+			// a canceled iteration leaves the cursor unusable, since the reply to the getMore
+			// may have been abandoned, so the server-side cursor is killed rather than left to
+			// time out
+			_ = c.kill(ctx)
+			c.closeImplicitSession()
+		}
+		c.err = res.err
 		return
 	}
 
+	c.id = res.id
 	// if this is the last getMore, close the session
 	if c.id == 0 {
+		observability.CursorsOpen.Add(context.Background(), -1)
 		c.closeImplicitSession()
 	}
+	c.batch = res.batch
+}
 
-	batch, err := response.Lookup("cursor", "nextBatch")
-	if err != nil {
-		c.err = err
+// prefetchNext sends the getMore for the next batch in the background if prefetching is enabled
+// and the cursor is not exhausted.
+func (c *cursor) prefetchNext(ctx context.Context) {
+	if !c.prefetch || c.prefetched != nil || c.id == 0 || c.err != nil {
 		return
 	}
-	c.batch, ok = batch.Value().MutableArrayOK()
-	if !ok {
-		c.err = fmt.Errorf("BSON Type %s is not %s", batch.Value().Type(), bson.TypeArray)
+
+	ctx, cancel := context.WithCancel(detachedContext{ctx})
+	p := &prefetchedGetMore{cancel: cancel, done: make(chan getMoreResult, 1)}
+	cmd := c.getMoreCommand()
+	go func() {
+		defer cancel()
+		p.done <- c.runGetMore(ctx, cmd)
+	}()
+	c.prefetched = p
+}
+
+// awaitPrefetched returns the result of the prefetched getMore, waiting for it until ctx is done.
+func (c *cursor) awaitPrefetched(ctx context.Context) getMoreResult {
+	p := c.prefetched
+	c.prefetched = nil
+
+	select {
+	case res := <-p.done:
+		return res
+	case <-ctx.Done():
+	}
+
+	p.cancel()
+	res := <-p.done
+	if res.err == nil {
+		// the batch arrived anyway
+		return res
+	}
+	res.err = csot.Wrap(ctx, ctx.Err())
+	res.canceled = ctx.Err() == context.Canceled
+	return res
+}
+
+// discardPrefetched cancels the prefetched getMore, if any, and drops its batch.
+func (c *cursor) discardPrefetched() {
+	p := c.prefetched
+	if p == nil {
 		return
 	}
+	c.prefetched = nil
+
+	p.cancel()
+	res := <-p.done
+	if res.reply != nil {
+		wiremessage.PutBuffer(res.reply)
+	}
+	switch {
+	case res.unavailable:
+		c.abandon()
+	case res.err == nil && res.id == 0:
+		observability.CursorsOpen.Add(context.Background(), -1)
+		c.id = 0
+	}
+}
 
-	return
+func (c *cursor) SetBatchSize(batchSize int32) {
+	c.batchSize = batchSize
 }
@@ -7,13 +7,22 @@
 package topology
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"net"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/command"
 	"github.com/mongodb/mongo-go-driver/core/connection"
+	"github.com/mongodb/mongo-go-driver/core/csot"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 	"github.com/mongodb/mongo-go-driver/internal"
 	"github.com/stretchr/testify/assert"
@@ -27,7 +36,7 @@ func TestCursorNextDoesNotPanicIfContextisNil(t *testing.T) {
 	// While more through testing might be ideal this check
 	// prevents a regression of GODRIVER-298
 
-	c := cursor{batch: bson.NewArray(bson.VC.String("a"), bson.VC.String("b"))}
+	c := cursor{batch: newBatch(t, bson.VC.DocumentFromElements(bson.EC.String("a", "b")))}
 
 	var iterNext bool
 	assert.NotPanics(t, func() {
@@ -43,9 +52,10 @@ func TestCursorLoopsUntilDocAvailable(t *testing.T) {
 
 	s := createDefaultConnectedServer(t, false)
 	c := cursor{
-		id:     1,
-		batch:  bson.NewArray(),
-		server: s,
+		id:         1,
+		batch:      command.NewBatch(nil),
+		server:     s,
+		generation: s.generation,
 	}
 
 	assert.True(t, c.Next(nil))
@@ -57,9 +67,10 @@ func TestCursorReturnsFalseOnContextCancellation(t *testing.T) {
 
 	s := createDefaultConnectedServer(t, false)
 	c := cursor{
-		id:     1,
-		batch:  bson.NewArray(),
-		server: s,
+		id:         1,
+		batch:      command.NewBatch(nil),
+		server:     s,
+		generation: s.generation,
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -75,9 +86,10 @@ func TestCursorNextReturnsFalseIfErrorOccurred(t *testing.T) {
 
 	s := createDefaultConnectedServer(t, true)
 	c := cursor{
-		id:     1,
-		batch:  bson.NewArray(),
-		server: s,
+		id:         1,
+		batch:      command.NewBatch(nil),
+		server:     s,
+		generation: s.generation,
 	}
 	assert.False(t, c.Next(nil))
 }
@@ -85,10 +97,156 @@ func TestCursorNextReturnsFalseIfErrorOccurred(t *testing.T) {
 func TestCursorNextReturnsFalseIfResIdZeroAndNoMoreDocs(t *testing.T) {
 	// Next should return false if the cursor id is 0 and there are no documents in the next batch
 
-	c := cursor{id: 0, batch: bson.NewArray()}
+	c := cursor{id: 0, batch: command.NewBatch(nil)}
 	assert.False(t, c.Next(nil))
 }
 
+func TestCursorNextBatch(t *testing.T) {
+	s := createDefaultConnectedServer(t, false)
+	c := cursor{
+		id:         1,
+		server:     s,
+		generation: s.generation,
+		batch: newBatch(t,
+			bson.VC.DocumentFromElements(bson.EC.Int32("x", 1)),
+			bson.VC.DocumentFromElements(bson.EC.Int32("x", 2)),
+			bson.VC.DocumentFromElements(bson.EC.Int32("x", 3)),
+		),
+	}
+
+	lookup := func(doc bson.Reader, key string) *bson.Value {
+		elem, err := doc.Lookup(key)
+		if err != nil {
+			t.Fatalf("could not find %s: %v", key, err)
+		}
+		return elem.Value()
+	}
+
+	// documents returned by Next are no longer part of the batch
+	assert.True(t, c.Next(nil))
+	doc, err := c.DecodeBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), lookup(doc, "x").Int32())
+	assert.Equal(t, 2, c.Batch().Len())
+
+	// the rest of the first batch is returned without a getMore, and iterating it does not
+	// advance the cursor
+	assert.True(t, c.NextBatch(nil))
+	batch := c.Batch()
+	for i := int32(2); i <= 3; i++ {
+		doc, ok := batch.Next()
+		assert.True(t, ok)
+		assert.Equal(t, i, lookup(doc, "x").Int32())
+	}
+	_, ok := batch.Next()
+	assert.False(t, ok)
+	assert.NoError(t, batch.Err())
+
+	assert.True(t, c.Next(nil))
+	doc, err = c.DecodeBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), lookup(doc, "x").Int32())
+
+	// NextBatch skips the rest of the batch and loops until a non-empty batch is returned
+	assert.True(t, c.NextBatch(nil))
+	assert.Equal(t, 1, c.Batch().Len())
+	assert.True(t, c.Next(nil))
+	doc, err = c.DecodeBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, "b", lookup(doc, "a").StringValue())
+}
+
+func TestCursorPooledReplies(t *testing.T) {
+	s := createDefaultConnectedServer(t, false)
+	s.cfg.pooledReplies = true
+	c := cursor{
+		id:         1,
+		batch:      command.NewBatch(nil),
+		server:     s,
+		generation: s.generation,
+	}
+
+	// the cursor keeps the reply holding its batch until it moves past it
+	assert.True(t, c.Next(nil))
+	assert.NotNil(t, c.reply)
+	doc, err := c.DecodeBytes()
+	assert.NoError(t, err)
+	assert.True(t, bytes.Contains(c.reply, doc))
+
+	c.releaseReply()
+	assert.Nil(t, c.reply)
+	assert.Equal(t, 0, c.Batch().Len())
+	_, err = c.DecodeBytes()
+	assert.Error(t, err)
+}
+
+func TestCursorTimeout(t *testing.T) {
+	t.Run("getMores share the remaining budget", func(t *testing.T) {
+		s := createDefaultConnectedServer(t, false)
+		c := cursor{
+			id:         1,
+			batch:      command.NewBatch(nil),
+			server:     s,
+			generation: s.generation,
+			timeout:    time.Minute,
+			timeoutSet: true,
+			deadline:   time.Now().Add(-time.Second),
+		}
+
+		assert.False(t, c.Next(context.Background()))
+		assert.True(t, errors.Is(c.Err(), csot.ErrDeadlineExceeded))
+		assert.True(t, errors.Is(c.Err(), context.DeadlineExceeded))
+	})
+
+	t.Run("each getMore of an iterated cursor gets a fresh budget", func(t *testing.T) {
+		s := createDefaultConnectedServer(t, false)
+		c := cursor{
+			id:         1,
+			batch:      command.NewBatch(nil),
+			server:     s,
+			generation: s.generation,
+			timeout:    time.Minute,
+			timeoutSet: true,
+			iteration:  true,
+			deadline:   time.Now().Add(-time.Second),
+		}
+
+		assert.True(t, c.Next(context.Background()))
+		assert.NoError(t, c.Err())
+	})
+}
+
+func TestCursorServerUnavailable(t *testing.T) {
+	t.Run("server left the topology", func(t *testing.T) {
+		s := createDefaultConnectedServer(t, false)
+		c := cursor{
+			id:         1,
+			batch:      command.NewBatch(nil),
+			server:     s,
+			generation: s.generation,
+		}
+		atomic.StoreInt32(&s.connectionstate, disconnected)
+
+		assert.False(t, c.Next(context.Background()))
+		assert.Equal(t, ErrCursorServerUnavailable, c.Err())
+		assert.Equal(t, int64(0), c.ID())
+	})
+
+	t.Run("server was reconnected", func(t *testing.T) {
+		s := createDefaultConnectedServer(t, false)
+		c := cursor{
+			id:         1,
+			batch:      command.NewBatch(nil),
+			server:     s,
+			generation: s.generation,
+		}
+		atomic.AddUint64(&s.generation, 1)
+
+		assert.Equal(t, ErrCursorServerUnavailable, c.Close(context.Background()))
+		assert.Equal(t, int64(0), c.ID())
+	})
+}
+
 func createDefaultConnectedServer(t *testing.T, willErr bool) *Server {
 	s, err := ConnectServer(nil, "127.0.0.1")
 	s.pool = &mockPool{t: t, willErr: willErr}
@@ -109,6 +267,15 @@ func createOKBatchReplyDoc(id int64, batchDocs *bson.Array) *bson.Document {
 				bson.EC.Array("nextBatch", batchDocs))))
 }
 
+func newBatch(t *testing.T, docs ...*bson.Value) *command.Batch {
+	arr, err := bson.NewArray(docs...).MarshalBSON()
+	if err != nil {
+		t.Fatalf("could not marshal batch: %v", err)
+	}
+
+	return command.NewBatch(arr)
+}
+
 // Mock Pool implementation
 type mockPool struct {
 	t       *testing.T
@@ -162,7 +329,7 @@ func (m *mockConnection) ReadWireMessage(ctx context.Context) (wiremessage.WireM
 		return nil, errors.New("intentional mock error")
 	} else {
 		// write non-empty batch
-		d := createOKBatchReplyDoc(2, bson.NewArray(bson.VC.String("a")))
+		d := createOKBatchReplyDoc(2, bson.NewArray(bson.VC.DocumentFromElements(bson.EC.String("a", "b"))))
 
 		return internal.MakeReply(m.t, d), nil
 	}
@@ -183,3 +350,118 @@ func (*mockConnection) Alive() bool {
 func (*mockConnection) ID() string {
 	return ""
 }
+
+// replayConn is a net.Conn that answers every wire message written to it with reply.
+type replayConn struct {
+	net.Conn
+	reply   []byte
+	pending []byte
+}
+
+func (c *replayConn) Write(b []byte) (int, error) {
+	c.pending = c.reply
+	return len(b), nil
+}
+
+func (c *replayConn) Read(b []byte) (int, error) {
+	n := copy(b, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+func (*replayConn) SetReadDeadline(time.Time) error  { return nil }
+func (*replayConn) SetWriteDeadline(time.Time) error { return nil }
+func (*replayConn) Close() error                     { return nil }
+
+type cursorBuilderFunc func(context.Context, bson.Reader, *session.Client, *session.ClusterClock, ...option.CursorOptioner) (command.Cursor, error)
+
+func (f cursorBuilderFunc) BuildCursor(ctx context.Context, result bson.Reader, sess *session.Client, clock *session.ClusterClock, opts ...option.CursorOptioner) (command.Cursor, error) {
+	return f(ctx, result, sess, clock, opts...)
+}
+
+// BenchmarkCursorFind runs a find returning 1000 documents in its first batch and iterates them.
+func BenchmarkCursorFind(b *testing.B) {
+	b.Run("unpooled", func(b *testing.B) { benchmarkCursorFind(b, false, false) })
+	b.Run("pooled", func(b *testing.B) { benchmarkCursorFind(b, true, false) })
+}
+
+// BenchmarkCursorDecode is BenchmarkCursorFind decoding every document into a struct.
+func BenchmarkCursorDecode(b *testing.B) {
+	b.Run("unpooled", func(b *testing.B) { benchmarkCursorFind(b, false, true) })
+	b.Run("pooled", func(b *testing.B) { benchmarkCursorFind(b, true, true) })
+}
+
+func benchmarkCursorFind(b *testing.B, pooled, decode bool) {
+	docs := make([]*bson.Value, 1000)
+	for i := range docs {
+		docs[i] = bson.VC.DocumentFromElements(
+			bson.EC.Int32("_id", int32(i)),
+			bson.EC.String("name", "benchmark document"),
+			bson.EC.Double("value", float64(i)/3),
+		)
+	}
+	body, err := bson.NewDocument(
+		bson.EC.SubDocumentFromElements("cursor",
+			bson.EC.Int64("id", 0),
+			bson.EC.String("ns", "db.coll"),
+			bson.EC.Array("firstBatch", bson.NewArray(docs...)),
+		),
+		bson.EC.Double("ok", 1),
+	).MarshalBSON()
+	if err != nil {
+		b.Fatal(err)
+	}
+	reply, err := wiremessage.Msg{Sections: []wiremessage.Section{wiremessage.SectionBody{Document: body}}}.MarshalWireMessage()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	conn, _, err := connection.New(context.Background(), address.Address("localhost:27017"),
+		connection.WithDialer(func(connection.Dialer) connection.Dialer {
+			return connection.DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+				return &replayConn{reply: reply}, nil
+			})
+		}),
+		connection.WithPooledReplies(func(bool) bool { return pooled }),
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer conn.Close()
+
+	desc := description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Min: 0, Max: 6}}}
+	cb := cursorBuilderFunc(func(ctx context.Context, result bson.Reader, sess *session.Client, clock *session.ClusterClock, opts ...option.CursorOptioner) (command.Cursor, error) {
+		return newCursor(ctx, result, sess, clock, &Server{cfg: &serverConfig{pooledReplies: pooled}}, opts...)
+	})
+	find := command.Find{NS: command.Namespace{DB: "db", Collection: "coll"}, Filter: bson.NewDocument()}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cur, err := find.RoundTrip(context.Background(), desc, cb, conn)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		var n int
+		var out struct {
+			ID    int32 `bson:"_id"`
+			Name  string
+			Value float64
+		}
+		for cur.Next(context.Background()) {
+			if decode {
+				err = cur.Decode(&out)
+			} else {
+				_, err = cur.DecodeBytes()
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+			n++
+		}
+		if err = cur.Err(); err != nil || n != len(docs) {
+			b.Fatalf("expected %d documents, got %d: %v", len(docs), n, err)
+		}
+	}
+}
@@ -36,32 +36,13 @@ func (f *fsm) apply(s description.Server) (description.Topology, error) {
 	newServers := make([]description.Server, len(f.Servers))
 	copy(newServers, f.Servers)
 
-	oldMinutes := f.SessionTimeoutMinutes
 	f.Topology = description.Topology{
 		Kind:    f.Kind,
 		Servers: newServers,
 	}
 
-	// For data bearing servers, set SessionTimeoutMinutes to the lowest among them
-	if oldMinutes == 0 {
-		// If timeout currently 0, check all servers to see if any still don't have a timeout
-		// If they all have timeout, pick the lowest.
-		timeout := s.SessionTimeoutMinutes
-		for _, server := range f.Servers {
-			if server.DataBearing() && server.SessionTimeoutMinutes < timeout {
-				timeout = server.SessionTimeoutMinutes
-			}
-		}
-		f.SessionTimeoutMinutes = timeout
-	} else {
-		if s.DataBearing() && oldMinutes > s.SessionTimeoutMinutes {
-			f.SessionTimeoutMinutes = s.SessionTimeoutMinutes
-		} else {
-			f.SessionTimeoutMinutes = oldMinutes
-		}
-	}
-
 	if _, ok := f.findServer(s.Addr); !ok {
+		f.SessionTimeoutMinutes = f.sessionTimeoutMinutes()
 		return f.Topology, nil
 	}
 
@@ -101,9 +82,30 @@ func (f *fsm) apply(s description.Server) (description.Topology, error) {
 		f.applyToSingle(s)
 	}
 
+	f.SessionTimeoutMinutes = f.sessionTimeoutMinutes()
 	return f.Topology, nil
 }
 
+// sessionTimeoutMinutes returns the logical session timeout of the topology: the lowest of the
+// timeouts the data bearing servers reported in their last handshake or heartbeat, or 0 if one of
+// them does not support sessions. It is recomputed on every change so that it follows the servers
+// when their configuration changes.
+func (f *fsm) sessionTimeoutMinutes() uint32 {
+	var timeout uint32
+	for _, server := range f.Servers {
+		if !server.DataBearing() {
+			continue
+		}
+		if server.SessionTimeoutMinutes == 0 {
+			return 0
+		}
+		if timeout == 0 || server.SessionTimeoutMinutes < timeout {
+			timeout = server.SessionTimeoutMinutes
+		}
+	}
+	return timeout
+}
+
 func (f *fsm) applyToReplicaSetNoPrimary(s description.Server) {
 	switch s.Kind {
 	case description.Standalone, description.Mongos:
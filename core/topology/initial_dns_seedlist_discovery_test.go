@@ -148,5 +148,5 @@ func getServerByAddress(address string, c *Topology) (description.Server, error)
 	if err != nil {
 		return description.Server{}, err
 	}
-	return selectedServer.Server.Description(), nil
+	return selectedServer.Description().Server, nil
 }
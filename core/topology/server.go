@@ -21,9 +21,12 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/event"
+	"github.com/mongodb/mongo-go-driver/core/logger"
 	"github.com/mongodb/mongo-go-driver/core/option"
 	"github.com/mongodb/mongo-go-driver/core/session"
-
+	"github.com/mongodb/mongo-go-driver/internal/clock"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 )
 
@@ -50,8 +53,9 @@ type SelectedServer struct {
 func (ss *SelectedServer) Description() description.SelectedServer {
 	sdesc := ss.Server.Description()
 	return description.SelectedServer{
-		Server: sdesc,
-		Kind:   ss.Kind,
+		Server:    sdesc,
+		Kind:      ss.Kind,
+		ServerAPI: ss.Server.cfg.serverAPI,
 	}
 }
 
@@ -69,6 +73,8 @@ type Server struct {
 	address address.Address
 
 	connectionstate int32
+	generation      uint64 // incremented each time the server is connected
+	poolGeneration  uint64 // incremented each time the server clears its connection pool
 	done            chan struct{}
 	checkNow        chan struct{}
 	closewg         sync.WaitGroup
@@ -76,8 +82,15 @@ type Server struct {
 
 	desc atomic.Value // holds a description.Server
 
+	// operations counts the operations in progress, which hold a connection to the server, so
+	// that server selection can prefer less loaded servers. statsCtx is tagged with the address
+	// of the server and the ID of its topology, and used to record it and the heartbeats.
+	operations *observability.Gauge
+	statsCtx   context.Context
+
 	averageRTTSet bool
 	averageRTT    time.Duration
+	rttSamples    []time.Duration // the most recent round trip times, oldest first
 
 	subLock             sync.Mutex
 	subscribers         map[uint64]chan description.Server
@@ -116,6 +129,12 @@ func NewServer(addr address.Address, opts ...ServerOption) (*Server, error) {
 		checkNow: make(chan struct{}, 1),
 
 		subscribers: make(map[uint64]chan description.Server),
+
+		operations: observability.NewGauge(observability.MOperationsInProgress),
+	}
+	s.statsCtx = observability.Tag(context.Background(), tag.Upsert(observability.KeyServerAddress, addr.String()))
+	if cfg.topologyID != "" {
+		s.statsCtx = observability.Tag(s.statsCtx, tag.Upsert(observability.KeyTopologyID, cfg.topologyID))
 	}
 	s.desc.Store(description.Server{Addr: addr})
 
@@ -126,7 +145,11 @@ func NewServer(addr address.Address, opts ...ServerOption) (*Server, error) {
 		maxConns = uint64(cfg.maxConns)
 	}
 
-	s.pool, err = connection.NewPool(addr, uint64(cfg.maxIdleConns), maxConns, cfg.connectionOpts...)
+	connOpts := append(cfg.connectionOpts,
+		connection.WithMinPoolSize(func(uint64) uint64 { return uint64(cfg.minConns) }),
+		connection.WithMaxConnecting(func(uint64) uint64 { return uint64(cfg.maxConnecting) }),
+	)
+	s.pool, err = connection.NewPool(addr, uint64(cfg.maxIdleConns), maxConns, connOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +163,7 @@ func (s *Server) Connect(ctx context.Context) error {
 	if !atomic.CompareAndSwapInt32(&s.connectionstate, disconnected, connected) {
 		return ErrServerConnected
 	}
+	atomic.AddUint64(&s.generation, 1)
 	s.desc.Store(description.Server{Addr: s.address})
 	go s.update()
 	s.closewg.Add(1)
@@ -176,7 +200,8 @@ func (s *Server) Disconnect(ctx context.Context) error {
 
 // Connection gets a connection to the server.
 func (s *Server) Connection(ctx context.Context) (connection.Connection, error) {
-	ctx, span := trace.StartSpan(ctx, "mongo-go-driver/core/topology.(*Server).Connection")
+	ctx = observability.Tag(ctx, tag.Upsert(observability.KeyServerAddress, s.address.String()))
+	ctx, span := observability.StartSpan(ctx, "mongo-go-driver/core/topology.(*Server).Connection")
 	defer span.End()
 
 	if atomic.LoadInt32(&s.connectionstate) != connected {
@@ -186,19 +211,46 @@ func (s *Server) Connection(ctx context.Context) (connection.Connection, error)
 	conn, desc, err := s.pool.Get(ctx)
 	span.Annotatef(nil, "Finished s.pool.Get")
 	if err != nil {
-		if _, ok := err.(*auth.Error); ok {
+		var authErr *auth.Error
+		if errors.As(err, &authErr) {
 			// authentication error --> drain connection
-			_ = s.pool.Drain()
+			logger.Log(s.cfg.logger, logger.LevelWarn, logger.ComponentConnection, "Connection pool cleared",
+				"address", s.address.String(), "error", err)
+			_ = s.clearPool()
 		}
 		return nil, err
 	}
 	if desc != nil {
 		go s.updateDescription(*desc, false)
 	}
-	sc := &sconn{Connection: conn, s: s}
+	s.operations.Add(s.statsCtx, 1)
+	sc := &sconn{
+		Connection: conn,
+		s:          s,
+		generation: atomic.LoadUint64(&s.poolGeneration),
+		desc:       s.Description(),
+	}
 	return sc, nil
 }
 
+// clearPool drains the connection pool of the server. Errors from the connections checked out
+// before are then ignored, since the state change they report has already been handled.
+func (s *Server) clearPool() error {
+	atomic.AddUint64(&s.poolGeneration, 1)
+	return s.pool.Drain()
+}
+
+// OperationCount returns the number of operations in progress on the server, which are those
+// holding a connection returned by Connection that they have not closed yet.
+func (s *Server) OperationCount() int64 {
+	return s.operations.Value()
+}
+
+// available reports whether the server is connected and has not been reconnected since generation.
+func (s *Server) available(generation uint64) bool {
+	return atomic.LoadInt32(&s.connectionstate) == connected && atomic.LoadUint64(&s.generation) == generation
+}
+
 // Description returns a description of the server as of the last heartbeat.
 func (s *Server) Description() description.Server {
 	return s.desc.Load().(description.Server)
@@ -210,8 +262,9 @@ func (s *Server) Description() description.Server {
 func (s *Server) SelectedDescription() description.SelectedServer {
 	sdesc := s.Description()
 	return description.SelectedServer{
-		Server: sdesc,
-		Kind:   description.Single,
+		Server:    sdesc,
+		Kind:      description.Single,
+		ServerAPI: s.cfg.serverAPI,
 	}
 }
 
@@ -256,8 +309,8 @@ func (s *Server) RequestImmediateCheck() {
 // newest description.Server retrieved.
 func (s *Server) update() {
 	defer s.closewg.Done()
-	heartbeatTicker := time.NewTicker(s.cfg.heartbeatInterval)
-	rateLimiter := time.NewTicker(minHeartbeatInterval)
+	heartbeatTicker := s.cfg.timeSource.NewTicker(s.cfg.heartbeatInterval)
+	rateLimiter := s.cfg.timeSource.NewTicker(minHeartbeatInterval)
 	defer heartbeatTicker.Stop()
 	defer rateLimiter.Stop()
 	checkNow := s.checkNow
@@ -277,7 +330,16 @@ func (s *Server) update() {
 	var conn connection.Connection
 	var desc description.Server
 
+	// The monitoring connection is not re-established before retry, which backs off while the
+	// server cannot be reached.
+	var backoff time.Duration
+	var retry time.Time
+
 	desc, conn = s.heartbeat(nil)
+	if conn == nil {
+		backoff = s.heartbeatBackoff(backoff)
+		retry = s.cfg.timeSource.Now().Add(backoff)
+	}
 	s.updateDescription(desc, true)
 
 	closeServer := func() {
@@ -296,7 +358,7 @@ func (s *Server) update() {
 	}
 	for {
 		select {
-		case <-heartbeatTicker.C:
+		case <-heartbeatTicker.C():
 		case <-checkNow:
 		case <-done:
 			closeServer()
@@ -304,28 +366,63 @@ func (s *Server) update() {
 		}
 
 		select {
-		case <-rateLimiter.C:
+		case <-rateLimiter.C():
 		case <-done:
 			closeServer()
 			return
 		}
 
+		if wait := retry.Sub(s.cfg.timeSource.Now()); wait > 0 {
+			timer := s.cfg.timeSource.NewTimer(wait)
+			select {
+			case <-timer.C():
+			case <-done:
+				timer.Stop()
+				closeServer()
+				return
+			}
+		}
+
 		desc, conn = s.heartbeat(conn)
+		if conn == nil {
+			backoff = s.heartbeatBackoff(backoff)
+			retry = s.cfg.timeSource.Now().Add(backoff)
+		} else {
+			backoff = 0
+		}
 		s.updateDescription(desc, false)
 	}
 }
 
-// updateDescription handles updating the description on the Server, notifying
-// subscribers, and potentially draining the connection pool. The initial
-// parameter is used to determine if this is the first description from the
-// server.
-func (s *Server) updateDescription(desc description.Server, initial bool) {
+// heartbeatBackoff returns how long the monitor waits before re-establishing its connection after
+// it failed again, given how long it waited after the previous failure. It starts at the minimum
+// heartbeat interval and doubles after each failure, up to the heartbeat interval.
+func (s *Server) heartbeatBackoff(prev time.Duration) time.Duration {
+	next := 2 * prev
+	if next < minHeartbeatInterval {
+		next = minHeartbeatInterval
+	}
+	if next > s.cfg.heartbeatInterval {
+		next = s.cfg.heartbeatInterval
+	}
+	return next
+}
+
+// setDescription stores desc as the description of the server and notifies the subscribers.
+func (s *Server) setDescription(desc description.Server) {
 	defer func() {
 		//  ¯\_(ツ)_/¯
 		_ = recover()
 	}()
+	prev := s.desc.Load().(description.Server)
 	s.desc.Store(desc)
 
+	if prev.Kind != desc.Kind {
+		logger.Log(s.cfg.logger, logger.LevelInfo, logger.ComponentTopology, "Server description changed",
+			"address", s.address.String(), "previousKind", prev.Kind.String(), "newKind", desc.Kind.String())
+		s.traceKindChange(prev, desc)
+	}
+
 	s.subLock.Lock()
 	for _, c := range s.subscribers {
 		select {
@@ -336,15 +433,43 @@ func (s *Server) updateDescription(desc description.Server, initial bool) {
 		c <- desc
 	}
 	s.subLock.Unlock()
+}
+
+// traceKindChange records a span for a change of the type of the server, so that servers flapping
+// between types show up in traces.
+func (s *Server) traceKindChange(prev, desc description.Server) {
+	_, span := observability.StartSpan(s.statsCtx, "mongo-go/core/topology.(*Server).descriptionChanged")
+	defer span.End()
 
-	if initial {
-		// We don't clear the pool on the first update on the description.
+	span.AddAttributes(
+		trace.StringAttribute("address", s.address.String()),
+		trace.StringAttribute("topology_id", s.cfg.topologyID),
+	)
+	span.Annotate([]trace.Attribute{
+		trace.StringAttribute("previous_kind", prev.Kind.String()),
+		trace.StringAttribute("new_kind", desc.Kind.String()),
+	}, "Server description changed")
+	span.SetStatus(observability.SpanStatus(desc.LastError))
+}
+
+// updateDescription handles updating the description on the Server, notifying
+// subscribers, and potentially draining the connection pool. The initial
+// parameter is used to determine if this is the first description from the
+// server.
+func (s *Server) updateDescription(desc description.Server, initial bool) {
+	s.setDescription(desc)
+
+	if initial && desc.LastError == nil {
+		// We don't clear the pool on the first update on the description, unless the monitor
+		// failed to reach the server.
 		return
 	}
 
 	switch desc.Kind {
 	case description.Unknown:
-		_ = s.pool.Drain()
+		logger.Log(s.cfg.logger, logger.LevelInfo, logger.ComponentConnection, "Connection pool cleared",
+			"address", s.address.String(), "error", desc.LastError)
+		_ = s.clearPool()
 	}
 }
 
@@ -364,11 +489,14 @@ func (s *Server) heartbeat(conn connection.Connection) (description.Server, conn
 		}
 
 		if conn == nil {
+			// The monitor dials its own connection rather than checking one out of the pool, so that
+			// it is not held up by an exhausted pool. connectTimeoutMS overrides the heartbeat timeout
+			// and is used as the socket timeout too, in place of socketTimeoutMS.
 			opts := []connection.Option{
 				connection.WithConnectTimeout(func(time.Duration) time.Duration { return s.cfg.heartbeatTimeout }),
-				connection.WithReadTimeout(func(time.Duration) time.Duration { return s.cfg.heartbeatTimeout }),
 			}
 			opts = append(opts, s.cfg.connectionOpts...)
+			opts = append(opts, connection.WithMonitoringTimeouts())
 			// We override whatever handshaker is currently attached to the options with an empty
 			// one because need to make sure we don't do auth.
 			opts = append(opts, connection.WithHandshaker(func(h connection.Handshaker) connection.Handshaker {
@@ -379,6 +507,10 @@ func (s *Server) heartbeat(conn connection.Connection) (description.Server, conn
 			opts = append(opts, connection.WithMonitor(func(*event.CommandMonitor) *event.CommandMonitor {
 				return nil
 			}))
+			// Heartbeats are not logged as commands either.
+			opts = append(opts, connection.WithLogger(func(l logger.Logger) logger.Logger {
+				return heartbeatLogger{l}
+			}))
 			conn, _, err = connection.New(ctx, s.address, opts...)
 			if err != nil {
 				saved = err
@@ -390,9 +522,9 @@ func (s *Server) heartbeat(conn connection.Connection) (description.Server, conn
 			}
 		}
 
-		now := time.Now()
+		now := s.cfg.timeSource.Now()
 
-		isMasterCmd := &command.IsMaster{Compressors: s.cfg.compressionOpts}
+		isMasterCmd := &command.IsMaster{Compressors: s.cfg.compressionOpts, ServerAPI: s.cfg.serverAPI}
 		isMaster, err := isMasterCmd.RoundTrip(ctx, conn)
 		if err != nil {
 			saved = err
@@ -406,8 +538,16 @@ func (s *Server) heartbeat(conn connection.Connection) (description.Server, conn
 			s.cfg.clock.AdvanceClusterTime(clusterTime)
 		}
 
-		delay := time.Since(now)
-		desc = description.NewServer(s.address, isMaster).SetAverageRTT(s.updateAverageRTT(delay))
+		delay := clock.Since(s.cfg.timeSource, now)
+		observability.Record(s.statsCtx, observability.MHeartbeatLatencyMilliseconds.M(delay.Seconds()*1000))
+		desc = description.NewServer(s.address, isMaster)
+		desc.LastUpdateTime = s.cfg.timeSource.Now().UTC()
+		if desc.Kind != s.Description().Kind {
+			// round trip times measured while the server had another type are not representative
+			s.resetRTT()
+		}
+		desc = desc.SetAverageRTT(s.updateAverageRTT(delay))
+		desc.MinRTT = s.updateMinRTT(delay)
 		desc.HeartbeatInterval = s.cfg.heartbeatInterval
 		set = true
 
@@ -415,6 +555,10 @@ func (s *Server) heartbeat(conn connection.Connection) (description.Server, conn
 	}
 
 	if !set {
+		observability.Record(s.statsCtx, observability.MHeartbeatFailures.M(1))
+		logger.Log(s.cfg.logger, logger.LevelWarn, logger.ComponentTopology, "Server heartbeat failed",
+			"address", s.address.String(), "error", saved)
+		s.resetRTT()
 		desc = description.Server{
 			Addr:      s.address,
 			LastError: saved,
@@ -424,9 +568,24 @@ func (s *Server) heartbeat(conn connection.Connection) (description.Server, conn
 	return desc, conn
 }
 
+// heartbeatLogger is the logger of heartbeat connections, which does not log commands.
+type heartbeatLogger struct {
+	logger.Logger
+}
+
+func (hl heartbeatLogger) Enabled(level logger.Level, component logger.Component) bool {
+	return component != logger.ComponentCommand && hl.Logger.Enabled(level, component)
+}
+
+// minRTTSamples is the number of round trip times the minimum round trip time is computed over.
+const minRTTSamples = 10
+
+// updateAverageRTT adds delay to the exponentially weighted moving average of the round trip time,
+// which weights the last 5 samples the most, and returns the new average.
 func (s *Server) updateAverageRTT(delay time.Duration) time.Duration {
 	if !s.averageRTTSet {
 		s.averageRTT = delay
+		s.averageRTTSet = true
 	} else {
 		alpha := 0.2
 		s.averageRTT = time.Duration(alpha*float64(delay) + (1-alpha)*float64(s.averageRTT))
@@ -434,17 +593,46 @@ func (s *Server) updateAverageRTT(delay time.Duration) time.Duration {
 	return s.averageRTT
 }
 
+// updateMinRTT adds delay to the recent round trip times and returns the minimum of the last
+// minRTTSamples of them, or 0 until at least 2 have been measured.
+func (s *Server) updateMinRTT(delay time.Duration) time.Duration {
+	if len(s.rttSamples) == minRTTSamples {
+		copy(s.rttSamples, s.rttSamples[1:])
+		s.rttSamples = s.rttSamples[:minRTTSamples-1]
+	}
+	s.rttSamples = append(s.rttSamples, delay)
+
+	if len(s.rttSamples) < 2 {
+		return 0
+	}
+
+	min := s.rttSamples[0]
+	for _, rtt := range s.rttSamples[1:] {
+		if rtt < min {
+			min = rtt
+		}
+	}
+	return min
+}
+
+// resetRTT discards the round trip times measured so far.
+func (s *Server) resetRTT() {
+	s.averageRTTSet = false
+	s.averageRTT = 0
+	s.rttSamples = s.rttSamples[:0]
+}
+
 // Drain will drain the connection pool of this server. This is mainly here so the
 // pool for the server doesn't need to be directly exposed and so that when an error
 // is returned from reading or writing, a client can drain the pool for this server.
 // This is exposed here so we don't have to wrap the Connection type and sniff responses
 // for errors that would cause the pool to be drained, which can in turn centralize the
 // logic for handling errors in the Client type.
-func (s *Server) Drain() error { return s.pool.Drain() }
+func (s *Server) Drain() error { return s.clearPool() }
 
 // BuildCursor implements the command.CursorBuilder interface for the Server type.
-func (s *Server) BuildCursor(result bson.Reader, clientSession *session.Client, clock *session.ClusterClock, opts ...option.CursorOptioner) (command.Cursor, error) {
-	return newCursor(result, clientSession, clock, s, opts...)
+func (s *Server) BuildCursor(ctx context.Context, result bson.Reader, clientSession *session.Client, clock *session.ClusterClock, opts ...option.CursorOptioner) (command.Cursor, error) {
+	return newCursor(ctx, result, clientSession, clock, s, opts...)
 }
 
 // ServerSubscription represents a subscription to the description.Server updates for
@@ -10,7 +10,10 @@ import (
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/connection"
+	"github.com/mongodb/mongo-go-driver/core/logger"
+	"github.com/mongodb/mongo-go-driver/core/serverapi"
 	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/internal/clock"
 )
 
 type serverConfig struct {
@@ -22,6 +25,13 @@ type serverConfig struct {
 	heartbeatTimeout  time.Duration
 	maxConns          uint16
 	maxIdleConns      uint16
+	minConns          uint16
+	maxConnecting     uint16
+	logger            logger.Logger
+	serverAPI         *serverapi.Options
+	pooledReplies     bool
+	topologyID        string
+	timeSource        clock.Clock
 }
 
 func newServerConfig(opts ...ServerOption) (*serverConfig, error) {
@@ -30,6 +40,9 @@ func newServerConfig(opts ...ServerOption) (*serverConfig, error) {
 		heartbeatTimeout:  30 * time.Second,
 		maxConns:          100,
 		maxIdleConns:      100,
+		maxConnecting:     2,
+		logger:            logger.Nop,
+		timeSource:        clock.Real,
 	}
 
 	for _, opt := range opts {
@@ -69,8 +82,9 @@ func WithHeartbeatInterval(fn func(time.Duration) time.Duration) ServerOption {
 	}
 }
 
-// WithHeartbeatTimeout configures how long to wait for a heartbeat socket to
-// connection.
+// WithHeartbeatTimeout configures how long to wait for the monitoring connection to connect and
+// for heartbeats to complete. A connect timeout configured by the connection options takes its
+// place.
 func WithHeartbeatTimeout(fn func(time.Duration) time.Duration) ServerOption {
 	return func(cfg *serverConfig) error {
 		cfg.heartbeatTimeout = fn(cfg.heartbeatTimeout)
@@ -88,6 +102,24 @@ func WithMaxConnections(fn func(uint16) uint16) ServerOption {
 	}
 }
 
+// WithMinConnections configures the number of idle connections established to the server as soon
+// as it is connected. It is capped by the maximum number of idle connections.
+func WithMinConnections(fn func(uint16) uint16) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.minConns = fn(cfg.minConns)
+		return nil
+	}
+}
+
+// WithMaxConnecting configures the maximum number of connections established concurrently while
+// the connection pool of the server is filled up to its minimum size. The default is 2.
+func WithMaxConnecting(fn func(uint16) uint16) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.maxConnecting = fn(cfg.maxConnecting)
+		return nil
+	}
+}
+
 // WithMaxIdleConnections configures the maximum number of idle connections
 // allowed for the server.
 func WithMaxIdleConnections(fn func(uint16) uint16) ServerOption {
@@ -97,10 +129,62 @@ func WithMaxIdleConnections(fn func(uint16) uint16) ServerOption {
 	}
 }
 
-// WithClock configures the ClusterClock for the server to use.
+// WithPooledReplies configures whether the server's connections read the documents of replies
+// into pooled buffers. Cursors created by the server then return the reply holding each batch to
+// the pool when they move on to the next batch or are closed, so the documents returned by their
+// DecodeBytes and Batch methods are only valid until then.
+func WithPooledReplies(fn func(bool) bool) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.pooledReplies = fn(cfg.pooledReplies)
+		pooled := cfg.pooledReplies
+		cfg.connectionOpts = append(cfg.connectionOpts, connection.WithPooledReplies(func(bool) bool { return pooled }))
+		return nil
+	}
+}
+
+// WithClock configures the ClusterClock for the server to use. The cluster time of the replies to
+// the commands and heartbeats of the server advances the clock, and commands sent to servers that
+// support sessions carry its cluster time. A topology configures its servers with its own clock.
 func WithClock(fn func(clock *session.ClusterClock) *session.ClusterClock) ServerOption {
 	return func(cfg *serverConfig) error {
 		cfg.clock = fn(cfg.clock)
 		return nil
 	}
 }
+
+// withServerLogger configures the logger used by the server and its connections. It is set by the
+// topology that creates the server.
+func withServerLogger(l logger.Logger) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.logger = l
+		cfg.connectionOpts = append(cfg.connectionOpts, connection.WithLogger(func(logger.Logger) logger.Logger { return l }))
+		return nil
+	}
+}
+
+// withServerTimeSource configures the clock the server times its heartbeats and their round trips
+// with. It is set by the topology that creates the server.
+func withServerTimeSource(c clock.Clock) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.timeSource = c
+		return nil
+	}
+}
+
+// withTopologyID configures the ID of the topology monitoring the server, which its heartbeat
+// metrics are tagged with. It is set by the topology that creates the server.
+func withTopologyID(id string) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.topologyID = id
+		return nil
+	}
+}
+
+// withServerAPI configures the server API declared for the commands the server sends, including
+// heartbeats. It is set by the topology that creates the server.
+func withServerAPI(api *serverapi.Options) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.serverAPI = api
+		return nil
+	}
+}
@@ -21,7 +21,7 @@ import (
 func TestServerSelectionRTTSpec(t *testing.T) {
 
 	type testCase struct {
-		AvgRttMs  json.Number `json:"avg_rtt_ms"`
+		AvgRttMs  interface{} `json:"avg_rtt_ms"` // either a number or "NULL"
 		NewRttMs  float64     `json:"new_rtt_ms"`
 		NewAvgRtt float64     `json:"new_avg_rtt"`
 	}
@@ -43,10 +43,7 @@ func TestServerSelectionRTTSpec(t *testing.T) {
 
 				var server Server
 
-				if test.AvgRttMs != "NULL" {
-					avg, err := test.AvgRttMs.Float64()
-					require.NoError(t, err)
-
+				if avg, ok := test.AvgRttMs.(float64); ok {
 					server.averageRTT = time.Duration(avg * float64(time.Millisecond))
 					server.averageRTTSet = true
 				}
@@ -57,3 +54,49 @@ func TestServerSelectionRTTSpec(t *testing.T) {
 		}(t, file)
 	}
 }
+
+func TestServerRTT(t *testing.T) {
+	ms := func(n float64) time.Duration { return time.Duration(n * float64(time.Millisecond)) }
+
+	t.Run("average converges", func(t *testing.T) {
+		var server Server
+		require.Equal(t, ms(100), server.updateAverageRTT(ms(100)))
+
+		// after a step change, the average moves 20% of the remaining distance per sample
+		var avg time.Duration
+		for i := 0; i < 5; i++ {
+			avg = server.updateAverageRTT(ms(10))
+		}
+		require.InDelta(t, float64(ms(10+90*0.32768)), float64(avg), float64(ms(0.01)))
+		for i := 0; i < 45; i++ {
+			avg = server.updateAverageRTT(ms(10))
+		}
+		require.InDelta(t, float64(ms(10)), float64(avg), float64(ms(0.01)))
+
+		// a single outlier only moves the average by a fifth of its distance
+		avg = server.updateAverageRTT(ms(60))
+		require.InDelta(t, float64(ms(20)), float64(avg), float64(ms(0.01)))
+	})
+	t.Run("minimum of recent samples", func(t *testing.T) {
+		var server Server
+		require.Equal(t, time.Duration(0), server.updateMinRTT(ms(5)), "a single sample is not enough")
+		require.Equal(t, ms(5), server.updateMinRTT(ms(20)))
+
+		// the 5ms sample falls out of the window after minRTTSamples more samples
+		for i := 0; i < minRTTSamples-2; i++ {
+			require.Equal(t, ms(5), server.updateMinRTT(ms(20)))
+		}
+		require.Equal(t, ms(20), server.updateMinRTT(ms(30)))
+		require.Equal(t, ms(15), server.updateMinRTT(ms(15)))
+	})
+	t.Run("reset", func(t *testing.T) {
+		var server Server
+		server.updateAverageRTT(ms(100))
+		server.updateMinRTT(ms(100))
+		server.updateMinRTT(ms(100))
+
+		server.resetRTT()
+		require.Equal(t, ms(10), server.updateAverageRTT(ms(10)))
+		require.Equal(t, time.Duration(0), server.updateMinRTT(ms(10)))
+	})
+}
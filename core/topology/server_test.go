@@ -8,25 +8,41 @@ package topology
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/address"
 	"github.com/mongodb/mongo-go-driver/core/auth"
+	"github.com/mongodb/mongo-go-driver/core/command"
 	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/mongodb/mongo-go-driver/internal/testutil/fakeclock"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 )
 
 type pool struct {
 	connectionError bool
 	drainCalled     bool
+	getCalled       bool
 }
 
 func (p *pool) Get(ctx context.Context) (connection.Connection, *description.Server, error) {
+	p.getCalled = true
 	if p.connectionError {
 		return nil, nil, &auth.Error{}
 	}
-	return nil, nil, nil
+	return &conn{}, nil, nil
 }
 
 func (p *pool) Connect(ctx context.Context) error {
@@ -42,6 +58,28 @@ func (p *pool) Drain() error {
 	return nil
 }
 
+// conn is a connection that only records being closed.
+type conn struct {
+	connection.Connection
+	closed bool
+}
+
+func (c *conn) Close() error {
+	c.closed = true
+	return nil
+}
+
+// drainPool is a pool that signals being drained.
+type drainPool struct {
+	pool
+	drained chan struct{}
+}
+
+func (p *drainPool) Drain() error {
+	p.drained <- struct{}{}
+	return nil
+}
+
 func NewPool(connectionError bool) (connection.Pool, error) {
 	p := &pool{
 		connectionError: connectionError,
@@ -78,3 +116,341 @@ func TestSever(t *testing.T) {
 		})
 	}
 }
+
+func TestServerMonitor(t *testing.T) {
+	t.Run("failure clears pool", func(t *testing.T) {
+		var dials int
+		dialer := connection.DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+			dials++
+			return nil, errors.New("connection refused")
+		})
+		s, err := NewServer(address.Address("localhost"), WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+			return append(opts, connection.WithDialer(func(connection.Dialer) connection.Dialer { return dialer }))
+		}))
+		require.NoError(t, err)
+		p := &pool{}
+		s.pool = p
+
+		desc, conn := s.heartbeat(nil)
+		require.Nil(t, conn)
+		require.Equal(t, description.ServerKind(description.Unknown), desc.Kind)
+		require.Error(t, desc.LastError)
+		require.Equal(t, 2, dials)
+		require.False(t, p.getCalled)
+
+		s.updateDescription(desc, true)
+		require.True(t, p.drainCalled)
+	})
+	t.Run("connect timeout is socket timeout", func(t *testing.T) {
+		dialer := connection.DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+			// the server end is never read from, so the heartbeat blocks until it times out
+			client, _ := net.Pipe()
+			return client, nil
+		})
+		s, err := NewServer(address.Address("localhost"), WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+			return append(opts,
+				connection.WithDialer(func(connection.Dialer) connection.Dialer { return dialer }),
+				connection.WithConnectTimeout(func(time.Duration) time.Duration { return 50 * time.Millisecond }),
+				connection.WithReadTimeout(func(time.Duration) time.Duration { return time.Hour }),
+				connection.WithWriteTimeout(func(time.Duration) time.Duration { return time.Hour }),
+			)
+		}))
+		require.NoError(t, err)
+
+		start := time.Now()
+		desc, conn := s.heartbeat(nil)
+		require.Nil(t, conn)
+		require.Error(t, desc.LastError)
+		require.True(t, time.Since(start) < 5*time.Second, "heartbeat took %v", time.Since(start))
+	})
+	t.Run("backoff", func(t *testing.T) {
+		s, err := NewServer(address.Address("localhost"), WithHeartbeatInterval(func(time.Duration) time.Duration { return 3 * time.Second }))
+		require.NoError(t, err)
+
+		var backoffs []time.Duration
+		var backoff time.Duration
+		for i := 0; i < 5; i++ {
+			backoff = s.heartbeatBackoff(backoff)
+			backoffs = append(backoffs, backoff)
+		}
+		require.Equal(t, []time.Duration{
+			minHeartbeatInterval, 2 * minHeartbeatInterval, 4 * minHeartbeatInterval, 3 * time.Second, 3 * time.Second,
+		}, backoffs)
+	})
+	t.Run("backoff delays checks", func(t *testing.T) {
+		clk := fakeclock.New(time.Now())
+		dials := make(chan struct{}, 100)
+		dialer := connection.DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+			dials <- struct{}{}
+			return nil, errors.New("connection refused")
+		})
+		s, err := NewServer(address.Address("localhost"),
+			WithHeartbeatInterval(func(time.Duration) time.Duration { return 10 * time.Second }),
+			WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+				return append(opts, connection.WithDialer(func(connection.Dialer) connection.Dialer { return dialer }))
+			}),
+			withServerTimeSource(clk),
+		)
+		require.NoError(t, err)
+		// the pool is drained after every failed heartbeat, once the next check is scheduled
+		p := &drainPool{drained: make(chan struct{}, 10)}
+		s.pool = p
+		require.NoError(t, s.Connect(context.Background()))
+		defer func() { _ = s.Disconnect(context.Background()) }()
+
+		// each heartbeat dials twice, as it retries once
+		<-p.drained
+		require.Len(t, dials, 2)
+
+		// the first backoff is as long as the rate limit of immediate checks
+		s.RequestImmediateCheck()
+		clk.Advance(minHeartbeatInterval)
+		<-p.drained
+		require.Len(t, dials, 4)
+
+		// the second backoff outlasts the rate limit, so the check waits on a timer
+		s.RequestImmediateCheck()
+		clk.Advance(minHeartbeatInterval)
+		clk.BlockUntil(3)
+		require.Len(t, dials, 4)
+		clk.Advance(minHeartbeatInterval)
+		<-p.drained
+		require.Len(t, dials, 6)
+	})
+}
+
+func TestServerProcessError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		err         error
+		maxWire     int32
+		unknown     bool
+		drainCalled bool
+	}{
+		{"not master", command.Error{Code: 10107, Message: "not master"}, 8, true, true},
+		{"not master message", command.Error{Code: 1, Message: "not master"}, 8, true, true},
+		{"node is recovering pre-4.2", command.Error{Code: 11602, Message: "InterruptedDueToReplStateChange"}, 7, true, true},
+		{"node is recovering 4.2", command.Error{Code: 11602, Message: "InterruptedDueToReplStateChange"}, 8, true, false},
+		{"not master or secondary 4.2", command.Error{Code: 1, Message: "not master or secondary"}, 8, true, false},
+		{"shutdown 4.2", command.Error{Code: 91, Message: "ShutdownInProgress"}, 8, true, true},
+		{"interrupted at shutdown 4.2", command.Error{Code: 11600, Message: "InterruptedAtShutdown"}, 8, true, true},
+		{"network error", connection.NetworkError{ConnectionID: "foo", Wrapped: errors.New("connection reset")}, 8, true, true},
+		{"network timeout", connection.NetworkError{ConnectionID: "foo", Wrapped: context.DeadlineExceeded}, 8, false, false},
+		{"other command error", command.Error{Code: 2, Message: "BadValue"}, 8, false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewServer(address.Address("localhost"))
+			require.NoError(t, err)
+			p := &pool{}
+			s.pool = p
+			s.desc.Store(description.Server{
+				Addr:        s.address,
+				Kind:        description.RSPrimary,
+				WireVersion: &description.VersionRange{Max: tc.maxWire},
+			})
+
+			s.processError(tc.err, 0, s.Description())
+			require.Equal(t, tc.unknown, s.Description().Kind == description.ServerKind(description.Unknown))
+			require.Equal(t, tc.drainCalled, p.drainCalled)
+			require.Equal(t, tc.unknown, len(s.checkNow) == 1)
+			if tc.unknown {
+				require.Equal(t, tc.err, s.Description().LastError)
+			}
+		})
+	}
+
+	newServer := func(t *testing.T) (*Server, *pool) {
+		s, err := NewServer(address.Address("localhost"))
+		require.NoError(t, err)
+		p := &pool{}
+		s.pool = p
+		s.connectionstate = connected
+		s.desc.Store(description.Server{
+			Addr:        s.address,
+			Kind:        description.RSPrimary,
+			WireVersion: &description.VersionRange{Max: 8},
+		})
+		return s, p
+	}
+	networkErr := connection.NetworkError{ConnectionID: "foo", Wrapped: errors.New("connection reset")}
+	recoveringErr := command.Error{Code: 11602, Message: "InterruptedDueToReplStateChange"}
+
+	t.Run("stale connection", func(t *testing.T) {
+		s, p := newServer(t)
+		stale, err := s.Connection(context.Background())
+		require.NoError(t, err)
+		current, err := s.Connection(context.Background())
+		require.NoError(t, err)
+
+		current.(*sconn).processErr(networkErr)
+		require.True(t, p.drainCalled)
+		<-s.checkNow
+		fresh, err := s.Connection(context.Background())
+		require.NoError(t, err)
+
+		// the error of a connection checked out before the pool was cleared is ignored
+		p.drainCalled = false
+		stale.(*sconn).processErr(networkErr)
+		require.False(t, p.drainCalled)
+		require.Len(t, s.checkNow, 0)
+
+		fresh.(*sconn).processErr(networkErr)
+		require.True(t, p.drainCalled)
+		require.Len(t, s.checkNow, 1)
+	})
+	t.Run("description at checkout", func(t *testing.T) {
+		s, p := newServer(t)
+		first, err := s.Connection(context.Background())
+		require.NoError(t, err)
+		second, err := s.Connection(context.Background())
+		require.NoError(t, err)
+
+		// the first error marks the server Unknown without a wire version, but the server of the
+		// second connection still keeps its connections
+		first.(*sconn).processErr(recoveringErr)
+		require.Equal(t, description.ServerKind(description.Unknown), s.Description().Kind)
+		require.Nil(t, s.Description().WireVersion)
+		second.(*sconn).processErr(recoveringErr)
+		require.False(t, p.drainCalled)
+	})
+}
+
+// spanRecorder is a trace.Exporter that keeps the spans it is given.
+type spanRecorder struct {
+	sync.Mutex
+	spans []*trace.SpanData
+}
+
+func (r *spanRecorder) ExportSpan(s *trace.SpanData) {
+	r.Lock()
+	defer r.Unlock()
+	r.spans = append(r.spans, s)
+}
+
+// isMasterDialer returns a dialer whose connections answer a single isMaster with doc.
+func isMasterDialer(t *testing.T, doc *bson.Document) connection.Dialer {
+	b, err := doc.MarshalBSON()
+	require.NoError(t, err)
+
+	return connection.DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go func() {
+			var header [16]byte
+			if _, err := io.ReadFull(server, header[:]); err != nil {
+				return
+			}
+			size := int32(binary.LittleEndian.Uint32(header[0:4]))
+			if _, err := io.ReadFull(server, make([]byte, size-16)); err != nil {
+				return
+			}
+			reply, _ := wiremessage.Reply{
+				MsgHeader:      wiremessage.Header{ResponseTo: int32(binary.LittleEndian.Uint32(header[4:8]))},
+				NumberReturned: 1,
+				Documents:      []bson.Reader{b},
+			}.MarshalWireMessage()
+			_, _ = server.Write(reply)
+		}()
+		return client, nil
+	})
+}
+
+func TestServerHeartbeatInstrumentation(t *testing.T) {
+	keys := []tag.Key{observability.KeyServerAddress, observability.KeyTopologyID}
+	latency := &view.View{Name: "test/heartbeat_latency", Measure: observability.MHeartbeatLatencyMilliseconds, Aggregation: view.Count(), TagKeys: keys}
+	failures := &view.View{Name: "test/heartbeat_failures", Measure: observability.MHeartbeatFailures, Aggregation: view.Count(), TagKeys: keys}
+	require.NoError(t, view.Register(latency, failures))
+	defer view.Unregister(latency, failures)
+
+	// count returns the number of measurements recorded by v for the server.
+	count := func(t *testing.T, v *view.View, addr string) int64 {
+		rows, err := view.RetrieveData(v.Name)
+		require.NoError(t, err)
+		var n int64
+		for _, row := range rows {
+			tags := make(map[tag.Key]string)
+			for _, tg := range row.Tags {
+				tags[tg.Key] = tg.Value
+			}
+			if tags[observability.KeyServerAddress] == addr {
+				require.Equal(t, "topology", tags[observability.KeyTopologyID])
+				n += row.Data.(*view.CountData).Value
+			}
+		}
+		return n
+	}
+	newServer := func(t *testing.T, addr string, dialer connection.Dialer) *Server {
+		s, err := NewServer(address.Address(addr), withTopologyID("topology"), WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+			return append(opts, connection.WithDialer(func(connection.Dialer) connection.Dialer { return dialer }))
+		}))
+		require.NoError(t, err)
+		s.pool = &pool{}
+		return s
+	}
+	refused := connection.DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		s := newServer(t, "latency:27017", isMasterDialer(t, bson.NewDocument(
+			bson.EC.Int32("ok", 1),
+			bson.EC.Boolean("ismaster", true),
+			bson.EC.Int32("maxWireVersion", 6),
+		)))
+		desc, conn := s.heartbeat(nil)
+		require.NotNil(t, conn)
+		conn.Close()
+		require.Equal(t, description.ServerKind(description.Standalone), desc.Kind)
+
+		require.Equal(t, int64(1), count(t, latency, "latency:27017"))
+		require.Equal(t, int64(0), count(t, failures, "latency:27017"))
+	})
+	t.Run("failure", func(t *testing.T) {
+		s := newServer(t, "failure:27017", refused)
+		desc, conn := s.heartbeat(nil)
+		require.Nil(t, conn)
+		require.Error(t, desc.LastError)
+
+		// a heartbeat retries once before failing, which counts as one failure
+		require.Equal(t, int64(1), count(t, failures, "failure:27017"))
+		require.Equal(t, int64(0), count(t, latency, "failure:27017"))
+	})
+	t.Run("kind change span", func(t *testing.T) {
+		observability.Configure(observability.Options{
+			TraceSampler: func(string) trace.Sampler { return trace.AlwaysSample() },
+		})
+		defer observability.Configure(observability.Options{})
+		spans := &spanRecorder{}
+		trace.RegisterExporter(spans)
+		defer trace.UnregisterExporter(spans)
+
+		s := newServer(t, "flapping:27017", refused)
+		s.updateDescription(description.Server{Addr: s.address, Kind: description.RSPrimary}, true)
+		s.updateDescription(description.Server{Addr: s.address, Kind: description.RSPrimary}, false)
+		s.updateDescription(description.Server{Addr: s.address, LastError: errors.New("boom")}, false)
+
+		spans.Lock()
+		defer spans.Unlock()
+		require.Len(t, spans.spans, 2)
+		for i, want := range [][2]string{{"Unknown", "RSPrimary"}, {"RSPrimary", "Unknown"}} {
+			span := spans.spans[i]
+			require.Equal(t, "mongo-go/core/topology.(*Server).descriptionChanged", span.Name)
+			require.Equal(t, "flapping:27017", span.Attributes["address"])
+			require.Equal(t, "topology", span.Attributes["topology_id"])
+			require.Len(t, span.Annotations, 1)
+			require.Equal(t, want[0], span.Annotations[0].Attributes["previous_kind"])
+			require.Equal(t, want[1], span.Annotations[0].Attributes["new_kind"])
+		}
+		require.Equal(t, int32(trace.StatusCodeOK), spans.spans[0].Status.Code)
+		require.NotEqual(t, int32(trace.StatusCodeOK), spans.spans[1].Status.Code)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		observability.Configure(observability.Options{Disabled: true})
+		defer observability.Configure(observability.Options{})
+
+		s := newServer(t, "disabled:27017", refused)
+		_, _ = s.heartbeat(nil)
+		require.Equal(t, int64(0), count(t, failures, "disabled:27017"))
+	})
+}
@@ -13,14 +13,21 @@ package topology
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/rand"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
 	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/deployment"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/logger"
 	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/internal/clock"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 
 	"go.opencensus.io/trace"
 )
@@ -39,7 +46,42 @@ var ErrTopologyConnected = errors.New("topology is connected or connecting")
 
 // ErrServerSelectionTimeout is returned from server selection when the server
 // selection process took longer than allowed by the timeout.
-var ErrServerSelectionTimeout = errors.New("server selection timeout")
+var ErrServerSelectionTimeout error = serverSelectionTimeout{}
+
+// serverSelectionTimeout is the type of ErrServerSelectionTimeout. Its Timeout method lets the
+// error be classified as a timeout, like the timeouts of the net package.
+type serverSelectionTimeout struct{}
+
+func (serverSelectionTimeout) Error() string { return "server selection timeout" }
+
+// Timeout reports that the error is a timeout.
+func (serverSelectionTimeout) Timeout() bool { return true }
+
+// ServerSelectionError is returned from server selection when no suitable server was found before
+// the server selection timeout or the context expired. Wrapped is ErrServerSelectionTimeout or the
+// error of the context, and Desc is the last description of the topology, whose servers hold the
+// errors of their last checks.
+type ServerSelectionError struct {
+	Desc    description.Topology
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e ServerSelectionError) Error() string {
+	servers := make([]string, 0, len(e.Desc.Servers))
+	for _, s := range e.Desc.Servers {
+		server := fmt.Sprintf("{ Addr: %s, Type: %s", s.Addr, s.Kind)
+		if s.LastError != nil {
+			server += fmt.Sprintf(", Last error: %v", s.LastError)
+		}
+		servers = append(servers, server+" }")
+	}
+	return fmt.Sprintf("%v, current topology: { Type: %s, Servers: [%s] }",
+		e.Wrapped, e.Desc.Kind, strings.Join(servers, ", "))
+}
+
+// Unwrap returns ErrServerSelectionTimeout or the error of the context that expired.
+func (e ServerSelectionError) Unwrap() error { return e.Wrapped }
 
 // MonitorMode represents the way in which a server is monitored.
 type MonitorMode uint8
@@ -50,13 +92,25 @@ const (
 	SingleMode
 )
 
+// sessionSweepInterval is how often the expired sessions of the session pool are pruned.
+const sessionSweepInterval = time.Minute
+
+var _ deployment.Deployment = (*Topology)(nil)
+var _ deployment.Server = (*SelectedServer)(nil)
+
 // Topology represents a MongoDB deployment.
 type Topology struct {
 	connectionstate int32
 
 	cfg *config
 
-	desc atomic.Value // holds a description.Topology
+	// id tells the topology apart from the other topologies of the process in the metrics of its
+	// servers.
+	id string
+
+	// snapshot holds the *topologySnapshot published after the latest change to the description
+	// or the servers of the topology.
+	snapshot atomic.Value
 
 	done chan struct{}
 
@@ -65,6 +119,12 @@ type Topology struct {
 	changeswg sync.WaitGroup
 
 	SessionPool *session.Pool
+	// sweepDone stops the periodic pruning of the expired sessions of SessionPool.
+	sweepDone chan struct{}
+
+	// clock tracks the highest cluster time seen by the servers of the topology, which is gossiped
+	// to every command they send.
+	clock *session.ClusterClock
 
 	// This should really be encapsulated into it's own type. This will likely
 	// require a redesign so we can share a minimum of data between the
@@ -94,13 +154,20 @@ func New(opts ...Option) (*Topology, error) {
 
 	t := &Topology{
 		cfg:         cfg,
+		id:          objectid.New().Hex(),
 		done:        make(chan struct{}),
 		fsm:         newFSM(),
 		changes:     make(chan description.Server),
 		subscribers: make(map[uint64]chan description.Topology),
 		servers:     make(map[address.Address]*Server),
+		clock:       &session.ClusterClock{},
 	}
-	t.desc.Store(description.Topology{})
+	t.snapshot.Store(&topologySnapshot{changed: make(chan struct{})})
+	// the servers share the clock of the topology unless they are configured with another one
+	cfg.serverOpts = append([]ServerOption{WithClock(func(*session.ClusterClock) *session.ClusterClock {
+		return t.clock
+	})}, cfg.serverOpts...)
+	cfg.serverOpts = append(cfg.serverOpts, withTopologyID(t.id))
 
 	if cfg.replicaSetName != "" {
 		t.fsm.SetName = cfg.replicaSetName
@@ -114,6 +181,12 @@ func New(opts ...Option) (*Topology, error) {
 	return t, nil
 }
 
+// ClusterClock returns the clock tracking the highest cluster time seen by the servers of the
+// topology, from the replies to their commands and heartbeats.
+func (t *Topology) ClusterClock() *session.ClusterClock {
+	return t.clock
+}
+
 // Connect initializes a Topology and starts the monitoring process. This function
 // must be called to properly monitor the topology.
 func (t *Topology) Connect(ctx context.Context) error {
@@ -121,7 +194,6 @@ func (t *Topology) Connect(ctx context.Context) error {
 		return ErrTopologyConnected
 	}
 
-	t.desc.Store(description.Topology{})
 	var err error
 	t.serversLock.Lock()
 	for _, a := range t.cfg.seedList {
@@ -129,6 +201,7 @@ func (t *Topology) Connect(ctx context.Context) error {
 		t.fsm.Servers = append(t.fsm.Servers, description.Server{Addr: addr})
 		err = t.addServer(ctx, addr)
 	}
+	t.publish(description.Topology{})
 	t.serversLock.Unlock()
 
 	go t.update()
@@ -140,7 +213,9 @@ func (t *Topology) Connect(ctx context.Context) error {
 
 	// After connection, make a subscription to keep the pool updated
 	sub, err := t.Subscribe()
-	t.SessionPool = session.NewPool(sub.C)
+	t.SessionPool = session.NewPoolWithClock(sub.C, t.cfg.timeSource)
+	t.sweepDone = make(chan struct{})
+	go t.SessionPool.Sweep(sessionSweepInterval, t.sweepDone)
 	return err
 }
 
@@ -161,20 +236,76 @@ func (t *Topology) Disconnect(ctx context.Context) error {
 	t.wg.Wait()
 	t.done <- struct{}{}
 	t.changeswg.Wait()
-
-	t.desc.Store(description.Topology{})
+	close(t.sweepDone)
 
 	atomic.StoreInt32(&t.connectionstate, disconnected)
+
+	// wake up the server selections still waiting so they notice the topology is closed
+	t.serversLock.Lock()
+	t.store(&topologySnapshot{closed: true})
+	t.serversLock.Unlock()
 	return nil
 }
 
-// Description returns a description of the topology.
+// Description returns a description of the topology. The OperationCount of each server is the
+// number of operations in progress on it when Description was called.
 func (t *Topology) Description() description.Topology {
-	td, ok := t.desc.Load().(description.Topology)
-	if !ok {
-		td = description.Topology{}
+	snap := t.loadSnapshot()
+	desc := snap.desc
+	desc.Servers = snap.withOperationCounts(desc.Servers)
+	return desc
+}
+
+// topologySnapshot is an immutable view of the description and the servers of the topology. A new
+// snapshot is published every time either changes, so server selection can read them without
+// taking any locks.
+type topologySnapshot struct {
+	desc    description.Topology
+	servers map[address.Address]*Server
+	closed  bool
+
+	// changed is closed once a newer snapshot has been published.
+	changed chan struct{}
+}
+
+// withOperationCounts returns a copy of descs with the OperationCount of each server set to the
+// number of operations in progress on it.
+func (snap *topologySnapshot) withOperationCounts(descs []description.Server) []description.Server {
+	if len(descs) == 0 {
+		return descs
+	}
+	counted := make([]description.Server, len(descs))
+	for i, desc := range descs {
+		if server, ok := snap.servers[desc.Addr]; ok {
+			desc.OperationCount = server.OperationCount()
+		}
+		counted[i] = desc
 	}
-	return td
+	return counted
+}
+
+func (t *Topology) loadSnapshot() *topologySnapshot {
+	return t.snapshot.Load().(*topologySnapshot)
+}
+
+// publish replaces the current snapshot with one holding desc and a copy of the current servers,
+// and wakes up the server selections waiting for a change. t.serversLock must be held.
+func (t *Topology) publish(desc description.Topology) {
+	servers := make(map[address.Address]*Server, len(t.servers))
+	for addr, server := range t.servers {
+		servers[addr] = server
+	}
+
+	t.store(&topologySnapshot{desc: desc, servers: servers})
+}
+
+// store makes snap the current snapshot. t.serversLock must be held.
+func (t *Topology) store(snap *topologySnapshot) {
+	snap.changed = make(chan struct{})
+
+	prev := t.loadSnapshot()
+	t.snapshot.Store(snap)
+	close(prev.changed)
 }
 
 // Subscribe returns a Subscription on which all updated description.Topologys
@@ -185,11 +316,7 @@ func (t *Topology) Subscribe() (*Subscription, error) {
 		return nil, errors.New("cannot subscribe to Topology that is not connected")
 	}
 	ch := make(chan description.Topology, 1)
-	td, ok := t.desc.Load().(description.Topology)
-	if !ok {
-		td = description.Topology{}
-	}
-	ch <- td
+	ch <- t.Description()
 
 	t.subLock.Lock()
 	defer t.subLock.Unlock()
@@ -213,11 +340,9 @@ func (t *Topology) RequestImmediateCheck() {
 	if atomic.LoadInt32(&t.connectionstate) != connected {
 		return
 	}
-	t.serversLock.Lock()
-	for _, server := range t.servers {
+	for _, server := range t.loadSnapshot().servers {
 		server.RequestImmediateCheck()
 	}
-	t.serversLock.Unlock()
 }
 
 // SupportsSessions returns true if the topology supports sessions.
@@ -228,51 +353,82 @@ func (t *Topology) SupportsSessions() bool {
 // SelectServer selects a server given a selector.SelectServer complies with the
 // server selection spec, and will time out after severSelectionTimeout or when the
 // parent context is done.
-func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelector) (*SelectedServer, error) {
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/topology.(*Topology).SelectServer")
+func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelector) (deployment.Server, error) {
+	selected, err := t.SelectServerLegacy(ctx, ss)
+	if err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// SelectServerLegacy selects a server like SelectServer but returns the concrete
+// *SelectedServer, for callers that need the underlying *Server.
+func (t *Topology) SelectServerLegacy(ctx context.Context, ss description.ServerSelector) (*SelectedServer, error) {
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/topology.(*Topology).SelectServer")
 	defer span.End()
 
 	if atomic.LoadInt32(&t.connectionstate) != connected {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: "Closed topology"})
+		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeUnavailable), Message: "Closed topology"})
 		return nil, ErrTopologyClosed
 	}
 	var ssTimeoutCh <-chan time.Time
 
 	if t.cfg.serverSelectionTimeout > 0 {
-		ssTimeout := time.NewTimer(t.cfg.serverSelectionTimeout)
-		ssTimeoutCh = ssTimeout.C
+		ssTimeout := t.cfg.timeSource.NewTimer(t.cfg.serverSelectionTimeout)
+		ssTimeoutCh = ssTimeout.C()
 		defer ssTimeout.Stop()
 	}
 
-	sub, err := t.Subscribe()
+	start := t.cfg.timeSource.Now()
+	logger.Log(t.cfg.logger, logger.LevelDebug, logger.ComponentServerSelection, "Server selection started")
+
+	selected, err := t.selectServer(ctx, ss, ssTimeoutCh)
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
+		t.logSelectionFailed(start, err)
 		return nil, err
 	}
-	defer sub.Unsubscribe()
 
-	for {
-		suitable, err := t.selectServer(ctx, sub.C, ss, ssTimeoutCh)
-		if err != nil {
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-			return nil, err
-		}
+	logger.Log(t.cfg.logger, logger.LevelDebug, logger.ComponentServerSelection, "Server selection succeeded",
+		"address", selected.Server.address.String(), "duration", clock.Since(t.cfg.timeSource, start))
+	return selected, nil
+}
 
-		selected := suitable[rand.Intn(len(suitable))]
-		selectedS, err := t.FindServer(selected)
-		switch {
-		case err != nil:
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
-			return nil, err
-		case selectedS != nil:
-			return selectedS, nil
-		default:
-			// We don't have an actual server for the provided description.
-			// This could happen for a number of reasons, including that the
-			// server has since stopped being a part of this topology, or that
-			// the server selector returned no suitable servers.
-		}
+// logSelectionFailed logs that server selection failed with err. The servers known to the topology
+// are only formatted if the message is logged.
+func (t *Topology) logSelectionFailed(start time.Time, err error) {
+	if !t.cfg.logger.Enabled(logger.LevelInfo, logger.ComponentServerSelection) {
+		return
 	}
+
+	desc := t.Description()
+	servers := make([]string, 0, len(desc.Servers))
+	for _, s := range desc.Servers {
+		servers = append(servers, fmt.Sprintf("%s (%s)", s.Addr, s.Kind))
+	}
+
+	logger.Log(t.cfg.logger, logger.LevelInfo, logger.ComponentServerSelection, "Server selection failed",
+		"error", err,
+		"duration", clock.Since(t.cfg.timeSource, start),
+		"topologyKind", desc.Kind.String(),
+		"servers", strings.Join(servers, ", "),
+	)
+}
+
+// Logger returns the logger of the topology.
+func (t *Topology) Logger() logger.Logger {
+	return t.cfg.logger
+}
+
+// ID returns the ID that the heartbeat metrics of the servers of the topology are tagged with.
+func (t *Topology) ID() string {
+	return t.id
+}
+
+// Timeout returns the default operation timeout of the topology, or 0 if operations are only bounded
+// by their context.
+func (t *Topology) Timeout() time.Duration {
+	return t.cfg.timeout
 }
 
 // FindServer will attempt to find a server that fits the given server description.
@@ -281,67 +437,110 @@ func (t *Topology) FindServer(selected description.Server) (*SelectedServer, err
 	if atomic.LoadInt32(&t.connectionstate) != connected {
 		return nil, ErrTopologyClosed
 	}
-	t.serversLock.Lock()
-	defer t.serversLock.Unlock()
-	server, ok := t.servers[selected.Addr]
+	return t.loadSnapshot().find(selected.Addr), nil
+}
+
+// find returns the server of the snapshot with the given address, or nil if there is none.
+func (snap *topologySnapshot) find(addr address.Address) *SelectedServer {
+	server, ok := snap.servers[addr]
 	if !ok {
-		return nil, nil
+		return nil
 	}
 
-	desc := t.Description()
 	return &SelectedServer{
 		Server: server,
-		Kind:   desc.Kind,
-	}, nil
+		Kind:   snap.desc.Kind,
+	}
 }
 
-// selectServer is the core piece of server selection. It handles getting
-// topology descriptions and running sever selection on those descriptions.
-func (t *Topology) selectServer(ctx context.Context, subscriptionCh <-chan description.Topology, ss description.ServerSelector, timeoutCh <-chan time.Time) ([]description.Server, error) {
-	ctx, span := trace.StartSpan(ctx, "mongo-go/core/topology.(*Topology).selectServer")
+// selectServer is the core piece of server selection. It runs the selector against the latest
+// snapshot of the topology, and only blocks waiting for a newer snapshot when no suitable server is
+// found.
+func (t *Topology) selectServer(ctx context.Context, ss description.ServerSelector, timeoutCh <-chan time.Time) (*SelectedServer, error) {
+	ctx, span := observability.StartSpan(ctx, "mongo-go/core/topology.(*Topology).selectServer")
 	defer span.End()
 
-	var current description.Topology
 	for {
-		select {
-		case <-ctx.Done():
-			span.SetStatus(trace.Status{
-				Code:    int32(trace.StatusCodeDeadlineExceeded),
-				Message: "Request timed out",
-			})
-			return nil, ctx.Err()
-		case <-timeoutCh:
-			span.SetStatus(trace.Status{
-				Code:    int32(trace.StatusCodeDeadlineExceeded),
-				Message: "Server selection timed out"})
-			return nil, ErrServerSelectionTimeout
-		case current = <-subscriptionCh:
+		snap := t.loadSnapshot()
+		if snap.closed {
+			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeUnavailable), Message: "Closed topology"})
+			return nil, ErrTopologyClosed
 		}
 
 		var allowed []description.Server
-		for _, s := range current.Servers {
-			if s.Kind != description.Unknown {
+		now := t.cfg.timeSource.Now()
+		for _, s := range snap.desc.Servers {
+			if s.Kind != description.Unknown && !t.stale(s, now) {
 				allowed = append(allowed, s)
 			}
 		}
 
-		suitable, err := ss.SelectServer(current, allowed)
+		allowed = snap.withOperationCounts(allowed)
+		suitable, err := ss.SelectServer(snap.desc, allowed)
 		if err != nil {
-			span.SetStatus(trace.Status{
-				Code:    int32(trace.StatusCodeInternal),
-				Message: err.Error(),
-			})
+			span.SetStatus(observability.SpanStatus(err))
 			return nil, err
 		}
 
 		if len(suitable) > 0 {
-			return suitable, nil
+			// The description and the servers of a snapshot are published together, so the selected
+			// server is only missing if the selector returned a server that is not in the topology.
+			if selected := snap.find(leastLoaded(suitable).Addr); selected != nil {
+				return selected, nil
+			}
+		} else {
+			t.RequestImmediateCheck()
 		}
 
-		t.RequestImmediateCheck()
+		select {
+		case <-ctx.Done():
+			span.SetStatus(trace.Status{
+				Code:    int32(trace.StatusCodeDeadlineExceeded),
+				Message: "Request timed out",
+			})
+			return nil, ServerSelectionError{Desc: snap.desc, Wrapped: ctx.Err()}
+		case <-timeoutCh:
+			span.SetStatus(trace.Status{
+				Code:    int32(trace.StatusCodeDeadlineExceeded),
+				Message: "Server selection timed out"})
+			return nil, ServerSelectionError{Desc: snap.desc, Wrapped: ErrServerSelectionTimeout}
+		case <-snap.changed:
+		}
 	}
 }
 
+// leastLoaded picks two of the suitable servers at random and returns the one with fewer operations
+// in progress, which steers operations away from slow servers without sending all of them to the
+// same one when several servers are idle.
+func leastLoaded(suitable []description.Server) description.Server {
+	if len(suitable) == 1 {
+		return suitable[0]
+	}
+	i := rand.Intn(len(suitable))
+	j := rand.Intn(len(suitable) - 1)
+	if j >= i {
+		j++
+	}
+	if suitable[j].OperationCount < suitable[i].OperationCount {
+		return suitable[j]
+	}
+	return suitable[i]
+}
+
+// stale returns true if the description of s was last updated longer ago than the staleness bound
+// of the topology, so that s is treated as Unknown by server selection. Descriptions that were not
+// produced by a monitor, which have no update time or heartbeat interval, are never stale.
+func (t *Topology) stale(s description.Server, now time.Time) bool {
+	if s.LastUpdateTime.IsZero() {
+		return false
+	}
+	bound := t.cfg.serverStaleness
+	if bound <= 0 {
+		bound = 2 * s.HeartbeatInterval
+	}
+	return bound > 0 && now.Sub(s.LastUpdateTime) > bound
+}
+
 func (t *Topology) update() {
 	defer t.changeswg.Done()
 	defer func() {
@@ -359,7 +558,6 @@ func (t *Topology) update() {
 				continue
 			}
 
-			t.desc.Store(current)
 			t.subLock.Lock()
 			for _, ch := range t.subscribers {
 				// We drain the description if there's one in the channel
@@ -392,6 +590,11 @@ func (t *Topology) apply(ctx context.Context, desc description.Server) (descript
 		return description.Topology{}, err
 	}
 
+	if prev.Kind != current.Kind {
+		logger.Log(t.cfg.logger, logger.LevelInfo, logger.ComponentTopology, "Topology description changed",
+			"previousKind", prev.Kind.String(), "newKind", current.Kind.String())
+	}
+
 	diff := description.DiffTopology(prev, current)
 	t.serversLock.Lock()
 	if t.serversClosed {
@@ -401,13 +604,19 @@ func (t *Topology) apply(ctx context.Context, desc description.Server) (descript
 
 	for _, removed := range diff.Removed {
 		if s, ok := t.servers[removed.Addr]; ok {
+			logger.Log(t.cfg.logger, logger.LevelDebug, logger.ComponentTopology, "Server removed from topology",
+				"address", removed.Addr.String())
 			t.removeServer(ctx, removed.Addr, s)
 		}
 	}
 
 	for _, added := range diff.Added {
+		logger.Log(t.cfg.logger, logger.LevelDebug, logger.ComponentTopology, "Server added to topology",
+			"address", added.Addr.String())
 		_ = t.addServer(ctx, added.Addr)
 	}
+
+	t.publish(current)
 	t.serversLock.Unlock()
 	return current, nil
 }
@@ -16,6 +16,9 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/compressor"
 	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/connstring"
+	"github.com/mongodb/mongo-go-driver/core/logger"
+	"github.com/mongodb/mongo-go-driver/core/serverapi"
+	"github.com/mongodb/mongo-go-driver/internal/clock"
 )
 
 // Option is a configuration option for a topology.
@@ -28,12 +31,19 @@ type config struct {
 	serverOpts             []ServerOption
 	cs                     connstring.ConnString
 	serverSelectionTimeout time.Duration
+	serverStaleness        time.Duration
+	timeout                time.Duration
+	logger                 logger.Logger
+	serverAPI              *serverapi.Options
+	timeSource             clock.Clock
 }
 
 func newConfig(opts ...Option) (*config, error) {
 	cfg := &config{
 		seedList:               []string{"localhost:27017"},
 		serverSelectionTimeout: 30 * time.Second,
+		logger:                 logger.Nop,
+		timeSource:             clock.Real,
 	}
 
 	for _, opt := range opts {
@@ -43,6 +53,18 @@ func newConfig(opts ...Option) (*config, error) {
 		}
 	}
 
+	if cfg.logger != logger.Nop {
+		cfg.serverOpts = append(cfg.serverOpts, withServerLogger(cfg.logger))
+	}
+
+	if cfg.serverAPI != nil {
+		cfg.serverOpts = append(cfg.serverOpts, withServerAPI(cfg.serverAPI))
+	}
+
+	if cfg.timeSource != clock.Real {
+		cfg.serverOpts = append(cfg.serverOpts, withServerTimeSource(cfg.timeSource))
+	}
+
 	return cfg, nil
 }
 
@@ -56,6 +78,10 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 			c.serverSelectionTimeout = cs.ServerSelectionTimeout
 		}
 
+		if cs.TimeoutSet {
+			c.timeout = cs.Timeout
+		}
+
 		var connOpts []connection.Option
 
 		if cs.AppName != "" {
@@ -101,6 +127,18 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 			c.serverOpts = append(c.serverOpts, WithMaxIdleConnections(func(uint16) uint16 { return cs.MaxIdleConnsPerHost }))
 		}
 
+		if cs.MinPoolSizeSet {
+			c.serverOpts = append(c.serverOpts, WithMinConnections(func(uint16) uint16 { return cs.MinPoolSize }))
+		}
+
+		if cs.MaxConnectingSet {
+			c.serverOpts = append(c.serverOpts, WithMaxConnecting(func(uint16) uint16 { return cs.MaxConnecting }))
+		}
+
+		if cs.WaitQueueTimeoutSet {
+			connOpts = append(connOpts, connection.WithWaitQueueTimeout(func(time.Duration) time.Duration { return cs.WaitQueueTimeout }))
+		}
+
 		if cs.ReplicaSet != "" {
 			c.replicaSetName = cs.ReplicaSet
 		}
@@ -147,7 +185,9 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 			connOpts = append(connOpts, connection.WithTLSConfig(func(*connection.TLSConfig) *connection.TLSConfig { return tlsConfig }))
 		}
 
-		if cs.Username != "" || cs.AuthMechanism == auth.MongoDBX509 || cs.AuthMechanism == auth.GSSAPI {
+		// The handshakers read c.serverAPI when a connection is created, so it is set regardless of
+		// whether WithServerAPI is applied before or after this option.
+		if cs.Username != "" || cs.AuthMechanism != "" {
 			cred := &auth.Cred{
 				Source:      "admin",
 				Username:    cs.Username,
@@ -182,6 +222,8 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 					AppName:       cs.AppName,
 					Authenticator: authenticator,
 					Compressors:   cs.Compressors,
+					Mechanism:     cs.AuthMechanism,
+					ServerAPI:     c.serverAPI,
 				}
 				if cs.AuthMechanism == "" {
 					// Required for SASL mechanism negotiation during handshake
@@ -192,7 +234,11 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 		} else {
 			// We need to add a non-auth Handshaker to the connection options
 			connOpts = append(connOpts, connection.WithHandshaker(func(h connection.Handshaker) connection.Handshaker {
-				return &command.Handshake{Client: command.ClientDoc(cs.AppName), Compressors: cs.Compressors}
+				return &command.Handshake{
+					Client:      command.ClientDoc(cs.AppName),
+					Compressors: cs.Compressors,
+					ServerAPI:   c.serverAPI,
+				}
 			}))
 		}
 
@@ -232,6 +278,18 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 	}
 }
 
+// WithLogger configures the logger used by the topology and by the servers and connections it
+// creates. A nil logger disables logging.
+func WithLogger(fn func(logger.Logger) logger.Logger) Option {
+	return func(cfg *config) error {
+		cfg.logger = fn(cfg.logger)
+		if cfg.logger == nil {
+			cfg.logger = logger.Nop
+		}
+		return nil
+	}
+}
+
 // WithMode configures the topology's monitor mode.
 func WithMode(fn func(MonitorMode) MonitorMode) Option {
 	return func(cfg *config) error {
@@ -265,6 +323,15 @@ func WithServerOptions(fn func(...ServerOption) []ServerOption) Option {
 	}
 }
 
+// WithServerAPI configures the server API declared for every command sent by the topology, including
+// the connection handshake, authentication and heartbeats.
+func WithServerAPI(fn func(*serverapi.Options) *serverapi.Options) Option {
+	return func(cfg *config) error {
+		cfg.serverAPI = fn(cfg.serverAPI)
+		return nil
+	}
+}
+
 // WithServerSelectionTimeout configures a topology's server selection timeout.
 // A server selection timeout of 0 means there is no timeout for server selection.
 func WithServerSelectionTimeout(fn func(time.Duration) time.Duration) Option {
@@ -273,3 +340,35 @@ func WithServerSelectionTimeout(fn func(time.Duration) time.Duration) Option {
 		return nil
 	}
 }
+
+// WithServerStaleness configures how long the description of a server can go without being
+// updated by its monitor before server selection treats the server as Unknown, which keeps
+// operations away from servers that stopped answering heartbeats, for instance during an election.
+// A staleness of 0, the default, means twice the heartbeat interval of the server.
+func WithServerStaleness(fn func(time.Duration) time.Duration) Option {
+	return func(cfg *config) error {
+		cfg.serverStaleness = fn(cfg.serverStaleness)
+		return nil
+	}
+}
+
+// WithTimeSource configures the clock the topology and the servers it creates tell the time with,
+// for server selection, heartbeats and the expiry of sessions. It is the system clock unless tests
+// replace it. It is unrelated to the cluster clock of WithClock.
+func WithTimeSource(fn func(clock.Clock) clock.Clock) Option {
+	return func(cfg *config) error {
+		cfg.timeSource = fn(cfg.timeSource)
+		return nil
+	}
+}
+
+// WithTimeout configures the default timeout of operations run against the topology. The timeout
+// bounds server selection, connection checkout, retries and every command of an operation, and is
+// used to derive the maxTimeMS of each command. A timeout of 0 means operations are only bounded by
+// their context.
+func WithTimeout(fn func(time.Duration) time.Duration) Option {
+	return func(cfg *config) error {
+		cfg.timeout = fn(cfg.timeout)
+		return nil
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/connstring"
+	"github.com/mongodb/mongo-go-driver/core/logger"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,3 +32,9 @@ func TestOptionsSetting(t *testing.T) {
 
 	assert.Equal(t, ssts, conf.serverSelectionTimeout)
 }
+
+func TestWithLoggerNil(t *testing.T) {
+	conf := &config{}
+	assert.NoError(t, WithLogger(func(logger.Logger) logger.Logger { return nil })(conf))
+	assert.Equal(t, logger.Nop, conf.logger)
+}
@@ -9,13 +9,18 @@ package topology
 import (
 	"context"
 	"errors"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/address"
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/internal/clock"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/mongodb/mongo-go-driver/internal/testutil/fakeclock"
 )
 
 const testTimeout = 2 * time.Second
@@ -27,6 +32,26 @@ func noerr(t *testing.T, err error) {
 	}
 }
 
+// publishDescription publishes a copy of desc as the description of topo, adding the servers of desc
+// that the topology does not have yet. Tests can change desc and publish it again.
+func publishDescription(t *testing.T, topo *Topology, desc description.Topology) {
+	topo.serversLock.Lock()
+	defer topo.serversLock.Unlock()
+
+	desc.Servers = append([]description.Server(nil), desc.Servers...)
+
+	for _, s := range desc.Servers {
+		if _, ok := topo.servers[s.Addr]; ok {
+			continue
+		}
+		srvr, err := NewServer(s.Addr)
+		noerr(t, err)
+		topo.servers[s.Addr] = srvr
+	}
+
+	topo.publish(desc)
+}
+
 func TestServerSelection(t *testing.T) {
 	var selectFirst description.ServerSelectorFunc = func(_ description.Topology, candidates []description.Server) ([]description.Server, error) {
 		if len(candidates) == 0 {
@@ -37,6 +62,9 @@ func TestServerSelection(t *testing.T) {
 	var selectNone description.ServerSelectorFunc = func(description.Topology, []description.Server) ([]description.Server, error) {
 		return []description.Server{}, nil
 	}
+	var selectAll description.ServerSelectorFunc = func(_ description.Topology, candidates []description.Server) ([]description.Server, error) {
+		return candidates, nil
+	}
 	var errSelectionError = errors.New("encountered an error in the selector")
 	var selectError description.ServerSelectorFunc = func(description.Topology, []description.Server) ([]description.Server, error) {
 		return nil, errSelectionError
@@ -52,29 +80,24 @@ func TestServerSelection(t *testing.T) {
 				{Addr: address.Address("three"), Kind: description.Standalone},
 			},
 		}
-		subCh := make(chan description.Topology, 1)
-		subCh <- desc
-		srvs, err := topo.selectServer(context.Background(), subCh, selectFirst, nil)
+		publishDescription(t, topo, desc)
+		srv, err := topo.selectServer(context.Background(), selectFirst, nil)
 		noerr(t, err)
-		if len(srvs) != 1 {
-			t.Errorf("Incorrect number of descriptions returned. got %d; want %d", len(srvs), 1)
-		}
-		if srvs[0].Addr != desc.Servers[0].Addr {
-			t.Errorf("Incorrect sever selected. got %s; want %s", srvs[0].Addr, desc.Servers[0].Addr)
+		if srv.Server.address != desc.Servers[0].Addr {
+			t.Errorf("Incorrect sever selected. got %s; want %s", srv.Server.address, desc.Servers[0].Addr)
 		}
 	})
 	t.Run("Updated", func(t *testing.T) {
 		topo, err := New()
 		noerr(t, err)
 		desc := description.Topology{Servers: []description.Server{}}
-		subCh := make(chan description.Topology, 1)
-		subCh <- desc
+		publishDescription(t, topo, desc)
 
-		resp := make(chan []description.Server)
+		resp := make(chan *SelectedServer)
 		go func() {
-			srvs, err := topo.selectServer(context.Background(), subCh, selectFirst, nil)
+			srv, err := topo.selectServer(context.Background(), selectFirst, nil)
 			noerr(t, err)
-			resp <- srvs
+			resp <- srv
 		}()
 
 		desc = description.Topology{
@@ -84,24 +107,17 @@ func TestServerSelection(t *testing.T) {
 				{Addr: address.Address("three"), Kind: description.Standalone},
 			},
 		}
-		select {
-		case subCh <- desc:
-		case <-time.After(100 * time.Millisecond):
-			t.Error("Timed out while trying to send topology description")
-		}
+		publishDescription(t, topo, desc)
 
-		var srvs []description.Server
+		var srv *SelectedServer
 		select {
-		case srvs = <-resp:
+		case srv = <-resp:
 		case <-time.After(100 * time.Millisecond):
-			t.Errorf("Timed out while trying to retrieve selected servers")
+			t.Fatalf("Timed out while trying to retrieve selected servers")
 		}
 
-		if len(srvs) != 1 {
-			t.Errorf("Incorrect number of descriptions returned. got %d; want %d", len(srvs), 1)
-		}
-		if srvs[0].Addr != desc.Servers[0].Addr {
-			t.Errorf("Incorrect sever selected. got %s; want %s", srvs[0].Addr, desc.Servers[0].Addr)
+		if srv.Server.address != desc.Servers[0].Addr {
+			t.Errorf("Incorrect sever selected. got %s; want %s", srv.Server.address, desc.Servers[0].Addr)
 		}
 	})
 	t.Run("Cancel", func(t *testing.T) {
@@ -114,12 +130,11 @@ func TestServerSelection(t *testing.T) {
 		}
 		topo, err := New()
 		noerr(t, err)
-		subCh := make(chan description.Topology, 1)
-		subCh <- desc
+		publishDescription(t, topo, desc)
 		resp := make(chan error)
 		ctx, cancel := context.WithCancel(context.Background())
 		go func() {
-			_, err := topo.selectServer(ctx, subCh, selectNone, nil)
+			_, err := topo.selectServer(ctx, selectNone, nil)
 			resp <- err
 		}()
 
@@ -137,7 +152,7 @@ func TestServerSelection(t *testing.T) {
 			t.Errorf("Timed out while trying to retrieve selected servers")
 		}
 
-		if err != context.Canceled {
+		if !errors.Is(err, context.Canceled) {
 			t.Errorf("Incorrect error received. got %v; want %v", err, context.Canceled)
 		}
 	})
@@ -145,18 +160,17 @@ func TestServerSelection(t *testing.T) {
 		desc := description.Topology{
 			Servers: []description.Server{
 				{Addr: address.Address("one"), Kind: description.Standalone},
-				{Addr: address.Address("two"), Kind: description.Standalone},
+				{Addr: address.Address("two"), LastError: errors.New("connection refused")},
 				{Addr: address.Address("three"), Kind: description.Standalone},
 			},
 		}
 		topo, err := New()
 		noerr(t, err)
-		subCh := make(chan description.Topology, 1)
-		subCh <- desc
+		publishDescription(t, topo, desc)
 		resp := make(chan error)
 		timeout := make(chan time.Time)
 		go func() {
-			_, err := topo.selectServer(context.Background(), subCh, selectNone, timeout)
+			_, err := topo.selectServer(context.Background(), selectNone, timeout)
 			resp <- err
 		}()
 
@@ -172,9 +186,23 @@ func TestServerSelection(t *testing.T) {
 			t.Errorf("Timed out while trying to retrieve selected servers")
 		}
 
-		if err != ErrServerSelectionTimeout {
+		if !errors.Is(err, ErrServerSelectionTimeout) {
 			t.Errorf("Incorrect error received. got %v; want %v", err, ErrServerSelectionTimeout)
 		}
+		var sserr ServerSelectionError
+		if !errors.As(err, &sserr) || len(sserr.Desc.Servers) != 3 {
+			t.Fatalf("Expected a ServerSelectionError with the topology description, got %#v", err)
+		}
+		want := "server selection timeout, current topology: { Type: Unknown, Servers: [" +
+			"{ Addr: one:27017, Type: Standalone }, " +
+			"{ Addr: two:27017, Type: Unknown, Last error: connection refused }, " +
+			"{ Addr: three:27017, Type: Standalone }] }"
+		if err.Error() != want {
+			t.Errorf("Incorrect error message. got %q; want %q", err.Error(), want)
+		}
+		if code := observability.ErrorCode(err); code != observability.ErrorCodeTimeout {
+			t.Errorf("Incorrect error code. got %q; want %q", code, observability.ErrorCodeTimeout)
+		}
 	})
 	t.Run("Error", func(t *testing.T) {
 		desc := description.Topology{
@@ -186,12 +214,11 @@ func TestServerSelection(t *testing.T) {
 		}
 		topo, err := New()
 		noerr(t, err)
-		subCh := make(chan description.Topology, 1)
-		subCh <- desc
+		publishDescription(t, topo, desc)
 		resp := make(chan error)
 		timeout := make(chan time.Time)
 		go func() {
-			_, err := topo.selectServer(context.Background(), subCh, selectError, timeout)
+			_, err := topo.selectServer(context.Background(), selectError, timeout)
 			resp <- err
 		}()
 
@@ -205,18 +232,49 @@ func TestServerSelection(t *testing.T) {
 			t.Errorf("Incorrect error received. got %v; want %v", err, errSelectionError)
 		}
 	})
+	t.Run("Closed", func(t *testing.T) {
+		topo, err := New()
+		noerr(t, err)
+		publishDescription(t, topo, description.Topology{
+			Servers: []description.Server{
+				{Addr: address.Address("one"), Kind: description.Standalone},
+			},
+		})
+		resp := make(chan error)
+		go func() {
+			_, err := topo.selectServer(context.Background(), selectNone, nil)
+			resp <- err
+		}()
+
+		select {
+		case err := <-resp:
+			t.Errorf("Received error from server selection too soon: %v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		topo.serversLock.Lock()
+		topo.store(&topologySnapshot{closed: true})
+		topo.serversLock.Unlock()
+
+		select {
+		case err = <-resp:
+		case <-time.After(100 * time.Millisecond):
+			t.Errorf("Timed out while trying to retrieve selected servers")
+		}
+
+		if err != ErrTopologyClosed {
+			t.Errorf("Incorrect error received. got %v; want %v", err, ErrTopologyClosed)
+		}
+	})
 	t.Run("findServer returns topology kind", func(t *testing.T) {
 		topo, err := New()
 		noerr(t, err)
 		atomic.StoreInt32(&topo.connectionstate, connected)
-		srvr, err := NewServer(address.Address("one"))
-		noerr(t, err)
-		topo.servers[address.Address("one")] = srvr
-		desc := topo.desc.Load().(description.Topology)
-		desc.Kind = description.Single
-		topo.desc.Store(desc)
-
 		selected := description.Server{Addr: address.Address("one")}
+		publishDescription(t, topo, description.Topology{
+			Kind:    description.Single,
+			Servers: []description.Server{selected},
+		})
 
 		ss, err := topo.FindServer(selected)
 		noerr(t, err)
@@ -242,11 +300,7 @@ func TestServerSelection(t *testing.T) {
 		}
 
 		// manually add the servers to the topology
-		for _, srv := range desc.Servers {
-			s, err := NewServer(srv.Addr)
-			noerr(t, err)
-			topo.servers[srv.Addr] = s
-		}
+		publishDescription(t, topo, desc)
 
 		// Send updated description
 		desc = description.Topology{
@@ -257,8 +311,7 @@ func TestServerSelection(t *testing.T) {
 			},
 		}
 
-		subCh := make(chan description.Topology, 1)
-		subCh <- desc
+		publishDescription(t, topo, desc)
 
 		// send a not master error to the server forcing an update
 		serv, err := topo.FindServer(desc.Servers[0])
@@ -269,163 +322,265 @@ func TestServerSelection(t *testing.T) {
 		sc := &sconn{s: serv.Server}
 		sc.processErr(command.Error{Message: "not master"})
 
-		resp := make(chan []description.Server)
+		resp := make(chan *SelectedServer)
 
 		go func() {
 			// server selection should discover the new topology
-			srvs, err := topo.selectServer(context.Background(), subCh, description.WriteSelector(), nil)
+			srv, err := topo.selectServer(context.Background(), description.WriteSelector(), nil)
 			noerr(t, err)
-			resp <- srvs
+			resp <- srv
 		}()
 
-		var srvs []description.Server
+		var srv *SelectedServer
 		select {
-		case srvs = <-resp:
+		case srv = <-resp:
 		case <-time.After(100 * time.Millisecond):
-			t.Errorf("Timed out while trying to retrieve selected servers")
+			t.Fatalf("Timed out while trying to retrieve selected servers")
 		}
 
-		if len(srvs) != 1 {
-			t.Errorf("Incorrect number of descriptions returned. got %d; want %d", len(srvs), 1)
-		}
-		if srvs[0].Addr != desc.Servers[1].Addr {
-			t.Errorf("Incorrect sever selected. got %s; want %s", srvs[0].Addr, desc.Servers[1].Addr)
+		if srv.Server.address != desc.Servers[1].Addr {
+			t.Errorf("Incorrect sever selected. got %s; want %s", srv.Server.address, desc.Servers[1].Addr)
 		}
 	})
-}
+	t.Run("Stale descriptions", func(t *testing.T) {
+		clk := fakeclock.New(time.Now())
+		topo, err := New(WithTimeSource(func(clock.Clock) clock.Clock { return clk }))
+		noerr(t, err)
+		atomic.StoreInt32(&topo.connectionstate, connected)
 
-func TestSessionTimeout(t *testing.T) {
-	t.Run("UpdateSessionTimeout", func(t *testing.T) {
-		topo, err := New()
+		// the monitor of "one" stopped updating it a minute ago, while "two" was just checked
+		now := clk.Now()
+		desc := description.Topology{
+			Servers: []description.Server{
+				{Addr: address.Address("one"), Kind: description.RSPrimary, HeartbeatInterval: 10 * time.Second, LastUpdateTime: now.Add(-time.Minute)},
+				{Addr: address.Address("two"), Kind: description.RSSecondary, HeartbeatInterval: 10 * time.Second, LastUpdateTime: now},
+			},
+		}
+		publishDescription(t, topo, desc)
+
+		srv, err := topo.selectServer(context.Background(), selectFirst, nil)
 		noerr(t, err)
-		doneCh := make(chan struct{}, 1)
+		if srv.Server.address != desc.Servers[1].Addr {
+			t.Errorf("Incorrect sever selected. got %s; want %s", srv.Server.address, desc.Servers[1].Addr)
+		}
 
-		// update topology and signal when done
+		resp := make(chan *SelectedServer)
 		go func() {
-			topo.changeswg.Add(1)
-			topo.update()
-			doneCh <- struct{}{}
+			srv, err := topo.selectServer(context.Background(), description.WriteSelector(), nil)
+			noerr(t, err)
+			resp <- srv
 		}()
 
-		timeoutChan := time.After(testTimeout)
-		topo.changes <- description.Server{
-			Kind:                  description.RSPrimary,
-			SessionTimeoutMinutes: 30,
-		}
-		topo.done <- struct{}{}
-
 		select {
-		case <-doneCh:
-			currDesc := topo.desc.Load().(description.Topology)
-			if currDesc.SessionTimeoutMinutes != 30 {
-				t.Errorf("session timeout minutes mismatch. got: %d. expected: 30", currDesc.SessionTimeoutMinutes)
-			}
-		case <-timeoutChan:
-			t.Errorf("test case timed out")
+		case <-resp:
+			t.Fatalf("Selected a server whose description is stale")
+		case <-time.After(100 * time.Millisecond):
 		}
-	})
-	t.Run("MultipleUpdates", func(t *testing.T) {
-		topo, err := New()
+		stale, err := topo.FindServer(desc.Servers[0])
 		noerr(t, err)
-		doneCh := make(chan struct{}, 2)
+		if len(stale.checkNow) != 1 {
+			t.Errorf("Expected an immediate check when only stale servers are suitable")
+		}
 
-		// update topology and signal when done
-		go func() {
-			topo.changeswg.Add(1)
-			topo.update()
-			doneCh <- struct{}{}
-		}()
+		// the re-check finds the primary again
+		desc.Servers[0].LastUpdateTime = clk.Now()
+		publishDescription(t, topo, desc)
 
-		timeoutChan := time.After(testTimeout)
-		topo.changes <- description.Server{
-			Kind:                  description.RSPrimary,
-			SessionTimeoutMinutes: 30,
+		select {
+		case srv = <-resp:
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Timed out while trying to retrieve selected servers")
 		}
-		// should update because new timeout is lower
-		topo.changes <- description.Server{
-			Kind:                  description.RSPrimary,
-			SessionTimeoutMinutes: 20,
+		if srv.Server.address != desc.Servers[0].Addr {
+			t.Errorf("Incorrect sever selected. got %s; want %s", srv.Server.address, desc.Servers[0].Addr)
 		}
-		topo.done <- struct{}{}
 
+		// both descriptions become stale once their monitors miss two heartbeats
+		clk.Advance(21 * time.Second)
+		go func() {
+			_, err := topo.selectServer(context.Background(), selectFirst, nil)
+			noerr(t, err)
+			resp <- nil
+		}()
 		select {
-		case <-doneCh:
-			currDesc := topo.Description()
-			if currDesc.SessionTimeoutMinutes != 20 {
-				t.Errorf("session timeout minutes mismatch. got: %d. expected: 20", currDesc.SessionTimeoutMinutes)
-			}
-		case <-timeoutChan:
-			t.Errorf("test case timed out")
+		case <-resp:
+			t.Fatalf("Selected a server whose description is stale")
+		case <-time.After(100 * time.Millisecond):
+		}
+		desc.Servers[1].LastUpdateTime = clk.Now()
+		publishDescription(t, topo, desc)
+		select {
+		case <-resp:
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Timed out while trying to retrieve selected servers")
 		}
 	})
-	t.Run("NoUpdate", func(t *testing.T) {
-		topo, err := New()
+	t.Run("Selection timeout", func(t *testing.T) {
+		clk := fakeclock.New(time.Now())
+		topo, err := New(
+			WithTimeSource(func(clock.Clock) clock.Clock { return clk }),
+			WithServerSelectionTimeout(func(time.Duration) time.Duration { return 30 * time.Second }),
+		)
 		noerr(t, err)
-		doneCh := make(chan struct{}, 2)
+		atomic.StoreInt32(&topo.connectionstate, connected)
+		publishDescription(t, topo, description.Topology{
+			Servers: []description.Server{{Addr: address.Address("one"), Kind: description.Standalone}},
+		})
 
-		// update topology and signal when done
+		resp := make(chan error)
 		go func() {
-			topo.changeswg.Add(1)
-			topo.update()
-			doneCh <- struct{}{}
+			_, err := topo.SelectServerLegacy(context.Background(), selectNone)
+			resp <- err
 		}()
 
-		timeoutChan := time.After(testTimeout)
-		topo.changes <- description.Server{
-			Kind:                  description.RSPrimary,
-			SessionTimeoutMinutes: 20,
-		}
-		// should not update because new timeout is higher
-		topo.changes <- description.Server{
-			Kind:                  description.RSPrimary,
-			SessionTimeoutMinutes: 30,
+		clk.BlockUntil(1)
+		clk.Advance(29 * time.Second)
+		select {
+		case err := <-resp:
+			t.Fatalf("Received error from server selection too soon: %v", err)
+		case <-time.After(10 * time.Millisecond):
 		}
-		topo.done <- struct{}{}
 
+		clk.Advance(time.Second)
 		select {
-		case <-doneCh:
-			currDesc := topo.desc.Load().(description.Topology)
-			if currDesc.SessionTimeoutMinutes != 20 {
-				t.Errorf("session timeout minutes mismatch. got: %d. expected: 20", currDesc.SessionTimeoutMinutes)
-			}
-		case <-timeoutChan:
-			t.Errorf("test case timed out")
+		case err = <-resp:
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out while trying to retrieve selected servers")
+		}
+		if !errors.Is(err, ErrServerSelectionTimeout) {
+			t.Errorf("Incorrect error received. got %v; want %v", err, ErrServerSelectionTimeout)
 		}
 	})
-	t.Run("TimeoutDataBearing", func(t *testing.T) {
+	t.Run("Least loaded", func(t *testing.T) {
 		topo, err := New()
 		noerr(t, err)
-		doneCh := make(chan struct{}, 2)
+		desc := description.Topology{
+			Servers: []description.Server{
+				{Addr: address.Address("slow"), Kind: description.Mongos},
+				{Addr: address.Address("fast"), Kind: description.Mongos},
+			},
+		}
+		publishDescription(t, topo, desc)
+		for _, s := range topo.servers {
+			s.pool = &pool{}
+			s.connectionstate = connected
+		}
 
-		// update topology and signal when done
-		go func() {
-			topo.changeswg.Add(1)
-			topo.update()
-			doneCh <- struct{}{}
-		}()
+		// operations on the slow server never complete while the test runs, so they accumulate,
+		// and those on the fast server complete before the next one is selected
+		var held []connection.Connection
+		for i := 0; i < 100; i++ {
+			srv, err := topo.selectServer(context.Background(), selectAll, nil)
+			noerr(t, err)
+			c, err := srv.Connection(context.Background())
+			noerr(t, err)
+			if srv.address == "slow" {
+				held = append(held, c)
+				continue
+			}
+			noerr(t, c.Close())
+		}
 
-		timeoutChan := time.After(testTimeout)
-		topo.changes <- description.Server{
-			Kind:                  description.RSPrimary,
-			SessionTimeoutMinutes: 20,
+		// the slow server is only selected while both are idle, which is before the first operation
+		if len(held) > 1 {
+			t.Errorf("Expected the slow server to be selected at most once, got %d", len(held))
 		}
-		// should not update because not a data bearing server
-		topo.changes <- description.Server{
-			Kind:                  description.Unknown,
-			SessionTimeoutMinutes: 10,
+		counts := make(map[address.Address]int64)
+		for _, s := range topo.Description().Servers {
+			counts[s.Addr] = s.OperationCount
+		}
+		if counts["slow"] != int64(len(held)) || counts["fast"] != 0 {
+			t.Errorf("Incorrect operation counts. got %v; want slow: %d, fast: 0", counts, len(held))
 		}
-		topo.done <- struct{}{}
 
-		select {
-		case <-doneCh:
-			currDesc := topo.desc.Load().(description.Topology)
-			if currDesc.SessionTimeoutMinutes != 20 {
-				t.Errorf("session timeout minutes mismatch. got: %d. expected: 20", currDesc.SessionTimeoutMinutes)
-			}
-		case <-timeoutChan:
-			t.Errorf("test case timed out")
+		for _, c := range held {
+			noerr(t, c.Close())
+			noerr(t, c.Close())
+		}
+		if n := topo.servers["slow"].OperationCount(); n != 0 {
+			t.Errorf("Expected no operations in progress once the connections are closed, got %d", n)
+		}
+	})
+	t.Run("Configured staleness", func(t *testing.T) {
+		clk := fakeclock.New(time.Now())
+		topo, err := New(
+			WithTimeSource(func(clock.Clock) clock.Clock { return clk }),
+			WithServerStaleness(func(time.Duration) time.Duration { return time.Hour }),
+		)
+		noerr(t, err)
+		desc := description.Topology{
+			Servers: []description.Server{
+				{Addr: address.Address("one"), Kind: description.Standalone, HeartbeatInterval: 10 * time.Second, LastUpdateTime: clk.Now()},
+			},
+		}
+		clk.Advance(time.Minute)
+		publishDescription(t, topo, desc)
+
+		srv, err := topo.selectServer(context.Background(), selectFirst, nil)
+		noerr(t, err)
+		if srv.Server.address != desc.Servers[0].Addr {
+			t.Errorf("Incorrect sever selected. got %s; want %s", srv.Server.address, desc.Servers[0].Addr)
 		}
 	})
+}
+
+func TestSessionTimeout(t *testing.T) {
+	one, two := address.Address("one:27017"), address.Address("two:27017")
+	mongos := func(addr address.Address, minutes uint32) description.Server {
+		return description.Server{Addr: addr, Kind: description.Mongos, SessionTimeoutMinutes: minutes}
+	}
+	testCases := []struct {
+		name    string
+		changes []description.Server
+		want    uint32
+	}{
+		{"UpdateSessionTimeout", []description.Server{mongos(one, 30), mongos(two, 30)}, 30},
+		// the lowest timeout of the servers is the timeout of the topology
+		{"LowestTimeout", []description.Server{mongos(one, 30), mongos(two, 20)}, 20},
+		// a server whose configuration changes is followed, whether its timeout decreases or increases
+		{"MultipleUpdates", []description.Server{mongos(one, 30), mongos(two, 30), mongos(one, 20)}, 20},
+		{"FollowsServer", []description.Server{mongos(one, 20), mongos(two, 30), mongos(one, 30)}, 30},
+		// servers that are not data bearing do not count
+		{"TimeoutDataBearing", []description.Server{
+			mongos(one, 20),
+			{Addr: two, Kind: description.Unknown, SessionTimeoutMinutes: 10},
+		}, 20},
+		// a data bearing server that does not support sessions disables them
+		{"ServerWithoutSessions", []description.Server{mongos(one, 30), mongos(two, 30), mongos(two, 0)}, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			topo, err := New()
+			noerr(t, err)
+			topo.fsm.Kind = description.Sharded
+			topo.fsm.Servers = []description.Server{{Addr: one}, {Addr: two}}
+			doneCh := make(chan struct{}, 1)
+
+			// update topology and signal when done
+			go func() {
+				topo.changeswg.Add(1)
+				topo.update()
+				doneCh <- struct{}{}
+			}()
+
+			timeoutChan := time.After(testTimeout)
+			for _, change := range tc.changes {
+				topo.changes <- change
+			}
+			topo.done <- struct{}{}
+
+			select {
+			case <-doneCh:
+				currDesc := topo.Description()
+				if currDesc.SessionTimeoutMinutes != tc.want {
+					t.Errorf("session timeout minutes mismatch. got: %d. expected: %d", currDesc.SessionTimeoutMinutes, tc.want)
+				}
+			case <-timeoutChan:
+				t.Errorf("test case timed out")
+			}
+		})
+	}
 	t.Run("MixedSessionSupport", func(t *testing.T) {
 		topo, err := New()
 		noerr(t, err)
@@ -448,9 +603,75 @@ func TestSessionTimeout(t *testing.T) {
 		topo.done <- struct{}{}
 
 		<-doneCh
-		currDesc := topo.desc.Load().(description.Topology)
+		currDesc := topo.Description()
 		if currDesc.SessionTimeoutMinutes != 0 {
 			t.Errorf("session timeout minutes mismatch. got: %d. expected: 0", currDesc.SessionTimeoutMinutes)
 		}
 	})
 }
+
+func BenchmarkSelectServerDuringChurn(b *testing.B) {
+	const selections = 1000
+
+	topo, err := New(WithServerSelectionTimeout(func(time.Duration) time.Duration { return 10 * time.Second }))
+	if err != nil {
+		b.Fatal(err)
+	}
+	atomic.StoreInt32(&topo.connectionstate, connected)
+
+	addr := address.Address("one").Canonicalize()
+	srvr, err := NewServer(addr)
+	if err != nil {
+		b.Fatal(err)
+	}
+	topo.servers[addr] = srvr
+	topo.fsm.Servers = append(topo.fsm.Servers, description.Server{Addr: addr})
+
+	topo.changeswg.Add(1)
+	go topo.update()
+	defer func() { topo.done <- struct{}{} }()
+
+	// simulate heartbeats updating the description of the server every 100µs, far more often than
+	// they do even during elections
+	stop := make(chan struct{})
+	var churn sync.WaitGroup
+	churn.Add(1)
+	go func() {
+		defer churn.Done()
+		ticker := time.NewTicker(100 * time.Microsecond)
+		defer ticker.Stop()
+		for i := 0; ; i++ {
+			change := description.Server{Addr: addr, Kind: description.Standalone}
+			change = change.SetAverageRTT(time.Duration(i%100) * time.Millisecond)
+			select {
+			case topo.changes <- change:
+			case <-stop:
+				return
+			}
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	defer func() {
+		close(stop)
+		churn.Wait()
+	}()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var wg sync.WaitGroup
+		wg.Add(selections)
+		for j := 0; j < selections; j++ {
+			go func() {
+				defer wg.Done()
+				if _, err := topo.SelectServer(context.Background(), description.WriteSelector()); err != nil {
+					b.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
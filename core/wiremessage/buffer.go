@@ -0,0 +1,43 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package wiremessage
+
+import "sync"
+
+// maxPooledBufferSize is the largest buffer returned to the pool, so that a single large message
+// does not keep a large buffer alive.
+const maxPooledBufferSize = 16 * 1024 * 1024
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+// GetBuffer returns an empty buffer with a capacity of at least size from the pool of buffers
+// used to read and write wire messages. The buffer should be returned with PutBuffer once it is
+// no longer used.
+func GetBuffer(size int) []byte {
+	b := *bufferPool.Get().(*[]byte)
+	if cap(b) < size {
+		return make([]byte, 0, size)
+	}
+
+	return b[:0]
+}
+
+// PutBuffer returns b to the pool. Neither b nor any slice of it may be used afterwards. It is safe
+// to put buffers that were not obtained from GetBuffer.
+func PutBuffer(b []byte) {
+	if b == nil || cap(b) > maxPooledBufferSize {
+		return
+	}
+
+	b = b[:0]
+	bufferPool.Put(&b)
+}
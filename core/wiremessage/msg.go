@@ -83,6 +83,17 @@ func (m Msg) Len() int {
 
 // UnmarshalWireMessage implements the Unmarshaler interface.
 func (m *Msg) UnmarshalWireMessage(b []byte) error {
+	return m.unmarshalWireMessage(b, false)
+}
+
+// UnmarshalWireMessagePooled is like UnmarshalWireMessage, but copies each document into a buffer
+// from the pool. The documents can be returned to the pool with PutBuffer once they are no longer
+// used.
+func (m *Msg) UnmarshalWireMessagePooled(b []byte) error {
+	return m.unmarshalWireMessage(b, true)
+}
+
+func (m *Msg) unmarshalWireMessage(b []byte, pooled bool) error {
 	var err error
 
 	m.MsgHeader, err = ReadHeader(b, 0)
@@ -113,7 +124,7 @@ func (m *Msg) UnmarshalWireMessage(b []byte) error {
 
 		switch sectionType {
 		case SingleDocument:
-			rdr, size, err := readDocument(b, int32(position))
+			rdr, size, err := readDocument(b, int32(position), pooled)
 			if err.Message != "" {
 				err.Type = ErrOpMsg
 				return err
@@ -145,7 +156,7 @@ func (m *Msg) UnmarshalWireMessage(b []byte) error {
 			// sequenceLen - 4 bytes for size field - identifierLength (including \0)
 			docsLen := int(sds.Size) - 4 - len(identifier) - 1
 			for docsLen > 0 {
-				rdr, size, err := readDocument(b, int32(position))
+				rdr, size, err := readDocument(b, int32(position), pooled)
 				if err.Message != "" {
 					err.Type = ErrOpMsg
 					return err
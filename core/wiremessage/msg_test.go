@@ -106,4 +106,34 @@ func TestMsg(t *testing.T) {
 			})
 		}
 	})
+	t.Run("UnmarshalWireMessagePooled", func(t *testing.T) {
+		b := sectionBytes(t)
+		var m Msg
+		if err := m.UnmarshalWireMessagePooled(b); err != nil {
+			t.Fatalf("Unexpected error unmarshaling: %v", err)
+		}
+		if len(m.Sections) != 1 {
+			t.Fatalf("Expected 1 section. got %d", len(m.Sections))
+		}
+
+		want := oneSection(t)[0].(SectionBody).Document
+		got := m.Sections[0].(SectionBody).Document
+		for i := range b {
+			b[i] = 0 // the documents must not reference the message
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("Documents do not match. got %#v; want %#v", got, want)
+		}
+		PutBuffer(got)
+	})
+}
+
+func TestBuffer(t *testing.T) {
+	for _, size := range []int{0, 16, 1024, maxPooledBufferSize + 1} {
+		b := GetBuffer(size)
+		if len(b) != 0 || cap(b) < size {
+			t.Errorf("Invalid buffer for size %d. got len %d and cap %d", size, len(b), cap(b))
+		}
+		PutBuffer(append(b, make([]byte, size)...))
+	}
 }
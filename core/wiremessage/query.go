@@ -121,14 +121,14 @@ func (q *Query) UnmarshalWireMessage(b []byte) error {
 
 	var size int
 	var wmerr Error
-	q.Query, size, wmerr = readDocument(b, int32(pos))
+	q.Query, size, wmerr = readDocument(b, int32(pos), false)
 	if wmerr.Message != "" {
 		wmerr.Type = ErrOpQuery
 		return wmerr
 	}
 	pos += size
 	if pos < len(b) {
-		q.ReturnFieldsSelector, size, wmerr = readDocument(b, int32(pos))
+		q.ReturnFieldsSelector, size, wmerr = readDocument(b, int32(pos), false)
 		if wmerr.Message != "" {
 			wmerr.Type = ErrOpQuery
 			return wmerr
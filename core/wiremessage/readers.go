@@ -32,8 +32,9 @@ func readInt64(b []byte, pos int32) int64 {
 }
 
 // readDocument will attempt to read a bson.Reader from the given slice of bytes
-// from the given position.
-func readDocument(b []byte, pos int32) (bson.Reader, int, Error) {
+// from the given position. The document is copied into a buffer from the pool if
+// pooled is true.
+func readDocument(b []byte, pos int32, pooled bool) (bson.Reader, int, Error) {
 	if int(pos)+4 > len(b) {
 		return nil, 0, Error{Message: "document too small to be valid"}
 	}
@@ -44,8 +45,12 @@ func readDocument(b []byte, pos int32) (bson.Reader, int, Error) {
 	if b[int(pos)+size-1] != 0x00 {
 		return nil, 0, Error{Message: "document invalid, last byte is not null"}
 	}
-	// TODO(GODRIVER-138): When we add 3.0 support, alter this so we either do one larger make or use a pool.
-	rdr := make(bson.Reader, size)
+	var rdr bson.Reader
+	if pooled {
+		rdr = GetBuffer(size)[:size]
+	} else {
+		rdr = make(bson.Reader, size)
+	}
 	copy(rdr, b[pos:int(pos)+size])
 	return rdr, size, Error{Type: ErrNil}
 }
@@ -86,6 +86,17 @@ func (r Reply) Len() int {
 
 // UnmarshalWireMessage implements the Unmarshaler interface.
 func (r *Reply) UnmarshalWireMessage(b []byte) error {
+	return r.unmarshalWireMessage(b, false)
+}
+
+// UnmarshalWireMessagePooled is like UnmarshalWireMessage, but copies each document into a buffer
+// from the pool. The documents can be returned to the pool with PutBuffer once they are no longer
+// used.
+func (r *Reply) UnmarshalWireMessagePooled(b []byte) error {
+	return r.unmarshalWireMessage(b, true)
+}
+
+func (r *Reply) unmarshalWireMessage(b []byte, pooled bool) error {
 	var err error
 	r.MsgHeader, err = ReadHeader(b, 0)
 	if err != nil {
@@ -104,7 +115,7 @@ func (r *Reply) UnmarshalWireMessage(b []byte) error {
 	r.NumberReturned = readInt32(b, 32)
 	pos := 36
 	for pos < len(b) {
-		rdr, size, err := readDocument(b, int32(pos))
+		rdr, size, err := readDocument(b, int32(pos), pooled)
 		if err.Message != "" {
 			err.Type = ErrOpReply
 			return err
@@ -8,6 +8,7 @@ package writeconcern
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
@@ -30,10 +31,14 @@ type WriteConcern struct {
 	wTimeout time.Duration
 }
 
-// Option is an option to provide when creating a ReadConcern.
+// Option is an option to provide when creating a WriteConcern.
 type Option func(concern *WriteConcern)
 
-// New constructs a new WriteConcern.
+// New constructs a new WriteConcern, e.g.
+//
+//		writeconcern.New(writeconcern.WMajority(), writeconcern.J(true), writeconcern.WTimeout(5*time.Second))
+//
+// The write concern is not validated until it is marshaled, or when Validate is called.
 func New(options ...Option) *WriteConcern {
 	concern := &WriteConcern{}
 
@@ -76,63 +81,132 @@ func J(j bool) Option {
 	}
 }
 
-// WTimeout specifies specifies a time limit for the write concern.
+// WTimeout specifies a time limit for the write concern. It is sent in milliseconds.
 func WTimeout(d time.Duration) Option {
 	return func(concern *WriteConcern) {
 		concern.wTimeout = d
 	}
 }
 
-// MarshalBSONElement marshals the write concern into a *bson.Element.
-func (wc *WriteConcern) MarshalBSONElement() (*bson.Element, error) {
+// Validate returns an error if the write concern cannot be sent to the server: if it requests
+// journaling of unacknowledged writes, or if w or wtimeout is negative.
+func (wc *WriteConcern) Validate() error {
+	if wc == nil {
+		return nil
+	}
 	if !wc.IsValid() {
-		return nil, ErrInconsistent
+		return ErrInconsistent
+	}
+	if w, ok := wc.w.(int); ok && w < 0 {
+		return ErrNegativeW
+	}
+	if wc.wTimeout < 0 {
+		return ErrNegativeWTimeout
 	}
 
-	var elems []*bson.Element
+	return nil
+}
 
-	if wc.w != nil {
-		switch t := wc.w.(type) {
-		case int:
-			if t < 0 {
-				return nil, ErrNegativeW
-			}
+// elements returns the fields of the write concern document.
+func (wc *WriteConcern) elements() ([]*bson.Element, error) {
+	if err := wc.Validate(); err != nil || wc == nil {
+		return nil, err
+	}
 
-			elems = append(elems, bson.EC.Int32("w", int32(t)))
-		case string:
-			elems = append(elems, bson.EC.String("w", t))
-		}
+	var elems []*bson.Element
+
+	switch t := wc.w.(type) {
+	case int:
+		elems = append(elems, bson.EC.Int32("w", int32(t)))
+	case string:
+		elems = append(elems, bson.EC.String("w", t))
 	}
 
 	if wc.j {
 		elems = append(elems, bson.EC.Boolean("j", wc.j))
 	}
 
-	if wc.wTimeout < 0 {
-		return nil, ErrNegativeWTimeout
-	}
-
 	if wc.wTimeout != 0 {
 		elems = append(elems, bson.EC.Int64("wtimeout", int64(wc.wTimeout/time.Millisecond)))
 	}
 
+	return elems, nil
+}
+
+// MarshalBSONElement marshals the write concern into a *bson.Element.
+func (wc *WriteConcern) MarshalBSONElement() (*bson.Element, error) {
+	elems, err := wc.elements()
+	if err != nil {
+		return nil, err
+	}
+
 	return bson.EC.SubDocumentFromElements("writeConcern", elems...), nil
 }
 
+// MarshalBSON implements the bson.Marshaler interface. It marshals the write concern into the
+// document sent as the writeConcern field of a command.
+func (wc *WriteConcern) MarshalBSON() ([]byte, error) {
+	elems, err := wc.elements()
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.NewDocument(elems...).MarshalBSON()
+}
+
+// UnmarshalBSON implements the bson.Unmarshaler interface. It reads a write concern document, such
+// as the writeConcern field of a command, into the write concern.
+func (wc *WriteConcern) UnmarshalBSON(b []byte) error {
+	*wc = WriteConcern{}
+	return bson.Reader(b).ForEach(func(key []byte, val bson.Value) error {
+		switch string(key) {
+		case "w":
+			if tag, err := val.AsString(); err == nil {
+				wc.w = tag
+				return nil
+			}
+			w, err := val.AsInt64()
+			if err != nil {
+				return fmt.Errorf("invalid write concern w: %v", err)
+			}
+			wc.w = int(w)
+		case "j":
+			j, ok := val.BooleanOK()
+			if !ok {
+				return fmt.Errorf("invalid write concern j: expected a boolean, got %v", val.Type())
+			}
+			wc.j = j
+		case "wtimeout":
+			ms, err := val.AsInt64()
+			if err != nil {
+				return fmt.Errorf("invalid write concern wtimeout: %v", err)
+			}
+			wc.wTimeout = time.Duration(ms) * time.Millisecond
+		}
+		return nil
+	})
+}
+
 // AcknowledgedElement returns true if a BSON element for a write concern represents an acknowledged write concern.
 // The element's value must be a document representing a write concern.
 func AcknowledgedElement(elem *bson.Element) bool {
-	wcDoc := elem.Value().MutableDocument()
+	wcDoc, ok := elem.Value().MutableDocumentOK()
+	if !ok {
+		return true
+	}
 	wVal, err := wcDoc.LookupErr("w")
 	if err != nil {
 		// key w not found --> acknowledged
 		return true
 	}
 
-	return wVal.Int32() != 0
+	// w can also be a tag set or "majority", which are acknowledged
+	w, err := wVal.AsInt64()
+	return err != nil || w != 0
 }
 
 // Acknowledged indicates whether or not a write with the given write concern will be acknowledged.
+// A nil write concern uses the server default, which is acknowledged.
 func (wc *WriteConcern) Acknowledged() bool {
 	if wc == nil || wc.j {
 		return true
@@ -164,7 +238,9 @@ func (wc *WriteConcern) IsValid() bool {
 	return true
 }
 
-// AckWrite returns true if a write concern represents an acknowledged write
+// AckWrite returns true if a write concern represents an acknowledged write.
+//
+// Deprecated: Use the Acknowledged method, which also accepts a nil write concern.
 func AckWrite(wc *WriteConcern) bool {
 	return wc == nil || wc.Acknowledged()
 }
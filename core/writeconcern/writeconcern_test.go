@@ -0,0 +1,122 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package writeconcern_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	. "github.com/mongodb/mongo-go-driver/core/writeconcern"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalBSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		wc       *WriteConcern
+		expected *bson.Document
+	}{
+		{"empty", New(), bson.NewDocument()},
+		{"w number", New(W(2)), bson.NewDocument(bson.EC.Int32("w", 2))},
+		{"w tag", New(WTagSet("dc")), bson.NewDocument(bson.EC.String("w", "dc"))},
+		{
+			"majority with journal and wtimeout",
+			New(WMajority(), J(true), WTimeout(5*time.Second)),
+			bson.NewDocument(bson.EC.String("w", "majority"), bson.EC.Boolean("j", true), bson.EC.Int64("wtimeout", 5000)),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := tc.wc.MarshalBSON()
+			require.NoError(t, err)
+
+			doc, err := bson.ReadDocument(b)
+			require.NoError(t, err)
+			require.True(t, tc.expected.Equal(doc), "expected %v, got %v", tc.expected, doc)
+
+			elem, err := tc.wc.MarshalBSONElement()
+			require.NoError(t, err)
+			require.Equal(t, "writeConcern", elem.Key())
+			require.True(t, tc.expected.Equal(elem.Value().MutableDocument()))
+		})
+	}
+}
+
+func TestUnmarshalBSON(t *testing.T) {
+	type command struct {
+		Insert       string
+		WriteConcern *WriteConcern `bson:"writeConcern"`
+	}
+
+	for _, wc := range []*WriteConcern{
+		New(),
+		New(W(0)),
+		New(WTagSet("dc")),
+		New(WMajority(), J(true), WTimeout(5*time.Second)),
+	} {
+		b, err := bson.Marshal(command{Insert: "coll", WriteConcern: wc})
+		require.NoError(t, err)
+
+		var out command
+		require.NoError(t, bson.Unmarshal(b, &out))
+		require.Equal(t, wc, out.WriteConcern)
+	}
+
+	var wc WriteConcern
+	b, err := bson.NewDocument(bson.EC.Boolean("w", true)).MarshalBSON()
+	require.NoError(t, err)
+	require.Error(t, wc.UnmarshalBSON(b))
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		wc   *WriteConcern
+		err  error
+	}{
+		{"nil", nil, nil},
+		{"valid", New(W(0), J(false)), nil},
+		{"w 0 with journal", New(W(0), J(true)), ErrInconsistent},
+		{"negative w", New(W(-1)), ErrNegativeW},
+		{"negative wtimeout", New(WTimeout(-time.Second)), ErrNegativeWTimeout},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.err, tc.wc.Validate())
+			if tc.err != nil {
+				_, err := tc.wc.MarshalBSON()
+				require.Equal(t, tc.err, err)
+			}
+		})
+	}
+}
+
+func TestAcknowledged(t *testing.T) {
+	var wc *WriteConcern
+	require.True(t, wc.Acknowledged())
+	require.True(t, New(WMajority()).Acknowledged())
+	require.True(t, New(W(1)).Acknowledged())
+	require.False(t, New(W(0)).Acknowledged())
+}
+
+func TestAcknowledgedElement(t *testing.T) {
+	elem := func(wc *WriteConcern) *bson.Element {
+		e, err := wc.MarshalBSONElement()
+		require.NoError(t, err)
+		return e
+	}
+
+	require.True(t, AcknowledgedElement(elem(New(WMajority()))))
+	require.True(t, AcknowledgedElement(elem(New(WTagSet("dc")))))
+	require.True(t, AcknowledgedElement(elem(New(W(1)))))
+	require.True(t, AcknowledgedElement(elem(New(J(true)))))
+	require.False(t, AcknowledgedElement(elem(New(W(0)))))
+	require.False(t, AcknowledgedElement(bson.EC.SubDocumentFromElements("writeConcern", bson.EC.Int64("w", 0))))
+}
@@ -0,0 +1,125 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package crypt implements the cryptography of client-side field level encryption: the
+// AEAD_AES_256_CBC_HMAC_SHA_512 algorithm data keys encrypt values with, and the KMS providers whose
+// master keys encrypt the data keys stored in a key vault.
+package crypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// KeyLen is the length of the keys used to encrypt and decrypt. A key is made of a 32 byte AES
+// encryption key, a 32 byte HMAC key and a 32 byte key used to derive deterministic IVs, in that order.
+const KeyLen = 96
+
+const (
+	encKeyLen = 32
+	macKeyLen = 32
+	ivLen     = aes.BlockSize
+	tagLen    = 32
+)
+
+// ErrAuthentication is returned when a ciphertext fails HMAC validation, i.e. it was not encrypted
+// with the key or associated data it is decrypted with, or it was altered.
+var ErrAuthentication = errors.New("crypt: HMAC validation failure")
+
+var randReader = rand.Reader
+
+// Encrypt encrypts plaintext with key and authenticates it along with the associated data ad. When
+// deterministic is true, the IV is derived from the key, the associated data and the plaintext, so
+// that encrypting the same plaintext twice yields the same ciphertext. Otherwise the IV is random.
+// The ciphertext is the IV followed by the AES-256-CBC ciphertext and the HMAC-SHA-512 tag truncated
+// to 32 bytes.
+func Encrypt(key, plaintext, ad []byte, deterministic bool) ([]byte, error) {
+	if len(key) != KeyLen {
+		return nil, fmt.Errorf("crypt: key must be %d bytes, got %d", KeyLen, len(key))
+	}
+
+	iv := make([]byte, ivLen)
+	if deterministic {
+		mac := hmac.New(sha512.New, key[encKeyLen+macKeyLen:])
+		_, _ = mac.Write(ad)
+		_, _ = mac.Write(adLen(ad))
+		_, _ = mac.Write(plaintext)
+		copy(iv, mac.Sum(nil))
+	} else if _, err := io.ReadFull(randReader, iv); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key[:encKeyLen])
+	if err != nil {
+		return nil, err
+	}
+
+	pad := aes.BlockSize - len(plaintext)%aes.BlockSize
+	out := make([]byte, ivLen+len(plaintext)+pad, ivLen+len(plaintext)+pad+tagLen)
+	copy(out, iv)
+	copy(out[ivLen:], plaintext)
+	for i := len(out) - pad; i < len(out); i++ {
+		out[i] = byte(pad)
+	}
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(out[ivLen:], out[ivLen:])
+
+	return append(out, tag(key[encKeyLen:encKeyLen+macKeyLen], ad, out)...), nil
+}
+
+// Decrypt authenticates ciphertext and the associated data ad with key, then decrypts ciphertext.
+func Decrypt(key, ciphertext, ad []byte) ([]byte, error) {
+	if len(key) != KeyLen {
+		return nil, fmt.Errorf("crypt: key must be %d bytes, got %d", KeyLen, len(key))
+	}
+	n := len(ciphertext) - ivLen - tagLen
+	if n < aes.BlockSize || n%aes.BlockSize != 0 {
+		return nil, errors.New("crypt: invalid ciphertext length")
+	}
+
+	sealed := ciphertext[:ivLen+n]
+	if !hmac.Equal(tag(key[encKeyLen:encKeyLen+macKeyLen], ad, sealed), ciphertext[ivLen+n:]) {
+		return nil, ErrAuthentication
+	}
+
+	block, err := aes.NewCipher(key[:encKeyLen])
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, n)
+	cipher.NewCBCDecrypter(block, sealed[:ivLen]).CryptBlocks(plaintext, sealed[ivLen:])
+
+	pad := int(plaintext[n-1])
+	if pad == 0 || pad > aes.BlockSize || !bytes.Equal(plaintext[n-pad:], bytes.Repeat([]byte{byte(pad)}, pad)) {
+		return nil, errors.New("crypt: invalid padding")
+	}
+	return plaintext[:n-pad], nil
+}
+
+// tag computes the authentication tag of the IV and ciphertext sealed along with the associated data
+// ad.
+func tag(macKey, ad, sealed []byte) []byte {
+	mac := hmac.New(sha512.New, macKey)
+	_, _ = mac.Write(ad)
+	_, _ = mac.Write(sealed)
+	_, _ = mac.Write(adLen(ad))
+	return mac.Sum(nil)[:tagLen]
+}
+
+// adLen returns the length of ad in bits as a 64-bit big-endian integer.
+func adLen(ad []byte) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(len(ad))*8)
+	return b
+}
@@ -0,0 +1,199 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package crypt
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeyLen)
+}
+
+func TestAEAD(t *testing.T) {
+	key := testKey(1)
+	ad := []byte("associated data")
+
+	for _, plaintext := range [][]byte{{}, []byte("0123456789abcde"), []byte("0123456789abcdef"), bytes.Repeat([]byte("x"), 100)} {
+		ciphertext, err := Encrypt(key, plaintext, ad, false)
+		require.NoError(t, err)
+		require.Len(t, ciphertext, ivLen+(len(plaintext)/16+1)*16+tagLen)
+
+		decrypted, err := Decrypt(key, ciphertext, ad)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+	}
+
+	t.Run("deterministic", func(t *testing.T) {
+		first, err := Encrypt(key, []byte("secret"), ad, true)
+		require.NoError(t, err)
+		second, err := Encrypt(key, []byte("secret"), ad, true)
+		require.NoError(t, err)
+		require.Equal(t, first, second)
+
+		other, err := Encrypt(key, []byte("secret"), []byte("other"), true)
+		require.NoError(t, err)
+		require.NotEqual(t, first[:ivLen], other[:ivLen])
+	})
+	t.Run("random", func(t *testing.T) {
+		first, err := Encrypt(key, []byte("secret"), ad, false)
+		require.NoError(t, err)
+		second, err := Encrypt(key, []byte("secret"), ad, false)
+		require.NoError(t, err)
+		require.NotEqual(t, first, second)
+	})
+	t.Run("authentication", func(t *testing.T) {
+		ciphertext, err := Encrypt(key, []byte("secret"), ad, false)
+		require.NoError(t, err)
+
+		_, err = Decrypt(key, ciphertext, []byte("other"))
+		require.Equal(t, ErrAuthentication, err)
+		_, err = Decrypt(testKey(2), ciphertext, ad)
+		require.Equal(t, ErrAuthentication, err)
+
+		ciphertext[ivLen] ^= 1
+		_, err = Decrypt(key, ciphertext, ad)
+		require.Equal(t, ErrAuthentication, err)
+
+		_, err = Decrypt(key, ciphertext[:ivLen+tagLen], ad)
+		require.Error(t, err)
+	})
+	t.Run("key length", func(t *testing.T) {
+		_, err := Encrypt(key[:64], []byte("secret"), ad, false)
+		require.Error(t, err)
+	})
+}
+
+// TestAEADKnownAnswer uses the AEAD_AES_256_CBC_HMAC_SHA_512 test case of section 5.4 of
+// draft-mcgrew-aead-aes-cbc-hmac-sha2-05, whose K is MAC_KEY followed by ENC_KEY.
+func TestAEADKnownAnswer(t *testing.T) {
+	seq := func(from byte) []byte {
+		b := make([]byte, 32)
+		for i := range b {
+			b[i] = from + byte(i)
+		}
+		return b
+	}
+	unhex := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		require.NoError(t, err)
+		return b
+	}
+
+	// the key is ENC_KEY, MAC_KEY and IV_KEY, in that order
+	key := append(append(seq(0x20), seq(0x00)...), seq(0x40)...)
+	plaintext := []byte("A cipher system must not be required to be secret, and it must be able to fall into the hands of the enemy without inconvenience")
+	ad := []byte("The second principle of Auguste Kerckhoffs")
+	iv := unhex("1af38c2dc2b96ffdd86694092341bc04")
+	random := unhex("1af38c2dc2b96ffdd86694092341bc04" +
+		"4affaaadb78c31c5da4b1b590d10ffbd3dd8d5d302423526912da037ecbcc7bd822c301dd67c373bccb584ad3e9279c2" +
+		"e6d12a1374b77f077553df829410446b36ebd97066296ae6427ea75c2e0846a11a09ccf5370dc80bfecbad28c73f09b3" +
+		"a3b75e662a2594410ae496b2e2e6609e31e6e02cc837f053d21f37ff4f51950bbe2638d09dd7a4930930806d0703b1f6" +
+		"4dd3b4c088a7f45c216839645b2012bf2e6269a8c56a816dbc1b267761955bc5")
+	// the IV is the first 16 bytes of HMAC-SHA-512(IV_KEY, A || AL || P), computed with OpenSSL
+	// and Python's hmac module
+	deterministic := unhex("789303e6989b65feacfa48ce9a4c292c" +
+		"758b56c0cca8bfa2aa3805aa710fb0c3110234e78b5a8464783dda10b7c8b7963b3740d6b2023dd6371680b76568ade5" +
+		"3e33c4c70204dcf002f947de43a2ff8239013a85210a94b70e7c3e29e999453cbe03be70fb733e03a2b7648bfc9314c8" +
+		"e459a8be7303d10009942101eba6a558832acd86740de7e6b295987203c8f7aa37d7902caf8e8b828a76249b86ebb62d" +
+		"e015b4a913b549a23d35dea3343390299b3ef0786de205ccce81a92cde0b349b")
+
+	t.Run("random", func(t *testing.T) {
+		defer func(r io.Reader) { randReader = r }(randReader)
+		randReader = bytes.NewReader(iv)
+
+		ciphertext, err := Encrypt(key, plaintext, ad, false)
+		require.NoError(t, err)
+		require.Equal(t, random, ciphertext)
+	})
+	t.Run("deterministic", func(t *testing.T) {
+		ciphertext, err := Encrypt(key, plaintext, ad, true)
+		require.NoError(t, err)
+		require.Equal(t, deterministic, ciphertext)
+	})
+	t.Run("decrypt", func(t *testing.T) {
+		for _, ciphertext := range [][]byte{random, deterministic} {
+			decrypted, err := Decrypt(key, ciphertext, ad)
+			require.NoError(t, err)
+			require.Equal(t, plaintext, decrypted)
+		}
+	})
+}
+
+func TestSignV4(t *testing.T) {
+	// the get-vanilla case of the AWS Signature Version 4 test suite
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	signV4(req, nil, credentials{"AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", ""}, "us-east-1", "service", now)
+
+	require.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	require.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, "+
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
+
+func TestAWSKMS(t *testing.T) {
+	var targets []string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		targets = append(targets, r.Header.Get("X-Amz-Target"))
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=id/") ||
+			r.Header.Get("X-Amz-Security-Token") != "token" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"IncompleteSignatureException","message":"bad signature"}`))
+			return
+		}
+
+		var params map[string][]byte
+		_ = json.NewDecoder(r.Body).Decode(&params)
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.Encrypt":
+			_ = json.NewEncoder(w).Encode(map[string][]byte{"CiphertextBlob": append([]byte("wrapped:"), params["Plaintext"]...)})
+		case "TrentService.Decrypt":
+			_ = json.NewEncoder(w).Encode(map[string][]byte{"Plaintext": bytes.TrimPrefix(params["CiphertextBlob"], []byte("wrapped:"))})
+		}
+	}))
+	defer srv.Close()
+
+	masterKey := bson.NewDocument(
+		bson.EC.String("provider", "aws"),
+		bson.EC.String("region", "us-east-1"),
+		bson.EC.String("key", "arn:aws:kms:us-east-1:000000000000:key/test"),
+		bson.EC.String("endpoint", srv.Listener.Addr().String()),
+	)
+	kms := AWSKMS{AccessKeyID: "id", SecretAccessKey: "secret", SessionToken: "token", HTTPClient: srv.Client()}
+
+	wrapped, err := kms.WrapKey(context.Background(), masterKey, []byte("dek"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("wrapped:dek"), wrapped)
+
+	dek, err := kms.UnwrapKey(context.Background(), masterKey, wrapped)
+	require.NoError(t, err)
+	require.Equal(t, []byte("dek"), dek)
+	require.Equal(t, []string{"TrentService.Encrypt", "TrentService.Decrypt"}, targets)
+
+	kms.SessionToken = ""
+	_, err = kms.WrapKey(context.Background(), masterKey, []byte("dek"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bad signature")
+
+	_, err = kms.WrapKey(context.Background(), bson.NewDocument(bson.EC.String("region", "us-east-1")), []byte("dek"))
+	require.Error(t, err)
+}
@@ -0,0 +1,159 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package crypt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+)
+
+// KMSProvider encrypts and decrypts data keys with a master key the provider holds or has access
+// to. The master key of a data key is described by the masterKey document stored with it in the key
+// vault.
+type KMSProvider interface {
+	// WrapKey encrypts the data key dek with the master key described by masterKey.
+	WrapKey(ctx context.Context, masterKey *bson.Document, dek []byte) ([]byte, error)
+
+	// UnwrapKey decrypts the key material of a data key with the master key described by masterKey.
+	UnwrapKey(ctx context.Context, masterKey *bson.Document, keyMaterial []byte) ([]byte, error)
+}
+
+// LocalKMS is a KMS provider whose master key is held by the application.
+type LocalKMS struct {
+	// Key is the master key, which must be KeyLen bytes.
+	Key []byte
+}
+
+// WrapKey implements the KMSProvider interface.
+func (l LocalKMS) WrapKey(_ context.Context, _ *bson.Document, dek []byte) ([]byte, error) {
+	return Encrypt(l.Key, dek, nil, false)
+}
+
+// UnwrapKey implements the KMSProvider interface.
+func (l LocalKMS) UnwrapKey(_ context.Context, _ *bson.Document, keyMaterial []byte) ([]byte, error) {
+	return Decrypt(l.Key, keyMaterial, nil)
+}
+
+// AWSKMS is a KMS provider whose master keys are customer master keys of the AWS Key Management
+// Service. Their masterKey documents hold the region and the ARN of the key, as "region" and "key",
+// and optionally the host of the endpoint to use in place of kms.<region>.amazonaws.com, as
+// "endpoint".
+type AWSKMS struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// HTTPClient is the client requests to AWS are sent with. If it is nil, http.DefaultClient is
+	// used.
+	HTTPClient *http.Client
+
+	now func() time.Time
+}
+
+// WrapKey implements the KMSProvider interface.
+func (a AWSKMS) WrapKey(ctx context.Context, masterKey *bson.Document, dek []byte) ([]byte, error) {
+	key, err := lookupString(masterKey, "key")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct{ CiphertextBlob []byte }
+	err = a.do(ctx, masterKey, "Encrypt", map[string]interface{}{"KeyId": key, "Plaintext": dek}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.CiphertextBlob, nil
+}
+
+// UnwrapKey implements the KMSProvider interface.
+func (a AWSKMS) UnwrapKey(ctx context.Context, masterKey *bson.Document, keyMaterial []byte) ([]byte, error) {
+	var resp struct{ Plaintext []byte }
+	err := a.do(ctx, masterKey, "Decrypt", map[string]interface{}{"CiphertextBlob": keyMaterial}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Plaintext, nil
+}
+
+// do runs the action of the AWS KMS API against the region of masterKey, decoding the response into
+// resp. encoding/json encodes the []byte values of params and resp as base64, as the API requires.
+func (a AWSKMS) do(ctx context.Context, masterKey *bson.Document, action string, params map[string]interface{}, resp interface{}) error {
+	region, err := lookupString(masterKey, "region")
+	if err != nil {
+		return err
+	}
+	host := "kms." + region + ".amazonaws.com"
+	if val, err := masterKey.LookupErr("endpoint"); err == nil {
+		endpoint, ok := val.StringValueOK()
+		if !ok {
+			return errors.New("crypt: masterKey endpoint must be a string")
+		}
+		host = endpoint
+	}
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "https://"+host+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+
+	now := time.Now
+	if a.now != nil {
+		now = a.now
+	}
+	signV4(req, body, credentials{a.AccessKeyID, a.SecretAccessKey, a.SessionToken}, region, "kms", now())
+
+	client := a.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		var awsErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(b, &awsErr)
+		return fmt.Errorf("crypt: AWS KMS %s failed with status %d: %s %s", action, res.StatusCode, awsErr.Type, awsErr.Message)
+	}
+	return json.Unmarshal(b, resp)
+}
+
+func lookupString(doc *bson.Document, key string) (string, error) {
+	if doc != nil {
+		if val, err := doc.LookupErr(key); err == nil {
+			if s, ok := val.StringValueOK(); ok {
+				return s, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("crypt: masterKey requires a string %s", key)
+}
@@ -101,6 +101,10 @@ func (e *multiError) Errors() []error {
 	return e.errors
 }
 
+func (e *multiError) Unwrap() []error {
+	return e.errors
+}
+
 type wrappedError struct {
 	message string
 	inner   error
@@ -117,3 +121,7 @@ func (e *wrappedError) Error() string {
 func (e *wrappedError) Inner() error {
 	return e.inner
 }
+
+func (e *wrappedError) Unwrap() error {
+	return e.inner
+}
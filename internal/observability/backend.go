@@ -0,0 +1,74 @@
+package observability
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/trace"
+)
+
+// Span is a span started by a Backend. It is the subset of *trace.Span used by the driver, so
+// OpenCensus spans are returned unchanged; other backends translate the OpenCensus statuses and
+// attributes passed to it.
+type Span interface {
+	End()
+	SetStatus(status trace.Status)
+	AddAttributes(attributes ...trace.Attribute)
+	Annotate(attributes []trace.Attribute, str string)
+	Annotatef(attributes []trace.Attribute, format string, a ...interface{})
+}
+
+// Backend emits the spans and measurements of the driver. The tags applied with Tag, such as
+// KeyMethod and KeyDatabase, are available from the context passed to both methods through
+// tag.FromContext.
+type Backend interface {
+	// StartSpan starts a span with the given name. If sampler is not nil, it was returned by the
+	// configured TraceSampler and should decide whether the span is sampled.
+	StartSpan(ctx context.Context, name string, sampler trace.Sampler) (context.Context, Span)
+
+	// Record records the given measurements.
+	Record(ctx context.Context, ms ...stats.Measurement)
+}
+
+// noSpan is returned when no span is started. Methods on a nil *trace.Span do nothing.
+var noSpan Span = (*trace.Span)(nil)
+
+// openCensus is the default Backend, which emits spans and measurements with OpenCensus.
+type openCensus struct{}
+
+func (openCensus) StartSpan(ctx context.Context, name string, sampler trace.Sampler) (context.Context, Span) {
+	if sampler != nil {
+		return trace.StartSpan(ctx, name, trace.WithSampler(sampler))
+	}
+	return trace.StartSpan(ctx, name)
+}
+
+func (openCensus) Record(ctx context.Context, ms ...stats.Measurement) {
+	stats.Record(ctx, ms...)
+}
+
+// backendValue holds the Backend in an atomic.Value, which requires a consistent concrete type.
+type backendValue struct {
+	b Backend
+}
+
+var backend atomic.Value // backendValue
+
+func init() {
+	backend.Store(backendValue{openCensus{}})
+}
+
+// SetBackend replaces the backend used to emit spans and measurements in the whole process. A nil
+// backend restores the OpenCensus backend. It is safe to call concurrently with running
+// operations.
+func SetBackend(b Backend) {
+	if b == nil {
+		b = openCensus{}
+	}
+	backend.Store(backendValue{b})
+}
+
+func currentBackend() Backend {
+	return backend.Load().(backendValue).b
+}
@@ -0,0 +1,174 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync/atomic"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+)
+
+// Options configures the instrumentation performed by the driver.
+type Options struct {
+	// Disabled turns off all spans, stats and tag propagation performed by the driver.
+	Disabled bool
+
+	// TraceSampler, if set, is called with the name of every span the driver starts, e.g.
+	// "mongo-go/mongo.(*Collection).Find", and returns the sampler to use for it. Returning nil
+	// uses the globally configured OpenCensus sampler. Sampling only applies to spans; stats,
+	// including error counts, are recorded for every operation.
+	TraceSampler func(spanName string) trace.Sampler
+
+	// CollectionTag controls how collection names are recorded in the KeyCollection tag. Deployments
+	// with many collections can hash or drop the tag to bound the cardinality of the exported views.
+	CollectionTag CollectionTagMode
+}
+
+// CollectionTagMode specifies how the collection name of an operation is recorded.
+type CollectionTagMode uint8
+
+// These constants are the supported collection tag modes.
+const (
+	// CollectionTagName records the collection name as is. This is the default.
+	CollectionTagName CollectionTagMode = iota
+	// CollectionTagHash records a hash of the collection name.
+	CollectionTagHash
+	// CollectionTagDrop omits the collection tag.
+	CollectionTagDrop
+)
+
+var config atomic.Value // *Options
+
+func init() {
+	config.Store(&Options{})
+}
+
+// Configure replaces the instrumentation options used by the driver. It is safe to call
+// concurrently with running operations.
+func Configure(opts Options) {
+	config.Store(&opts)
+}
+
+func current() *Options {
+	return config.Load().(*Options)
+}
+
+// Enabled reports whether instrumentation is enabled.
+func Enabled() bool {
+	return !current().Disabled
+}
+
+// StartSpan starts a span with the given name, honoring the configured sampler. If
+// instrumentation is disabled, the context is returned unchanged along with a span that does
+// nothing.
+func StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	opts := current()
+	if opts.Disabled {
+		return ctx, noSpan
+	}
+
+	var sampler trace.Sampler
+	if opts.TraceSampler != nil {
+		sampler = opts.TraceSampler(name)
+	}
+
+	return currentBackend().StartSpan(ctx, name, sampler)
+}
+
+// Record records the given measurements unless instrumentation is disabled.
+func Record(ctx context.Context, ms ...stats.Measurement) {
+	if current().Disabled {
+		return
+	}
+
+	currentBackend().Record(ctx, ms...)
+}
+
+// Tag returns a context with the given tag mutations applied. If instrumentation is disabled, the
+// context is returned unchanged. A mutation with an invalid value is skipped rather than causing
+// the others to be dropped.
+func Tag(ctx context.Context, mutators ...tag.Mutator) context.Context {
+	if current().Disabled {
+		return ctx
+	}
+
+	tagged, err := tag.New(ctx, mutators...)
+	if err == nil {
+		return tagged
+	}
+
+	for _, m := range mutators {
+		if tagged, err = tag.New(ctx, m); err == nil {
+			ctx = tagged
+		}
+	}
+
+	return ctx
+}
+
+// TagNamespace returns a context tagged with the database, collection and command name of an
+// operation. Empty values are not tagged, and the collection is recorded according to the
+// configured CollectionTagMode.
+func TagNamespace(ctx context.Context, db, collection, commandName string) context.Context {
+	opts := current()
+	if opts.Disabled {
+		return ctx
+	}
+
+	switch opts.CollectionTag {
+	case CollectionTagHash:
+		if collection != "" {
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(collection))
+			collection = fmt.Sprintf("%08x", h.Sum32())
+		}
+	case CollectionTagDrop:
+		collection = ""
+	}
+
+	mutators := make([]tag.Mutator, 0, 3)
+	if db != "" {
+		mutators = append(mutators, tag.Upsert(KeyDatabase, tagValue(db)))
+	}
+	if collection != "" {
+		mutators = append(mutators, tag.Upsert(KeyCollection, tagValue(collection)))
+	}
+	if commandName != "" {
+		mutators = append(mutators, tag.Upsert(KeyCommandName, tagValue(commandName)))
+	}
+
+	return Tag(ctx, mutators...)
+}
+
+// tagValue returns s as a valid tag value. Tag values must be at most 255 printable ASCII
+// characters, while database and collection names may contain any UTF-8 characters.
+func tagValue(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return '_'
+		}
+		return r
+	}, s)
+
+	if len(s) > 255 {
+		s = s[:255]
+	}
+
+	return s
+}
+
+// RecordError records err as having occurred in the given part of an operation. The part and the
+// code and category of err are tagged on a copy of ctx, so measurements later recorded with ctx are
+// not attributed to the failure. A nil err records the error without a code or category.
+func RecordError(ctx context.Context, part string, err error) {
+	if current().Disabled {
+		return
+	}
+
+	mutators := append(errorTags(err), tag.Upsert(KeyPart, part))
+	Record(Tag(ctx, mutators...), MErrors.M(1))
+}
@@ -0,0 +1,126 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+)
+
+func TestConfigure(t *testing.T) {
+	defer Configure(Options{})
+
+	t.Run("Disabled", func(t *testing.T) {
+		v := &view.View{Name: "test/disabled_calls", Measure: MCalls, Aggregation: view.Count()}
+		if err := view.Register(v); err != nil {
+			t.Fatalf("unexpected error registering view: %s", err)
+		}
+		defer view.Unregister(v)
+
+		Configure(Options{Disabled: true})
+		if Enabled() {
+			t.Fatalf("expected instrumentation to be disabled")
+		}
+
+		ctx := context.Background()
+		if got := Tag(ctx, tag.Insert(KeyMethod, "find")); got != ctx {
+			t.Fatalf("expected context to be returned unchanged")
+		}
+
+		got, span := StartSpan(ctx, "test")
+		if got != ctx || span != noSpan {
+			t.Fatalf("expected no span to be started")
+		}
+		span.End()
+
+		Record(ctx, MCalls.M(1))
+		rows, err := view.RetrieveData(v.Name)
+		if err != nil {
+			t.Fatalf("unexpected error retrieving data: %s", err)
+		}
+		if len(rows) != 0 {
+			t.Fatalf("expected no rows to be recorded, got %d", len(rows))
+		}
+	})
+
+	t.Run("TraceSampler", func(t *testing.T) {
+		Configure(Options{
+			TraceSampler: func(name string) trace.Sampler {
+				if name == "sampled" {
+					return trace.AlwaysSample()
+				}
+				return trace.NeverSample()
+			},
+		})
+
+		_, span := StartSpan(context.Background(), "sampled")
+		if !span.(*trace.Span).SpanContext().IsSampled() {
+			t.Fatalf("expected span to be sampled")
+		}
+		span.End()
+
+		_, span = StartSpan(context.Background(), "dropped")
+		if span.(*trace.Span).SpanContext().IsSampled() {
+			t.Fatalf("expected span not to be sampled")
+		}
+		span.End()
+	})
+}
+
+func TestTagNamespace(t *testing.T) {
+	defer Configure(Options{})
+
+	testCases := []struct {
+		name       string
+		mode       CollectionTagMode
+		collection string
+		ok         bool
+	}{
+		{"Name", CollectionTagName, "users", true},
+		{"Hash", CollectionTagHash, "5e7cc513", true},
+		{"Drop", CollectionTagDrop, "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			Configure(Options{CollectionTag: tc.mode})
+
+			ctx := TagNamespace(context.Background(), "app", "users", "find")
+			tags := tag.FromContext(ctx)
+
+			if db, _ := tags.Value(KeyDatabase); db != "app" {
+				t.Fatalf("expected database tag %q, got %q", "app", db)
+			}
+			if name, _ := tags.Value(KeyCommandName); name != "find" {
+				t.Fatalf("expected command name tag %q, got %q", "find", name)
+			}
+
+			coll, ok := tags.Value(KeyCollection)
+			if ok != tc.ok || coll != tc.collection {
+				t.Fatalf("expected collection tag %q (present: %v), got %q (present: %v)", tc.collection, tc.ok, coll, ok)
+			}
+		})
+	}
+}
+
+func TestTagInvalidValue(t *testing.T) {
+	ctx := Tag(context.Background(),
+		tag.Upsert(KeyMethod, "find"),
+		tag.Upsert(KeyPart, "invalid\x00value"),
+	)
+	tags := tag.FromContext(ctx)
+
+	if method, _ := tags.Value(KeyMethod); method != "find" {
+		t.Fatalf("expected method tag %q, got %q", "find", method)
+	}
+	if _, ok := tags.Value(KeyPart); ok {
+		t.Fatalf("expected invalid part tag to be skipped")
+	}
+
+	ctx = TagNamespace(context.Background(), "app", "café", "find")
+	if coll, _ := tag.FromContext(ctx).Value(KeyCollection); coll != "caf_" {
+		t.Fatalf("expected collection tag %q, got %q", "caf_", coll)
+	}
+}
@@ -0,0 +1,86 @@
+package observability
+
+import (
+	"context"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/address"
+)
+
+// OperationDebug holds details of how an operation ran, filled by the driver for operations run
+// with a context returned by WithDebug. Unlike command monitoring, it only costs anything for the
+// operations it is requested for. An OperationDebug must not be shared by concurrent operations.
+type OperationDebug struct {
+	// Address is the address of the server the last command of the operation ran against.
+	Address address.Address
+
+	// RTT is the time between sending the last command of the operation and reading its reply.
+	RTT time.Duration
+
+	// Retries is the number of times operations run with the OperationDebug were retried.
+	Retries int
+
+	// ClusterTime is the $clusterTime of the last reply, or nil if the server did not report one.
+	ClusterTime *bson.Document
+
+	// OperationTime is the operationTime of the last reply, or nil if the server did not report one.
+	OperationTime *bson.Timestamp
+
+	sent time.Time
+}
+
+type debugKey struct{}
+
+// WithDebug returns a copy of ctx whose operations fill d.
+func WithDebug(ctx context.Context, d *OperationDebug) context.Context {
+	return context.WithValue(ctx, debugKey{}, d)
+}
+
+func debugFrom(ctx context.Context) *OperationDebug {
+	d, _ := ctx.Value(debugKey{}).(*OperationDebug)
+	return d
+}
+
+// DebugSent records that a command of the operation run with ctx was sent to the server at addr.
+func DebugSent(ctx context.Context, addr address.Address) {
+	if d := debugFrom(ctx); d != nil {
+		d.Address = addr
+		d.sent = time.Now()
+	}
+}
+
+// DebugReply records the reply to the last command sent by the operation run with ctx.
+func DebugReply(ctx context.Context, reply bson.Reader) {
+	d := debugFrom(ctx)
+	if d == nil {
+		return
+	}
+
+	if !d.sent.IsZero() {
+		d.RTT = time.Since(d.sent)
+	}
+	d.ClusterTime, d.OperationTime = nil, nil
+	if reply == nil {
+		return
+	}
+
+	if elem, err := reply.Lookup("$clusterTime"); err == nil {
+		if rdr, ok := elem.Value().ReaderDocumentOK(); ok {
+			// the reply may be read into a buffer that is reused
+			d.ClusterTime, _ = bson.ReadDocument(append([]byte(nil), rdr...))
+		}
+	}
+	if elem, err := reply.Lookup("operationTime"); err == nil {
+		if t, i, ok := elem.Value().TimestampOK(); ok {
+			d.OperationTime = &bson.Timestamp{T: t, I: i}
+		}
+	}
+}
+
+// DebugRetry records that the operation run with ctx is being retried.
+func DebugRetry(ctx context.Context) {
+	if d := debugFrom(ctx); d != nil {
+		d.Retries++
+	}
+}
@@ -0,0 +1,165 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+)
+
+// These constants are the values of KeyErrorCode for errors that did not come from the server.
+const (
+	ErrorCodeNetwork = "network"
+	ErrorCodeTimeout = "timeout"
+	ErrorCodeClient  = "client"
+)
+
+// These constants are the values of KeyErrorCategory.
+const (
+	ErrorCategoryRetryable    = "retryable"
+	ErrorCategoryNonRetryable = "nonretryable"
+)
+
+// Server error codes with a specific span status.
+const (
+	codeUnauthorized         = 13
+	codeAuthenticationFailed = 18
+	codeNamespaceNotFound    = 26
+	codeMaxTimeMSExpired     = 50
+	codeDuplicateKey         = 11000
+)
+
+// networkErrorLabel is the error label the server and the command package attach to network errors.
+const networkErrorLabel = "NetworkError"
+
+// coder is implemented by errors that carry a server error code, such as command.Error.
+type coder interface {
+	ErrorCode() int32
+}
+
+// labeler is implemented by errors that carry server error labels, such as command.Error.
+type labeler interface {
+	HasErrorLabel(label string) bool
+}
+
+// retryabler is implemented by errors that know whether the operation that returned them can be
+// retried, such as command.Error.
+type retryabler interface {
+	Retryable() bool
+}
+
+// serverCode returns the server error code carried by err or any error it wraps, or 0 if there is
+// none.
+func serverCode(err error) int32 {
+	var c coder
+	if errors.As(err, &c) {
+		return c.ErrorCode()
+	}
+	return 0
+}
+
+// timeouter is implemented by errors that know whether they are timeouts, such as net.Error and
+// topology.ErrServerSelectionTimeout.
+type timeouter interface {
+	Timeout() bool
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var t timeouter
+	return errors.As(err, &t) && t.Timeout()
+}
+
+func isNetwork(err error) bool {
+	var l labeler
+	if errors.As(err, &l) && l.HasErrorLabel(networkErrorLabel) {
+		return true
+	}
+	var ne net.Error
+	return errors.As(err, &ne) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// ErrorCode returns the value of KeyErrorCode for err.
+func ErrorCode(err error) string {
+	if code := serverCode(err); code != 0 {
+		return strconv.Itoa(int(code))
+	}
+
+	switch {
+	case isTimeout(err):
+		return ErrorCodeTimeout
+	case isNetwork(err):
+		return ErrorCodeNetwork
+	default:
+		return ErrorCodeClient
+	}
+}
+
+// ErrorCategory returns the value of KeyErrorCategory for err. Errors that report whether they are
+// retryable are trusted; otherwise only network errors are considered retryable.
+func ErrorCategory(err error) string {
+	var r retryabler
+	if errors.As(err, &r) {
+		if r.Retryable() {
+			return ErrorCategoryRetryable
+		}
+		return ErrorCategoryNonRetryable
+	}
+
+	if !isTimeout(err) && isNetwork(err) {
+		return ErrorCategoryRetryable
+	}
+	return ErrorCategoryNonRetryable
+}
+
+// errorTags returns the mutators tagging the code and category of err.
+func errorTags(err error) []tag.Mutator {
+	if err == nil {
+		return nil
+	}
+
+	return []tag.Mutator{
+		tag.Upsert(KeyErrorCode, ErrorCode(err)),
+		tag.Upsert(KeyErrorCategory, ErrorCategory(err)),
+	}
+}
+
+// SpanStatus returns the span status for err. Server errors with an equivalent trace status code
+// are mapped to it, as are timeouts, cancellations and network errors; everything else is
+// StatusCodeInternal.
+func SpanStatus(err error) trace.Status {
+	if err == nil {
+		return trace.Status{Code: int32(trace.StatusCodeOK)}
+	}
+
+	code := trace.StatusCodeInternal
+	switch serverCode(err) {
+	case codeNamespaceNotFound:
+		code = trace.StatusCodeNotFound
+	case codeMaxTimeMSExpired:
+		code = trace.StatusCodeDeadlineExceeded
+	case codeAuthenticationFailed:
+		code = trace.StatusCodeUnauthenticated
+	case codeUnauthorized:
+		code = trace.StatusCodePermissionDenied
+	case codeDuplicateKey:
+		code = trace.StatusCodeAlreadyExists
+	case 0:
+		switch {
+		case isTimeout(err):
+			code = trace.StatusCodeDeadlineExceeded
+		case errors.Is(err, context.Canceled):
+			code = trace.StatusCodeCancelled
+		case isNetwork(err):
+			code = trace.StatusCodeUnavailable
+		}
+	}
+
+	return trace.Status{Code: int32(code), Message: err.Error()}
+}
@@ -0,0 +1,120 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+)
+
+// serverError mimics command.Error, which this package cannot import.
+type serverError struct {
+	code    int32
+	labels  []string
+	wrapped error
+}
+
+func (e serverError) Error() string    { return fmt.Sprintf("server error %d", e.code) }
+func (e serverError) Unwrap() error    { return e.wrapped }
+func (e serverError) ErrorCode() int32 { return e.code }
+func (e serverError) Retryable() bool  { return e.code == 91 || e.HasErrorLabel(networkErrorLabel) }
+func (e serverError) HasErrorLabel(label string) bool {
+	for _, l := range e.labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// selectionTimeoutError mimics topology.ErrServerSelectionTimeout, which is a timeout but not a
+// network error.
+type selectionTimeoutError struct{}
+
+func (selectionTimeoutError) Error() string { return "server selection timeout" }
+func (selectionTimeoutError) Timeout() bool { return true }
+
+func TestErrorClassification(t *testing.T) {
+	var _ net.Error = timeoutError{}
+
+	testCases := []struct {
+		name     string
+		err      error
+		code     string
+		category string
+		status   int32
+	}{
+		{"DuplicateKey", serverError{code: 11000}, "11000", ErrorCategoryNonRetryable, trace.StatusCodeAlreadyExists},
+		{"NamespaceNotFound", serverError{code: 26}, "26", ErrorCategoryNonRetryable, trace.StatusCodeNotFound},
+		{"MaxTimeMSExpired", serverError{code: 50}, "50", ErrorCategoryNonRetryable, trace.StatusCodeDeadlineExceeded},
+		{"AuthenticationFailed", fmt.Errorf("auth: %w", serverError{code: 18}), "18", ErrorCategoryNonRetryable, trace.StatusCodeUnauthenticated},
+		{"ShutdownInProgress", serverError{code: 91}, "91", ErrorCategoryRetryable, trace.StatusCodeInternal},
+		{"LabeledNetwork", serverError{labels: []string{networkErrorLabel}, wrapped: io.EOF}, ErrorCodeNetwork, ErrorCategoryRetryable, trace.StatusCodeUnavailable},
+		{"EOF", io.ErrUnexpectedEOF, ErrorCodeNetwork, ErrorCategoryRetryable, trace.StatusCodeUnavailable},
+		{"SocketTimeout", fmt.Errorf("read: %w", timeoutError{}), ErrorCodeTimeout, ErrorCategoryNonRetryable, trace.StatusCodeDeadlineExceeded},
+		{"ServerSelectionTimeout", fmt.Errorf("select: %w", selectionTimeoutError{}), ErrorCodeTimeout, ErrorCategoryNonRetryable, trace.StatusCodeDeadlineExceeded},
+		{"ContextDeadline", context.DeadlineExceeded, ErrorCodeTimeout, ErrorCategoryNonRetryable, trace.StatusCodeDeadlineExceeded},
+		{"ContextCanceled", context.Canceled, ErrorCodeClient, ErrorCategoryNonRetryable, trace.StatusCodeCancelled},
+		{"Client", errors.New("invalid document"), ErrorCodeClient, ErrorCategoryNonRetryable, trace.StatusCodeInternal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ErrorCode(tc.err); got != tc.code {
+				t.Errorf("expected code %q, got %q", tc.code, got)
+			}
+			if got := ErrorCategory(tc.err); got != tc.category {
+				t.Errorf("expected category %q, got %q", tc.category, got)
+			}
+			if got := SpanStatus(tc.err); got.Code != tc.status || got.Message != tc.err.Error() {
+				t.Errorf("expected status %d with message %q, got %d with message %q", tc.status, tc.err.Error(), got.Code, got.Message)
+			}
+		})
+	}
+}
+
+func TestRecordErrorTags(t *testing.T) {
+	v := &view.View{
+		Name:        "test/classified_errors",
+		Measure:     MErrors,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{KeyPart, KeyErrorCode, KeyErrorCategory},
+	}
+	if err := view.Register(v); err != nil {
+		t.Fatalf("unexpected error registering view: %s", err)
+	}
+	defer view.Unregister(v)
+
+	RecordError(context.Background(), "insert", serverError{code: 11000})
+
+	rows, err := view.RetrieveData(v.Name)
+	if err != nil {
+		t.Fatalf("unexpected error retrieving data: %s", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	want := map[tag.Key]string{KeyPart: "insert", KeyErrorCode: "11000", KeyErrorCategory: ErrorCategoryNonRetryable}
+	for _, tg := range rows[0].Tags {
+		if want[tg.Key] != tg.Value {
+			t.Errorf("expected tag %s to be %q, got %q", tg.Key.Name(), want[tg.Key], tg.Value)
+		}
+		delete(want, tg.Key)
+	}
+	if len(want) != 0 {
+		t.Errorf("missing tags: %v", want)
+	}
+}
@@ -0,0 +1,74 @@
+package observability
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opencensus.io/stats"
+)
+
+// Gauge tracks a value that goes up and down, such as the number of open connections, and
+// records its current value every time it changes.
+type Gauge struct {
+	value   int64
+	measure *stats.Int64Measure
+	// total, if set, is the gauge shared with the other gauges of the same key, whose value is
+	// recorded instead of this one.
+	total *Gauge
+}
+
+// NewGauge creates a gauge that records to the given measure.
+func NewGauge(measure *stats.Int64Measure) *Gauge {
+	return &Gauge{measure: measure}
+}
+
+type sharedGaugeKey struct {
+	measure *stats.Int64Measure
+	key     string
+}
+
+var sharedGauges = struct {
+	sync.Mutex
+	m map[sharedGaugeKey]*Gauge
+}{m: make(map[sharedGaugeKey]*Gauge)}
+
+// NewSharedGauge creates a gauge that records to the given measure the total of every gauge
+// created with the same measure and key. The value of the returned gauge is its own. The pools of
+// different clients connected to the same server share their gauges this way, so that their
+// values add up instead of overwriting each other.
+func NewSharedGauge(measure *stats.Int64Measure, key string) *Gauge {
+	sharedGauges.Lock()
+	defer sharedGauges.Unlock()
+
+	k := sharedGaugeKey{measure: measure, key: key}
+	total, ok := sharedGauges.m[k]
+	if !ok {
+		total = NewGauge(measure)
+		sharedGauges.m[k] = total
+	}
+	return &Gauge{measure: measure, total: total}
+}
+
+// Add adds delta to the gauge and records the new value with ctx. A shared gauge records the new
+// total instead.
+func (g *Gauge) Add(ctx context.Context, delta int64) {
+	value := atomic.AddInt64(&g.value, delta)
+	if g.total != nil {
+		g.total.Add(ctx, delta)
+		return
+	}
+	Record(ctx, g.measure.M(value))
+}
+
+// Value returns the current value of the gauge.
+func (g *Gauge) Value() int64 {
+	return atomic.LoadInt64(&g.value)
+}
+
+// CursorsOpen and SessionsActive track the open server cursors and active client sessions of
+// the process.
+var (
+	CursorsOpen    = NewGauge(MCursorsOpen)
+	SessionsActive = NewGauge(MSessionsActive)
+)
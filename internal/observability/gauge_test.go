@@ -0,0 +1,53 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+)
+
+func TestSharedGauge(t *testing.T) {
+	measure := stats.Int64("test/shared_gauge", "A gauge shared by key", stats.UnitDimensionless)
+	v := &view.View{Name: "test/shared_gauge", Measure: measure, Aggregation: view.LastValue()}
+	if err := view.Register(v); err != nil {
+		t.Fatalf("unexpected error registering view: %s", err)
+	}
+	defer view.Unregister(v)
+
+	last := func() float64 {
+		rows, err := view.RetrieveData(v.Name)
+		if err != nil {
+			t.Fatalf("unexpected error retrieving data: %s", err)
+		}
+		if len(rows) != 1 {
+			t.Fatalf("expected 1 row, got %d", len(rows))
+		}
+		return rows[0].Data.(*view.LastValueData).Value
+	}
+
+	ctx := context.Background()
+	first := NewSharedGauge(measure, "localhost:27017")
+	second := NewSharedGauge(measure, "localhost:27017")
+	other := NewSharedGauge(measure, "localhost:27018")
+
+	first.Add(ctx, 2)
+	second.Add(ctx, 3)
+	if got := last(); got != 5 {
+		t.Errorf("expected the total 5 to be recorded, got %v", got)
+	}
+	if first.Value() != 2 || second.Value() != 3 {
+		t.Errorf("expected the gauges to keep their own values, got %d and %d", first.Value(), second.Value())
+	}
+
+	first.Add(ctx, -2)
+	if got := last(); got != 3 {
+		t.Errorf("expected the total 3 to be recorded, got %v", got)
+	}
+
+	other.Add(ctx, 1)
+	if got := last(); got != 1 {
+		t.Errorf("expected a gauge of another key to record its own total 1, got %v", got)
+	}
+}
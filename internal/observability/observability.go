@@ -16,6 +16,29 @@ const dimensionless = "1"
 var KeyMethod, _ = tag.NewKey("method")
 var KeyPart, _ = tag.NewKey("part")
 
+// KeyDatabase, KeyCollection and KeyCommandName identify the namespace and server command of an
+// operation. They are set by the dispatch layer; see TagNamespace.
+var KeyDatabase, _ = tag.NewKey("database")
+var KeyCollection, _ = tag.NewKey("collection")
+var KeyCommandName, _ = tag.NewKey("command_name")
+
+// KeyErrorCode and KeyErrorCategory classify the errors counted by MErrors. KeyErrorCode is the
+// numeric server error code, or one of ErrorCodeNetwork, ErrorCodeTimeout and ErrorCodeClient for
+// errors that did not come from the server. KeyErrorCategory is ErrorCategoryRetryable or
+// ErrorCategoryNonRetryable.
+var KeyErrorCode, _ = tag.NewKey("error_code")
+var KeyErrorCategory, _ = tag.NewKey("error_category")
+
+// KeyServerAddress identifies the server a connection pool belongs to.
+var KeyServerAddress, _ = tag.NewKey("server_address")
+
+// KeyAuthMechanism identifies the mechanism a connection authenticates with, e.g. "SCRAM-SHA-256".
+var KeyAuthMechanism, _ = tag.NewKey("auth_mechanism")
+
+// KeyTopologyID identifies the topology a server is monitored by, which tells apart the servers of
+// the different clients of a process.
+var KeyTopologyID, _ = tag.NewKey("topology_id")
+
 var (
 	// MErrors is representative of all errors, differentiated by the tag of the command e.g:
 	//   "write", "read", "drop", "decode", "connection", "find", "distinction"
@@ -28,6 +51,14 @@ var (
 	MBytesWritten = stats.Int64("mongo/client/bytes_written", "The number of bytes written", by)
 	MBytesRead    = stats.Int64("mongo/client/bytes_read", "The number of bytes read", by)
 
+	// MBytesSent and MBytesReceived are the sizes of the wire messages of each command round trip,
+	// as sent over the network. The uncompressed measures are the sizes before compression, so
+	// they are equal to the others on connections without compression.
+	MBytesSent                 = stats.Int64("mongo/client/bytes_sent", "The size of command wire messages sent", by)
+	MBytesReceived             = stats.Int64("mongo/client/bytes_received", "The size of reply wire messages received", by)
+	MBytesSentUncompressed     = stats.Int64("mongo/client/bytes_sent_uncompressed", "The uncompressed size of command wire messages sent", by)
+	MBytesReceivedUncompressed = stats.Int64("mongo/client/bytes_received_uncompressed", "The uncompressed size of reply wire messages received", by)
+
 	MDeletions  = stats.Int64("mongo/client/deletions", "The number of deletions", dimensionless)
 	MInsertions = stats.Int64("mongo/client/insertions", "The number of insertions", dimensionless)
 	MReads      = stats.Int64("mongo/client/reads", "The number of reads", dimensionless)
@@ -39,17 +70,42 @@ var (
 	MConnectionsReused = stats.Int64("mongo/client/connections_reused", "The number of reused connections", dimensionless)
 	MConnectionsClosed = stats.Int64("mongo/client/connections_closed", "The number of closed connections", dimensionless)
 
+	// MConnectionCheckOutFailures counts the attempts to check out a connection of a pool that
+	// failed, tagged with KeyPart set to the reason of the CMAP ConnectionCheckOutFailed event.
+	MConnectionCheckOutFailures = stats.Int64("mongo/client/connection_checkout_failures", "The number of failed connection checkouts", dimensionless)
+
+	MConnectionsOpen      = stats.Int64("mongo/client/connections_open", "The number of open connections", dimensionless)
+	MConnectionsInUse     = stats.Int64("mongo/client/connections_in_use", "The number of connections checked out of a pool", dimensionless)
+	MOperationsInProgress = stats.Int64("mongo/client/operations_in_progress", "The number of operations holding a connection to a server", dimensionless)
+	MConnectionsWaitQueue = stats.Int64("mongo/client/connections_wait_queue", "The number of operations waiting to check out a connection", dimensionless)
+	MCursorsOpen          = stats.Int64("mongo/client/cursors_open", "The number of open server cursors", dimensionless)
+	MSessionsActive       = stats.Int64("mongo/client/sessions_active", "The number of active client sessions", dimensionless)
+
 	MConnectionLatencyMilliseconds = stats.Int64("mongo/client/connection_latency", "The latency to make a connection", ms)
 	MRoundTripLatencyMilliseconds  = stats.Float64("mongo/client/roundtrip_latency", "The roundtrip latency of commands in milliseconds", ms)
+	MHandshakeLatencyMilliseconds  = stats.Float64("mongo/client/handshake_latency", "The latency of connection handshakes in milliseconds", ms)
+
+	// MHeartbeatLatencyMilliseconds is the latency of the successful heartbeats of the server
+	// monitors, while MHeartbeatFailures counts the heartbeats that failed to reach the server.
+	MHeartbeatLatencyMilliseconds = stats.Float64("mongo/client/heartbeat_latency", "The latency of server heartbeats in milliseconds", ms)
+	MHeartbeatFailures            = stats.Int64("mongo/client/heartbeat_failures", "The number of failed server heartbeats", dimensionless)
+
+	// MAuthLatencyMilliseconds is the duration of the authentication conversation of a new
+	// connection and MAuthRoundTrips the number of commands it sent, while MAuthFailures counts the
+	// conversations that failed.
+	MAuthLatencyMilliseconds = stats.Float64("mongo/client/auth_latency", "The latency of connection authentication in milliseconds", ms)
+	MAuthRoundTrips          = stats.Int64("mongo/client/auth_round_trips", "The number of round trips of connection authentication", dimensionless)
+	MAuthFailures            = stats.Int64("mongo/client/auth_failures", "The number of failed connection authentications", dimensionless)
+
+	// MCursorsKilledCanceled counts the server cursors killed because the context of their
+	// iteration was canceled, which would otherwise have lingered until they timed out.
+	MCursorsKilledCanceled = stats.Int64("mongo/client/cursors_killed_canceled", "The number of server cursors killed after their context was canceled", dimensionless)
 )
 
 var (
 	defaultLatencyMillisecondsDistribution = view.Distribution(
-		0, 0.5, 1, 2, 3, 4, 5, 6, 8, 10, 13, 16, 20, 30, 40, 50, 60, 80, 100, 130, 160, 200, 230, 250,
-		300, 350, 400, 500, 550, 600, 700, 800, 900, 1000, 1050, 1200, 2000, 2500, 3000, 3500, 4000,
-		4500, 5000, 7000, 8000, 10000, 11000, 12000, 15000, 20000, 22000, 25000, 30000, 34000, 40000,
-		45000, 50000, 55000, 60000, 65000, 70000, 75000, 80000, 85000, 90000, 95000, 100000, 105000,
-		110000, 115000, 120000, 125000, 150000, 200000, 250000, 300000, 400000, 500000, 700000, 800000)
+		0, 0.1, 0.25, 0.5, 0.75, 1, 2, 3, 4, 5, 6, 8, 10, 13, 16, 20, 25, 30, 40, 50, 65, 80, 100,
+		130, 160, 200, 250, 300, 400, 500, 650, 800, 1000, 2000, 5000, 10000)
 
 	defaultByteSizesDistribution = view.Distribution(
 		0, 1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096, 8192, 16384, 32768,
@@ -82,6 +138,34 @@ var AllViews = []*view.View{
 		Measure:     MBytesWritten,
 		Aggregation: view.Count(),
 	},
+	{
+		Name:        "mongo/client/bytes_sent",
+		Description: "The distribution of command wire message sizes",
+		Measure:     MBytesSent,
+		Aggregation: defaultByteSizesDistribution,
+		TagKeys:     []tag.Key{KeyMethod, KeyCommandName},
+	},
+	{
+		Name:        "mongo/client/bytes_received",
+		Description: "The distribution of reply wire message sizes",
+		Measure:     MBytesReceived,
+		Aggregation: defaultByteSizesDistribution,
+		TagKeys:     []tag.Key{KeyMethod, KeyCommandName},
+	},
+	{
+		Name:        "mongo/client/bytes_sent_uncompressed",
+		Description: "The distribution of uncompressed command wire message sizes",
+		Measure:     MBytesSentUncompressed,
+		Aggregation: defaultByteSizesDistribution,
+		TagKeys:     []tag.Key{KeyMethod, KeyCommandName},
+	},
+	{
+		Name:        "mongo/client/bytes_received_uncompressed",
+		Description: "The distribution of uncompressed reply wire message sizes",
+		Measure:     MBytesReceivedUncompressed,
+		Aggregation: defaultByteSizesDistribution,
+		TagKeys:     []tag.Key{KeyMethod, KeyCommandName},
+	},
 	{
 		Name:        "mongo/client/reads",
 		Description: "The number of reads",
@@ -99,7 +183,7 @@ var AllViews = []*view.View{
 		Description: "The distribution of roundtrip latencies",
 		Measure:     MRoundTripLatencyMilliseconds,
 		Aggregation: defaultLatencyMillisecondsDistribution,
-		TagKeys:     []tag.Key{KeyMethod},
+		TagKeys:     []tag.Key{KeyMethod, KeyDatabase, KeyCollection, KeyCommandName},
 	},
 	{
 		Name:        "mongo/client/connection_latency",
@@ -107,6 +191,48 @@ var AllViews = []*view.View{
 		Measure:     MConnectionLatencyMilliseconds,
 		Aggregation: defaultLatencyMillisecondsDistribution,
 	},
+	{
+		Name:        "mongo/client/handshake_latency",
+		Description: "The distribution of connection handshake latencies per server",
+		Measure:     MHandshakeLatencyMilliseconds,
+		Aggregation: defaultLatencyMillisecondsDistribution,
+		TagKeys:     []tag.Key{KeyServerAddress},
+	},
+	{
+		Name:        "mongo/client/heartbeat_latency",
+		Description: "The distribution of server heartbeat latencies per server",
+		Measure:     MHeartbeatLatencyMilliseconds,
+		Aggregation: defaultLatencyMillisecondsDistribution,
+		TagKeys:     []tag.Key{KeyServerAddress, KeyTopologyID},
+	},
+	{
+		Name:        "mongo/client/heartbeat_failures",
+		Description: "The number of failed server heartbeats per server",
+		Measure:     MHeartbeatFailures,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{KeyServerAddress, KeyTopologyID},
+	},
+	{
+		Name:        "mongo/client/auth_latency",
+		Description: "The distribution of connection authentication latencies per mechanism and server",
+		Measure:     MAuthLatencyMilliseconds,
+		Aggregation: defaultLatencyMillisecondsDistribution,
+		TagKeys:     []tag.Key{KeyAuthMechanism, KeyServerAddress},
+	},
+	{
+		Name:        "mongo/client/auth_round_trips",
+		Description: "The distribution of the number of round trips of connection authentication per mechanism",
+		Measure:     MAuthRoundTrips,
+		Aggregation: view.Distribution(0, 1, 2, 3, 4, 5, 6, 8, 10),
+		TagKeys:     []tag.Key{KeyAuthMechanism},
+	},
+	{
+		Name:        "mongo/client/auth_failures",
+		Description: "The number of failed connection authentications per mechanism, server and error",
+		Measure:     MAuthFailures,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{KeyAuthMechanism, KeyServerAddress, KeyErrorCode, KeyErrorCategory},
+	},
 
 	{
 		Name:        "mongo/client/connections_new",
@@ -127,22 +253,88 @@ var AllViews = []*view.View{
 		Aggregation: view.Count(),
 	},
 
+	{
+		Name:        "mongo/client/connection_checkout_failures",
+		Description: "The number of failed connection checkouts per server and reason",
+		Measure:     MConnectionCheckOutFailures,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{KeyServerAddress, KeyPart},
+	},
+
+	{
+		Name:        "mongo/client/connections_open",
+		Description: "The number of open connections per server",
+		Measure:     MConnectionsOpen,
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{KeyServerAddress},
+	},
+	{
+		Name:        "mongo/client/connections_in_use",
+		Description: "The number of connections checked out of the pool per server",
+		Measure:     MConnectionsInUse,
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{KeyServerAddress},
+	},
+	{
+		Name:        "mongo/client/operations_in_progress",
+		Description: "The number of operations in progress per server, which server selection balances",
+		Measure:     MOperationsInProgress,
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{KeyServerAddress},
+	},
+	{
+		Name:        "mongo/client/connections_wait_queue",
+		Description: "The number of operations waiting to check out a connection per server",
+		Measure:     MConnectionsWaitQueue,
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{KeyServerAddress},
+	},
+	{
+		Name:        "mongo/client/cursors_open",
+		Description: "The number of open server cursors",
+		Measure:     MCursorsOpen,
+		Aggregation: view.LastValue(),
+	},
+	{
+		Name:        "mongo/client/cursors_killed_canceled",
+		Description: "The number of server cursors killed after their context was canceled",
+		Measure:     MCursorsKilledCanceled,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{KeyServerAddress},
+	},
+	{
+		Name:        "mongo/client/sessions_active",
+		Description: "The number of active client sessions",
+		Measure:     MSessionsActive,
+		Aggregation: view.LastValue(),
+	},
+
 	{
 		Name:        "mongo/client/errors",
 		Description: "The number of errors during different operations",
 		Measure:     MErrors,
 		Aggregation: view.Count(),
-		TagKeys:     []tag.Key{KeyMethod, KeyPart},
+		TagKeys:     []tag.Key{KeyMethod, KeyPart, KeyDatabase, KeyCollection, KeyCommandName, KeyErrorCode, KeyErrorCategory},
 	},
 	{
 		Name:        "mongo/client/calls",
 		Description: "The number of calls differentiated by their command names",
 		Measure:     MCalls,
 		Aggregation: view.Count(),
-		TagKeys:     []tag.Key{KeyMethod},
+		TagKeys:     []tag.Key{KeyMethod, KeyDatabase, KeyCollection, KeyCommandName},
 	},
 }
 
+// RegisterAllViews registers every view in AllViews.
+func RegisterAllViews() error {
+	return view.Register(AllViews...)
+}
+
+// UnregisterAllViews unregisters every view in AllViews.
+func UnregisterAllViews() {
+	view.Unregister(AllViews...)
+}
+
 // Helper functions
 func SinceInMilliseconds(startTime time.Time) float64 {
 	return time.Since(startTime).Seconds() * 1000
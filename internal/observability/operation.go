@@ -0,0 +1,100 @@
+package observability
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+)
+
+// Operation instruments a single driver operation. It owns the operation's span and records the
+// MCalls, MErrors and latency measurements for it.
+type Operation struct {
+	ctx    context.Context
+	span   Span
+	start  time.Time
+	failed bool
+}
+
+// StartOperation tags the context with the given method, unless the caller has already tagged
+// one, and starts a span with the given name. The returned context must be used for the rest of
+// the operation, and End must be called once it completes. If instrumentation is disabled, the
+// context is returned unchanged along with a nil *Operation, which is safe to use.
+func StartOperation(ctx context.Context, method, spanName string) (context.Context, *Operation) {
+	if current().Disabled {
+		return ctx, nil
+	}
+
+	ctx = Tag(ctx, tag.Insert(KeyMethod, method))
+	ctx, span := StartSpan(ctx, spanName)
+
+	op := &Operation{
+		ctx:   ctx,
+		span:  span,
+		start: time.Now(),
+	}
+	return context.WithValue(ctx, operationKey{}, op), op
+}
+
+type operationKey struct{}
+
+// AnnotateOperation adds an annotation to the span of the operation started with ctx, if any.
+// It lets lower layers, such as connections, annotate the operation they are serving.
+func AnnotateOperation(ctx context.Context, attributes []trace.Attribute, str string) {
+	if op, ok := ctx.Value(operationKey{}).(*Operation); ok {
+		op.span.Annotate(attributes, str)
+	}
+}
+
+// AddOperationAttributes sets attributes on the span of the operation started with ctx, if any.
+// Like AnnotateOperation, it lets lower layers describe the operation they are serving.
+func AddOperationAttributes(ctx context.Context, attributes ...trace.Attribute) {
+	if op, ok := ctx.Value(operationKey{}).(*Operation); ok {
+		op.span.AddAttributes(attributes...)
+	}
+}
+
+// Span returns the span of the operation.
+func (op *Operation) Span() Span {
+	if op == nil {
+		return noSpan
+	}
+
+	return op.span
+}
+
+// Fail records an error that occurred in the given part of the operation and sets the status of
+// the span. The error is not recorded again by End.
+func (op *Operation) Fail(part string, err error) {
+	if op == nil || err == nil {
+		return
+	}
+
+	op.failed = true
+	RecordError(op.ctx, part, err)
+	op.span.SetStatus(SpanStatus(err))
+}
+
+// End records the call and its latency, records err if it has not already been recorded by Fail,
+// and ends the span. If err is nil, the span is marked successful even if an earlier attempt, such
+// as a retried write, failed.
+func (op *Operation) End(err error) {
+	if op == nil {
+		return
+	}
+
+	switch {
+	case err == nil && op.failed:
+		op.span.SetStatus(trace.Status{Code: int32(trace.StatusCodeOK)})
+	case err != nil && !op.failed:
+		Record(Tag(op.ctx, errorTags(err)...), MErrors.M(1))
+		op.span.SetStatus(SpanStatus(err))
+	}
+
+	Record(op.ctx,
+		MCalls.M(1),
+		MRoundTripLatencyMilliseconds.M(SinceInMilliseconds(op.start)),
+	)
+	op.span.End()
+}
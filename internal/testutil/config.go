@@ -110,7 +110,7 @@ func MonitoredTopology(t *testing.T, dbName string, monitor *event.CommandMonito
 		_, err = (&command.Write{
 			DB:      dbName,
 			Command: bson.NewDocument(bson.EC.Int32("dropDatabase", 1)),
-		}).RoundTrip(context.Background(), s.SelectedDescription(), c)
+		}).RoundTrip(context.Background(), s.Description(), c)
 
 		require.NoError(t, err)
 	}
@@ -154,7 +154,7 @@ func GlobalMonitoredTopology(t *testing.T, monitor *event.CommandMonitor) *topol
 			_, err = (&command.Write{
 				DB:      DBName(t),
 				Command: bson.NewDocument(bson.EC.Int32("dropDatabase", 1)),
-			}).RoundTrip(context.Background(), s.SelectedDescription(), c)
+			}).RoundTrip(context.Background(), s.Description(), c)
 
 			require.NoError(t, err)
 		}
@@ -186,7 +186,7 @@ func Topology(t *testing.T) *topology.Topology {
 			_, err = (&command.Write{
 				DB:      DBName(t),
 				Command: bson.NewDocument(bson.EC.Int32("dropDatabase", 1)),
-			}).RoundTrip(context.Background(), s.SelectedDescription(), c)
+			}).RoundTrip(context.Background(), s.Description(), c)
 			require.NoError(t, err)
 		}
 	})
@@ -0,0 +1,164 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package fakeclock contains a clock whose time only passes when tests advance it.
+package fakeclock
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/internal/clock"
+)
+
+// Clock is a clock.Clock standing still until it is advanced. Its timers and tickers fire while it
+// is advanced past their deadlines. Like those of the time package, their channels hold a single
+// event, and ticks are dropped while the previous one has not been received.
+type Clock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*waiter
+	changed chan struct{} // closed and replaced when a timer or a ticker is created or stopped
+}
+
+var _ clock.Clock = (*Clock)(nil)
+
+// waiter is a timer, or a ticker if its period is set.
+type waiter struct {
+	clock    *Clock
+	deadline time.Time
+	period   time.Duration
+	c        chan time.Time
+}
+
+// New returns a clock whose time is start.
+func New(start time.Time) *Clock {
+	return &Clock{now: start, changed: make(chan struct{})}
+}
+
+// Now implements the clock.Clock interface.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After implements the clock.Clock interface.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer implements the clock.Clock interface.
+func (c *Clock) NewTimer(d time.Duration) clock.Timer {
+	return c.add(d, 0)
+}
+
+// NewTicker implements the clock.Clock interface. It panics if d is not positive.
+func (c *Clock) NewTicker(d time.Duration) clock.Ticker {
+	if d <= 0 {
+		panic("fakeclock: non-positive interval for NewTicker")
+	}
+	return ticker{c.add(d, d)}
+}
+
+// Advance moves the time of the clock forward by d, firing the timers and tickers whose deadlines
+// are reached in the order of their deadlines.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	end := c.now.Add(d)
+	for {
+		sort.SliceStable(c.waiters, func(i, j int) bool {
+			return c.waiters[i].deadline.Before(c.waiters[j].deadline)
+		})
+		if len(c.waiters) == 0 || c.waiters[0].deadline.After(end) {
+			break
+		}
+
+		w := c.waiters[0]
+		c.now = w.deadline
+		select {
+		case w.c <- c.now:
+		default:
+		}
+		if w.period > 0 {
+			w.deadline = w.deadline.Add(w.period)
+		} else {
+			c.removeLocked(w)
+		}
+	}
+	c.now = end
+}
+
+// Waiters returns the number of timers and tickers that have neither fired nor been stopped.
+func (c *Clock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil blocks until at least n timers and tickers are waiting for the clock to be advanced,
+// so that a test advances it once the code under test is waiting.
+func (c *Clock) BlockUntil(n int) {
+	for {
+		c.mu.Lock()
+		waiting, changed := len(c.waiters), c.changed
+		c.mu.Unlock()
+		if waiting >= n {
+			return
+		}
+		<-changed
+	}
+}
+
+func (c *Clock) add(d, period time.Duration) *waiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &waiter{clock: c, deadline: c.now.Add(d), period: period, c: make(chan time.Time, 1)}
+	if d <= 0 {
+		w.c <- c.now
+		return w
+	}
+	c.waiters = append(c.waiters, w)
+	c.notifyLocked()
+	return w
+}
+
+// removeLocked removes w from the waiters, returning whether it was waiting. Assumes the caller
+// holds the mutex.
+func (c *Clock) removeLocked(w *waiter) bool {
+	for i, other := range c.waiters {
+		if other == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.notifyLocked()
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Clock) notifyLocked() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+func (w *waiter) C() <-chan time.Time { return w.c }
+
+func (w *waiter) Stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	return w.clock.removeLocked(w)
+}
+
+// ticker hides the result of Stop, which time.Ticker does not return.
+type ticker struct{ w *waiter }
+
+func (t ticker) C() <-chan time.Time { return t.w.c }
+
+func (t ticker) Stop() { t.w.Stop() }
@@ -0,0 +1,79 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package fakeclock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("timer", func(t *testing.T) {
+		c := New(start)
+		timer := c.NewTimer(time.Second)
+
+		c.Advance(999 * time.Millisecond)
+		select {
+		case <-timer.C():
+			t.Fatal("timer fired early")
+		default:
+		}
+
+		c.Advance(time.Millisecond)
+		require.Equal(t, start.Add(time.Second), <-timer.C())
+		require.False(t, timer.Stop())
+		require.Equal(t, 0, c.Waiters())
+	})
+	t.Run("stopped timer", func(t *testing.T) {
+		c := New(start)
+		timer := c.NewTimer(time.Second)
+		require.True(t, timer.Stop())
+
+		c.Advance(time.Minute)
+		select {
+		case <-timer.C():
+			t.Fatal("stopped timer fired")
+		default:
+		}
+	})
+	t.Run("ticker", func(t *testing.T) {
+		c := New(start)
+		ticker := c.NewTicker(time.Second)
+
+		// ticks are dropped while the previous one has not been received
+		c.Advance(3 * time.Second)
+		require.Equal(t, start.Add(time.Second), <-ticker.C())
+		c.Advance(time.Second)
+		require.Equal(t, start.Add(4*time.Second), <-ticker.C())
+		require.Equal(t, start.Add(4*time.Second), c.Now())
+
+		ticker.Stop()
+		require.Equal(t, 0, c.Waiters())
+	})
+	t.Run("deadline order", func(t *testing.T) {
+		c := New(start)
+		var fired []time.Duration
+		late, early := c.After(2*time.Second), c.After(time.Second)
+
+		c.Advance(5 * time.Second)
+		for _, ch := range []<-chan time.Time{early, late} {
+			fired = append(fired, (<-ch).Sub(start))
+		}
+		require.Equal(t, []time.Duration{time.Second, 2 * time.Second}, fired)
+	})
+	t.Run("block until", func(t *testing.T) {
+		c := New(start)
+		go func() { <-c.After(time.Second) }()
+
+		c.BlockUntil(1)
+		require.Equal(t, 1, c.Waiters())
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/mongo/aggregateopt"
+	"github.com/mongodb/mongo-go-driver/mongo/changestreamopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+// openCursor returns a handler that replies a batch of one document of an open cursor, as the first
+// batch of aggregate commands and the next batch of getMores.
+func openCursor(batch string) mongotest.Handler {
+	return mongotest.OK(bson.EC.SubDocumentFromElements("cursor",
+		bson.EC.Int64("id", 42),
+		bson.EC.String("ns", "db.coll"),
+		bson.EC.ArrayFromElements(batch, bson.VC.DocumentFromElements(
+			bson.EC.SubDocumentFromElements("_id", bson.EC.Int32("token", 1)),
+		)),
+	))
+}
+
+func TestAggregateBatchSize(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("aggregate", openCursor("firstBatch"))
+	d.Handle("getMore", openCursor("nextBatch"))
+	d.Handle("killCursors", mongotest.OK())
+
+	client := newMockClient(t, d)
+	coll := client.Database("db").Collection("coll")
+
+	// lastBatchSize returns the batch size of the last command named name, or -1 if it has none.
+	lastBatchSize := func(name string, key ...string) int32 {
+		cmd := d.LastCommand(name)
+		require.NotNil(t, cmd, "no %s command was sent", name)
+		val, err := cmd.Document.LookupErr(key...)
+		if err != nil {
+			return -1
+		}
+		return val.Int32()
+	}
+
+	t.Run("aggregate", func(t *testing.T) {
+		cur, err := coll.Aggregate(context.Background(), bson.NewArray(), aggregateopt.BatchSize(2))
+		require.NoError(t, err)
+		defer func() { _ = cur.Close(context.Background()) }()
+		require.Equal(t, int32(2), lastBatchSize("aggregate", "cursor", "batchSize"))
+
+		require.True(t, cur.Next(context.Background()))
+		require.True(t, cur.Next(context.Background()))
+		require.Equal(t, int32(2), lastBatchSize("getMore", "batchSize"))
+
+		cur.SetBatchSize(5)
+		require.True(t, cur.Next(context.Background()))
+		require.Equal(t, int32(5), lastBatchSize("getMore", "batchSize"))
+
+		cur.SetBatchSize(0)
+		require.True(t, cur.Next(context.Background()))
+		require.Equal(t, int32(-1), lastBatchSize("getMore", "batchSize"))
+	})
+	t.Run("change stream", func(t *testing.T) {
+		cs, err := coll.Watch(context.Background(), nil,
+			changestreamopt.BatchSize(3), changestreamopt.MaxAwaitTime(time.Second))
+		require.NoError(t, err)
+		defer func() { _ = cs.Close(context.Background()) }()
+		require.Equal(t, int32(3), lastBatchSize("aggregate", "cursor", "batchSize"))
+		require.Equal(t, int32(-1), lastBatchSize("aggregate", "pipeline", "0", "$changeStream", "batchSize"))
+
+		require.True(t, cs.Next(context.Background()))
+		require.True(t, cs.Next(context.Background()))
+		require.Equal(t, int32(3), lastBatchSize("getMore", "batchSize"))
+
+		cs.SetBatchSize(7)
+		require.True(t, cs.Next(context.Background()))
+		require.Equal(t, int32(7), lastBatchSize("getMore", "batchSize"))
+	})
+	t.Run("max await time", func(t *testing.T) {
+		_, err := coll.Aggregate(context.Background(), bson.NewArray(), aggregateopt.MaxAwaitTime(time.Second))
+		require.Equal(t, command.ErrMaxAwaitTimeNotTailable, err)
+	})
+}
+
+func TestDatabaseAggregate(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("aggregate", mongotest.Cursor("admin.$cmd.aggregate",
+		bson.NewDocument(bson.EC.String("op", "query"), bson.EC.Int64("opid", 1)),
+	))
+
+	client := newMockClient(t, d)
+
+	lastAggregate := func() *bson.Document {
+		cmd := d.LastCommand("aggregate")
+		require.NotNil(t, cmd)
+		return cmd.Document
+	}
+
+	t.Run("aggregate", func(t *testing.T) {
+		pipeline := bson.NewArray(bson.VC.DocumentFromElements(bson.EC.SubDocument("$listLocalSessions", bson.NewDocument())))
+		cur, err := client.Database("db").Aggregate(context.Background(), pipeline)
+		require.NoError(t, err)
+		require.NoError(t, cur.Close(context.Background()))
+
+		cmd := lastAggregate()
+		require.Equal(t, int32(1), cmd.Lookup("aggregate").Int32())
+		require.Equal(t, "db", cmd.Lookup("$db").StringValue())
+
+		_, err = client.Database("").Aggregate(context.Background(), pipeline)
+		require.Error(t, err)
+	})
+	t.Run("current op", func(t *testing.T) {
+		cur, err := client.CurrentOp(context.Background(), bson.NewDocument(bson.EC.String("op", "query")))
+		require.NoError(t, err)
+		defer func() { _ = cur.Close(context.Background()) }()
+		require.True(t, cur.Next(context.Background()))
+		rdr, err := cur.DecodeBytes()
+		require.NoError(t, err)
+		elem, err := rdr.Lookup("op")
+		require.NoError(t, err)
+		require.Equal(t, "query", elem.Value().StringValue())
+
+		cmd := lastAggregate()
+		require.Equal(t, int32(1), cmd.Lookup("aggregate").Int32())
+		require.Equal(t, "admin", cmd.Lookup("$db").StringValue())
+		require.NotNil(t, cmd.Lookup("pipeline", "0", "$currentOp"))
+		require.Equal(t, "query", cmd.Lookup("pipeline", "1", "$match", "op").StringValue())
+	})
+}
+
+func TestAggregatePipelineForms(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("aggregate", openCursor("firstBatch"))
+	d.Handle("killCursors", mongotest.OK())
+
+	client := newMockClient(t, d)
+	coll := client.Database("db").Collection("coll")
+
+	// lastStages returns the first key of each stage of the pipeline of the last aggregate.
+	lastStages := func() []string {
+		cmd := d.LastCommand("aggregate")
+		require.NotNil(t, cmd)
+		var stages []string
+		itr, err := cmd.Document.Lookup("pipeline").MutableArray().Iterator()
+		require.NoError(t, err)
+		for itr.Next() {
+			stages = append(stages, itr.Value().MutableDocument().ElementAt(0).Key())
+		}
+		return stages
+	}
+
+	pipeline := []map[string]interface{}{
+		{"$match": map[string]interface{}{"x": 1}},
+		{"$project": map[string]interface{}{"x": 1}},
+	}
+	cur, err := coll.Aggregate(context.Background(), pipeline)
+	require.NoError(t, err)
+	require.NoError(t, cur.Close(context.Background()))
+	require.Equal(t, []string{"$match", "$project"}, lastStages())
+
+	cs, err := coll.Watch(context.Background(), pipeline)
+	require.NoError(t, err)
+	require.NoError(t, cs.Close(context.Background()))
+	require.Equal(t, []string{"$changeStream", "$match", "$project"}, lastStages())
+
+	_, err = coll.Watch(context.Background(), []interface{}{pipeline[0], "$project"})
+	require.EqualError(t, err, "cannot convert pipeline stage 1: cannot transform type string to a *bson.Document")
+}
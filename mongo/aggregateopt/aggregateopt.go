@@ -12,6 +12,8 @@ import (
 	"reflect"
 
 	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
 )
@@ -123,10 +125,10 @@ func (ab *AggregateBundle) MaxAwaitTime(d time.Duration) *AggregateBundle {
 	return bundle
 }
 
-// Comment adds an option to specify a string to help trace the operation through the database profiler, currentOp, and logs.
-func (ab *AggregateBundle) Comment(s string) *AggregateBundle {
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (ab *AggregateBundle) Comment(comment interface{}) *AggregateBundle {
 	bundle := &AggregateBundle{
-		option: Comment(s),
+		option: Comment(comment),
 		next:   ab,
 	}
 
@@ -143,6 +145,38 @@ func (ab *AggregateBundle) Hint(hint interface{}) *AggregateBundle {
 	return bundle
 }
 
+// Let adds an option to specify a document of variables that can be accessed in the pipeline.
+func (ab *AggregateBundle) Let(let interface{}) *AggregateBundle {
+	bundle := &AggregateBundle{
+		option: Let(let),
+		next:   ab,
+	}
+
+	return bundle
+}
+
+// ReadConcern adds an option to specify the read concern of the aggregation, overriding the one of
+// the collection.
+func (ab *AggregateBundle) ReadConcern(rc *readconcern.ReadConcern) *AggregateBundle {
+	bundle := &AggregateBundle{
+		option: ReadConcern(rc),
+		next:   ab,
+	}
+
+	return bundle
+}
+
+// ReadPreference adds an option to specify the read preference of the aggregation, overriding
+// the one of the collection.
+func (ab *AggregateBundle) ReadPreference(rp *readpref.ReadPref) *AggregateBundle {
+	bundle := &AggregateBundle{
+		option: ReadPreference(rp),
+		next:   ab,
+	}
+
+	return bundle
+}
+
 // Calculates the total length of a bundle, accounting for nested bundles.
 func (ab *AggregateBundle) bundleLength() int {
 	if ab == nil {
@@ -277,7 +311,8 @@ func AllowDiskUse(b bool) OptAllowDiskUse {
 	return OptAllowDiskUse(b)
 }
 
-// BatchSize specifies the number of documents to return in every batch.
+// BatchSize specifies the number of documents to return in every batch, for the initial aggregate command and every
+// getMore. Cursor.SetBatchSize overrides it for the getMores that follow.
 func BatchSize(i int32) OptBatchSize {
 	return OptBatchSize(i)
 }
@@ -299,14 +334,16 @@ func MaxTime(d time.Duration) OptMaxTime {
 	return OptMaxTime(d)
 }
 
-// MaxAwaitTime specifies the maximum amount of time for the server to wait on new documents to satisfy a tailable cursor query
+// MaxAwaitTime specifies the maximum amount of time for the server to wait on new documents to satisfy a tailable cursor query.
+// It can only be set for change streams: other aggregations fail with command.ErrMaxAwaitTimeNotTailable.
 func MaxAwaitTime(d time.Duration) OptMaxAwaitTime {
 	return OptMaxAwaitTime(d)
 }
 
-// Comment allows users to specify a string to help trace the operation through the database profiler, currentOp, and logs.
-func Comment(s string) OptComment {
-	return OptComment(s)
+// Comment allows users to specify a value of any BSON type to help trace the operation through the
+// database profiler, currentOp, and logs. Servers older than 4.4 only accept string comments.
+func Comment(comment interface{}) OptComment {
+	return OptComment{Comment: comment}
 }
 
 // Hint specifies the index to use for the aggregation.
@@ -314,6 +351,23 @@ func Hint(hint interface{}) OptHint {
 	return OptHint{hint}
 }
 
+// Let specifies a document of variables that can be accessed in the pipeline using $$var. It
+// requires server version 5.0 or newer.
+func Let(let interface{}) OptLet {
+	return OptLet{let}
+}
+
+// ReadConcern specifies the read concern of the aggregation, overriding the one of the collection.
+func ReadConcern(rc *readconcern.ReadConcern) OptReadConcern {
+	return OptReadConcern{rc}
+}
+
+// ReadPreference specifies the read preference of the aggregation, overriding the one of the
+// collection.
+func ReadPreference(rp *readpref.ReadPref) OptReadPreference {
+	return OptReadPreference{rp}
+}
+
 // OptAllowDiskUse allows aggregation stages to write to temporary files.
 type OptAllowDiskUse option.OptAllowDiskUse
 
@@ -374,7 +428,7 @@ func (opt OptMaxAwaitTime) ConvertAggregateOption() option.AggregateOptioner {
 	return option.OptMaxAwaitTime(opt)
 }
 
-// OptComment allows users to specify a string to help trace the operation through the database profiler, currentOp, and logs.
+// OptComment allows users to specify a value to help trace the operation through the database profiler, currentOp, and logs.
 type OptComment option.OptComment
 
 func (OptComment) aggregate() {}
@@ -394,6 +448,36 @@ func (opt OptHint) ConvertAggregateOption() option.AggregateOptioner {
 	return option.OptHint(opt)
 }
 
+// OptLet specifies a document of variables that can be accessed in the pipeline.
+type OptLet option.OptLet
+
+func (OptLet) aggregate() {}
+
+// ConvertAggregateOption implements the Aggregate interface
+func (opt OptLet) ConvertAggregateOption() option.AggregateOptioner {
+	return option.OptLet(opt)
+}
+
+// OptReadConcern specifies the read concern of the aggregation.
+type OptReadConcern option.OptReadConcern
+
+// OptReadPreference specifies the read preference of the aggregation.
+type OptReadPreference option.OptReadPreference
+
+func (OptReadConcern) aggregate() {}
+
+func (OptReadPreference) aggregate() {}
+
+// ConvertAggregateOption implements the Aggregate interface
+func (opt OptReadConcern) ConvertAggregateOption() option.AggregateOptioner {
+	return option.OptReadConcern(opt)
+}
+
+// ConvertAggregateOption implements the Aggregate interface
+func (opt OptReadPreference) ConvertAggregateOption() option.AggregateOptioner {
+	return option.OptReadPreference(opt)
+}
+
 // AggregateSessionOpt is an aggregate session option.
 type AggregateSessionOpt struct{}
 
@@ -89,17 +89,17 @@ func TestAggregateOpt(t *testing.T) {
 
 	bundle3Opts := []option.Optioner{
 		OptBatchSize(1).ConvertAggregateOption(),
-		OptComment("Hello").ConvertAggregateOption(),
+		Comment("Hello").ConvertAggregateOption(),
 		OptBatchSize(2).ConvertAggregateOption(),
 		OptBypassDocumentValidation(false).ConvertAggregateOption(),
 		OptBypassDocumentValidation(true).ConvertAggregateOption(),
-		OptComment("World").ConvertAggregateOption(),
+		Comment("World").ConvertAggregateOption(),
 	}
 
 	bundle3DedupOpts := []option.Optioner{
 		OptBatchSize(2).ConvertAggregateOption(),
 		OptBypassDocumentValidation(true).ConvertAggregateOption(),
-		OptComment("World").ConvertAggregateOption(),
+		Comment("World").ConvertAggregateOption(),
 	}
 
 	nilBundle := BundleAggregate()
@@ -110,13 +110,13 @@ func TestAggregateOpt(t *testing.T) {
 		OptAllowDiskUse(true).ConvertAggregateOption(),
 		OptMaxTime(500).ConvertAggregateOption(),
 		OptAllowDiskUse(false).ConvertAggregateOption(),
-		OptComment("hello world nested").ConvertAggregateOption(),
+		Comment("hello world nested").ConvertAggregateOption(),
 		OptBatchSize(1000).ConvertAggregateOption(),
 	}
 	nestedBundleDedupOpts1 := []option.Optioner{
 		OptMaxTime(500).ConvertAggregateOption(),
 		OptAllowDiskUse(false).ConvertAggregateOption(),
-		OptComment("hello world nested").ConvertAggregateOption(),
+		Comment("hello world nested").ConvertAggregateOption(),
 		OptBatchSize(1000).ConvertAggregateOption(),
 	}
 
@@ -126,14 +126,14 @@ func TestAggregateOpt(t *testing.T) {
 		OptMaxTime(500).ConvertAggregateOption(),
 		OptMaxTime(100).ConvertAggregateOption(),
 		OptAllowDiskUse(false).ConvertAggregateOption(),
-		OptComment("nest1").ConvertAggregateOption(),
-		OptComment("nest2").ConvertAggregateOption(),
+		Comment("nest1").ConvertAggregateOption(),
+		Comment("nest2").ConvertAggregateOption(),
 		OptBatchSize(1000).ConvertAggregateOption(),
 	}
 	nestedBundleDedupOpts2 := []option.Optioner{
 		OptMaxTime(100).ConvertAggregateOption(),
 		OptAllowDiskUse(false).ConvertAggregateOption(),
-		OptComment("nest2").ConvertAggregateOption(),
+		Comment("nest2").ConvertAggregateOption(),
 		OptBatchSize(1000).ConvertAggregateOption(),
 	}
 
@@ -141,19 +141,19 @@ func TestAggregateOpt(t *testing.T) {
 	nestedBundleOpts3 := []option.Optioner{
 		OptMaxTime(100).ConvertAggregateOption(),
 		OptAllowDiskUse(true).ConvertAggregateOption(),
-		OptComment("nest3").ConvertAggregateOption(),
-		OptComment("nest4").ConvertAggregateOption(),
+		Comment("nest3").ConvertAggregateOption(),
+		Comment("nest4").ConvertAggregateOption(),
 		OptMaxTime(500).ConvertAggregateOption(),
 		OptMaxTime(100).ConvertAggregateOption(),
 		OptAllowDiskUse(false).ConvertAggregateOption(),
-		OptComment("nest1").ConvertAggregateOption(),
-		OptComment("nest2").ConvertAggregateOption(),
+		Comment("nest1").ConvertAggregateOption(),
+		Comment("nest2").ConvertAggregateOption(),
 		OptBatchSize(1000).ConvertAggregateOption(),
 	}
 	nestedBundleDedupOpts3 := []option.Optioner{
 		OptMaxTime(100).ConvertAggregateOption(),
 		OptAllowDiskUse(false).ConvertAggregateOption(),
-		OptComment("nest2").ConvertAggregateOption(),
+		Comment("nest2").ConvertAggregateOption(),
 		OptBatchSize(1000).ConvertAggregateOption(),
 	}
 
@@ -0,0 +1,282 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/writeconcern"
+	"github.com/mongodb/mongo-go-driver/mongo/aggregateopt"
+	"github.com/mongodb/mongo-go-driver/mongo/collectionopt"
+	"github.com/mongodb/mongo-go-driver/mongo/countopt"
+	"github.com/mongodb/mongo-go-driver/mongo/deleteopt"
+	"github.com/mongodb/mongo-go-driver/mongo/distinctopt"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+	"github.com/mongodb/mongo-go-driver/mongo/insertopt"
+	"github.com/mongodb/mongo-go-driver/mongo/replaceopt"
+	"github.com/mongodb/mongo-go-driver/mongo/sessionopt"
+	"github.com/mongodb/mongo-go-driver/mongo/updateopt"
+)
+
+// ErrCausalHandleClosed is returned by the methods of a CausalHandle after it has been closed.
+var ErrCausalHandleClosed = errors.New("mongo: the causal handle has been closed")
+
+// CausalHandle runs operations against a collection so that every read observes the writes made
+// through the handle before it. Writes use a majority write concern and reads a majority read
+// concern, and all of them run in a causally consistent session owned by the handle, so each read
+// carries the operation time of the latest write, or read, as its afterClusterTime.
+//
+// Operations run through a CausalHandle one at a time. Cursors returned by its reads belong to its
+// session, so they should be closed before the handle is.
+type CausalHandle struct {
+	coll *Collection
+
+	mu   sync.Mutex
+	sess *Session
+}
+
+// CausalPair returns a CausalHandle for coll whose reads observe its writes. The handle keeps the
+// read preference of coll and the session options given in opts, but always enables causal
+// consistency. Close must be called when the handle is no longer needed to release its session.
+func CausalPair(coll *Collection, opts ...sessionopt.Session) (*CausalHandle, error) {
+	majority, err := coll.Clone(
+		collectionopt.ReadConcern(readconcern.Majority()),
+		collectionopt.WriteConcern(writeconcern.New(writeconcern.WMajority())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := coll.client.StartSession(append(opts, sessionopt.CausalConsistency(true))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CausalHandle{coll: majority, sess: sess}, nil
+}
+
+// Collection returns the collection the handle runs operations against.
+func (ch *CausalHandle) Collection() *Collection {
+	return ch.coll
+}
+
+// OperationTime returns the operation time of the latest operation run through the handle, which
+// the next read waits for. It is nil if no operation has returned one.
+func (ch *CausalHandle) OperationTime() *bson.Timestamp {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	if ch.sess == nil || ch.sess.OperationTime == nil {
+		return nil
+	}
+	opTime := *ch.sess.OperationTime
+	return &opTime
+}
+
+// Close ends the session of the handle. Closing a closed handle does nothing.
+func (ch *CausalHandle) Close(ctx context.Context) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	if ch.sess == nil {
+		return
+	}
+	ch.sess.EndSession(ctx)
+	ch.sess = nil
+}
+
+// do calls f with the session of the handle while holding its lock.
+func (ch *CausalHandle) do(f func(sess *Session) error) error {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	if ch.sess == nil {
+		return ErrCausalHandleClosed
+	}
+	return f(ch.sess)
+}
+
+// InsertOne inserts a document as Collection.InsertOne does.
+func (ch *CausalHandle) InsertOne(ctx context.Context, document interface{},
+	opts ...insertopt.One) (res *InsertOneResult, err error) {
+
+	err = ch.do(func(sess *Session) error {
+		res, err = ch.coll.InsertOne(ctx, document, append(opts, sess)...)
+		return err
+	})
+	return res, err
+}
+
+// InsertMany inserts documents as Collection.InsertMany does.
+func (ch *CausalHandle) InsertMany(ctx context.Context, documents []interface{},
+	opts ...insertopt.Many) (res *InsertManyResult, err error) {
+
+	err = ch.do(func(sess *Session) error {
+		res, err = ch.coll.InsertMany(ctx, documents, append(opts, sess)...)
+		return err
+	})
+	return res, err
+}
+
+// DeleteOne deletes a document as Collection.DeleteOne does.
+func (ch *CausalHandle) DeleteOne(ctx context.Context, filter interface{},
+	opts ...deleteopt.Delete) (res *DeleteResult, err error) {
+
+	err = ch.do(func(sess *Session) error {
+		res, err = ch.coll.DeleteOne(ctx, filter, append(opts, sess)...)
+		return err
+	})
+	return res, err
+}
+
+// DeleteMany deletes documents as Collection.DeleteMany does.
+func (ch *CausalHandle) DeleteMany(ctx context.Context, filter interface{},
+	opts ...deleteopt.Delete) (res *DeleteResult, err error) {
+
+	err = ch.do(func(sess *Session) error {
+		res, err = ch.coll.DeleteMany(ctx, filter, append(opts, sess)...)
+		return err
+	})
+	return res, err
+}
+
+// UpdateOne updates a document as Collection.UpdateOne does.
+func (ch *CausalHandle) UpdateOne(ctx context.Context, filter interface{}, update interface{},
+	opts ...updateopt.Update) (res *UpdateResult, err error) {
+
+	err = ch.do(func(sess *Session) error {
+		res, err = ch.coll.UpdateOne(ctx, filter, update, append(opts, sess)...)
+		return err
+	})
+	return res, err
+}
+
+// UpdateMany updates documents as Collection.UpdateMany does.
+func (ch *CausalHandle) UpdateMany(ctx context.Context, filter interface{}, update interface{},
+	opts ...updateopt.Update) (res *UpdateResult, err error) {
+
+	err = ch.do(func(sess *Session) error {
+		res, err = ch.coll.UpdateMany(ctx, filter, update, append(opts, sess)...)
+		return err
+	})
+	return res, err
+}
+
+// ReplaceOne replaces a document as Collection.ReplaceOne does.
+func (ch *CausalHandle) ReplaceOne(ctx context.Context, filter interface{}, replacement interface{},
+	opts ...replaceopt.Replace) (res *UpdateResult, err error) {
+
+	err = ch.do(func(sess *Session) error {
+		res, err = ch.coll.ReplaceOne(ctx, filter, replacement, append(opts, sess)...)
+		return err
+	})
+	return res, err
+}
+
+// FindOneAndDelete deletes a document as Collection.FindOneAndDelete does.
+func (ch *CausalHandle) FindOneAndDelete(ctx context.Context, filter interface{},
+	opts ...findopt.DeleteOne) (res *DocumentResult) {
+
+	err := ch.do(func(sess *Session) error {
+		res = ch.coll.FindOneAndDelete(ctx, filter, append(opts, sess)...)
+		return nil
+	})
+	if err != nil {
+		return &DocumentResult{err: err}
+	}
+	return res
+}
+
+// FindOneAndReplace replaces a document as Collection.FindOneAndReplace does.
+func (ch *CausalHandle) FindOneAndReplace(ctx context.Context, filter interface{},
+	replacement interface{}, opts ...findopt.ReplaceOne) (res *DocumentResult) {
+
+	err := ch.do(func(sess *Session) error {
+		res = ch.coll.FindOneAndReplace(ctx, filter, replacement, append(opts, sess)...)
+		return nil
+	})
+	if err != nil {
+		return &DocumentResult{err: err}
+	}
+	return res
+}
+
+// FindOneAndUpdate updates a document as Collection.FindOneAndUpdate does.
+func (ch *CausalHandle) FindOneAndUpdate(ctx context.Context, filter interface{},
+	update interface{}, opts ...findopt.UpdateOne) (res *DocumentResult) {
+
+	err := ch.do(func(sess *Session) error {
+		res = ch.coll.FindOneAndUpdate(ctx, filter, update, append(opts, sess)...)
+		return nil
+	})
+	if err != nil {
+		return &DocumentResult{err: err}
+	}
+	return res
+}
+
+// Find finds documents as Collection.Find does.
+func (ch *CausalHandle) Find(ctx context.Context, filter interface{},
+	opts ...findopt.Find) (cur Cursor, err error) {
+
+	err = ch.do(func(sess *Session) error {
+		cur, err = ch.coll.Find(ctx, filter, append(opts, sess)...)
+		return err
+	})
+	return cur, err
+}
+
+// FindOne finds a document as Collection.FindOne does.
+func (ch *CausalHandle) FindOne(ctx context.Context, filter interface{},
+	opts ...findopt.One) (res *DocumentResult) {
+
+	err := ch.do(func(sess *Session) error {
+		res = ch.coll.FindOne(ctx, filter, append(opts, sess)...)
+		return nil
+	})
+	if err != nil {
+		return &DocumentResult{err: err}
+	}
+	return res
+}
+
+// CountDocuments counts documents as Collection.CountDocuments does.
+func (ch *CausalHandle) CountDocuments(ctx context.Context, filter interface{},
+	opts ...countopt.Count) (n int64, err error) {
+
+	err = ch.do(func(sess *Session) error {
+		n, err = ch.coll.CountDocuments(ctx, filter, append(opts, sess)...)
+		return err
+	})
+	return n, err
+}
+
+// Distinct finds distinct values as Collection.Distinct does.
+func (ch *CausalHandle) Distinct(ctx context.Context, fieldName string, filter interface{},
+	opts ...distinctopt.Distinct) (values []interface{}, err error) {
+
+	err = ch.do(func(sess *Session) error {
+		values, err = ch.coll.Distinct(ctx, fieldName, filter, append(opts, sess)...)
+		return err
+	})
+	return values, err
+}
+
+// Aggregate runs an aggregation as Collection.Aggregate does.
+func (ch *CausalHandle) Aggregate(ctx context.Context, pipeline interface{},
+	opts ...aggregateopt.Aggregate) (cur Cursor, err error) {
+
+	err = ch.do(func(sess *Session) error {
+		cur, err = ch.coll.Aggregate(ctx, pipeline, append(opts, sess)...)
+		return err
+	})
+	return cur, err
+}
@@ -0,0 +1,97 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/writeconcern"
+	"github.com/mongodb/mongo-go-driver/mongo/sessionopt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCausalPairLifetime(t *testing.T) {
+	client, err := NewClient("mongodb://localhost:27017")
+	require.NoError(t, err)
+	client.topology.SessionPool = session.NewPool(nil)
+	coll := client.Database("db").Collection("coll")
+
+	ch, err := CausalPair(coll, sessionopt.CausalConsistency(false))
+	require.NoError(t, err)
+	require.True(t, ch.sess.Consistent)
+	require.Equal(t, readconcern.Majority(), ch.Collection().readConcern)
+	require.Equal(t, writeconcern.New(writeconcern.WMajority()), ch.Collection().writeConcern)
+	require.NotEqual(t, readconcern.Majority(), coll.readConcern, "the collection passed to CausalPair should not change")
+	require.Nil(t, ch.OperationTime())
+
+	require.NoError(t, ch.sess.AdvanceOperationTime(&bson.Timestamp{T: 1, I: 2}))
+	opTime := ch.OperationTime()
+	require.Equal(t, &bson.Timestamp{T: 1, I: 2}, opTime)
+	opTime.T = 5
+	require.Equal(t, uint32(1), ch.sess.OperationTime.T)
+
+	ch.Close(ctx)
+	ch.Close(ctx)
+	require.Nil(t, ch.OperationTime())
+
+	_, err = ch.InsertOne(ctx, doc)
+	require.Equal(t, ErrCausalHandleClosed, err)
+	_, err = ch.Find(ctx, emptyDoc)
+	require.Equal(t, ErrCausalHandleClosed, err)
+	require.Equal(t, ErrCausalHandleClosed, ch.FindOne(ctx, emptyDoc).Err())
+	require.Equal(t, ErrCausalHandleClosed, ch.FindOneAndReplace(ctx, emptyDoc, doc).Err())
+}
+
+func TestCausalPair(t *testing.T) {
+	skipInvalidTopology(t)
+	skipIfBelow36(t)
+
+	client := createSessionsMonitoredClient(t, ccMonitor)
+	db := client.Database("CausalPairDB")
+	require.NoError(t, db.Drop(ctx))
+	coll := db.Collection("CausalPairColl")
+
+	ch, err := CausalPair(coll)
+	require.NoError(t, err)
+	defer ch.Close(ctx)
+
+	// the first read has nothing to wait for
+	_, err = ch.CountDocuments(ctx, emptyDoc)
+	require.NoError(t, err)
+	require.NotNil(t, ccStarted)
+	checkReadConcern(t, ccStarted.Command, true, "majority", false, nil)
+
+	// each read waits for the write before it, including writes after earlier reads
+	for i := 0; i < 2; i++ {
+		_, err = ch.InsertOne(ctx, bson.NewDocument(bson.EC.Int32("x", int32(i))))
+		require.NoError(t, err)
+		wc, err := ccStarted.Command.LookupErr("writeConcern", "w")
+		require.NoError(t, err)
+		require.Equal(t, "majority", wc.StringValue())
+
+		writeTime := ch.OperationTime()
+		require.NotNil(t, writeTime)
+
+		n, err := ch.CountDocuments(ctx, emptyDoc)
+		require.NoError(t, err)
+		require.Equal(t, int64(i+1), n)
+		checkReadConcern(t, ccStarted.Command, true, "majority", true, writeTime)
+	}
+
+	// reads through a FindOneAndReplace also wait for it
+	res := ch.FindOneAndReplace(ctx, bson.NewDocument(bson.EC.Int32("x", 0)), bson.NewDocument(bson.EC.Int32("x", 2)))
+	require.NoError(t, res.Err())
+	writeTime := ch.OperationTime()
+
+	cur, err := ch.Find(ctx, bson.NewDocument(bson.EC.Int32("x", 2)))
+	require.NoError(t, err)
+	checkReadConcern(t, ccStarted.Command, true, "majority", true, writeTime)
+	require.NoError(t, cur.Close(ctx))
+}
@@ -16,10 +16,9 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/command"
 	"github.com/mongodb/mongo-go-driver/core/option"
 	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 	"github.com/mongodb/mongo-go-driver/mongo/aggregateopt"
 	"github.com/mongodb/mongo-go-driver/mongo/changestreamopt"
-
-	"go.opencensus.io/trace"
 )
 
 // ErrMissingResumeToken indicates that a change stream notification from the server did not
@@ -27,14 +26,17 @@ import (
 var ErrMissingResumeToken = errors.New("cannot provide resume functionality when the resume token is missing")
 
 type changeStream struct {
-	pipeline    *bson.Array
-	options     []option.ChangeStreamOptioner
-	coll        *Collection
-	cursor      Cursor
-	session     *session.Client
-	clock       *session.ClusterClock
-	resumeToken *bson.Document
-	err         error
+	// stageOptions holds the options of the $changeStream stage that starts pipeline. Resuming
+	// patches the resume token into it in place, so the rest of the pipeline is not rebuilt.
+	stageOptions *bson.Document
+	pipeline     *bson.Array
+	aggregate    command.Aggregate // reused to resume the change stream
+	coll         *Collection
+	cursor       Cursor
+	session      *session.Client
+	clock        *session.ClusterClock
+	resumeToken  *bson.Document
+	err          error
 }
 
 const errorCodeNotMaster int32 = 10107
@@ -43,14 +45,14 @@ const errorCodeCursorNotFound int32 = 43
 func newChangeStream(ctx context.Context, coll *Collection, pipeline interface{},
 	opts ...changestreamopt.ChangeStream) (*changeStream, error) {
 
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.newChangeStream")
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.newChangeStream")
 	defer span.End()
 
 	span.Annotatef(nil, "Started aggregate pipeline transformation")
-	pipelineArr, err := transformAggregatePipeline(pipeline)
+	pipelineArr, err := transformAggregatePipeline(coll.registry, pipeline)
 	span.Annotatef(nil, "Finished aggregate pipeline transformation")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
@@ -73,6 +75,8 @@ func newChangeStream(ctx context.Context, coll *Collection, pipeline interface{}
 			continue
 		case option.OptMaxAwaitTime:
 			aggOptions = append(aggOptions, aggregateopt.MaxAwaitTime(time.Duration(t)))
+		case option.OptBatchSize:
+			aggOptions = append(aggOptions, aggregateopt.BatchSize(int32(t)))
 		default:
 			err = opt.Option(changeStreamOptions)
 			if err != nil {
@@ -81,37 +85,81 @@ func newChangeStream(ctx context.Context, coll *Collection, pipeline interface{}
 		}
 	}
 
-	pipelineArr.Prepend(
-		bson.VC.Document(
-			bson.NewDocument(
-				bson.EC.SubDocument("$changeStream", changeStreamOptions))))
+	pipelineArr, err = changeStreamPipeline(changeStreamOptions, pipelineArr)
+	if err != nil {
+		return nil, err
+	}
+
+	resumeOpts, _, err := aggregateopt.BundleAggregate(aggOptions...).Unbundle(true)
+	if err != nil {
+		return nil, err
+	}
 
 	span.Annotatef(nil, "Starting the pipeline aggregation")
 	cursor, err := coll.Aggregate(ctx, pipelineArr, aggOptions...)
 	span.Annotatef(nil, "Finished the pipeline aggregation")
 	if err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
+	oldns := coll.namespace()
 	cs := &changeStream{
-		pipeline: pipelineArr,
-		options:  csOpts,
-		coll:     coll,
-		cursor:   cursor,
-		session:  sess,
-		clock:    coll.client.clock,
+		stageOptions: changeStreamOptions,
+		pipeline:     pipelineArr,
+		aggregate: command.Aggregate{
+			NS:       command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
+			Pipeline: pipelineArr,
+			Opts:     resumeOpts,
+			ReadPref: coll.readPreference,
+			Session:  sess,
+			Clock:    coll.client.clock,
+		},
+		coll:    coll,
+		cursor:  cursor,
+		session: sess,
+		clock:   coll.client.clock,
 	}
 
 	return cs, nil
 }
 
+// changeStreamPipeline returns a new pipeline made of a $changeStream stage with the given options
+// followed by the stages of pipeline, which may belong to the caller and is left unmodified. The
+// stages are encoded once, so resuming only re-encodes the $changeStream stage.
+func changeStreamPipeline(stageOptions *bson.Document, pipeline *bson.Array) (*bson.Array, error) {
+	arr := bson.NewArray(bson.VC.Document(bson.NewDocument(bson.EC.SubDocument("$changeStream", stageOptions))))
+
+	for i := 0; i < pipeline.Len(); i++ {
+		stage, err := pipeline.Lookup(uint(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if doc, ok := stage.MutableDocumentOK(); ok {
+			rdr, err := doc.MarshalBSON()
+			if err != nil {
+				return nil, err
+			}
+			stage = bson.VC.DocumentFromReader(rdr)
+		}
+
+		arr.Append(stage)
+	}
+
+	return arr, nil
+}
+
 func (cs *changeStream) ID() int64 {
 	return cs.cursor.ID()
 }
 
+func (cs *changeStream) Namespace() (string, string) {
+	return cs.cursor.Namespace()
+}
+
 func (cs *changeStream) Next(ctx context.Context) bool {
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*changeStream).Next")
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*changeStream).Next")
 	defer span.End()
 
 	span.Annotatef(nil, "Invoking next")
@@ -132,33 +180,15 @@ func (cs *changeStream) Next(ctx context.Context) bool {
 		}
 	}
 
-	resumeToken := changestreamopt.ResumeAfter(cs.resumeToken).ConvertChangeStreamOption()
-	found := false
-
-	for i, opt := range cs.options {
-		if _, ok := opt.(option.OptResumeAfter); ok {
-			cs.options[i] = resumeToken
-			found = true
-			break
-		}
-	}
-
-	if !found {
-		cs.options = append(cs.options, resumeToken)
-	}
-
-	oldns := cs.coll.namespace()
-	killCursors := command.KillCursors{
-		NS:  command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
-		IDs: []int64{cs.ID()},
-	}
+	// the cursor is killed on the server it was created on, which may no longer be selected
+	_ = cs.cursor.Close(ctx)
 
 	span.Annotatef(nil, "Selecting the server in the topology")
 	ss, err := cs.coll.client.topology.SelectServer(ctx, cs.coll.readSelector)
 	span.Annotatef(nil, "Finished selecting the server in the topology")
 	if err != nil {
 		cs.err = err
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return false
 	}
 
@@ -167,45 +197,24 @@ func (cs *changeStream) Next(ctx context.Context) bool {
 	span.Annotatef(nil, "Finished retrieving the connection")
 	if err != nil {
 		cs.err = err
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return false
 	}
 	defer conn.Close()
 
-	_, _ = killCursors.RoundTrip(ctx, ss.Description(), conn)
-
-	changeStreamOptions := bson.NewDocument()
-
-	for _, opt := range cs.options {
-		err = opt.Option(changeStreamOptions)
-		if err != nil {
-			cs.err = err
-			return false
-		}
-	}
-
-	cs.pipeline.Set(0, bson.VC.Document(
-		bson.NewDocument(
-			bson.EC.SubDocument("$changeStream", changeStreamOptions)),
-	),
-	)
-
-	oldns = cs.coll.namespace()
-	aggCmd := command.Aggregate{
-		NS:       command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
-		Pipeline: cs.pipeline,
-		Session:  cs.session,
-		Clock:    cs.coll.client.clock,
+	// without a resume token, the change stream resumes with its original options
+	if cs.resumeToken != nil {
+		cs.stageOptions.Set(bson.EC.SubDocument("resumeAfter", cs.resumeToken))
 	}
 
 	span.Annotatef(nil, "Now invoking aggregate command RoundTrip")
-	cur, err := aggCmd.RoundTrip(ctx, ss.Description(), ss, conn)
+	cur, err := cs.aggregate.RoundTrip(ctx, ss.Description(), ss, conn)
 	span.Annotatef(nil, "Finished invoking aggregate command RoundTrip")
 	cs.cursor = cur
 	cs.err = err
 
 	if cs.err != nil {
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: cs.err.Error()})
+		span.SetStatus(observability.SpanStatus(cs.err))
 		return false
 	}
 
@@ -227,17 +236,80 @@ func (cs *changeStream) DecodeBytes() (bson.Reader, error) {
 		return nil, err
 	}
 
-	id, err := br.Lookup("_id")
+	token, err := resumeToken(br)
 	if err != nil {
 		_ = cs.Close(context.Background())
-		return nil, ErrMissingResumeToken
+		return nil, err
 	}
 
-	cs.resumeToken = id.Value().MutableDocument()
+	cs.resumeToken = token
 
 	return br, nil
 }
 
+// errResumeTokenFound stops the iteration of a change once its resume token has been found.
+var errResumeTokenFound = errors.New("resume token found")
+
+// resumeToken returns a copy of the resume token of change, which may outlive the batch holding
+// the change.
+func resumeToken(change bson.Reader) (*bson.Document, error) {
+	var token bson.Reader
+	err := change.ForEach(func(key []byte, val bson.Value) error {
+		if string(key) != "_id" {
+			return nil
+		}
+
+		token, _ = val.ReaderDocumentOK()
+		return errResumeTokenFound
+	})
+	if err != errResumeTokenFound || token == nil {
+		return nil, ErrMissingResumeToken
+	}
+
+	return bson.ReadDocument(append([]byte(nil), token...))
+}
+
+func (cs *changeStream) NextBatch(ctx context.Context) bool {
+	if cs.err != nil || !cs.cursor.NextBatch(ctx) {
+		return false
+	}
+
+	// the batch is expected to be consumed in full, so resuming starts after its last change
+	batch := cs.cursor.Batch()
+	var last bson.Reader
+	for doc, ok := batch.Next(); ok; doc, ok = batch.Next() {
+		last = doc
+	}
+
+	token, err := resumeToken(last)
+	if err != nil {
+		_ = cs.Close(context.Background())
+		cs.err = err
+		return false
+	}
+
+	cs.resumeToken = token
+	return true
+}
+
+func (cs *changeStream) Batch() *command.Batch {
+	return cs.cursor.Batch()
+}
+
+// SetBatchSize sets the batch size of the getMores of the change stream, including those of the
+// cursors it resumes with.
+func (cs *changeStream) SetBatchSize(batchSize int32) {
+	cs.cursor.SetBatchSize(batchSize)
+
+	opts := make([]option.AggregateOptioner, 0, len(cs.aggregate.Opts)+1)
+	for _, opt := range cs.aggregate.Opts {
+		if _, ok := opt.(option.OptBatchSize); !ok {
+			opts = append(opts, opt)
+		}
+	}
+	cs.aggregate.Opts = append(opts, option.OptBatchSize(batchSize))
+}
+
 func (cs *changeStream) Err() error {
 	if cs.err != nil {
 		return cs.err
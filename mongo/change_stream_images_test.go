@@ -0,0 +1,83 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchPreImagesAndExpandedEvents(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("killCursors", mongotest.OK())
+
+	client := newMockClient(t, d)
+
+	coll := client.Database("db").Collection("coll")
+	ctx := context.Background()
+	opts := options.ChangeStream().
+		SetFullDocument(mongoopt.WhenAvailable).
+		SetFullDocumentBeforeChange(mongoopt.Required).
+		SetShowExpandedEvents(true)
+
+	// openCursor replies a cursor that is not exhausted, so the change stream sends a getMore
+	openCursor := mongotest.OK(bson.EC.SubDocumentFromElements("cursor",
+		bson.EC.Int64("id", 7),
+		bson.EC.String("ns", "db.coll"),
+		bson.EC.ArrayFromElements("firstBatch"),
+	))
+	stages := func() []*bson.Document {
+		var docs []*bson.Document
+		for _, c := range d.CommandsNamed("aggregate") {
+			docs = append(docs, c.Document.Lookup("pipeline", "0", "$changeStream").MutableDocument())
+		}
+		return docs
+	}
+
+	t.Run("resume", func(t *testing.T) {
+		d.Handle("aggregate", mongotest.Sequence(openCursor, mongotest.Cursor("db.coll")))
+		d.Handle("getMore", mongotest.Error(43, "CursorNotFound", "cursor id 7 not found"))
+
+		cs, err := coll.Watch(ctx, nil, opts)
+		require.NoError(t, err)
+		defer func() { _ = cs.Close(ctx) }()
+		require.False(t, cs.Next(ctx))
+		require.NoError(t, cs.Err())
+
+		// the resumed change stream keeps its options
+		sent := stages()
+		require.Len(t, sent, 2)
+		for _, stage := range sent {
+			require.Equal(t, "whenAvailable", stage.Lookup("fullDocument").StringValue())
+			require.Equal(t, "required", stage.Lookup("fullDocumentBeforeChange").StringValue())
+			require.True(t, stage.Lookup("showExpandedEvents").Boolean())
+		}
+	})
+	t.Run("missing pre-image", func(t *testing.T) {
+		msg := "Change stream was configured to require a pre-image for all update, delete and replace events, " +
+			"but the pre-image was not found"
+		d.Handle("aggregate", openCursor)
+		d.Handle("getMore", mongotest.Error(280, "ChangeStreamFatalError", msg))
+
+		cs, err := coll.Watch(ctx, nil, opts)
+		require.NoError(t, err)
+		defer func() { _ = cs.Close(ctx) }()
+		require.False(t, cs.Next(ctx))
+
+		cmdErr, ok := cs.Err().(command.Error)
+		require.True(t, ok, "expected a command.Error, got %v", cs.Err())
+		require.Equal(t, int32(280), cmdErr.Code)
+		require.Equal(t, msg, cmdErr.Message)
+	})
+}
@@ -15,8 +15,8 @@ import (
 	"strings"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/bsonassert"
 	"github.com/mongodb/mongo-go-driver/core/command"
-	"github.com/mongodb/mongo-go-driver/core/option"
 	"github.com/stretchr/testify/require"
 )
 
@@ -206,16 +206,59 @@ func TestChangeStream_resumableError(t *testing.T) {
 	require.True(t, strings.Contains(err.Error(), "context deadline exceeded"))
 
 	// If the ResumeAfter option is present, the the operation attempted to resume.
-	hasResume := false
+	hasResume := changes.(*changeStream).stageOptions.LookupElement("resumeAfter") != nil
 
-	for _, opt := range changes.(*changeStream).options {
-		if _, ok := opt.(option.OptResumeAfter); ok {
-			hasResume = true
-			break
-		}
+	require.True(t, hasResume)
+}
+
+func TestChangeStream_pipeline(t *testing.T) {
+	match := bson.NewDocument(bson.EC.SubDocument("$match", bson.NewDocument(bson.EC.Int32("x", 1))))
+	userPipeline := bson.NewArray(bson.VC.Document(match))
+	stageOptions := bson.NewDocument(bson.EC.String("fullDocument", "updateLookup"))
+
+	pipeline, err := changeStreamPipeline(stageOptions, userPipeline)
+	require.NoError(t, err)
+	require.Equal(t, 1, userPipeline.Len(), "the pipeline of the caller should not be modified")
+
+	// resuming patches the resume token into the options of the first stage
+	token := bson.NewDocument(bson.EC.Int32("token", 1))
+	stageOptions.Set(bson.EC.SubDocument("resumeAfter", token))
+
+	b, err := bson.NewDocument(bson.EC.Array("pipeline", pipeline)).MarshalBSON()
+	require.NoError(t, err)
+
+	want, err := bson.NewDocument(bson.EC.ArrayFromElements("pipeline",
+		bson.VC.DocumentFromElements(bson.EC.SubDocumentFromElements("$changeStream",
+			bson.EC.String("fullDocument", "updateLookup"),
+			bson.EC.SubDocument("resumeAfter", token),
+		)),
+		bson.VC.Document(match),
+	)).MarshalBSON()
+	require.NoError(t, err)
+	require.Equal(t, bson.Reader(want), bson.Reader(b))
+}
+
+func TestChangeStream_resumeToken(t *testing.T) {
+	change, err := bson.NewDocument(
+		bson.EC.SubDocumentFromElements("_id", bson.EC.String("_data", "token")),
+		bson.EC.String("operationType", "insert"),
+	).MarshalBSON()
+	require.NoError(t, err)
+
+	token, err := resumeToken(change)
+	require.NoError(t, err)
+	bsonassert.RequireEqual(t, bson.NewDocument(bson.EC.String("_data", "token")), token)
+
+	// the token is a copy which outlives the change
+	for i := range change {
+		change[i] = 0
 	}
+	require.Equal(t, "token", token.Lookup("_data").StringValue())
 
-	require.True(t, hasResume)
+	change, err = bson.NewDocument(bson.EC.String("operationType", "insert")).MarshalBSON()
+	require.NoError(t, err)
+	_, err = resumeToken(change)
+	require.Equal(t, ErrMissingResumeToken, err)
 }
 
 // TODO: GODRIVER-247 Test that a change stream does not attempt to resume after a server error.
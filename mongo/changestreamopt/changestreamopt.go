@@ -4,6 +4,8 @@
 // not use this file except in compliance with the License. You may obtain
 // a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
 
+// Package changestreamopt contains the options of change streams. The Opt types and bundles of this
+// package are superseded by options.ChangeStream and are kept working for existing code.
 package changestreamopt
 
 import (
@@ -49,11 +51,17 @@ func (csb *ChangeStreamBundle) ConvertChangeStreamOption() option.ChangeStreamOp
 	return nil
 }
 
-// BundleChangeStream bundles ChangeStream options.
+// BundleChangeStream bundles ChangeStream options. A *ChangeStreamOptions is bundled as the options
+// its fields are set to when it is bundled. New code should build the options with
+// options.ChangeStream instead.
 func BundleChangeStream(opts ...ChangeStream) *ChangeStreamBundle {
 	head := csBundle
 
 	for _, opt := range opts {
+		if o, ok := opt.(*ChangeStreamOptions); ok {
+			opt = o.bundle()
+		}
+
 		newBundle := ChangeStreamBundle{
 			option: opt,
 			next:   head,
@@ -95,6 +103,17 @@ func (csb *ChangeStreamBundle) FullDocument(fd mongoopt.FullDocument) *ChangeStr
 	return bundle
 }
 
+// FullDocumentBeforeChange specifies if a copy of the whole document before the change should be
+// returned.
+func (csb *ChangeStreamBundle) FullDocumentBeforeChange(fd mongoopt.FullDocument) *ChangeStreamBundle {
+	bundle := &ChangeStreamBundle{
+		option: FullDocumentBeforeChange(fd),
+		next:   csb,
+	}
+
+	return bundle
+}
+
 // MaxAwaitTime specifies the maximum amount of time for the server to wait on new documents.
 func (csb *ChangeStreamBundle) MaxAwaitTime(d time.Duration) *ChangeStreamBundle {
 	bundle := &ChangeStreamBundle{
@@ -115,6 +134,16 @@ func (csb *ChangeStreamBundle) ResumeAfter(d *bson.Document) *ChangeStreamBundle
 	return bundle
 }
 
+// ShowExpandedEvents specifies whether DDL events and additional event fields should be returned.
+func (csb *ChangeStreamBundle) ShowExpandedEvents(b bool) *ChangeStreamBundle {
+	bundle := &ChangeStreamBundle{
+		option: ShowExpandedEvents(b),
+		next:   csb,
+	}
+
+	return bundle
+}
+
 // Unbundle transforms a bundle into a slice of options, optionally deduplicating
 func (csb *ChangeStreamBundle) Unbundle(deduplicate bool) ([]option.ChangeStreamOptioner, *session.Client, error) {
 
@@ -260,6 +289,14 @@ func FullDocument(fd mongoopt.FullDocument) OptFullDocument {
 	return OptFullDocument(fd)
 }
 
+// FullDocumentBeforeChange specifies whether a copy of the whole document before the change should
+// be returned. The server only has this copy if the collection records pre-images, which requires
+// MongoDB 6.0 or later. With mongoopt.Required, the server fails the change stream when it does not
+// have the copy, and the error is returned as the server reported it.
+func FullDocumentBeforeChange(fd mongoopt.FullDocument) OptFullDocumentBeforeChange {
+	return OptFullDocumentBeforeChange(fd)
+}
+
 // MaxAwaitTime specifies the max amount of time for the server to wait on new documents.
 func MaxAwaitTime(d time.Duration) OptMaxAwaitTime {
 	return OptMaxAwaitTime(d)
@@ -272,6 +309,13 @@ func ResumeAfter(d *bson.Document) OptResumeAfter {
 	}
 }
 
+// ShowExpandedEvents specifies whether the change stream should return DDL events, such as
+// create and createIndexes, and additional fields, such as operationDescription. It requires
+// MongoDB 6.0 or later.
+func ShowExpandedEvents(b bool) OptShowExpandedEvents {
+	return OptShowExpandedEvents(b)
+}
+
 // OptBatchSize specifies the number of documents to return in each batch.
 type OptBatchSize option.OptBatchSize
 
@@ -302,6 +346,17 @@ func (opt OptFullDocument) ConvertChangeStreamOption() option.ChangeStreamOption
 	return option.OptFullDocument(opt)
 }
 
+// OptFullDocumentBeforeChange specifies whether a copy of the whole document before the change
+// should be returned.
+type OptFullDocumentBeforeChange option.OptFullDocumentBeforeChange
+
+func (OptFullDocumentBeforeChange) changeStream() {}
+
+// ConvertChangeStreamOption implements the ChangeStream interface.
+func (opt OptFullDocumentBeforeChange) ConvertChangeStreamOption() option.ChangeStreamOptioner {
+	return option.OptFullDocumentBeforeChange(opt)
+}
+
 // OptMaxAwaitTime specifies the max amount of time for the server to wait on new documents.
 type OptMaxAwaitTime option.OptMaxAwaitTime
 
@@ -322,6 +377,17 @@ func (opt OptResumeAfter) ConvertChangeStreamOption() option.ChangeStreamOptione
 	return option.OptResumeAfter(opt)
 }
 
+// OptShowExpandedEvents specifies whether DDL events and additional event fields should be
+// returned.
+type OptShowExpandedEvents option.OptShowExpandedEvents
+
+func (OptShowExpandedEvents) changeStream() {}
+
+// ConvertChangeStreamOption implements the ChangeStream interface.
+func (opt OptShowExpandedEvents) ConvertChangeStreamOption() option.ChangeStreamOptioner {
+	return option.OptShowExpandedEvents(opt)
+}
+
 // ChangeStreamSessionOpt is an count session option.
 type ChangeStreamSessionOpt struct{}
 
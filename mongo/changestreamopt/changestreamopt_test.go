@@ -178,8 +178,10 @@ func TestChangeStreamOpt(t *testing.T) {
 			BatchSize(5),
 			Collation(c),
 			FullDocument(mongoopt.UpdateLookup),
+			FullDocumentBeforeChange(mongoopt.WhenAvailable),
 			MaxAwaitTime(5000),
 			ResumeAfter(resumeAfter2),
+			ShowExpandedEvents(true),
 		}
 		params := make([]ChangeStream, len(opts))
 		for i := range opts {
@@ -0,0 +1,103 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package changestreamopt
+
+import (
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
+)
+
+// ChangeStreamOptions holds the options of a change stream as plain fields, which are set directly
+// or with its Set methods. A *ChangeStreamOptions is passed to Watch like any other ChangeStream
+// option and is converted to the equivalent bundle when the options are bundled.
+type ChangeStreamOptions struct {
+	BatchSize                *int32
+	Collation                *mongoopt.Collation
+	FullDocument             *mongoopt.FullDocument
+	FullDocumentBeforeChange *mongoopt.FullDocument
+	MaxAwaitTime             *time.Duration
+	ResumeAfter              *bson.Document
+	ShowExpandedEvents       *bool
+}
+
+func (*ChangeStreamOptions) changeStream() {}
+
+// SetBatchSize sets the BatchSize field. See BatchSize.
+func (cso *ChangeStreamOptions) SetBatchSize(i int32) *ChangeStreamOptions {
+	cso.BatchSize = &i
+	return cso
+}
+
+// SetCollation sets the Collation field. See Collation.
+func (cso *ChangeStreamOptions) SetCollation(c *mongoopt.Collation) *ChangeStreamOptions {
+	cso.Collation = c
+	return cso
+}
+
+// SetFullDocument sets the FullDocument field. See FullDocument.
+func (cso *ChangeStreamOptions) SetFullDocument(fd mongoopt.FullDocument) *ChangeStreamOptions {
+	cso.FullDocument = &fd
+	return cso
+}
+
+// SetFullDocumentBeforeChange sets the FullDocumentBeforeChange field. See
+// FullDocumentBeforeChange.
+func (cso *ChangeStreamOptions) SetFullDocumentBeforeChange(fd mongoopt.FullDocument) *ChangeStreamOptions {
+	cso.FullDocumentBeforeChange = &fd
+	return cso
+}
+
+// SetMaxAwaitTime sets the MaxAwaitTime field. See MaxAwaitTime.
+func (cso *ChangeStreamOptions) SetMaxAwaitTime(d time.Duration) *ChangeStreamOptions {
+	cso.MaxAwaitTime = &d
+	return cso
+}
+
+// SetResumeAfter sets the ResumeAfter field. See ResumeAfter.
+func (cso *ChangeStreamOptions) SetResumeAfter(d *bson.Document) *ChangeStreamOptions {
+	cso.ResumeAfter = d
+	return cso
+}
+
+// SetShowExpandedEvents sets the ShowExpandedEvents field. See ShowExpandedEvents.
+func (cso *ChangeStreamOptions) SetShowExpandedEvents(b bool) *ChangeStreamOptions {
+	cso.ShowExpandedEvents = &b
+	return cso
+}
+
+// bundle converts the set fields to a bundle, in the order the fields are declared in.
+func (cso *ChangeStreamOptions) bundle() *ChangeStreamBundle {
+	csb := BundleChangeStream()
+	if cso == nil {
+		return csb
+	}
+
+	if cso.BatchSize != nil {
+		csb = csb.BatchSize(*cso.BatchSize)
+	}
+	if cso.Collation != nil {
+		csb = csb.Collation(cso.Collation)
+	}
+	if cso.FullDocument != nil {
+		csb = csb.FullDocument(*cso.FullDocument)
+	}
+	if cso.FullDocumentBeforeChange != nil {
+		csb = csb.FullDocumentBeforeChange(*cso.FullDocumentBeforeChange)
+	}
+	if cso.MaxAwaitTime != nil {
+		csb = csb.MaxAwaitTime(*cso.MaxAwaitTime)
+	}
+	if cso.ResumeAfter != nil {
+		csb = csb.ResumeAfter(cso.ResumeAfter)
+	}
+	if cso.ShowExpandedEvents != nil {
+		csb = csb.ShowExpandedEvents(*cso.ShowExpandedEvents)
+	}
+	return csb
+}
@@ -10,10 +10,13 @@ import (
 	"context"
 	"time"
 
+	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/command"
 	"github.com/mongodb/mongo-go-driver/core/connstring"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/dispatch"
+	"github.com/mongodb/mongo-go-driver/core/logger"
+	"github.com/mongodb/mongo-go-driver/core/option"
 	"github.com/mongodb/mongo-go-driver/core/readconcern"
 	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
@@ -21,12 +24,11 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
 	"github.com/mongodb/mongo-go-driver/mongo/clientopt"
 	"github.com/mongodb/mongo-go-driver/mongo/dbopt"
 	"github.com/mongodb/mongo-go-driver/mongo/listdbopt"
 	"github.com/mongodb/mongo-go-driver/mongo/sessionopt"
-
-	"go.opencensus.io/trace"
 )
 
 const defaultLocalThreshold = 15 * time.Millisecond
@@ -43,6 +45,9 @@ type Client struct {
 	readPreference  *readpref.ReadPref
 	readConcern     *readconcern.ReadConcern
 	writeConcern    *writeconcern.WriteConcern
+	registry        *bson.Registry
+	// comment is the comment of the operations that are not passed one, if any.
+	comment *option.OptComment
 }
 
 // Connect creates a new Client and then initializes it using the Connect method.
@@ -127,6 +132,26 @@ func (c *Client) Ping(ctx context.Context, rp *readpref.ReadPref) error {
 	return err
 }
 
+// SupportsFeature returns true if the server selected with the read preference rp supports f, so
+// that applications can check for a feature before using it. If rp is nil then the default read
+// preference of the client is used. Operations using a feature the server they are sent to does
+// not support fail with a description.ErrUnsupportedServerVersion.
+func (c *Client) SupportsFeature(ctx context.Context, f description.Feature, rp *readpref.ReadPref) (bool, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if rp == nil {
+		rp = c.readPreference
+	}
+
+	ss, err := c.topology.SelectServer(ctx, description.ReadPrefSelector(rp))
+	if err != nil {
+		return false, err
+	}
+	return ss.Description().SupportsFeature(f), nil
+}
+
 // StartSession starts a new session.
 func (c *Client) StartSession(opts ...sessionopt.Session) (*Session, error) {
 	if c.topology.SessionPool == nil {
@@ -178,6 +203,17 @@ func newClient(cs connstring.ConnString, opts ...clientopt.Option) (*Client, err
 		topologyOptions: clientOpt.TopologyOptions,
 		connString:      clientOpt.ConnString,
 		localThreshold:  defaultLocalThreshold,
+		readPreference:  clientOpt.ReadPreference,
+		readConcern:     clientOpt.ReadConcern,
+		writeConcern:    clientOpt.WriteConcern,
+		registry:        clientOpt.Registry,
+		retryWrites:     clientOpt.ConnString.RetryWrites,
+	}
+	if clientOpt.RetryWritesSet {
+		client.retryWrites = clientOpt.RetryWrites
+	}
+	if clientOpt.Comment != nil {
+		client.comment = &option.OptComment{Comment: clientOpt.Comment, Default: true}
 	}
 
 	uuid, err := uuid.New()
@@ -189,18 +225,18 @@ func newClient(cs connstring.ConnString, opts ...clientopt.Option) (*Client, err
 	topts := append(
 		client.topologyOptions,
 		topology.WithConnString(func(connstring.ConnString) connstring.ConnString { return client.connString }),
-		topology.WithServerOptions(func(opts ...topology.ServerOption) []topology.ServerOption {
-			return append(opts, topology.WithClock(func(clock *session.ClusterClock) *session.ClusterClock {
-				return client.clock
-			}))
-		}),
 	)
 	topo, err := topology.New(topts...)
 	if err != nil {
 		return nil, err
 	}
 	client.topology = topo
-	client.clock = &session.ClusterClock{}
+	client.clock = topo.ClusterClock()
+
+	for _, warning := range client.connString.Warnings {
+		logger.Log(topo.Logger(), logger.LevelWarn, logger.ComponentConnection, "Connection string warning",
+			"warning", warning)
+	}
 
 	if client.readConcern == nil {
 		client.readConcern = readConcernFromConnString(&client.connString)
@@ -240,39 +276,7 @@ func readConcernFromConnString(cs *connstring.ConnString) *readconcern.ReadConce
 }
 
 func writeConcernFromConnString(cs *connstring.ConnString) *writeconcern.WriteConcern {
-	var wc *writeconcern.WriteConcern
-
-	if len(cs.WString) > 0 {
-		if wc == nil {
-			wc = writeconcern.New()
-		}
-
-		writeconcern.WTagSet(cs.WString)(wc)
-	} else if cs.WNumberSet {
-		if wc == nil {
-			wc = writeconcern.New()
-		}
-
-		writeconcern.W(cs.WNumber)(wc)
-	}
-
-	if cs.JSet {
-		if wc == nil {
-			wc = writeconcern.New()
-		}
-
-		writeconcern.J(cs.J)(wc)
-	}
-
-	if cs.WTimeoutSet {
-		if wc == nil {
-			wc = writeconcern.New()
-		}
-
-		writeconcern.WTimeout(cs.WTimeout)(wc)
-	}
-
-	return wc
+	return cs.WriteConcern()
 }
 
 func readPreferenceFromConnString(cs *connstring.ConnString) (*readpref.ReadPref, error) {
@@ -326,7 +330,7 @@ func (c *Client) ListDatabases(ctx context.Context, filter interface{}, opts ...
 		ctx = context.Background()
 	}
 
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Client).ListDatabases")
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Client).ListDatabases")
 	defer span.End()
 
 	listDbOpts, sess, err := listdbopt.BundleListDatabases(opts...).Unbundle(true)
@@ -339,7 +343,7 @@ func (c *Client) ListDatabases(ctx context.Context, filter interface{}, opts ...
 		return ListDatabasesResult{}, err
 	}
 
-	f, err := TransformDocument(filter)
+	f, err := transformDocument(c.registry, "filter", filter)
 	if err != nil {
 		return ListDatabasesResult{}, err
 	}
@@ -371,13 +375,13 @@ func (c *Client) ListDatabaseNames(ctx context.Context, filter interface{}, opts
 		ctx = context.Background()
 	}
 
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Client).ListDatabaseNames")
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Client).ListDatabaseNames")
 	defer span.End()
 
 	opts = append(opts, listdbopt.NameOnly(true))
 	res, err := c.ListDatabases(ctx, filter, opts...)
 	if err != nil {
-		span.SetStatus(trace.Status{Code: trace.StatusCodeInternal, Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
@@ -388,3 +392,18 @@ func (c *Client) ListDatabaseNames(ctx context.Context, filter interface{}, opts
 
 	return names, nil
 }
+
+// CurrentOp returns a cursor over the in-progress operations of the server matching filter, which
+// may be nil to return all of them. It runs a $currentOp aggregation against the admin database.
+func (c *Client) CurrentOp(ctx context.Context, filter interface{}) (Cursor, error) {
+	pipeline := bson.NewArray(bson.VC.DocumentFromElements(bson.EC.SubDocument("$currentOp", bson.NewDocument())))
+	if filter != nil {
+		f, err := transformDocument(c.registry, "filter", filter)
+		if err != nil {
+			return nil, err
+		}
+		pipeline.Append(bson.VC.DocumentFromElements(bson.EC.SubDocument("$match", f)))
+	}
+
+	return c.Database("admin").Aggregate(ctx, pipeline)
+}
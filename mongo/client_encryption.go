@@ -0,0 +1,366 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/uuid"
+	"github.com/mongodb/mongo-go-driver/core/writeconcern"
+	"github.com/mongodb/mongo-go-driver/internal/crypt"
+	"github.com/mongodb/mongo-go-driver/mongo/collectionopt"
+	"github.com/mongodb/mongo-go-driver/mongo/encryptopt"
+)
+
+// The BSON binary subtypes used by client-side field level encryption.
+const (
+	uuidSubtype      byte = 0x04
+	encryptedSubtype byte = 0x06
+)
+
+// The first byte of an encrypted value, which identifies the algorithm it was encrypted with.
+const (
+	deterministicBlob byte = 0x01
+	randomBlob        byte = 0x02
+)
+
+// ErrDataKeyNotFound is returned when the data key to encrypt or decrypt a value with is not in the
+// key vault.
+var ErrDataKeyNotFound = errors.New("data key not found in the key vault")
+
+// ClientEncryption encrypts and decrypts values explicitly, following the client-side field level
+// encryption specification so that the values it encrypts can be decrypted by other drivers and
+// vice versa. Values are encrypted with data keys that are stored in a key vault collection,
+// themselves encrypted with a master key managed by a KMS provider.
+//
+// Only explicit encryption is supported: documents are not encrypted or decrypted automatically.
+type ClientEncryption struct {
+	keyVault *Collection
+	kms      map[string]crypt.KMSProvider
+}
+
+// NewClientEncryption creates a ClientEncryption that stores its data keys in the key vault
+// collection of client named by keyVaultNamespace, e.g. "encryption.__keyVault". kmsProviders
+// configures the KMS providers data keys can be created with, by name:
+//
+//	"local": {"key": a 96 byte []byte master key}
+//	"aws":   {"accessKeyId": string, "secretAccessKey": string, "sessionToken": optional string}
+//
+// The key vault is read and written with a majority read and write concern.
+func NewClientEncryption(client *Client, keyVaultNamespace string, kmsProviders map[string]map[string]interface{}) (*ClientEncryption, error) {
+	dot := strings.Index(keyVaultNamespace, ".")
+	if dot <= 0 || dot == len(keyVaultNamespace)-1 {
+		return nil, fmt.Errorf("invalid key vault namespace %q, it must be of the form database.collection", keyVaultNamespace)
+	}
+	if len(kmsProviders) == 0 {
+		return nil, errors.New("at least one KMS provider is required")
+	}
+
+	ce := &ClientEncryption{
+		keyVault: client.Database(keyVaultNamespace[:dot]).Collection(keyVaultNamespace[dot+1:],
+			collectionopt.ReadConcern(readconcern.Majority()),
+			collectionopt.WriteConcern(writeconcern.New(writeconcern.WMajority())),
+		),
+		kms: make(map[string]crypt.KMSProvider, len(kmsProviders)),
+	}
+
+	for name, opts := range kmsProviders {
+		switch name {
+		case "local":
+			key, ok := opts["key"].([]byte)
+			if !ok || len(key) != crypt.KeyLen {
+				return nil, fmt.Errorf("the local KMS provider requires a %d byte key", crypt.KeyLen)
+			}
+			ce.kms[name] = crypt.LocalKMS{Key: key}
+		case "aws":
+			id, _ := opts["accessKeyId"].(string)
+			secret, _ := opts["secretAccessKey"].(string)
+			token, _ := opts["sessionToken"].(string)
+			if id == "" || secret == "" {
+				return nil, errors.New("the aws KMS provider requires an accessKeyId and a secretAccessKey")
+			}
+			ce.kms[name] = crypt.AWSKMS{AccessKeyID: id, SecretAccessKey: secret, SessionToken: token}
+		default:
+			return nil, fmt.Errorf("unsupported KMS provider %q", name)
+		}
+	}
+
+	return ce, nil
+}
+
+// CreateDataKey creates a data key encrypted with the master key of the named KMS provider, stores
+// it in the key vault and returns its id, a UUID binary.
+func (ce *ClientEncryption) CreateDataKey(ctx context.Context, kmsProvider string, opts ...encryptopt.DataKey) (bson.Binary, error) {
+	kms, ok := ce.kms[kmsProvider]
+	if !ok {
+		return bson.Binary{}, fmt.Errorf("KMS provider %q is not configured", kmsProvider)
+	}
+
+	dko, err := encryptopt.BundleDataKey(opts...).Unbundle()
+	if err != nil {
+		return bson.Binary{}, err
+	}
+
+	masterKey, err := masterKeyDocument(kmsProvider, dko.MasterKey)
+	if err != nil {
+		return bson.Binary{}, err
+	}
+
+	dek := make([]byte, crypt.KeyLen)
+	if _, err = rand.Read(dek); err != nil {
+		return bson.Binary{}, err
+	}
+	keyMaterial, err := kms.WrapKey(ctx, masterKey, dek)
+	if err != nil {
+		return bson.Binary{}, err
+	}
+
+	id, err := uuid.New()
+	if err != nil {
+		return bson.Binary{}, err
+	}
+
+	now := time.Now()
+	keyDoc := bson.NewDocument(bson.EC.BinaryWithSubtype("_id", id[:], uuidSubtype))
+	if len(dko.KeyAltNames) > 0 {
+		names := bson.NewArray()
+		for _, name := range dko.KeyAltNames {
+			names.Append(bson.VC.String(name))
+		}
+		keyDoc.Append(bson.EC.Array("keyAltNames", names))
+	}
+	keyDoc.Append(
+		bson.EC.BinaryWithSubtype("keyMaterial", keyMaterial, 0),
+		bson.EC.Time("creationDate", now),
+		bson.EC.Time("updateDate", now),
+		bson.EC.Int32("status", 0),
+		bson.EC.SubDocument("masterKey", masterKey),
+	)
+
+	if _, err = ce.keyVault.InsertOne(ctx, keyDoc); err != nil {
+		return bson.Binary{}, err
+	}
+
+	return bson.Binary{Subtype: uuidSubtype, Data: id[:]}, nil
+}
+
+// Encrypt encrypts value with a data key from the key vault and returns the encrypted value, a
+// binary of subtype 6. The algorithm and either the id or an alternate name of the data key must be
+// set with the encryptopt options. Values encrypted deterministically cannot be documents, arrays,
+// doubles, decimals, booleans or JavaScript code with scope, and no value can be null, undefined,
+// MinKey or MaxKey.
+func (ce *ClientEncryption) Encrypt(ctx context.Context, value interface{}, opts ...encryptopt.Encrypt) (bson.Binary, error) {
+	eo, err := encryptopt.BundleEncrypt(opts...).Unbundle()
+	if err != nil {
+		return bson.Binary{}, err
+	}
+
+	var blobType byte
+	switch eo.Algorithm {
+	case encryptopt.AEADDeterministic:
+		blobType = deterministicBlob
+	case encryptopt.AEADRandom:
+		blobType = randomBlob
+	default:
+		return bson.Binary{}, fmt.Errorf("unsupported encryption algorithm %q", eo.Algorithm)
+	}
+	if (eo.KeyID == nil) == (eo.KeyAltName == nil) {
+		return bson.Binary{}, errors.New("exactly one of a key id and a key alternate name is required")
+	}
+
+	typ, plaintext, err := valueBytes(value)
+	if err != nil {
+		return bson.Binary{}, err
+	}
+	switch typ {
+	case bson.TypeNull, bson.TypeUndefined, bson.TypeMinKey, bson.TypeMaxKey:
+		return bson.Binary{}, fmt.Errorf("cannot encrypt a value of type %v", typ)
+	case bson.TypeEmbeddedDocument, bson.TypeArray, bson.TypeDouble, bson.TypeDecimal128,
+		bson.TypeBoolean, bson.TypeCodeWithScope:
+		if blobType == deterministicBlob {
+			return bson.Binary{}, fmt.Errorf("cannot encrypt a value of type %v deterministically", typ)
+		}
+	}
+
+	var filter *bson.Document
+	if eo.KeyID != nil {
+		filter = keyIDFilter(*eo.KeyID)
+	} else {
+		filter = bson.NewDocument(bson.EC.String("keyAltNames", *eo.KeyAltName))
+	}
+	keyID, dek, err := ce.dataKey(ctx, filter)
+	if err != nil {
+		return bson.Binary{}, err
+	}
+
+	ad := make([]byte, 0, 18)
+	ad = append(ad, blobType)
+	ad = append(ad, keyID...)
+	ad = append(ad, byte(typ))
+	ciphertext, err := crypt.Encrypt(dek, plaintext, ad, blobType == deterministicBlob)
+	if err != nil {
+		return bson.Binary{}, err
+	}
+
+	return bson.Binary{Subtype: encryptedSubtype, Data: append(ad, ciphertext...)}, nil
+}
+
+// Decrypt decrypts a value encrypted by Encrypt, or by any driver implementing client-side field
+// level encryption, with its data key from the key vault.
+func (ce *ClientEncryption) Decrypt(ctx context.Context, value bson.Binary) (*bson.Value, error) {
+	if value.Subtype != encryptedSubtype || len(value.Data) < 18 {
+		return nil, errors.New("value is not an encrypted binary")
+	}
+	if value.Data[0] != deterministicBlob && value.Data[0] != randomBlob {
+		return nil, fmt.Errorf("unsupported encrypted value type %d", value.Data[0])
+	}
+
+	ad := value.Data[:18]
+	_, dek, err := ce.dataKey(ctx, keyIDFilter(bson.Binary{Subtype: uuidSubtype, Data: ad[1:17]}))
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := crypt.Decrypt(dek, value.Data[18:], ad)
+	if err != nil {
+		return nil, err
+	}
+
+	// the plaintext is the value of an element with an empty key
+	doc := make([]byte, 4, 4+2+len(plaintext)+1)
+	doc = append(doc, ad[17], 0x00)
+	doc = append(doc, plaintext...)
+	doc = append(doc, 0x00)
+	doc[0], doc[1], doc[2], doc[3] = byte(len(doc)), byte(len(doc)>>8), byte(len(doc)>>16), byte(len(doc)>>24)
+
+	elem, err := bson.Reader(doc).Lookup("")
+	if err != nil {
+		return nil, err
+	}
+	return elem.Value(), nil
+}
+
+// dataKey finds the data key matching filter in the key vault and returns its id and decrypted key
+// material.
+func (ce *ClientEncryption) dataKey(ctx context.Context, filter *bson.Document) ([]byte, []byte, error) {
+	rdr, err := ce.keyVault.FindOne(ctx, filter).DecodeBytes()
+	if err == ErrNoDocuments {
+		return nil, nil, ErrDataKeyNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id, dek, _, err := ce.unwrapKey(ctx, rdr)
+	return id, dek, err
+}
+
+// unwrapKey decrypts the key material of the data key document key and returns its id, key material
+// and master key.
+func (ce *ClientEncryption) unwrapKey(ctx context.Context, key bson.Reader) ([]byte, []byte, *bson.Document, error) {
+	elem, err := key.Lookup("_id")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	subtype, id, ok := elem.Value().BinaryOK()
+	if !ok || subtype != uuidSubtype || len(id) != 16 {
+		return nil, nil, nil, errors.New("data key _id is not a UUID")
+	}
+
+	elem, err = key.Lookup("keyMaterial")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	_, keyMaterial, ok := elem.Value().BinaryOK()
+	if !ok {
+		return nil, nil, nil, errors.New("data key keyMaterial is not binary data")
+	}
+
+	elem, err = key.Lookup("masterKey")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	masterKeyRdr, ok := elem.Value().ReaderDocumentOK()
+	if !ok {
+		return nil, nil, nil, errors.New("data key masterKey is not a document")
+	}
+	masterKey, err := bson.ReadDocument(masterKeyRdr)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var provider string
+	if val, err := masterKey.LookupErr("provider"); err == nil {
+		provider, _ = val.StringValueOK()
+	}
+	kms, ok := ce.kms[provider]
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("KMS provider %q of the data key is not configured", provider)
+	}
+
+	dek, err := kms.UnwrapKey(ctx, masterKey, keyMaterial)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(dek) != crypt.KeyLen {
+		return nil, nil, nil, fmt.Errorf("data key must be %d bytes, got %d", crypt.KeyLen, len(dek))
+	}
+	return id, dek, masterKey, nil
+}
+
+// masterKeyDocument returns the masterKey document of a data key encrypted with the master key of
+// the named KMS provider described by masterKey.
+func masterKeyDocument(provider string, masterKey interface{}) (*bson.Document, error) {
+	doc := bson.NewDocument(bson.EC.String("provider", provider))
+	if masterKey == nil {
+		return doc, nil
+	}
+
+	mk, err := TransformDocument(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	itr := mk.Iterator()
+	for itr.Next() {
+		if itr.Element().Key() != "provider" {
+			doc.Append(itr.Element())
+		}
+	}
+	if err = itr.Err(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// keyIDFilter returns the filter that matches the data key with the given id.
+func keyIDFilter(id bson.Binary) *bson.Document {
+	return bson.NewDocument(bson.EC.BinaryWithSubtype("_id", id.Data, uuidSubtype))
+}
+
+// valueBytes returns the BSON type of value and its encoding without a type or key, which is the
+// plaintext that is encrypted.
+func valueBytes(value interface{}) (bson.Type, []byte, error) {
+	if elem, ok := value.(*bson.Element); ok {
+		value = elem.Value()
+	}
+	elem, err := bson.EC.InterfaceErr("", value)
+	if err != nil {
+		return 0, nil, err
+	}
+	b, err := bson.NewDocument(elem).MarshalBSON()
+	if err != nil {
+		return 0, nil, err
+	}
+	// the document is the length, the type, the empty key, the value and the terminating null
+	return bson.Type(b[4]), b[6 : len(b)-1], nil
+}
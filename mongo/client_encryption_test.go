@@ -0,0 +1,176 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/encryptopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+// keyVault serves the inserts and finds of a key vault collection from memory.
+type keyVault struct {
+	mu   sync.Mutex
+	keys []*bson.Document
+}
+
+func (kv *keyVault) insert(cmd *mongotest.Command) mongotest.Response {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	docs := cmd.Document.Lookup("documents").MutableArray()
+	for i := uint(0); i < uint(docs.Len()); i++ {
+		val, _ := docs.Lookup(i)
+		kv.keys = append(kv.keys, val.MutableDocument())
+	}
+	return mongotest.Response{Document: bson.NewDocument(bson.EC.Int32("n", int32(docs.Len())), bson.EC.Double("ok", 1))}
+}
+
+func (kv *keyVault) find(cmd *mongotest.Command) mongotest.Response {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	return mongotest.Cursor("encryption.__keyVault", kv.match(cmd.Document.Lookup("filter").MutableDocument())...)(cmd)
+}
+
+// match returns the keys matching filter, which is empty or matches on _id or keyAltNames.
+func (kv *keyVault) match(filter *bson.Document) []*bson.Document {
+	var found []*bson.Document
+	for _, key := range kv.keys {
+		if val, err := filter.LookupErr("_id"); err == nil {
+			_, want := val.Binary()
+			_, id := key.Lookup("_id").Binary()
+			if bytes.Equal(want, id) {
+				found = append(found, key)
+			}
+			continue
+		}
+		if val, err := filter.LookupErr("keyAltNames"); err == nil {
+			for _, name := range keyAltNames(key) {
+				if name == val.StringValue() {
+					found = append(found, key)
+				}
+			}
+			continue
+		}
+		found = append(found, key)
+	}
+	return found
+}
+
+// keyAltNames returns the alternate names of key.
+func keyAltNames(key *bson.Document) []string {
+	var names []string
+	if val, err := key.LookupErr("keyAltNames"); err == nil {
+		itr, _ := val.MutableArray().Iterator()
+		for itr.Next() {
+			names = append(names, itr.Value().StringValue())
+		}
+	}
+	return names
+}
+
+func TestClientEncryption(t *testing.T) {
+	d := mongotest.New()
+	kv := &keyVault{}
+	d.Handle("insert", kv.insert)
+	d.Handle("find", kv.find)
+
+	client := newMockClient(t, d)
+
+	localKey := bytes.Repeat([]byte{0x42}, 96)
+	ce, err := NewClientEncryption(client, "encryption.__keyVault", map[string]map[string]interface{}{
+		"local": {"key": localKey},
+	})
+	require.NoError(t, err)
+
+	keyID, err := ce.CreateDataKey(context.Background(), "local", encryptopt.KeyAltNames("pii"))
+	require.NoError(t, err)
+	require.Equal(t, byte(4), keyID.Subtype)
+	require.Len(t, keyID.Data, 16)
+
+	t.Run("key document", func(t *testing.T) {
+		insert := d.LastCommand("insert")
+		require.NotNil(t, insert)
+		require.Equal(t, "majority", insert.Document.Lookup("writeConcern", "w").StringValue())
+
+		require.Len(t, kv.keys, 1)
+		key := kv.keys[0]
+		require.Equal(t, "local", key.Lookup("masterKey", "provider").StringValue())
+		require.Equal(t, int32(0), key.Lookup("status").Int32())
+		subtype, material := key.Lookup("keyMaterial").Binary()
+		require.Equal(t, byte(0), subtype)
+		require.NotEmpty(t, material)
+		require.NotNil(t, key.Lookup("creationDate"))
+		require.NotNil(t, key.Lookup("updateDate"))
+	})
+	t.Run("round trip", func(t *testing.T) {
+		for _, value := range []interface{}{"123-45-6789", int32(42), int64(1) << 40, bson.NewDocument(bson.EC.String("a", "b"))} {
+			opts := []encryptopt.Encrypt{encryptopt.Algorithm(encryptopt.AEADRandom), encryptopt.KeyAltName("pii")}
+			encrypted, err := ce.Encrypt(context.Background(), value, opts...)
+			require.NoError(t, err)
+			require.Equal(t, byte(6), encrypted.Subtype)
+			require.Equal(t, byte(2), encrypted.Data[0])
+			require.Equal(t, keyID.Data, encrypted.Data[1:17])
+
+			decrypted, err := ce.Decrypt(context.Background(), encrypted)
+			require.NoError(t, err)
+			typ, expected, err := valueBytes(value)
+			require.NoError(t, err)
+			require.Equal(t, typ, decrypted.Type())
+			_, actual, err := valueBytes(decrypted)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		}
+
+		find := d.LastCommand("find")
+		require.NotNil(t, find)
+		require.Equal(t, "majority", find.Document.Lookup("readConcern", "level").StringValue())
+	})
+	t.Run("deterministic", func(t *testing.T) {
+		opts := encryptopt.BundleEncrypt().Algorithm(encryptopt.AEADDeterministic).KeyID(keyID)
+		first, err := ce.Encrypt(context.Background(), "123-45-6789", opts)
+		require.NoError(t, err)
+		second, err := ce.Encrypt(context.Background(), "123-45-6789", opts)
+		require.NoError(t, err)
+		require.Equal(t, first, second)
+		require.Equal(t, byte(1), first.Data[0])
+		require.Equal(t, byte(bson.TypeString), first.Data[17])
+
+		_, err = ce.Encrypt(context.Background(), true, opts)
+		require.Error(t, err)
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := ce.Encrypt(context.Background(), "x", encryptopt.KeyID(keyID))
+		require.Error(t, err)
+		_, err = ce.Encrypt(context.Background(), "x", encryptopt.Algorithm(encryptopt.AEADRandom))
+		require.Error(t, err)
+		_, err = ce.Encrypt(context.Background(), nil, encryptopt.Algorithm(encryptopt.AEADRandom), encryptopt.KeyID(keyID))
+		require.Error(t, err)
+		_, err = ce.Encrypt(context.Background(), "x", encryptopt.Algorithm(encryptopt.AEADRandom), encryptopt.KeyAltName("missing"))
+		require.Equal(t, ErrDataKeyNotFound, err)
+
+		encrypted, err := ce.Encrypt(context.Background(), "x", encryptopt.Algorithm(encryptopt.AEADRandom), encryptopt.KeyID(keyID))
+		require.NoError(t, err)
+		encrypted.Data[17] = byte(bson.TypeInt32)
+		_, err = ce.Decrypt(context.Background(), encrypted)
+		require.Error(t, err)
+
+		_, err = ce.CreateDataKey(context.Background(), "aws")
+		require.Error(t, err)
+		_, err = NewClientEncryption(client, "novault", map[string]map[string]interface{}{"local": {"key": localKey}})
+		require.Error(t, err)
+		_, err = NewClientEncryption(client, "encryption.__keyVault", map[string]map[string]interface{}{"local": {"key": localKey[:32]}})
+		require.Error(t, err)
+	})
+}
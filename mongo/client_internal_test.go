@@ -15,6 +15,8 @@ import (
 	"fmt"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/auth"
+	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/tag"
 	"github.com/mongodb/mongo-go-driver/internal/testutil"
@@ -24,8 +26,10 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
 	"github.com/mongodb/mongo-go-driver/mongo/clientopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
 	"github.com/mongodb/mongo-go-driver/mongo/sessionopt"
 )
 
@@ -40,6 +44,19 @@ func createTestClient(t *testing.T) *Client {
 	}
 }
 
+// newMockClient returns a client connected to the mock deployment d. At the end of the test the
+// client is disconnected and then d is closed, so that the sessions of the client can be ended. The
+// options opts are applied after those of the deployment.
+func newMockClient(t *testing.T, d *mongotest.Deployment, opts ...clientopt.Option) *Client {
+	t.Helper()
+	t.Cleanup(d.Close)
+	client, err := NewClientWithOptions(d.URI(), append([]clientopt.Option{d.ClientOptions()}, opts...)...)
+	require.NoError(t, err)
+	require.NoError(t, client.Connect(context.Background()))
+	t.Cleanup(func() { _ = client.Disconnect(context.Background()) })
+	return client
+}
+
 func TestNewClient(t *testing.T) {
 	t.Parallel()
 
@@ -431,3 +448,63 @@ func TestClient_Ping_InvalidHost(t *testing.T) {
 	err = c.Ping(ctx, nil)
 	require.NotNil(t, err)
 }
+
+func TestClient_SupportsFeature(t *testing.T) {
+	d := mongotest.New(mongotest.WithMaxWireVersion(6))
+	c := newMockClient(t, d)
+
+	supported, err := c.SupportsFeature(ctx, description.FeatureArrayFilters, nil)
+	require.NoError(t, err)
+	require.True(t, supported)
+
+	supported, err = c.SupportsFeature(ctx, description.FeatureLet, readpref.PrimaryPreferred())
+	require.NoError(t, err)
+	require.False(t, supported)
+}
+
+// tokenSaslClient is the client of a SASL mechanism that sends a token taken from the
+// authMechanismProperties.
+type tokenSaslClient struct {
+	token string
+}
+
+func (c *tokenSaslClient) Start() (string, []byte, error) { return "X-TOKEN", []byte(c.token), nil }
+func (c *tokenSaslClient) Next([]byte) ([]byte, error)    { return nil, nil }
+func (c *tokenSaslClient) Completed() bool                { return true }
+
+type tokenAuthenticator struct {
+	cred *auth.Cred
+}
+
+func (a *tokenAuthenticator) Auth(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) error {
+	return auth.ConductSaslConversation(ctx, desc, rw, a.cred.Source, &tokenSaslClient{token: a.cred.Props["TOKEN"]})
+}
+
+func init() {
+	auth.RegisterAuthenticatorFactory("X-TOKEN", func(cred *auth.Cred) (auth.Authenticator, error) {
+		return &tokenAuthenticator{cred: cred}, nil
+	})
+}
+
+func TestClient_CustomAuthMechanism(t *testing.T) {
+	d := mongotest.New()
+	defer d.Close()
+	d.Handle("saslStart", mongotest.OK(bson.EC.Int32("conversationId", 1), bson.EC.Boolean("done", true)))
+	d.Handle("ping", mongotest.OK())
+
+	uri := d.URI() + "/app?authMechanism=x-token&authMechanismProperties=TOKEN:s3cret"
+	c, err := NewClientWithOptions(uri, d.ClientOptions())
+	require.NoError(t, err)
+	require.NoError(t, c.Connect(ctx))
+	defer func() { _ = c.Disconnect(ctx) }()
+
+	_, err = c.Database("app").RunCommand(ctx, bson.NewDocument(bson.EC.Int32("ping", 1)))
+	require.NoError(t, err)
+
+	start := d.LastCommand("saslStart")
+	require.NotNil(t, start, "no saslStart command sent")
+	require.Equal(t, "app", start.Database)
+	require.Equal(t, "X-TOKEN", start.Document.Lookup("mechanism").StringValue())
+	_, payload := start.Document.Lookup("payload").Binary()
+	require.Equal(t, "s3cret", string(payload))
+}
@@ -8,12 +8,14 @@ package mongo
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"sync/atomic"
 	"testing"
 
 	"time"
 
+	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/connstring"
 	"github.com/mongodb/mongo-go-driver/core/readconcern"
 	"github.com/mongodb/mongo-go-driver/core/readpref"
@@ -21,6 +23,9 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
 	"github.com/mongodb/mongo-go-driver/internal/testutil"
 	"github.com/mongodb/mongo-go-driver/mongo/clientopt"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+	"github.com/mongodb/mongo-go-driver/mongo/insertopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -101,8 +106,10 @@ func TestClientOptions_chainAll(t *testing.T) {
 			"mongodb://localhost:27019"}).
 		LocalThreshold(time.Second).
 		MaxConnIdleTime(30 * time.Second).
+		MaxConnecting(4).
 		MaxConnsPerHost(150).
 		MaxIdleConnsPerHost(20).
+		MinPoolSize(10).
 		ReadConcern(rc).
 		ReadPreference(rp).
 		ReplicaSet("foo").
@@ -140,10 +147,14 @@ func TestClientOptions_chainAll(t *testing.T) {
 			LocalThreshold:                     time.Second,
 			MaxConnIdleTime:                    30 * time.Second,
 			MaxConnIdleTimeSet:                 true,
+			MaxConnecting:                      4,
+			MaxConnectingSet:                   true,
 			MaxConnsPerHost:                    150,
 			MaxConnsPerHostSet:                 true,
 			MaxIdleConnsPerHost:                20,
 			MaxIdleConnsPerHostSet:             true,
+			MinPoolSize:                        10,
+			MinPoolSizeSet:                     true,
 			ReplicaSet:                         "foo",
 			ServerSelectionTimeoutSet:          true,
 			ServerSelectionTimeout:             time.Second,
@@ -199,3 +210,90 @@ func (td *testDialer) DialContext(ctx context.Context, network, address string)
 	atomic.AddInt32(&td.called, 1)
 	return td.d.DialContext(ctx, network, address)
 }
+
+func TestClientOptions_comment(t *testing.T) {
+	connect := func(t *testing.T, opts ...mongotest.Option) (*mongotest.Deployment, *Collection) {
+		d := mongotest.New(opts...)
+		d.Handle("insert", mongotest.OK(bson.EC.Int32("n", 1)))
+		d.Handle("find", openCursor("firstBatch"))
+		d.Handle("getMore", openCursor("nextBatch"))
+		d.Handle("killCursors", mongotest.OK())
+
+		comment := bson.NewDocument(bson.EC.String("app", "reports"))
+		client := newMockClient(t, d, clientopt.Comment(comment))
+		return d, client.Database("db").Collection("coll")
+	}
+	doc := bson.NewDocument(bson.EC.Int32("x", 1))
+
+	t.Run("default and override", func(t *testing.T) {
+		d, coll := connect(t)
+
+		_, err := coll.InsertOne(context.Background(), doc)
+		require.NoError(t, err)
+		require.Equal(t, "reports", d.LastCommand("insert").Document.Lookup("comment", "app").StringValue())
+
+		cur, err := coll.Find(context.Background(), nil, findopt.Comment(int32(7)))
+		require.NoError(t, err)
+		defer func() { _ = cur.Close(context.Background()) }()
+		require.True(t, cur.Next(context.Background()))
+		require.True(t, cur.Next(context.Background()))
+		require.Equal(t, int32(7), d.LastCommand("find").Document.Lookup("comment").Int32())
+		require.Equal(t, int32(7), d.LastCommand("getMore").Document.Lookup("comment").Int32())
+	})
+	t.Run("before 4.4", func(t *testing.T) {
+		d, coll := connect(t, mongotest.WithMaxWireVersion(8))
+
+		_, err := coll.InsertOne(context.Background(), doc)
+		require.NoError(t, err)
+		_, err = d.LastCommand("insert").Document.LookupErr("comment")
+		require.Error(t, err)
+
+		cur, err := coll.Find(context.Background(), nil)
+		require.NoError(t, err)
+		require.NoError(t, cur.Close(context.Background()))
+		_, err = d.LastCommand("find").Document.LookupErr("comment")
+		require.Error(t, err)
+
+		cur, err = coll.Find(context.Background(), nil, findopt.Comment("report"))
+		require.NoError(t, err)
+		require.NoError(t, cur.Close(context.Background()))
+		require.Equal(t, "report", d.LastCommand("find").Document.Lookup("comment").StringValue())
+
+		inserts := len(d.Commands())
+		_, err = coll.InsertOne(context.Background(), doc, insertopt.Comment("report"))
+		require.Error(t, err)
+		require.Len(t, d.Commands(), inserts)
+	})
+}
+
+func TestClientOptions_retryWrites(t *testing.T) {
+	for _, retry := range []bool{true, false} {
+		t.Run(fmt.Sprint(retry), func(t *testing.T) {
+			d := mongotest.New(mongotest.WithReplicaSet("rs"))
+			d.Handle("insert", mongotest.Sequence(mongotest.NotMaster(), mongotest.OK(bson.EC.Int32("n", 1))))
+			client := newMockClient(t, d, clientopt.RetryWrites(retry))
+
+			_, err := client.Database("db").Collection("coll").InsertOne(context.Background(),
+				bson.NewDocument(bson.EC.Int32("x", 1)))
+			if retry {
+				require.NoError(t, err)
+				require.Equal(t, 2, d.CountCommands("insert"))
+			} else {
+				require.Error(t, err)
+				require.Equal(t, 1, d.CountCommands("insert"))
+			}
+		})
+	}
+}
+
+func TestClientOptions_nilLogger(t *testing.T) {
+	d := mongotest.New(mongotest.WithReplicaSet("rs"))
+	d.Handle("insert", mongotest.Sequence(mongotest.NotMaster(), mongotest.OK(bson.EC.Int32("n", 1))))
+	client := newMockClient(t, d, clientopt.Logger(nil), clientopt.RetryWrites(true))
+
+	// the insert is retried, which is logged
+	_, err := client.Database("db").Collection("coll").InsertOne(context.Background(),
+		bson.NewDocument(bson.EC.Int32("x", 1)))
+	require.NoError(t, err)
+	require.Equal(t, 2, d.CountCommands("insert"))
+}
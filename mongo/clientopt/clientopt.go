@@ -8,16 +8,20 @@ package clientopt
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"time"
 
 	"reflect"
 
+	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/connstring"
 	"github.com/mongodb/mongo-go-driver/core/event"
+	"github.com/mongodb/mongo-go-driver/core/logger"
 	"github.com/mongodb/mongo-go-driver/core/readconcern"
 	"github.com/mongodb/mongo-go-driver/core/readpref"
+	"github.com/mongodb/mongo-go-driver/core/serverapi"
 	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
 )
@@ -61,7 +65,8 @@ type SSLOpt struct {
 // Credential holds auth options.
 //
 // AuthMechanism indicates the mechanism to use for authentication.
-// Supported values include "SCRAM-SHA-256", "SCRAM-SHA-1", "MONGODB-CR", "PLAIN", "GSSAPI", and "MONGODB-X509".
+// Supported values include "SCRAM-SHA-256", "SCRAM-SHA-1", "MONGODB-CR", "PLAIN", "GSSAPI", and "MONGODB-X509",
+// and the mechanisms registered with auth.RegisterAuthenticatorFactory.
 //
 // AuthMechanismProperties specifies additional configuration options which may be used by certain
 // authentication mechanisms.
@@ -88,6 +93,8 @@ type Client struct {
 	ReadPreference  *readpref.ReadPref
 	ReadConcern     *readconcern.ReadConcern
 	WriteConcern    *writeconcern.WriteConcern
+	Registry        *bson.Registry
+	Comment         interface{}
 }
 
 // ClientBundle is a bundle of client options
@@ -180,6 +187,14 @@ func (cb *ClientBundle) LocalThreshold(d time.Duration) *ClientBundle {
 	}
 }
 
+// Logger specifies the logger used by the client.
+func (cb *ClientBundle) Logger(l logger.Logger) *ClientBundle {
+	return &ClientBundle{
+		option: Logger(l),
+		next:   cb,
+	}
+}
+
 // MaxConnIdleTime specifies the maximum number of milliseconds that a connection can remain idle
 // in a connection pool before being removed and closed.
 func (cb *ClientBundle) MaxConnIdleTime(d time.Duration) *ClientBundle {
@@ -189,6 +204,15 @@ func (cb *ClientBundle) MaxConnIdleTime(d time.Duration) *ClientBundle {
 	}
 }
 
+// MaxConnecting specifies the maximum number of connections established concurrently while a
+// server's connection pool is filled up to its minimum size. The default is 2.
+func (cb *ClientBundle) MaxConnecting(u uint16) *ClientBundle {
+	return &ClientBundle{
+		option: MaxConnecting(u),
+		next:   cb,
+	}
+}
+
 // MaxConnsPerHost specifies the max size of a server's connection pool.
 func (cb *ClientBundle) MaxConnsPerHost(u uint16) *ClientBundle {
 	return &ClientBundle{
@@ -206,6 +230,15 @@ func (cb *ClientBundle) MaxIdleConnsPerHost(u uint16) *ClientBundle {
 	}
 }
 
+// MinPoolSize specifies the number of idle connections established to each server as soon as the
+// client connects to it, so that the first operations do not wait for connections to be dialed.
+func (cb *ClientBundle) MinPoolSize(u uint16) *ClientBundle {
+	return &ClientBundle{
+		option: MinPoolSize(u),
+		next:   cb,
+	}
+}
+
 // Monitor specifies a command monitor for this client.
 func (cb *ClientBundle) Monitor(m *event.CommandMonitor) *ClientBundle {
 	return &ClientBundle{
@@ -214,6 +247,23 @@ func (cb *ClientBundle) Monitor(m *event.CommandMonitor) *ClientBundle {
 	}
 }
 
+// PoolMonitor specifies a monitor for the events of the connection pools of this client.
+func (cb *ClientBundle) PoolMonitor(m *event.PoolMonitor) *ClientBundle {
+	return &ClientBundle{
+		option: PoolMonitor(m),
+		next:   cb,
+	}
+}
+
+// PooledReplies specifies whether replies are read into pooled buffers. See PooledReplies for the
+// ownership rule this implies.
+func (cb *ClientBundle) PooledReplies(b bool) *ClientBundle {
+	return &ClientBundle{
+		option: PooledReplies(b),
+		next:   cb,
+	}
+}
+
 // ReadConcern specifies the read concern.
 func (cb *ClientBundle) ReadConcern(rc *readconcern.ReadConcern) *ClientBundle {
 	return &ClientBundle{
@@ -222,6 +272,14 @@ func (cb *ClientBundle) ReadConcern(rc *readconcern.ReadConcern) *ClientBundle {
 	}
 }
 
+// Comment specifies the comment sent with the operations that are not passed one.
+func (cb *ClientBundle) Comment(comment interface{}) *ClientBundle {
+	return &ClientBundle{
+		option: Comment(comment),
+		next:   cb,
+	}
+}
+
 // ReadPreference specifies the read preference.
 func (cb *ClientBundle) ReadPreference(rp *readpref.ReadPref) *ClientBundle {
 	return &ClientBundle{
@@ -230,6 +288,23 @@ func (cb *ClientBundle) ReadPreference(rp *readpref.ReadPref) *ClientBundle {
 	}
 }
 
+// RedactDocumentValues specifies whether the values of the documents of monitored and logged
+// commands are redacted. See RedactDocumentValues for what is kept.
+func (cb *ClientBundle) RedactDocumentValues(b bool) *ClientBundle {
+	return &ClientBundle{
+		option: RedactDocumentValues(b),
+		next:   cb,
+	}
+}
+
+// Registry specifies the registry used to encode documents and decode results.
+func (cb *ClientBundle) Registry(r *bson.Registry) *ClientBundle {
+	return &ClientBundle{
+		option: Registry(r),
+		next:   cb,
+	}
+}
+
 // ReplicaSet specifies the name of the replica set of the cluster.
 func (cb *ClientBundle) ReplicaSet(s string) *ClientBundle {
 	return &ClientBundle{
@@ -238,6 +313,14 @@ func (cb *ClientBundle) ReplicaSet(s string) *ClientBundle {
 	}
 }
 
+// RequestIDPrefix specifies the prefix of the request IDs of the wire messages sent by the client.
+func (cb *ClientBundle) RequestIDPrefix(prefix uint8) *ClientBundle {
+	return &ClientBundle{
+		option: RequestIDPrefix(prefix),
+		next:   cb,
+	}
+}
+
 // RetryWrites specifies whether the client has retryable writes enabled.
 func (cb *ClientBundle) RetryWrites(b bool) *ClientBundle {
 	return &ClientBundle{
@@ -246,6 +329,14 @@ func (cb *ClientBundle) RetryWrites(b bool) *ClientBundle {
 	}
 }
 
+// ServerAPIOptions specifies the server API declared for every command sent by the client.
+func (cb *ClientBundle) ServerAPIOptions(opts *serverapi.Options) *ClientBundle {
+	return &ClientBundle{
+		option: ServerAPIOptions(opts),
+		next:   cb,
+	}
+}
+
 // ServerSelectionTimeout specifies a timeout in milliseconds to block for server selection.
 func (cb *ClientBundle) ServerSelectionTimeout(d time.Duration) *ClientBundle {
 	return &ClientBundle{
@@ -280,6 +371,24 @@ func (cb *ClientBundle) SSL(ssl *SSLOpt) *ClientBundle {
 	}
 }
 
+// Timeout specifies the default time limit of each operation, including server selection,
+// connection checkout, retries and the commands it runs.
+func (cb *ClientBundle) Timeout(d time.Duration) *ClientBundle {
+	return &ClientBundle{
+		option: Timeout(d),
+		next:   cb,
+	}
+}
+
+// WaitQueueTimeout specifies the maximum amount of time an operation waits for a connection of a
+// full connection pool.
+func (cb *ClientBundle) WaitQueueTimeout(d time.Duration) *ClientBundle {
+	return &ClientBundle{
+		option: WaitQueueTimeout(d),
+		next:   cb,
+	}
+}
+
 // WriteConcern specifies the write concern.
 func (cb *ClientBundle) WriteConcern(wc *writeconcern.WriteConcern) *ClientBundle {
 	return &ClientBundle{
@@ -482,6 +591,20 @@ func LocalThreshold(d time.Duration) Option {
 		})
 }
 
+// Logger specifies the logger used to log connection lifecycle events, server selection, topology
+// changes, retries and, at debug level, commands. Logging is disabled by default and by a nil
+// logger.
+func Logger(l logger.Logger) Option {
+	return optionFunc(
+		func(c *Client) error {
+			c.TopologyOptions = append(
+				c.TopologyOptions,
+				topology.WithLogger(func(logger.Logger) logger.Logger { return l }),
+			)
+			return nil
+		})
+}
+
 // MaxConnIdleTime specifies the maximum number of milliseconds that a connection can remain idle
 // in a connection pool before being removed and closed.
 func MaxConnIdleTime(d time.Duration) Option {
@@ -495,6 +618,19 @@ func MaxConnIdleTime(d time.Duration) Option {
 		})
 }
 
+// MaxConnecting specifies the maximum number of connections established concurrently while a
+// server's connection pool is filled up to its minimum size. The default is 2.
+func MaxConnecting(u uint16) Option {
+	return optionFunc(
+		func(c *Client) error {
+			if !c.ConnString.MaxConnectingSet {
+				c.ConnString.MaxConnecting = u
+				c.ConnString.MaxConnectingSet = true
+			}
+			return nil
+		})
+}
+
 // MaxConnsPerHost specifies the max size of a server's connection pool.
 func MaxConnsPerHost(u uint16) Option {
 	return optionFunc(
@@ -520,6 +656,63 @@ func MaxIdleConnsPerHost(u uint16) Option {
 		})
 }
 
+// MinPoolSize specifies the number of idle connections established to each server as soon as the
+// client connects to it, so that the first operations do not wait for connections to be dialed.
+func MinPoolSize(u uint16) Option {
+	return optionFunc(
+		func(c *Client) error {
+			if !c.ConnString.MinPoolSizeSet {
+				c.ConnString.MinPoolSize = u
+				c.ConnString.MinPoolSizeSet = true
+			}
+			return nil
+		})
+}
+
+// PoolMonitor specifies a monitor used to see the events of the connection pools of a client, such
+// as the connection checkouts that failed.
+func PoolMonitor(m *event.PoolMonitor) Option {
+	return optionFunc(
+		func(c *Client) error {
+			c.TopologyOptions = append(
+				c.TopologyOptions,
+				topology.WithServerOptions(func(opts ...topology.ServerOption) []topology.ServerOption {
+					return append(
+						opts,
+						topology.WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+							return append(
+								opts,
+								connection.WithPoolMonitor(func(*event.PoolMonitor) *event.PoolMonitor {
+									return m
+								}),
+							)
+						}),
+					)
+				}),
+			)
+			return nil
+		})
+}
+
+// PooledReplies specifies whether the documents of replies are read into buffers that are reused
+// across operations rather than freshly allocated for every reply, which reduces allocations when
+// iterating large cursors. A cursor returns the buffer holding its current batch when it moves on
+// to the next batch or is closed, so documents returned by DecodeBytes and Batch must be copied to
+// be retained beyond that point. Documents decoded with Decode are always copied. The default is
+// false, in which case documents returned by a cursor remain valid for as long as they are used.
+func PooledReplies(b bool) Option {
+	return optionFunc(
+		func(c *Client) error {
+			c.TopologyOptions = append(
+				c.TopologyOptions,
+				topology.WithServerOptions(func(opts ...topology.ServerOption) []topology.ServerOption {
+					return append(opts, topology.WithPooledReplies(func(bool) bool { return b }))
+				}),
+			)
+			return nil
+		})
+}
+
 // ReadConcern specifies the read concern.
 func ReadConcern(rc *readconcern.ReadConcern) Option {
 	return optionFunc(func(c *Client) error {
@@ -530,6 +723,21 @@ func ReadConcern(rc *readconcern.ReadConcern) Option {
 	})
 }
 
+// Comment specifies a value of any BSON type sent as the comment of the find, aggregate, insert,
+// update, delete, findAndModify, count and distinct commands that are not passed a Comment option,
+// to attribute them in the database profiler, currentOp, and logs. It is left out of the commands
+// servers older than 4.4 do not accept it with: those other than find and aggregate, and all
+// commands if it is not a string.
+func Comment(comment interface{}) Option {
+	return optionFunc(
+		func(c *Client) error {
+			if c.Comment == nil {
+				c.Comment = comment
+			}
+			return nil
+		})
+}
+
 // ReadPreference specifies the read preference
 func ReadPreference(rp *readpref.ReadPref) Option {
 	return optionFunc(
@@ -541,6 +749,44 @@ func ReadPreference(rp *readpref.ReadPref) Option {
 		})
 }
 
+// RedactDocumentValues specifies whether the values of the documents of the commands and replies
+// reported to the command monitor and logged are redacted, for applications whose documents hold
+// personal or otherwise sensitive data. The keys of the documents are kept, and their values are
+// replaced by event.RedactedValue except for the command name, the database and the status of
+// replies, as are the error messages of failed commands. The default is false. The commands
+// carrying credentials, like those authenticating and creating users, are always fully redacted.
+func RedactDocumentValues(b bool) Option {
+	return optionFunc(
+		func(c *Client) error {
+			c.TopologyOptions = append(
+				c.TopologyOptions,
+				topology.WithServerOptions(func(opts ...topology.ServerOption) []topology.ServerOption {
+					return append(
+						opts,
+						topology.WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+							return append(
+								opts,
+								connection.WithRedactDocumentValues(func(bool) bool { return b }),
+							)
+						}),
+					)
+				}),
+			)
+			return nil
+		})
+}
+
+// Registry specifies the registry used to encode documents and decode results.
+func Registry(r *bson.Registry) Option {
+	return optionFunc(
+		func(c *Client) error {
+			if c.Registry == nil {
+				c.Registry = r
+			}
+			return nil
+		})
+}
+
 // ReplicaSet specifies the name of the replica set of the cluster.
 func ReplicaSet(s string) Option {
 	return optionFunc(
@@ -552,6 +798,36 @@ func ReplicaSet(s string) Option {
 		})
 }
 
+// RequestIDPrefix specifies the prefix of the request IDs of the wire messages sent by the client.
+// Each connection numbers the wire messages it sends from 1 in the low 24 bits of their request IDs,
+// which are reported by command monitoring events, command spans and server logs. The prefix fills
+// the bits above them, so that applications given different prefixes, such as the instances of a
+// service, do not send the same request IDs to a server. The prefix must be at most
+// connection.MaxRequestIDPrefix, which is 127. The default is 0.
+func RequestIDPrefix(prefix uint8) Option {
+	return optionFunc(
+		func(c *Client) error {
+			if prefix > connection.MaxRequestIDPrefix {
+				return fmt.Errorf("request ID prefix %d is greater than the maximum of %d", prefix, connection.MaxRequestIDPrefix)
+			}
+			c.TopologyOptions = append(
+				c.TopologyOptions,
+				topology.WithServerOptions(func(opts ...topology.ServerOption) []topology.ServerOption {
+					return append(
+						opts,
+						topology.WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+							return append(
+								opts,
+								connection.WithRequestIDPrefix(func(uint8) uint8 { return prefix }),
+							)
+						}),
+					)
+				}),
+			)
+			return nil
+		})
+}
+
 // RetryWrites specifies whether the client has retryable writes enabled.
 func RetryWrites(b bool) Option {
 	return optionFunc(
@@ -564,6 +840,20 @@ func RetryWrites(b bool) Option {
 		})
 }
 
+// ServerAPIOptions specifies the server API declared for every command sent by the client,
+// including the connection handshake, authentication, getMore and killCursors. Commands run with
+// RunCommand must not set apiVersion, apiStrict or apiDeprecationErrors themselves.
+func ServerAPIOptions(opts *serverapi.Options) Option {
+	return optionFunc(
+		func(c *Client) error {
+			c.TopologyOptions = append(
+				c.TopologyOptions,
+				topology.WithServerAPI(func(*serverapi.Options) *serverapi.Options { return opts }),
+			)
+			return nil
+		})
+}
+
 // ServerSelectionTimeout specifies a timeout in milliseconds to block for server selection.
 func ServerSelectionTimeout(d time.Duration) Option {
 	return optionFunc(
@@ -635,6 +925,34 @@ func SSL(ssl *SSLOpt) Option {
 		})
 }
 
+// Timeout specifies the default time limit of each operation, including server selection,
+// connection checkout, retries and the commands it runs. A single operation can override it with
+// csot.WithTimeout.
+func Timeout(d time.Duration) Option {
+	return optionFunc(
+		func(c *Client) error {
+			if !c.ConnString.TimeoutSet {
+				c.ConnString.Timeout = d
+				c.ConnString.TimeoutSet = true
+			}
+			return nil
+		})
+}
+
+// WaitQueueTimeout specifies the maximum amount of time an operation waits for a connection of a
+// full connection pool to be returned before failing with a connection.WaitQueueTimeoutError, which
+// reports the statistics of the pool. By default, operations wait until their context is done.
+func WaitQueueTimeout(d time.Duration) Option {
+	return optionFunc(
+		func(c *Client) error {
+			if !c.ConnString.WaitQueueTimeoutSet {
+				c.ConnString.WaitQueueTimeout = d
+				c.ConnString.WaitQueueTimeoutSet = true
+			}
+			return nil
+		})
+}
+
 // WriteConcern sets the write concern.
 func WriteConcern(wc *writeconcern.WriteConcern) Option {
 	return optionFunc(
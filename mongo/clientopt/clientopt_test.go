@@ -9,6 +9,7 @@ package clientopt
 import (
 	"testing"
 
+	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/connstring"
 	"github.com/mongodb/mongo-go-driver/internal/testutil/helpers"
 )
@@ -127,4 +128,14 @@ func TestClientOpt(t *testing.T) {
 			})
 		}
 	})
+	t.Run("RequestIDPrefix", func(t *testing.T) {
+		client, err := BundleClient(RequestIDPrefix(connection.MaxRequestIDPrefix)).Unbundle(connstring.ConnString{})
+		testhelpers.RequireNil(t, err, "err unbundling client: %s", err)
+		if len(client.TopologyOptions) != 1 {
+			t.Errorf("expected 1 topology option, got %d", len(client.TopologyOptions))
+		}
+
+		_, err = BundleClient(RequestIDPrefix(connection.MaxRequestIDPrefix + 1)).Unbundle(connstring.ConnString{})
+		testhelpers.RequireNotNil(t, err, "expected an error for a prefix greater than %d", connection.MaxRequestIDPrefix)
+	})
 }
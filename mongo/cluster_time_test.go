@@ -0,0 +1,75 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterTimeGossip(t *testing.T) {
+	d := mongotest.New(mongotest.WithReplicaSet("rs"))
+
+	client := newMockClient(t, d)
+
+	coll := client.Database("db").Collection("coll")
+	ctx := context.Background()
+	// inserted replies to an insert with the cluster time ts
+	inserted := func(ts uint32) mongotest.Handler {
+		return mongotest.OK(bson.EC.Int32("n", 1), bson.EC.SubDocumentFromElements("$clusterTime",
+			bson.EC.Timestamp("clusterTime", ts, 1),
+			bson.EC.SubDocumentFromElements("signature",
+				bson.EC.Binary("hash", make([]byte, 20)),
+				bson.EC.Int64("keyId", 0),
+			),
+		))
+	}
+	d.Handle("insert", mongotest.Sequence(inserted(100), inserted(200)))
+	d.Handle("find", mongotest.Cursor("db.coll"))
+	d.Handle("commitTransaction", mongotest.OK())
+
+	// sent returns the cluster time carried by the last command named name.
+	sent := func(name string) uint32 {
+		cmd := d.LastCommand(name)
+		require.NotNil(t, cmd, "no %s command was sent", name)
+		v, err := cmd.Document.LookupErr("$clusterTime", "clusterTime")
+		require.NoError(t, err, "expected %s to carry a cluster time", name)
+		ts, _ := v.Timestamp()
+		return ts
+	}
+
+	writer, err := client.StartSession()
+	require.NoError(t, err)
+	defer writer.EndSession(ctx)
+	reader, err := client.StartSession()
+	require.NoError(t, err)
+	defer reader.EndSession(ctx)
+
+	_, err = coll.InsertOne(ctx, bson.NewDocument(bson.EC.Int32("_id", 1)), writer)
+	require.NoError(t, err)
+
+	// the read in another session carries the cluster time of the write
+	cur, err := coll.Find(ctx, nil, reader)
+	require.NoError(t, err)
+	require.NoError(t, cur.Close(ctx))
+	require.Equal(t, uint32(100), sent("find"))
+
+	// a transaction is committed with the latest cluster time of the client, which the session has
+	// not seen
+	require.NoError(t, reader.StartTransaction())
+	cur, err = coll.Find(ctx, nil, reader)
+	require.NoError(t, err)
+	require.NoError(t, cur.Close(ctx))
+	_, err = coll.InsertOne(ctx, bson.NewDocument(bson.EC.Int32("_id", 2)), writer)
+	require.NoError(t, err)
+	require.NoError(t, reader.CommitTransaction(ctx))
+	require.Equal(t, uint32(200), sent("commitTransaction"))
+}
@@ -9,8 +9,8 @@ package mongo
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
-	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/command"
@@ -24,6 +24,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/mongo/aggregateopt"
 	"github.com/mongodb/mongo-go-driver/mongo/changestreamopt"
 	"github.com/mongodb/mongo-go-driver/mongo/collectionopt"
+	"github.com/mongodb/mongo-go-driver/mongo/collmodopt"
 	"github.com/mongodb/mongo-go-driver/mongo/countopt"
 	"github.com/mongodb/mongo-go-driver/mongo/deleteopt"
 	"github.com/mongodb/mongo-go-driver/mongo/distinctopt"
@@ -34,7 +35,6 @@ import (
 	"github.com/mongodb/mongo-go-driver/mongo/updateopt"
 
 	"github.com/mongodb/mongo-go-driver/internal/observability"
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 )
@@ -47,8 +47,15 @@ type Collection struct {
 	readConcern    *readconcern.ReadConcern
 	writeConcern   *writeconcern.WriteConcern
 	readPreference *readpref.ReadPref
+	registry       *bson.Registry
 	readSelector   description.ServerSelector
 	writeSelector  description.ServerSelector
+	// selector is the selector supplied with the ServerSelector option of the collection or its
+	// database, if any.
+	selector description.ServerSelector
+	// nameErr is the reason the namespace of the collection is not valid, if it is not. Operations
+	// against the collection fail with it before selecting a server.
+	nameErr error
 }
 
 func newCollection(db *Database, name string, opts ...collectionopt.Option) *Collection {
@@ -72,10 +79,15 @@ func newCollection(db *Database, name string, opts ...collectionopt.Option) *Col
 		rp = collOpt.ReadPreference
 	}
 
-	readSelector := description.CompositeSelector([]description.ServerSelector{
-		description.ReadPrefSelector(rp),
-		description.LatencySelector(db.client.localThreshold),
-	})
+	reg := db.registry
+	if collOpt.Registry != nil {
+		reg = collOpt.Registry
+	}
+
+	selector := db.selector
+	if collOpt.ServerSelector != nil {
+		selector = collOpt.ServerSelector
+	}
 
 	coll := &Collection{
 		client:         db.client,
@@ -84,9 +96,14 @@ func newCollection(db *Database, name string, opts ...collectionopt.Option) *Col
 		readPreference: rp,
 		readConcern:    rc,
 		writeConcern:   wc,
-		readSelector:   readSelector,
-		writeSelector:  db.writeSelector,
+		registry:       reg,
+		selector:       selector,
+		nameErr:        db.nameErr,
+	}
+	if coll.nameErr == nil {
+		coll.nameErr = command.ValidateCollectionName(name)
 	}
+	coll.setSelectors()
 
 	return coll
 }
@@ -99,9 +116,42 @@ func (coll *Collection) copy() *Collection {
 		readConcern:    coll.readConcern,
 		writeConcern:   coll.writeConcern,
 		readPreference: coll.readPreference,
+		registry:       coll.registry,
 		readSelector:   coll.readSelector,
 		writeSelector:  coll.writeSelector,
+		selector:       coll.selector,
+		nameErr:        coll.nameErr,
+	}
+}
+
+// setSelectors sets the selectors of the read and write operations run against the collection.
+func (coll *Collection) setSelectors() {
+	if coll.nameErr != nil {
+		coll.readSelector = failingSelector(coll.nameErr)
+		coll.writeSelector = coll.readSelector
+		return
+	}
+	if coll.selector != nil {
+		coll.readPreference = selectedReadPref(coll.readPreference)
+		coll.readSelector = coll.selector
+		coll.writeSelector = writeSelector(coll.selector)
+		return
+	}
+
+	coll.readSelector = description.CompositeSelector([]description.ServerSelector{
+		description.ReadPrefSelector(coll.readPreference),
+		description.LatencySelector(coll.client.localThreshold),
+	})
+	coll.writeSelector = description.WriteSelector()
+}
+
+// readPrefSelector returns the read preference and the selector of a read against the collection
+// run with the ReadPreference option rp, or with the read preference of the collection if rp is nil.
+func (coll *Collection) readPrefSelector(rp *readpref.ReadPref) (*readpref.ReadPref, description.ServerSelector) {
+	if rp == nil || coll.nameErr != nil {
+		return coll.readPreference, coll.readSelector
 	}
+	return readSelector(coll.client, coll.selector, rp)
 }
 
 // Clone creates a copy of this collection with updated options, if any are given.
@@ -124,10 +174,15 @@ func (coll *Collection) Clone(opts ...collectionopt.Option) (*Collection, error)
 		copyColl.readPreference = optsColl.ReadPreference
 	}
 
-	copyColl.readSelector = description.CompositeSelector([]description.ServerSelector{
-		description.ReadPrefSelector(copyColl.readPreference),
-		description.LatencySelector(copyColl.client.localThreshold),
-	})
+	if optsColl.Registry != nil {
+		copyColl.registry = optsColl.Registry
+	}
+
+	if optsColl.ServerSelector != nil {
+		copyColl.selector = optsColl.ServerSelector
+	}
+
+	copyColl.setSelectors()
 
 	return copyColl, nil
 }
@@ -163,21 +218,16 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "insert_one"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).InsertOne")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "insert_one"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).InsertOne")
+	defer span.End()
 
 	span.Annotate(nil, "Starting TransformDocument")
-	doc, err := TransformDocument(document)
+	doc, err := transformRequiredDocument(coll.registry, "document", document)
 	span.Annotate(nil, "Finished TransformDocument")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
@@ -185,13 +235,15 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 	insertedID, err := ensureID(doc)
 	span.Annotate(nil, "Finished EnsureID")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "ensure_id"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "ensure_id", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
 	// convert options into []option.InsertOptioner and dedup
+	if c := coll.client.comment; c != nil {
+		opts = append([]insertopt.One{insertopt.OptComment(*c)}, opts...)
+	}
 	oneOpts, sess, err := insertopt.BundleOne(opts...).Unbundle(true)
 	if err != nil {
 		return nil, err
@@ -225,14 +277,18 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 		coll.client.retryWrites,
 	)
 
+	// dispatch.Insert already sets error metrics, so only the write errors of its result are
+	// recorded here
+	dispatchErr := err
 	rr, err := processWriteError(res.WriteConcernError, res.WriteErrors, err)
 
 	if err == nil {
-		stats.Record(ctx, observability.MInsertions.M(1))
+		observability.Record(ctx, observability.MInsertions.M(1))
 	} else {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "dispatch_insert"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		if dispatchErr == nil {
+			observability.RecordError(ctx, "process_write_error", err)
+		}
+		span.SetStatus(observability.SpanStatus(err))
 	}
 
 	if rr&rrOne == 0 {
@@ -259,36 +315,30 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "insert_many"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).InsertMany")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "insert_many"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).InsertMany")
+	defer span.End()
 
 	result := make([]interface{}, len(documents))
 	docs := make([]*bson.Document, len(documents))
 
 	for i, doc := range documents {
-		bdoc, err := TransformDocument(doc)
+		bdoc, err := transformRequiredDocument(coll.registry, fmt.Sprintf("document %d", i), doc)
 		if err != nil {
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document"))
-			stats.Record(ctx, observability.MErrors.M(1))
+			observability.RecordError(ctx, "transform_document", err)
 			span.Annotatef([]trace.Attribute{
 				trace.Int64Attribute("i", int64(i)),
 			}, "TransformDocument error")
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			span.SetStatus(observability.SpanStatus(err))
 			return nil, err
 		}
 		insertedID, err := ensureID(bdoc)
 		if err != nil {
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "ensure_doc"))
-			stats.Record(ctx, observability.MErrors.M(1))
+			observability.RecordError(ctx, "ensure_doc", err)
 			span.Annotatef([]trace.Attribute{
 				trace.Int64Attribute("i", int64(i)),
 			}, "ensureID error")
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			span.SetStatus(observability.SpanStatus(err))
 			return nil, err
 		}
 
@@ -297,6 +347,9 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 	}
 
 	// convert options into []option.InsertOptioner and dedup
+	if c := coll.client.comment; c != nil {
+		opts = append([]insertopt.Many{insertopt.OptComment(*c)}, opts...)
+	}
 	manyOpts, sess, err := insertopt.BundleMany(opts...).Unbundle(true)
 	if err != nil {
 		return nil, err
@@ -337,9 +390,8 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 		return &InsertManyResult{InsertedIDs: result}, ErrUnacknowledgedWrite
 
 	default:
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "dispatch_insert"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		// dispatch.Insert already sets error metrics
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
@@ -351,11 +403,10 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 	}
 
 	if err == nil {
-		stats.Record(ctx, observability.MInsertions.M(1))
+		observability.Record(ctx, observability.MInsertions.M(1))
 	} else {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "dispatch_insert"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "process_write_error", err)
+		span.SetStatus(observability.SpanStatus(err))
 	}
 
 	return &InsertManyResult{InsertedIDs: result}, err
@@ -374,19 +425,14 @@ func (coll *Collection) DeleteOne(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "delete_one"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).DeleteOne")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "delete_one"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).DeleteOne")
+	defer span.End()
 
-	f, err := TransformDocument(filter)
+	f, err := transformDocument(coll.registry, "filter", filter)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 	deleteDocs := []*bson.Document{
@@ -395,6 +441,9 @@ func (coll *Collection) DeleteOne(ctx context.Context, filter interface{},
 			bson.EC.Int32("limit", 1)),
 	}
 
+	if c := coll.client.comment; c != nil {
+		opts = append([]deleteopt.Delete{deleteopt.OptComment(*c)}, opts...)
+	}
 	deleteOpts, sess, err := deleteopt.BundleDelete(opts...).Unbundle(true)
 	if err != nil {
 		return nil, err
@@ -429,13 +478,17 @@ func (coll *Collection) DeleteOne(ctx context.Context, filter interface{},
 		coll.client.retryWrites,
 	)
 
+	// dispatch.Delete already sets error metrics, so only the write errors of its result are
+	// recorded here
+	dispatchErr := err
 	rr, err := processWriteError(res.WriteConcernError, res.WriteErrors, err)
 	if err == nil {
-		stats.Record(ctx, observability.MDeletions.M(1))
+		observability.Record(ctx, observability.MDeletions.M(1))
 	} else {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "dispatch_delete"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		if dispatchErr == nil {
+			observability.RecordError(ctx, "process_write_error", err)
+		}
+		span.SetStatus(observability.SpanStatus(err))
 	}
 	if rr&rrOne == 0 {
 		return nil, err
@@ -457,23 +510,21 @@ func (coll *Collection) DeleteMany(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "delete_many"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).DeleteMany")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "delete_many"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).DeleteMany")
+	defer span.End()
 
-	f, err := TransformDocument(filter)
+	f, err := transformDocument(coll.registry, "filter", filter)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Insert(observability.KeyPart, "transform_document"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 	deleteDocs := []*bson.Document{bson.NewDocument(bson.EC.SubDocument("q", f), bson.EC.Int32("limit", 0))}
 
+	if c := coll.client.comment; c != nil {
+		opts = append([]deleteopt.Delete{deleteopt.OptComment(*c)}, opts...)
+	}
 	deleteOpts, sess, err := deleteopt.BundleDelete(opts...).Unbundle(true)
 	if err != nil {
 		return nil, err
@@ -508,13 +559,17 @@ func (coll *Collection) DeleteMany(ctx context.Context, filter interface{},
 		false,
 	)
 
+	// dispatch.Delete already sets error metrics, so only the write errors of its result are
+	// recorded here
+	dispatchErr := err
 	rr, err := processWriteError(res.WriteConcernError, res.WriteErrors, err)
 	if err == nil {
-		stats.Record(ctx, observability.MDeletions.M(1))
+		observability.Record(ctx, observability.MDeletions.M(1))
 	} else {
-		ctx, _ = tag.New(ctx, tag.Insert(observability.KeyPart, "dispatch_delete"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		if dispatchErr == nil {
+			observability.RecordError(ctx, "process_write_error", err)
+		}
+		span.SetStatus(observability.SpanStatus(err))
 	}
 
 	if rr&rrMany == 0 {
@@ -530,7 +585,7 @@ func (coll *Collection) updateOrReplaceOne(ctx context.Context, filter,
 		ctx = context.Background()
 	}
 
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).updateOrReplaceOne")
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).updateOrReplaceOne")
 	defer span.End()
 
 	updateDocs := []*bson.Document{
@@ -565,9 +620,8 @@ func (coll *Collection) updateOrReplaceOne(ctx context.Context, filter,
 		coll.client.retryWrites,
 	)
 	if err != nil && err != command.ErrUnacknowledgedWrite {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "dispatch_update"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		// dispatch.Update already sets error metrics
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
@@ -580,13 +634,16 @@ func (coll *Collection) updateOrReplaceOne(ctx context.Context, filter,
 		res.MatchedCount--
 	}
 
+	// only the write errors of the result are left for the error metrics
+	dispatchErr := err
 	rr, err := processWriteError(r.WriteConcernError, r.WriteErrors, err)
 	if err == nil {
-		stats.Record(ctx, observability.MUpdates.M(1), observability.MReplaces.M(1))
+		observability.Record(ctx, observability.MUpdates.M(1), observability.MReplaces.M(1))
 	} else {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "process_write_error"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		if dispatchErr == nil {
+			observability.RecordError(ctx, "process_write_error", err)
+		}
+		span.SetStatus(observability.SpanStatus(err))
 	}
 	if rr&rrOne == 0 {
 		return nil, err
@@ -607,41 +664,37 @@ func (coll *Collection) UpdateOne(ctx context.Context, filter interface{}, updat
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "update_one"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).UpdateOne")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "update_one"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).UpdateOne")
+	defer span.End()
 
-	f, err := TransformDocument(filter)
+	f, err := transformDocument(coll.registry, "filter", filter)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_filter"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document_filter", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
-	u, err := TransformDocument(update)
+	u, err := transformRequiredDocument(coll.registry, "update", update)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_update"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document_update", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
 	if err := ensureDollarKey(u); err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "ensure_dollar_key"))
-		stats.Record(ctx, observability.MErrors.M(1))
+		observability.RecordError(ctx, "ensure_dollar_key", err)
 		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInvalidArgument), Message: err.Error()})
 		return nil, err
 	}
 
+	if c := coll.client.comment; c != nil {
+		options = append([]updateopt.Update{updateopt.OptComment(*c)}, options...)
+	}
 	updOpts, sess, err := updateopt.BundleUpdate(options...).Unbundle(true)
 	if err != nil {
 		// updateOrReplaceOne already sets error metrics
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
@@ -666,34 +719,27 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "update_many"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).UpdateMany")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "update_many"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).UpdateMany")
+	defer span.End()
 
-	f, err := TransformDocument(filter)
+	f, err := transformDocument(coll.registry, "filter", filter)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_filter"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document_filter", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
-	u, err := TransformDocument(update)
+	u, err := transformRequiredDocument(coll.registry, "update", update)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_update"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document_update", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
 	if err = ensureDollarKey(u); err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "ensure_dollar_key"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "ensure_dollar_key", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
@@ -705,19 +751,20 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 		),
 	}
 
+	if c := coll.client.comment; c != nil {
+		opts = append([]updateopt.Update{updateopt.OptComment(*c)}, opts...)
+	}
 	updOpts, sess, err := updateopt.BundleUpdate(opts...).Unbundle(true)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "updateopt_bundleupdate"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "updateopt_bundleupdate", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
 	err = coll.client.ValidSession(sess)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "client_validsession"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "client_validsession", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
@@ -746,7 +793,7 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 	)
 	if err != nil && err != command.ErrUnacknowledgedWrite {
 		// dispatch.Update already sets error metrics
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 	res := &UpdateResult{
@@ -759,13 +806,16 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 		res.MatchedCount--
 	}
 
+	// only the write errors of the result are left for the error metrics
+	dispatchErr := err
 	rr, err := processWriteError(r.WriteConcernError, r.WriteErrors, err)
 	if err == nil {
-		stats.Record(ctx, observability.MUpdates.M(1))
+		observability.Record(ctx, observability.MUpdates.M(1))
 	} else {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "process_write_error"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		if dispatchErr == nil {
+			observability.RecordError(ctx, "process_write_error", err)
+		}
+		span.SetStatus(observability.SpanStatus(err))
 	}
 	if rr&rrMany == 0 {
 		return nil, err
@@ -787,40 +837,37 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "replace_one"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).ReplaceOne")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "replace_one"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).ReplaceOne")
+	defer span.End()
 
-	f, err := TransformDocument(filter)
+	f, err := transformDocument(coll.registry, "filter", filter)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_filter"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document_filter", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
-	r, err := TransformDocument(replacement)
+	r, err := transformRequiredDocument(coll.registry, "replacement", replacement)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_update"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document_update", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
 	if elem, ok := r.ElementAtOK(0); ok && strings.HasPrefix(elem.Key(), "$") {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "elem_ok"))
-		stats.Record(ctx, observability.MErrors.M(1))
+		err := errors.New("replacement document cannot contains keys beginning with '$")
+		observability.RecordError(ctx, "elem_ok", err)
 		span.SetStatus(trace.Status{
 			Code:    int32(trace.StatusCodeInvalidArgument),
 			Message: "Cannot contain keys beginning with '$'",
 		})
-		return nil, errors.New("replacement document cannot contains keys beginning with '$")
+		return nil, err
 	}
 
+	if c := coll.client.comment; c != nil {
+		opts = append([]replaceopt.Replace{replaceopt.OptComment(*c)}, opts...)
+	}
 	repOpts, sess, err := replaceopt.BundleReplace(opts...).Unbundle(true)
 	if err != nil {
 		return nil, err
@@ -839,7 +886,7 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 	ures, err := coll.updateOrReplaceOne(ctx, f, r, sess, updateOptions...)
 	if err != nil {
 		// updateOrReplaceOne already sets error metrics
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 	}
 	return ures, err
 }
@@ -849,9 +896,9 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 //
 // See https://docs.mongodb.com/manual/aggregation/.
 //
-// This method uses TransformDocument to turn the pipeline parameter into a
-// *bson.Document. See TransformDocument for the list of valid types for
-// pipeline.
+// The pipeline can be a *bson.Array, a slice of stages of any type supported by TransformDocument,
+// or a raw BSON array. A single stage document is also accepted as a pipeline of one stage, but
+// this is deprecated: pass a slice holding the stage instead.
 func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 	opts ...aggregateopt.Aggregate) (Cursor, error) {
 
@@ -859,23 +906,21 @@ func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "aggregate"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).Aggregate")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "aggregate"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).Aggregate")
+	defer span.End()
 
-	pipelineArr, err := transformAggregatePipeline(pipeline)
+	pipelineArr, err := transformAggregatePipeline(coll.registry, pipeline)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_aggregate_pipeline"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_aggregate_pipeline", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
 	// convert options into []option.Optioner and dedup
+	if c := coll.client.comment; c != nil {
+		opts = append([]aggregateopt.Aggregate{aggregateopt.OptComment(*c)}, opts...)
+	}
 	aggOpts, sess, err := aggregateopt.BundleAggregate(opts...).Unbundle(true)
 	if err != nil {
 		return nil, err
@@ -896,29 +941,41 @@ func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 		rc = nil
 	}
 
+	rp, selector := coll.readPrefSelector(nil)
+	var opRP *readpref.ReadPref
+	for _, opt := range aggOpts {
+		if o, ok := opt.(option.OptReadPreference); ok {
+			opRP = o.ReadPreference
+			rp, selector = coll.readPrefSelector(opRP)
+		}
+	}
+
 	oldns := coll.namespace()
 	cmd := command.Aggregate{
 		NS:           command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
 		Pipeline:     pipelineArr,
 		Opts:         aggOpts,
-		ReadPref:     coll.readPreference,
+		ReadPref:     rp,
 		WriteConcern: wc,
 		ReadConcern:  rc,
 		Session:      sess,
 		Clock:        coll.client.clock,
 	}
+	if opRP != nil && opRP.Mode() != readpref.PrimaryMode && cmd.HasDollarOut() {
+		return nil, ErrOutReadPreference
+	}
 
 	cur, err := dispatch.Aggregate(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
+		selector,
 		coll.writeSelector,
 		coll.client.id,
 		coll.client.topology.SessionPool,
 	)
 	if err != nil {
 		// dispatch.Aggregate already sets error metrics
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 	}
 	return cur, err
 
@@ -937,22 +994,20 @@ func (coll *Collection) Count(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "count"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).Count")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "count"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).Count")
+	defer span.End()
 
-	f, err := TransformDocument(filter)
+	f, err := transformDocument(coll.registry, "filter", filter)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_filter"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document_filter", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return 0, err
 	}
 
+	if c := coll.client.comment; c != nil {
+		opts = append([]countopt.Count{countopt.OptComment(*c)}, opts...)
+	}
 	countOpts, sess, err := countopt.BundleCount(opts...).Unbundle(true)
 	if err != nil {
 		return 0, err
@@ -968,29 +1023,36 @@ func (coll *Collection) Count(ctx context.Context, filter interface{},
 		rc = nil
 	}
 
+	rp, selector := coll.readPrefSelector(nil)
+	for _, opt := range countOpts {
+		if o, ok := opt.(option.OptReadPreference); ok {
+			rp, selector = coll.readPrefSelector(o.ReadPreference)
+		}
+	}
+
 	oldns := coll.namespace()
 	cmd := command.Count{
 		NS:          command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
 		Query:       f,
 		Opts:        countOpts,
-		ReadPref:    coll.readPreference,
+		ReadPref:    rp,
 		ReadConcern: rc,
 		Session:     sess,
 		Clock:       coll.client.clock,
 	}
 
-	count, err := dispatch.Count(
+	res, err := dispatch.Count(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
+		selector,
 		coll.client.id,
 		coll.client.topology.SessionPool,
 	)
 	if err != nil {
 		// dispatch.Count already sets error metrics
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 	}
-	return count, err
+	return res.N, err
 }
 
 // CountDocuments gets the number of documents matching the filter. A user can supply a
@@ -1005,11 +1067,14 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
-	pipelineArr, err := countDocumentsAggregatePipeline(filter, opts...)
+	pipelineArr, err := countDocumentsAggregatePipeline(coll.registry, filter, opts...)
 	if err != nil {
 		return 0, err
 	}
 
+	if c := coll.client.comment; c != nil {
+		opts = append([]countopt.Count{countopt.OptComment(*c)}, opts...)
+	}
 	countOpts, sess, err := countopt.BundleCount(opts...).Unbundle(true)
 	if err != nil {
 		return 0, err
@@ -1025,12 +1090,19 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 		rc = nil
 	}
 
+	rp, selector := coll.readPrefSelector(nil)
+	for _, opt := range countOpts {
+		if o, ok := opt.(option.OptReadPreference); ok {
+			rp, selector = coll.readPrefSelector(o.ReadPreference)
+		}
+	}
+
 	oldns := coll.namespace()
 	cmd := command.CountDocuments{
 		NS:          command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
 		Pipeline:    pipelineArr,
 		Opts:        countOpts,
-		ReadPref:    coll.readPreference,
+		ReadPref:    rp,
 		ReadConcern: rc,
 		Session:     sess,
 		Clock:       coll.client.clock,
@@ -1038,7 +1110,7 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	return dispatch.CountDocuments(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
+		selector,
 		coll.client.id,
 		coll.client.topology.SessionPool,
 	)
@@ -1052,6 +1124,9 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 		ctx = context.Background()
 	}
 
+	if c := coll.client.comment; c != nil {
+		opts = append([]countopt.EstimatedDocumentCount{countopt.OptComment(*c)}, opts...)
+	}
 	countOpts, sess, err := countopt.BundleEstimatedDocumentCount(opts...).Unbundle(true)
 	if err != nil {
 		return 0, err
@@ -1067,23 +1142,31 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 		rc = nil
 	}
 
+	rp, selector := coll.readPrefSelector(nil)
+	for _, opt := range countOpts {
+		if o, ok := opt.(option.OptReadPreference); ok {
+			rp, selector = coll.readPrefSelector(o.ReadPreference)
+		}
+	}
+
 	oldns := coll.namespace()
 	cmd := command.Count{
 		NS:          command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
 		Query:       bson.NewDocument(),
 		Opts:        countOpts,
-		ReadPref:    coll.readPreference,
+		ReadPref:    rp,
 		ReadConcern: rc,
 		Session:     sess,
 		Clock:       coll.client.clock,
 	}
-	return dispatch.Count(
+	res, err := dispatch.Count(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
+		selector,
 		coll.client.id,
 		coll.client.topology.SessionPool,
 	)
+	return res.N, err
 }
 
 // Distinct finds the distinct values for a specified field across a single
@@ -1100,28 +1183,26 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "distinct"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).Distinct")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "distinct"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).Distinct")
+	defer span.End()
 
 	var f *bson.Document
 	var err error
 	if filter != nil {
 		span.Annotatef(nil, "Invoking TransformDocument with filter")
-		f, err = TransformDocument(filter)
+		f, err = transformDocument(coll.registry, "filter", filter)
 		span.Annotatef(nil, "Finished TransformDocument with filter")
 		if err != nil {
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_filter"))
-			stats.Record(ctx, observability.MErrors.M(1))
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			observability.RecordError(ctx, "transform_document_filter", err)
+			span.SetStatus(observability.SpanStatus(err))
 			return nil, err
 		}
 	}
 
+	if c := coll.client.comment; c != nil {
+		opts = append([]distinctopt.Distinct{distinctopt.OptComment(*c)}, opts...)
+	}
 	distinctOpts, sess, err := distinctopt.BundleDistinct(opts...).Unbundle(true)
 	if err != nil {
 		return nil, err
@@ -1137,13 +1218,20 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 		rc = nil
 	}
 
+	rp, selector := coll.readPrefSelector(nil)
+	for _, opt := range distinctOpts {
+		if o, ok := opt.(option.OptReadPreference); ok {
+			rp, selector = coll.readPrefSelector(o.ReadPreference)
+		}
+	}
+
 	oldns := coll.namespace()
 	cmd := command.Distinct{
 		NS:          command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
 		Field:       fieldName,
 		Query:       f,
 		Opts:        distinctOpts,
-		ReadPref:    coll.readPreference,
+		ReadPref:    rp,
 		ReadConcern: rc,
 		Session:     sess,
 		Clock:       coll.client.clock,
@@ -1152,13 +1240,13 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 	res, err := dispatch.Distinct(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
+		selector,
 		coll.client.id,
 		coll.client.topology.SessionPool,
 	)
 	if err != nil {
 		// dispatch.Distinct already sets error metrics
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
 
@@ -1178,32 +1266,33 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "find"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).Find")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "find"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).Find")
+	defer span.End()
 
 	var f *bson.Document
 	var err error
 	if filter != nil {
 		span.Annotatef(nil, "Invoking TransformDocument with filter")
-		f, err = TransformDocument(filter)
+		f, err = transformDocument(coll.registry, "filter", filter)
 		span.Annotatef(nil, "Finished TransformDocument with filter")
 		if err != nil {
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_filter"))
-			stats.Record(ctx, observability.MErrors.M(1))
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			observability.RecordError(ctx, "transform_document_filter", err)
+			span.SetStatus(observability.SpanStatus(err))
 			return nil, err
 		}
 	}
 
+	if c := coll.client.comment; c != nil {
+		opts = append([]findopt.Find{findopt.OptComment(*c)}, opts...)
+	}
 	findOpts, sess, err := findopt.BundleFind(opts...).Unbundle(true)
 	if err != nil {
 		return nil, err
 	}
+	if err = findopt.Validate(findOpts); err != nil {
+		return nil, err
+	}
 
 	err = coll.client.ValidSession(sess)
 	if err != nil {
@@ -1215,12 +1304,19 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 		rc = nil
 	}
 
+	rp, selector := coll.readPrefSelector(nil)
+	for _, opt := range findOpts {
+		if o, ok := opt.(option.OptReadPreference); ok {
+			rp, selector = coll.readPrefSelector(o.ReadPreference)
+		}
+	}
+
 	oldns := coll.namespace()
 	cmd := command.Find{
 		NS:          command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
 		Filter:      f,
 		Opts:        findOpts,
-		ReadPref:    coll.readPreference,
+		ReadPref:    rp,
 		ReadConcern: rc,
 		Session:     sess,
 		Clock:       coll.client.clock,
@@ -1229,13 +1325,13 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	cur, err := dispatch.Find(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
+		selector,
 		coll.client.id,
 		coll.client.topology.SessionPool,
 	)
 	if err != nil {
 		// dispatch.Find already sets error metrics
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 	}
 	return cur, err
 }
@@ -1254,33 +1350,35 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "find_one"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).FindOne")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "find_one"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).FindOne")
+	defer span.End()
 
 	var f *bson.Document
 	var err error
 	if filter != nil {
 		span.Annotatef(nil, "Invoking TransformDocument with filter")
-		f, err = TransformDocument(filter)
+		f, err = transformDocument(coll.registry, "filter", filter)
 		span.Annotatef(nil, "Finished TransformDocument with filter")
 		if err != nil {
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_filter"))
-			stats.Record(ctx, observability.MErrors.M(1))
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			observability.RecordError(ctx, "transform_document_filter", err)
+			span.SetStatus(observability.SpanStatus(err))
 			return &DocumentResult{err: err}
 		}
 	}
 
+	if c := coll.client.comment; c != nil {
+		opts = append([]findopt.One{findopt.OptComment(*c)}, opts...)
+	}
 	findOneOpts, sess, err := findopt.BundleOne(opts...).Unbundle(true)
 	if err != nil {
 		return &DocumentResult{err: err}
 	}
-	findOneOpts = append(findOneOpts, findopt.Limit(1).ConvertFindOption())
+	// a negative limit returns the document in a single batch, which closes the cursor
+	findOneOpts = append(findOneOpts, findopt.Limit(-1).ConvertFindOption())
+	if err = findopt.Validate(findOneOpts); err != nil {
+		return &DocumentResult{err: err}
+	}
 
 	err = coll.client.ValidSession(sess)
 	if err != nil {
@@ -1292,12 +1390,19 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 		rc = nil
 	}
 
+	rp, selector := coll.readPrefSelector(nil)
+	for _, opt := range findOneOpts {
+		if o, ok := opt.(option.OptReadPreference); ok {
+			rp, selector = coll.readPrefSelector(o.ReadPreference)
+		}
+	}
+
 	oldns := coll.namespace()
 	cmd := command.Find{
 		NS:          command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
 		Filter:      f,
 		Opts:        findOneOpts,
-		ReadPref:    coll.readPreference,
+		ReadPref:    rp,
 		ReadConcern: rc,
 		Session:     sess,
 		Clock:       coll.client.clock,
@@ -1306,17 +1411,66 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 	cursor, err := dispatch.Find(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
+		selector,
 		coll.client.id,
 		coll.client.topology.SessionPool,
 	)
 	if err != nil {
 		// dispatch.Find already sets error metrics
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return &DocumentResult{err: err}
 	}
 
-	return &DocumentResult{cur: cursor}
+	return &DocumentResult{cur: cursor, reg: coll.registry}
+}
+
+// FindByID finds the document whose _id is id and decodes it into out. It returns ErrNoDocuments
+// if there is no such document. If out is nil, the document is only looked up. A user can supply a
+// custom context to this method, or nil to default to context.Background().
+//
+// The id is encoded with the registry of the collection, so that custom types of _id are encoded
+// as they are when inserted. The options are those of FindOne.
+func (coll *Collection) FindByID(ctx context.Context, id interface{}, out interface{},
+	opts ...findopt.One) error {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "find_by_id"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).FindByID")
+	defer span.End()
+
+	return coll.FindOne(ctx, map[string]interface{}{"_id": id}, opts...).Decode(out)
+}
+
+// Exists returns whether a document matches the filter. It finds up to one document, of which only
+// the _id is returned, so that it is cheaper than FindOne. A user can supply a custom context to
+// this method, or nil to default to context.Background().
+//
+// This method uses TransformDocument to turn the filter parameter into a *bson.Document. See
+// TransformDocument for the list of valid types for filter. The options are those of FindOne,
+// except for the projection, which is replaced.
+func (coll *Collection) Exists(ctx context.Context, filter interface{},
+	opts ...findopt.One) (bool, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "exists"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).Exists")
+	defer span.End()
+
+	opts = append(opts, findopt.Projection(bson.NewDocument(bson.EC.Int32("_id", 1))))
+	err := coll.FindOne(ctx, filter, opts...).Err()
+	switch err {
+	case nil:
+		return true, nil
+	case ErrNoDocuments:
+		return false, nil
+	}
+	return false, err
 }
 
 // FindOneAndDelete find a single document and deletes it, returning the
@@ -1335,28 +1489,26 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "find_one_and_delete"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).FindOneAndDelete")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "find_one_and_delete"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).FindOneAndDelete")
+	defer span.End()
 
 	var f *bson.Document
 	var err error
 	if filter != nil {
 		span.Annotatef(nil, "Invoking TransformDocument with filter")
-		f, err = TransformDocument(filter)
+		f, err = transformDocument(coll.registry, "filter", filter)
 		span.Annotatef(nil, "Finished TransformDocument with filter")
 		if err != nil {
-			ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_filter"))
-			stats.Record(ctx, observability.MErrors.M(1))
-			span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+			observability.RecordError(ctx, "transform_document_filter", err)
+			span.SetStatus(observability.SpanStatus(err))
 			return &DocumentResult{err: err}
 		}
 	}
 
+	if c := coll.client.comment; c != nil {
+		opts = append([]findopt.DeleteOne{findopt.OptComment(*c)}, opts...)
+	}
 	findOpts, sess, err := findopt.BundleDeleteOne(opts...).Unbundle(true)
 	if err != nil {
 		return &DocumentResult{err: err}
@@ -1392,11 +1544,18 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 	)
 	if err != nil {
 		// dispatch.FindOneAndDelete already sets error metrics
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return &DocumentResult{err: err}
 	}
 
-	return &DocumentResult{rdr: res.Value}
+	if res.WriteConcernError != nil {
+		err = *convertWriteConcernError(res.WriteConcernError)
+		observability.RecordError(ctx, "write_concern_error", err)
+		span.SetStatus(observability.SpanStatus(err))
+		return findAndModifyResult(res, err, coll.registry)
+	}
+
+	return findAndModifyResult(res, nil, coll.registry)
 }
 
 // FindOneAndReplace finds a single document and replaces it, returning either
@@ -1415,41 +1574,38 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "find_one_and_replace"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).FindOneAndReplace")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "find_one_and_replace"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).FindOneAndReplace")
+	defer span.End()
 
 	span.Annotatef(nil, "Invoking TransformDocument with filter")
-	f, err := TransformDocument(filter)
+	f, err := transformDocument(coll.registry, "filter", filter)
 	span.Annotatef(nil, "Finished TransformDocument with filter")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_filter"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document_filter", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return &DocumentResult{err: err}
 	}
 
 	span.Annotatef(nil, "Invoking TransformDocument with replacement")
-	r, err := TransformDocument(replacement)
+	r, err := transformRequiredDocument(coll.registry, "replacement", replacement)
 	span.Annotatef(nil, "Finished TransformDocument with replacement")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_update"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document_update", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return &DocumentResult{err: err}
 	}
 
 	if elem, ok := r.ElementAtOK(0); ok && strings.HasPrefix(elem.Key(), "$") {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "elem_ok"))
-		stats.Record(ctx, observability.MErrors.M(1))
+		err := errors.New("replacement document cannot contains keys beginning with '$")
+		observability.RecordError(ctx, "elem_ok", err)
 		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInvalidArgument), Message: "Cannot contain keys beginning with '$'"})
-		return &DocumentResult{err: errors.New("replacement document cannot contains keys beginning with '$")}
+		return &DocumentResult{err: err}
 	}
 
+	if c := coll.client.comment; c != nil {
+		opts = append([]findopt.ReplaceOne{findopt.OptComment(*c)}, opts...)
+	}
 	findOpts, sess, err := findopt.BundleReplaceOne(opts...).Unbundle(true)
 
 	if err != nil {
@@ -1490,7 +1646,14 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 		return &DocumentResult{err: err}
 	}
 
-	return &DocumentResult{rdr: res.Value}
+	if res.WriteConcernError != nil {
+		err = *convertWriteConcernError(res.WriteConcernError)
+		observability.RecordError(ctx, "write_concern_error", err)
+		span.SetStatus(observability.SpanStatus(err))
+		return findAndModifyResult(res, err, coll.registry)
+	}
+
+	return findAndModifyResult(res, nil, coll.registry)
 }
 
 // FindOneAndUpdate finds a single document and updates it, returning either
@@ -1509,40 +1672,37 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "findOneAndUpdate"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).FindOneAndUpdate")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "findOneAndUpdate"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).FindOneAndUpdate")
+	defer span.End()
 
 	span.Annotatef(nil, "Invoking TransformDocument with filter")
-	f, err := TransformDocument(filter)
+	f, err := transformDocument(coll.registry, "filter", filter)
 	span.Annotatef(nil, "Finished TransformDocument with filter")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_filter"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document_filter", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return &DocumentResult{err: err}
 	}
 
 	span.Annotatef(nil, "Invoking TransformDocument with update")
-	u, err := TransformDocument(update)
+	u, err := transformRequiredDocument(coll.registry, "update", update)
 	span.Annotatef(nil, "Finished TransformDocument with update")
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_document_update"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		observability.RecordError(ctx, "transform_document_update", err)
+		span.SetStatus(observability.SpanStatus(err))
 		return &DocumentResult{err: err}
 	}
 
 	if elem, ok := u.ElementAtOK(0); !ok || !strings.HasPrefix(elem.Key(), "$") {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "elem_ok"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		return &DocumentResult{err: errors.New("update document must contain key beginning with '$")}
+		err := errors.New("update document must contain key beginning with '$")
+		observability.RecordError(ctx, "elem_ok", err)
+		return &DocumentResult{err: err}
 	}
 
+	if c := coll.client.comment; c != nil {
+		opts = append([]findopt.UpdateOne{findopt.OptComment(*c)}, opts...)
+	}
 	findOpts, sess, err := findopt.BundleUpdateOne(opts...).Unbundle(true)
 	if err != nil {
 		return &DocumentResult{err: err}
@@ -1579,31 +1739,35 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 	)
 	if err != nil {
 		// dispatch.FindOneAndUpdate already sets error metrics.
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return &DocumentResult{err: err}
 	}
 
-	return &DocumentResult{rdr: res.Value}
+	if res.WriteConcernError != nil {
+		err = *convertWriteConcernError(res.WriteConcernError)
+		observability.RecordError(ctx, "write_concern_error", err)
+		span.SetStatus(observability.SpanStatus(err))
+		return findAndModifyResult(res, err, coll.registry)
+	}
+
+	return findAndModifyResult(res, nil, coll.registry)
 }
 
 // Watch returns a change stream cursor used to receive notifications of changes to the collection.
 // This method is preferred to running a raw aggregation with a $changeStream stage because it
-// supports resumability in the case of some errors.
+// supports resumability in the case of some errors. The pipeline, which follows the $changeStream
+// stage, accepts the same types as the pipeline of Aggregate.
 func (coll *Collection) Watch(ctx context.Context, pipeline interface{},
 	opts ...changestreamopt.ChangeStream) (Cursor, error) {
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "watch"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).Watch")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "watch"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).Watch")
+	defer span.End()
 
 	cur, err := newChangeStream(ctx, coll, pipeline, opts...)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyMethod, "new_change_stream"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		ctx = observability.Tag(ctx, tag.Upsert(observability.KeyMethod, "new_change_stream"))
+		observability.Record(ctx, observability.MErrors.M(1))
+		span.SetStatus(observability.SpanStatus(err))
 	}
 	return cur, err
 }
@@ -1619,13 +1783,9 @@ func (coll *Collection) Drop(ctx context.Context, opts ...dropcollopt.DropColl)
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "drop"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Collection).Drop")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "drop"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).Drop")
+	defer span.End()
 
 	var sess *session.Client
 	for _, opt := range opts {
@@ -1659,10 +1819,90 @@ func (coll *Collection) Drop(ctx context.Context, opts ...dropcollopt.DropColl)
 		coll.client.topology.SessionPool,
 	)
 	if err != nil && !command.IsNotFound(err) {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "dispatch_dropcollection"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return err
 	}
 	return nil
 }
+
+// Modify changes the properties of the collection, or of one of its indexes, with the collMod
+// command: the expiration of the documents of a TTL index or whether an index is hidden, the
+// validator of the collection and whether the pre- and post-images of its changes are recorded.
+// An index is identified by exactly one of its name and key pattern, which is checked before the
+// command is sent. The result holds the previous and new values of the properties of the index.
+func (coll *Collection) Modify(ctx context.Context, opts ...collmodopt.Option) (CollModResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "coll_mod"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).Modify")
+	defer span.End()
+
+	cm, sess, err := collmodopt.BundleCollMod(opts...).Unbundle()
+	if err != nil {
+		return CollModResult{}, err
+	}
+
+	err = coll.client.ValidSession(sess)
+	if err != nil {
+		return CollModResult{}, err
+	}
+
+	wc := coll.writeConcern
+	if sess != nil && sess.TransactionRunning() {
+		wc = nil
+	}
+
+	oldns := coll.namespace()
+	cmd := command.CollMod{
+		NS:                           command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
+		ChangeStreamPreAndPostImages: cm.ChangeStreamPreAndPostImages,
+		WriteConcern:                 wc,
+		Session:                      sess,
+		Clock:                        coll.client.clock,
+	}
+	if cm.ModifiesIndex() {
+		cmd.Index = bson.NewDocument()
+		if cm.IndexName != nil {
+			cmd.Index.Append(bson.EC.String("name", *cm.IndexName))
+		} else {
+			keys, err := transformRequiredDocument(coll.registry, "index key pattern", cm.IndexKeyPattern)
+			if err != nil {
+				return CollModResult{}, err
+			}
+			cmd.Index.Append(bson.EC.SubDocument("keyPattern", keys))
+		}
+		if cm.ExpireAfterSeconds != nil {
+			cmd.Index.Append(bson.EC.Int64("expireAfterSeconds", *cm.ExpireAfterSeconds))
+		}
+		if cm.Hidden != nil {
+			cmd.Index.Append(bson.EC.Boolean("hidden", *cm.Hidden))
+		}
+	}
+	if cm.Validator != nil {
+		cmd.Validator, err = transformRequiredDocument(coll.registry, "validator", cm.Validator)
+		if err != nil {
+			return CollModResult{}, err
+		}
+	}
+	if cm.ValidationLevel != nil {
+		cmd.ValidationLevel = *cm.ValidationLevel
+	}
+	if cm.ValidationAction != nil {
+		cmd.ValidationAction = *cm.ValidationAction
+	}
+
+	res, err := dispatch.CollMod(
+		ctx, cmd,
+		coll.client.topology,
+		coll.writeSelector,
+		coll.client.id,
+		coll.client.topology.SessionPool,
+	)
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+		return CollModResult{}, err
+	}
+	return CollModResult{}.fromResult(res), nil
+}
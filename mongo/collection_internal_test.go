@@ -21,6 +21,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/mongo/aggregateopt"
 	"github.com/mongodb/mongo-go-driver/mongo/collectionopt"
 	"github.com/mongodb/mongo-go-driver/mongo/countopt"
+	"github.com/mongodb/mongo-go-driver/mongo/dbopt"
 	"github.com/mongodb/mongo-go-driver/mongo/deleteopt"
 	"github.com/mongodb/mongo-go-driver/mongo/distinctopt"
 	"github.com/mongodb/mongo-go-driver/mongo/findopt"
@@ -149,6 +150,56 @@ func TestCollection_InheritOptions(t *testing.T) {
 	}
 }
 
+func TestCollection_InheritRegistry(t *testing.T) {
+	t.Parallel()
+
+	clientReg := bson.NewRegistryBuilder().Build()
+	dbReg := bson.NewRegistryBuilder().Build()
+	collReg := bson.NewRegistryBuilder().Build()
+	client := &Client{registry: clientReg}
+
+	t.Run("FromClient", func(t *testing.T) {
+		coll := client.Database("db").Collection("coll")
+		if coll.registry != clientReg {
+			t.Errorf("expected registry of client. got %#v", coll.registry)
+		}
+	})
+
+	t.Run("FromDatabase", func(t *testing.T) {
+		coll := client.Database("db", dbopt.Registry(dbReg)).Collection("coll")
+		if coll.registry != dbReg {
+			t.Errorf("expected registry of database. got %#v", coll.registry)
+		}
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		db := client.Database("db", dbopt.Registry(dbReg))
+		coll := db.Collection("coll", collectionopt.Registry(collReg))
+		if coll.registry != collReg {
+			t.Errorf("expected registry of collection. got %#v", coll.registry)
+		}
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		coll := client.Database("db").Collection("coll")
+
+		clone, err := coll.Clone()
+		require.NoError(t, err)
+		if clone.registry != clientReg {
+			t.Errorf("expected registry of original collection. got %#v", clone.registry)
+		}
+
+		clone, err = coll.Clone(collectionopt.Registry(collReg))
+		require.NoError(t, err)
+		if clone.registry != collReg {
+			t.Errorf("expected registry of clone. got %#v", clone.registry)
+		}
+		if coll.registry != clientReg {
+			t.Errorf("expected original collection to keep its registry. got %#v", coll.registry)
+		}
+	})
+}
+
 func TestCollection_namespace(t *testing.T) {
 	t.Parallel()
 
@@ -1238,6 +1289,114 @@ func TestCollection_Aggregate_withOptions(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCollection_Explain(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+
+	filter := bson.NewDocument(bson.EC.SubDocumentFromElements("x", bson.EC.Int32("$gte", 2)))
+
+	t.Run("Find", func(t *testing.T) {
+		rdr, err := coll.FindExplain(context.Background(), filter, mongoopt.ExecutionStats, findopt.Limit(2))
+		require.NoError(t, err)
+
+		_, err = rdr.Lookup("executionStats")
+		require.NoError(t, err)
+
+		plan, err := WinningPlan(rdr)
+		require.NoError(t, err)
+		require.NotEmpty(t, plan.Stage)
+	})
+
+	t.Run("Aggregate", func(t *testing.T) {
+		pipeline := bson.NewArray(bson.VC.DocumentFromElements(bson.EC.SubDocument("$match", filter)))
+		rdr, err := coll.AggregateExplain(context.Background(), pipeline, mongoopt.QueryPlanner)
+		require.NoError(t, err)
+
+		plan, err := WinningPlan(rdr)
+		require.NoError(t, err)
+		require.NotEmpty(t, plan.Stage)
+	})
+}
+
+func TestCollection_Let(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	serverVersion, err := getServerVersion(createTestDatabase(t, nil))
+	require.NoError(t, err)
+	if compareVersions(t, serverVersion, "5.0") < 0 {
+		t.Skip("let requires server version 5.0 or newer")
+	}
+
+	filter := bson.NewDocument(
+		bson.EC.SubDocumentFromElements("$expr",
+			bson.EC.ArrayFromElements("$eq", bson.VC.String("$status"), bson.VC.String("$$target")),
+		),
+	)
+	let := bson.NewDocument(bson.EC.String("target", "A"))
+
+	insertStatuses := func(t *testing.T, coll *Collection) {
+		_, err := coll.InsertMany(context.Background(), []interface{}{
+			bson.NewDocument(bson.EC.String("status", "A")),
+			bson.NewDocument(bson.EC.String("status", "B")),
+			bson.NewDocument(bson.EC.String("status", "A")),
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("Aggregate", func(t *testing.T) {
+		coll := createTestCollection(t, nil, nil)
+		insertStatuses(t, coll)
+
+		pipeline := bson.NewArray(bson.VC.DocumentFromElements(bson.EC.SubDocument("$match", filter)))
+		cursor, err := coll.Aggregate(context.Background(), pipeline, aggregateopt.Let(let))
+		require.NoError(t, err)
+
+		var n int
+		for cursor.Next(context.Background()) {
+			n++
+		}
+		require.NoError(t, cursor.Err())
+		require.Equal(t, 2, n)
+	})
+
+	t.Run("UpdateMany", func(t *testing.T) {
+		coll := createTestCollection(t, nil, nil)
+		insertStatuses(t, coll)
+
+		update := bson.NewDocument(bson.EC.SubDocumentFromElements("$set", bson.EC.Boolean("done", true)))
+		res, err := coll.UpdateMany(context.Background(), filter, update, updateopt.Let(let))
+		require.NoError(t, err)
+		require.Equal(t, int64(2), res.ModifiedCount)
+	})
+
+	t.Run("DeleteMany", func(t *testing.T) {
+		coll := createTestCollection(t, nil, nil)
+		insertStatuses(t, coll)
+
+		res, err := coll.DeleteMany(context.Background(), filter, deleteopt.Let(let))
+		require.NoError(t, err)
+		require.Equal(t, int64(2), res.DeletedCount)
+	})
+
+	t.Run("FindOneAndDelete", func(t *testing.T) {
+		coll := createTestCollection(t, nil, nil)
+		insertStatuses(t, coll)
+
+		doc := bson.NewDocument()
+		err := coll.FindOneAndDelete(context.Background(), filter, findopt.Let(let)).Decode(doc)
+		require.NoError(t, err)
+		require.Equal(t, "A", doc.Lookup("status").StringValue())
+	})
+}
+
 func TestCollection_Count(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -9,6 +9,8 @@ package collectionopt
 import (
 	"reflect"
 
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/readconcern"
 	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
@@ -29,6 +31,8 @@ type Collection struct {
 	ReadConcern    *readconcern.ReadConcern
 	WriteConcern   *writeconcern.WriteConcern
 	ReadPreference *readpref.ReadPref
+	Registry       *bson.Registry
+	ServerSelector description.ServerSelector
 }
 
 // CollectionBundle is a bundle of collection options.
@@ -83,6 +87,22 @@ func (cb *CollectionBundle) ReadPreference(rp *readpref.ReadPref) *CollectionBun
 	}
 }
 
+// Registry sets the registry used to encode documents and decode results.
+func (cb *CollectionBundle) Registry(r *bson.Registry) *CollectionBundle {
+	return &CollectionBundle{
+		option: Registry(r),
+		next:   cb,
+	}
+}
+
+// ServerSelector sets the selector used to select the server operations run against.
+func (cb *CollectionBundle) ServerSelector(ss description.ServerSelector) *CollectionBundle {
+	return &CollectionBundle{
+		option: ServerSelector(ss),
+		next:   cb,
+	}
+}
+
 // String prints a string representation of the bundle for debug purposes
 func (cb *CollectionBundle) String() string {
 	if cb == nil {
@@ -172,3 +192,28 @@ func ReadPreference(rp *readpref.ReadPref) Option {
 			return nil
 		})
 }
+
+// Registry sets the registry used to encode documents and decode results.
+func Registry(r *bson.Registry) Option {
+	return optionFunc(
+		func(c *Collection) error {
+			if c.Registry == nil {
+				c.Registry = r
+			}
+			return nil
+		})
+}
+
+// ServerSelector sets the selector used to select the server operations run against, in place of
+// the selectors derived from the read preference. It can pin operations to a single server with
+// mongo.HostSelector. Write operations fail rather than run against a selected server that cannot
+// accept writes.
+func ServerSelector(ss description.ServerSelector) Option {
+	return optionFunc(
+		func(c *Collection) error {
+			if c.ServerSelector == nil {
+				c.ServerSelector = ss
+			}
+			return nil
+		})
+}
@@ -0,0 +1,86 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/collmodopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionModify(t *testing.T) {
+	d := mongotest.New()
+
+	client := newMockClient(t, d)
+
+	coll := client.Database("db").Collection("coll")
+	ctx := context.Background()
+	t.Run("index", func(t *testing.T) {
+		d.Handle("collMod", mongotest.OK(
+			bson.EC.Int64("expireAfterSeconds_old", 3600),
+			bson.EC.Int32("expireAfterSeconds_new", 60),
+		))
+		before := d.CountCommands("collMod")
+
+		res, err := coll.Modify(ctx,
+			collmodopt.IndexKeyPattern(bson.NewDocument(bson.EC.Int32("createdAt", 1))),
+			collmodopt.ExpireAfterSeconds(60),
+		)
+		require.NoError(t, err)
+		require.Equal(t, int64(3600), *res.ExpireAfterSecondsOld)
+		require.Equal(t, int64(60), *res.ExpireAfterSecondsNew)
+		require.Nil(t, res.HiddenOld)
+		require.Nil(t, res.HiddenNew)
+		require.NotNil(t, res.Raw)
+
+		require.Equal(t, before+1, d.CountCommands("collMod"))
+		cmd := d.LastCommand("collMod").Document
+		require.Equal(t, "coll", cmd.Lookup("collMod").StringValue())
+		index := cmd.Lookup("index").MutableDocument()
+		require.Equal(t, int32(1), index.Lookup("keyPattern", "createdAt").Int32())
+		require.Equal(t, int64(60), index.Lookup("expireAfterSeconds").Int64())
+		_, err = index.LookupErr("name")
+		require.Error(t, err)
+	})
+	t.Run("collection", func(t *testing.T) {
+		d.Handle("collMod", mongotest.OK())
+
+		_, err := coll.Modify(ctx, collmodopt.BundleCollMod().
+			Validator(bson.NewDocument(bson.EC.SubDocumentFromElements("age", bson.EC.String("$type", "int")))).
+			ValidationLevel(collmodopt.ValidationModerate).
+			ValidationAction(collmodopt.ValidationWarn).
+			ChangeStreamPreAndPostImages(true))
+		require.NoError(t, err)
+
+		cmd := d.LastCommand("collMod").Document
+		require.Equal(t, "int", cmd.Lookup("validator", "age", "$type").StringValue())
+		require.Equal(t, "moderate", cmd.Lookup("validationLevel").StringValue())
+		require.Equal(t, "warn", cmd.Lookup("validationAction").StringValue())
+		require.True(t, cmd.Lookup("changeStreamPreAndPostImages", "enabled").Boolean())
+		_, err = cmd.LookupErr("index")
+		require.Error(t, err)
+	})
+	t.Run("invalid index", func(t *testing.T) {
+		before := d.CountCommands("collMod")
+
+		_, err := coll.Modify(ctx, collmodopt.IndexName("ttl"), collmodopt.IndexKeyPattern(bson.NewDocument()),
+			collmodopt.Hidden(true))
+		require.Equal(t, collmodopt.ErrIndexIdentifier, err)
+		require.Equal(t, before, d.CountCommands("collMod"), "the command was sent")
+	})
+	t.Run("error", func(t *testing.T) {
+		d.Handle("collMod", mongotest.Error(27, "IndexNotFound", "cannot find index ttl"))
+
+		_, err := coll.Modify(ctx, collmodopt.IndexName("ttl"), collmodopt.Hidden(true))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cannot find index")
+	})
+}
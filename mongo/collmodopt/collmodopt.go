@@ -0,0 +1,348 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package collmodopt contains the options of Collection.Modify, which changes the properties of a
+// collection or of one of its indexes with the collMod command.
+package collmodopt
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/mongodb/mongo-go-driver/core/session"
+)
+
+// ErrIndexIdentifier is returned when the properties of an index are modified without identifying
+// the index by exactly one of its name and key pattern.
+var ErrIndexIdentifier = errors.New("exactly one of the name and the key pattern of the index to modify must be given")
+
+// ErrNoIndexChange is returned when an index is identified without any of its properties to modify.
+var ErrNoIndexChange = errors.New("the index to modify is given without any property to modify")
+
+// The validation levels of a collection.
+const (
+	ValidationOff      = "off"
+	ValidationStrict   = "strict"
+	ValidationModerate = "moderate"
+)
+
+// The validation actions of a collection.
+const (
+	ValidationError = "error"
+	ValidationWarn  = "warn"
+)
+
+var collModBundle = new(CollModBundle)
+
+// Option represents a Modify option.
+type Option interface {
+	collModOption()
+}
+
+// CollModSession is the session for the Modify() function.
+type CollModSession interface {
+	Option
+	ConvertCollModSession() *session.Client
+}
+
+// optionFunc adds the option to the CollMod instance.
+type optionFunc func(*CollMod) error
+
+// CollMod holds the changes made by Modify. Unset fields are left unchanged.
+type CollMod struct {
+	// The index to modify, identified by exactly one of its key pattern and its name, and its
+	// properties to modify.
+	IndexKeyPattern    interface{}
+	IndexName          *string
+	ExpireAfterSeconds *int64
+	Hidden             *bool
+
+	Validator                    interface{}
+	ValidationLevel              *string
+	ValidationAction             *string
+	ChangeStreamPreAndPostImages *bool
+}
+
+// ModifiesIndex returns whether cm modifies an index.
+func (cm *CollMod) ModifiesIndex() bool {
+	return cm.IndexKeyPattern != nil || cm.IndexName != nil || cm.ExpireAfterSeconds != nil || cm.Hidden != nil
+}
+
+// Validate returns an error if the index to modify is not identified by exactly one of its name and
+// key pattern, or if none of its properties is modified.
+func (cm *CollMod) Validate() error {
+	if !cm.ModifiesIndex() {
+		return nil
+	}
+	if (cm.IndexKeyPattern == nil) == (cm.IndexName == nil) {
+		return ErrIndexIdentifier
+	}
+	if cm.ExpireAfterSeconds == nil && cm.Hidden == nil {
+		return ErrNoIndexChange
+	}
+	return nil
+}
+
+// CollModBundle is a bundle of Modify options.
+type CollModBundle struct {
+	option Option
+	next   *CollModBundle
+}
+
+func (*CollModBundle) collModOption() {}
+
+func (optionFunc) collModOption() {}
+
+// BundleCollMod bundles Modify options.
+func BundleCollMod(opts ...Option) *CollModBundle {
+	head := collModBundle
+
+	for _, opt := range opts {
+		newBundle := CollModBundle{
+			option: opt,
+			next:   head,
+		}
+		head = &newBundle
+	}
+
+	return head
+}
+
+// IndexKeyPattern identifies the index to modify by its key pattern.
+func (cmb *CollModBundle) IndexKeyPattern(keys interface{}) *CollModBundle {
+	return &CollModBundle{
+		option: IndexKeyPattern(keys),
+		next:   cmb,
+	}
+}
+
+// IndexName identifies the index to modify by its name.
+func (cmb *CollModBundle) IndexName(name string) *CollModBundle {
+	return &CollModBundle{
+		option: IndexName(name),
+		next:   cmb,
+	}
+}
+
+// ExpireAfterSeconds sets the number of seconds after which the documents of a TTL index expire.
+func (cmb *CollModBundle) ExpireAfterSeconds(seconds int64) *CollModBundle {
+	return &CollModBundle{
+		option: ExpireAfterSeconds(seconds),
+		next:   cmb,
+	}
+}
+
+// Hidden sets whether the index is hidden from the query planner.
+func (cmb *CollModBundle) Hidden(b bool) *CollModBundle {
+	return &CollModBundle{
+		option: Hidden(b),
+		next:   cmb,
+	}
+}
+
+// Validator sets the validator of the collection.
+func (cmb *CollModBundle) Validator(validator interface{}) *CollModBundle {
+	return &CollModBundle{
+		option: Validator(validator),
+		next:   cmb,
+	}
+}
+
+// ValidationLevel sets how strictly the validator of the collection is applied.
+func (cmb *CollModBundle) ValidationLevel(level string) *CollModBundle {
+	return &CollModBundle{
+		option: ValidationLevel(level),
+		next:   cmb,
+	}
+}
+
+// ValidationAction sets whether invalid documents are rejected or only logged.
+func (cmb *CollModBundle) ValidationAction(action string) *CollModBundle {
+	return &CollModBundle{
+		option: ValidationAction(action),
+		next:   cmb,
+	}
+}
+
+// ChangeStreamPreAndPostImages sets whether the pre- and post-images of the changes to the
+// collection are recorded for change streams.
+func (cmb *CollModBundle) ChangeStreamPreAndPostImages(b bool) *CollModBundle {
+	return &CollModBundle{
+		option: ChangeStreamPreAndPostImages(b),
+		next:   cmb,
+	}
+}
+
+// Unbundle unbundles the options, returning a CollMod instance. It returns an error if the changes
+// made to an index are not valid. See CollMod.Validate.
+func (cmb *CollModBundle) Unbundle() (*CollMod, *session.Client, error) {
+	cm := &CollMod{}
+	sess, err := cmb.unbundle(cm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = cm.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return cm, sess, nil
+}
+
+// Helper that recursively unwraps the bundle.
+func (cmb *CollModBundle) unbundle(cm *CollMod) (*session.Client, error) {
+	if cmb == nil {
+		return nil, nil
+	}
+
+	var sess *session.Client
+	for head := cmb; head != nil && head.option != nil; head = head.next {
+		var err error
+		switch opt := head.option.(type) {
+		case *CollModBundle:
+			s, e := opt.unbundle(cm) // add all bundle's options to cm
+			if s != nil && sess == nil {
+				sess = s
+			}
+			err = e
+		case optionFunc:
+			err = opt(cm) // add option to cm
+		case CollModSession:
+			if sess == nil {
+				sess = opt.ConvertCollModSession()
+			}
+		default:
+			return sess, nil
+		}
+		if err != nil {
+			return sess, err
+		}
+	}
+
+	return sess, nil
+}
+
+// String implements the Stringer interface
+func (cmb *CollModBundle) String() string {
+	if cmb == nil {
+		return ""
+	}
+
+	str := ""
+	for head := cmb; head != nil && head.option != nil; head = head.next {
+		switch opt := head.option.(type) {
+		case *CollModBundle:
+			str += opt.String()
+		case optionFunc:
+			str += reflect.TypeOf(opt).String() + "\n"
+		}
+	}
+
+	return str
+}
+
+// IndexKeyPattern identifies the index to modify by its key pattern.
+func IndexKeyPattern(keys interface{}) Option {
+	return optionFunc(
+		func(cm *CollMod) error {
+			if cm.IndexKeyPattern == nil {
+				cm.IndexKeyPattern = keys
+			}
+			return nil
+		})
+}
+
+// IndexName identifies the index to modify by its name.
+func IndexName(name string) Option {
+	return optionFunc(
+		func(cm *CollMod) error {
+			if cm.IndexName == nil {
+				cm.IndexName = &name
+			}
+			return nil
+		})
+}
+
+// ExpireAfterSeconds sets the number of seconds after which the documents of a TTL index expire.
+func ExpireAfterSeconds(seconds int64) Option {
+	return optionFunc(
+		func(cm *CollMod) error {
+			if cm.ExpireAfterSeconds == nil {
+				cm.ExpireAfterSeconds = &seconds
+			}
+			return nil
+		})
+}
+
+// Hidden sets whether the index is hidden from the query planner.
+func Hidden(b bool) Option {
+	return optionFunc(
+		func(cm *CollMod) error {
+			if cm.Hidden == nil {
+				cm.Hidden = &b
+			}
+			return nil
+		})
+}
+
+// Validator sets the validator of the collection, a query filter the documents inserted or updated
+// must match.
+func Validator(validator interface{}) Option {
+	return optionFunc(
+		func(cm *CollMod) error {
+			if cm.Validator == nil {
+				cm.Validator = validator
+			}
+			return nil
+		})
+}
+
+// ValidationLevel sets how strictly the validator of the collection is applied, one of
+// ValidationOff, ValidationStrict and ValidationModerate.
+func ValidationLevel(level string) Option {
+	return optionFunc(
+		func(cm *CollMod) error {
+			if cm.ValidationLevel == nil {
+				cm.ValidationLevel = &level
+			}
+			return nil
+		})
+}
+
+// ValidationAction sets whether documents that do not match the validator of the collection are
+// rejected, with ValidationError, or only logged, with ValidationWarn.
+func ValidationAction(action string) Option {
+	return optionFunc(
+		func(cm *CollMod) error {
+			if cm.ValidationAction == nil {
+				cm.ValidationAction = &action
+			}
+			return nil
+		})
+}
+
+// ChangeStreamPreAndPostImages sets whether the pre- and post-images of the changes to the
+// collection are recorded, so that change streams can return the documents before and after each
+// change. It requires MongoDB 6.0 or later.
+func ChangeStreamPreAndPostImages(b bool) Option {
+	return optionFunc(
+		func(cm *CollMod) error {
+			if cm.ChangeStreamPreAndPostImages == nil {
+				cm.ChangeStreamPreAndPostImages = &b
+			}
+			return nil
+		})
+}
+
+// CollModSessionOpt is a Modify session option.
+type CollModSessionOpt struct{}
+
+func (CollModSessionOpt) collModOption() {}
+
+// ConvertCollModSession implements the CollModSession interface.
+func (CollModSessionOpt) ConvertCollModSession() *session.Client {
+	return nil
+}
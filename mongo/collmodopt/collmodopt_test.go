@@ -0,0 +1,60 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package collmodopt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollModOpt(t *testing.T) {
+	t.Run("Unbundle", func(t *testing.T) {
+		nested := BundleCollMod(ExpireAfterSeconds(60), ValidationLevel(ValidationModerate))
+		bundle := BundleCollMod(IndexName("ttl"), ExpireAfterSeconds(30), nested).
+			ValidationAction(ValidationWarn).
+			ChangeStreamPreAndPostImages(true)
+
+		cm, sess, err := bundle.Unbundle()
+		require.NoError(t, err)
+		require.Nil(t, sess)
+		require.Equal(t, "ttl", *cm.IndexName)
+		require.Equal(t, int64(60), *cm.ExpireAfterSeconds)
+		require.Nil(t, cm.Hidden)
+		require.Equal(t, ValidationModerate, *cm.ValidationLevel)
+		require.Equal(t, ValidationWarn, *cm.ValidationAction)
+		require.True(t, *cm.ChangeStreamPreAndPostImages)
+		require.True(t, cm.ModifiesIndex())
+	})
+	t.Run("NilBundle", func(t *testing.T) {
+		var bundle *CollModBundle
+		cm, _, err := bundle.Unbundle()
+		require.NoError(t, err)
+		require.Equal(t, &CollMod{}, cm)
+		require.False(t, cm.ModifiesIndex())
+	})
+	t.Run("Validate", func(t *testing.T) {
+		cases := []struct {
+			name string
+			opts []Option
+			err  error
+		}{
+			{"validator only", []Option{Validator("validator")}, nil},
+			{"name", []Option{IndexName("ttl"), ExpireAfterSeconds(1)}, nil},
+			{"key pattern", []Option{IndexKeyPattern("keys"), Hidden(true)}, nil},
+			{"no identifier", []Option{ExpireAfterSeconds(1)}, ErrIndexIdentifier},
+			{"both identifiers", []Option{IndexName("ttl"), IndexKeyPattern("keys"), Hidden(true)}, ErrIndexIdentifier},
+			{"no change", []Option{IndexName("ttl")}, ErrNoIndexChange},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, _, err := BundleCollMod(tc.opts...).Unbundle()
+				require.Equal(t, tc.err, err)
+			})
+		}
+	})
+}
@@ -0,0 +1,60 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/mongo/collectionopt"
+	"github.com/mongodb/mongo-go-driver/mongo/countopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollection_CountOptions(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("count", mongotest.OK(bson.EC.Double("n", 3)))
+	d.HandleMatch(func(cmd *mongotest.Command) bool {
+		return cmd.Name == "count" && cmd.Document.Lookup("count").StringValue() == "view" &&
+			cmd.Document.Lookup("hint") != nil
+	}, mongotest.Error(2, "BadValue", "hint is not supported on views"))
+
+	client := newMockClient(t, d)
+	db := client.Database("db")
+
+	t.Run("options are sent", func(t *testing.T) {
+		coll := db.Collection("coll", collectionopt.ReadConcern(readconcern.Majority()))
+		n, err := coll.Count(context.Background(), bson.NewDocument(bson.EC.Int32("x", 1)),
+			countopt.Limit(-10),
+			countopt.Skip(2),
+			countopt.MaxTime(time.Second),
+			countopt.Hint(bson.NewDocument(bson.EC.Int32("x", 1))),
+		)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), n)
+
+		cmd := d.LastCommand("count").Document
+		require.Equal(t, int32(1), cmd.Lookup("query", "x").Int32())
+		require.Equal(t, int64(10), cmd.Lookup("limit").Int64())
+		require.Equal(t, int64(2), cmd.Lookup("skip").Int64())
+		require.Equal(t, int64(1000), cmd.Lookup("maxTimeMS").Int64())
+		require.Equal(t, int32(1), cmd.Lookup("hint", "x").Int32())
+		require.Equal(t, "majority", cmd.Lookup("readConcern", "level").StringValue())
+	})
+	t.Run("hint on a view", func(t *testing.T) {
+		_, err := db.Collection("view").Count(context.Background(), nil, countopt.Hint("x_1"))
+		cerr, ok := err.(command.Error)
+		require.True(t, ok, "expected a command.Error, got %T: %v", err, err)
+		require.Equal(t, int32(2), cerr.Code)
+		require.Equal(t, "hint is not supported on views", cerr.Message)
+	})
+}
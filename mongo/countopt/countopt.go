@@ -8,8 +8,11 @@ package countopt
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
 )
@@ -73,7 +76,18 @@ func (cb *CountBundle) Collation(c *mongoopt.Collation) *CountBundle {
 	return bundle
 }
 
-// Limit adds an option to limit the maximum number of documents to count.
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (cb *CountBundle) Comment(comment interface{}) *CountBundle {
+	bundle := &CountBundle{
+		option: Comment(comment),
+		next:   cb,
+	}
+
+	return bundle
+}
+
+// Limit adds an option to limit the maximum number of documents to count. A negative limit is
+// treated as its absolute value, as it is by the shell.
 func (cb *CountBundle) Limit(i int64) *CountBundle {
 	bundle := &CountBundle{
 		option: Limit(i),
@@ -93,7 +107,8 @@ func (cb *CountBundle) Skip(i int64) *CountBundle {
 	return bundle
 }
 
-// Hint adds an option to specify the index to use.
+// Hint adds an option to specify the index to use, either by name or by its key specification
+// document.
 func (cb *CountBundle) Hint(hint interface{}) *CountBundle {
 	bundle := &CountBundle{
 		option: Hint(hint),
@@ -113,6 +128,38 @@ func (cb *CountBundle) MaxTimeMs(i int32) *CountBundle {
 	return bundle
 }
 
+// MaxTime adds an option to specify the maximum amount of time to allow the operation to run.
+func (cb *CountBundle) MaxTime(d time.Duration) *CountBundle {
+	bundle := &CountBundle{
+		option: MaxTime(d),
+		next:   cb,
+	}
+
+	return bundle
+}
+
+// ReadConcern adds an option to specify the read concern of the operation, overriding the one of
+// the collection.
+func (cb *CountBundle) ReadConcern(rc *readconcern.ReadConcern) *CountBundle {
+	bundle := &CountBundle{
+		option: ReadConcern(rc),
+		next:   cb,
+	}
+
+	return bundle
+}
+
+// ReadPreference adds an option to specify the read preference of the operation, overriding the
+// one of the collection.
+func (cb *CountBundle) ReadPreference(rp *readpref.ReadPref) *CountBundle {
+	bundle := &CountBundle{
+		option: ReadPreference(rp),
+		next:   cb,
+	}
+
+	return bundle
+}
+
 // Unbundle transforms a bundle into a slice of options, optionally deduplicating.
 func (cb *CountBundle) Unbundle(deduplicate bool) ([]option.CountOptioner, *session.Client, error) {
 	options, sess, err := cb.unbundle()
@@ -249,7 +296,15 @@ func Collation(collation *mongoopt.Collation) OptCollation {
 	}
 }
 
-// Limit limits the maximum number of documents to count.
+// Comment specifies a value of any BSON type to help trace the operation through the database
+// profiler, currentOp, and logs. Servers older than 4.4 only accept string comments with
+// CountDocuments, which runs an aggregation.
+func Comment(comment interface{}) OptComment {
+	return OptComment{Comment: comment}
+}
+
+// Limit limits the maximum number of documents to count. A negative limit is treated as its absolute
+// value, as it is by the shell.
 func Limit(i int64) OptLimit {
 	return OptLimit(i)
 }
@@ -259,16 +314,34 @@ func Skip(i int64) OptSkip {
 	return OptSkip(i)
 }
 
-// Hint specifies the index to use.
+// Hint specifies the index to use, either by name or by its key specification document. The server
+// returns an error if a hint is used to count the documents of a view.
 func Hint(hint interface{}) OptHint {
 	return OptHint{hint}
 }
 
-// MaxTimeMs specifies the maximum amount of time to allow the operation to run.
+// MaxTimeMs specifies the maximum amount of time to allow the operation to run, in milliseconds.
 func MaxTimeMs(i int32) OptMaxTimeMs {
 	return OptMaxTimeMs(i)
 }
 
+// MaxTime specifies the maximum amount of time to allow the operation to run. It is rounded down to
+// the millisecond.
+func MaxTime(d time.Duration) OptMaxTimeMs {
+	return OptMaxTimeMs(d / time.Millisecond)
+}
+
+// ReadConcern specifies the read concern of the operation, overriding the one of the collection.
+func ReadConcern(rc *readconcern.ReadConcern) OptReadConcern {
+	return OptReadConcern{rc}
+}
+
+// ReadPreference specifies the read preference of the operation, overriding the one of the
+// collection.
+func ReadPreference(rp *readpref.ReadPref) OptReadPreference {
+	return OptReadPreference{rp}
+}
+
 // OptCollation specifies a collation.
 type OptCollation option.OptCollation
 
@@ -279,12 +352,37 @@ func (opt OptCollation) ConvertCountOption() option.CountOptioner {
 	return option.OptCollation(opt)
 }
 
+// OptComment specifies a value to help trace the operation through the database profiler, currentOp, and logs.
+type OptComment option.OptComment
+
+// ConvertCountOption implements the Count interface.
+func (opt OptComment) ConvertCountOption() option.CountOptioner {
+	return option.OptComment(opt)
+}
+
+// ConvertEstimateDocumentCountOption implements the Count interface.
+func (opt OptComment) ConvertEstimateDocumentCountOption() option.CountOptioner {
+	return option.OptComment(opt)
+}
+
+func (OptComment) estimatedCount() {}
+
+func (OptComment) count() {}
+
 // OptLimit limits the maximum number of documents to count.
 type OptLimit option.OptLimit
 
 // ConvertCountOption implements the Count interface.
 func (opt OptLimit) ConvertCountOption() option.CountOptioner {
-	return option.OptLimit(opt)
+	return option.OptLimit(opt.abs())
+}
+
+// abs returns the absolute value of the limit.
+func (opt OptLimit) abs() int64 {
+	if opt < 0 {
+		return -int64(opt)
+	}
+	return int64(opt)
 }
 
 func (OptLimit) count() {}
@@ -309,23 +407,57 @@ func (opt OptHint) ConvertCountOption() option.CountOptioner {
 
 func (OptHint) count() {}
 
-// OptMaxTimeMs specifies the maximum amount of time to allow the operation to run.
+// OptMaxTimeMs specifies the maximum amount of time to allow the operation to run, in milliseconds.
 type OptMaxTimeMs option.OptMaxTime
 
 // ConvertCountOption implements the Count interface.
 func (opt OptMaxTimeMs) ConvertCountOption() option.CountOptioner {
-	return option.OptMaxTime(opt)
+	return option.OptMaxTime(time.Duration(opt) * time.Millisecond)
 }
 
 // ConvertEstimateDocumentCountOption implements the Count interface.
 func (opt OptMaxTimeMs) ConvertEstimateDocumentCountOption() option.CountOptioner {
-	return option.OptMaxTime(opt)
+	return option.OptMaxTime(time.Duration(opt) * time.Millisecond)
 }
 
 func (OptMaxTimeMs) estimatedCount() {}
 
 func (OptMaxTimeMs) count() {}
 
+// OptReadConcern specifies the read concern of the operation.
+type OptReadConcern option.OptReadConcern
+
+// ConvertCountOption implements the Count interface.
+func (opt OptReadConcern) ConvertCountOption() option.CountOptioner {
+	return option.OptReadConcern(opt)
+}
+
+// ConvertEstimateDocumentCountOption implements the Count interface.
+func (opt OptReadConcern) ConvertEstimateDocumentCountOption() option.CountOptioner {
+	return option.OptReadConcern(opt)
+}
+
+func (OptReadConcern) estimatedCount() {}
+
+func (OptReadConcern) count() {}
+
+// OptReadPreference specifies the read preference of the operation.
+type OptReadPreference option.OptReadPreference
+
+// ConvertCountOption implements the Count interface.
+func (opt OptReadPreference) ConvertCountOption() option.CountOptioner {
+	return option.OptReadPreference(opt)
+}
+
+// ConvertEstimateDocumentCountOption implements the Count interface.
+func (opt OptReadPreference) ConvertEstimateDocumentCountOption() option.CountOptioner {
+	return option.OptReadPreference(opt)
+}
+
+func (OptReadPreference) estimatedCount() {}
+
+func (OptReadPreference) count() {}
+
 // CountSessionOpt is an count session option.
 type CountSessionOpt struct{}
 
@@ -8,9 +8,12 @@ package countopt
 
 import (
 	"testing"
+	"time"
 
 	"reflect"
 
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/bsonassert"
 	"github.com/mongodb/mongo-go-driver/core/option"
 	"github.com/mongodb/mongo-go-driver/internal/testutil/helpers"
 	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
@@ -246,3 +249,45 @@ func TestCountOpt(t *testing.T) {
 		}
 	})
 }
+
+func TestCountOptionElements(t *testing.T) {
+	testCases := []struct {
+		name string
+		opt  CountOption
+		elem *bson.Element
+	}{
+		{"limit", Limit(10), bson.EC.Int64("limit", 10)},
+		{"negative limit", Limit(-10), bson.EC.Int64("limit", 10)},
+		{"skip", Skip(5), bson.EC.Int64("skip", 5)},
+		{"maxTimeMs", MaxTimeMs(1500), bson.EC.Int64("maxTimeMS", 1500)},
+		{"maxTime", MaxTime(2 * time.Second), bson.EC.Int64("maxTimeMS", 2000)},
+		{"hint name", Hint("x_1"), bson.EC.String("hint", "x_1")},
+		{
+			"hint document",
+			Hint(bson.NewDocument(bson.EC.Int32("x", 1))),
+			bson.EC.SubDocumentFromElements("hint", bson.EC.Int32("x", 1)),
+		},
+		{
+			"hint map",
+			Hint(map[string]interface{}{"x": int32(1)}),
+			bson.EC.SubDocumentFromElements("hint", bson.EC.Int32("x", 1)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := bson.NewDocument()
+			err := tc.opt.ConvertCountOption().Option(doc)
+			testhelpers.RequireNil(t, err, "error converting option: %s", err)
+
+			bsonassert.Equal(t, bson.NewDocument(tc.elem), doc)
+		})
+	}
+
+	t.Run("unsupported hint", func(t *testing.T) {
+		err := Hint(42).ConvertCountOption().Option(bson.NewDocument())
+		if err == nil {
+			t.Errorf("expected an error for a hint that is neither a name nor a document")
+		}
+	})
+}
@@ -8,8 +8,11 @@ package countopt
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
 )
 
@@ -161,6 +164,16 @@ func (cb *EstimatedDocumentCountBundle) bundleLength() int {
 	return bundleLen
 }
 
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (cb *EstimatedDocumentCountBundle) Comment(comment interface{}) *EstimatedDocumentCountBundle {
+	bundle := &EstimatedDocumentCountBundle{
+		option: Comment(comment),
+		next:   cb,
+	}
+
+	return bundle
+}
+
 // MaxTimeMs adds an option to specify the maximum amount of time to allow the operation to run.
 func (cb *EstimatedDocumentCountBundle) MaxTimeMs(i int32) *EstimatedDocumentCountBundle {
 	bundle := &EstimatedDocumentCountBundle{
@@ -170,3 +183,35 @@ func (cb *EstimatedDocumentCountBundle) MaxTimeMs(i int32) *EstimatedDocumentCou
 
 	return bundle
 }
+
+// MaxTime adds an option to specify the maximum amount of time to allow the operation to run.
+func (cb *EstimatedDocumentCountBundle) MaxTime(d time.Duration) *EstimatedDocumentCountBundle {
+	bundle := &EstimatedDocumentCountBundle{
+		option: MaxTime(d),
+		next:   cb,
+	}
+
+	return bundle
+}
+
+// ReadConcern adds an option to specify the read concern of the operation, overriding the one of
+// the collection.
+func (cb *EstimatedDocumentCountBundle) ReadConcern(rc *readconcern.ReadConcern) *EstimatedDocumentCountBundle {
+	bundle := &EstimatedDocumentCountBundle{
+		option: ReadConcern(rc),
+		next:   cb,
+	}
+
+	return bundle
+}
+
+// ReadPreference adds an option to specify the read preference of the operation, overriding the
+// one of the collection.
+func (cb *EstimatedDocumentCountBundle) ReadPreference(rp *readpref.ReadPref) *EstimatedDocumentCountBundle {
+	bundle := &EstimatedDocumentCountBundle{
+		option: ReadPreference(rp),
+		next:   cb,
+	}
+
+	return bundle
+}
@@ -10,8 +10,14 @@ import (
 	"context"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/topology"
 )
 
+// ErrCursorServerUnavailable is returned when a cursor is iterated or closed after the server it was
+// created on has left the topology. Cursors only exist on that server, so they are not retargeted.
+var ErrCursorServerUnavailable = topology.ErrCursorServerUnavailable
+
 // Cursor instances iterate a stream of documents. Each document is
 // decoded into the result according to the rules of the bson package.
 //
@@ -40,14 +46,39 @@ type Cursor interface {
 	// Get the ID of the cursor.
 	ID() int64
 
+	// Get the database and collection names of the namespace the server
+	// reported for the cursor, so that generic code can tell where its
+	// documents come from.
+	Namespace() (db, coll string)
+
 	// Get the next result from the cursor.
 	// Returns true if there were no errors and there is a next result.
 	Next(context.Context) bool
 
 	Decode(interface{}) error
 
+	// Returns the current document without copying it. If the client reads
+	// replies into pooled buffers, the document is only valid until the
+	// cursor moves on to the next batch or is closed.
 	DecodeBytes() (bson.Reader, error)
 
+	// Advance to the next batch of documents returned by the server,
+	// skipping the documents of the current batch that have not been
+	// returned by Next. Returns true if there were no errors and the batch
+	// is not empty. Next and NextBatch can be mixed on the same cursor.
+	NextBatch(context.Context) bool
+
+	// Returns the documents of the current batch that have not been
+	// returned by Next. The documents are read in place from the server
+	// reply, and Raw returns the whole batch so it can be forwarded without
+	// decoding.
+	Batch() *command.Batch
+
+	// Set the number of documents requested by each subsequent getMore,
+	// overriding the batch size the cursor was created with. 0 requests the
+	// server default.
+	SetBatchSize(int32)
+
 	// Returns the error status of the cursor
 	Err() error
 
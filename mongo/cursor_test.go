@@ -0,0 +1,328 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/mongodb/mongo-go-driver/mongo/aggregateopt"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+)
+
+func TestCursorGetMoreOptions(t *testing.T) {
+	// lastGetMore iterates cur past its first batch and returns the getMore it sent.
+	lastGetMore := func(t *testing.T, d *mongotest.Deployment, cur Cursor) *bson.Document {
+		require.True(t, cur.Next(context.Background()))
+		require.True(t, cur.Next(context.Background()))
+
+		cmd := d.LastCommand("getMore")
+		require.NotNil(t, cmd, "no getMore command was sent")
+		return cmd.Document
+	}
+	connect := func(t *testing.T, opts ...mongotest.Option) (*mongotest.Deployment, *Collection) {
+		d := mongotest.New(opts...)
+		d.Handle("find", openCursor("firstBatch"))
+		d.Handle("aggregate", openCursor("firstBatch"))
+		d.Handle("getMore", openCursor("nextBatch"))
+		d.Handle("killCursors", mongotest.OK())
+
+		client := newMockClient(t, d)
+		return d, client.Database("db").Collection("coll")
+	}
+
+	t.Run("tailable await find", func(t *testing.T) {
+		d, coll := connect(t)
+
+		cur, err := coll.Find(context.Background(), nil,
+			findopt.Comment("report"),
+			findopt.CursorType(mongoopt.TailableAwait),
+			findopt.MaxAwaitTime(250*time.Millisecond),
+			findopt.BatchSize(3),
+		)
+		require.NoError(t, err)
+		defer func() { _ = cur.Close(context.Background()) }()
+
+		cmd := lastGetMore(t, d, cur)
+		require.Equal(t, "report", cmd.Lookup("comment").StringValue())
+		require.Equal(t, int64(250), cmd.Lookup("maxTimeMS").Int64())
+		require.Equal(t, int32(3), cmd.Lookup("batchSize").Int32())
+		_, err = cmd.LookupErr("maxAwaitTimeMS")
+		require.Error(t, err)
+	})
+	t.Run("non-tailable find", func(t *testing.T) {
+		d, coll := connect(t)
+
+		cur, err := coll.Find(context.Background(), nil, findopt.Comment("report"))
+		require.NoError(t, err)
+		defer func() { _ = cur.Close(context.Background()) }()
+
+		cmd := lastGetMore(t, d, cur)
+		require.Equal(t, "report", cmd.Lookup("comment").StringValue())
+		_, err = cmd.LookupErr("maxTimeMS")
+		require.Error(t, err)
+		_, err = cmd.LookupErr("batchSize")
+		require.Error(t, err)
+	})
+	t.Run("aggregate", func(t *testing.T) {
+		d, coll := connect(t)
+
+		cur, err := coll.Aggregate(context.Background(), bson.NewArray(), aggregateopt.Comment("report"))
+		require.NoError(t, err)
+		defer func() { _ = cur.Close(context.Background()) }()
+
+		cmd := lastGetMore(t, d, cur)
+		require.Equal(t, "report", cmd.Lookup("comment").StringValue())
+	})
+	t.Run("comment before 4.4", func(t *testing.T) {
+		d, coll := connect(t, mongotest.WithMaxWireVersion(8))
+
+		cur, err := coll.Find(context.Background(), nil, findopt.Comment("report"), findopt.BatchSize(3))
+		require.NoError(t, err)
+		defer func() { _ = cur.Close(context.Background()) }()
+
+		cmd := lastGetMore(t, d, cur)
+		_, err = cmd.LookupErr("comment")
+		require.Error(t, err)
+		require.Equal(t, int32(3), cmd.Lookup("batchSize").Int32())
+	})
+}
+
+func TestCursorKilledOnCancel(t *testing.T) {
+	killed := &view.View{Name: "test/cursors_killed_canceled", Measure: observability.MCursorsKilledCanceled, Aggregation: view.Count()}
+	require.NoError(t, view.Register(killed))
+	defer view.Unregister(killed)
+	killedCount := func() int64 {
+		rows, err := view.RetrieveData(killed.Name)
+		require.NoError(t, err)
+		if len(rows) == 0 {
+			return 0
+		}
+		return rows[0].Data.(*view.CountData).Value
+	}
+
+	connect := func(t *testing.T, getMore mongotest.Handler) (*mongotest.Deployment, *Collection) {
+		d := mongotest.New()
+		d.Handle("find", openCursor("firstBatch"))
+		d.Handle("aggregate", openCursor("firstBatch"))
+		d.Handle("getMore", getMore)
+		d.Handle("killCursors", mongotest.OK())
+
+		client := newMockClient(t, d)
+		return d, client.Database("db").Collection("coll")
+	}
+
+	t.Run("next", func(t *testing.T) {
+		d, coll := connect(t, mongotest.Delay(time.Minute, openCursor("nextBatch")))
+		before := killedCount()
+
+		cur, err := coll.Find(context.Background(), nil)
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		require.True(t, cur.Next(ctx))
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		start := time.Now()
+		require.False(t, cur.Next(ctx))
+		require.True(t, time.Since(start) < 10*time.Second, "Next did not return soon after cancellation")
+		require.True(t, errors.Is(cur.Err(), context.Canceled), "expected a cancellation error, got %v", cur.Err())
+
+		require.Equal(t, 1, d.CountCommands("killCursors"))
+		cmd := d.LastCommand("killCursors").Document
+		require.Equal(t, "coll", cmd.Lookup("killCursors").StringValue())
+		id, err := cmd.Lookup("cursors").MutableArray().Lookup(0)
+		require.NoError(t, err)
+		require.Equal(t, int64(42), id.Int64())
+		require.Equal(t, before+1, killedCount())
+
+		require.NoError(t, cur.Close(context.Background()))
+	})
+	t.Run("close", func(t *testing.T) {
+		d, coll := connect(t, openCursor("nextBatch"))
+		before := killedCount()
+
+		cur, err := coll.Find(context.Background(), nil)
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		require.NoError(t, cur.Close(ctx))
+		require.Equal(t, 1, d.CountCommands("killCursors"))
+		require.Equal(t, before+1, killedCount())
+	})
+	t.Run("change stream resume", func(t *testing.T) {
+		d, coll := connect(t, mongotest.Sequence(
+			mongotest.Error(43, "CursorNotFound", "cursor not found"),
+			openCursor("nextBatch"),
+		))
+
+		cs, err := coll.Watch(context.Background(), nil)
+		require.NoError(t, err)
+		defer func() { _ = cs.Close(context.Background()) }()
+		ctx := context.Background()
+		require.True(t, cs.Next(ctx))
+		// the getMore fails, so the change stream kills its cursor and resumes
+		require.True(t, cs.Next(ctx))
+
+		require.Equal(t, 1, d.CountCommands("killCursors"))
+		require.Equal(t, 2, d.CountCommands("aggregate"))
+	})
+}
+
+func TestCursorNamespace(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("find", openCursor("firstBatch"))
+	d.Handle("aggregate", openCursor("firstBatch"))
+	d.Handle("killCursors", mongotest.OK())
+
+	client := newMockClient(t, d)
+	coll := client.Database("db").Collection("coll")
+	require.Equal(t, "coll", coll.Name())
+	require.Equal(t, "db", coll.Database().Name())
+	require.True(t, coll.Database().Client() == client)
+
+	cur, err := coll.Find(context.Background(), nil)
+	require.NoError(t, err)
+	defer func() { _ = cur.Close(context.Background()) }()
+	db, name := cur.Namespace()
+	require.Equal(t, "db", db)
+	require.Equal(t, "coll", name)
+
+	cs, err := coll.Watch(context.Background(), nil)
+	require.NoError(t, err)
+	defer func() { _ = cs.Close(context.Background()) }()
+	db, name = cs.Namespace()
+	require.Equal(t, "db", db)
+	require.Equal(t, "coll", name)
+}
+
+func TestCursorPrefetch(t *testing.T) {
+	// batch replies a cursor with the given id and a batch of the documents with the given _ids.
+	batch := func(name string, id int64, ids ...int32) mongotest.Handler {
+		docs := make([]*bson.Value, 0, len(ids))
+		for _, i := range ids {
+			docs = append(docs, bson.VC.DocumentFromElements(bson.EC.Int32("_id", i)))
+		}
+		return mongotest.OK(bson.EC.SubDocumentFromElements("cursor",
+			bson.EC.Int64("id", id),
+			bson.EC.String("ns", "db.coll"),
+			bson.EC.ArrayFromElements(name, docs...),
+		))
+	}
+	connect := func(t *testing.T, getMore mongotest.Handler) (*mongotest.Deployment, *Collection) {
+		d := mongotest.New()
+		d.Handle("find", batch("firstBatch", 7, 0, 1))
+		d.Handle("getMore", getMore)
+		d.Handle("killCursors", mongotest.OK())
+
+		client := newMockClient(t, d)
+		return d, client.Database("db").Collection("coll")
+	}
+	// awaitGetMores waits until n getMores have been sent, since prefetched getMores are sent in
+	// the background.
+	awaitGetMores := func(t *testing.T, d *mongotest.Deployment, n int) {
+		deadline := time.Now().Add(5 * time.Second)
+		for d.CountCommands("getMore") < n {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %d getMores, got %d", n, d.CountCommands("getMore"))
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	next := func(t *testing.T, cur Cursor, id int32) {
+		require.True(t, cur.Next(context.Background()), "Next failed: %v", cur.Err())
+		doc := bson.NewDocument()
+		require.NoError(t, cur.Decode(doc))
+		require.Equal(t, id, doc.Lookup("_id").Int32())
+	}
+
+	t.Run("iteration", func(t *testing.T) {
+		d, coll := connect(t, mongotest.Sequence(batch("nextBatch", 7, 2, 3), batch("nextBatch", 0, 4)))
+
+		cur, err := coll.Find(context.Background(), nil, findopt.Prefetch(true))
+		require.NoError(t, err)
+		require.Equal(t, 0, d.CountCommands("getMore"))
+
+		// the next batch is requested with the first document of the current one
+		next(t, cur, 0)
+		awaitGetMores(t, d, 1)
+		next(t, cur, 1)
+		next(t, cur, 2)
+		awaitGetMores(t, d, 2)
+		next(t, cur, 3)
+		next(t, cur, 4)
+		require.False(t, cur.Next(context.Background()))
+		require.NoError(t, cur.Err())
+
+		require.NoError(t, cur.Close(context.Background()))
+		require.Equal(t, 2, d.CountCommands("getMore"))
+	})
+	t.Run("error", func(t *testing.T) {
+		d, coll := connect(t, mongotest.Error(13, "Unauthorized", "not authorized"))
+
+		cur, err := coll.Find(context.Background(), nil, findopt.Prefetch(true))
+		require.NoError(t, err)
+		defer func() { _ = cur.Close(context.Background()) }()
+
+		// the documents already received are returned before the error of the getMore
+		next(t, cur, 0)
+		awaitGetMores(t, d, 1)
+		next(t, cur, 1)
+		require.NoError(t, cur.Err())
+		require.False(t, cur.Next(context.Background()))
+		require.Error(t, cur.Err())
+		require.Contains(t, cur.Err().Error(), "not authorized")
+		require.Equal(t, 1, d.CountCommands("getMore"))
+	})
+	t.Run("close", func(t *testing.T) {
+		d, coll := connect(t, mongotest.Delay(time.Minute, batch("nextBatch", 7, 2)))
+
+		cur, err := coll.Find(context.Background(), nil, findopt.Prefetch(true))
+		require.NoError(t, err)
+		next(t, cur, 0)
+		awaitGetMores(t, d, 1)
+
+		start := time.Now()
+		require.NoError(t, cur.Close(context.Background()))
+		require.True(t, time.Since(start) < 10*time.Second, "Close waited for the prefetched getMore")
+		require.Equal(t, 1, d.CountCommands("killCursors"))
+	})
+	t.Run("cancel", func(t *testing.T) {
+		d, coll := connect(t, mongotest.Delay(time.Minute, batch("nextBatch", 7, 2)))
+
+		cur, err := coll.Find(context.Background(), nil, findopt.Prefetch(true))
+		require.NoError(t, err)
+		defer func() { _ = cur.Close(context.Background()) }()
+		next(t, cur, 0)
+		next(t, cur, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		require.False(t, cur.Next(ctx))
+		require.True(t, errors.Is(cur.Err(), context.Canceled), "expected a cancellation error, got %v", cur.Err())
+		require.Equal(t, 1, d.CountCommands("getMore"))
+		require.Equal(t, 1, d.CountCommands("killCursors"))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		d, coll := connect(t, batch("nextBatch", 0, 2))
+
+		cur, err := coll.Find(context.Background(), nil)
+		require.NoError(t, err)
+		defer func() { _ = cur.Close(context.Background()) }()
+		next(t, cur, 0)
+		time.Sleep(50 * time.Millisecond)
+		require.Equal(t, 0, d.CountCommands("getMore"))
+	})
+}
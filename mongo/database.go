@@ -8,25 +8,24 @@ package mongo
 
 import (
 	"context"
-	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/command"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/dispatch"
+	"github.com/mongodb/mongo-go-driver/core/option"
 	"github.com/mongodb/mongo-go-driver/core/readconcern"
 	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/writeconcern"
+	"github.com/mongodb/mongo-go-driver/mongo/aggregateopt"
 	"github.com/mongodb/mongo-go-driver/mongo/collectionopt"
 	"github.com/mongodb/mongo-go-driver/mongo/dbopt"
 	"github.com/mongodb/mongo-go-driver/mongo/listcollectionopt"
 	"github.com/mongodb/mongo-go-driver/mongo/runcmdopt"
 
 	"github.com/mongodb/mongo-go-driver/internal/observability"
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
-	"go.opencensus.io/trace"
 )
 
 // Database performs operations on a given database.
@@ -36,8 +35,14 @@ type Database struct {
 	readConcern    *readconcern.ReadConcern
 	writeConcern   *writeconcern.WriteConcern
 	readPreference *readpref.ReadPref
+	registry       *bson.Registry
 	readSelector   description.ServerSelector
 	writeSelector  description.ServerSelector
+	// selector is the selector supplied with the ServerSelector option, if any.
+	selector description.ServerSelector
+	// nameErr is the reason name is not a valid database name, if it is not. Operations against
+	// the database and its collections fail with it before selecting a server.
+	nameErr error
 }
 
 func newDatabase(client *Client, name string, opts ...dbopt.Option) *Database {
@@ -61,24 +66,59 @@ func newDatabase(client *Client, name string, opts ...dbopt.Option) *Database {
 		wc = dbOpt.WriteConcern
 	}
 
+	reg := client.registry
+	if dbOpt.Registry != nil {
+		reg = dbOpt.Registry
+	}
+
 	db := &Database{
 		client:         client,
 		name:           name,
 		readPreference: rp,
 		readConcern:    rc,
 		writeConcern:   wc,
+		registry:       reg,
+		selector:       dbOpt.ServerSelector,
 	}
 
-	db.readSelector = description.CompositeSelector([]description.ServerSelector{
-		description.ReadPrefSelector(db.readPreference),
-		description.LatencySelector(db.client.localThreshold),
-	})
-
-	db.writeSelector = description.WriteSelector()
+	if dbOpt.AllowNonPortableName != nil && *dbOpt.AllowNonPortableName {
+		db.nameErr = command.ValidateNonPortableDatabaseName(name)
+	} else {
+		db.nameErr = command.ValidateDatabaseName(name)
+	}
+	db.setSelectors()
 
 	return db
 }
 
+// setSelectors sets the selectors of the read and write operations run against the database.
+func (db *Database) setSelectors() {
+	switch {
+	case db.nameErr != nil:
+		db.readSelector = failingSelector(db.nameErr)
+		db.writeSelector = db.readSelector
+	case db.selector != nil:
+		db.readPreference = selectedReadPref(db.readPreference)
+		db.readSelector = db.selector
+		db.writeSelector = writeSelector(db.selector)
+	default:
+		db.readSelector = description.CompositeSelector([]description.ServerSelector{
+			description.ReadPrefSelector(db.readPreference),
+			description.LatencySelector(db.client.localThreshold),
+		})
+		db.writeSelector = description.WriteSelector()
+	}
+}
+
+// readPrefSelector returns the read preference and the selector of a read against the database run
+// with the ReadPreference option rp, or with the read preference of the database if rp is nil.
+func (db *Database) readPrefSelector(rp *readpref.ReadPref) (*readpref.ReadPref, description.ServerSelector) {
+	if rp == nil || db.nameErr != nil {
+		return db.readPreference, db.readSelector
+	}
+	return readSelector(db.client, db.selector, rp)
+}
+
 // Client returns the Client the database was created from.
 func (db *Database) Client() *Client {
 	return db.client
@@ -102,21 +142,110 @@ func (db *Database) RunCommand(ctx context.Context, runCommand interface{}, opts
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "db_runcommand"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Database).RunCommand")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "db_runcommand"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Database).RunCommand")
+	defer span.End()
 
-	runCmd, sess, err := runcmdopt.BundleRunCmd(opts...).Unbundle()
+	cmd, selector, err := db.runCommandRead(ctx, runCommand, opts...)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "runcmdopt_bundlerun"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return nil, err
 	}
+
+	br, err := dispatch.Read(ctx,
+		cmd,
+		db.client.topology,
+		selector,
+		db.client.id,
+		db.client.topology.SessionPool,
+	)
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+	}
+	return br, nil
+}
+
+// RunCommandRaw runs a command on the database like RunCommand, and returns its reply along with
+// the address of the server that ran it, the duration of the round trip and the request ID of the
+// command, which is what a shell needs to display. A command that fails with ok: 0 returns a
+// command.Error whose Raw field holds the reply. A user can supply a custom context to this
+// method, or nil to default to context.Background().
+func (db *Database) RunCommandRaw(ctx context.Context, runCommand interface{}, opts ...runcmdopt.Option) (command.Reply, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "db_runcommandraw"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Database).RunCommandRaw")
+	defer span.End()
+
+	cmd, selector, err := db.runCommandRead(ctx, runCommand, opts...)
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+		return command.Reply{}, err
+	}
+
+	reply, err := dispatch.ReadWithInfo(ctx,
+		cmd,
+		db.client.topology,
+		selector,
+		db.client.id,
+		db.client.topology.SessionPool,
+	)
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+	}
+	return reply, err
+}
+
+// RunCommandCursor runs a command that returns a cursor on the database, such as listCollections
+// or aggregate, and returns the cursor, whose getMores are sent to the server that ran the command.
+// The command is run like RunCommand and accepts the same options. It returns
+// command.ErrNoCursor if the reply has no cursor document. A user can supply a custom context to
+// this method, or nil to default to context.Background().
+func (db *Database) RunCommandCursor(ctx context.Context, runCommand interface{}, opts ...runcmdopt.Option) (Cursor, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "db_runcommandcursor"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Database).RunCommandCursor")
+	defer span.End()
+
+	cmd, selector, err := db.runCommandRead(ctx, runCommand, opts...)
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+		return nil, err
+	}
+
+	cur, err := dispatch.ReadCursor(ctx,
+		cmd,
+		db.client.topology,
+		selector,
+		db.client.id,
+		db.client.topology.SessionPool,
+	)
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+		return nil, err
+	}
+	return cur, nil
+}
+
+// runCommandRead returns the command RunCommand, RunCommandRaw and RunCommandCursor send, and the
+// selector of the server to send it to.
+func (db *Database) runCommandRead(ctx context.Context, runCommand interface{},
+	opts ...runcmdopt.Option) (command.Read, description.ServerSelector, error) {
+
+	if db.nameErr != nil {
+		return command.Read{}, nil, db.nameErr
+	}
+
+	runCmd, sess, err := runcmdopt.BundleRunCmd(opts...).Unbundle()
+	if err != nil {
+		observability.RecordError(ctx, "runcmdopt_bundlerun", err)
+		return command.Read{}, nil, err
+	}
 	rp := runCmd.ReadPreference
 	if rp == nil {
 		if sess != nil && sess.TransactionRunning() {
@@ -127,47 +256,136 @@ func (db *Database) RunCommand(ctx context.Context, runCommand interface{}, opts
 		}
 	}
 
-	runCmdDoc, err := TransformDocument(runCommand)
+	// commands run against an explicitly selected server whatever its type, so that commands that
+	// are allowed on secondaries, such as compact, can run there
+	selector := db.writeSelector
+	switch {
+	case runCmd.ServerSelector != nil:
+		selector = runCmd.ServerSelector
+		rp = selectedReadPref(rp)
+	case db.selector != nil:
+		selector = db.selector
+	case runCmd.ReadPreference != nil:
+		// commands run with an explicit read preference, such as aggregate-like admin commands,
+		// are routed like reads
+		selector = description.CompositeSelector([]description.ServerSelector{
+			description.ReadPrefSelector(rp),
+			description.LatencySelector(db.client.localThreshold),
+		})
+	}
+
+	runCmdDoc, err := transformRequiredDocument(db.registry, "command", runCommand)
+	if err != nil {
+		observability.RecordError(ctx, "transform_doc", err)
+		return command.Read{}, nil, err
+	}
+
+	return command.Read{
+		DB:       db.Name(),
+		Command:  runCmdDoc,
+		ReadPref: rp,
+		Session:  sess,
+		Clock:    db.client.clock,
+	}, selector, nil
+}
+
+// Aggregate runs an aggregation framework pipeline against the database, for stages that do not
+// read from a collection such as $currentOp, which must run against the admin database, and
+// $listLocalSessions. A user can supply a custom context to this method, or nil to default to
+// context.Background().
+//
+// See https://docs.mongodb.com/manual/reference/command/aggregate/.
+//
+// The pipeline accepts the same types as the pipeline of Collection.Aggregate.
+func (db *Database) Aggregate(ctx context.Context, pipeline interface{},
+	opts ...aggregateopt.Aggregate) (Cursor, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "db_aggregate"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Database).Aggregate")
+	defer span.End()
+
+	pipelineArr, err := transformAggregatePipeline(db.registry, pipeline)
+	if err != nil {
+		observability.RecordError(ctx, "transform_aggregate_pipeline", err)
+		span.SetStatus(observability.SpanStatus(err))
+		return nil, err
+	}
+
+	if c := db.client.comment; c != nil {
+		opts = append([]aggregateopt.Aggregate{aggregateopt.OptComment(*c)}, opts...)
+	}
+	aggOpts, sess, err := aggregateopt.BundleAggregate(opts...).Unbundle(true)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "transform_doc"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
 		return nil, err
 	}
-	br, err := dispatch.Read(ctx,
-		command.Read{
-			DB:       db.Name(),
-			Command:  runCmdDoc,
-			ReadPref: rp,
-			Session:  sess,
-			Clock:    db.client.clock,
-		},
+
+	err = db.client.ValidSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	wc := db.writeConcern
+	if sess != nil && sess.TransactionRunning() {
+		wc = nil
+	}
+
+	rc := db.readConcern
+	if sess != nil && (sess.TransactionInProgress()) {
+		rc = nil
+	}
+
+	rp, selector := db.readPrefSelector(nil)
+	var opRP *readpref.ReadPref
+	for _, opt := range aggOpts {
+		if o, ok := opt.(option.OptReadPreference); ok {
+			opRP = o.ReadPreference
+			rp, selector = db.readPrefSelector(opRP)
+		}
+	}
+
+	cmd := command.Aggregate{
+		NS:           command.Namespace{DB: db.name},
+		Pipeline:     pipelineArr,
+		Opts:         aggOpts,
+		ReadPref:     rp,
+		WriteConcern: wc,
+		ReadConcern:  rc,
+		Session:      sess,
+		Clock:        db.client.clock,
+	}
+	if opRP != nil && opRP.Mode() != readpref.PrimaryMode && cmd.HasDollarOut() {
+		return nil, ErrOutReadPreference
+	}
+
+	cur, err := dispatch.Aggregate(
+		ctx, cmd,
 		db.client.topology,
+		selector,
 		db.writeSelector,
 		db.client.id,
 		db.client.topology.SessionPool,
 	)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "dispatch_read"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		// dispatch.Aggregate already sets error metrics
+		span.SetStatus(observability.SpanStatus(err))
 	}
-	return br, nil
+	return cur, err
 }
 
-// Drop drops this database from mongodb.
+// Drop drops this database from mongodb with the write concern of the database. Dropping a
+// database that does not exist succeeds.
 func (db *Database) Drop(ctx context.Context, opts ...dbopt.DropDB) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "db_drop"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(*Database).Drop")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "db_drop"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Database).Drop")
+	defer span.End()
 
 	var sess *session.Client
 	for _, opt := range opts {
@@ -181,10 +399,16 @@ func (db *Database) Drop(ctx context.Context, opts ...dbopt.DropDB) error {
 		return err
 	}
 
+	wc := db.writeConcern
+	if sess != nil && sess.TransactionRunning() {
+		wc = nil
+	}
+
 	cmd := command.DropDatabase{
-		DB:      db.name,
-		Session: sess,
-		Clock:   db.client.clock,
+		DB:           db.name,
+		WriteConcern: wc,
+		Session:      sess,
+		Clock:        db.client.clock,
 	}
 	_, err = dispatch.DropDatabase(
 		ctx, cmd,
@@ -194,9 +418,7 @@ func (db *Database) Drop(ctx context.Context, opts ...dbopt.DropDB) error {
 		db.client.topology.SessionPool,
 	)
 	if err != nil && !command.IsNotFound(err) {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "dispatch_dropdatabase"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 		return err
 	}
 	return nil
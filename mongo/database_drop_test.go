@@ -0,0 +1,66 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/writeconcern"
+	"github.com/mongodb/mongo-go-driver/mongo/dbopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseDropMock(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("ping", mongotest.OK())
+
+	client := newMockClient(t, d)
+
+	db := client.Database("db", dbopt.WriteConcern(writeconcern.New(writeconcern.WMajority())))
+	ctx := context.Background()
+	last := func(name string) *bson.Document {
+		cmd := d.LastCommand(name)
+		require.NotNil(t, cmd, "no %s command was sent", name)
+		return cmd.Document
+	}
+
+	t.Run("write concern", func(t *testing.T) {
+		d.Handle("dropDatabase", mongotest.OK(bson.EC.String("dropped", "db")))
+
+		require.NoError(t, db.Drop(ctx))
+		cmd := last("dropDatabase")
+		require.Equal(t, "db", cmd.Lookup("$db").StringValue())
+		require.Equal(t, "majority", cmd.Lookup("writeConcern", "w").StringValue())
+	})
+	t.Run("not found", func(t *testing.T) {
+		d.Handle("dropDatabase", mongotest.Reply(bson.NewDocument(
+			bson.EC.Int32("ok", 0),
+			bson.EC.Int32("code", 26),
+			bson.EC.String("errmsg", "database not found"),
+			bson.EC.SubDocumentFromElements("$clusterTime", bson.EC.Timestamp("clusterTime", 1234, 5)),
+		)))
+
+		require.NoError(t, db.Drop(ctx))
+
+		// the cluster time of the reply is gossiped even though the command failed
+		_, err := db.RunCommand(ctx, bson.NewDocument(bson.EC.Int32("ping", 1)))
+		require.NoError(t, err)
+		ts, i := last("ping").Lookup("$clusterTime", "clusterTime").Timestamp()
+		require.Equal(t, uint32(1234), ts)
+		require.Equal(t, uint32(5), i)
+	})
+	t.Run("error", func(t *testing.T) {
+		d.Handle("dropDatabase", mongotest.Error(13, "Unauthorized", "not authorized"))
+
+		err := db.Drop(ctx)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not authorized")
+	})
+}
@@ -9,6 +9,8 @@ package dbopt
 import (
 	"reflect"
 
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/readconcern"
 	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
@@ -30,6 +32,10 @@ type Database struct {
 	ReadConcern    *readconcern.ReadConcern
 	WriteConcern   *writeconcern.WriteConcern
 	ReadPreference *readpref.ReadPref
+	Registry       *bson.Registry
+	ServerSelector description.ServerSelector
+	// AllowNonPortableName is set by the AllowNonPortableName option.
+	AllowNonPortableName *bool
 }
 
 // DatabaseBundle is a bundle of database options.
@@ -102,6 +108,31 @@ func (db *DatabaseBundle) ReadPreference(rp *readpref.ReadPref) *DatabaseBundle
 	}
 }
 
+// Registry sets the registry used to encode documents and decode results.
+func (db *DatabaseBundle) Registry(r *bson.Registry) *DatabaseBundle {
+	return &DatabaseBundle{
+		option: Registry(r),
+		next:   db,
+	}
+}
+
+// ServerSelector sets the selector used to select the server operations run against.
+func (db *DatabaseBundle) ServerSelector(ss description.ServerSelector) *DatabaseBundle {
+	return &DatabaseBundle{
+		option: ServerSelector(ss),
+		next:   db,
+	}
+}
+
+// AllowNonPortableName sets whether the database name may contain characters only servers running
+// on Windows reject.
+func (db *DatabaseBundle) AllowNonPortableName(b bool) *DatabaseBundle {
+	return &DatabaseBundle{
+		option: AllowNonPortableName(b),
+		next:   db,
+	}
+}
+
 // Unbundle unbundles the options, returning a collection.
 func (db *DatabaseBundle) Unbundle() (*Database, error) {
 	database := &Database{}
@@ -188,3 +219,42 @@ func ReadPreference(rp *readpref.ReadPref) Option {
 			return nil
 		})
 }
+
+// Registry sets the registry used to encode documents and decode results.
+func Registry(r *bson.Registry) Option {
+	return optionFunc(
+		func(d *Database) error {
+			if d.Registry == nil {
+				d.Registry = r
+			}
+			return nil
+		})
+}
+
+// ServerSelector sets the selector used to select the server operations run against, in place of
+// the selectors derived from the read preference. It can pin operations to a single server with
+// mongo.HostSelector. Write operations fail rather than run against a selected server that cannot
+// accept writes.
+func ServerSelector(ss description.ServerSelector) Option {
+	return optionFunc(
+		func(d *Database) error {
+			if d.ServerSelector == nil {
+				d.ServerSelector = ss
+			}
+			return nil
+		})
+}
+
+// AllowNonPortableName sets whether the database name may contain '*', '<', '>', ':', '|' or '?'.
+// Names with these characters are legal on servers running on Linux and macOS, but not on servers
+// running on Windows, so operations against such a database fail before being sent unless this
+// option is true.
+func AllowNonPortableName(b bool) Option {
+	return optionFunc(
+		func(d *Database) error {
+			if d.AllowNonPortableName == nil {
+				d.AllowNonPortableName = &b
+			}
+			return nil
+		})
+}
@@ -0,0 +1,62 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/collectionopt"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+)
+
+// ErrDBRefNoCollection is returned by ResolveDBRef when given a reference without a collection.
+var ErrDBRefNoCollection = errors.New("mongo: the DBRef has no collection")
+
+// ResolveDBRef finds the document ref refers to and decodes it into out as DocumentResult.Decode
+// does, returning ErrNoDocuments if there is no such document. A reference without a database
+// refers to the database of the collection. The document is read with the read preference, read
+// concern and registry of the collection, and opts. A user can supply a custom context to this
+// method, or nil to default to context.Background().
+func (coll *Collection) ResolveDBRef(ctx context.Context, ref bson.DBRef, out interface{},
+	opts ...findopt.One) error {
+
+	if ref.Collection == "" {
+		return ErrDBRefNoCollection
+	}
+
+	// the _id is encoded as the $id of the reference is
+	b, err := ref.MarshalBSON()
+	if err != nil {
+		return err
+	}
+	idElem, err := bson.Reader(b).Lookup("$id")
+	if err != nil {
+		return err
+	}
+	id, err := bson.EC.InterfaceErr("_id", idElem.Value())
+	if err != nil {
+		return err
+	}
+	filter := bson.NewDocument(id)
+
+	db := coll.db
+	if ref.DB != "" && ref.DB != db.name {
+		db = coll.client.Database(ref.DB)
+	}
+	collOpts := []collectionopt.Option{
+		collectionopt.ReadConcern(coll.readConcern),
+		collectionopt.ReadPreference(coll.readPreference),
+		collectionopt.Registry(coll.registry),
+	}
+	if coll.selector != nil {
+		collOpts = append(collOpts, collectionopt.ServerSelector(coll.selector))
+	}
+
+	return db.Collection(ref.Collection, collOpts...).FindOne(ctx, filter, opts...).Decode(out)
+}
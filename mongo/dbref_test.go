@@ -0,0 +1,68 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
+	"github.com/mongodb/mongo-go-driver/mongo/collectionopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDBRef(t *testing.T) {
+	oid, err := objectid.FromHex("5b3b6b7b7b7b7b7b7b7b7b7b")
+	require.NoError(t, err)
+
+	d := mongotest.New()
+	client := newMockClient(t, d)
+
+	coll := client.Database("app").Collection("posts", collectionopt.ReadPreference(readpref.SecondaryPreferred()))
+	lastFind := func(t *testing.T) *mongotest.Command {
+		last := d.LastCommand("find")
+		require.NotNil(t, last, "no find command sent")
+		return last
+	}
+
+	t.Run("current database", func(t *testing.T) {
+		d.Handle("find", mongotest.Cursor("app.users",
+			bson.NewDocument(bson.EC.ObjectID("_id", oid), bson.EC.String("name", "ada"))))
+
+		var user struct {
+			Name string `bson:"name"`
+		}
+		err := coll.ResolveDBRef(context.Background(), bson.DBRef{Collection: "users", ID: oid}, &user)
+		require.NoError(t, err)
+		require.Equal(t, "ada", user.Name)
+
+		find := lastFind(t)
+		require.Equal(t, "app", find.Database)
+		require.Equal(t, "users", find.Document.Lookup("find").StringValue())
+		require.Equal(t, oid, find.Document.Lookup("filter", "_id").ObjectID())
+		require.Equal(t, "secondaryPreferred", find.Document.Lookup("$readPreference", "mode").StringValue())
+	})
+	t.Run("other database", func(t *testing.T) {
+		d.Handle("find", mongotest.Cursor("billing.accounts"))
+
+		ref := bson.DBRef{Collection: "accounts", ID: bson.Binary{Subtype: 4, Data: make([]byte, 16)}, DB: "billing"}
+		err := coll.ResolveDBRef(context.Background(), ref, nil)
+		require.Equal(t, ErrNoDocuments, err)
+
+		find := lastFind(t)
+		require.Equal(t, "billing", find.Database)
+		subtype, _ := find.Document.Lookup("filter", "_id").Binary()
+		require.Equal(t, byte(4), subtype)
+	})
+	t.Run("no collection", func(t *testing.T) {
+		err := coll.ResolveDBRef(context.Background(), bson.DBRef{ID: oid}, nil)
+		require.Equal(t, ErrDBRefNoCollection, err)
+	})
+}
@@ -72,6 +72,36 @@ func (db *DeleteBundle) Collation(c *mongoopt.Collation) *DeleteBundle {
 	return bundle
 }
 
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (db *DeleteBundle) Comment(comment interface{}) *DeleteBundle {
+	bundle := &DeleteBundle{
+		option: Comment(comment),
+		next:   db,
+	}
+
+	return bundle
+}
+
+// Let adds an option to specify a document of variables that can be accessed in the filter.
+func (db *DeleteBundle) Let(let interface{}) *DeleteBundle {
+	bundle := &DeleteBundle{
+		option: Let(let),
+		next:   db,
+	}
+
+	return bundle
+}
+
+// Ordered adds an option to specify whether the delete statements stop at the first write error.
+func (db *DeleteBundle) Ordered(b bool) *DeleteBundle {
+	bundle := &DeleteBundle{
+		option: Ordered(b),
+		next:   db,
+	}
+
+	return bundle
+}
+
 // Unbundle transforms a bundle into a slice of options, optionally deduplicating
 func (db *DeleteBundle) Unbundle(deduplicate bool) ([]option.DeleteOptioner, *session.Client, error) {
 
@@ -217,6 +247,54 @@ func (opt OptCollation) ConvertDeleteOption() option.DeleteOptioner {
 	return option.OptCollation(opt)
 }
 
+// Comment specifies a value of any BSON type to help trace the operation through the database
+// profiler, currentOp, and logs. It requires server version 4.4 or newer.
+func Comment(comment interface{}) OptComment {
+	return OptComment{Comment: comment}
+}
+
+// OptComment specifies a value to help trace the operation through the database profiler, currentOp, and logs.
+type OptComment option.OptComment
+
+func (OptComment) delete() {}
+
+// ConvertDeleteOption implements the Delete interface.
+func (opt OptComment) ConvertDeleteOption() option.DeleteOptioner {
+	return option.OptComment(opt)
+}
+
+// Let specifies a document of variables that can be accessed in the filter using $$var. It is set on
+// the delete command rather than on each statement and requires server version 5.0 or newer.
+func Let(let interface{}) OptLet {
+	return OptLet{let}
+}
+
+// OptLet specifies a document of variables that can be accessed in the filter.
+type OptLet option.OptLet
+
+func (OptLet) delete() {}
+
+// ConvertDeleteOption implements the Delete interface.
+func (opt OptLet) ConvertDeleteOption() option.DeleteOptioner {
+	return option.OptLet(opt)
+}
+
+// Ordered specifies whether the delete statements stop at the first write error. If false, the
+// remaining statements are executed, including those of later batches.
+func Ordered(b bool) OptOrdered {
+	return OptOrdered(b)
+}
+
+// OptOrdered specifies whether the delete statements stop at the first write error.
+type OptOrdered option.OptOrdered
+
+func (OptOrdered) delete() {}
+
+// ConvertDeleteOption implements the Delete interface.
+func (opt OptOrdered) ConvertDeleteOption() option.DeleteOptioner {
+	return option.OptOrdered(opt)
+}
+
 // DeleteSessionOpt is an delete session option.
 type DeleteSessionOpt struct{}
 
@@ -142,6 +142,7 @@ func TestDeleteOpt(t *testing.T) {
 
 		opts := []DeleteOption{
 			Collation(c),
+			Ordered(false),
 		}
 		params := make([]Delete, len(opts))
 		for i := range opts {
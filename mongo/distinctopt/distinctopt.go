@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
 )
@@ -70,6 +72,15 @@ func (db *DistinctBundle) Collation(collation *mongoopt.Collation) *DistinctBund
 	return bundle
 }
 
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (db *DistinctBundle) Comment(comment interface{}) *DistinctBundle {
+	bundle := &DistinctBundle{
+		option: Comment(comment),
+		next:   db,
+	}
+	return bundle
+}
+
 // MaxTime adds an option to specify the maximum amount of time to allow the query to run.
 func (db *DistinctBundle) MaxTime(d time.Duration) *DistinctBundle {
 	bundle := &DistinctBundle{
@@ -79,6 +90,26 @@ func (db *DistinctBundle) MaxTime(d time.Duration) *DistinctBundle {
 	return bundle
 }
 
+// ReadConcern adds an option to specify the read concern of the operation, overriding the one of
+// the collection.
+func (db *DistinctBundle) ReadConcern(rc *readconcern.ReadConcern) *DistinctBundle {
+	bundle := &DistinctBundle{
+		option: ReadConcern(rc),
+		next:   db,
+	}
+	return bundle
+}
+
+// ReadPreference adds an option to specify the read preference of the operation, overriding the
+// one of the collection.
+func (db *DistinctBundle) ReadPreference(rp *readpref.ReadPref) *DistinctBundle {
+	bundle := &DistinctBundle{
+		option: ReadPreference(rp),
+		next:   db,
+	}
+	return bundle
+}
+
 // Unbundle transofrms a bundle into a slice of DistinctOptioner, optionally deduplicating.
 func (db *DistinctBundle) Unbundle(deduplicate bool) ([]option.DistinctOptioner, *session.Client, error) {
 	options, sess, err := db.unbundle()
@@ -215,11 +246,28 @@ func Collation(collation *mongoopt.Collation) OptCollation {
 	}
 }
 
+// Comment specifies a value of any BSON type to help trace the operation through the database
+// profiler, currentOp, and logs. It requires server version 4.4 or newer.
+func Comment(comment interface{}) OptComment {
+	return OptComment{Comment: comment}
+}
+
 // MaxTime adds an optin to specify the maximum amount of time to allow the query to run.
 func MaxTime(d time.Duration) OptMaxTime {
 	return OptMaxTime(d)
 }
 
+// ReadConcern specifies the read concern of the operation, overriding the one of the collection.
+func ReadConcern(rc *readconcern.ReadConcern) OptReadConcern {
+	return OptReadConcern{rc}
+}
+
+// ReadPreference specifies the read preference of the operation, overriding the one of the
+// collection.
+func ReadPreference(rp *readpref.ReadPref) OptReadPreference {
+	return OptReadPreference{rp}
+}
+
 // OptCollation specifies a collation
 type OptCollation option.OptCollation
 
@@ -230,6 +278,16 @@ func (opt OptCollation) ConvertDistinctOption() option.DistinctOptioner {
 	return option.OptCollation(opt)
 }
 
+// OptComment specifies a value to help trace the operation through the database profiler, currentOp, and logs.
+type OptComment option.OptComment
+
+func (OptComment) distinct() {}
+
+// ConvertDistinctOption implements the Distinct interface.
+func (opt OptComment) ConvertDistinctOption() option.DistinctOptioner {
+	return option.OptComment(opt)
+}
+
 // OptMaxTime specifies the maximum amount of time to allow the query to run.
 type OptMaxTime option.OptMaxTime
 
@@ -240,6 +298,26 @@ func (opt OptMaxTime) ConvertDistinctOption() option.DistinctOptioner {
 	return option.OptMaxTime(opt)
 }
 
+// OptReadConcern specifies the read concern of the operation.
+type OptReadConcern option.OptReadConcern
+
+// OptReadPreference specifies the read preference of the operation.
+type OptReadPreference option.OptReadPreference
+
+func (OptReadConcern) distinct() {}
+
+func (OptReadPreference) distinct() {}
+
+// ConvertDistinctOption implements the Distinct interface.
+func (opt OptReadConcern) ConvertDistinctOption() option.DistinctOptioner {
+	return option.OptReadConcern(opt)
+}
+
+// ConvertDistinctOption implements the Distinct interface.
+func (opt OptReadPreference) ConvertDistinctOption() option.DistinctOptioner {
+	return option.OptReadPreference(opt)
+}
+
 // DistinctSessionOpt is an distinct session option.
 type DistinctSessionOpt struct{}
 
@@ -11,6 +11,7 @@ import (
 	"errors"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/result"
 )
 
 // ErrNoDocuments is returned by Decode when an operation that returns a
@@ -24,6 +25,32 @@ type DocumentResult struct {
 	err error
 	cur Cursor
 	rdr bson.Reader
+	reg *bson.Registry
+
+	lastErrorObject *LastErrorObject
+}
+
+// LastErrorObject describes the effect of a FindOneAndUpdate or FindOneAndReplace operation.
+type LastErrorObject struct {
+	// UpdatedExisting is true if the operation updated or replaced an existing document.
+	UpdatedExisting bool
+	// UpsertedID is the _id of the document inserted by an upsert, or nil if no document was
+	// inserted.
+	UpsertedID interface{}
+}
+
+// findAndModifyResult returns the DocumentResult of a findAndModify operation that returned res
+// along with err, which is only set for write concern errors.
+func findAndModifyResult(res result.FindAndModify, err error, reg *bson.Registry) *DocumentResult {
+	return &DocumentResult{
+		err: err,
+		rdr: res.Value,
+		reg: reg,
+		lastErrorObject: &LastErrorObject{
+			UpdatedExisting: res.LastErrorObject.UpdatedExisting,
+			UpsertedID:      res.LastErrorObject.Upserted,
+		},
+	}
 }
 
 // Decode will attempt to decode the first document into v. If there was an
@@ -31,27 +58,71 @@ type DocumentResult struct {
 // will be returned. If there were no returned documents, ErrNoDocuments is
 // returned.
 func (dr *DocumentResult) Decode(v interface{}) error {
+	rdr, err := dr.DecodeBytes()
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return nil
+	}
+
+	return dr.unmarshal(rdr, v)
+}
+
+// DecodeBytes returns the document without decoding it. It returns the same errors as Decode. If
+// the operation returned a document along with a write concern error, both are returned.
+func (dr *DocumentResult) DecodeBytes() (bson.Reader, error) {
+	dr.load()
 	switch {
 	case dr.err != nil:
-		return dr.err
-	case dr.rdr != nil:
-		if v == nil {
-			return nil
-		}
-		return bson.Unmarshal(dr.rdr, v)
-	case dr.cur != nil:
-		defer dr.cur.Close(context.TODO())
-		if !dr.cur.Next(context.TODO()) {
-			if err := dr.cur.Err(); err != nil {
-				return err
-			}
-			return ErrNoDocuments
-		}
-		if v == nil {
-			return nil
-		}
-		return dr.cur.Decode(v)
-	}
-
-	return ErrNoDocuments
+		return dr.rdr, dr.err
+	case dr.rdr == nil:
+		return nil, ErrNoDocuments
+	}
+
+	return dr.rdr, nil
+}
+
+// Err returns the error of the operation that created this DocumentResult, or ErrNoDocuments if it
+// did not return a document.
+func (dr *DocumentResult) Err() error {
+	_, err := dr.DecodeBytes()
+	return err
+}
+
+// LastErrorObject returns whether a FindOneAndUpdate or FindOneAndReplace operation updated an
+// existing document or upserted a new one. It returns nil for the results of other operations.
+func (dr *DocumentResult) LastErrorObject() *LastErrorObject {
+	return dr.lastErrorObject
+}
+
+// load reads the document of a result returned as a cursor and closes the cursor.
+func (dr *DocumentResult) load() {
+	if dr.cur == nil || dr.err != nil {
+		return
+	}
+
+	cur := dr.cur
+	dr.cur = nil
+	defer cur.Close(context.TODO())
+
+	if !cur.Next(context.TODO()) {
+		dr.err = cur.Err()
+		return
+	}
+
+	rdr, err := cur.DecodeBytes()
+	if err != nil {
+		dr.err = err
+		return
+	}
+	// the document may be read from a buffer the cursor reuses once it is closed
+	dr.rdr = append(bson.Reader(nil), rdr...)
+}
+
+func (dr *DocumentResult) unmarshal(rdr bson.Reader, v interface{}) error {
+	if dr.reg == nil {
+		return bson.Unmarshal(rdr, v)
+	}
+	return bson.UnmarshalWithRegistry(dr.reg, rdr, v)
 }
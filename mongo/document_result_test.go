@@ -0,0 +1,80 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentResult(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("findAndModify", mongotest.OK(bson.EC.Null("value")))
+	d.HandleMatch(func(cmd *mongotest.Command) bool {
+		return cmd.Name == "findAndModify" && cmd.Document.Lookup("upsert") != nil
+	}, mongotest.OK(
+		bson.EC.SubDocumentFromElements("value", bson.EC.String("_id", "a"), bson.EC.Int32("x", 1)),
+		bson.EC.SubDocumentFromElements("lastErrorObject",
+			bson.EC.Int32("n", 1),
+			bson.EC.Boolean("updatedExisting", false),
+			bson.EC.String("upserted", "a"),
+		),
+	))
+	d.Handle("find", mongotest.Cursor("db.coll", bson.NewDocument(bson.EC.String("_id", "a"))))
+
+	client := newMockClient(t, d)
+	coll := client.Database("db").Collection("coll")
+
+	filter := bson.NewDocument(bson.EC.String("_id", "a"))
+	update := bson.NewDocument(bson.EC.SubDocumentFromElements("$set", bson.EC.Int32("x", 1)))
+
+	t.Run("no document", func(t *testing.T) {
+		res := coll.FindOneAndUpdate(context.Background(), filter, update)
+		require.Equal(t, ErrNoDocuments, res.Err())
+		require.Equal(t, ErrNoDocuments, res.Decode(nil))
+		rdr, err := res.DecodeBytes()
+		require.Equal(t, ErrNoDocuments, err)
+		require.Nil(t, rdr)
+		require.False(t, res.LastErrorObject().UpdatedExisting)
+		require.Nil(t, res.LastErrorObject().UpsertedID)
+	})
+	t.Run("upserted", func(t *testing.T) {
+		res := coll.FindOneAndUpdate(context.Background(), filter, update, findopt.Upsert(true))
+		require.NoError(t, res.Err())
+
+		rdr, err := res.DecodeBytes()
+		require.NoError(t, err)
+		elem, err := rdr.Lookup("x")
+		require.NoError(t, err)
+		require.Equal(t, int32(1), elem.Value().Int32())
+
+		var doc struct {
+			ID string `bson:"_id"`
+			X  int32  `bson:"x"`
+		}
+		require.NoError(t, res.Decode(&doc))
+		require.Equal(t, "a", doc.ID)
+
+		require.Equal(t, &LastErrorObject{UpsertedID: "a"}, res.LastErrorObject())
+	})
+	t.Run("cursor", func(t *testing.T) {
+		res := coll.FindOne(context.Background(), filter)
+		require.NoError(t, res.Err())
+		require.Nil(t, res.LastErrorObject())
+
+		rdr, err := res.DecodeBytes()
+		require.NoError(t, err)
+		elem, err := rdr.Lookup("_id")
+		require.NoError(t, err)
+		require.Equal(t, "a", elem.Value().StringValue())
+	})
+}
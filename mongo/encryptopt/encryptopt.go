@@ -0,0 +1,379 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package encryptopt contains the options of explicit client-side field level encryption.
+package encryptopt
+
+import (
+	"github.com/mongodb/mongo-go-driver/bson"
+)
+
+// The algorithms values can be encrypted with.
+const (
+	// AEADDeterministic encrypts a value to the same ciphertext every time it is encrypted with the
+	// same data key, so that encrypted fields can be queried for equality.
+	AEADDeterministic = "AEAD_AES_256_CBC_HMAC_SHA_512-Deterministic"
+
+	// AEADRandom encrypts a value to a different ciphertext every time it is encrypted.
+	AEADRandom = "AEAD_AES_256_CBC_HMAC_SHA_512-Random"
+)
+
+var dataKeyBundle = new(DataKeyBundle)
+var encryptBundle = new(EncryptBundle)
+var rewrapBundle = new(RewrapManyDataKeyBundle)
+
+// DataKey represents an option of ClientEncryption.CreateDataKey.
+type DataKey interface {
+	dataKeyOption()
+}
+
+// Encrypt represents an option of ClientEncryption.Encrypt.
+type Encrypt interface {
+	encryptOption()
+}
+
+// RewrapManyDataKey represents an option of ClientEncryption.RewrapManyDataKey.
+type RewrapManyDataKey interface {
+	rewrapOption()
+}
+
+// DataKeyOptions are the options a data key is created with.
+type DataKeyOptions struct {
+	MasterKey   interface{}
+	KeyAltNames []string
+}
+
+// EncryptOptions are the options a value is encrypted with.
+type EncryptOptions struct {
+	Algorithm  string
+	KeyID      *bson.Binary
+	KeyAltName *string
+}
+
+// RewrapManyDataKeyOptions are the options data keys are rewrapped with.
+type RewrapManyDataKeyOptions struct {
+	Provider  string
+	MasterKey interface{}
+}
+
+// dataKeyFunc adds the option to the DataKeyOptions instance.
+type dataKeyFunc func(*DataKeyOptions) error
+
+// encryptFunc adds the option to the EncryptOptions instance.
+type encryptFunc func(*EncryptOptions) error
+
+// rewrapFunc adds the option to the RewrapManyDataKeyOptions instance.
+type rewrapFunc func(*RewrapManyDataKeyOptions) error
+
+func (dataKeyFunc) dataKeyOption() {}
+func (encryptFunc) encryptOption() {}
+func (rewrapFunc) rewrapOption()   {}
+
+// DataKeyBundle is a bundle of CreateDataKey options.
+type DataKeyBundle struct {
+	option DataKey
+	next   *DataKeyBundle
+}
+
+func (*DataKeyBundle) dataKeyOption() {}
+
+// BundleDataKey bundles CreateDataKey options.
+func BundleDataKey(opts ...DataKey) *DataKeyBundle {
+	head := dataKeyBundle
+
+	for _, opt := range opts {
+		newBundle := DataKeyBundle{
+			option: opt,
+			next:   head,
+		}
+		head = &newBundle
+	}
+
+	return head
+}
+
+// MasterKey sets the master key.
+func (dkb *DataKeyBundle) MasterKey(masterKey interface{}) *DataKeyBundle {
+	return &DataKeyBundle{
+		option: MasterKey(masterKey),
+		next:   dkb,
+	}
+}
+
+// KeyAltNames sets the alternate names.
+func (dkb *DataKeyBundle) KeyAltNames(names ...string) *DataKeyBundle {
+	return &DataKeyBundle{
+		option: KeyAltNames(names...),
+		next:   dkb,
+	}
+}
+
+// Unbundle unbundles the options, returning a DataKeyOptions instance.
+func (dkb *DataKeyBundle) Unbundle() (*DataKeyOptions, error) {
+	opts := &DataKeyOptions{}
+	if err := dkb.unbundle(opts); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// Helper that recursively unwraps the bundle.
+func (dkb *DataKeyBundle) unbundle(opts *DataKeyOptions) error {
+	if dkb == nil {
+		return nil
+	}
+
+	for head := dkb; head != nil && head.option != nil; head = head.next {
+		var err error
+		switch opt := head.option.(type) {
+		case *DataKeyBundle:
+			err = opt.unbundle(opts)
+		case dataKeyFunc:
+			err = opt(opts)
+		case OptMasterKey:
+			if opts.MasterKey == nil {
+				opts.MasterKey = opt.MasterKey
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EncryptBundle is a bundle of Encrypt options.
+type EncryptBundle struct {
+	option Encrypt
+	next   *EncryptBundle
+}
+
+func (*EncryptBundle) encryptOption() {}
+
+// BundleEncrypt bundles Encrypt options.
+func BundleEncrypt(opts ...Encrypt) *EncryptBundle {
+	head := encryptBundle
+
+	for _, opt := range opts {
+		newBundle := EncryptBundle{
+			option: opt,
+			next:   head,
+		}
+		head = &newBundle
+	}
+
+	return head
+}
+
+// Algorithm sets the algorithm.
+func (eb *EncryptBundle) Algorithm(algorithm string) *EncryptBundle {
+	return &EncryptBundle{
+		option: Algorithm(algorithm),
+		next:   eb,
+	}
+}
+
+// KeyID sets the id of the data key.
+func (eb *EncryptBundle) KeyID(id bson.Binary) *EncryptBundle {
+	return &EncryptBundle{
+		option: KeyID(id),
+		next:   eb,
+	}
+}
+
+// KeyAltName sets the alternate name of the data key.
+func (eb *EncryptBundle) KeyAltName(name string) *EncryptBundle {
+	return &EncryptBundle{
+		option: KeyAltName(name),
+		next:   eb,
+	}
+}
+
+// Unbundle unbundles the options, returning an EncryptOptions instance.
+func (eb *EncryptBundle) Unbundle() (*EncryptOptions, error) {
+	opts := &EncryptOptions{}
+	if err := eb.unbundle(opts); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// Helper that recursively unwraps the bundle.
+func (eb *EncryptBundle) unbundle(opts *EncryptOptions) error {
+	if eb == nil {
+		return nil
+	}
+
+	for head := eb; head != nil && head.option != nil; head = head.next {
+		var err error
+		switch opt := head.option.(type) {
+		case *EncryptBundle:
+			err = opt.unbundle(opts)
+		case encryptFunc:
+			err = opt(opts)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RewrapManyDataKeyBundle is a bundle of RewrapManyDataKey options.
+type RewrapManyDataKeyBundle struct {
+	option RewrapManyDataKey
+	next   *RewrapManyDataKeyBundle
+}
+
+func (*RewrapManyDataKeyBundle) rewrapOption() {}
+
+// BundleRewrapManyDataKey bundles RewrapManyDataKey options.
+func BundleRewrapManyDataKey(opts ...RewrapManyDataKey) *RewrapManyDataKeyBundle {
+	head := rewrapBundle
+
+	for _, opt := range opts {
+		newBundle := RewrapManyDataKeyBundle{
+			option: opt,
+			next:   head,
+		}
+		head = &newBundle
+	}
+
+	return head
+}
+
+// Provider sets the KMS provider.
+func (rb *RewrapManyDataKeyBundle) Provider(name string) *RewrapManyDataKeyBundle {
+	return &RewrapManyDataKeyBundle{
+		option: Provider(name),
+		next:   rb,
+	}
+}
+
+// MasterKey sets the master key.
+func (rb *RewrapManyDataKeyBundle) MasterKey(masterKey interface{}) *RewrapManyDataKeyBundle {
+	return &RewrapManyDataKeyBundle{
+		option: MasterKey(masterKey),
+		next:   rb,
+	}
+}
+
+// Unbundle unbundles the options, returning a RewrapManyDataKeyOptions instance.
+func (rb *RewrapManyDataKeyBundle) Unbundle() (*RewrapManyDataKeyOptions, error) {
+	opts := &RewrapManyDataKeyOptions{}
+	if err := rb.unbundle(opts); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// Helper that recursively unwraps the bundle.
+func (rb *RewrapManyDataKeyBundle) unbundle(opts *RewrapManyDataKeyOptions) error {
+	if rb == nil {
+		return nil
+	}
+
+	for head := rb; head != nil && head.option != nil; head = head.next {
+		var err error
+		switch opt := head.option.(type) {
+		case *RewrapManyDataKeyBundle:
+			err = opt.unbundle(opts)
+		case rewrapFunc:
+			err = opt(opts)
+		case OptMasterKey:
+			if opts.MasterKey == nil {
+				opts.MasterKey = opt.MasterKey
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// OptMasterKey describes a master key data keys are encrypted with.
+type OptMasterKey struct {
+	MasterKey interface{}
+}
+
+func (OptMasterKey) dataKeyOption() {}
+func (OptMasterKey) rewrapOption()  {}
+
+// MasterKey describes the master key data keys are encrypted with. It is required by the "aws"
+// KMS provider, whose master keys are described by a document holding their "region" and "key",
+// the ARN of the key, and optionally the host of the "endpoint" of the KMS. It is ignored by the
+// "local" KMS provider.
+// DataKey, RewrapManyDataKey
+func MasterKey(masterKey interface{}) OptMasterKey {
+	return OptMasterKey{MasterKey: masterKey}
+}
+
+// KeyAltNames sets alternate names the data key can be referred to by instead of its id.
+func KeyAltNames(names ...string) DataKey {
+	return dataKeyFunc(
+		func(opts *DataKeyOptions) error {
+			if opts.KeyAltNames == nil {
+				opts.KeyAltNames = names
+			}
+			return nil
+		})
+}
+
+// Algorithm sets the algorithm the value is encrypted with, AEADDeterministic or AEADRandom. It is
+// required.
+func Algorithm(algorithm string) Encrypt {
+	return encryptFunc(
+		func(opts *EncryptOptions) error {
+			if opts.Algorithm == "" {
+				opts.Algorithm = algorithm
+			}
+			return nil
+		})
+}
+
+// KeyID sets the id of the data key the value is encrypted with, as returned by CreateDataKey.
+// Either KeyID or KeyAltName is required.
+func KeyID(id bson.Binary) Encrypt {
+	return encryptFunc(
+		func(opts *EncryptOptions) error {
+			if opts.KeyID == nil {
+				opts.KeyID = &id
+			}
+			return nil
+		})
+}
+
+// KeyAltName sets an alternate name of the data key the value is encrypted with. Either KeyID or
+// KeyAltName is required.
+func KeyAltName(name string) Encrypt {
+	return encryptFunc(
+		func(opts *EncryptOptions) error {
+			if opts.KeyAltName == nil {
+				opts.KeyAltName = &name
+			}
+			return nil
+		})
+}
+
+// Provider sets the KMS provider whose master key data keys are rewrapped with. If it is not set,
+// each data key is rewrapped with the master key it is encrypted with, e.g. after that master key
+// was rotated by its KMS.
+func Provider(name string) RewrapManyDataKey {
+	return rewrapFunc(
+		func(opts *RewrapManyDataKeyOptions) error {
+			if opts.Provider == "" {
+				opts.Provider = name
+			}
+			return nil
+		})
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/result"
 )
 
@@ -20,6 +21,10 @@ import (
 // write concern.
 var ErrUnacknowledgedWrite = errors.New("unacknowledged write")
 
+// WaitQueueTimeoutError is returned when an operation waits longer than the WaitQueueTimeout client
+// option for a connection of a full connection pool.
+type WaitQueueTimeoutError = connection.WaitQueueTimeoutError
+
 // WriteError is a non-write concern failure that occurred as a result of a write
 // operation.
 type WriteError struct {
@@ -30,6 +35,9 @@ type WriteError struct {
 
 func (we WriteError) Error() string { return we.Message }
 
+// ErrorCode returns the server error code.
+func (we WriteError) ErrorCode() int32 { return int32(we.Code) }
+
 // WriteErrors is a group of non-write concern failures that occurred as a result
 // of a write operation.
 type WriteErrors []WriteError
@@ -47,6 +55,14 @@ func (we WriteErrors) Error() string {
 	return buf.String()
 }
 
+// ErrorCode returns the server error code of the first write error, or 0 if there are none.
+func (we WriteErrors) ErrorCode() int32 {
+	if len(we) == 0 {
+		return 0
+	}
+	return we[0].ErrorCode()
+}
+
 func writeErrorsFromResult(rwes []result.WriteError) WriteErrors {
 	wes := make(WriteErrors, 0, len(rwes))
 	for _, err := range rwes {
@@ -56,7 +72,8 @@ func writeErrorsFromResult(rwes []result.WriteError) WriteErrors {
 }
 
 // WriteConcernError is a write concern failure that occurred as a result of a
-// write operation.
+// write operation. Details contains the errInfo document returned by the server,
+// if any, which describes why the write concern could not be satisfied.
 type WriteConcernError struct {
 	Code    int
 	Message string
@@ -65,6 +82,9 @@ type WriteConcernError struct {
 
 func (wce WriteConcernError) Error() string { return wce.Message }
 
+// ErrorCode returns the server error code.
+func (wce WriteConcernError) ErrorCode() int32 { return int32(wce.Code) }
+
 func convertWriteConcernError(wce *result.WriteConcernError) *WriteConcernError {
 	if wce == nil {
 		return nil
@@ -87,6 +107,15 @@ func (bwe BulkWriteError) Error() string {
 	return buf.String()
 }
 
+// Unwrap returns the write concern error, if any, so that errors.As can be used to
+// extract a WriteConcernError from a BulkWriteError.
+func (bwe BulkWriteError) Unwrap() error {
+	if bwe.WriteConcernError == nil {
+		return nil
+	}
+	return *bwe.WriteConcernError
+}
+
 // returnResult is used to determine if a function calling processWriteError should return
 // the result or return nil. Since the processWriteError function is used by many different
 // methods, both *One and *Many, we need a way to differentiate if the method should return
@@ -113,7 +142,7 @@ func processWriteError(wce *result.WriteConcernError, wes []result.WriteError, e
 	case err != nil:
 		return rrNone, err
 	case wce != nil:
-		return rrMany, WriteConcernError{Code: wce.Code, Message: wce.ErrMsg}
+		return rrMany, *convertWriteConcernError(wce)
 	case len(wes) > 0:
 		return rrMany, writeErrorsFromResult(wes)
 	default:
@@ -0,0 +1,84 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/result"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteConcernErrorDetails(t *testing.T) {
+	errInfo, err := bson.NewDocument(bson.EC.Boolean("wtimeout", true)).MarshalBSON()
+	require.NoError(t, err)
+	rwce := &result.WriteConcernError{Code: 64, ErrMsg: "waiting for replication timed out", ErrInfo: errInfo}
+
+	t.Run("processWriteError", func(t *testing.T) {
+		rr, err := processWriteError(rwce, nil, nil)
+		require.Equal(t, rrMany, rr)
+
+		var wce WriteConcernError
+		require.True(t, errors.As(err, &wce))
+		require.Equal(t, 64, wce.Code)
+		require.Equal(t, bson.Reader(errInfo), wce.Details)
+	})
+	t.Run("BulkWriteError", func(t *testing.T) {
+		var err error = BulkWriteError{WriteConcernError: convertWriteConcernError(rwce)}
+
+		var wce WriteConcernError
+		require.True(t, errors.As(err, &wce))
+		require.Equal(t, "waiting for replication timed out", wce.Message)
+		require.Equal(t, bson.Reader(errInfo), wce.Details)
+	})
+	t.Run("BulkWriteError without write concern error", func(t *testing.T) {
+		var err error = BulkWriteError{WriteErrors: WriteErrors{{Index: 0, Code: 11000}}}
+
+		var wce WriteConcernError
+		require.False(t, errors.As(err, &wce))
+	})
+}
+
+func TestErrorsWrapContextErrors(t *testing.T) {
+	// nothing listens on this port, so server selection blocks until the context expires
+	client, err := NewClient("mongodb://localhost:1/?connectTimeoutMS=50")
+	require.NoError(t, err)
+	require.NoError(t, client.Connect(context.Background()))
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_ = client.Disconnect(ctx)
+	}()
+
+	coll := client.Database("errors_test").Collection("deadline")
+
+	t.Run("Find", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := coll.Find(ctx, bson.NewDocument())
+		require.True(t, errors.Is(err, context.DeadlineExceeded), "expected context.DeadlineExceeded, got %v", err)
+	})
+	t.Run("InsertOne", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := coll.InsertOne(ctx, bson.NewDocument(bson.EC.Int32("x", 1)))
+		require.True(t, errors.Is(err, context.DeadlineExceeded), "expected context.DeadlineExceeded, got %v", err)
+	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := coll.FindOne(ctx, bson.NewDocument()).Decode(nil)
+		require.True(t, errors.Is(err, context.Canceled), "expected context.Canceled, got %v", err)
+	})
+}
@@ -0,0 +1,311 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/dispatch"
+	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/mongodb/mongo-go-driver/mongo/aggregateopt"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
+	"go.opencensus.io/tag"
+)
+
+// ErrNoWinningPlan is returned by WinningPlan when the explain output does not contain a winning plan.
+var ErrNoWinningPlan = errors.New("mongo: explain output does not contain a winning plan")
+
+// FindExplain explains the find operation that Find would run with the given filter and options,
+// and returns the raw output of the explain command. A user can supply a custom context to this
+// method, or nil to default to context.Background().
+//
+// This method uses TransformDocument to turn the filter parameter into a
+// *bson.Document. See TransformDocument for the list of valid types for
+// filter.
+func (coll *Collection) FindExplain(ctx context.Context, filter interface{},
+	verbosity mongoopt.ExplainVerbosity, opts ...findopt.Find) (bson.Reader, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "find_explain"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).FindExplain")
+	defer span.End()
+
+	var f *bson.Document
+	var err error
+	if filter != nil {
+		f, err = transformDocument(coll.registry, "filter", filter)
+		if err != nil {
+			observability.RecordError(ctx, "transform_document_filter", err)
+			span.SetStatus(observability.SpanStatus(err))
+			return nil, err
+		}
+	}
+
+	findOpts, sess, err := findopt.BundleFind(opts...).Unbundle(true)
+	if err != nil {
+		return nil, err
+	}
+	if err = findopt.Validate(findOpts); err != nil {
+		return nil, err
+	}
+
+	err = coll.client.ValidSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	rp, selector := coll.readPrefSelector(nil)
+	for _, opt := range findOpts {
+		if o, ok := opt.(option.OptReadPreference); ok {
+			rp, selector = coll.readPrefSelector(o.ReadPreference)
+		}
+	}
+
+	find := command.Find{
+		NS:       coll.namespace(),
+		Filter:   f,
+		Opts:     findOpts,
+		ReadPref: rp,
+		Session:  sess,
+		Clock:    coll.client.clock,
+	}
+
+	cmd, err := find.Explain(string(verbosity))
+	if err != nil {
+		return nil, err
+	}
+
+	return coll.explain(ctx, span, cmd, selector)
+}
+
+// AggregateExplain explains the aggregation that Aggregate would run with the given pipeline and
+// options, and returns the raw output of the explain command. A user can supply a custom context to
+// this method, or nil to default to context.Background().
+//
+// See Aggregate for the list of valid types for pipeline.
+func (coll *Collection) AggregateExplain(ctx context.Context, pipeline interface{},
+	verbosity mongoopt.ExplainVerbosity, opts ...aggregateopt.Aggregate) (bson.Reader, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "aggregate_explain"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).AggregateExplain")
+	defer span.End()
+
+	pipelineArr, err := transformAggregatePipeline(coll.registry, pipeline)
+	if err != nil {
+		observability.RecordError(ctx, "transform_aggregate_pipeline", err)
+		span.SetStatus(observability.SpanStatus(err))
+		return nil, err
+	}
+
+	aggOpts, sess, err := aggregateopt.BundleAggregate(opts...).Unbundle(true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = coll.client.ValidSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	rp, selector := coll.readPrefSelector(nil)
+	for _, opt := range aggOpts {
+		if o, ok := opt.(option.OptReadPreference); ok {
+			rp, selector = coll.readPrefSelector(o.ReadPreference)
+		}
+	}
+
+	agg := command.Aggregate{
+		NS:       coll.namespace(),
+		Pipeline: pipelineArr,
+		Opts:     aggOpts,
+		ReadPref: rp,
+		Session:  sess,
+		Clock:    coll.client.clock,
+	}
+
+	cmd, err := agg.Explain(string(verbosity))
+	if err != nil {
+		return nil, err
+	}
+
+	return coll.explain(ctx, span, cmd, selector)
+}
+
+func (coll *Collection) explain(ctx context.Context, span observability.Span, cmd command.Read,
+	selector description.ServerSelector) (bson.Reader, error) {
+
+	rdr, err := dispatch.Read(
+		ctx, cmd,
+		coll.client.topology,
+		selector,
+		coll.client.id,
+		coll.client.topology.SessionPool,
+	)
+	if err != nil {
+		// dispatch.Read already sets error metrics
+		span.SetStatus(observability.SpanStatus(err))
+	}
+	return rdr, err
+}
+
+// PlanStage is a stage of a query plan as reported by explain, such as COLLSCAN, IXSCAN or FETCH.
+// The stages form a chain from the stage that returns results to the stage that reads documents
+// or index keys.
+type PlanStage struct {
+	Stage      string
+	IndexName  string
+	Direction  string
+	Filter     *bson.Document
+	InputStage *PlanStage
+	// InputStages holds the inputs of stages, such as OR, that combine several plans.
+	InputStages []*PlanStage
+}
+
+// WinningPlan returns the winning plan from the output of FindExplain or AggregateExplain. For a
+// sharded collection, the winning plan of the first shard is returned. ErrNoWinningPlan is
+// returned if the output has no winning plan, e.g. because the aggregation does not read from the
+// collection.
+func WinningPlan(explain bson.Reader) (*PlanStage, error) {
+	planner, err := queryPlanner(explain)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, ok := lookupDocument(planner, "winningPlan")
+	if !ok {
+		return nil, ErrNoWinningPlan
+	}
+
+	if shard, ok := lookupDocument(plan, "shards", "0", "winningPlan"); ok {
+		plan = shard
+	}
+	// servers using the slot-based execution engine nest the plan one level deeper
+	if queryPlan, ok := lookupDocument(plan, "queryPlan"); ok {
+		plan = queryPlan
+	}
+
+	return newPlanStage(plan)
+}
+
+// queryPlanner returns the queryPlanner section of explain. Aggregations report it as part of
+// their first stage unless the whole pipeline was executed by the query layer.
+func queryPlanner(explain bson.Reader) (bson.Reader, error) {
+	if planner, ok := lookupDocument(explain, "queryPlanner"); ok {
+		return planner, nil
+	}
+	if planner, ok := lookupDocument(explain, "stages", "0", "$cursor", "queryPlanner"); ok {
+		return planner, nil
+	}
+	if planner, ok := lookupDocument(explain, "shards"); ok {
+		// sharded aggregations report the output of each shard under its name
+		iter, err := planner.Iterator()
+		if err != nil {
+			return nil, err
+		}
+		if iter.Next() {
+			if shard, ok := iter.Element().Value().ReaderDocumentOK(); ok {
+				return queryPlanner(shard)
+			}
+		}
+	}
+
+	return nil, ErrNoWinningPlan
+}
+
+func lookupDocument(rdr bson.Reader, key ...string) (bson.Reader, bool) {
+	elem, err := rdr.Lookup(key...)
+	if err != nil {
+		return nil, false
+	}
+
+	return elem.Value().ReaderDocumentOK()
+}
+
+func newPlanStage(rdr bson.Reader) (*PlanStage, error) {
+	stage := new(PlanStage)
+
+	iter, err := rdr.Iterator()
+	if err != nil {
+		return nil, err
+	}
+
+	for iter.Next() {
+		elem := iter.Element()
+		switch elem.Key() {
+		case "stage":
+			stage.Stage, _ = elem.Value().StringValueOK()
+		case "indexName":
+			stage.IndexName, _ = elem.Value().StringValueOK()
+		case "direction":
+			stage.Direction, _ = elem.Value().StringValueOK()
+		case "filter":
+			// the iterator reuses its value, so the filter is read from the raw document rather than
+			// converted in place with MutableDocument
+			filter, ok := elem.Value().ReaderDocumentOK()
+			if !ok {
+				continue
+			}
+			if stage.Filter, err = bson.ReadDocument(filter); err != nil {
+				return nil, err
+			}
+		case "inputStage":
+			input, ok := elem.Value().ReaderDocumentOK()
+			if !ok {
+				continue
+			}
+			if stage.InputStage, err = newPlanStage(input); err != nil {
+				return nil, err
+			}
+		case "inputStages":
+			inputs, ok := elem.Value().ReaderArrayOK()
+			if !ok {
+				continue
+			}
+			if stage.InputStages, err = newPlanStages(inputs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return stage, iter.Err()
+}
+
+func newPlanStages(arr bson.Reader) ([]*PlanStage, error) {
+	iter, err := arr.Iterator()
+	if err != nil {
+		return nil, err
+	}
+
+	var stages []*PlanStage
+	for iter.Next() {
+		input, ok := iter.Element().Value().ReaderDocumentOK()
+		if !ok {
+			continue
+		}
+
+		stage, err := newPlanStage(input)
+		if err != nil {
+			return nil, err
+		}
+		stages = append(stages, stage)
+	}
+
+	return stages, iter.Err()
+}
@@ -0,0 +1,126 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWinningPlan(t *testing.T) {
+	fetchIxscan := bson.NewDocument(
+		bson.EC.String("stage", "FETCH"),
+		bson.EC.SubDocumentFromElements("filter", bson.EC.SubDocumentFromElements("y", bson.EC.Int32("$eq", 2))),
+		bson.EC.SubDocumentFromElements("inputStage",
+			bson.EC.String("stage", "IXSCAN"),
+			bson.EC.String("indexName", "x_1"),
+			bson.EC.String("direction", "forward"),
+		),
+	)
+
+	requireFetchIxscan := func(t *testing.T, plan *PlanStage) {
+		require.Equal(t, "FETCH", plan.Stage)
+		require.NotNil(t, plan.Filter)
+		require.NotNil(t, plan.InputStage)
+		require.Equal(t, "IXSCAN", plan.InputStage.Stage)
+		require.Equal(t, "x_1", plan.InputStage.IndexName)
+		require.Equal(t, "forward", plan.InputStage.Direction)
+		require.Nil(t, plan.InputStage.InputStage)
+	}
+
+	testCases := []struct {
+		name    string
+		explain *bson.Document
+	}{
+		{
+			"find",
+			bson.NewDocument(bson.EC.SubDocumentFromElements("queryPlanner",
+				bson.EC.String("namespace", "db.coll"),
+				bson.EC.SubDocument("winningPlan", fetchIxscan),
+			)),
+		},
+		{
+			"slot-based execution",
+			bson.NewDocument(bson.EC.SubDocumentFromElements("queryPlanner",
+				bson.EC.SubDocumentFromElements("winningPlan", bson.EC.SubDocument("queryPlan", fetchIxscan)),
+			)),
+		},
+		{
+			"aggregate",
+			bson.NewDocument(bson.EC.ArrayFromElements("stages",
+				bson.VC.DocumentFromElements(bson.EC.SubDocumentFromElements("$cursor",
+					bson.EC.SubDocumentFromElements("queryPlanner", bson.EC.SubDocument("winningPlan", fetchIxscan)),
+				)),
+				bson.VC.DocumentFromElements(bson.EC.SubDocumentFromElements("$group", bson.EC.Null("_id"))),
+			)),
+		},
+		{
+			"sharded find",
+			bson.NewDocument(bson.EC.SubDocumentFromElements("queryPlanner",
+				bson.EC.SubDocumentFromElements("winningPlan",
+					bson.EC.String("stage", "SINGLE_SHARD"),
+					bson.EC.ArrayFromElements("shards", bson.VC.DocumentFromElements(
+						bson.EC.String("shardName", "shard0"),
+						bson.EC.SubDocument("winningPlan", fetchIxscan),
+					)),
+				),
+			)),
+		},
+		{
+			"sharded aggregate",
+			bson.NewDocument(bson.EC.SubDocumentFromElements("shards",
+				bson.EC.SubDocumentFromElements("shard0",
+					bson.EC.SubDocumentFromElements("queryPlanner", bson.EC.SubDocument("winningPlan", fetchIxscan)),
+				),
+			)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rdr, err := tc.explain.MarshalBSON()
+			require.NoError(t, err)
+
+			plan, err := WinningPlan(rdr)
+			require.NoError(t, err)
+			requireFetchIxscan(t, plan)
+		})
+	}
+
+	t.Run("input stages", func(t *testing.T) {
+		explain := bson.NewDocument(bson.EC.SubDocumentFromElements("queryPlanner",
+			bson.EC.SubDocumentFromElements("winningPlan",
+				bson.EC.String("stage", "OR"),
+				bson.EC.ArrayFromElements("inputStages",
+					bson.VC.Document(fetchIxscan),
+					bson.VC.DocumentFromElements(bson.EC.String("stage", "COLLSCAN")),
+				),
+			),
+		))
+		rdr, err := explain.MarshalBSON()
+		require.NoError(t, err)
+
+		plan, err := WinningPlan(rdr)
+		require.NoError(t, err)
+		require.Equal(t, "OR", plan.Stage)
+		require.Len(t, plan.InputStages, 2)
+		requireFetchIxscan(t, plan.InputStages[0])
+		require.Equal(t, "COLLSCAN", plan.InputStages[1].Stage)
+	})
+
+	t.Run("no winning plan", func(t *testing.T) {
+		rdr, err := bson.NewDocument(bson.EC.ArrayFromElements("stages",
+			bson.VC.DocumentFromElements(bson.EC.SubDocumentFromElements("$collStats", bson.EC.SubDocument("count", bson.NewDocument()))),
+		)).MarshalBSON()
+		require.NoError(t, err)
+
+		_, err = WinningPlan(rdr)
+		require.Equal(t, ErrNoWinningPlan, err)
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
+	"github.com/mongodb/mongo-go-driver/mongo/collectionopt"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+// orderID is an _id type encoded as a string by orderIDCodec.
+type orderID int
+
+type orderIDCodec struct{}
+
+func (orderIDCodec) EncodeValue(_ bson.EncodeContext, vw bson.ValueWriter, i interface{}) error {
+	return vw.WriteString(fmt.Sprintf("order-%d", i.(orderID)))
+}
+
+func (orderIDCodec) DecodeValue(bson.DecodeContext, bson.ValueReader, interface{}) error {
+	return errors.New("orderID cannot be decoded")
+}
+
+func TestFindHelpers(t *testing.T) {
+	d := mongotest.New(mongotest.WithMongos())
+	d.Handle("find", func(cmd *mongotest.Command) mongotest.Response {
+		switch cmd.Document.Lookup("find").StringValue() {
+		case "empty":
+			return mongotest.Cursor("db.empty")(cmd)
+		case "broken":
+			return mongotest.Error(50, "MaxTimeMSExpired", "operation exceeded time limit")(cmd)
+		}
+		return mongotest.Cursor("db.orders", bson.NewDocument(
+			bson.EC.String("_id", "order-7"),
+			bson.EC.String("item", "pencil"),
+		))(cmd)
+	})
+
+	client := newMockClient(t, d)
+
+	reg := bson.NewRegistryBuilder().Register(reflect.TypeOf(orderID(0)), orderIDCodec{}).Build()
+	db := client.Database("db")
+	orders := db.Collection("orders", collectionopt.Registry(reg))
+	ctx := context.Background()
+	lastFind := func() *bson.Document {
+		return d.LastCommand("find").Document
+	}
+
+	t.Run("find by id", func(t *testing.T) {
+		var order struct {
+			ID   string `bson:"_id"`
+			Item string `bson:"item"`
+		}
+		err := orders.FindByID(ctx, orderID(7), &order,
+			findopt.ReadPreference(readpref.Secondary()), findopt.MaxTime(time.Second))
+		require.NoError(t, err)
+		require.Equal(t, "order-7", order.ID)
+		require.Equal(t, "pencil", order.Item)
+
+		find := lastFind()
+		require.Equal(t, "order-7", find.Lookup("filter", "_id").StringValue())
+		require.Equal(t, 1, find.Lookup("filter").MutableDocument().Len())
+		_, err = find.LookupErr("projection")
+		require.Equal(t, bson.ErrElementNotFound, err)
+		require.Equal(t, int64(1), find.Lookup("limit").Int64())
+		require.Equal(t, "secondary", find.Lookup("$readPreference", "mode").StringValue())
+		require.Equal(t, int64(1000), find.Lookup("maxTimeMS").Int64())
+	})
+	t.Run("find by id without document", func(t *testing.T) {
+		err := db.Collection("empty").FindByID(ctx, 7, nil)
+		require.Equal(t, ErrNoDocuments, err)
+		require.Equal(t, int64(7), lastFind().Lookup("filter", "_id").Int64())
+	})
+	t.Run("exists", func(t *testing.T) {
+		ok, err := orders.Exists(ctx, map[string]interface{}{"item": "pencil"},
+			findopt.Projection(bson.NewDocument(bson.EC.Int32("item", 1))),
+			findopt.ReadPreference(readpref.Nearest()), findopt.MaxTime(time.Second))
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		find := lastFind()
+		require.Equal(t, "pencil", find.Lookup("filter", "item").StringValue())
+		require.True(t, bson.NewDocument(bson.EC.Int32("_id", 1)).Equal(find.Lookup("projection").MutableDocument()))
+		require.Equal(t, int64(1), find.Lookup("limit").Int64())
+		require.True(t, find.Lookup("singleBatch").Boolean())
+		require.Equal(t, "nearest", find.Lookup("$readPreference", "mode").StringValue())
+		require.Equal(t, int64(1000), find.Lookup("maxTimeMS").Int64())
+	})
+	t.Run("does not exist", func(t *testing.T) {
+		ok, err := db.Collection("empty").Exists(ctx, nil)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+	t.Run("exists error", func(t *testing.T) {
+		ok, err := db.Collection("broken").Exists(ctx, nil)
+		var cmdErr command.Error
+		require.True(t, errors.As(err, &cmdErr), "unexpected error %v", err)
+		require.Equal(t, int32(50), cmdErr.Code)
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindOptionValidation(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("find", mongotest.Cursor("db.coll", bson.NewDocument(bson.EC.Int32("_id", 1))))
+
+	client := newMockClient(t, d)
+
+	coll := client.Database("db").Collection("coll")
+	ctx := context.Background()
+	lastFind := func() *bson.Document {
+		return d.LastCommand("find").Document
+	}
+
+	t.Run("maxAwaitTime", func(t *testing.T) {
+		before := len(d.Commands())
+		_, err := coll.Find(ctx, nil, findopt.MaxAwaitTime(time.Second))
+		require.Equal(t, findopt.ErrMaxAwaitTimeNotTailableAwait, err)
+		require.Len(t, d.Commands(), before, "expected the find not to be sent")
+
+		cur, err := coll.Find(ctx, nil, findopt.CursorType(mongoopt.TailableAwait), findopt.MaxAwaitTime(time.Second))
+		require.NoError(t, err)
+		require.NoError(t, cur.Close(ctx))
+	})
+	t.Run("negative limit", func(t *testing.T) {
+		cur, err := coll.Find(ctx, nil, findopt.Limit(-2))
+		require.NoError(t, err)
+		require.NoError(t, cur.Close(ctx))
+		require.Equal(t, int64(2), lastFind().Lookup("limit").Int64())
+		require.True(t, lastFind().Lookup("singleBatch").Boolean())
+	})
+	t.Run("find one", func(t *testing.T) {
+		require.NoError(t, coll.FindOne(ctx, nil).Decode(nil))
+		require.Equal(t, int64(1), lastFind().Lookup("limit").Int64())
+		require.True(t, lastFind().Lookup("singleBatch").Boolean())
+
+		err := coll.FindOne(ctx, nil, findopt.CursorType(mongoopt.Tailable), findopt.Skip(3)).Decode(nil)
+		require.Equal(t, findopt.ErrTailableSkipLimit, err)
+	})
+}
@@ -71,6 +71,26 @@ func (dob *DeleteOneBundle) Collation(collation *mongoopt.Collation) *DeleteOneB
 	return bundle
 }
 
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (dob *DeleteOneBundle) Comment(comment interface{}) *DeleteOneBundle {
+	bundle := &DeleteOneBundle{
+		option: Comment(comment),
+		next:   dob,
+	}
+
+	return bundle
+}
+
+// Let adds an option to specify a document of variables that can be accessed in the filter.
+func (dob *DeleteOneBundle) Let(let interface{}) *DeleteOneBundle {
+	bundle := &DeleteOneBundle{
+		option: Let(let),
+		next:   dob,
+	}
+
+	return bundle
+}
+
 // MaxTime adds an option to specify the max time to allow the query to run.
 func (dob *DeleteOneBundle) MaxTime(d time.Duration) *DeleteOneBundle {
 	bundle := &DeleteOneBundle{
@@ -91,6 +111,17 @@ func (dob *DeleteOneBundle) Projection(projection interface{}) *DeleteOneBundle
 	return bundle
 }
 
+// ProjectStruct adds an option to limit the fields returned for all documents to those a value of
+// the struct type of v is decoded from.
+func (dob *DeleteOneBundle) ProjectStruct(v interface{}) *DeleteOneBundle {
+	bundle := &DeleteOneBundle{
+		option: ProjectStruct(v),
+		next:   dob,
+	}
+
+	return bundle
+}
+
 // Sort adds an option to specify the order in which to return results.
 func (dob *DeleteOneBundle) Sort(sort interface{}) *DeleteOneBundle {
 	bundle := &DeleteOneBundle{
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
 )
@@ -40,11 +42,17 @@ type FindBundle struct {
 	next   *FindBundle
 }
 
-// BundleFind bundles Find options
+// BundleFind bundles Find options. A *FindOptions is bundled as the options its fields are set to
+// when it is bundled.
+// New code should build the options with options.Find instead.
 func BundleFind(opts ...Find) *FindBundle {
 	head := findBundle
 
 	for _, opt := range opts {
+		if o, ok := opt.(*FindOptions); ok {
+			opt = o.bundle()
+		}
+
 		newBundle := FindBundle{
 			option: opt,
 			next:   head,
@@ -91,10 +99,10 @@ func (fb *FindBundle) Collation(collation *mongoopt.Collation) *FindBundle {
 	return bundle
 }
 
-// Comment adds an option to specify a string to help trace the operation through the database profiler, currentOp, and logs.
-func (fb *FindBundle) Comment(s string) *FindBundle {
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (fb *FindBundle) Comment(comment interface{}) *FindBundle {
 	bundle := &FindBundle{
-		option: Comment(s),
+		option: Comment(comment),
 		next:   fb,
 	}
 
@@ -121,6 +129,16 @@ func (fb *FindBundle) Hint(hint interface{}) *FindBundle {
 	return bundle
 }
 
+// Let adds an option to specify a document of variables that can be accessed in the filter.
+func (fb *FindBundle) Let(let interface{}) *FindBundle {
+	bundle := &FindBundle{
+		option: Let(let),
+		next:   fb,
+	}
+
+	return bundle
+}
+
 // Limit adds an option to set the limit on the number of results.
 func (fb *FindBundle) Limit(i int64) *FindBundle {
 	bundle := &FindBundle{
@@ -201,6 +219,17 @@ func (fb *FindBundle) OplogReplay(b bool) *FindBundle {
 	return bundle
 }
 
+// Prefetch adds an option to request the next batch of the cursor while the current one is
+// iterated.
+func (fb *FindBundle) Prefetch(b bool) *FindBundle {
+	bundle := &FindBundle{
+		option: Prefetch(b),
+		next:   fb,
+	}
+
+	return bundle
+}
+
 // Projection adds an option to limit the fields returned for all documents.
 func (fb *FindBundle) Projection(projection interface{}) *FindBundle {
 	bundle := &FindBundle{
@@ -211,6 +240,39 @@ func (fb *FindBundle) Projection(projection interface{}) *FindBundle {
 	return bundle
 }
 
+// ProjectStruct adds an option to limit the fields returned for all documents to those a value of
+// the struct type of v is decoded from.
+func (fb *FindBundle) ProjectStruct(v interface{}) *FindBundle {
+	bundle := &FindBundle{
+		option: ProjectStruct(v),
+		next:   fb,
+	}
+
+	return bundle
+}
+
+// ReadConcern adds an option to specify the read concern of the operation, overriding the one of
+// the collection.
+func (fb *FindBundle) ReadConcern(rc *readconcern.ReadConcern) *FindBundle {
+	bundle := &FindBundle{
+		option: ReadConcern(rc),
+		next:   fb,
+	}
+
+	return bundle
+}
+
+// ReadPreference adds an option to specify the read preference of the operation, overriding the
+// one of the collection.
+func (fb *FindBundle) ReadPreference(rp *readpref.ReadPref) *FindBundle {
+	bundle := &FindBundle{
+		option: ReadPreference(rp),
+		next:   fb,
+	}
+
+	return bundle
+}
+
 // ReturnKey adds an option to only return index keys for all result documents.
 func (fb *FindBundle) ReturnKey(b bool) *FindBundle {
 	bundle := &FindBundle{
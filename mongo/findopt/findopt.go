@@ -4,12 +4,17 @@
 // not use this file except in compliance with the License. You may obtain
 // a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
 
+// Package findopt contains the options of the find and findAndModify operations. The Opt types and
+// bundles of this package are superseded by the option structs of the options package, such as
+// options.Find, and are kept working for existing code.
 package findopt
 
 import (
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
 )
@@ -17,17 +22,21 @@ import (
 var (
 	_ DeleteOne  = (*DeleteOneBundle)(nil)
 	_ DeleteOne  = (*OptCollation)(nil)
+	_ DeleteOne  = (*OptComment)(nil)
 	_ DeleteOne  = (*OptFields)(nil)
+	_ DeleteOne  = (*OptLet)(nil)
 	_ DeleteOne  = (*OptMaxTime)(nil)
 	_ DeleteOne  = (*OptProjection)(nil)
 	_ DeleteOne  = (*OptSort)(nil)
 	_ Find       = (*FindBundle)(nil)
+	_ Find       = (*FindOptions)(nil)
 	_ Find       = (*OptAllowPartialResults)(nil)
 	_ Find       = (*OptBatchSize)(nil)
 	_ Find       = (*OptCollation)(nil)
 	_ Find       = (*OptComment)(nil)
 	_ Find       = (*OptCursorType)(nil)
 	_ Find       = (*OptHint)(nil)
+	_ Find       = (*OptLet)(nil)
 	_ Find       = (*OptLimit)(nil)
 	_ Find       = (*OptMax)(nil)
 	_ Find       = (*OptMaxAwaitTime)(nil)
@@ -36,19 +45,24 @@ var (
 	_ Find       = (*OptMin)(nil)
 	_ Find       = (*OptNoCursorTimeout)(nil)
 	_ Find       = (*OptOplogReplay)(nil)
+	_ Find       = (*OptPrefetch)(nil)
 	_ Find       = (*OptProjection)(nil)
+	_ Find       = (*OptReadConcern)(nil)
+	_ Find       = (*OptReadPreference)(nil)
 	_ Find       = (*OptReturnKey)(nil)
 	_ Find       = (*OptShowRecordID)(nil)
 	_ Find       = (*OptSkip)(nil)
 	_ Find       = (*OptSnapshot)(nil)
 	_ Find       = (*OptSort)(nil)
 	_ One        = (*OneBundle)(nil)
+	_ One        = (*FindOneOptions)(nil)
 	_ One        = (*OptAllowPartialResults)(nil)
 	_ One        = (*OptBatchSize)(nil)
 	_ One        = (*OptCollation)(nil)
 	_ One        = (*OptComment)(nil)
 	_ One        = (*OptCursorType)(nil)
 	_ One        = (*OptHint)(nil)
+	_ One        = (*OptLet)(nil)
 	_ One        = (*OptMax)(nil)
 	_ One        = (*OptMaxAwaitTime)(nil)
 	_ One        = (*OptMaxScan)(nil)
@@ -57,6 +71,8 @@ var (
 	_ One        = (*OptNoCursorTimeout)(nil)
 	_ One        = (*OptOplogReplay)(nil)
 	_ One        = (*OptProjection)(nil)
+	_ One        = (*OptReadConcern)(nil)
+	_ One        = (*OptReadPreference)(nil)
 	_ One        = (*OptReturnKey)(nil)
 	_ One        = (*OptShowRecordID)(nil)
 	_ One        = (*OptSkip)(nil)
@@ -65,7 +81,9 @@ var (
 	_ ReplaceOne = (*ReplaceOneBundle)(nil)
 	_ ReplaceOne = (*OptBypassDocumentValidation)(nil)
 	_ ReplaceOne = (*OptCollation)(nil)
+	_ ReplaceOne = (*OptComment)(nil)
 	_ ReplaceOne = (*OptFields)(nil)
+	_ ReplaceOne = (*OptLet)(nil)
 	_ ReplaceOne = (*OptMaxTime)(nil)
 	_ ReplaceOne = (*OptProjection)(nil)
 	_ ReplaceOne = (*OptReturnDocument)(nil)
@@ -75,7 +93,9 @@ var (
 	_ UpdateOne  = (*OptArrayFilters)(nil)
 	_ UpdateOne  = (*OptBypassDocumentValidation)(nil)
 	_ UpdateOne  = (*OptCollation)(nil)
+	_ UpdateOne  = (*OptComment)(nil)
 	_ UpdateOne  = (*OptFields)(nil)
+	_ UpdateOne  = (*OptLet)(nil)
 	_ UpdateOne  = (*OptMaxTime)(nil)
 	_ UpdateOne  = (*OptProjection)(nil)
 	_ UpdateOne  = (*OptReturnDocument)(nil)
@@ -121,10 +141,11 @@ func CursorType(ct mongoopt.CursorType) OptCursorType {
 	return OptCursorType(ct)
 }
 
-// Comment specifies a string to help trace the operation through the database profiler, currentOp, and logs.
-// Find, One
-func Comment(s string) OptComment {
-	return OptComment(s)
+// Comment specifies a value of any BSON type to help trace the operation through the database
+// profiler, currentOp, and logs. Servers older than 4.4 only accept string comments on find.
+// Find, One, DeleteOne, ReplaceOne, UpdateOne
+func Comment(comment interface{}) OptComment {
+	return OptComment{Comment: comment}
 }
 
 // Hint specifies which index to use.
@@ -133,7 +154,15 @@ func Hint(hint interface{}) OptHint {
 	return OptHint{hint}
 }
 
-// Limit sets a limit on the number of results.
+// Let specifies a document of variables that can be accessed in the filter using $$var. It requires
+// server version 5.0 or newer.
+// Find, One, DeleteOne, ReplaceOne, UpdateOne
+func Let(let interface{}) OptLet {
+	return OptLet{let}
+}
+
+// Limit sets a limit on the number of results. A negative limit returns at most -i results in a
+// single batch, closing the cursor after the first batch.
 // Find
 func Limit(i int64) OptLimit {
 	return OptLimit(i)
@@ -145,7 +174,8 @@ func Max(max interface{}) OptMax {
 	return OptMax{max}
 }
 
-// MaxAwaitTime specifies the max amount of time for the server to wait on new documents.
+// MaxAwaitTime specifies the max amount of time for the server to wait on new documents. It can
+// only be set for a TailableAwait cursor.
 // Find, One
 func MaxAwaitTime(d time.Duration) OptMaxAwaitTime {
 	return OptMaxAwaitTime(d)
@@ -181,6 +211,16 @@ func OplogReplay(b bool) OptOplogReplay {
 	return OptOplogReplay(b)
 }
 
+// Prefetch makes the cursor request its next batch as soon as the documents of the current batch
+// start being iterated, so that the batch is already there when they run out. At most one request
+// is in flight, and its error, if any, is returned by the call to Next that needs the batch.
+// Prefetching is disabled when the cursor is used in an explicit session, which the requests
+// cannot share.
+// Find
+func Prefetch(b bool) OptPrefetch {
+	return OptPrefetch(b)
+}
+
 // Projection limits the fields returned for all documents.
 // Find, One, DeleteOne, ReplaceOne, UpdateOne
 func Projection(projection interface{}) OptProjection {
@@ -189,6 +229,30 @@ func Projection(projection interface{}) OptProjection {
 	}
 }
 
+// ProjectStruct limits the fields returned for all documents to those a value of the struct type
+// of v is decoded from, as described by option.StructProjection.
+// Find, One, DeleteOne, ReplaceOne, UpdateOne
+func ProjectStruct(v interface{}) OptProjection {
+	return Projection(option.StructProjection{Value: v})
+}
+
+// ReadConcern specifies the read concern of the operation, overriding the one of the collection.
+// Find, One
+func ReadConcern(rc *readconcern.ReadConcern) OptReadConcern {
+	return OptReadConcern{
+		ReadConcern: rc,
+	}
+}
+
+// ReadPreference specifies the read preference of the operation, overriding the one of the
+// collection.
+// Find, One
+func ReadPreference(rp *readpref.ReadPref) OptReadPreference {
+	return OptReadPreference{
+		ReadPreference: rp,
+	}
+}
+
 // ReturnDocument specifies whether to return the updated or original document.
 // ReplaceOne, UpdateOne
 func ReturnDocument(rd mongoopt.ReturnDocument) OptReturnDocument {
@@ -201,7 +265,8 @@ func ReturnKey(b bool) OptReturnKey {
 	return OptReturnKey(b)
 }
 
-// ShowRecordID specifies whether to return the record identifier for each document.
+// ShowRecordID specifies whether to return the record identifier for each document. The identifier
+// is added to each document in the $recordId field.
 // Find, One
 func ShowRecordID(b bool) OptShowRecordID {
 	return OptShowRecordID(b)
@@ -340,11 +405,14 @@ func (opt OptCursorType) ConvertFindOneOption() option.FindOptioner {
 	return option.OptCursorType(opt)
 }
 
-// OptComment specifies a string to help trace the operation through the database profiler, currentOp, and logs.
+// OptComment specifies a value to help trace the operation through the database profiler, currentOp, and logs.
 type OptComment option.OptComment
 
-func (OptComment) find() {}
-func (OptComment) one()  {}
+func (OptComment) find()       {}
+func (OptComment) one()        {}
+func (OptComment) deleteOne()  {}
+func (OptComment) replaceOne() {}
+func (OptComment) updateOne()  {}
 
 // ConvertFindOption implements the Find interface.
 func (opt OptComment) ConvertFindOption() option.FindOptioner {
@@ -356,6 +424,21 @@ func (opt OptComment) ConvertFindOneOption() option.FindOptioner {
 	return option.OptComment(opt)
 }
 
+// ConvertDeleteOneOption implements the DeleteOne interface.
+func (opt OptComment) ConvertDeleteOneOption() option.FindOneAndDeleteOptioner {
+	return option.OptComment(opt)
+}
+
+// ConvertReplaceOneOption implements the ReplaceOne interface.
+func (opt OptComment) ConvertReplaceOneOption() option.FindOneAndReplaceOptioner {
+	return option.OptComment(opt)
+}
+
+// ConvertUpdateOneOption implements the UpdateOne interface.
+func (opt OptComment) ConvertUpdateOneOption() option.FindOneAndUpdateOptioner {
+	return option.OptComment(opt)
+}
+
 // OptFields limits the fields returned for find/modify commands.
 type OptFields option.OptFields
 
@@ -394,6 +477,40 @@ func (opt OptHint) ConvertFindOneOption() option.FindOptioner {
 	return option.OptHint(opt)
 }
 
+// OptLet specifies a document of variables that can be accessed in the filter.
+type OptLet option.OptLet
+
+func (OptLet) find()       {}
+func (OptLet) one()        {}
+func (OptLet) deleteOne()  {}
+func (OptLet) replaceOne() {}
+func (OptLet) updateOne()  {}
+
+// ConvertFindOption implements the Find interface.
+func (opt OptLet) ConvertFindOption() option.FindOptioner {
+	return option.OptLet(opt)
+}
+
+// ConvertFindOneOption implements the One interface.
+func (opt OptLet) ConvertFindOneOption() option.FindOptioner {
+	return option.OptLet(opt)
+}
+
+// ConvertDeleteOneOption implements the DeleteOne interface.
+func (opt OptLet) ConvertDeleteOneOption() option.FindOneAndDeleteOptioner {
+	return option.OptLet(opt)
+}
+
+// ConvertReplaceOneOption implements the ReplaceOne interface.
+func (opt OptLet) ConvertReplaceOneOption() option.FindOneAndReplaceOptioner {
+	return option.OptLet(opt)
+}
+
+// ConvertUpdateOneOption implements the UpdateOne interface.
+func (opt OptLet) ConvertUpdateOneOption() option.FindOneAndUpdateOptioner {
+	return option.OptLet(opt)
+}
+
 // OptLimit sets a limit on the number of results.
 type OptLimit option.OptLimit
 
@@ -534,6 +651,16 @@ func (opt OptOplogReplay) ConvertFindOneOption() option.FindOptioner {
 	return option.OptOplogReplay(opt)
 }
 
+// OptPrefetch requests the next batch of the cursor while the current one is iterated.
+type OptPrefetch option.OptPrefetch
+
+func (OptPrefetch) find() {}
+
+// ConvertFindOption implements the Find interface.
+func (opt OptPrefetch) ConvertFindOption() option.FindOptioner {
+	return option.OptPrefetch(opt)
+}
+
 // OptProjection limits the fields returned for all documents.
 type OptProjection option.OptProjection
 
@@ -574,6 +701,38 @@ func (opt OptProjection) ConvertUpdateOneOption() option.FindOneAndUpdateOptione
 	}
 }
 
+// OptReadConcern specifies the read concern of the operation.
+type OptReadConcern option.OptReadConcern
+
+func (OptReadConcern) find() {}
+func (OptReadConcern) one()  {}
+
+// ConvertFindOption implements the Find interface.
+func (opt OptReadConcern) ConvertFindOption() option.FindOptioner {
+	return option.OptReadConcern(opt)
+}
+
+// ConvertFindOneOption implements the One interface.
+func (opt OptReadConcern) ConvertFindOneOption() option.FindOptioner {
+	return option.OptReadConcern(opt)
+}
+
+// OptReadPreference specifies the read preference of the operation.
+type OptReadPreference option.OptReadPreference
+
+func (OptReadPreference) find() {}
+func (OptReadPreference) one()  {}
+
+// ConvertFindOption implements the Find interface.
+func (opt OptReadPreference) ConvertFindOption() option.FindOptioner {
+	return option.OptReadPreference(opt)
+}
+
+// ConvertFindOneOption implements the One interface.
+func (opt OptReadPreference) ConvertFindOneOption() option.FindOptioner {
+	return option.OptReadPreference(opt)
+}
+
 // OptReturnDocument specifies whether to return the updated or original document.
 type OptReturnDocument option.OptReturnDocument
 
@@ -89,17 +89,17 @@ func TestFindOpt(t *testing.T) {
 
 	bundle3Opts := []option.Optioner{
 		OptBatchSize(1).ConvertFindOption(),
-		OptComment("Hello").ConvertFindOption(),
+		Comment("Hello").ConvertFindOption(),
 		OptBatchSize(2).ConvertFindOption(),
 		OptReturnKey(false).ConvertFindOption(),
 		OptReturnKey(true).ConvertFindOption(),
-		OptComment("World").ConvertFindOption(),
+		Comment("World").ConvertFindOption(),
 	}
 
 	bundle3DedupOpts := []option.Optioner{
 		OptBatchSize(2).ConvertFindOption(),
 		OptReturnKey(true).ConvertFindOption(),
-		OptComment("World").ConvertFindOption(),
+		Comment("World").ConvertFindOption(),
 	}
 
 	nilBundle := BundleFind()
@@ -110,13 +110,13 @@ func TestFindOpt(t *testing.T) {
 		OptAllowPartialResults(true).ConvertFindOption(),
 		OptMaxTime(500).ConvertFindOption(),
 		OptAllowPartialResults(false).ConvertFindOption(),
-		OptComment("hello world nested").ConvertFindOption(),
+		Comment("hello world nested").ConvertFindOption(),
 		OptBatchSize(1000).ConvertFindOption(),
 	}
 	nestedBundleDedupOpts1 := []option.Optioner{
 		OptMaxTime(500).ConvertFindOption(),
 		OptAllowPartialResults(false).ConvertFindOption(),
-		OptComment("hello world nested").ConvertFindOption(),
+		Comment("hello world nested").ConvertFindOption(),
 		OptBatchSize(1000).ConvertFindOption(),
 	}
 
@@ -126,14 +126,14 @@ func TestFindOpt(t *testing.T) {
 		OptMaxTime(500).ConvertFindOption(),
 		OptMaxTime(100).ConvertFindOption(),
 		OptAllowPartialResults(false).ConvertFindOption(),
-		OptComment("nest1").ConvertFindOption(),
-		OptComment("nest2").ConvertFindOption(),
+		Comment("nest1").ConvertFindOption(),
+		Comment("nest2").ConvertFindOption(),
 		OptBatchSize(1000).ConvertFindOption(),
 	}
 	nestedBundleDedupOpts2 := []option.Optioner{
 		OptMaxTime(100).ConvertFindOption(),
 		OptAllowPartialResults(false).ConvertFindOption(),
-		OptComment("nest2").ConvertFindOption(),
+		Comment("nest2").ConvertFindOption(),
 		OptBatchSize(1000).ConvertFindOption(),
 	}
 
@@ -141,19 +141,19 @@ func TestFindOpt(t *testing.T) {
 	nestedBundleOpts3 := []option.Optioner{
 		OptMaxTime(100).ConvertFindOption(),
 		OptAllowPartialResults(true).ConvertFindOption(),
-		OptComment("nest3").ConvertFindOption(),
-		OptComment("nest4").ConvertFindOption(),
+		Comment("nest3").ConvertFindOption(),
+		Comment("nest4").ConvertFindOption(),
 		OptMaxTime(500).ConvertFindOption(),
 		OptMaxTime(100).ConvertFindOption(),
 		OptAllowPartialResults(false).ConvertFindOption(),
-		OptComment("nest1").ConvertFindOption(),
-		OptComment("nest2").ConvertFindOption(),
+		Comment("nest1").ConvertFindOption(),
+		Comment("nest2").ConvertFindOption(),
 		OptBatchSize(1000).ConvertFindOption(),
 	}
 	nestedBundleDedupOpts3 := []option.Optioner{
 		OptMaxTime(100).ConvertFindOption(),
 		OptAllowPartialResults(false).ConvertFindOption(),
-		OptComment("nest2").ConvertFindOption(),
+		Comment("nest2").ConvertFindOption(),
 		OptBatchSize(1000).ConvertFindOption(),
 	}
 
@@ -169,6 +169,7 @@ func TestFindOpt(t *testing.T) {
 			Comment("hello world testing find"),
 			CursorType(mongoopt.Tailable),
 			Hint("hint for find"),
+			Let("let for find"),
 			Limit(10),
 			Max("max for find"),
 			MaxAwaitTime(100),
@@ -177,6 +178,7 @@ func TestFindOpt(t *testing.T) {
 			Min("min for find"),
 			NoCursorTimeout(false),
 			OplogReplay(true),
+			Prefetch(true),
 			Projection("projection for find"),
 			ReturnKey(true),
 			ShowRecordID(false),
@@ -0,0 +1,60 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package findopt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []Find
+		err  error
+	}{
+		{"no options", nil, nil},
+		{"maxAwaitTime tailable await", []Find{CursorType(mongoopt.TailableAwait), MaxAwaitTime(time.Second)}, nil},
+		{"maxAwaitTime tailable", []Find{CursorType(mongoopt.Tailable), MaxAwaitTime(time.Second)}, ErrMaxAwaitTimeNotTailableAwait},
+		{"maxAwaitTime non-tailable", []Find{MaxAwaitTime(time.Second)}, ErrMaxAwaitTimeNotTailableAwait},
+		{"last cursor type applies", []Find{CursorType(mongoopt.TailableAwait), MaxAwaitTime(time.Second), CursorType(mongoopt.NonTailable)}, ErrMaxAwaitTimeNotTailableAwait},
+		{"skip and limit", []Find{Skip(3), Limit(10)}, nil},
+		{"skip tailable", []Find{CursorType(mongoopt.Tailable), Skip(3)}, nil},
+		{"limit tailable", []Find{CursorType(mongoopt.Tailable), Limit(-1)}, nil},
+		{"skip and limit tailable", []Find{CursorType(mongoopt.Tailable), Skip(3), Limit(10)}, ErrTailableSkipLimit},
+		{"skip and limit tailable await", []Find{CursorType(mongoopt.TailableAwait), Skip(3), Limit(-1)}, ErrTailableSkipLimit},
+		{"zero skip tailable", []Find{CursorType(mongoopt.Tailable), Skip(0), Limit(10)}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts, _, err := BundleFind(tc.opts...).Unbundle(false)
+			if err != nil {
+				t.Fatalf("got non-nil error from unbundle: %s", err)
+			}
+
+			if err = Validate(opts); err != tc.err {
+				t.Errorf("expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+
+	t.Run("find one", func(t *testing.T) {
+		opts, _, err := BundleOne(CursorType(mongoopt.Tailable), Skip(3)).Unbundle(true)
+		if err != nil {
+			t.Fatalf("got non-nil error from unbundle: %s", err)
+		}
+		opts = append(opts, option.OptLimit(-1))
+
+		if err = Validate(opts); err != ErrTailableSkipLimit {
+			t.Errorf("expected error %v, got %v", ErrTailableSkipLimit, err)
+		}
+	})
+}
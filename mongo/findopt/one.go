@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
 )
@@ -40,11 +42,17 @@ type OneBundle struct {
 	next   *OneBundle
 }
 
-// BundleOne bundles FindOne options
+// BundleOne bundles FindOne options. A *FindOneOptions is bundled as the options its fields are set
+// to when it is bundled.
+// New code should build the options with options.FindOne instead.
 func BundleOne(opts ...One) *OneBundle {
 	head := oneBundle
 
 	for _, opt := range opts {
+		if o, ok := opt.(*FindOneOptions); ok {
+			opt = o.bundle()
+		}
+
 		newBundle := OneBundle{
 			option: opt,
 			next:   head,
@@ -91,10 +99,10 @@ func (ob *OneBundle) Collation(collation *mongoopt.Collation) *OneBundle {
 	return bundle
 }
 
-// Comment adds an option to specify a string to help trace the operation through teh database profiler, currentOp, and logs
-func (ob *OneBundle) Comment(s string) *OneBundle {
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (ob *OneBundle) Comment(comment interface{}) *OneBundle {
 	bundle := &OneBundle{
-		option: Comment(s),
+		option: Comment(comment),
 		next:   ob,
 	}
 
@@ -121,6 +129,16 @@ func (ob *OneBundle) Hint(hint interface{}) *OneBundle {
 	return bundle
 }
 
+// Let adds an option to specify a document of variables that can be accessed in the filter.
+func (ob *OneBundle) Let(let interface{}) *OneBundle {
+	bundle := &OneBundle{
+		option: Let(let),
+		next:   ob,
+	}
+
+	return bundle
+}
+
 // Max adds an option to set an exclusive upper bound for a specific index.
 func (ob *OneBundle) Max(max interface{}) *OneBundle {
 	bundle := &OneBundle{
@@ -201,6 +219,39 @@ func (ob *OneBundle) Projection(projection interface{}) *OneBundle {
 	return bundle
 }
 
+// ProjectStruct adds an option to limit the fields returned for all documents to those a value of
+// the struct type of v is decoded from.
+func (ob *OneBundle) ProjectStruct(v interface{}) *OneBundle {
+	bundle := &OneBundle{
+		option: ProjectStruct(v),
+		next:   ob,
+	}
+
+	return bundle
+}
+
+// ReadConcern adds an option to specify the read concern of the operation, overriding the one of
+// the collection.
+func (ob *OneBundle) ReadConcern(rc *readconcern.ReadConcern) *OneBundle {
+	bundle := &OneBundle{
+		option: ReadConcern(rc),
+		next:   ob,
+	}
+
+	return bundle
+}
+
+// ReadPreference adds an option to specify the read preference of the operation, overriding the
+// one of the collection.
+func (ob *OneBundle) ReadPreference(rp *readpref.ReadPref) *OneBundle {
+	bundle := &OneBundle{
+		option: ReadPreference(rp),
+		next:   ob,
+	}
+
+	return bundle
+}
+
 // ReturnKey adds an option to only return index keys for all results.
 func (ob *OneBundle) ReturnKey(b bool) *OneBundle {
 	bundle := &OneBundle{
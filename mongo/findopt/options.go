@@ -0,0 +1,411 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package findopt
+
+import (
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
+	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
+)
+
+// FindOptions holds the options of Collection.Find as plain fields, which are set directly or with
+// its Set methods. A *FindOptions is passed to Collection.Find like any other Find option and is
+// converted to the equivalent bundle when the options are bundled. Unset fields are left out.
+type FindOptions struct {
+	AllowPartialResults *bool
+	BatchSize           *int32
+	Collation           *mongoopt.Collation
+	Comment             interface{}
+	CursorType          *mongoopt.CursorType
+	Hint                interface{}
+	Let                 interface{}
+	Limit               *int64
+	Max                 interface{}
+	MaxAwaitTime        *time.Duration
+	MaxTime             *time.Duration
+	Min                 interface{}
+	NoCursorTimeout     *bool
+	Prefetch            *bool
+	Projection          interface{}
+	ReadConcern         *readconcern.ReadConcern
+	ReadPreference      *readpref.ReadPref
+	ReturnKey           *bool
+	ShowRecordID        *bool
+	Skip                *int64
+	Sort                interface{}
+}
+
+func (*FindOptions) find() {}
+
+// SetAllowPartialResults sets the AllowPartialResults field. See AllowPartialResults.
+func (fo *FindOptions) SetAllowPartialResults(b bool) *FindOptions {
+	fo.AllowPartialResults = &b
+	return fo
+}
+
+// SetBatchSize sets the BatchSize field. See BatchSize.
+func (fo *FindOptions) SetBatchSize(i int32) *FindOptions {
+	fo.BatchSize = &i
+	return fo
+}
+
+// SetCollation sets the Collation field. See Collation.
+func (fo *FindOptions) SetCollation(collation *mongoopt.Collation) *FindOptions {
+	fo.Collation = collation
+	return fo
+}
+
+// SetComment sets the Comment field. See Comment.
+func (fo *FindOptions) SetComment(comment interface{}) *FindOptions {
+	fo.Comment = comment
+	return fo
+}
+
+// SetCursorType sets the CursorType field. See CursorType.
+func (fo *FindOptions) SetCursorType(ct mongoopt.CursorType) *FindOptions {
+	fo.CursorType = &ct
+	return fo
+}
+
+// SetHint sets the Hint field. See Hint.
+func (fo *FindOptions) SetHint(hint interface{}) *FindOptions {
+	fo.Hint = hint
+	return fo
+}
+
+// SetLet sets the Let field. See Let.
+func (fo *FindOptions) SetLet(let interface{}) *FindOptions {
+	fo.Let = let
+	return fo
+}
+
+// SetLimit sets the Limit field. See Limit.
+func (fo *FindOptions) SetLimit(i int64) *FindOptions {
+	fo.Limit = &i
+	return fo
+}
+
+// SetMax sets the Max field. See Max.
+func (fo *FindOptions) SetMax(max interface{}) *FindOptions {
+	fo.Max = max
+	return fo
+}
+
+// SetMaxAwaitTime sets the MaxAwaitTime field. See MaxAwaitTime.
+func (fo *FindOptions) SetMaxAwaitTime(d time.Duration) *FindOptions {
+	fo.MaxAwaitTime = &d
+	return fo
+}
+
+// SetMaxTime sets the MaxTime field. See MaxTime.
+func (fo *FindOptions) SetMaxTime(d time.Duration) *FindOptions {
+	fo.MaxTime = &d
+	return fo
+}
+
+// SetMin sets the Min field. See Min.
+func (fo *FindOptions) SetMin(min interface{}) *FindOptions {
+	fo.Min = min
+	return fo
+}
+
+// SetNoCursorTimeout sets the NoCursorTimeout field. See NoCursorTimeout.
+func (fo *FindOptions) SetNoCursorTimeout(b bool) *FindOptions {
+	fo.NoCursorTimeout = &b
+	return fo
+}
+
+// SetPrefetch sets the Prefetch field. See Prefetch.
+func (fo *FindOptions) SetPrefetch(b bool) *FindOptions {
+	fo.Prefetch = &b
+	return fo
+}
+
+// SetProjection sets the Projection field. See Projection.
+func (fo *FindOptions) SetProjection(projection interface{}) *FindOptions {
+	fo.Projection = projection
+	return fo
+}
+
+// SetReadConcern sets the ReadConcern field. See ReadConcern.
+func (fo *FindOptions) SetReadConcern(rc *readconcern.ReadConcern) *FindOptions {
+	fo.ReadConcern = rc
+	return fo
+}
+
+// SetReadPreference sets the ReadPreference field. See ReadPreference.
+func (fo *FindOptions) SetReadPreference(rp *readpref.ReadPref) *FindOptions {
+	fo.ReadPreference = rp
+	return fo
+}
+
+// SetReturnKey sets the ReturnKey field. See ReturnKey.
+func (fo *FindOptions) SetReturnKey(b bool) *FindOptions {
+	fo.ReturnKey = &b
+	return fo
+}
+
+// SetShowRecordID sets the ShowRecordID field. See ShowRecordID.
+func (fo *FindOptions) SetShowRecordID(b bool) *FindOptions {
+	fo.ShowRecordID = &b
+	return fo
+}
+
+// SetSkip sets the Skip field. See Skip.
+func (fo *FindOptions) SetSkip(i int64) *FindOptions {
+	fo.Skip = &i
+	return fo
+}
+
+// SetSort sets the Sort field. See Sort.
+func (fo *FindOptions) SetSort(sort interface{}) *FindOptions {
+	fo.Sort = sort
+	return fo
+}
+
+// bundle converts the set fields to a bundle, in the order the fields are declared in.
+func (fo *FindOptions) bundle() *FindBundle {
+	fb := BundleFind()
+	if fo == nil {
+		return fb
+	}
+
+	if fo.AllowPartialResults != nil {
+		fb = fb.AllowPartialResults(*fo.AllowPartialResults)
+	}
+	if fo.BatchSize != nil {
+		fb = fb.BatchSize(*fo.BatchSize)
+	}
+	if fo.Collation != nil {
+		fb = fb.Collation(fo.Collation)
+	}
+	if fo.Comment != nil {
+		fb = fb.Comment(fo.Comment)
+	}
+	if fo.CursorType != nil {
+		fb = fb.CursorType(*fo.CursorType)
+	}
+	if fo.Hint != nil {
+		fb = fb.Hint(fo.Hint)
+	}
+	if fo.Let != nil {
+		fb = fb.Let(fo.Let)
+	}
+	if fo.Limit != nil {
+		fb = fb.Limit(*fo.Limit)
+	}
+	if fo.Max != nil {
+		fb = fb.Max(fo.Max)
+	}
+	if fo.MaxAwaitTime != nil {
+		fb = fb.MaxAwaitTime(*fo.MaxAwaitTime)
+	}
+	if fo.MaxTime != nil {
+		fb = fb.MaxTime(*fo.MaxTime)
+	}
+	if fo.Min != nil {
+		fb = fb.Min(fo.Min)
+	}
+	if fo.NoCursorTimeout != nil {
+		fb = fb.NoCursorTimeout(*fo.NoCursorTimeout)
+	}
+	if fo.Prefetch != nil {
+		fb = fb.Prefetch(*fo.Prefetch)
+	}
+	if fo.Projection != nil {
+		fb = fb.Projection(fo.Projection)
+	}
+	if fo.ReadConcern != nil {
+		fb = fb.ReadConcern(fo.ReadConcern)
+	}
+	if fo.ReadPreference != nil {
+		fb = fb.ReadPreference(fo.ReadPreference)
+	}
+	if fo.ReturnKey != nil {
+		fb = fb.ReturnKey(*fo.ReturnKey)
+	}
+	if fo.ShowRecordID != nil {
+		fb = fb.ShowRecordID(*fo.ShowRecordID)
+	}
+	if fo.Skip != nil {
+		fb = fb.Skip(*fo.Skip)
+	}
+	if fo.Sort != nil {
+		fb = fb.Sort(fo.Sort)
+	}
+	return fb
+}
+
+// FindOneOptions holds the options of Collection.FindOne as plain fields, which are set directly
+// or with its Set methods. A *FindOneOptions is passed to Collection.FindOne like any other One
+// option and is converted to the equivalent bundle when the options are bundled.
+type FindOneOptions struct {
+	AllowPartialResults *bool
+	Collation           *mongoopt.Collation
+	Comment             interface{}
+	Hint                interface{}
+	Let                 interface{}
+	Max                 interface{}
+	MaxTime             *time.Duration
+	Min                 interface{}
+	Projection          interface{}
+	ReadConcern         *readconcern.ReadConcern
+	ReadPreference      *readpref.ReadPref
+	ReturnKey           *bool
+	ShowRecordID        *bool
+	Skip                *int64
+	Sort                interface{}
+}
+
+func (*FindOneOptions) one() {}
+
+// SetAllowPartialResults sets the AllowPartialResults field. See AllowPartialResults.
+func (fo *FindOneOptions) SetAllowPartialResults(b bool) *FindOneOptions {
+	fo.AllowPartialResults = &b
+	return fo
+}
+
+// SetCollation sets the Collation field. See Collation.
+func (fo *FindOneOptions) SetCollation(collation *mongoopt.Collation) *FindOneOptions {
+	fo.Collation = collation
+	return fo
+}
+
+// SetComment sets the Comment field. See Comment.
+func (fo *FindOneOptions) SetComment(comment interface{}) *FindOneOptions {
+	fo.Comment = comment
+	return fo
+}
+
+// SetHint sets the Hint field. See Hint.
+func (fo *FindOneOptions) SetHint(hint interface{}) *FindOneOptions {
+	fo.Hint = hint
+	return fo
+}
+
+// SetLet sets the Let field. See Let.
+func (fo *FindOneOptions) SetLet(let interface{}) *FindOneOptions {
+	fo.Let = let
+	return fo
+}
+
+// SetMax sets the Max field. See Max.
+func (fo *FindOneOptions) SetMax(max interface{}) *FindOneOptions {
+	fo.Max = max
+	return fo
+}
+
+// SetMaxTime sets the MaxTime field. See MaxTime.
+func (fo *FindOneOptions) SetMaxTime(d time.Duration) *FindOneOptions {
+	fo.MaxTime = &d
+	return fo
+}
+
+// SetMin sets the Min field. See Min.
+func (fo *FindOneOptions) SetMin(min interface{}) *FindOneOptions {
+	fo.Min = min
+	return fo
+}
+
+// SetProjection sets the Projection field. See Projection.
+func (fo *FindOneOptions) SetProjection(projection interface{}) *FindOneOptions {
+	fo.Projection = projection
+	return fo
+}
+
+// SetReadConcern sets the ReadConcern field. See ReadConcern.
+func (fo *FindOneOptions) SetReadConcern(rc *readconcern.ReadConcern) *FindOneOptions {
+	fo.ReadConcern = rc
+	return fo
+}
+
+// SetReadPreference sets the ReadPreference field. See ReadPreference.
+func (fo *FindOneOptions) SetReadPreference(rp *readpref.ReadPref) *FindOneOptions {
+	fo.ReadPreference = rp
+	return fo
+}
+
+// SetReturnKey sets the ReturnKey field. See ReturnKey.
+func (fo *FindOneOptions) SetReturnKey(b bool) *FindOneOptions {
+	fo.ReturnKey = &b
+	return fo
+}
+
+// SetShowRecordID sets the ShowRecordID field. See ShowRecordID.
+func (fo *FindOneOptions) SetShowRecordID(b bool) *FindOneOptions {
+	fo.ShowRecordID = &b
+	return fo
+}
+
+// SetSkip sets the Skip field. See Skip.
+func (fo *FindOneOptions) SetSkip(i int64) *FindOneOptions {
+	fo.Skip = &i
+	return fo
+}
+
+// SetSort sets the Sort field. See Sort.
+func (fo *FindOneOptions) SetSort(sort interface{}) *FindOneOptions {
+	fo.Sort = sort
+	return fo
+}
+
+// bundle converts the set fields to a bundle, in the order the fields are declared in.
+func (fo *FindOneOptions) bundle() *OneBundle {
+	ob := BundleOne()
+	if fo == nil {
+		return ob
+	}
+
+	if fo.AllowPartialResults != nil {
+		ob = ob.AllowPartialResults(*fo.AllowPartialResults)
+	}
+	if fo.Collation != nil {
+		ob = ob.Collation(fo.Collation)
+	}
+	if fo.Comment != nil {
+		ob = ob.Comment(fo.Comment)
+	}
+	if fo.Hint != nil {
+		ob = ob.Hint(fo.Hint)
+	}
+	if fo.Let != nil {
+		ob = ob.Let(fo.Let)
+	}
+	if fo.Max != nil {
+		ob = ob.Max(fo.Max)
+	}
+	if fo.MaxTime != nil {
+		ob = ob.MaxTime(*fo.MaxTime)
+	}
+	if fo.Min != nil {
+		ob = ob.Min(fo.Min)
+	}
+	if fo.Projection != nil {
+		ob = ob.Projection(fo.Projection)
+	}
+	if fo.ReadConcern != nil {
+		ob = ob.ReadConcern(fo.ReadConcern)
+	}
+	if fo.ReadPreference != nil {
+		ob = ob.ReadPreference(fo.ReadPreference)
+	}
+	if fo.ReturnKey != nil {
+		ob = ob.ReturnKey(*fo.ReturnKey)
+	}
+	if fo.ShowRecordID != nil {
+		ob = ob.ShowRecordID(*fo.ShowRecordID)
+	}
+	if fo.Skip != nil {
+		ob = ob.Skip(*fo.Skip)
+	}
+	if fo.Sort != nil {
+		ob = ob.Sort(fo.Sort)
+	}
+	return ob
+}
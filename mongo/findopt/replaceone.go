@@ -82,6 +82,26 @@ func (rob *ReplaceOneBundle) Collation(collation *mongoopt.Collation) *ReplaceOn
 	return bundle
 }
 
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (rob *ReplaceOneBundle) Comment(comment interface{}) *ReplaceOneBundle {
+	bundle := &ReplaceOneBundle{
+		option: Comment(comment),
+		next:   rob,
+	}
+
+	return bundle
+}
+
+// Let adds an option to specify a document of variables that can be accessed in the filter.
+func (rob *ReplaceOneBundle) Let(let interface{}) *ReplaceOneBundle {
+	bundle := &ReplaceOneBundle{
+		option: Let(let),
+		next:   rob,
+	}
+
+	return bundle
+}
+
 // MaxTime adds an option to specify the max time to allow the query to run.
 func (rob *ReplaceOneBundle) MaxTime(d time.Duration) *ReplaceOneBundle {
 	bundle := &ReplaceOneBundle{
@@ -102,6 +122,17 @@ func (rob *ReplaceOneBundle) Projection(projection interface{}) *ReplaceOneBundl
 	return bundle
 }
 
+// ProjectStruct adds an option to limit the fields returned for all documents to those a value of
+// the struct type of v is decoded from.
+func (rob *ReplaceOneBundle) ProjectStruct(v interface{}) *ReplaceOneBundle {
+	bundle := &ReplaceOneBundle{
+		option: ProjectStruct(v),
+		next:   rob,
+	}
+
+	return bundle
+}
+
 // ReturnDocument adds an option to specify whether to return the updated or original document.
 func (rob *ReplaceOneBundle) ReturnDocument(rd mongoopt.ReturnDocument) *ReplaceOneBundle {
 	bundle := &ReplaceOneBundle{
@@ -92,6 +92,26 @@ func (uob *UpdateOneBundle) Collation(collation *mongoopt.Collation) *UpdateOneB
 	return bundle
 }
 
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (uob *UpdateOneBundle) Comment(comment interface{}) *UpdateOneBundle {
+	bundle := &UpdateOneBundle{
+		option: Comment(comment),
+		next:   uob,
+	}
+
+	return bundle
+}
+
+// Let adds an option to specify a document of variables that can be accessed in the filter.
+func (uob *UpdateOneBundle) Let(let interface{}) *UpdateOneBundle {
+	bundle := &UpdateOneBundle{
+		option: Let(let),
+		next:   uob,
+	}
+
+	return bundle
+}
+
 // MaxTime adds an option to specify the max time to allow the query to run.
 func (uob *UpdateOneBundle) MaxTime(d time.Duration) *UpdateOneBundle {
 	bundle := &UpdateOneBundle{
@@ -112,6 +132,17 @@ func (uob *UpdateOneBundle) Projection(projection interface{}) *UpdateOneBundle
 	return bundle
 }
 
+// ProjectStruct adds an option to limit the fields returned for all documents to those a value of
+// the struct type of v is decoded from.
+func (uob *UpdateOneBundle) ProjectStruct(v interface{}) *UpdateOneBundle {
+	bundle := &UpdateOneBundle{
+		option: ProjectStruct(v),
+		next:   uob,
+	}
+
+	return bundle
+}
+
 // ReturnDocument adds an option to specify whether to return the updated or original document.
 func (uob *UpdateOneBundle) ReturnDocument(rd mongoopt.ReturnDocument) *UpdateOneBundle {
 	bundle := &UpdateOneBundle{
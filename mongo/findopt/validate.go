@@ -0,0 +1,48 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package findopt
+
+import (
+	"errors"
+
+	"github.com/mongodb/mongo-go-driver/core/option"
+)
+
+// ErrMaxAwaitTimeNotTailableAwait is returned when MaxAwaitTime is set for a cursor that is not
+// TailableAwait, whose getMores never wait for new documents.
+var ErrMaxAwaitTimeNotTailableAwait = errors.New("maxAwaitTime can only be set for a TailableAwait cursor")
+
+// ErrTailableSkipLimit is returned when both Skip and Limit are set for a tailable cursor.
+var ErrTailableSkipLimit = errors.New("skip and limit cannot both be set for a tailable cursor")
+
+// Validate returns an error if the unbundled options of a find are not valid together. When an
+// option is given several times, the last one applies. Both Find and FindOne, which forces a limit
+// of -1, validate their options with it.
+func Validate(opts []option.FindOptioner) error {
+	var cursorType option.CursorType
+	var maxAwaitTime, skip, limit bool
+	for _, opt := range opts {
+		switch t := opt.(type) {
+		case option.OptCursorType:
+			cursorType = option.CursorType(t)
+		case option.OptMaxAwaitTime:
+			maxAwaitTime = t != 0
+		case option.OptSkip:
+			skip = t != 0
+		case option.OptLimit:
+			limit = t != 0
+		}
+	}
+
+	if maxAwaitTime && cursorType != option.TailableAwait {
+		return ErrMaxAwaitTimeNotTailableAwait
+	}
+	if skip && limit && (cursorType == option.Tailable || cursorType == option.TailableAwait) {
+		return ErrTailableSkipLimit
+	}
+	return nil
+}
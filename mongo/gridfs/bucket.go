@@ -7,6 +7,8 @@ import (
 
 	"errors"
 
+	"fmt"
+
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
@@ -191,7 +193,12 @@ func (b *Bucket) OpenDownloadStreamByName(filename string, opts ...NameOptioner)
 	var numSkip int32 = -1
 	var sortOrder int32 = 1
 
-	for _, opt := range opts {
+	nameOpts, err := BundleName(opts...).Unbundle(true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, opt := range nameOpts {
 		if revision, ok := opt.(OptRevision); ok {
 			numSkip = int32(revision)
 		}
@@ -327,16 +334,39 @@ func (b *Bucket) openDownloadStream(filter interface{}, opts ...findopt.Find) (*
 		return nil, err
 	}
 
-	fileLen := fileLenElem.Value().Int64()
+	// drivers are permitted to store the length and chunkSize fields as any numeric type
+	fileLen, err := fileLenElem.Value().AsInt64()
+	if err != nil || fileLen < 0 {
+		return nil, ErrWrongSize
+	}
+
+	// the file may have been uploaded with a chunk size other than the bucket's
+	chunkSize := b.chunkSize
+	if chunkSizeElem, err := fileRdr.Lookup("chunkSize"); err == nil {
+		size, err := chunkSizeElem.Value().AsInt64()
+		if err != nil || size <= 0 {
+			return nil, ErrWrongSize
+		}
+		chunkSize = int32(size)
+	}
+
+	fileID, ok := fileIDElem.Value().ObjectIDOK()
+	if !ok {
+		return nil, fmt.Errorf("gridfs: file _id is a BSON %s, but only ObjectIDs are supported", fileIDElem.Value().Type())
+	}
+	findChunks := func(ctx context.Context, fromChunk int32) (mongo.Cursor, error) {
+		return b.findChunks(ctx, fileID, fromChunk)
+	}
+
 	if fileLen == 0 {
-		return newDownloadStream(nil, b.chunkSize, 0), nil
+		return newDownloadStream(nil, chunkSize, 0, findChunks), nil
 	}
 
-	chunksCursor, err := b.findChunks(ctx, fileIDElem.Value().ObjectID())
+	chunksCursor, err := b.findChunks(ctx, fileID, 0)
 	if err != nil {
 		return nil, err
 	}
-	return newDownloadStream(chunksCursor, b.chunkSize, fileLen), nil
+	return newDownloadStream(chunksCursor, chunkSize, fileLen, findChunks), nil
 }
 
 func deadlineContext(deadline time.Time) (context.Context, context.CancelFunc) {
@@ -384,10 +414,15 @@ func (b *Bucket) findFile(ctx context.Context, filter interface{}, opts ...findo
 	return cursor, nil
 }
 
-func (b *Bucket) findChunks(ctx context.Context, fileID objectid.ObjectID) (mongo.Cursor, error) {
-	chunksCursor, err := b.chunksColl.Find(ctx, bson.NewDocument(
+func (b *Bucket) findChunks(ctx context.Context, fileID objectid.ObjectID, fromChunk int32) (mongo.Cursor, error) {
+	filter := bson.NewDocument(
 		bson.EC.ObjectID("files_id", fileID),
-	), findopt.Sort(bson.NewDocument(
+	)
+	if fromChunk > 0 {
+		filter.Append(bson.EC.SubDocumentFromElements("n", bson.EC.Int32("$gte", fromChunk)))
+	}
+
+	chunksCursor, err := b.chunksColl.Find(ctx, filter, findopt.Sort(bson.NewDocument(
 		bson.EC.Int32("n", 1), // sort by chunk index
 	)))
 	if err != nil {
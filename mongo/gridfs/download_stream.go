@@ -18,32 +18,46 @@ var ErrWrongIndex = errors.New("chunk index does not match expected index")
 // ErrWrongSize is used when the chunk retrieved from the server does not have the expected size.
 var ErrWrongSize = errors.New("chunk size does not match expected size")
 
+// ErrInvalidWhence is used when Seek is called with a whence value other than io.SeekStart,
+// io.SeekCurrent, or io.SeekEnd.
+var ErrInvalidWhence = errors.New("invalid whence")
+
+// ErrNegativeOffset is used when a seek or read would move to a position before the start of the file.
+var ErrNegativeOffset = errors.New("negative offset")
+
 var errNoMoreChunks = errors.New("no more chunks remaining")
 
-// DownloadStream is a io.Reader that can be used to download a file from a GridFS bucket.
+// chunkFinder returns a cursor over the chunks of a file, starting at the chunk with the given index.
+type chunkFinder func(ctx context.Context, fromChunk int32) (mongo.Cursor, error)
+
+// DownloadStream is a io.Reader, io.Seeker, and io.ReaderAt that can be used to download a file from a GridFS
+// bucket.
 type DownloadStream struct {
 	numChunks     int32
 	chunkSize     int32
-	cursor        mongo.Cursor
-	done          bool
+	cursor        mongo.Cursor // nil if the chunks must be re-queried starting at expectedChunk
+	findChunks    chunkFinder
 	closed        bool
 	buffer        []byte // store up to 1 chunk if the user provided buffer isn't big enough
 	bufferStart   int
+	bufferEnd     int   // number of bytes of the current chunk held in buffer
+	chunkOffset   int   // number of bytes to discard from the next chunk fetched after a seek
 	expectedChunk int32 // index of next expected chunk
+	offset        int64 // current position in the file
 	readDeadline  time.Time
 	fileLen       int64
 }
 
-func newDownloadStream(cursor mongo.Cursor, chunkSize int32, fileLen int64) *DownloadStream {
+func newDownloadStream(cursor mongo.Cursor, chunkSize int32, fileLen int64, findChunks chunkFinder) *DownloadStream {
 	numChunks := int32(math.Ceil(float64(fileLen) / float64(chunkSize)))
 
 	return &DownloadStream{
-		numChunks: numChunks,
-		chunkSize: chunkSize,
-		cursor:    cursor,
-		buffer:    make([]byte, chunkSize),
-		done:      cursor == nil,
-		fileLen:   fileLen,
+		numChunks:  numChunks,
+		chunkSize:  chunkSize,
+		cursor:     cursor,
+		findChunks: findChunks,
+		buffer:     make([]byte, chunkSize),
+		fileLen:    fileLen,
 	}
 }
 
@@ -54,7 +68,7 @@ func (ds *DownloadStream) Close() error {
 	}
 
 	ds.closed = true
-	return nil
+	return ds.closeCursor()
 }
 
 // SetReadDeadline sets the read deadline for this download stream.
@@ -67,13 +81,14 @@ func (ds *DownloadStream) SetReadDeadline(t time.Time) error {
 	return nil
 }
 
-// Read reads the file from the server and writes it to a destination byte slice.
+// Read reads the file from the server and writes it to a destination byte slice. If a chunk of the file
+// is missing or has an unexpected size, ErrWrongIndex or ErrWrongSize is returned.
 func (ds *DownloadStream) Read(p []byte) (int, error) {
 	if ds.closed {
 		return 0, ErrStreamClosed
 	}
 
-	if ds.done {
+	if ds.offset >= ds.fileLen {
 		return 0, io.EOF
 	}
 
@@ -86,11 +101,14 @@ func (ds *DownloadStream) Read(p []byte) (int, error) {
 	var err error
 
 	for bytesCopied < len(p) {
-		if ds.bufferStart == 0 {
+		if ds.bufferStart == ds.bufferEnd {
 			// buffer empty
 			err = ds.fillBuffer(ctx)
 			if err != nil {
 				if err == errNoMoreChunks {
+					if bytesCopied == 0 {
+						return 0, io.EOF
+					}
 					return bytesCopied, nil
 				}
 
@@ -98,67 +116,154 @@ func (ds *DownloadStream) Read(p []byte) (int, error) {
 			}
 		}
 
-		copied := copy(p, ds.buffer[ds.bufferStart:])
+		copied := copy(p[bytesCopied:], ds.buffer[ds.bufferStart:ds.bufferEnd])
 		bytesCopied += copied
-		ds.bufferStart = (ds.bufferStart + copied) % int(ds.chunkSize)
+		ds.bufferStart += copied
+		ds.offset += int64(copied)
 	}
 
 	return len(p), nil
 }
 
+// Seek sets the offset for the next Read. Seeking to a position in a chunk other than the buffered or next chunk
+// queries the chunks collection starting at the target chunk rather than reading from the start of the file. As
+// with an os.File, it is valid to seek past the end of the file; subsequent reads return io.EOF.
+func (ds *DownloadStream) Seek(offset int64, whence int) (int64, error) {
+	if ds.closed {
+		return 0, ErrStreamClosed
+	}
+
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = ds.offset + offset
+	case io.SeekEnd:
+		abs = ds.fileLen + offset
+	default:
+		return 0, ErrInvalidWhence
+	}
+
+	if abs < 0 {
+		return 0, ErrNegativeOffset
+	}
+
+	chunk := int32(abs / int64(ds.chunkSize))
+	chunkOffset := int(abs % int64(ds.chunkSize))
+
+	switch {
+	case abs >= ds.fileLen:
+		// reads past the end of the file return io.EOF, so there is nothing to fetch
+	case ds.bufferEnd > 0 && chunk == ds.expectedChunk-1:
+		// the target is in the buffered chunk
+		ds.bufferStart = chunkOffset
+	case ds.cursor != nil && chunk == ds.expectedChunk:
+		// the target is in the next chunk of the open cursor
+		ds.bufferStart, ds.bufferEnd = 0, 0
+		ds.chunkOffset = chunkOffset
+	default:
+		if err := ds.closeCursor(); err != nil {
+			return 0, err
+		}
+
+		ds.expectedChunk = chunk
+		ds.bufferStart, ds.bufferEnd = 0, 0
+		ds.chunkOffset = chunkOffset
+	}
+
+	ds.offset = abs
+	return abs, nil
+}
+
+// ReadAt reads len(p) bytes from the file starting at byte offset off. It does not change the offset used by
+// Read and Seek.
+func (ds *DownloadStream) ReadAt(p []byte, off int64) (int, error) {
+	if ds.closed {
+		return 0, ErrStreamClosed
+	}
+
+	if off < 0 {
+		return 0, ErrNegativeOffset
+	}
+
+	rs := newDownloadStream(nil, ds.chunkSize, ds.fileLen, ds.findChunks)
+	rs.readDeadline = ds.readDeadline
+	defer func() {
+		_ = rs.Close()
+	}()
+
+	if _, err := rs.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	n, err := io.ReadFull(rs, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+
+	return n, err
+}
+
 // Skip skips a given number of bytes in the file.
 func (ds *DownloadStream) Skip(skip int64) (int64, error) {
 	if ds.closed {
 		return 0, ErrStreamClosed
 	}
 
-	if ds.done {
+	remaining := ds.fileLen - ds.offset
+	if skip <= 0 || remaining <= 0 {
 		return 0, nil
 	}
 
-	ctx, cancel := deadlineContext(ds.readDeadline)
-	if cancel != nil {
-		defer cancel()
+	if skip > remaining {
+		skip = remaining
 	}
 
-	var skipped int64
-	var err error
-
-	for skipped < skip {
-		if ds.bufferStart == 0 {
-			err = ds.fillBuffer(ctx)
-			if err != nil {
-				if err == errNoMoreChunks {
-					return skipped, nil
-				}
+	if _, err := ds.Seek(skip, io.SeekCurrent); err != nil {
+		return 0, err
+	}
 
-				return skipped, err
-			}
-		}
+	return skip, nil
+}
 
-		// try to skip whole chunk if possible
-		toSkip := 0
-		if skip-skipped < int64(len(ds.buffer)) {
-			// can skip whole chunk
-			toSkip = len(ds.buffer)
-		} else {
-			// can only skip part of buffer
-			toSkip = int(skip - skipped)
-		}
+func (ds *DownloadStream) closeCursor() error {
+	if ds.cursor == nil {
+		return nil
+	}
 
-		skipped += int64(toSkip)
-		ds.bufferStart = (ds.bufferStart + toSkip) % (int(ds.chunkSize))
+	ctx, cancel := deadlineContext(ds.readDeadline)
+	if cancel != nil {
+		defer cancel()
 	}
 
-	return skip, nil
+	err := ds.cursor.Close(ctx)
+	ds.cursor = nil
+	return err
 }
 
 func (ds *DownloadStream) fillBuffer(ctx context.Context) error {
-	if !ds.cursor.Next(ctx) {
-		ds.done = true
+	if ds.expectedChunk == ds.numChunks {
 		return errNoMoreChunks
 	}
 
+	if ds.cursor == nil {
+		cursor, err := ds.findChunks(ctx, ds.expectedChunk)
+		if err != nil {
+			return err
+		}
+		ds.cursor = cursor
+	}
+
+	if !ds.cursor.Next(ctx) {
+		if err := ds.cursor.Err(); err != nil {
+			return err
+		}
+
+		// the files document says there are more chunks than the chunks collection holds
+		return ErrWrongIndex
+	}
+
 	nextChunk, err := ds.cursor.DecodeBytes()
 	if err != nil {
 		return err
@@ -169,7 +274,7 @@ func (ds *DownloadStream) fillBuffer(ctx context.Context) error {
 		return err
 	}
 
-	if chunkIndex.Value().Int32() != ds.expectedChunk {
+	if n, ok := chunkIndex.Value().Int32OK(); !ok || n != ds.expectedChunk {
 		return ErrWrongIndex
 	}
 
@@ -179,13 +284,16 @@ func (ds *DownloadStream) fillBuffer(ctx context.Context) error {
 		return err
 	}
 
-	_, dataBytes := data.Value().Binary()
+	_, dataBytes, ok := data.Value().BinaryOK()
+	if !ok {
+		return ErrWrongSize
+	}
 
 	bytesLen := int32(len(dataBytes))
 	if ds.expectedChunk == ds.numChunks {
 		// final chunk can be fewer than ds.chunkSize bytes
-		bytesDownloaded := ds.chunkSize * (ds.expectedChunk - 1)
-		bytesRemaining := ds.fileLen - int64(bytesDownloaded)
+		bytesDownloaded := int64(ds.chunkSize) * int64(ds.expectedChunk-1)
+		bytesRemaining := ds.fileLen - bytesDownloaded
 
 		if int64(bytesLen) != bytesRemaining {
 			return ErrWrongSize
@@ -196,6 +304,8 @@ func (ds *DownloadStream) fillBuffer(ctx context.Context) error {
 	}
 
 	copy(ds.buffer, dataBytes)
-	ds.bufferStart = 0
+	ds.bufferStart = ds.chunkOffset
+	ds.bufferEnd = int(bytesLen)
+	ds.chunkOffset = 0
 	return nil
 }
@@ -0,0 +1,248 @@
+package gridfs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/mongo"
+)
+
+// chunkCursor is a mongo.Cursor over an in-memory set of chunk documents.
+type chunkCursor struct {
+	chunks  []*bson.Document
+	idx     int
+	fetched *int // incremented for each chunk returned, if set
+}
+
+// chunkFinder returns a chunkFinder that serves chunks from the chunks held by c.
+func (c *chunkCursor) chunkFinder(fetched *int) chunkFinder {
+	return func(ctx context.Context, fromChunk int32) (mongo.Cursor, error) {
+		return &chunkCursor{chunks: c.chunks[fromChunk:], idx: -1, fetched: fetched}, nil
+	}
+}
+
+func newChunkCursor(data []byte, chunkSize int, indexes ...int32) *chunkCursor {
+	c := &chunkCursor{idx: -1}
+	for i := 0; len(data) > 0; i++ {
+		n := chunkSize
+		if len(data) < n {
+			n = len(data)
+		}
+
+		index := int32(i)
+		if i < len(indexes) {
+			index = indexes[i]
+		}
+
+		c.chunks = append(c.chunks, bson.NewDocument(
+			bson.EC.Int32("n", index),
+			bson.EC.Binary("data", data[:n]),
+		))
+		data = data[n:]
+	}
+
+	return c
+}
+
+func (c *chunkCursor) ID() int64 { return 0 }
+
+func (c *chunkCursor) Next(context.Context) bool {
+	c.idx++
+	if c.idx >= len(c.chunks) {
+		return false
+	}
+
+	if c.fetched != nil {
+		*c.fetched++
+	}
+	return true
+}
+
+func (c *chunkCursor) Decode(v interface{}) error {
+	rdr, err := c.DecodeBytes()
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(rdr, v)
+}
+
+func (c *chunkCursor) DecodeBytes() (bson.Reader, error) {
+	return c.chunks[c.idx].MarshalBSON()
+}
+
+func (c *chunkCursor) Namespace() (string, string) { return "db", "fs.chunks" }
+
+func (c *chunkCursor) NextBatch(context.Context) bool { return false }
+
+func (c *chunkCursor) SetBatchSize(int32) {}
+
+func (c *chunkCursor) Batch() *command.Batch { return command.NewBatch(nil) }
+
+func (c *chunkCursor) Err() error { return nil }
+
+func (c *chunkCursor) Close(context.Context) error { return nil }
+
+func TestDownloadStream(t *testing.T) {
+	data := make([]byte, 2500)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	t.Run("ReadsAllChunks", func(t *testing.T) {
+		ds := newDownloadStream(newChunkCursor(data, 1000), 1000, int64(len(data)), nil)
+
+		got, err := ioutil.ReadAll(ds)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("downloaded bytes do not match uploaded bytes")
+		}
+	})
+
+	t.Run("MissingChunk", func(t *testing.T) {
+		ds := newDownloadStream(newChunkCursor(data[:2000], 1000), 1000, int64(len(data)), nil)
+
+		_, err := ioutil.ReadAll(ds)
+		if err != ErrWrongIndex {
+			t.Fatalf("expected error %v, got %v", ErrWrongIndex, err)
+		}
+	})
+
+	t.Run("OutOfOrderChunk", func(t *testing.T) {
+		ds := newDownloadStream(newChunkCursor(data, 1000, 0, 2, 1), 1000, int64(len(data)), nil)
+
+		_, err := ioutil.ReadAll(ds)
+		if err != ErrWrongIndex {
+			t.Fatalf("expected error %v, got %v", ErrWrongIndex, err)
+		}
+	})
+
+	t.Run("ShortChunk", func(t *testing.T) {
+		ds := newDownloadStream(newChunkCursor(data[:2400], 1000), 1000, int64(len(data)), nil)
+
+		_, err := ioutil.ReadAll(ds)
+		if err != ErrWrongSize {
+			t.Fatalf("expected error %v, got %v", ErrWrongSize, err)
+		}
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		cursor := newChunkCursor(data, 1000)
+		ds := newDownloadStream(cursor, 1000, int64(len(data)), cursor.chunkFinder(nil))
+
+		skipped, err := ds.Skip(1500)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if skipped != 1500 {
+			t.Fatalf("expected to skip 1500 bytes, skipped %d", skipped)
+		}
+
+		got, err := ioutil.ReadAll(ds)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !bytes.Equal(got, data[1500:]) {
+			t.Fatalf("bytes read after skip do not match")
+		}
+	})
+}
+
+func TestDownloadStreamSeek(t *testing.T) {
+	const fileLen = 10 * 1024 * 1024
+	const window = 64 * 1024
+
+	data := make([]byte, fileLen)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+
+	var chunksFetched int
+	findChunks := newChunkCursor(data, int(DefaultChunkSize)).chunkFinder(&chunksFetched)
+
+	t.Run("SeekBackwards", func(t *testing.T) {
+		chunksFetched = 0
+		ds := newDownloadStream(nil, DefaultChunkSize, fileLen, findChunks)
+		buf := make([]byte, window)
+
+		for off := int64(fileLen - window); off >= 0; off -= window {
+			pos, err := ds.Seek(off, io.SeekStart)
+			if err != nil {
+				t.Fatalf("unexpected error seeking to %d: %s", off, err)
+			}
+			if pos != off {
+				t.Fatalf("expected position %d, got %d", off, pos)
+			}
+
+			if _, err = io.ReadFull(ds, buf); err != nil {
+				t.Fatalf("unexpected error reading at %d: %s", off, err)
+			}
+			if !bytes.Equal(buf, data[off:off+window]) {
+				t.Fatalf("bytes read at offset %d do not match", off)
+			}
+		}
+
+		// a window that straddles a chunk boundary fetches both chunks and leaves the later one buffered,
+		// so each chunk is fetched at most three times; nothing is re-read from the start of the file
+		numChunks := (fileLen + int(DefaultChunkSize) - 1) / int(DefaultChunkSize)
+		if chunksFetched > 3*numChunks {
+			t.Fatalf("expected targeted chunk queries, fetched %d chunk documents", chunksFetched)
+		}
+	})
+
+	t.Run("ReadAtBackwards", func(t *testing.T) {
+		ds := newDownloadStream(nil, DefaultChunkSize, fileLen, findChunks)
+		buf := make([]byte, window)
+
+		for off := int64(fileLen - window); off >= 0; off -= window {
+			if _, err := ds.ReadAt(buf, off); err != nil {
+				t.Fatalf("unexpected error reading at %d: %s", off, err)
+			}
+			if !bytes.Equal(buf, data[off:off+window]) {
+				t.Fatalf("bytes read at offset %d do not match", off)
+			}
+		}
+
+		n, err := ds.ReadAt(buf, fileLen-10)
+		if n != 10 || err != io.EOF {
+			t.Fatalf("expected 10 bytes and io.EOF, got %d and %v", n, err)
+		}
+	})
+
+	t.Run("PastEnd", func(t *testing.T) {
+		ds := newDownloadStream(nil, DefaultChunkSize, fileLen, findChunks)
+
+		pos, err := ds.Seek(10, io.SeekEnd)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if pos != fileLen+10 {
+			t.Fatalf("expected position %d, got %d", fileLen+10, pos)
+		}
+
+		if _, err = ds.Read(make([]byte, 1)); err != io.EOF {
+			t.Fatalf("expected io.EOF, got %v", err)
+		}
+
+		if _, err = ds.Seek(-1, io.SeekStart); err != ErrNegativeOffset {
+			t.Fatalf("expected error %v, got %v", ErrNegativeOffset, err)
+		}
+
+		if _, err = ds.Seek(-5, io.SeekEnd); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		got, err := ioutil.ReadAll(ds)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !bytes.Equal(got, data[fileLen-5:]) {
+			t.Fatalf("bytes read at end of file do not match")
+		}
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/mongodb/mongo-go-driver/mongo/aggregateopt"
+	"go.opencensus.io/tag"
+)
+
+// The codes of the errors the server replies when it cannot run $indexStats.
+const (
+	codeUnauthorized              = 13
+	codeCommandNotSupportedOnView = 166
+)
+
+// IndexUsageStats holds the usage statistics of an index on one server, as reported by the
+// $indexStats aggregation stage.
+type IndexUsageStats struct {
+	Name string
+	Key  *bson.Document
+	// Host is the host and port of the server the statistics come from, and Shard the name of its
+	// shard when connected to a mongos, which reports the statistics of every shard.
+	Host  string
+	Shard string
+	// Ops is the number of operations that used the index since Since, the time the server started
+	// counting them, which is when it started or when the index was created.
+	Ops   int64
+	Since time.Time
+}
+
+// IndexStatsError is returned by IndexView.Stats and IndexView.FindUnused when the server refuses
+// to report the usage statistics of the indexes of the collection.
+type IndexStatsError struct {
+	// Reason explains why the statistics are not available.
+	Reason string
+	// Err is the error replied by the server.
+	Err error
+}
+
+func (e IndexStatsError) Error() string {
+	return "mongo: " + e.Reason + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error replied by the server.
+func (e IndexStatsError) Unwrap() error { return e.Err }
+
+// indexStats is the document the $indexStats stage returns for every index.
+type indexStats struct {
+	Name     string         `bson:"name"`
+	Key      *bson.Document `bson:"key"`
+	Host     string         `bson:"host"`
+	Shard    string         `bson:"shard"`
+	Accesses struct {
+		Ops   int64     `bson:"ops"`
+		Since time.Time `bson:"since"`
+	} `bson:"accesses"`
+}
+
+// Stats returns the usage statistics of the indexes of the collection, by running an aggregation
+// with the $indexStats stage. A mongos reports the statistics of every index on every shard. The
+// options are those of Collection.Aggregate.
+//
+// Reading the statistics requires the indexStats privilege on the collection, on every shard when
+// connected to a mongos. An IndexStatsError is returned when the user lacks it and when the
+// collection is a view, which has no indexes of its own.
+func (iv IndexView) Stats(ctx context.Context, opts ...aggregateopt.Aggregate) ([]IndexUsageStats, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "indexview_stats"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(IndexView).Stats")
+	defer span.End()
+
+	pipeline := bson.NewArray(bson.VC.DocumentFromElements(bson.EC.SubDocument("$indexStats", bson.NewDocument())))
+	cur, err := iv.coll.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		err = indexStatsError(err)
+		span.SetStatus(observability.SpanStatus(err))
+		return nil, err
+	}
+	defer func() { _ = cur.Close(ctx) }()
+
+	var stats []IndexUsageStats
+	for cur.Next(ctx) {
+		var s indexStats
+		if err = cur.Decode(&s); err != nil {
+			return nil, err
+		}
+		stats = append(stats, IndexUsageStats{
+			Name:  s.Name,
+			Key:   s.Key,
+			Host:  s.Host,
+			Shard: s.Shard,
+			Ops:   s.Accesses.Ops,
+			Since: s.Accesses.Since,
+		})
+	}
+	if err = cur.Err(); err != nil {
+		return nil, indexStatsError(err)
+	}
+
+	return stats, nil
+}
+
+// FindUnused returns the usage statistics of the indexes of the collection that have not been used
+// since the given time: those whose statistics have been counted since that time or earlier without
+// any operation using them, on every server reporting them. The _id index, which cannot be dropped,
+// is left out. See Stats for the options and errors.
+func (iv IndexView) FindUnused(ctx context.Context, since time.Time, opts ...aggregateopt.Aggregate) ([]IndexUsageStats, error) {
+	stats, err := iv.Stats(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	for _, s := range stats {
+		if s.Ops > 0 || s.Since.After(since) {
+			used[s.Name] = true
+		}
+	}
+
+	var unused []IndexUsageStats
+	for _, s := range stats {
+		if s.Name != "_id_" && !used[s.Name] {
+			unused = append(unused, s)
+		}
+	}
+	return unused, nil
+}
+
+// indexStatsError returns an IndexStatsError explaining err if the server replied it because it
+// cannot run $indexStats on the collection, or else err.
+func indexStatsError(err error) error {
+	var cmdErr command.Error
+	if !errors.As(err, &cmdErr) {
+		return err
+	}
+
+	switch cmdErr.Code {
+	case codeUnauthorized:
+		return IndexStatsError{
+			Reason: "the indexStats privilege on the collection is required to read the usage statistics of its indexes",
+			Err:    err,
+		}
+	case codeCommandNotSupportedOnView:
+		return IndexStatsError{Reason: "a view has no index usage statistics", Err: err}
+	}
+	return err
+}
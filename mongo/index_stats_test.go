@@ -0,0 +1,100 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexViewStats(t *testing.T) {
+	d := mongotest.New(mongotest.WithMongos())
+
+	client := newMockClient(t, d)
+
+	iv := client.Database("db").Collection("coll").Indexes()
+	ctx := context.Background()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats := func(name, shard string, ops int64, since time.Time) *bson.Document {
+		return bson.NewDocument(
+			bson.EC.String("name", name),
+			bson.EC.SubDocumentFromElements("key", bson.EC.Int32(name, 1)),
+			bson.EC.String("host", shard+".example.com:27017"),
+			bson.EC.String("shard", shard),
+			bson.EC.SubDocumentFromElements("accesses",
+				bson.EC.Int64("ops", ops),
+				bson.EC.Time("since", since),
+			),
+		)
+	}
+	d.Handle("aggregate", mongotest.Cursor("db.coll",
+		stats("_id_", "sh0", 0, start),
+		stats("a", "sh0", 0, start),
+		stats("a", "sh1", 0, start),
+		stats("b", "sh0", 0, start),
+		stats("b", "sh1", 3, start),
+		stats("c", "sh0", 0, start.Add(48*time.Hour)),
+	))
+
+	t.Run("stats", func(t *testing.T) {
+		res, err := iv.Stats(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 6)
+		require.Equal(t, "b", res[4].Name)
+		require.Equal(t, int32(1), res[4].Key.Lookup("b").Int32())
+		require.Equal(t, "sh1.example.com:27017", res[4].Host)
+		require.Equal(t, "sh1", res[4].Shard)
+		require.Equal(t, int64(3), res[4].Ops)
+		require.True(t, start.Equal(res[4].Since))
+
+		pipeline := d.LastCommand("aggregate").Document.Lookup("pipeline").MutableArray()
+		stage, err := pipeline.Lookup(0)
+		require.NoError(t, err)
+		require.Equal(t, 0, stage.MutableDocument().Lookup("$indexStats").MutableDocument().Len())
+	})
+	t.Run("find unused", func(t *testing.T) {
+		// b is used on one shard and c has not been counted for long enough
+		res, err := iv.FindUnused(ctx, start.Add(24*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		for _, s := range res {
+			require.Equal(t, "a", s.Name)
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			code     int32
+			codeName string
+		}{
+			{13, "Unauthorized"},
+			{166, "CommandNotSupportedOnView"},
+		} {
+			d.Handle("aggregate", mongotest.Error(tc.code, tc.codeName, "refused"))
+			_, err := iv.Stats(ctx)
+			var statsErr IndexStatsError
+			require.True(t, errors.As(err, &statsErr), "expected an IndexStatsError, got %v", err)
+			var cmdErr command.Error
+			require.True(t, errors.As(err, &cmdErr))
+			require.Equal(t, tc.code, cmdErr.Code)
+
+			_, err = iv.FindUnused(ctx, start)
+			require.True(t, errors.As(err, &statsErr), "expected an IndexStatsError, got %v", err)
+		}
+
+		d.Handle("aggregate", mongotest.Error(8000, "AtlasError", "other"))
+		_, err := iv.Stats(ctx)
+		require.Error(t, err)
+		require.False(t, errors.As(err, new(IndexStatsError)))
+	})
+}
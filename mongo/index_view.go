@@ -11,7 +11,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
+	"strconv"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/command"
@@ -19,7 +19,6 @@ import (
 	"github.com/mongodb/mongo-go-driver/mongo/indexopt"
 
 	"github.com/mongodb/mongo-go-driver/internal/observability"
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 )
@@ -46,13 +45,9 @@ type IndexModel struct {
 
 // List returns a cursor iterating over all the indexes in the collection.
 func (iv IndexView) List(ctx context.Context, opts ...indexopt.List) (Cursor, error) {
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "indexview_list"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(IndexView).List")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "indexview_list"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(IndexView).List")
+	defer span.End()
 
 	listOpts, sess, err := indexopt.BundleList(opts...).Unbundle(true)
 	if err != nil {
@@ -79,9 +74,7 @@ func (iv IndexView) List(ctx context.Context, opts ...indexopt.List) (Cursor, er
 		iv.coll.client.topology.SessionPool,
 	)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "dispatch_listindexes"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: err.Error()})
+		span.SetStatus(observability.SpanStatus(err))
 	}
 
 	return cur, err
@@ -89,32 +82,25 @@ func (iv IndexView) List(ctx context.Context, opts ...indexopt.List) (Cursor, er
 
 // CreateOne creates a single index in the collection specified by the model.
 func (iv IndexView) CreateOne(ctx context.Context, model IndexModel, opts ...indexopt.Create) (string, error) {
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "indexview_create_one"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(IndexView).CreateOne")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "indexview_create_one"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(IndexView).CreateOne")
+	defer span.End()
 
 	names, err := iv.CreateMany(ctx, []IndexModel{model}, opts...)
-	if err != nil {
+	if len(names) == 0 {
 		return "", err
 	}
 
-	return names[0], nil
+	return names[0], err
 }
 
 // CreateMany creates multiple indexes in the collection specified by the models. The names of the
-// creates indexes are returned.
+// creates indexes are returned. If the server reports a write concern failure, the names are
+// returned along with a WriteConcernError.
 func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ...indexopt.Create) ([]string, error) {
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "indexview_create_many"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(IndexView).CreateMany")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "indexview_create_many"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(IndexView).CreateMany")
+	defer span.End()
 
 	names := make([]string, 0, len(models))
 	indexes := bson.NewArray()
@@ -137,8 +123,7 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 		if model.Options != nil {
 			err = index.Concat(model.Options)
 			if err != nil {
-				ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "index_concat"))
-				stats.Record(ctx, observability.MErrors.M(1))
+				observability.RecordError(ctx, "index_concat", err)
 				return nil, err
 			}
 		}
@@ -157,15 +142,21 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 		return nil, err
 	}
 
+	wc := iv.coll.writeConcern
+	if sess != nil && sess.TransactionRunning() {
+		wc = nil
+	}
+
 	cmd := command.CreateIndexes{
-		NS:      iv.coll.namespace(),
-		Indexes: indexes,
-		Opts:    createOpts,
-		Session: sess,
-		Clock:   iv.coll.client.clock,
+		NS:           iv.coll.namespace(),
+		Indexes:      indexes,
+		Opts:         createOpts,
+		WriteConcern: wc,
+		Session:      sess,
+		Clock:        iv.coll.client.clock,
 	}
 
-	_, err = dispatch.CreateIndexes(
+	res, err := dispatch.CreateIndexes(
 		ctx, cmd,
 		iv.coll.client.topology,
 		iv.coll.writeSelector,
@@ -173,39 +164,38 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 		iv.coll.client.topology.SessionPool,
 	)
 	if err != nil {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "dispatch_create_indexes"))
-		stats.Record(ctx, observability.MErrors.M(1))
 		return nil, err
 	}
 
+	if res.WriteConcernError != nil {
+		wce := *convertWriteConcernError(res.WriteConcernError)
+		observability.RecordError(ctx, "write_concern_error", wce)
+		return names, wce
+	}
+
 	return names, nil
 }
 
 // DropOne drops the index with the given name from the collection.
-func (iv IndexView) DropOne(ctx context.Context, name string, opts ...indexopt.Drop) (bson.Reader, error) {
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "indexview_drop_one"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(IndexView).DropOne")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+func (iv IndexView) DropOne(ctx context.Context, name string, opts ...indexopt.Drop) (DropIndexesResult, error) {
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "indexview_drop_one"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(IndexView).DropOne")
+	defer span.End()
 
 	if name == "*" {
-		ctx, _ = tag.New(ctx, tag.Upsert(observability.KeyPart, "indexview_drop_one_namecheck"))
-		stats.Record(ctx, observability.MErrors.M(1))
-		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInternal), Message: "* used to drop multiple indices"})
-		return nil, ErrMultipleIndexDrop
+		observability.RecordError(ctx, "indexview_drop_one_namecheck", ErrMultipleIndexDrop)
+		span.SetStatus(trace.Status{Code: int32(trace.StatusCodeInvalidArgument), Message: "* used to drop multiple indices"})
+		return DropIndexesResult{}, ErrMultipleIndexDrop
 	}
 
 	dropOpts, sess, err := indexopt.BundleDrop(opts...).Unbundle(true)
 	if err != nil {
-		return nil, err
+		return DropIndexesResult{}, err
 	}
 
 	err = iv.coll.client.ValidSession(sess)
 	if err != nil {
-		return nil, err
+		return DropIndexesResult{}, err
 	}
 
 	cmd := command.DropIndexes{
@@ -216,33 +206,30 @@ func (iv IndexView) DropOne(ctx context.Context, name string, opts ...indexopt.D
 		Clock:   iv.coll.client.clock,
 	}
 
-	return dispatch.DropIndexes(
+	res, err := dispatch.DropIndexes(
 		ctx, cmd,
 		iv.coll.client.topology,
 		iv.coll.writeSelector,
 		iv.coll.client.id,
 		iv.coll.client.topology.SessionPool,
 	)
+	return DropIndexesResult{}.fromResult(res), err
 }
 
 // DropAll drops all indexes in the collection.
-func (iv IndexView) DropAll(ctx context.Context, opts ...indexopt.Drop) (bson.Reader, error) {
-	ctx, _ = tag.New(ctx, tag.Insert(observability.KeyMethod, "indexview_drop_all"))
-	ctx, span := trace.StartSpan(ctx, "mongo-go/mongo.(IndexView).DropAll")
-	startTime := time.Now()
-	defer func() {
-		stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(observability.SinceInMilliseconds(startTime)), observability.MCalls.M(1))
-		span.End()
-	}()
+func (iv IndexView) DropAll(ctx context.Context, opts ...indexopt.Drop) (DropIndexesResult, error) {
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "indexview_drop_all"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(IndexView).DropAll")
+	defer span.End()
 
 	dropOpts, sess, err := indexopt.BundleDrop(opts...).Unbundle(true)
 	if err != nil {
-		return nil, err
+		return DropIndexesResult{}, err
 	}
 
 	err = iv.coll.client.ValidSession(sess)
 	if err != nil {
-		return nil, err
+		return DropIndexesResult{}, err
 	}
 
 	cmd := command.DropIndexes{
@@ -253,13 +240,14 @@ func (iv IndexView) DropAll(ctx context.Context, opts ...indexopt.Drop) (bson.Re
 		Clock:   iv.coll.client.clock,
 	}
 
-	return dispatch.DropIndexes(
+	res, err := dispatch.DropIndexes(
 		ctx, cmd,
 		iv.coll.client.topology,
 		iv.coll.writeSelector,
 		iv.coll.client.id,
 		iv.coll.client.topology.SessionPool,
 	)
+	return DropIndexesResult{}.fromResult(res), err
 }
 
 func getOrGenerateIndexName(model IndexModel) (string, error) {
@@ -270,60 +258,57 @@ func getOrGenerateIndexName(model IndexModel) (string, error) {
 		case bson.ErrElementNotFound:
 			break
 		case nil:
-			if nameVal.Type() != bson.TypeString {
+			name, err := nameVal.AsString()
+			if err != nil {
 				return "", ErrNonStringIndexName
 			}
 
-			return nameVal.StringValue(), nil
+			return name, nil
 		default:
 			return "", err
 		}
 	}
 
 	name := bytes.NewBufferString("")
-	itr := model.Keys.Iterator()
 	first := true
 
-	for itr.Next() {
+	err := model.Keys.ForEach(func(key []byte, val bson.Value) error {
 		if !first {
 			_, err := name.WriteRune('_')
 			if err != nil {
-				return "", err
+				return err
 			}
 		}
 
-		elem := itr.Element()
-		_, err := name.WriteString(elem.Key())
+		_, err := name.Write(key)
 		if err != nil {
-			return "", err
+			return err
 		}
 
 		_, err = name.WriteRune('_')
 		if err != nil {
-			return "", err
+			return err
 		}
 
-		var value string
-
-		switch elem.Value().Type() {
-		case bson.TypeInt32:
-			value = fmt.Sprintf("%d", elem.Value().Int32())
-		case bson.TypeInt64:
-			value = fmt.Sprintf("%d", elem.Value().Int64())
-		case bson.TypeString:
-			value = elem.Value().StringValue()
-		default:
-			return "", ErrInvalidIndexValue
+		// directions may be stored as any number, such as the 1.0 the shell creates
+		value, err := val.AsString()
+		if err != nil {
+			n, err := val.AsInt64()
+			if err != nil {
+				return ErrInvalidIndexValue
+			}
+			value = strconv.FormatInt(n, 10)
 		}
 
 		_, err = name.WriteString(value)
 		if err != nil {
-			return "", err
+			return err
 		}
 
 		first = false
-	}
-	if err := itr.Err(); err != nil {
+		return nil
+	})
+	if err != nil {
 		return "", err
 	}
 
@@ -9,6 +9,7 @@ package mongo
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/rand"
 	"sync/atomic"
@@ -16,7 +17,9 @@ import (
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
 	"github.com/mongodb/mongo-go-driver/mongo/indexopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -52,6 +55,40 @@ func getIndexableCollection(t *testing.T) (string, *Collection) {
 	return dbName, db.Collection(dbName)
 }
 
+func TestIndexView_generatedName(t *testing.T) {
+	name, err := getOrGenerateIndexName(IndexModel{
+		Keys: bson.NewDocument(
+			bson.EC.Int32("foo", 1),
+			bson.EC.Int64("bar", -1),
+			bson.EC.String("baz", "text"),
+		),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "foo_1_bar_-1_baz_text", name)
+
+	name, err = getOrGenerateIndexName(IndexModel{
+		Keys: bson.NewDocument(bson.EC.Double("foo", 1)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "foo_1", name)
+
+	_, err = getOrGenerateIndexName(IndexModel{
+		Keys: bson.NewDocument(bson.EC.Double("foo", 1.5)),
+	})
+	require.Equal(t, ErrInvalidIndexValue, err)
+
+	_, err = getOrGenerateIndexName(IndexModel{
+		Keys: bson.NewDocument(bson.EC.Boolean("foo", true)),
+	})
+	require.Equal(t, ErrInvalidIndexValue, err)
+
+	_, err = getOrGenerateIndexName(IndexModel{
+		Keys:    bson.NewDocument(bson.EC.Int32("foo", 1)),
+		Options: bson.NewDocument(bson.EC.Int32("name", 1)),
+	})
+	require.Equal(t, ErrNonStringIndexName, err)
+}
+
 func TestIndexView_List(t *testing.T) {
 	t.Parallel()
 
@@ -562,3 +599,24 @@ func TestIndexView_DropIndexesOptioner(t *testing.T) {
 	}
 	require.NoError(t, cursor.Err())
 }
+
+func TestIndexViewDropResult(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("dropIndexes", mongotest.OK(bson.EC.Int32("nIndexesWas", 3)))
+
+	client := newMockClient(t, d)
+	iv := client.Database("db").Collection("coll").Indexes()
+
+	res, err := iv.DropAll(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(3), res.NIndexesWas)
+	elem, err := res.Raw.Lookup("nIndexesWas")
+	require.NoError(t, err)
+	require.Equal(t, int32(3), elem.Value().Int32())
+
+	d.Handle("dropIndexes", mongotest.Error(27, "IndexNotFound", "index not found with name [x_1]"))
+	_, err = iv.DropOne(context.Background(), "x_1")
+	var cerr command.Error
+	require.True(t, errors.As(err, &cerr), "expected a command error, got %v", err)
+	require.Equal(t, int32(27), cerr.Code)
+}
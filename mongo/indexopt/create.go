@@ -39,11 +39,16 @@ type CreateBundle struct {
 	next   *CreateBundle
 }
 
-// BundleCreate bundles Create options
+// BundleCreate bundles Create options. A *CreateOptions is bundled as the options its fields are
+// set to when it is bundled. New code should build the options with options.CreateIndexes instead.
 func BundleCreate(opts ...Create) *CreateBundle {
 	head := createBundle
 
 	for _, opt := range opts {
+		if o, ok := opt.(*CreateOptions); ok {
+			opt = o.bundle()
+		}
+
 		newBundle := CreateBundle{
 			option: opt,
 			next:   head,
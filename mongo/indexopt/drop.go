@@ -39,11 +39,16 @@ type DropBundle struct {
 	next   *DropBundle
 }
 
-// BundleDrop bundles Drop options
+// BundleDrop bundles Drop options. A *DropOptions is bundled as the options its fields are
+// set to when it is bundled. New code should build the options with options.DropIndexes instead.
 func BundleDrop(opts ...Drop) *DropBundle {
 	head := dropBundle
 
 	for _, opt := range opts {
+		if o, ok := opt.(*DropOptions); ok {
+			opt = o.bundle()
+		}
+
 		newBundle := DropBundle{
 			option: opt,
 			next:   head,
@@ -4,6 +4,9 @@
 // not use this file except in compliance with the License. You may obtain
 // a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
 
+// Package indexopt contains the options of index view operations. The Opt types and bundles of this
+// package are superseded by the index view option structs of the options package, such as
+// options.ListIndexes, and are kept working for existing code.
 package indexopt
 
 import (
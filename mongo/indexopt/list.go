@@ -39,11 +39,16 @@ type ListBundle struct {
 	next   *ListBundle
 }
 
-// BundleList bundles List options
+// BundleList bundles List options. A *ListOptions is bundled as the options its fields are
+// set to when it is bundled. New code should build the options with options.ListIndexes instead.
 func BundleList(opts ...List) *ListBundle {
 	head := listBundle
 
 	for _, opt := range opts {
+		if o, ok := opt.(*ListOptions); ok {
+			opt = o.bundle()
+		}
+
 		newBundle := ListBundle{
 			option: opt,
 			next:   head,
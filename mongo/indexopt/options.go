@@ -0,0 +1,105 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package indexopt
+
+import (
+	"time"
+)
+
+// CreateOptions holds the options of IndexView.CreateOne and IndexView.CreateMany as plain fields,
+// which are set directly or with its Set methods. A *CreateOptions is passed like any other Create
+// option and is converted to the equivalent bundle when the options are bundled.
+type CreateOptions struct {
+	MaxTime *time.Duration
+}
+
+func (*CreateOptions) create() {}
+
+// SetMaxTime sets the MaxTime field. See MaxTime.
+func (co *CreateOptions) SetMaxTime(d time.Duration) *CreateOptions {
+	co.MaxTime = &d
+	return co
+}
+
+// bundle converts the set fields to a bundle.
+func (co *CreateOptions) bundle() *CreateBundle {
+	cb := BundleCreate()
+	if co == nil {
+		return cb
+	}
+
+	if co.MaxTime != nil {
+		cb = cb.MaxTime(*co.MaxTime)
+	}
+	return cb
+}
+
+// DropOptions holds the options of IndexView.DropOne and IndexView.DropAll as plain fields, which
+// are set directly or with its Set methods. A *DropOptions is passed like any other Drop option and
+// is converted to the equivalent bundle when the options are bundled.
+type DropOptions struct {
+	MaxTime *time.Duration
+}
+
+func (*DropOptions) drop() {}
+
+// SetMaxTime sets the MaxTime field. See MaxTime.
+func (do *DropOptions) SetMaxTime(d time.Duration) *DropOptions {
+	do.MaxTime = &d
+	return do
+}
+
+// bundle converts the set fields to a bundle.
+func (do *DropOptions) bundle() *DropBundle {
+	db := BundleDrop()
+	if do == nil {
+		return db
+	}
+
+	if do.MaxTime != nil {
+		db = db.MaxTime(*do.MaxTime)
+	}
+	return db
+}
+
+// ListOptions holds the options of IndexView.List as plain fields, which are set directly or with
+// its Set methods. A *ListOptions is passed like any other List option and is converted to the
+// equivalent bundle when the options are bundled.
+type ListOptions struct {
+	BatchSize *int32
+	MaxTime   *time.Duration
+}
+
+func (*ListOptions) list() {}
+
+// SetBatchSize sets the BatchSize field. See BatchSize.
+func (lo *ListOptions) SetBatchSize(i int32) *ListOptions {
+	lo.BatchSize = &i
+	return lo
+}
+
+// SetMaxTime sets the MaxTime field. See MaxTime.
+func (lo *ListOptions) SetMaxTime(d time.Duration) *ListOptions {
+	lo.MaxTime = &d
+	return lo
+}
+
+// bundle converts the set fields to a bundle, in the order the fields are declared in.
+func (lo *ListOptions) bundle() *ListBundle {
+	lb := BundleList()
+	if lo == nil {
+		return lb
+	}
+
+	if lo.BatchSize != nil {
+		lb = lb.BatchSize(*lo.BatchSize)
+	}
+	if lo.MaxTime != nil {
+		lb = lb.MaxTime(*lo.MaxTime)
+	}
+	return lb
+}
@@ -0,0 +1,320 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/dispatch"
+	"github.com/mongodb/mongo-go-driver/core/option"
+	"github.com/mongodb/mongo-go-driver/core/result"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/writeconcern"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/mongodb/mongo-go-driver/mongo/insertopt"
+	"go.opencensus.io/tag"
+)
+
+// ErrInsertStreamClosed is returned when pushing to or closing an insert stream that has been
+// closed.
+var ErrInsertStreamClosed = errors.New("mongo: the insert stream has been closed")
+
+// The limits of a batch when the server does not report them.
+const (
+	defaultMaxBatchCount   = 100000
+	defaultMaxMessageSize  = 48000000
+	defaultMaxDocumentSize = 16 * 1024 * 1024
+)
+
+// InsertStream inserts documents as they are pushed, in batches of the largest size the server
+// accepts, so that more documents than fit in memory can be inserted. A batch is sent once it
+// holds maxWriteBatchSize documents or maxMessageSizeBytes bytes, while the next batch is
+// accumulated. Only one batch is sent at a time: Push blocks when the next batch is full and the
+// previous one has not been acknowledged yet, so a stream holds at most two batches in memory.
+//
+// The errors of a batch are returned by the first call to Push or Close after the batch is
+// acknowledged, and the indexes of its write errors are those of the documents in the order they
+// were pushed. Push does not accept the document when it returns an error. An ordered stream, which
+// is the default, stops at the first batch with write errors: subsequent calls to Push return the
+// same BulkWriteError and the documents that were not sent are not inserted. An unordered stream
+// keeps inserting, and Close returns the write errors of every batch.
+//
+// Close must be called to send the last batch. An InsertStream is not safe for concurrent use.
+type InsertStream struct {
+	coll    *Collection
+	ctx     context.Context
+	opts    []option.InsertOptioner
+	sess    *session.Client
+	wc      *writeconcern.WriteConcern
+	ordered bool
+
+	maxBatchCount   int
+	maxMessageSize  int
+	maxDocumentSize int
+
+	docs []*bson.Document
+	size int
+	ids  []interface{}
+	sent int // the number of documents sent before docs
+
+	inFlight *insertBatch
+	closed   bool
+
+	err               error // stops the stream
+	pending           error // returned once by Push
+	writeErrors       WriteErrors
+	writeConcernError *WriteConcernError
+	unacknowledged    bool
+}
+
+// insertBatch is a batch of an insert stream being sent.
+type insertBatch struct {
+	offset int
+	done   chan struct{}
+	res    result.Insert
+	err    error
+}
+
+// InsertStream returns a stream inserting the documents pushed to it into the collection. The
+// context is used to send every batch. The options are those of InsertMany and apply to every
+// batch; with a session, the batches are sent in that session.
+func (coll *Collection) InsertStream(ctx context.Context, opts ...insertopt.Many) (*InsertStream, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "insert_stream"))
+
+	if c := coll.client.comment; c != nil {
+		opts = append([]insertopt.Many{insertopt.OptComment(*c)}, opts...)
+	}
+	manyOpts, sess, err := insertopt.BundleMany(opts...).Unbundle(true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = coll.client.ValidSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	wc := coll.writeConcern
+	if sess != nil && sess.TransactionRunning() {
+		wc = nil
+	}
+
+	ss, err := coll.client.topology.SelectServer(ctx, coll.writeSelector)
+	if err != nil {
+		return nil, err
+	}
+	desc := ss.Description()
+
+	s := &InsertStream{
+		coll:            coll,
+		ctx:             ctx,
+		opts:            manyOpts,
+		sess:            sess,
+		wc:              wc,
+		ordered:         true,
+		maxBatchCount:   orDefault(int(desc.MaxBatchCount), defaultMaxBatchCount),
+		maxMessageSize:  orDefault(int(desc.MaxMessageSize), defaultMaxMessageSize),
+		maxDocumentSize: orDefault(int(desc.MaxDocumentSize), defaultMaxDocumentSize),
+	}
+	for _, opt := range manyOpts {
+		if ordered, ok := opt.(option.OptOrdered); ok {
+			s.ordered = bool(ordered)
+		}
+	}
+
+	return s, nil
+}
+
+func orDefault(n, def int) int {
+	if n <= 0 {
+		return def
+	}
+	return n
+}
+
+// Push adds doc to the batch being accumulated, sending the batch first if doc does not fit in
+// it. It returns the errors of the last batch sent if it has been acknowledged since the previous
+// call, and blocks while the previous batch is being sent if the batch being accumulated is full.
+//
+// This method uses TransformDocument to turn doc into a *bson.Document. See TransformDocument for
+// the list of valid types for doc.
+func (s *InsertStream) Push(doc interface{}) error {
+	if s.closed {
+		return ErrInsertStreamClosed
+	}
+
+	s.collect(false)
+	if err := s.takeErr(); err != nil {
+		return err
+	}
+
+	bdoc, err := transformRequiredDocument(s.coll.registry, fmt.Sprintf("document %d", len(s.ids)), doc)
+	if err != nil {
+		return err
+	}
+	insertedID, err := ensureID(bdoc)
+	if err != nil {
+		return err
+	}
+	size, err := bdoc.Validate()
+	if err != nil {
+		return err
+	}
+	if int(size) > s.maxDocumentSize {
+		return command.ErrDocumentTooLarge
+	}
+
+	if len(s.docs) == s.maxBatchCount || len(s.docs) > 0 && s.size+int(size) > s.maxMessageSize {
+		s.collect(true)
+		if err := s.takeErr(); err != nil {
+			return err
+		}
+		s.send()
+	}
+
+	s.docs = append(s.docs, bdoc)
+	s.ids = append(s.ids, insertedID)
+	s.size += int(size)
+	return nil
+}
+
+// Close sends the last batch and waits for every batch to be acknowledged. The result holds the
+// _ids of all the documents accepted by Push, whether they were inserted or not. The error is a
+// BulkWriteError holding the write errors of every batch, if any, or the error that stopped the
+// stream.
+func (s *InsertStream) Close() (InsertManyResult, error) {
+	if s.closed {
+		return InsertManyResult{}, ErrInsertStreamClosed
+	}
+	s.closed = true
+
+	s.collect(true)
+	if s.err == nil && len(s.docs) > 0 {
+		s.send()
+		s.collect(true)
+	}
+
+	res := InsertManyResult{InsertedIDs: s.ids}
+	var err error
+	switch {
+	case s.err != nil:
+		err = s.err
+	case len(s.writeErrors) > 0 || s.writeConcernError != nil:
+		err = BulkWriteError{WriteErrors: s.writeErrors, WriteConcernError: s.writeConcernError}
+	case s.unacknowledged:
+		return res, ErrUnacknowledgedWrite
+	}
+
+	// dispatch.Insert already sets error metrics for the batches that failed
+	switch err.(type) {
+	case nil:
+		observability.Record(s.ctx, observability.MInsertions.M(1))
+	case BulkWriteError:
+		observability.RecordError(s.ctx, "process_write_error", err)
+	}
+
+	return res, err
+}
+
+// send starts sending the batch being accumulated. No other batch may be in flight.
+func (s *InsertStream) send() {
+	oldns := s.coll.namespace()
+	cmd := command.Insert{
+		NS:           command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
+		Docs:         s.docs,
+		Opts:         s.opts,
+		WriteConcern: s.wc,
+		Session:      s.sess,
+		Clock:        s.coll.client.clock,
+	}
+
+	b := &insertBatch{offset: s.sent, done: make(chan struct{})}
+	s.inFlight = b
+	s.sent += len(s.docs)
+	s.docs, s.size = nil, 0
+
+	go func() {
+		defer close(b.done)
+		b.res, b.err = dispatch.Insert(
+			s.ctx, cmd,
+			s.coll.client.topology,
+			s.coll.writeSelector,
+			s.coll.client.id,
+			s.coll.client.topology.SessionPool,
+			s.coll.client.retryWrites,
+		)
+	}()
+}
+
+// collect records the outcome of the batch in flight once it has been acknowledged, waiting for
+// it if wait is set.
+func (s *InsertStream) collect(wait bool) {
+	b := s.inFlight
+	if b == nil {
+		return
+	}
+	if wait {
+		<-b.done
+	} else {
+		select {
+		case <-b.done:
+		default:
+			return
+		}
+	}
+	s.inFlight = nil
+
+	switch b.err {
+	case nil:
+	case command.ErrUnacknowledgedWrite:
+		s.unacknowledged = true
+		return
+	default:
+		s.err = b.err
+		return
+	}
+
+	wes := writeErrorsFromResult(b.res.WriteErrors)
+	for i := range wes {
+		wes[i].Index += b.offset
+	}
+	wce := convertWriteConcernError(b.res.WriteConcernError)
+	if len(wes) == 0 && wce == nil {
+		return
+	}
+
+	s.writeErrors = append(s.writeErrors, wes...)
+	if wce != nil {
+		s.writeConcernError = wce
+	}
+
+	if s.ordered && len(wes) > 0 {
+		s.err = BulkWriteError{WriteErrors: s.writeErrors, WriteConcernError: s.writeConcernError}
+		return
+	}
+	s.pending = BulkWriteError{WriteErrors: wes, WriteConcernError: wce}
+}
+
+// takeErr returns the error that stopped the stream, or else the errors of the last batch that
+// have not been returned yet.
+func (s *InsertStream) takeErr() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	err := s.pending
+	s.pending = nil
+	return err
+}
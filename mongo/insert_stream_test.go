@@ -0,0 +1,171 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/insertopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertStream(t *testing.T) {
+	d := mongotest.New(mongotest.WithMaxWriteBatchSize(2))
+
+	client := newMockClient(t, d)
+
+	coll := client.Database("db").Collection("coll")
+	ctx := context.Background()
+	ok := mongotest.OK(bson.EC.Int32("n", 2))
+	// duplicate fails the second document of a batch
+	duplicate := mongotest.OK(bson.EC.Int32("n", 1), bson.EC.ArrayFromElements("writeErrors",
+		bson.VC.DocumentFromElements(
+			bson.EC.Int32("index", 1),
+			bson.EC.Int32("code", 11000),
+			bson.EC.String("errmsg", "duplicate key"),
+		),
+	))
+	// gate returns a handler answering like h once release is closed.
+	gate := func(release chan struct{}, h mongotest.Handler) mongotest.Handler {
+		return func(cmd *mongotest.Command) mongotest.Response {
+			<-release
+			return h(cmd)
+		}
+	}
+	// batches returns the _ids of the documents of every insert sent since the nth insert.
+	batches := func(n int) [][]int32 {
+		var ids [][]int32
+		for _, c := range d.CommandsNamed("insert")[n:] {
+			var batch []int32
+			docs := c.Document.Lookup("documents").MutableArray()
+			for i := 0; i < docs.Len(); i++ {
+				v, err := docs.Lookup(uint(i))
+				require.NoError(t, err)
+				batch = append(batch, v.MutableDocument().Lookup("_id").Int32())
+			}
+			ids = append(ids, batch)
+		}
+		return ids
+	}
+	push := func(t *testing.T, s *InsertStream, from, to int32) {
+		for i := from; i < to; i++ {
+			require.NoError(t, s.Push(bson.NewDocument(bson.EC.Int32("_id", i))))
+		}
+	}
+
+	t.Run("batches", func(t *testing.T) {
+		d.Handle("insert", ok)
+		before := d.CountCommands("insert")
+
+		s, err := coll.InsertStream(ctx)
+		require.NoError(t, err)
+		push(t, s, 0, 5)
+		res, err := s.Close()
+		require.NoError(t, err)
+		require.Len(t, res.InsertedIDs, 5)
+		for i, id := range res.InsertedIDs {
+			require.Equal(t, int32(i), id.(*bson.Element).Value().Int32())
+		}
+		require.Equal(t, [][]int32{{0, 1}, {2, 3}, {4}}, batches(before))
+
+		_, err = s.Close()
+		require.Equal(t, ErrInsertStreamClosed, err)
+		require.Equal(t, ErrInsertStreamClosed, s.Push(bson.NewDocument()))
+	})
+	t.Run("backpressure", func(t *testing.T) {
+		release := make(chan struct{})
+		d.Handle("insert", gate(release, ok))
+
+		s, err := coll.InsertStream(ctx)
+		require.NoError(t, err)
+		// the first batch is sent with the third document and the second batch fills up
+		push(t, s, 0, 4)
+
+		pushed := make(chan error)
+		go func() { pushed <- s.Push(bson.NewDocument(bson.EC.Int32("_id", 4))) }()
+		select {
+		case err := <-pushed:
+			t.Fatalf("Push returned while the previous batch was in flight: %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+		require.NoError(t, <-pushed)
+		_, err = s.Close()
+		require.NoError(t, err)
+	})
+	t.Run("ordered", func(t *testing.T) {
+		release := make(chan struct{})
+		d.Handle("insert", mongotest.Sequence(ok, gate(release, duplicate), ok))
+		before := d.CountCommands("insert")
+
+		s, err := coll.InsertStream(ctx)
+		require.NoError(t, err)
+		push(t, s, 0, 6)
+		close(release)
+
+		// the seventh document waits for the failed batch and is not accepted
+		err = s.Push(bson.NewDocument(bson.EC.Int32("_id", 6)))
+		bwe, isBulk := err.(BulkWriteError)
+		require.True(t, isBulk, "expected a BulkWriteError, got %v", err)
+		require.Len(t, bwe.WriteErrors, 1)
+		require.Equal(t, 3, bwe.WriteErrors[0].Index)
+		require.Equal(t, 11000, bwe.WriteErrors[0].Code)
+		require.Equal(t, err, s.Push(bson.NewDocument(bson.EC.Int32("_id", 6))))
+
+		res, closeErr := s.Close()
+		require.Equal(t, err, closeErr)
+		require.Len(t, res.InsertedIDs, 6)
+		require.Equal(t, [][]int32{{0, 1}, {2, 3}}, batches(before))
+	})
+	t.Run("unordered", func(t *testing.T) {
+		release := make(chan struct{})
+		d.Handle("insert", mongotest.Sequence(gate(release, duplicate), ok, duplicate))
+		before := d.CountCommands("insert")
+
+		s, err := coll.InsertStream(ctx, insertopt.Ordered(false))
+		require.NoError(t, err)
+		push(t, s, 0, 4)
+		close(release)
+
+		err = s.Push(bson.NewDocument(bson.EC.Int32("_id", 4)))
+		bwe, isBulk := err.(BulkWriteError)
+		require.True(t, isBulk, "expected a BulkWriteError, got %v", err)
+		require.Equal(t, 1, bwe.WriteErrors[0].Index)
+
+		// the stream goes on after reporting the errors
+		push(t, s, 4, 6)
+		_, err = s.Close()
+		bwe, isBulk = err.(BulkWriteError)
+		require.True(t, isBulk, "expected a BulkWriteError, got %v", err)
+		require.Len(t, bwe.WriteErrors, 2)
+		require.Equal(t, 1, bwe.WriteErrors[0].Index)
+		require.Equal(t, 5, bwe.WriteErrors[1].Index)
+		require.Equal(t, [][]int32{{0, 1}, {2, 3}, {4, 5}}, batches(before))
+		require.False(t, batchOrdered(t, d.LastCommand("insert")))
+	})
+	t.Run("command error", func(t *testing.T) {
+		d.Handle("insert", mongotest.Error(13, "Unauthorized", "not authorized"))
+
+		s, err := coll.InsertStream(ctx)
+		require.NoError(t, err)
+		push(t, s, 0, 3)
+		_, err = s.Close()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not authorized")
+	})
+}
+
+func batchOrdered(t *testing.T, cmd *mongotest.Command) bool {
+	v, err := cmd.Document.LookupErr("ordered")
+	require.NoError(t, err)
+	return v.Boolean()
+}
@@ -78,6 +78,16 @@ func BundleOne(opts ...One) *OneBundle {
 	return head
 }
 
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (ob *OneBundle) Comment(comment interface{}) *OneBundle {
+	bundle := &OneBundle{
+		option: Comment(comment),
+		next:   ob,
+	}
+
+	return bundle
+}
+
 // BypassDocumentValidation adds an option allowing the write to opt-out of the document-level validation.
 func (ob *OneBundle) BypassDocumentValidation(b bool) *OneBundle {
 	bundle := &OneBundle{
@@ -251,6 +261,16 @@ func (mb *ManyBundle) insertMany() {}
 // ConvertInsertOption implements the Many interface
 func (mb *ManyBundle) ConvertInsertOption() option.InsertOptioner { return nil }
 
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (mb *ManyBundle) Comment(comment interface{}) *ManyBundle {
+	bundle := &ManyBundle{
+		option: Comment(comment),
+		next:   mb,
+	}
+
+	return bundle
+}
+
 // BypassDocumentValidation adds an option allowing the write to opt-out of the document-level validation.
 func (mb *ManyBundle) BypassDocumentValidation(b bool) *ManyBundle {
 	bundle := &ManyBundle{
@@ -411,6 +431,12 @@ func BypassDocumentValidation(b bool) OptBypassDocumentValidation {
 	return OptBypassDocumentValidation(b)
 }
 
+// Comment specifies a value of any BSON type to help trace the operation through the database
+// profiler, currentOp, and logs. It requires server version 4.4 or newer.
+func Comment(comment interface{}) OptComment {
+	return OptComment{Comment: comment}
+}
+
 // Ordered if true and insert fails, returns without performing remaining writes, otherwise continues
 func Ordered(b bool) OptOrdered {
 	return OptOrdered(b)
@@ -431,6 +457,18 @@ func (opt OptBypassDocumentValidation) ConvertInsertOption() option.InsertOption
 	return option.OptBypassDocumentValidation(opt)
 }
 
+// OptComment specifies a value to help trace the operation through the database profiler, currentOp, and logs.
+type OptComment option.OptComment
+
+func (OptComment) insertMany() {}
+
+func (OptComment) insertOne() {}
+
+// ConvertInsertOption implements the One,Many interface
+func (opt OptComment) ConvertInsertOption() option.InsertOptioner {
+	return option.OptComment(opt)
+}
+
 func (OptOrdered) insertMany() {}
 
 // ConvertInsertOption implements the Many interface
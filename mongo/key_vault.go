@@ -0,0 +1,199 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/dispatch"
+	"github.com/mongodb/mongo-go-driver/internal/crypt"
+	"github.com/mongodb/mongo-go-driver/mongo/encryptopt"
+)
+
+// rewrapBatchSize is the number of data keys RewrapManyDataKey rewraps before writing them back to
+// the key vault in a single update command.
+const rewrapBatchSize = 100
+
+// GetKey returns the data key with the given id from the key vault.
+func (ce *ClientEncryption) GetKey(ctx context.Context, id bson.Binary) *DocumentResult {
+	return ce.keyVault.FindOne(ctx, keyIDFilter(id))
+}
+
+// GetKeys returns a cursor over all the data keys of the key vault.
+func (ce *ClientEncryption) GetKeys(ctx context.Context) (Cursor, error) {
+	return ce.keyVault.Find(ctx, bson.NewDocument())
+}
+
+// DeleteKey deletes the data key with the given id from the key vault. Values encrypted with the key
+// can no longer be decrypted.
+func (ce *ClientEncryption) DeleteKey(ctx context.Context, id bson.Binary) (*DeleteResult, error) {
+	return ce.keyVault.DeleteOne(ctx, keyIDFilter(id))
+}
+
+// AddKeyAltName adds an alternate name to the data key with the given id and returns the data key
+// as it was before the name was added.
+func (ce *ClientEncryption) AddKeyAltName(ctx context.Context, id bson.Binary, name string) *DocumentResult {
+	update := bson.NewDocument(bson.EC.SubDocumentFromElements("$addToSet", bson.EC.String("keyAltNames", name)))
+	return ce.keyVault.FindOneAndUpdate(ctx, keyIDFilter(id), update)
+}
+
+// RemoveKeyAltName removes an alternate name from the data key with the given id and returns the data
+// key as it was before the name was removed. The keyAltNames field is removed along with the last
+// alternate name of the key.
+func (ce *ClientEncryption) RemoveKeyAltName(ctx context.Context, id bson.Binary, name string) *DocumentResult {
+	update := bson.NewDocument(bson.EC.SubDocumentFromElements("$pull", bson.EC.String("keyAltNames", name)))
+	res := ce.keyVault.FindOneAndUpdate(ctx, keyIDFilter(id), update)
+
+	rdr, err := res.DecodeBytes()
+	if err != nil {
+		return res
+	}
+	elem, err := rdr.Lookup("keyAltNames")
+	if err != nil {
+		return res
+	}
+	names, ok := elem.Value().ReaderArrayOK()
+	if !ok {
+		return res
+	}
+	if n, err := names.Keys(false); err != nil || len(n) != 1 {
+		return res
+	}
+
+	filter := keyIDFilter(id)
+	filter.Append(bson.EC.SubDocumentFromElements("keyAltNames", bson.EC.Int32("$size", 0)))
+	unset := bson.NewDocument(bson.EC.SubDocumentFromElements("$unset", bson.EC.String("keyAltNames", "")))
+	if _, err = ce.keyVault.UpdateOne(ctx, filter, unset); err != nil {
+		return &DocumentResult{err: err}
+	}
+	return res
+}
+
+// RewrapManyDataKey decrypts the key material of the data keys matching filter and encrypts it again
+// with a master key, then writes it back to the key vault along with the new master key, in batches.
+// The master key is set with the encryptopt.Provider and encryptopt.MasterKey options. If no provider
+// is set, each key is encrypted again with its current master key, which rotates the keys of a KMS
+// that versions its master keys such as AWS.
+func (ce *ClientEncryption) RewrapManyDataKey(ctx context.Context, filter interface{},
+	opts ...encryptopt.RewrapManyDataKey) (*RewrapManyDataKeyResult, error) {
+
+	ro, err := encryptopt.BundleRewrapManyDataKey(opts...).Unbundle()
+	if err != nil {
+		return nil, err
+	}
+
+	var kms crypt.KMSProvider
+	var masterKey *bson.Document
+	switch {
+	case ro.Provider != "":
+		var ok bool
+		if kms, ok = ce.kms[ro.Provider]; !ok {
+			return nil, fmt.Errorf("KMS provider %q is not configured", ro.Provider)
+		}
+		if masterKey, err = masterKeyDocument(ro.Provider, ro.MasterKey); err != nil {
+			return nil, err
+		}
+	case ro.MasterKey != nil:
+		return nil, errors.New("a master key cannot be set without a KMS provider")
+	}
+
+	if filter == nil {
+		filter = bson.NewDocument()
+	}
+	cur, err := ce.keyVault.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cur.Close(ctx) }()
+
+	res := &RewrapManyDataKeyResult{}
+	batch := make([]*bson.Document, 0, rewrapBatchSize)
+	for cur.Next(ctx) {
+		rdr, err := cur.DecodeBytes()
+		if err != nil {
+			return res, err
+		}
+		id, dek, oldMasterKey, err := ce.unwrapKey(ctx, rdr)
+		if err != nil {
+			return res, err
+		}
+
+		keyKMS, keyMasterKey := kms, masterKey
+		if keyKMS == nil {
+			// unwrapKey has checked that the provider is configured
+			provider, _ := oldMasterKey.Lookup("provider").AsString()
+			keyKMS, keyMasterKey = ce.kms[provider], oldMasterKey
+		}
+		keyMaterial, err := keyKMS.WrapKey(ctx, keyMasterKey, dek)
+		if err != nil {
+			return res, err
+		}
+
+		batch = append(batch, bson.NewDocument(
+			bson.EC.SubDocument("q", keyIDFilter(bson.Binary{Subtype: uuidSubtype, Data: id})),
+			bson.EC.SubDocumentFromElements("u",
+				bson.EC.SubDocumentFromElements("$set",
+					bson.EC.BinaryWithSubtype("keyMaterial", keyMaterial, 0),
+					bson.EC.SubDocument("masterKey", keyMasterKey),
+				),
+				bson.EC.SubDocumentFromElements("$currentDate", bson.EC.Boolean("updateDate", true)),
+			),
+			bson.EC.Boolean("multi", false),
+		))
+		if len(batch) == rewrapBatchSize {
+			n, err := ce.updateKeys(ctx, batch)
+			res.RewrappedCount += n
+			if err != nil {
+				return res, err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err = cur.Err(); err != nil {
+		return res, err
+	}
+
+	if len(batch) > 0 {
+		n, err := ce.updateKeys(ctx, batch)
+		res.RewrappedCount += n
+		if err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// updateKeys runs the update statements docs against the key vault in a single update command and
+// returns the number of data keys they modified.
+func (ce *ClientEncryption) updateKeys(ctx context.Context, docs []*bson.Document) (int64, error) {
+	coll := ce.keyVault
+	cmd := command.Update{
+		NS:           coll.namespace(),
+		Docs:         docs,
+		WriteConcern: coll.writeConcern,
+		Clock:        coll.client.clock,
+	}
+
+	r, err := dispatch.Update(
+		ctx, cmd,
+		coll.client.topology,
+		coll.writeSelector,
+		coll.client.id,
+		coll.client.topology.SessionPool,
+		coll.client.retryWrites,
+	)
+	if err != nil && err != command.ErrUnacknowledgedWrite {
+		return 0, err
+	}
+
+	_, err = processWriteError(r.WriteConcernError, r.WriteErrors, err)
+	return r.ModifiedCount, err
+}
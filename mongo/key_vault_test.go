@@ -0,0 +1,194 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/encryptopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func (kv *keyVault) delete(cmd *mongotest.Command) mongotest.Response {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	val, _ := cmd.Document.Lookup("deletes").MutableArray().Lookup(0)
+	var n int32
+	for _, key := range kv.match(val.MutableDocument().Lookup("q").MutableDocument()) {
+		for i := range kv.keys {
+			if kv.keys[i] == key {
+				kv.keys = append(kv.keys[:i], kv.keys[i+1:]...)
+				n++
+				break
+			}
+		}
+	}
+	return mongotest.Response{Document: bson.NewDocument(bson.EC.Int32("n", n), bson.EC.Double("ok", 1))}
+}
+
+// findAndModify supports the $addToSet and $pull updates of keyAltNames.
+func (kv *keyVault) findAndModify(cmd *mongotest.Command) mongotest.Response {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	found := kv.match(cmd.Document.Lookup("query").MutableDocument())
+	if len(found) == 0 {
+		return mongotest.OK(bson.EC.Null("value"))(cmd)
+	}
+	key := found[0]
+	before := key.Copy()
+
+	update := cmd.Document.Lookup("update").MutableDocument()
+	names := keyAltNames(key)
+	if val, err := update.LookupErr("$addToSet", "keyAltNames"); err == nil {
+		names = append(names, val.StringValue())
+	}
+	if val, err := update.LookupErr("$pull", "keyAltNames"); err == nil {
+		kept := names[:0]
+		for _, name := range names {
+			if name != val.StringValue() {
+				kept = append(kept, name)
+			}
+		}
+		names = kept
+	}
+	arr := bson.NewArray()
+	for _, name := range names {
+		arr.Append(bson.VC.String(name))
+	}
+	key.Set(bson.EC.Array("keyAltNames", arr))
+
+	return mongotest.OK(bson.EC.SubDocument("value", before))(cmd)
+}
+
+// update supports the $set, $currentDate and $unset updates of the key vault helpers.
+func (kv *keyVault) update(cmd *mongotest.Command) mongotest.Response {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	var n int32
+	itr, _ := cmd.Document.Lookup("updates").MutableArray().Iterator()
+	for itr.Next() {
+		stmt := itr.Value().MutableDocument()
+		filter := stmt.Lookup("q").MutableDocument()
+		update := stmt.Lookup("u").MutableDocument()
+		for _, key := range kv.match(filter) {
+			if _, err := filter.LookupErr("keyAltNames", "$size"); err == nil && len(keyAltNames(key)) > 0 {
+				continue
+			}
+			if set, err := update.LookupErr("$set"); err == nil {
+				elems := set.MutableDocument().Iterator()
+				for elems.Next() {
+					key.Set(elems.Element())
+				}
+			}
+			if _, err := update.LookupErr("$unset", "keyAltNames"); err == nil {
+				key.Delete("keyAltNames")
+			}
+			n++
+		}
+	}
+	return mongotest.Response{Document: bson.NewDocument(
+		bson.EC.Int32("n", n), bson.EC.Int32("nModified", n), bson.EC.Double("ok", 1),
+	)}
+}
+
+func TestClientEncryptionKeyVault(t *testing.T) {
+	d := mongotest.New()
+	kv := &keyVault{}
+	d.Handle("insert", kv.insert)
+	d.Handle("find", kv.find)
+	d.Handle("delete", kv.delete)
+	d.Handle("findAndModify", kv.findAndModify)
+	d.Handle("update", kv.update)
+
+	client := newMockClient(t, d)
+
+	ce, err := NewClientEncryption(client, "encryption.__keyVault", map[string]map[string]interface{}{
+		"local": {"key": bytes.Repeat([]byte{1}, 96)},
+	})
+	require.NoError(t, err)
+
+	var ids []bson.Binary
+	for i := 0; i < 3; i++ {
+		id, err := ce.CreateDataKey(context.Background(), "local")
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	encrypted, err := ce.Encrypt(context.Background(), "secret",
+		encryptopt.Algorithm(encryptopt.AEADRandom), encryptopt.KeyID(ids[0]))
+	require.NoError(t, err)
+
+	t.Run("get", func(t *testing.T) {
+		rdr, err := ce.GetKey(context.Background(), ids[1]).DecodeBytes()
+		require.NoError(t, err)
+		elem, err := rdr.Lookup("_id")
+		require.NoError(t, err)
+		_, id := elem.Value().Binary()
+		require.Equal(t, ids[1].Data, id)
+
+		cur, err := ce.GetKeys(context.Background())
+		require.NoError(t, err)
+		var n int
+		for cur.Next(context.Background()) {
+			n++
+		}
+		require.NoError(t, cur.Err())
+		require.Equal(t, 3, n)
+	})
+	t.Run("key alt names", func(t *testing.T) {
+		res := ce.AddKeyAltName(context.Background(), ids[0], "pii")
+		require.NoError(t, res.Err())
+		_, err := ce.Encrypt(context.Background(), "x", encryptopt.Algorithm(encryptopt.AEADRandom), encryptopt.KeyAltName("pii"))
+		require.NoError(t, err)
+
+		rdr, err := ce.RemoveKeyAltName(context.Background(), ids[0], "pii").DecodeBytes()
+		require.NoError(t, err)
+		_, err = rdr.Lookup("keyAltNames")
+		require.NoError(t, err, "the key is returned as it was before the name was removed")
+		_, err = kv.keys[0].LookupErr("keyAltNames")
+		require.Equal(t, bson.ErrElementNotFound, err)
+
+		_, err = ce.Encrypt(context.Background(), "x", encryptopt.Algorithm(encryptopt.AEADRandom), encryptopt.KeyAltName("pii"))
+		require.Equal(t, ErrDataKeyNotFound, err)
+	})
+	t.Run("rewrap", func(t *testing.T) {
+		_, before := kv.keys[0].Lookup("keyMaterial").Binary()
+		res, err := ce.RewrapManyDataKey(context.Background(), nil, encryptopt.Provider("local"))
+		require.NoError(t, err)
+		require.Equal(t, int64(3), res.RewrappedCount)
+		_, after := kv.keys[0].Lookup("keyMaterial").Binary()
+		require.NotEqual(t, before, after)
+
+		decrypted, err := ce.Decrypt(context.Background(), encrypted)
+		require.NoError(t, err)
+		require.Equal(t, "secret", decrypted.StringValue())
+
+		update := d.LastCommand("update").Document
+		require.Equal(t, 3, update.Lookup("updates").MutableArray().Len(), "the keys are written back in a single batch")
+		require.Equal(t, "majority", update.Lookup("writeConcern", "w").StringValue())
+
+		_, err = ce.RewrapManyDataKey(context.Background(), nil, encryptopt.MasterKey(bson.NewDocument()))
+		require.Error(t, err)
+		_, err = ce.RewrapManyDataKey(context.Background(), nil, encryptopt.Provider("aws"))
+		require.Error(t, err)
+	})
+	t.Run("delete", func(t *testing.T) {
+		res, err := ce.DeleteKey(context.Background(), ids[0])
+		require.NoError(t, err)
+		require.Equal(t, int64(1), res.DeletedCount)
+
+		_, err = ce.Decrypt(context.Background(), encrypted)
+		require.Equal(t, ErrDataKeyNotFound, err)
+		require.Equal(t, ErrNoDocuments, ce.GetKey(context.Background(), ids[0]).Err())
+	})
+}
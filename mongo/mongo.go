@@ -17,6 +17,7 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/mongodb/mongo-go-driver/internal"
 	"github.com/mongodb/mongo-go-driver/mongo/countopt"
 )
 
@@ -25,23 +26,71 @@ type Dialer interface {
 	DialContext(ctx context.Context, network, address string) (net.Conn, error)
 }
 
+// ErrNilDocument is returned when a nil document is passed as an argument that must be a
+// document, such as an update or a replacement.
+var ErrNilDocument = errors.New("document is nil")
+
 // TransformDocument handles transforming a document of an allowable type into
 // a *bson.Document. This method is called directly after most methods that
 // have one or more parameters that are documents.
 //
 // The supported types for document are:
 //
+//  *bson.Document
 //  bson.Marshaler
 //  bson.DocumentMarshaler
 //  bson.Reader
 //  []byte (must be a valid BSON document)
 //  io.Reader (only 1 BSON document will be read)
+//  A map with string keys
 //  A custom struct type
 //
+// A nil document, including a nil pointer, map or slice, is transformed into an empty document.
 func TransformDocument(document interface{}) (*bson.Document, error) {
-	switch d := document.(type) {
-	case nil:
+	if isNil(document) {
+		return bson.NewDocument(), nil
+	}
+	return convertDocument(nil, document)
+}
+
+// transformDocument is like TransformDocument, but encodes structs and maps using registry if it is
+// not nil, and names the argument arg that failed to convert in its errors, e.g. "filter".
+func transformDocument(registry *bson.Registry, arg string, document interface{}) (*bson.Document, error) {
+	if isNil(document) {
 		return bson.NewDocument(), nil
+	}
+
+	doc, err := convertDocument(registry, document)
+	if err != nil {
+		return nil, internal.WrapErrorf(err, "cannot convert %s", arg)
+	}
+	return doc, nil
+}
+
+// transformRequiredDocument is like transformDocument, but returns ErrNilDocument for a nil document
+// instead of an empty document, for arguments such as updates and replacements.
+func transformRequiredDocument(registry *bson.Registry, arg string, document interface{}) (*bson.Document, error) {
+	if isNil(document) {
+		return nil, internal.WrapErrorf(ErrNilDocument, "cannot convert %s", arg)
+	}
+	return transformDocument(registry, arg, document)
+}
+
+// isNil reports whether document is nil or a nil pointer, map or slice.
+func isNil(document interface{}) bool {
+	if document == nil {
+		return true
+	}
+
+	switch v := reflect.ValueOf(document); v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+func convertDocument(registry *bson.Registry, document interface{}) (*bson.Document, error) {
+	switch d := document.(type) {
 	case *bson.Document:
 		return d, nil
 	case bson.Marshaler, bson.Reader, []byte, io.Reader:
@@ -54,17 +103,24 @@ func TransformDocument(document interface{}) (*bson.Document, error) {
 			kind = t.Elem().Kind()
 		}
 		if reflect.ValueOf(document).Kind() == reflect.Struct || kind == reflect.Struct {
-			return bson.NewDocumentEncoder().EncodeDocument(document)
+			return encodeDocument(registry, document)
 		}
 		if reflect.ValueOf(document).Kind() == reflect.Map &&
 			reflect.TypeOf(document).Key().Kind() == reflect.String {
-			return bson.NewDocumentEncoder().EncodeDocument(document)
+			return encodeDocument(registry, document)
 		}
 
 		return nil, fmt.Errorf("cannot transform type %s to a *bson.Document", reflect.TypeOf(document))
 	}
 }
 
+func encodeDocument(registry *bson.Registry, document interface{}) (*bson.Document, error) {
+	if registry == nil {
+		return bson.NewDocumentEncoder().EncodeDocument(document)
+	}
+	return bson.MarshalDocumentWithRegistry(registry, document)
+}
+
 func ensureID(d *bson.Document) (interface{}, error) {
 	var id interface{}
 
@@ -89,44 +145,58 @@ func ensureDollarKey(doc *bson.Document) error {
 	return nil
 }
 
-func transformAggregatePipeline(pipeline interface{}) (*bson.Array, error) {
-	var pipelineArr *bson.Array
+// transformAggregatePipeline converts pipeline into an array of stages, preserving their order. The
+// pipeline can be:
+//
+//  *bson.Array
+//  A slice or array of stages of any type supported by TransformDocument, including named slice types
+//  A raw BSON array, or a document whose values are the stages in order
+//  A single stage document, such as {"$match": ...}, which is deprecated
+//
+// Errors name the pipeline stage that failed to convert.
+func transformAggregatePipeline(registry *bson.Registry, pipeline interface{}) (*bson.Array, error) {
 	switch t := pipeline.(type) {
 	case *bson.Array:
-		pipelineArr = t
-	case []*bson.Document:
-		pipelineArr = bson.NewArray()
-
-		for _, doc := range t {
-			pipelineArr.Append(bson.VC.Document(doc))
-		}
-	case []interface{}:
-		pipelineArr = bson.NewArray()
-
-		for _, val := range t {
-			doc, err := TransformDocument(val)
-			if err != nil {
-				return nil, err
-			}
-
-			pipelineArr.Append(bson.VC.Document(doc))
-		}
+		return t, nil
+	case bson.Reader, []byte:
 	default:
-		p, err := TransformDocument(pipeline)
-		if err != nil {
-			return nil, err
+		if v := reflect.ValueOf(pipeline); v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			pipelineArr := bson.NewArray()
+			for i := 0; i < v.Len(); i++ {
+				doc, err := transformRequiredDocument(registry, fmt.Sprintf("pipeline stage %d", i), v.Index(i).Interface())
+				if err != nil {
+					return nil, err
+				}
+
+				pipelineArr.Append(bson.VC.Document(doc))
+			}
+			return pipelineArr, nil
 		}
+	}
+
+	p, err := transformDocument(registry, "pipeline", pipeline)
+	if err != nil {
+		return nil, err
+	}
 
-		pipelineArr = bson.ArrayFromDocument(p)
+	// stages start with an operator, whereas the keys of arrays are indexes
+	if elem, ok := p.ElementAtOK(0); ok && strings.HasPrefix(elem.Key(), "$") {
+		return bson.NewArray(bson.VC.Document(p)), nil
 	}
 
-	return pipelineArr, nil
+	for i := 0; i < p.Len(); i++ {
+		elem, _ := p.ElementAtOK(uint(i))
+		if typ := elem.Value().Type(); typ != bson.TypeEmbeddedDocument {
+			return nil, fmt.Errorf("cannot convert pipeline stage %d: a stage of type %s is not a document", i, typ)
+		}
+	}
+	return bson.ArrayFromDocument(p), nil
 }
 
 // Build the aggregation pipeline for the CountDocument command.
-func countDocumentsAggregatePipeline(filter interface{}, opts ...countopt.Count) (*bson.Array, error) {
+func countDocumentsAggregatePipeline(registry *bson.Registry, filter interface{}, opts ...countopt.Count) (*bson.Array, error) {
 	pipeline := bson.NewArray()
-	filterDoc, err := TransformDocument(filter)
+	filterDoc, err := transformDocument(registry, "filter", filter)
 
 	if err != nil {
 		return nil, err
@@ -139,6 +209,9 @@ func countDocumentsAggregatePipeline(filter interface{}, opts ...countopt.Count)
 			pipeline.Append(bson.VC.Document(bson.NewDocument(bson.EC.Int64("$skip", skip))))
 		case countopt.OptLimit:
 			limit := int64(t)
+			if limit < 0 {
+				limit = -limit
+			}
 			pipeline.Append(bson.VC.Document(bson.NewDocument(bson.EC.Int64("$limit", limit))))
 		}
 	}
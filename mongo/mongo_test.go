@@ -7,8 +7,10 @@
 package mongo
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -68,6 +70,114 @@ func TestTransformDocument(t *testing.T) {
 	}
 }
 
+func TestTransformArguments(t *testing.T) {
+	want := bson.NewDocument(bson.EC.String("foo", "bar"))
+	raw, err := want.MarshalBSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("kinds", func(t *testing.T) {
+		for _, document := range []interface{}{
+			want,
+			bson.Reader(raw),
+			raw,
+			map[string]interface{}{"foo": "bar"},
+			map[string]string{"foo": "bar"},
+			reflectStruct{Foo: "bar"},
+		} {
+			got, err := transformDocument(nil, "filter", document)
+			if err != nil {
+				t.Errorf("Unexpected error for %T: %v", document, err)
+				continue
+			}
+			if !got.Equal(want) {
+				t.Errorf("Documents differ for %T. got %v; want %v", document, got, want)
+			}
+		}
+	})
+	t.Run("nil", func(t *testing.T) {
+		for _, document := range []interface{}{nil, (*reflectStruct)(nil), map[string]interface{}(nil), bson.Reader(nil)} {
+			got, err := transformDocument(nil, "filter", document)
+			if err != nil || got.Len() != 0 {
+				t.Errorf("Expected an empty filter for %T, got %v, %v", document, got, err)
+			}
+
+			_, err = transformRequiredDocument(nil, "update", document)
+			if !errors.Is(err, ErrNilDocument) || !strings.Contains(err.Error(), "update") {
+				t.Errorf("Expected ErrNilDocument naming the update for %T, got %v", document, err)
+			}
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := transformDocument(nil, "filter", raw[:len(raw)-2])
+		if err == nil || !strings.HasPrefix(err.Error(), "cannot convert filter: ") {
+			t.Errorf("Expected an error naming the filter, got %v", err)
+		}
+		_, err = transformDocument(nil, "filter", map[int]string{1: "a"})
+		if err == nil || !strings.HasPrefix(err.Error(), "cannot convert filter: ") {
+			t.Errorf("Expected an error naming the filter, got %v", err)
+		}
+	})
+	t.Run("pipeline", func(t *testing.T) {
+		stages := []interface{}{want, map[string]interface{}{"foo": "bar"}, raw}
+		pipeline, err := transformAggregatePipeline(nil, stages)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pipeline.Len() != 3 {
+			t.Errorf("Expected 3 stages, got %d", pipeline.Len())
+		}
+
+		pipeline, err = transformAggregatePipeline(nil, []map[string]interface{}{{"foo": "bar"}})
+		if err != nil || pipeline.Len() != 1 {
+			t.Errorf("Expected 1 stage, got %v, %v", pipeline, err)
+		}
+
+		_, err = transformAggregatePipeline(nil, []interface{}{want, want, 42})
+		if err == nil || !strings.HasPrefix(err.Error(), "cannot convert pipeline stage 2: ") {
+			t.Errorf("Expected an error naming pipeline stage 2, got %v", err)
+		}
+		_, err = transformAggregatePipeline(nil, []*bson.Document{want, nil})
+		if !errors.Is(err, ErrNilDocument) {
+			t.Errorf("Expected ErrNilDocument, got %v", err)
+		}
+
+		type namedPipeline []map[string]interface{}
+		pipeline, err = transformAggregatePipeline(nil, namedPipeline{{"$match": "a"}, {"$limit": 1}})
+		if err != nil || pipeline.Len() != 2 {
+			t.Errorf("Expected 2 stages, got %v, %v", pipeline, err)
+		}
+
+		match := bson.NewDocument(bson.EC.SubDocumentFromElements("$match", bson.EC.Int32("x", 1)))
+		limit := bson.NewDocument(bson.EC.Int32("$limit", 1))
+		raw, err := bson.NewArray(bson.VC.Document(match), bson.VC.Document(limit)).MarshalBSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		pipeline, err = transformAggregatePipeline(nil, raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pipeline.Len() != 2 {
+			t.Fatalf("Expected 2 stages, got %d", pipeline.Len())
+		}
+		if stage, _ := pipeline.Lookup(1); !stage.MutableDocument().Equal(limit) {
+			t.Errorf("Expected the $limit stage second, got %v", stage)
+		}
+
+		pipeline, err = transformAggregatePipeline(nil, match)
+		if err != nil || pipeline.Len() != 1 {
+			t.Errorf("Expected a single stage, got %v, %v", pipeline, err)
+		}
+
+		_, err = transformAggregatePipeline(nil, bson.NewDocument(bson.EC.SubDocument("0", match), bson.EC.Int32("1", 1)))
+		if err == nil || !strings.HasPrefix(err.Error(), "cannot convert pipeline stage 1: ") {
+			t.Errorf("Expected an error naming pipeline stage 1, got %v", err)
+		}
+	})
+}
+
 func compareErrors(err1, err2 error) bool {
 	if err1 == nil && err2 == nil {
 		return true
@@ -70,4 +70,28 @@ const (
 	// UpdateLookup includes a delta describing the changes to the document and a copy of the entire document that
 	// was changed
 	UpdateLookup FullDocument = "updateLookup"
+	// Off does not include a copy of the document before the change. It is only used with
+	// FullDocumentBeforeChange.
+	Off FullDocument = "off"
+	// WhenAvailable includes a copy of the document before or after the change if the server has
+	// recorded one. The pre-image requires the collection to record pre-images and the post-image
+	// requires MongoDB 6.0 or later.
+	WhenAvailable FullDocument = "whenAvailable"
+	// Required includes a copy of the document before or after the change and fails the change
+	// stream if the server has not recorded one.
+	Required FullDocument = "required"
+)
+
+// ExplainVerbosity specifies how much information an explain operation returns about the execution
+// of a command.
+type ExplainVerbosity string
+
+const (
+	// QueryPlanner returns the plan selected by the query optimizer.
+	QueryPlanner ExplainVerbosity = "queryPlanner"
+	// ExecutionStats additionally runs the selected plan and returns its execution statistics.
+	ExecutionStats ExplainVerbosity = "executionStats"
+	// AllPlansExecution additionally returns the execution statistics of the plans considered
+	// while selecting the winning plan.
+	AllPlansExecution ExplainVerbosity = "allPlansExecution"
 )
@@ -0,0 +1,138 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongotest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+)
+
+// Command is a command received by a deployment.
+type Command struct {
+	// Name is the name of the command, i.e. its first key.
+	Name string
+	// Database is the database the command was sent to.
+	Database string
+	// Document is the command as sent by the driver. The documents the driver sends as OP_MSG
+	// document sequences, such as the documents of an insert, are added to it as arrays.
+	Document *bson.Document
+	// OpCode is the opcode of the wire message that carried the command.
+	OpCode wiremessage.OpCode
+}
+
+func newCommand(db string, doc *bson.Document, opCode wiremessage.OpCode) *Command {
+	cmd := &Command{
+		Database: db,
+		Document: doc,
+		OpCode:   opCode,
+	}
+	if elem, ok := doc.ElementAtOK(0); ok {
+		cmd.Name = elem.Key()
+	}
+
+	return cmd
+}
+
+// Matcher reports whether a handler answers a command.
+type Matcher func(*Command) bool
+
+// Handler returns the response of a deployment to a command. Handlers may be called concurrently.
+type Handler func(*Command) Response
+
+// Response is how a deployment responds to a command.
+type Response struct {
+	// Document is the reply to the command. A nil document replies {ok: 1}.
+	Document *bson.Document
+	// Delay is how long the deployment waits before responding.
+	Delay time.Duration
+	// CloseConnection closes the connection instead of replying, which the driver sees as a
+	// network error.
+	CloseConnection bool
+}
+
+// Reply returns a handler that replies doc to every command.
+func Reply(doc *bson.Document) Handler {
+	return func(*Command) Response {
+		return Response{Document: doc}
+	}
+}
+
+// OK returns a handler that replies a successful response made of the given elements.
+func OK(elems ...*bson.Element) Handler {
+	doc := bson.NewDocument(bson.EC.Int32("ok", 1)).Append(elems...)
+	return Reply(doc)
+}
+
+// Error returns a handler that fails commands with the given error.
+func Error(code int32, codeName, errmsg string) Handler {
+	return Reply(bson.NewDocument(
+		bson.EC.Int32("ok", 0),
+		bson.EC.String("errmsg", errmsg),
+		bson.EC.Int32("code", code),
+		bson.EC.String("codeName", codeName),
+	))
+}
+
+// NotMaster returns a handler that fails commands as a secondary would fail writes.
+func NotMaster() Handler {
+	return Error(10107, "NotMaster", "not master")
+}
+
+// NetworkError returns a handler that closes the connection of every command.
+func NetworkError() Handler {
+	return func(*Command) Response {
+		return Response{CloseConnection: true}
+	}
+}
+
+// Delay returns a handler that responds like h after waiting for d.
+func Delay(d time.Duration, h Handler) Handler {
+	return func(cmd *Command) Response {
+		resp := h(cmd)
+		resp.Delay += d
+		return resp
+	}
+}
+
+// Sequence returns a handler that answers the first command with the first handler, the second
+// with the second handler and so on. The last handler answers the remaining commands, so
+//
+//	Sequence(NotMaster(), OK())
+//
+// fails the first command and lets a retry succeed.
+func Sequence(hs ...Handler) Handler {
+	var mu sync.Mutex
+	var n int
+	return func(cmd *Command) Response {
+		mu.Lock()
+		h := hs[n]
+		if n < len(hs)-1 {
+			n++
+		}
+		mu.Unlock()
+
+		return h(cmd)
+	}
+}
+
+// Cursor returns a handler that replies the given documents as the first and only batch of a
+// cursor on the namespace ns, e.g. "db.coll".
+func Cursor(ns string, docs ...*bson.Document) Handler {
+	batch := bson.NewArray()
+	for _, doc := range docs {
+		batch.Append(bson.VC.Document(doc))
+	}
+
+	return OK(bson.EC.SubDocumentFromElements("cursor",
+		bson.EC.Int64("id", 0),
+		bson.EC.String("ns", ns),
+		bson.EC.Array("firstBatch", batch),
+	))
+}
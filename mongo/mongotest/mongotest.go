@@ -0,0 +1,470 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package mongotest provides an in-memory MongoDB deployment for unit tests of code that uses the
+// driver.
+//
+// A Deployment speaks the wire protocol over in-memory connections handed to the driver through
+// its Dialer option, so operations go through the whole driver, including server selection,
+// sessions, retries and the encoding of options:
+//
+//	d := mongotest.New()
+//	defer d.Close()
+//	d.Handle("find", mongotest.Cursor("db.coll", bson.NewDocument(bson.EC.Int32("_id", 1))))
+//
+//	client, err := mongo.NewClientWithOptions(d.URI(), d.ClientOptions())
+//
+// The deployment answers isMaster itself. Every other command is answered by the most recently
+// registered handler that matches it, and recorded as it was sent by the driver so that tests can
+// inspect it with Commands.
+package mongotest
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/mongo/clientopt"
+)
+
+// ErrClosed is returned when dialing a closed deployment.
+var ErrClosed = errors.New("mongotest: deployment is closed")
+
+// Option configures the deployment returned by New.
+type Option func(*config)
+
+type config struct {
+	addr           string
+	replicaSet     string
+	mongos         bool
+	maxWireVersion int32
+	maxBatchSize   int32
+}
+
+// WithAddress sets the address of the deployment. The default is "mongotest:27017".
+func WithAddress(addr string) Option {
+	return func(cfg *config) { cfg.addr = addr }
+}
+
+// WithReplicaSet makes the deployment report itself as the primary of a replica set with the
+// given name rather than as a standalone server.
+func WithReplicaSet(name string) Option {
+	return func(cfg *config) { cfg.replicaSet = name }
+}
+
+// WithMongos makes the deployment report itself as a mongos rather than as a standalone server.
+func WithMongos() Option {
+	return func(cfg *config) { cfg.mongos = true }
+}
+
+// WithMaxWireVersion sets the maximum wire version reported by the deployment, which determines
+// the features the driver uses. The default is 13, i.e. MongoDB 5.0.
+func WithMaxWireVersion(v int32) Option {
+	return func(cfg *config) { cfg.maxWireVersion = v }
+}
+
+// WithMaxWriteBatchSize sets the maximum number of documents of a write command reported by the
+// deployment, which the driver splits larger writes by. The default is 100000.
+func WithMaxWriteBatchSize(n int32) Option {
+	return func(cfg *config) { cfg.maxBatchSize = n }
+}
+
+// Deployment is an in-memory MongoDB deployment made of a single server.
+type Deployment struct {
+	cfg config
+
+	mu       sync.Mutex
+	handlers []handler
+	commands []*Command
+	conns    map[net.Conn]struct{}
+	closed   bool
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+type handler struct {
+	match Matcher
+	h     Handler
+}
+
+// New returns a deployment configured with the given options. It answers ping, endSessions and
+// killCursors successfully, and every other command with a CommandNotFound error until a handler
+// is registered for it.
+func New(opts ...Option) *Deployment {
+	cfg := config{
+		addr:           "mongotest:27017",
+		maxWireVersion: 13,
+		maxBatchSize:   100000,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	d := &Deployment{
+		cfg:   cfg,
+		conns: make(map[net.Conn]struct{}),
+		done:  make(chan struct{}),
+	}
+	for _, name := range []string{"ping", "endSessions", "killCursors"} {
+		d.Handle(name, OK())
+	}
+
+	return d
+}
+
+// Address returns the address of the deployment.
+func (d *Deployment) Address() string {
+	return d.cfg.addr
+}
+
+// URI returns a connection string for the deployment.
+func (d *Deployment) URI() string {
+	if d.cfg.replicaSet != "" {
+		return fmt.Sprintf("mongodb://%s/?replicaSet=%s", d.cfg.addr, d.cfg.replicaSet)
+	}
+	return "mongodb://" + d.cfg.addr
+}
+
+// ClientOptions returns the options a client needs to connect to the deployment.
+func (d *Deployment) ClientOptions() *clientopt.ClientBundle {
+	return clientopt.BundleClient().Dialer(d)
+}
+
+// DialContext opens an in-memory connection to the deployment. It implements the
+// clientopt.ContextDialer interface.
+func (d *Deployment) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if address != d.cfg.addr {
+		return nil, fmt.Errorf("mongotest: no server at %s", address)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+
+	client, server := net.Pipe()
+	d.conns[server] = struct{}{}
+	d.wg.Add(1)
+	go d.serve(server)
+
+	return client, nil
+}
+
+// Handle registers h to answer the commands with the given name, such as "insert".
+func (d *Deployment) Handle(commandName string, h Handler) {
+	d.HandleMatch(func(cmd *Command) bool { return cmd.Name == commandName }, h)
+}
+
+// HandleMatch registers h to answer the commands match returns true for. Handlers registered later
+// take precedence over earlier ones, so a test can override the handler of a command.
+func (d *Deployment) HandleMatch(match Matcher, h Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers = append(d.handlers, handler{match: match, h: h})
+}
+
+// Commands returns the commands the deployment has received, other than isMaster, in the order it
+// received them.
+func (d *Deployment) Commands() []*Command {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]*Command(nil), d.commands...)
+}
+
+// CommandsNamed returns the commands with the given name the deployment has received, in the order
+// it received them.
+func (d *Deployment) CommandsNamed(name string) []*Command {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var cmds []*Command
+	for _, cmd := range d.commands {
+		if cmd.Name == name {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return cmds
+}
+
+// LastCommand returns the last command with the given name the deployment has received, or nil if
+// it has received none.
+func (d *Deployment) LastCommand(name string) *Command {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := len(d.commands) - 1; i >= 0; i-- {
+		if d.commands[i].Name == name {
+			return d.commands[i]
+		}
+	}
+	return nil
+}
+
+// CountCommands returns the number of commands with the given name the deployment has received.
+func (d *Deployment) CountCommands(name string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var n int
+	for _, cmd := range d.commands {
+		if cmd.Name == name {
+			n++
+		}
+	}
+	return n
+}
+
+// Close closes every connection to the deployment and waits for them to be done. Later dials fail
+// with ErrClosed.
+func (d *Deployment) Close() {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return
+	}
+	d.closed = true
+	close(d.done)
+	for nc := range d.conns {
+		_ = nc.Close()
+	}
+	d.mu.Unlock()
+
+	d.wg.Wait()
+}
+
+// serve answers the commands sent over nc until it is closed.
+func (d *Deployment) serve(nc net.Conn) {
+	defer d.wg.Done()
+	defer func() {
+		_ = nc.Close()
+		d.mu.Lock()
+		delete(d.conns, nc)
+		d.mu.Unlock()
+	}()
+
+	for {
+		b, err := readWireMessage(nc)
+		if err != nil {
+			return
+		}
+
+		req, err := decodeRequest(b)
+		if err != nil {
+			return
+		}
+
+		var resp Response
+		if isMaster(req.cmd.Name) {
+			resp = Response{Document: d.isMaster()}
+		} else {
+			resp = d.handle(req.cmd)
+		}
+
+		if resp.Delay > 0 {
+			select {
+			case <-time.After(resp.Delay):
+			case <-d.done:
+				return
+			}
+		}
+		if resp.CloseConnection {
+			return
+		}
+		if req.moreToCome {
+			continue
+		}
+
+		doc := resp.Document
+		if doc == nil {
+			doc = bson.NewDocument(bson.EC.Int32("ok", 1))
+		}
+		b, err = req.encodeReply(doc)
+		if err != nil {
+			return
+		}
+		if _, err = nc.Write(b); err != nil {
+			return
+		}
+	}
+}
+
+// handle records cmd and returns the response of the latest handler that matches it.
+func (d *Deployment) handle(cmd *Command) Response {
+	d.mu.Lock()
+	d.commands = append(d.commands, cmd)
+	handlers := d.handlers
+	d.mu.Unlock()
+
+	var h Handler
+	for i := len(handlers) - 1; i >= 0; i-- {
+		if handlers[i].match(cmd) {
+			h = handlers[i].h
+			break
+		}
+	}
+
+	if h == nil {
+		h = Error(59, "CommandNotFound", fmt.Sprintf("mongotest: no handler for command %s", cmd.Name))
+	}
+	return h(cmd)
+}
+
+func (d *Deployment) isMaster() *bson.Document {
+	doc := bson.NewDocument(
+		bson.EC.Boolean("ismaster", true),
+		bson.EC.Int32("maxBsonObjectSize", 16*1024*1024),
+		bson.EC.Int32("maxMessageSizeBytes", 48000000),
+		bson.EC.Int32("maxWriteBatchSize", d.cfg.maxBatchSize),
+		bson.EC.Int32("logicalSessionTimeoutMinutes", 30),
+		bson.EC.Int32("minWireVersion", 0),
+		bson.EC.Int32("maxWireVersion", d.cfg.maxWireVersion),
+	)
+	if d.cfg.replicaSet != "" {
+		doc.Append(
+			bson.EC.String("setName", d.cfg.replicaSet),
+			bson.EC.Boolean("secondary", false),
+			bson.EC.ArrayFromElements("hosts", bson.VC.String(d.cfg.addr)),
+			bson.EC.String("me", d.cfg.addr),
+		)
+	}
+	if d.cfg.mongos {
+		doc.Append(bson.EC.String("msg", "isdbgrid"))
+	}
+	doc.Append(bson.EC.Int32("ok", 1))
+
+	return doc
+}
+
+func isMaster(commandName string) bool {
+	return strings.EqualFold(commandName, "isMaster") || commandName == "hello"
+}
+
+// readWireMessage reads a whole wire message from r.
+func readWireMessage(r io.Reader) ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, err
+	}
+
+	n := int32(binary.LittleEndian.Uint32(size[:]))
+	if n < 16 {
+		return nil, errors.New("mongotest: wire message is too small")
+	}
+
+	b := make([]byte, n)
+	copy(b, size[:])
+	if _, err := io.ReadFull(r, b[4:]); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// request is a command received by the deployment.
+type request struct {
+	header     wiremessage.Header
+	cmd        *Command
+	moreToCome bool
+}
+
+func decodeRequest(b []byte) (*request, error) {
+	header, err := wiremessage.ReadHeader(b, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &request{header: header}
+	switch header.OpCode {
+	case wiremessage.OpQuery:
+		var q wiremessage.Query
+		if err = q.UnmarshalWireMessage(b); err != nil {
+			return nil, err
+		}
+		doc, err := bson.ReadDocument(q.Query)
+		if err != nil {
+			return nil, err
+		}
+		// commands sent to mongos wrap the command along with the read preference
+		if val, err := doc.LookupErr("$query"); err == nil {
+			if query, ok := val.MutableDocumentOK(); ok {
+				doc = query
+			}
+		}
+		req.cmd = newCommand(strings.TrimSuffix(q.FullCollectionName, ".$cmd"), doc, header.OpCode)
+	case wiremessage.OpMsg:
+		var m wiremessage.Msg
+		if err = m.UnmarshalWireMessage(b); err != nil {
+			return nil, err
+		}
+		doc, err := m.GetMainDocument()
+		if err != nil {
+			return nil, err
+		}
+		// the documents of a sequence are added to the command the way the server does
+		for _, section := range m.Sections[1:] {
+			sds, ok := section.(wiremessage.SectionDocumentSequence)
+			if !ok {
+				continue
+			}
+			arr := bson.NewArray()
+			for _, rdr := range sds.Documents {
+				seqDoc, err := bson.ReadDocument(rdr)
+				if err != nil {
+					return nil, err
+				}
+				arr.Append(bson.VC.Document(seqDoc))
+			}
+			doc.Append(bson.EC.Array(sds.Identifier, arr))
+		}
+		var db string
+		if val, err := doc.LookupErr("$db"); err == nil {
+			db, _ = val.StringValueOK()
+		}
+		req.cmd = newCommand(db, doc, header.OpCode)
+		req.moreToCome = m.FlagBits&wiremessage.MoreToCome != 0
+	default:
+		return nil, fmt.Errorf("mongotest: unsupported opcode %v", header.OpCode)
+	}
+
+	return req, nil
+}
+
+// encodeReply encodes doc as the reply to req, using the opcode that matches the request.
+func (req *request) encodeReply(doc *bson.Document) ([]byte, error) {
+	rdr, err := doc.MarshalBSON()
+	if err != nil {
+		return nil, err
+	}
+
+	header := wiremessage.Header{
+		RequestID:  wiremessage.NextRequestID(),
+		ResponseTo: req.header.RequestID,
+	}
+	if req.header.OpCode == wiremessage.OpQuery {
+		return wiremessage.Reply{
+			MsgHeader:      header,
+			NumberReturned: 1,
+			Documents:      []bson.Reader{rdr},
+		}.MarshalWireMessage()
+	}
+
+	return wiremessage.Msg{
+		MsgHeader: header,
+		Sections: []wiremessage.Section{
+			wiremessage.SectionBody{PayloadType: wiremessage.SingleDocument, Document: rdr},
+		},
+	}.MarshalWireMessage()
+}
@@ -0,0 +1,169 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongotest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/mongo"
+	"github.com/mongodb/mongo-go-driver/mongo/insertopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func connect(t *testing.T, d *mongotest.Deployment, uri string) *mongo.Client {
+	t.Helper()
+
+	client, err := mongo.NewClientWithOptions(uri, d.ClientOptions())
+	require.NoError(t, err)
+	require.NoError(t, client.Connect(context.Background()))
+	t.Cleanup(func() { _ = client.Disconnect(context.Background()) })
+
+	return client
+}
+
+func TestDeployment_recordsCommands(t *testing.T) {
+	d := mongotest.New()
+	defer d.Close()
+	d.Handle("insert", mongotest.OK(bson.EC.Int32("n", 2)))
+
+	client := connect(t, d, d.URI())
+	coll := client.Database("db").Collection("coll")
+	_, err := coll.InsertMany(context.Background(), []interface{}{
+		bson.NewDocument(bson.EC.Int32("_id", 1)),
+		bson.NewDocument(bson.EC.Int32("_id", 2)),
+	}, insertopt.Ordered(false))
+	require.NoError(t, err)
+
+	require.Equal(t, 1, d.CountCommands("insert"))
+	cmd := d.LastCommand("insert")
+	require.Equal(t, "db", cmd.Database)
+	require.Equal(t, "coll", cmd.Document.Lookup("insert").StringValue())
+	require.False(t, cmd.Document.Lookup("ordered").Boolean())
+	docs := cmd.Document.Lookup("documents").MutableArray()
+	require.Equal(t, 2, docs.Len())
+}
+
+func TestDeployment_cursor(t *testing.T) {
+	d := mongotest.New()
+	defer d.Close()
+	d.Handle("find", mongotest.Cursor("db.coll",
+		bson.NewDocument(bson.EC.Int32("_id", 1)),
+		bson.NewDocument(bson.EC.Int32("_id", 2)),
+	))
+
+	client := connect(t, d, d.URI())
+	cur, err := client.Database("db").Collection("coll").Find(context.Background(),
+		bson.NewDocument(bson.EC.Int32("x", 1)))
+	require.NoError(t, err)
+
+	var ids []int32
+	for cur.Next(context.Background()) {
+		doc, err := cur.DecodeBytes()
+		require.NoError(t, err)
+		elem, err := doc.Lookup("_id")
+		require.NoError(t, err)
+		ids = append(ids, elem.Value().Int32())
+	}
+	require.NoError(t, cur.Err())
+	require.Equal(t, []int32{1, 2}, ids)
+
+	require.Equal(t, 1, d.CountCommands("find"))
+	require.Equal(t, int32(1), d.LastCommand("find").Document.Lookup("filter", "x").Int32())
+}
+
+func TestDeployment_lastCommand(t *testing.T) {
+	d := mongotest.New()
+	defer d.Close()
+	d.Handle("find", mongotest.Cursor("db.coll"))
+
+	client := connect(t, d, d.URI())
+	require.Nil(t, d.LastCommand("find"))
+	require.Equal(t, 0, d.CountCommands("find"))
+
+	for _, name := range []string{"one", "two"} {
+		_, err := client.Database("db").Collection(name).Find(context.Background(), nil)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, d.CountCommands("find"))
+	require.Equal(t, "two", d.LastCommand("find").Document.Lookup("find").StringValue())
+	require.Nil(t, d.LastCommand("insert"))
+}
+
+func TestDeployment_retries(t *testing.T) {
+	testCases := []struct {
+		name    string
+		failure mongotest.Handler
+	}{
+		{"not master", mongotest.NotMaster()},
+		{"network error", mongotest.NetworkError()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := mongotest.New(mongotest.WithReplicaSet("rs"))
+			defer d.Close()
+			d.Handle("insert", mongotest.Sequence(tc.failure, mongotest.OK(bson.EC.Int32("n", 1))))
+
+			client := connect(t, d, d.URI()+"&retryWrites=true")
+			_, err := client.Database("db").Collection("coll").InsertOne(context.Background(),
+				bson.NewDocument(bson.EC.Int32("_id", 1)))
+			require.NoError(t, err)
+
+			inserts := d.CommandsNamed("insert")
+			require.Len(t, inserts, 2)
+			require.Equal(t,
+				inserts[0].Document.Lookup("txnNumber").Int64(),
+				inserts[1].Document.Lookup("txnNumber").Int64(),
+				"the retry should use the transaction number of the first attempt")
+		})
+	}
+}
+
+func TestDeployment_delay(t *testing.T) {
+	d := mongotest.New()
+	defer d.Close()
+	d.Handle("count", mongotest.Delay(time.Second, mongotest.OK(bson.EC.Int32("n", 1))))
+
+	client := connect(t, d, d.URI())
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.Database("db").Collection("coll").Count(ctx, nil)
+	require.Error(t, err)
+}
+
+func TestDeployment_unhandledCommand(t *testing.T) {
+	d := mongotest.New()
+	defer d.Close()
+
+	client := connect(t, d, d.URI())
+	_, err := client.Database("db").Collection("coll").Count(context.Background(), nil)
+	cerr, ok := err.(command.Error)
+	require.True(t, ok, "expected a command.Error, got %T: %v", err, err)
+	require.Equal(t, int32(59), cerr.Code)
+}
+
+func TestDeployment_handlerPrecedence(t *testing.T) {
+	d := mongotest.New()
+	defer d.Close()
+	d.Handle("count", mongotest.OK(bson.EC.Int32("n", 1)))
+	d.HandleMatch(func(cmd *mongotest.Command) bool {
+		return cmd.Name == "count" && cmd.Document.Lookup("count").StringValue() == "other"
+	}, mongotest.OK(bson.EC.Int32("n", 2)))
+
+	client := connect(t, d, d.URI())
+	n, err := client.Database("db").Collection("coll").Count(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+	n, err = client.Database("db").Collection("other").Count(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+}
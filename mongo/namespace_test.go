@@ -0,0 +1,87 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
+	"github.com/mongodb/mongo-go-driver/mongo/collectionopt"
+	"github.com/mongodb/mongo-go-driver/mongo/dbopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceValidation(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("insert", mongotest.OK(bson.EC.Int32("n", 1)))
+	d.Handle("find", openCursor("firstBatch"))
+	d.Handle("ping", mongotest.OK())
+
+	client := newMockClient(t, d)
+
+	sent := func() int {
+		return d.CountCommands("insert") + d.CountCommands("find") + d.CountCommands("ping")
+	}
+	doc := bson.NewDocument(bson.EC.Int32("x", 1))
+	ping := bson.NewDocument(bson.EC.Int32("ping", 1))
+
+	t.Run("invalid database name", func(t *testing.T) {
+		for _, name := range []string{"", "a.b", "a b", "a/b", "a\x00b", "a:b", "a*b"} {
+			expected := command.ValidateDatabaseName(name)
+			db := client.Database(name)
+
+			_, err := db.RunCommand(context.Background(), ping)
+			require.Equal(t, expected, err, "%q", name)
+			_, err = db.Collection("coll").InsertOne(context.Background(), doc)
+			require.Equal(t, expected, err, "%q", name)
+			_, err = db.Collection("coll").Find(context.Background(), nil)
+			require.Equal(t, expected, err, "%q", name)
+		}
+		require.Equal(t, 0, sent())
+	})
+	t.Run("invalid collection name", func(t *testing.T) {
+		before := sent()
+		for _, name := range []string{"", "a\x00b"} {
+			expected := command.ValidateCollectionName(name)
+			coll := client.Database("db").Collection(name)
+
+			_, err := coll.InsertOne(context.Background(), doc)
+			require.Equal(t, expected, err, "%q", name)
+
+			clone, err := coll.Clone(collectionopt.ReadPreference(readpref.Nearest()))
+			require.NoError(t, err)
+			_, err = clone.Find(context.Background(), nil)
+			require.Equal(t, expected, err, "%q", name)
+		}
+		require.Equal(t, before, sent())
+	})
+	t.Run("valid names", func(t *testing.T) {
+		before := sent()
+		coll := client.Database("db").Collection("system.profile")
+		_, err := coll.InsertOne(context.Background(), doc)
+		require.NoError(t, err)
+		require.Equal(t, before+1, sent())
+	})
+	t.Run("non-portable database name", func(t *testing.T) {
+		before := sent()
+		db := client.Database("a:b", dbopt.AllowNonPortableName(true))
+		_, err := db.RunCommand(context.Background(), ping)
+		require.NoError(t, err)
+		_, err = db.Collection("coll").InsertOne(context.Background(), doc)
+		require.NoError(t, err)
+		require.Equal(t, before+2, sent())
+
+		db = client.Database("a.b", dbopt.AllowNonPortableName(true))
+		_, err = db.RunCommand(context.Background(), ping)
+		require.Equal(t, command.ValidateNonPortableDatabaseName("a.b"), err)
+		require.Equal(t, before+2, sent())
+	})
+}
@@ -0,0 +1,37 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/option"
+)
+
+// ErrNaturalSortWithKeys is returned when a sort orders documents by $natural along with other keys.
+var ErrNaturalSortWithKeys = option.ErrNaturalSortWithKeys
+
+// SortNatural returns a sort document that orders documents in the order they are stored, i.e.
+// {$natural: 1} when direction is positive or zero and {$natural: -1} when it is negative. It is
+// used with the Sort options, e.g. to read a capped collection such as the oplog from its newest
+// document, and cannot be combined with other sort keys.
+func SortNatural(direction int) *bson.Document {
+	return naturalOrder(direction)
+}
+
+// HintNatural returns a hint document that forces a collection scan in the order documents are
+// stored, forward when direction is positive or zero and in reverse when it is negative. It is used
+// with the Hint options.
+func HintNatural(direction int) *bson.Document {
+	return naturalOrder(direction)
+}
+
+func naturalOrder(direction int) *bson.Document {
+	if direction < 0 {
+		return bson.NewDocument(bson.EC.Int32("$natural", -1))
+	}
+	return bson.NewDocument(bson.EC.Int32("$natural", 1))
+}
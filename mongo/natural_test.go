@@ -0,0 +1,55 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNaturalOrder(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("find", mongotest.Cursor("local.oplog.rs"))
+
+	client := newMockClient(t, d)
+	coll := client.Database("local").Collection("oplog.rs")
+
+	lastFind := func() *bson.Document {
+		cmd := d.LastCommand("find")
+		require.NotNil(t, cmd)
+		return cmd.Document
+	}
+
+	t.Run("sort", func(t *testing.T) {
+		for _, tc := range []struct {
+			direction int
+			expected  int32
+		}{{-5, -1}, {-1, -1}, {0, 1}, {1, 1}} {
+			_, err := coll.Find(context.Background(), nil, findopt.Sort(SortNatural(tc.direction)))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, lastFind().Lookup("sort", "$natural").Int32())
+		}
+	})
+	t.Run("hint", func(t *testing.T) {
+		_, err := coll.Find(context.Background(), nil, findopt.Hint(HintNatural(-1)))
+		require.NoError(t, err)
+		require.Equal(t, int32(-1), lastFind().Lookup("hint", "$natural").Int32())
+	})
+	t.Run("sort with other keys", func(t *testing.T) {
+		sort := bson.NewDocument(bson.EC.Int32("$natural", 1), bson.EC.Int32("ts", 1))
+		_, err := coll.Find(context.Background(), nil, findopt.Sort(sort))
+		require.Equal(t, ErrNaturalSortWithKeys, err)
+
+		err = coll.FindOne(context.Background(), nil, findopt.Sort(sort)).Err()
+		require.Equal(t, ErrNaturalSortWithKeys, err)
+	})
+}
@@ -6,6 +6,88 @@
 
 package mongo
 
-import "github.com/mongodb/mongo-go-driver/internal/observability"
+import (
+	"context"
+
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/trace"
+)
 
 var AllViews = observability.AllViews
+
+// InstrumentationOptions configures the OpenCensus spans and stats emitted by the driver. Setting
+// Disabled skips all instrumentation work. TraceSampler chooses a sampler per span name, so that for
+// example only a fraction of "mongo-go/mongo.(*Collection).Find" spans are kept; stats such as error
+// counts are recorded regardless of sampling.
+type InstrumentationOptions = observability.Options
+
+// ConfigureInstrumentation sets the instrumentation options for every client in the process.
+func ConfigureInstrumentation(opts InstrumentationOptions) {
+	observability.Configure(opts)
+}
+
+// InstrumentationSpan is a span started by an InstrumentationBackend. It is the subset of
+// *trace.Span used by the driver, so backends other than OpenCensus translate the OpenCensus
+// statuses and attributes passed to it.
+type InstrumentationSpan interface {
+	End()
+	SetStatus(status trace.Status)
+	AddAttributes(attributes ...trace.Attribute)
+	Annotate(attributes []trace.Attribute, str string)
+	Annotatef(attributes []trace.Attribute, format string, a ...interface{})
+}
+
+// InstrumentationBackend emits the spans and measurements of the driver. The default backend uses
+// OpenCensus; the otelmongo package provides one that uses OpenTelemetry. The tags the driver
+// applies, such as the method and the database, are available from the context passed to both
+// methods through tag.FromContext.
+type InstrumentationBackend interface {
+	// StartSpan starts a span with the given name. If sampler is not nil, it was returned by the
+	// TraceSampler of the InstrumentationOptions and should decide whether the span is sampled.
+	StartSpan(ctx context.Context, name string, sampler trace.Sampler) (context.Context, InstrumentationSpan)
+
+	// Record records the given measurements.
+	Record(ctx context.Context, ms ...stats.Measurement)
+}
+
+// SetInstrumentationBackend sets the backend used to emit spans and measurements for every client
+// in the process. It should be called once, before any client is created. A nil backend restores
+// the default OpenCensus backend.
+func SetInstrumentationBackend(b InstrumentationBackend) {
+	if b == nil {
+		observability.SetBackend(nil)
+		return
+	}
+	observability.SetBackend(instrumentationBackend{b})
+}
+
+// instrumentationBackend adapts an InstrumentationBackend to the backend used by the driver.
+type instrumentationBackend struct {
+	InstrumentationBackend
+}
+
+func (b instrumentationBackend) StartSpan(ctx context.Context, name string, sampler trace.Sampler) (context.Context, observability.Span) {
+	return b.InstrumentationBackend.StartSpan(ctx, name, sampler)
+}
+
+// CollectionTagMode specifies how the collection of an operation is recorded in the "collection" tag of the
+// exported views.
+type CollectionTagMode = observability.CollectionTagMode
+
+// These constants are the supported collection tag modes.
+const (
+	CollectionTagName = observability.CollectionTagName
+	CollectionTagHash = observability.CollectionTagHash
+	CollectionTagDrop = observability.CollectionTagDrop
+)
+
+// RegisterAllViews registers every view in AllViews.
+func RegisterAllViews() error {
+	return observability.RegisterAllViews()
+}
+
+// UnregisterAllViews unregisters every view in AllViews.
+func UnregisterAllViews() {
+	observability.UnregisterAllViews()
+}
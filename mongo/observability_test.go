@@ -0,0 +1,116 @@
+// Copyright (C) MongoDB, Inc. 2018-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+)
+
+type recordingBackend struct {
+	mu    sync.Mutex
+	spans []string
+	ms    []string
+}
+
+func (b *recordingBackend) StartSpan(ctx context.Context, name string, _ trace.Sampler) (context.Context, InstrumentationSpan) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spans = append(b.spans, name)
+	return ctx, (*trace.Span)(nil)
+}
+
+func (b *recordingBackend) Record(_ context.Context, ms ...stats.Measurement) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, m := range ms {
+		b.ms = append(b.ms, m.Measure().Name())
+	}
+}
+
+// recorded returns the names of the spans started and of the measures recorded so far.
+func (b *recordingBackend) recorded() (spans, ms []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.spans...), append([]string(nil), b.ms...)
+}
+
+func TestSetInstrumentationBackend(t *testing.T) {
+	b := &recordingBackend{}
+	SetInstrumentationBackend(b)
+	defer SetInstrumentationBackend(nil)
+
+	d := mongotest.New()
+	d.Handle("insert", mongotest.OK(bson.EC.Int32("n", 1)))
+	client := newMockClient(t, d)
+
+	_, err := client.Database("db").Collection("coll").InsertOne(context.Background(), bson.NewDocument(bson.EC.Int32("_id", 1)))
+	require.NoError(t, err)
+
+	spans, ms := b.recorded()
+	require.Contains(t, spans, "mongo-go/mongo.(*Collection).InsertOne")
+	require.Contains(t, ms, observability.MCalls.Name())
+}
+
+func TestErrorsRecordedOnce(t *testing.T) {
+	keys := []tag.Key{observability.KeyMethod}
+	calls := &view.View{Name: "test/mongo_calls", Measure: observability.MCalls, Aggregation: view.Count(), TagKeys: keys}
+	errs := &view.View{Name: "test/mongo_errors", Measure: observability.MErrors, Aggregation: view.Count(), TagKeys: keys}
+	// count returns the number of measurements of v tagged with the insert_one method.
+	count := func(v *view.View) int64 {
+		rows, err := view.RetrieveData(v.Name)
+		require.NoError(t, err)
+		var n int64
+		for _, row := range rows {
+			if len(row.Tags) == 1 && row.Tags[0].Value == "insert_one" {
+				n += row.Data.(*view.CountData).Value
+			}
+		}
+		return n
+	}
+
+	testCases := []struct {
+		name   string
+		insert mongotest.Handler
+	}{
+		{"dispatch error", mongotest.Error(2, "BadValue", "bad value")},
+		{"write error", mongotest.OK(bson.EC.Int32("n", 0), bson.EC.ArrayFromElements("writeErrors",
+			bson.VC.DocumentFromElements(
+				bson.EC.Int32("index", 0),
+				bson.EC.Int32("code", 11000),
+				bson.EC.String("errmsg", "duplicate key"),
+			),
+		))},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, view.Register(calls, errs))
+			defer view.Unregister(calls, errs)
+
+			d := mongotest.New()
+			d.Handle("insert", tc.insert)
+			client := newMockClient(t, d)
+
+			coll := client.Database("db").Collection("coll")
+			_, err := coll.InsertOne(context.Background(), bson.NewDocument(bson.EC.Int32("_id", 1)))
+			require.Error(t, err)
+
+			require.Equal(t, int64(1), count(calls))
+			require.Equal(t, int64(1), count(errs))
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+)
+
+// OperationDebug holds the server address, round trip time, retry count and reply times of an
+// operation, for tracking latency objectives without enabling command monitoring. It is filled by
+// the operations run with a context returned by WithOperationDebug.
+type OperationDebug = observability.OperationDebug
+
+// WithOperationDebug returns a copy of ctx that fills d with the details of the operations run with
+// it. Each field describes the last command sent, except Retries, which counts the retries of every
+// operation run with the context. Operations run without such a context do no extra work.
+func WithOperationDebug(ctx context.Context, d *OperationDebug) context.Context {
+	return observability.WithDebug(ctx, d)
+}
@@ -0,0 +1,58 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/clientopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationDebug(t *testing.T) {
+	d := mongotest.New(mongotest.WithReplicaSet("rs"))
+	d.Handle("insert", mongotest.Sequence(
+		mongotest.NotMaster(),
+		mongotest.OK(
+			bson.EC.Int32("n", 1),
+			bson.EC.Timestamp("operationTime", 42, 7),
+			bson.EC.SubDocumentFromElements("$clusterTime", bson.EC.Timestamp("clusterTime", 42, 7)),
+		),
+	))
+	d.Handle("find", mongotest.Cursor("db.coll"))
+
+	client := newMockClient(t, d, clientopt.RetryWrites(true))
+	coll := client.Database("db").Collection("coll")
+
+	t.Run("retried write", func(t *testing.T) {
+		var debug OperationDebug
+		_, err := coll.InsertOne(WithOperationDebug(context.Background(), &debug), bson.NewDocument(bson.EC.Int32("_id", 1)))
+		require.NoError(t, err)
+
+		require.Equal(t, 1, debug.Retries)
+		require.Equal(t, d.Address(), debug.Address.String())
+		require.True(t, debug.RTT > 0)
+		require.Equal(t, &bson.Timestamp{T: 42, I: 7}, debug.OperationTime)
+		require.NotNil(t, debug.ClusterTime)
+		t1, i1 := debug.ClusterTime.Lookup("clusterTime").Timestamp()
+		require.Equal(t, []uint32{42, 7}, []uint32{t1, i1})
+	})
+	t.Run("read", func(t *testing.T) {
+		var debug OperationDebug
+		cur, err := coll.Find(WithOperationDebug(context.Background(), &debug), bson.NewDocument())
+		require.NoError(t, err)
+		require.NoError(t, cur.Close(context.Background()))
+
+		require.Equal(t, 0, debug.Retries)
+		require.Equal(t, d.Address(), debug.Address.String())
+		require.Nil(t, debug.OperationTime)
+		require.Nil(t, debug.ClusterTime)
+	})
+}
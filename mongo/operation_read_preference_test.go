@@ -0,0 +1,97 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
+	"github.com/mongodb/mongo-go-driver/mongo/aggregateopt"
+	"github.com/mongodb/mongo-go-driver/mongo/countopt"
+	"github.com/mongodb/mongo-go-driver/mongo/distinctopt"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationReadPreference(t *testing.T) {
+	d := mongotest.New(mongotest.WithMongos())
+	d.Handle("find", mongotest.Cursor("db.coll"))
+	d.Handle("aggregate", mongotest.Cursor("db.coll"))
+	d.Handle("count", mongotest.OK(bson.EC.Int32("n", 0)))
+	d.Handle("distinct", mongotest.OK(bson.EC.ArrayFromElements("values")))
+
+	client := newMockClient(t, d)
+
+	coll := client.Database("db").Collection("coll")
+	ctx := context.Background()
+	requireMode := func(t *testing.T, name string, mode string) {
+		cmd := d.LastCommand(name)
+		require.NotNil(t, cmd, "no %s command sent", name)
+		require.Equal(t, mode, cmd.Document.Lookup("$readPreference", "mode").StringValue())
+	}
+
+	t.Run("find", func(t *testing.T) {
+		cur, err := coll.Find(ctx, nil, findopt.ReadPreference(readpref.Secondary()))
+		require.NoError(t, err)
+		require.NoError(t, cur.Close(ctx))
+		requireMode(t, "find", "secondary")
+
+		// the override does not outlive the operation
+		cur, err = coll.Find(ctx, nil)
+		require.NoError(t, err)
+		require.NoError(t, cur.Close(ctx))
+		_, err = d.LastCommand("find").Document.LookupErr("$readPreference")
+		require.Equal(t, bson.ErrElementNotFound, err)
+	})
+	t.Run("find one", func(t *testing.T) {
+		err := coll.FindOne(ctx, nil, findopt.ReadPreference(readpref.Nearest())).Decode(nil)
+		require.Equal(t, ErrNoDocuments, err)
+		requireMode(t, "find", "nearest")
+	})
+	t.Run("aggregate", func(t *testing.T) {
+		cur, err := coll.Aggregate(ctx, bson.NewArray(), aggregateopt.ReadPreference(readpref.SecondaryPreferred()))
+		require.NoError(t, err)
+		require.NoError(t, cur.Close(ctx))
+		requireMode(t, "aggregate", "secondaryPreferred")
+	})
+	t.Run("count", func(t *testing.T) {
+		_, err := coll.Count(ctx, nil, countopt.ReadPreference(readpref.Secondary()))
+		require.NoError(t, err)
+		requireMode(t, "count", "secondary")
+	})
+	t.Run("distinct", func(t *testing.T) {
+		_, err := coll.Distinct(ctx, "x", nil, distinctopt.ReadPreference(readpref.PrimaryPreferred()))
+		require.NoError(t, err)
+		requireMode(t, "distinct", "primaryPreferred")
+	})
+	t.Run("output stage", func(t *testing.T) {
+		target := func(key string) *bson.Element {
+			return bson.EC.SubDocumentFromElements(key, bson.EC.String("db", "otherdb"), bson.EC.String("coll", "other"))
+		}
+		stages := map[string]*bson.Element{
+			"$out":                         bson.EC.String("$out", "other"),
+			"$merge":                       bson.EC.String("$merge", "other"),
+			"$out to another database":     target("$out"),
+			"$merge into another database": bson.EC.SubDocumentFromElements("$merge", target("into")),
+		}
+		for stage, elem := range stages {
+			pipeline := bson.NewArray(bson.VC.DocumentFromElements(elem))
+			before := len(d.Commands())
+
+			_, err := coll.Aggregate(ctx, pipeline, aggregateopt.ReadPreference(readpref.Secondary()))
+			require.Equal(t, ErrOutReadPreference, err, stage)
+			require.Len(t, d.Commands(), before, "%s aggregate sent", stage)
+
+			cur, err := coll.Aggregate(ctx, pipeline, aggregateopt.ReadPreference(readpref.Primary()))
+			require.NoError(t, err, stage)
+			require.NoError(t, cur.Close(ctx))
+		}
+	})
+}
@@ -0,0 +1,262 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package options builds the options of operations as plain structs, instead of the Opt values and
+// bundles of the per-operation option packages:
+//
+//	cur, err := coll.Find(ctx, filter, options.Find().SetLimit(10).SetSort(sort))
+//
+// The structs are passed to the same methods as the options they replace, which convert them to
+// the equivalent bundles, so that both styles can be mixed while existing code is migrated. An
+// option set both ways is taken from whichever is passed last.
+package options
+
+import (
+	"github.com/mongodb/mongo-go-driver/mongo/changestreamopt"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+	"github.com/mongodb/mongo-go-driver/mongo/indexopt"
+)
+
+// FindOptions are the options of Collection.Find.
+type FindOptions = findopt.FindOptions
+
+// FindOneOptions are the options of Collection.FindOne.
+type FindOneOptions = findopt.FindOneOptions
+
+// ChangeStreamOptions are the options of Collection.Watch.
+type ChangeStreamOptions = changestreamopt.ChangeStreamOptions
+
+// CreateIndexesOptions are the options of IndexView.CreateOne and IndexView.CreateMany.
+type CreateIndexesOptions = indexopt.CreateOptions
+
+// DropIndexesOptions are the options of IndexView.DropOne and IndexView.DropAll.
+type DropIndexesOptions = indexopt.DropOptions
+
+// ListIndexesOptions are the options of IndexView.List.
+type ListIndexesOptions = indexopt.ListOptions
+
+// Find returns empty options for Collection.Find.
+func Find() *FindOptions {
+	return &FindOptions{}
+}
+
+// FindOne returns empty options for Collection.FindOne.
+func FindOne() *FindOneOptions {
+	return &FindOneOptions{}
+}
+
+// ChangeStream returns empty options for Collection.Watch.
+func ChangeStream() *ChangeStreamOptions {
+	return &ChangeStreamOptions{}
+}
+
+// CreateIndexes returns empty options for IndexView.CreateOne and IndexView.CreateMany.
+func CreateIndexes() *CreateIndexesOptions {
+	return &CreateIndexesOptions{}
+}
+
+// DropIndexes returns empty options for IndexView.DropOne and IndexView.DropAll.
+func DropIndexes() *DropIndexesOptions {
+	return &DropIndexesOptions{}
+}
+
+// ListIndexes returns empty options for IndexView.List.
+func ListIndexes() *ListIndexesOptions {
+	return &ListIndexesOptions{}
+}
+
+// ProfilingOptions are the options of Database.SetProfilingLevel. Unset fields leave the current
+// settings of the server unchanged.
+type ProfilingOptions struct {
+	// SlowMS is the threshold in milliseconds above which operations are considered slow.
+	SlowMS *int32
+	// SampleRate is the fraction of slow operations that are profiled, between 0 and 1.
+	SampleRate *float64
+}
+
+// Profiling returns empty options for Database.SetProfilingLevel.
+func Profiling() *ProfilingOptions {
+	return &ProfilingOptions{}
+}
+
+// SetSlowMS sets the SlowMS field.
+func (po *ProfilingOptions) SetSlowMS(ms int32) *ProfilingOptions {
+	po.SlowMS = &ms
+	return po
+}
+
+// SetSampleRate sets the SampleRate field.
+func (po *ProfilingOptions) SetSampleRate(rate float64) *ProfilingOptions {
+	po.SampleRate = &rate
+	return po
+}
+
+// Role identifies a role by its name and the database it is defined in.
+type Role struct {
+	Role string `bson:"role"`
+	DB   string `bson:"db"`
+}
+
+// AuthenticationRestriction restricts the addresses a user can authenticate from and to. Empty
+// fields impose no restriction.
+type AuthenticationRestriction struct {
+	// ClientSource lists the IP addresses and CIDR ranges the user can connect from.
+	ClientSource []string `bson:"clientSource,omitempty"`
+	// ServerAddress lists the IP addresses and CIDR ranges the user can connect to.
+	ServerAddress []string `bson:"serverAddress,omitempty"`
+}
+
+// CreateUserOptions are the options of Database.CreateUser.
+type CreateUserOptions struct {
+	// Mechanisms restricts the SCRAM mechanisms the user can authenticate with.
+	Mechanisms []string
+	// AuthenticationRestrictions restricts the addresses the user can authenticate from and to.
+	AuthenticationRestrictions []AuthenticationRestriction
+	// CustomData is any information to store with the user. It accepts the same types as the
+	// filter of Collection.Find.
+	CustomData interface{}
+}
+
+// CreateUser returns empty options for Database.CreateUser.
+func CreateUser() *CreateUserOptions {
+	return &CreateUserOptions{}
+}
+
+// SetMechanisms sets the Mechanisms field.
+func (cuo *CreateUserOptions) SetMechanisms(mechanisms ...string) *CreateUserOptions {
+	cuo.Mechanisms = mechanisms
+	return cuo
+}
+
+// SetAuthenticationRestrictions sets the AuthenticationRestrictions field.
+func (cuo *CreateUserOptions) SetAuthenticationRestrictions(restrictions ...AuthenticationRestriction) *CreateUserOptions {
+	cuo.AuthenticationRestrictions = restrictions
+	return cuo
+}
+
+// SetCustomData sets the CustomData field.
+func (cuo *CreateUserOptions) SetCustomData(data interface{}) *CreateUserOptions {
+	cuo.CustomData = data
+	return cuo
+}
+
+// UpdateUserOptions are the options of Database.UpdateUser. Unset fields leave the user unchanged.
+type UpdateUserOptions struct {
+	// Password is the new password of the user.
+	Password *string
+	// Roles replaces the roles of the user. An empty, non-nil slice removes all of them.
+	Roles []Role
+	// Mechanisms restricts the SCRAM mechanisms the user can authenticate with.
+	Mechanisms []string
+	// AuthenticationRestrictions replaces the restrictions on the addresses the user can
+	// authenticate from and to. An empty, non-nil slice removes all of them.
+	AuthenticationRestrictions []AuthenticationRestriction
+	// CustomData replaces the information stored with the user.
+	CustomData interface{}
+}
+
+// UpdateUser returns empty options for Database.UpdateUser.
+func UpdateUser() *UpdateUserOptions {
+	return &UpdateUserOptions{}
+}
+
+// SetPassword sets the Password field.
+func (uuo *UpdateUserOptions) SetPassword(password string) *UpdateUserOptions {
+	uuo.Password = &password
+	return uuo
+}
+
+// SetRoles sets the Roles field.
+func (uuo *UpdateUserOptions) SetRoles(roles ...Role) *UpdateUserOptions {
+	uuo.Roles = append([]Role{}, roles...)
+	return uuo
+}
+
+// SetMechanisms sets the Mechanisms field.
+func (uuo *UpdateUserOptions) SetMechanisms(mechanisms ...string) *UpdateUserOptions {
+	uuo.Mechanisms = mechanisms
+	return uuo
+}
+
+// SetAuthenticationRestrictions sets the AuthenticationRestrictions field.
+func (uuo *UpdateUserOptions) SetAuthenticationRestrictions(restrictions ...AuthenticationRestriction) *UpdateUserOptions {
+	uuo.AuthenticationRestrictions = append([]AuthenticationRestriction{}, restrictions...)
+	return uuo
+}
+
+// SetCustomData sets the CustomData field.
+func (uuo *UpdateUserOptions) SetCustomData(data interface{}) *UpdateUserOptions {
+	uuo.CustomData = data
+	return uuo
+}
+
+// UsersInfoOptions are the options of Database.UsersInfo.
+type UsersInfoOptions struct {
+	// ShowPrivileges includes the privileges the roles of each user grant.
+	ShowPrivileges *bool
+	// ShowAuthenticationRestrictions includes the authentication restrictions of each user.
+	ShowAuthenticationRestrictions *bool
+	// AllDatabases lists the users of every database instead of those of the database the
+	// command runs against, which must then be the admin database.
+	AllDatabases *bool
+	// Filter selects the users to return. It accepts the same types as the filter of
+	// Collection.Find. It requires MongoDB 4.0 or later.
+	Filter interface{}
+}
+
+// UsersInfo returns empty options for Database.UsersInfo.
+func UsersInfo() *UsersInfoOptions {
+	return &UsersInfoOptions{}
+}
+
+// SetShowPrivileges sets the ShowPrivileges field.
+func (uio *UsersInfoOptions) SetShowPrivileges(b bool) *UsersInfoOptions {
+	uio.ShowPrivileges = &b
+	return uio
+}
+
+// SetShowAuthenticationRestrictions sets the ShowAuthenticationRestrictions field.
+func (uio *UsersInfoOptions) SetShowAuthenticationRestrictions(b bool) *UsersInfoOptions {
+	uio.ShowAuthenticationRestrictions = &b
+	return uio
+}
+
+// SetAllDatabases sets the AllDatabases field.
+func (uio *UsersInfoOptions) SetAllDatabases(b bool) *UsersInfoOptions {
+	uio.AllDatabases = &b
+	return uio
+}
+
+// SetFilter sets the Filter field.
+func (uio *UsersInfoOptions) SetFilter(filter interface{}) *UsersInfoOptions {
+	uio.Filter = filter
+	return uio
+}
+
+// RolesInfoOptions are the options of Database.RolesInfo.
+type RolesInfoOptions struct {
+	// ShowPrivileges includes the privileges each role grants.
+	ShowPrivileges *bool
+	// ShowBuiltinRoles includes the built-in roles when listing all of the roles of a database.
+	ShowBuiltinRoles *bool
+}
+
+// RolesInfo returns empty options for Database.RolesInfo.
+func RolesInfo() *RolesInfoOptions {
+	return &RolesInfoOptions{}
+}
+
+// SetShowPrivileges sets the ShowPrivileges field.
+func (rio *RolesInfoOptions) SetShowPrivileges(b bool) *RolesInfoOptions {
+	rio.ShowPrivileges = &b
+	return rio
+}
+
+// SetShowBuiltinRoles sets the ShowBuiltinRoles field.
+func (rio *RolesInfoOptions) SetShowBuiltinRoles(b bool) *RolesInfoOptions {
+	rio.ShowBuiltinRoles = &b
+	return rio
+}
@@ -0,0 +1,212 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/readconcern"
+	"github.com/mongodb/mongo-go-driver/mongo/changestreamopt"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+	"github.com/mongodb/mongo-go-driver/mongo/indexopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongoopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionStyles(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("find", openCursor("firstBatch"))
+	d.Handle("aggregate", openCursor("firstBatch"))
+	d.Handle("listIndexes", openCursor("firstBatch"))
+	d.Handle("killCursors", mongotest.OK())
+	d.Handle("createIndexes", mongotest.OK())
+	d.Handle("dropIndexes", mongotest.OK())
+
+	client := newMockClient(t, d)
+	coll := client.Database("db").Collection("coll")
+	ctx := context.Background()
+
+	// lastCommand returns the last command named name, without its session id.
+	lastCommand := func(name string) *bson.Document {
+		cmd := d.LastCommand(name)
+		require.NotNil(t, cmd)
+		doc := cmd.Document.Copy()
+		doc.Delete("lsid")
+		return doc
+	}
+	// requireSame runs both styles of an operation and requires them to send the same command.
+	requireSame := func(t *testing.T, name string, bundled, structured func() error) {
+		require.NoError(t, bundled())
+		expected := lastCommand(name)
+		require.NoError(t, structured())
+		require.True(t, expected.Equal(lastCommand(name)), "expected %v, got %v", expected, lastCommand(name))
+	}
+	closeCursor := func(cur Cursor, err error) error {
+		if err != nil {
+			return err
+		}
+		return cur.Close(ctx)
+	}
+
+	collation := &mongoopt.Collation{Locale: "en"}
+	let := bson.NewDocument(bson.EC.Int32("x", 1))
+	hint := bson.NewDocument(bson.EC.Int32("a", 1))
+	max := bson.NewDocument(bson.EC.Int32("a", 10))
+	min := bson.NewDocument(bson.EC.Int32("a", 0))
+	projection := bson.NewDocument(bson.EC.Int32("a", 1))
+	sort := bson.NewDocument(bson.EC.Int32("a", -1))
+
+	t.Run("find", func(t *testing.T) {
+		requireSame(t, "find", func() error {
+			return closeCursor(coll.Find(ctx, nil, findopt.BundleFind().
+				AllowPartialResults(true).
+				BatchSize(5).
+				Collation(collation).
+				Comment("comment").
+				CursorType(mongoopt.TailableAwait).
+				Hint(hint).
+				Let(let).
+				Limit(10).
+				Max(max).
+				MaxAwaitTime(time.Second).
+				MaxTime(2*time.Second).
+				Min(min).
+				NoCursorTimeout(true).
+				Prefetch(true).
+				Projection(projection).
+				ReadConcern(readconcern.Majority()).
+				ReturnKey(true).
+				ShowRecordID(true).
+				Sort(sort)))
+		}, func() error {
+			return closeCursor(coll.Find(ctx, nil, options.Find().
+				SetAllowPartialResults(true).
+				SetBatchSize(5).
+				SetCollation(collation).
+				SetComment("comment").
+				SetCursorType(mongoopt.TailableAwait).
+				SetHint(hint).
+				SetLet(let).
+				SetLimit(10).
+				SetMax(max).
+				SetMaxAwaitTime(time.Second).
+				SetMaxTime(2*time.Second).
+				SetMin(min).
+				SetNoCursorTimeout(true).
+				SetPrefetch(true).
+				SetProjection(projection).
+				SetReadConcern(readconcern.Majority()).
+				SetReturnKey(true).
+				SetShowRecordID(true).
+				SetSort(sort)))
+		})
+	})
+	t.Run("find mixed", func(t *testing.T) {
+		requireSame(t, "find", func() error {
+			return closeCursor(coll.Find(ctx, nil, findopt.Limit(10), findopt.Skip(3), findopt.Limit(20)))
+		}, func() error {
+			return closeCursor(coll.Find(ctx, nil, options.Find().SetLimit(10).SetSkip(3), findopt.Limit(20)))
+		})
+		requireSame(t, "find", func() error {
+			return closeCursor(coll.Find(ctx, nil, findopt.Limit(20)))
+		}, func() error {
+			return closeCursor(coll.Find(ctx, nil, findopt.Limit(10), options.Find().SetLimit(20)))
+		})
+		requireSame(t, "find", func() error {
+			return closeCursor(coll.Find(ctx, nil))
+		}, func() error {
+			return closeCursor(coll.Find(ctx, nil, options.Find(), (*options.FindOptions)(nil)))
+		})
+	})
+	t.Run("find one", func(t *testing.T) {
+		requireSame(t, "find", func() error {
+			return coll.FindOne(ctx, nil, findopt.BundleOne().
+				AllowPartialResults(true).
+				Collation(collation).
+				Comment("comment").
+				Hint(hint).
+				Let(let).
+				Max(max).
+				MaxTime(2*time.Second).
+				Min(min).
+				Projection(projection).
+				ReadConcern(readconcern.Majority()).
+				ReturnKey(true).
+				ShowRecordID(true).
+				Skip(3).
+				Sort(sort)).Decode(nil)
+		}, func() error {
+			return coll.FindOne(ctx, nil, options.FindOne().
+				SetAllowPartialResults(true).
+				SetCollation(collation).
+				SetComment("comment").
+				SetHint(hint).
+				SetLet(let).
+				SetMax(max).
+				SetMaxTime(2*time.Second).
+				SetMin(min).
+				SetProjection(projection).
+				SetReadConcern(readconcern.Majority()).
+				SetReturnKey(true).
+				SetShowRecordID(true).
+				SetSkip(3).
+				SetSort(sort)).Decode(nil)
+		})
+	})
+	t.Run("watch", func(t *testing.T) {
+		resumeAfter := bson.NewDocument(bson.EC.Int32("token", 1))
+		requireSame(t, "aggregate", func() error {
+			return closeCursor(coll.Watch(ctx, nil, changestreamopt.BundleChangeStream().
+				BatchSize(5).
+				Collation(collation).
+				FullDocument(mongoopt.UpdateLookup).
+				FullDocumentBeforeChange(mongoopt.WhenAvailable).
+				MaxAwaitTime(time.Second).
+				ResumeAfter(resumeAfter).
+				ShowExpandedEvents(true)))
+		}, func() error {
+			return closeCursor(coll.Watch(ctx, nil, options.ChangeStream().
+				SetBatchSize(5).
+				SetCollation(collation).
+				SetFullDocument(mongoopt.UpdateLookup).
+				SetFullDocumentBeforeChange(mongoopt.WhenAvailable).
+				SetMaxAwaitTime(time.Second).
+				SetResumeAfter(resumeAfter).
+				SetShowExpandedEvents(true)))
+		})
+	})
+	t.Run("index view", func(t *testing.T) {
+		iv := coll.Indexes()
+		requireSame(t, "listIndexes", func() error {
+			return closeCursor(iv.List(ctx, indexopt.BundleList().BatchSize(5).MaxTime(time.Second)))
+		}, func() error {
+			return closeCursor(iv.List(ctx, options.ListIndexes().SetBatchSize(5).SetMaxTime(time.Second)))
+		})
+
+		model := IndexModel{Keys: bson.NewDocument(bson.EC.Int32("a", 1))}
+		requireSame(t, "createIndexes", func() error {
+			_, err := iv.CreateOne(ctx, model, indexopt.MaxTime(time.Second))
+			return err
+		}, func() error {
+			_, err := iv.CreateOne(ctx, model, options.CreateIndexes().SetMaxTime(time.Second))
+			return err
+		})
+
+		requireSame(t, "dropIndexes", func() error {
+			_, err := iv.DropAll(ctx, indexopt.MaxTime(time.Second))
+			return err
+		}, func() error {
+			_, err := iv.DropAll(ctx, options.DropIndexes().SetMaxTime(time.Second))
+			return err
+		})
+	})
+}
@@ -0,0 +1,343 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package otelmongo emits the driver's spans and metrics with OpenTelemetry instead of OpenCensus.
+//
+// The driver uses OpenCensus by default. To use OpenTelemetry, set the backend returned by New
+// before creating any client:
+//
+//	mongo.SetInstrumentationBackend(otelmongo.New())
+//
+// Spans follow the OpenTelemetry semantic conventions for database clients. The driver's
+// OpenCensus measures are exported as instruments of the same name: measures with a last value view
+// become gauges, measures with a distribution view become histograms and the rest become counters.
+// The tags the driver applies, such as method and database, become attributes.
+//
+// This package lives apart from the driver so that programs that do not use it do not depend on
+// OpenTelemetry.
+package otelmongo
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/mongodb/mongo-go-driver/mongo"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	octag "go.opencensus.io/tag"
+	octrace "go.opencensus.io/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the driver to tracer and meter providers.
+const instrumentationName = "github.com/mongodb/mongo-go-driver"
+
+// Option configures the backend returned by New.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the tracer provider used to start spans. The global tracer provider is
+// used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = tp }
+}
+
+// WithMeterProvider sets the meter provider used to create instruments. The global meter provider
+// is used by default.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.meterProvider = mp }
+}
+
+// New returns a backend that emits spans and metrics with OpenTelemetry.
+func New(opts ...Option) mongo.InstrumentationBackend {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &backend{
+		tracer:      cfg.tracerProvider.Tracer(instrumentationName),
+		meter:       cfg.meterProvider.Meter(instrumentationName),
+		aggregation: aggregations(),
+		instruments: make(map[string]instrument),
+	}
+}
+
+type backend struct {
+	tracer      trace.Tracer
+	meter       metric.Meter
+	aggregation map[string]view.AggType
+
+	mu          sync.Mutex
+	instruments map[string]instrument
+}
+
+// StartSpan implements the mongo.InstrumentationBackend interface.
+func (b *backend) StartSpan(ctx context.Context, name string, sampler octrace.Sampler) (context.Context, mongo.InstrumentationSpan) {
+	if sampler != nil {
+		parent := trace.SpanContextFromContext(ctx)
+		decision := sampler(octrace.SamplingParameters{
+			ParentContext:   octrace.SpanContext{TraceID: octrace.TraceID(parent.TraceID()), SpanID: octrace.SpanID(parent.SpanID())},
+			TraceID:         octrace.TraceID(parent.TraceID()),
+			Name:            name,
+			HasRemoteParent: parent.IsRemote(),
+		})
+		if !decision.Sample {
+			return ctx, (*octrace.Span)(nil)
+		}
+	}
+
+	attrs := []attribute.KeyValue{semconv.DBSystemMongoDB}
+	kind := trace.SpanKindInternal
+	if m := octag.FromContext(ctx); m != nil {
+		if db, ok := m.Value(observability.KeyDatabase); ok {
+			attrs = append(attrs, semconv.DBName(db))
+		}
+		if coll, ok := m.Value(observability.KeyCollection); ok {
+			attrs = append(attrs, semconv.DBMongoDBCollection(coll))
+		}
+		if cmd, ok := m.Value(observability.KeyCommandName); ok {
+			attrs = append(attrs, semconv.DBOperation(cmd))
+			kind = trace.SpanKindClient
+		}
+		if addr, ok := m.Value(observability.KeyServerAddress); ok {
+			attrs = append(attrs, peerAttributes(addr)...)
+		}
+	}
+
+	ctx, span := b.tracer.Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+	return ctx, otelSpan{span}
+}
+
+// peerAttributes returns the net.peer.name and net.peer.port attributes for a server address.
+func peerAttributes(addr string) []attribute.KeyValue {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return []attribute.KeyValue{semconv.NetPeerName(addr)}
+	}
+
+	attrs := []attribute.KeyValue{semconv.NetPeerName(host)}
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, semconv.NetPeerPort(p))
+	}
+	return attrs
+}
+
+// Record implements the mongo.InstrumentationBackend interface.
+func (b *backend) Record(ctx context.Context, ms ...stats.Measurement) {
+	attrs := attribute.NewSet(tagAttributes(ctx)...)
+	for _, m := range ms {
+		inst, err := b.instrument(m.Measure())
+		if err != nil {
+			otel.Handle(err)
+			continue
+		}
+		inst.record(ctx, m.Value(), attrs)
+	}
+}
+
+// tagAttributes returns the OpenCensus tags of ctx as attributes.
+func tagAttributes(ctx context.Context) []attribute.KeyValue {
+	m := octag.FromContext(ctx)
+	if m == nil {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	for _, key := range tagKeys {
+		if v, ok := m.Value(key); ok {
+			attrs = append(attrs, attribute.String(key.Name(), v))
+		}
+	}
+	return attrs
+}
+
+// tagKeys are the tag keys the driver applies.
+var tagKeys = []octag.Key{
+	observability.KeyMethod,
+	observability.KeyPart,
+	observability.KeyDatabase,
+	observability.KeyCollection,
+	observability.KeyCommandName,
+	observability.KeyErrorCode,
+	observability.KeyErrorCategory,
+	observability.KeyServerAddress,
+	observability.KeyTopologyID,
+}
+
+// aggregations returns the aggregation of every measure with a view in observability.AllViews. A
+// measure with several views is exported with the most informative one.
+func aggregations() map[string]view.AggType {
+	rank := map[view.AggType]int{view.AggTypeCount: 1, view.AggTypeSum: 2, view.AggTypeDistribution: 3, view.AggTypeLastValue: 4}
+
+	aggs := make(map[string]view.AggType)
+	for _, v := range observability.AllViews {
+		name, agg := v.Measure.Name(), v.Aggregation.Type
+		if rank[agg] > rank[aggs[name]] {
+			aggs[name] = agg
+		}
+	}
+	return aggs
+}
+
+// instrument records the measurements of one measure.
+type instrument interface {
+	record(ctx context.Context, value float64, attrs attribute.Set)
+}
+
+func (b *backend) instrument(m stats.Measure) (instrument, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if inst, ok := b.instruments[m.Name()]; ok {
+		return inst, nil
+	}
+
+	var inst instrument
+	var err error
+	switch b.aggregation[m.Name()] {
+	case view.AggTypeLastValue:
+		inst, err = newGauge(b.meter, m)
+	case view.AggTypeDistribution:
+		var h metric.Float64Histogram
+		h, err = b.meter.Float64Histogram(m.Name(), metric.WithDescription(m.Description()), metric.WithUnit(m.Unit()))
+		inst = histogram{h}
+	case view.AggTypeCount:
+		var c metric.Int64Counter
+		c, err = b.meter.Int64Counter(m.Name(), metric.WithDescription(m.Description()), metric.WithUnit(m.Unit()))
+		inst = counter{c}
+	default:
+		var c metric.Float64Counter
+		c, err = b.meter.Float64Counter(m.Name(), metric.WithDescription(m.Description()), metric.WithUnit(m.Unit()))
+		inst = sum{c}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating instrument for %s: %v", m.Name(), err)
+	}
+
+	b.instruments[m.Name()] = inst
+	return inst, nil
+}
+
+// counter counts measurements, like view.Count.
+type counter struct{ c metric.Int64Counter }
+
+func (c counter) record(ctx context.Context, _ float64, attrs attribute.Set) {
+	c.c.Add(ctx, 1, metric.WithAttributeSet(attrs))
+}
+
+// sum adds up measurements, like view.Sum.
+type sum struct{ c metric.Float64Counter }
+
+func (s sum) record(ctx context.Context, value float64, attrs attribute.Set) {
+	s.c.Add(ctx, value, metric.WithAttributeSet(attrs))
+}
+
+type histogram struct{ h metric.Float64Histogram }
+
+func (h histogram) record(ctx context.Context, value float64, attrs attribute.Set) {
+	h.h.Record(ctx, value, metric.WithAttributeSet(attrs))
+}
+
+// gauge reports the last value recorded for each attribute set, like view.LastValue.
+type gauge struct {
+	mu   sync.Mutex
+	last map[attribute.Distinct]observation
+}
+
+type observation struct {
+	value int64
+	attrs attribute.Set
+}
+
+func newGauge(meter metric.Meter, m stats.Measure) (*gauge, error) {
+	g := &gauge{last: make(map[attribute.Distinct]observation)}
+	_, err := meter.Int64ObservableGauge(m.Name(),
+		metric.WithDescription(m.Description()),
+		metric.WithUnit(m.Unit()),
+		metric.WithInt64Callback(g.observe),
+	)
+	return g, err
+}
+
+func (g *gauge) record(_ context.Context, value float64, attrs attribute.Set) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.last[attrs.Equivalent()] = observation{value: int64(value), attrs: attrs}
+}
+
+func (g *gauge) observe(_ context.Context, o metric.Int64Observer) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, obs := range g.last {
+		o.Observe(obs.value, metric.WithAttributeSet(obs.attrs))
+	}
+	return nil
+}
+
+// otelSpan adapts an OpenTelemetry span to the mongo.InstrumentationSpan interface.
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s otelSpan) End() {
+	s.span.End()
+}
+
+func (s otelSpan) SetStatus(status octrace.Status) {
+	if status.Code == octrace.StatusCodeOK {
+		s.span.SetStatus(codes.Ok, "")
+		return
+	}
+	s.span.SetStatus(codes.Error, status.Message)
+}
+
+func (s otelSpan) AddAttributes(attributes ...octrace.Attribute) {
+	s.span.SetAttributes(convertAttributes(attributes)...)
+}
+
+func (s otelSpan) Annotate(attributes []octrace.Attribute, str string) {
+	s.span.AddEvent(str, trace.WithAttributes(convertAttributes(attributes)...))
+}
+
+func (s otelSpan) Annotatef(attributes []octrace.Attribute, format string, a ...interface{}) {
+	s.Annotate(attributes, fmt.Sprintf(format, a...))
+}
+
+func convertAttributes(attributes []octrace.Attribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attributes))
+	for _, a := range attributes {
+		switch v := a.Value().(type) {
+		case bool:
+			kvs = append(kvs, attribute.Bool(a.Key(), v))
+		case int64:
+			kvs = append(kvs, attribute.Int64(a.Key(), v))
+		case float64:
+			kvs = append(kvs, attribute.Float64(a.Key(), v))
+		case string:
+			kvs = append(kvs, attribute.String(a.Key(), v))
+		default:
+			kvs = append(kvs, attribute.String(a.Key(), fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
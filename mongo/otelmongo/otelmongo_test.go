@@ -0,0 +1,143 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package otelmongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/mongodb/mongo-go-driver/mongo"
+	"github.com/stretchr/testify/require"
+	octag "go.opencensus.io/tag"
+	octrace "go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setup(t *testing.T, opts observability.Options) (*tracetest.SpanRecorder, sdkmetric.Reader) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	mongo.SetInstrumentationBackend(New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	))
+	observability.Configure(opts)
+	t.Cleanup(func() {
+		observability.Configure(observability.Options{})
+		mongo.SetInstrumentationBackend(nil)
+	})
+
+	return spans, reader
+}
+
+func attributes(kvs []attribute.KeyValue) map[string]interface{} {
+	m := make(map[string]interface{}, len(kvs))
+	for _, kv := range kvs {
+		m[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	return m
+}
+
+func TestSpans(t *testing.T) {
+	spans, _ := setup(t, observability.Options{})
+
+	ctx := observability.TagNamespace(context.Background(), "db", "users", "find")
+	ctx, op := observability.StartOperation(ctx, "find", "mongo-go/core/dispatch.Find")
+	ctx = observability.Tag(ctx, octag.Upsert(observability.KeyServerAddress, "localhost:27017"))
+	_, span := observability.StartSpan(ctx, "mongo-go/core/connection.(*pool).wait")
+	span.AddAttributes(octrace.BoolAttribute("waited", true))
+	span.Annotate([]octrace.Attribute{octrace.Int64Attribute("documents", 3)}, "Received batch")
+	span.End()
+	op.End(errors.New("boom"))
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+
+	wait, find := ended[0], ended[1]
+	require.Equal(t, find.SpanContext().SpanID(), wait.Parent().SpanID())
+
+	require.Equal(t, trace.SpanKindClient, find.SpanKind())
+	require.Equal(t, map[string]interface{}{
+		"db.system":             "mongodb",
+		"db.name":               "db",
+		"db.mongodb.collection": "users",
+		"db.operation":          "find",
+	}, attributes(find.Attributes()))
+	require.Equal(t, codes.Error, find.Status().Code)
+	require.Equal(t, "boom", find.Status().Description)
+
+	attrs := attributes(wait.Attributes())
+	require.Equal(t, "localhost", attrs["net.peer.name"])
+	require.Equal(t, int64(27017), attrs["net.peer.port"])
+	require.Equal(t, true, attrs["waited"])
+	require.Len(t, wait.Events(), 1)
+	require.Equal(t, "Received batch", wait.Events()[0].Name)
+	require.Equal(t, int64(3), attributes(wait.Events()[0].Attributes)["documents"])
+}
+
+func TestSampler(t *testing.T) {
+	spans, _ := setup(t, observability.Options{
+		TraceSampler: func(name string) octrace.Sampler {
+			if name == "sampled" {
+				return octrace.AlwaysSample()
+			}
+			return octrace.NeverSample()
+		},
+	})
+
+	for _, name := range []string{"sampled", "dropped"} {
+		_, span := observability.StartSpan(context.Background(), name)
+		span.End()
+	}
+
+	ended := spans.Ended()
+	require.Len(t, ended, 1)
+	require.Equal(t, "sampled", ended[0].Name())
+}
+
+func TestMetrics(t *testing.T) {
+	_, reader := setup(t, observability.Options{})
+
+	ctx, op := observability.StartOperation(context.Background(), "insert_one", "mongo-go/core/dispatch.Insert")
+	op.End(nil)
+
+	ctx = observability.Tag(context.Background(), octag.Upsert(observability.KeyServerAddress, "localhost:27017"))
+	g := observability.NewGauge(observability.MConnectionsOpen)
+	g.Add(ctx, 2)
+	g.Add(ctx, -1)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+
+	calls, ok := metrics[observability.MCalls.Name()].(metricdata.Sum[int64])
+	require.True(t, ok, "expected calls to be an int64 counter")
+	require.Len(t, calls.DataPoints, 1)
+	require.Equal(t, int64(1), calls.DataPoints[0].Value)
+	method, _ := calls.DataPoints[0].Attributes.Value(attribute.Key("method"))
+	require.Equal(t, "insert_one", method.AsString())
+
+	_, ok = metrics[observability.MRoundTripLatencyMilliseconds.Name()].(metricdata.Histogram[float64])
+	require.True(t, ok, "expected roundtrip latency to be a histogram")
+
+	open, ok := metrics[observability.MConnectionsOpen.Name()].(metricdata.Gauge[int64])
+	require.True(t, ok, "expected open connections to be a gauge")
+	require.Len(t, open.DataPoints, 1)
+	require.Equal(t, int64(1), open.DataPoints[0].Value)
+}
@@ -0,0 +1,272 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/mongodb/mongo-go-driver/mongo/aggregateopt"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+)
+
+// ErrInvalidNumCursors is returned by ParallelFind when it is asked for less than one cursor.
+var ErrInvalidNumCursors = errors.New("mongo: the number of cursors must be positive")
+
+// ParallelFind splits the documents matching filter into up to numCursors consecutive ranges of
+// _id and returns a cursor for each range, so that a collection can be exported by several
+// goroutines at once. Each cursor finds {$and: [filter, {_id: <range>}]} with a hint of the _id
+// index, followed by opts, so options such as Skip and Limit apply to each cursor separately.
+//
+// When the _ids are ObjectIDs, the ranges are bounded by the first _ids at or after ObjectIDs
+// evenly spaced between the smallest and largest _id, so they follow the times documents were
+// inserted at. Other _ids are split into ranges of about the same number of documents with a
+// $bucketAuto aggregation. Fewer cursors are returned when there are too few distinct _ids, and a
+// single cursor without a range when the smallest and largest _id are of different types, because
+// a range of _id only matches values of the type of its bounds.
+//
+// The queries that choose the ranges run in the session passed in opts, if any. When opening a
+// cursor fails, the cursors opened before it are closed.
+func (coll *Collection) ParallelFind(ctx context.Context, numCursors int, filter interface{},
+	opts ...findopt.Find) ([]Cursor, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if numCursors < 1 {
+		return nil, ErrInvalidNumCursors
+	}
+
+	f, err := transformDocument(coll.registry, "filter", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var sess *Session
+	for _, opt := range opts {
+		if s, ok := opt.(*Session); ok {
+			sess = s
+		}
+	}
+
+	var bounds []*bson.Value
+	if numCursors > 1 {
+		bounds, err = coll.idBoundaries(ctx, sess, f, numCursors)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	findOpts := append([]findopt.Find{findopt.Hint(idIndex())}, opts...)
+	filters := rangeFilters(f, bounds)
+	cursors := make([]Cursor, 0, len(filters))
+	for _, rf := range filters {
+		cur, err := coll.Find(ctx, rf, findOpts...)
+		if err != nil {
+			for _, opened := range cursors {
+				_ = opened.Close(ctx)
+			}
+			return nil, err
+		}
+		cursors = append(cursors, cur)
+	}
+	return cursors, nil
+}
+
+func idIndex() *bson.Document {
+	return bson.NewDocument(bson.EC.Int32("_id", 1))
+}
+
+// idBoundaries returns the increasing _ids that split the documents matching f into at most n
+// ranges, or none if they cannot be split.
+func (coll *Collection) idBoundaries(ctx context.Context, sess *Session, f *bson.Document, n int) ([]*bson.Value, error) {
+	first, err := coll.idAfter(ctx, sess, f, nil, 1)
+	if err == ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	last, err := coll.idAfter(ctx, sess, f, nil, -1)
+	if err == ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if first.Type() != last.Type() {
+		return nil, nil
+	}
+	if first.Type() != bson.TypeObjectID {
+		return coll.bucketBoundaries(ctx, sess, f, n)
+	}
+
+	return objectIDBoundaries(first.ObjectID(), last.ObjectID(), n, func(target objectid.ObjectID) (*bson.Value, error) {
+		return coll.idAfter(ctx, sess, f, bson.VC.ObjectID(target), 1)
+	})
+}
+
+// idAfter returns the smallest _id of the documents matching f when direction is positive, or the
+// largest when it is negative, only considering _ids greater than or equal to min if it is set.
+func (coll *Collection) idAfter(ctx context.Context, sess *Session, f *bson.Document, min *bson.Value,
+	direction int32) (*bson.Value, error) {
+
+	filter := f
+	if min != nil {
+		filter = rangeFilter(f, bson.NewDocument(bson.EC.Interface("$gte", min)))
+	}
+
+	opts := []findopt.One{
+		findopt.Sort(bson.NewDocument(bson.EC.Int32("_id", direction))),
+		findopt.Projection(idIndex()),
+	}
+	if sess != nil {
+		opts = append(opts, sess)
+	}
+
+	rdr, err := coll.FindOne(ctx, filter, opts...).DecodeBytes()
+	if err != nil {
+		return nil, err
+	}
+	elem, err := rdr.Lookup("_id")
+	if err != nil {
+		return nil, err
+	}
+	return elem.Value(), nil
+}
+
+// bucketBoundaries returns the lower bounds of all but the first of n buckets of about the same
+// number of documents matching f, ordered by _id.
+func (coll *Collection) bucketBoundaries(ctx context.Context, sess *Session, f *bson.Document, n int) ([]*bson.Value, error) {
+	pipeline := bson.NewArray(
+		bson.VC.DocumentFromElements(bson.EC.SubDocument("$match", f)),
+		bson.VC.DocumentFromElements(bson.EC.SubDocumentFromElements("$bucketAuto",
+			bson.EC.String("groupBy", "$_id"),
+			bson.EC.Int32("buckets", int32(n)),
+		)),
+	)
+
+	opts := []aggregateopt.Aggregate{aggregateopt.AllowDiskUse(true)}
+	if sess != nil {
+		opts = append(opts, sess)
+	}
+
+	cur, err := coll.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var bounds []*bson.Value
+	for first := true; cur.Next(ctx); first = false {
+		if first {
+			continue
+		}
+
+		rdr, err := cur.DecodeBytes()
+		if err != nil {
+			return nil, err
+		}
+		// the document is read from a buffer the cursor reuses
+		elem, err := append(bson.Reader(nil), rdr...).Lookup("_id", "min")
+		if err != nil {
+			return nil, err
+		}
+		bounds = append(bounds, elem.Value())
+	}
+	return bounds, cur.Err()
+}
+
+// objectIDBoundaries returns the distinct ObjectIDs returned by next for the n-1 ObjectIDs evenly
+// spaced between min and max, leaving out min itself. next returns the smallest _id greater than
+// or equal to its argument; when the _ids are crowded together, several targets lead to the same
+// _id and fewer boundaries are returned.
+func objectIDBoundaries(min, max objectid.ObjectID, n int,
+	next func(objectid.ObjectID) (*bson.Value, error)) ([]*bson.Value, error) {
+
+	var bounds []*bson.Value
+	prev := min
+	for i := 1; i < n; i++ {
+		target := objectIDBetween(min, max, i, n)
+		if bytes.Compare(target[:], prev[:]) <= 0 {
+			continue
+		}
+
+		id, err := next(target)
+		if err == ErrNoDocuments {
+			// the documents after target were removed since max was read
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		oid, ok := id.ObjectIDOK()
+		if !ok {
+			break
+		}
+		if bytes.Compare(oid[:], prev[:]) <= 0 {
+			continue
+		}
+		bounds = append(bounds, id)
+		prev = oid
+	}
+	return bounds, nil
+}
+
+// objectIDBetween returns the ObjectID i/n of the way from min to max, treating ObjectIDs as
+// unsigned 96-bit integers.
+func objectIDBetween(min, max objectid.ObjectID, i, n int) objectid.ObjectID {
+	lo := new(big.Int).SetBytes(min[:])
+	hi := new(big.Int).SetBytes(max[:])
+
+	step := new(big.Int).Sub(hi, lo)
+	step.Mul(step, big.NewInt(int64(i)))
+	step.Quo(step, big.NewInt(int64(n)))
+	b := lo.Add(lo, step).Bytes()
+
+	var oid objectid.ObjectID
+	copy(oid[len(oid)-len(b):], b)
+	return oid
+}
+
+// rangeFilters returns the filters of the ranges of _id split by bounds, which must be increasing:
+// below the first bound, between each pair of consecutive bounds, and from the last bound on. It
+// returns f alone when there are no bounds.
+func rangeFilters(f *bson.Document, bounds []*bson.Value) []*bson.Document {
+	if len(bounds) == 0 {
+		return []*bson.Document{f}
+	}
+
+	filters := make([]*bson.Document, 0, len(bounds)+1)
+	filters = append(filters, rangeFilter(f, bson.NewDocument(bson.EC.Interface("$lt", bounds[0]))))
+	for i := 1; i < len(bounds); i++ {
+		filters = append(filters, rangeFilter(f, bson.NewDocument(
+			bson.EC.Interface("$gte", bounds[i-1]),
+			bson.EC.Interface("$lt", bounds[i]),
+		)))
+	}
+	filters = append(filters, rangeFilter(f, bson.NewDocument(bson.EC.Interface("$gte", bounds[len(bounds)-1]))))
+	return filters
+}
+
+// rangeFilter returns a filter matching the documents that match f and whose _id matches cond.
+func rangeFilter(f *bson.Document, cond *bson.Document) *bson.Document {
+	idFilter := bson.NewDocument(bson.EC.SubDocument("_id", cond))
+	if f == nil || f.Len() == 0 {
+		return idFilter
+	}
+	return bson.NewDocument(bson.EC.ArrayFromElements("$and",
+		bson.VC.Document(f.Copy()),
+		bson.VC.Document(idFilter),
+	))
+}
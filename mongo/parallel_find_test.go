@@ -0,0 +1,303 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sort"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+// objectIDAt returns an ObjectID with the given timestamp and counter.
+func objectIDAt(seconds, counter uint32) objectid.ObjectID {
+	var oid objectid.ObjectID
+	binary.BigEndian.PutUint32(oid[0:4], seconds)
+	binary.BigEndian.PutUint32(oid[8:12], counter)
+	return oid
+}
+
+// firstAtOrAfter returns the smallest of the sorted ids that is greater than or equal to target.
+func firstAtOrAfter(ids []objectid.ObjectID, target objectid.ObjectID) (*bson.Value, error) {
+	i := sort.Search(len(ids), func(i int) bool { return bytes.Compare(ids[i][:], target[:]) >= 0 })
+	if i == len(ids) {
+		return nil, ErrNoDocuments
+	}
+	return bson.VC.ObjectID(ids[i]), nil
+}
+
+func TestObjectIDBetween(t *testing.T) {
+	min := objectIDAt(100, 0)
+	max := objectIDAt(200, 0)
+
+	require.Equal(t, min, objectIDBetween(min, max, 0, 4))
+	require.Equal(t, objectIDAt(125, 0), objectIDBetween(min, max, 1, 4))
+	require.Equal(t, objectIDAt(150, 0), objectIDBetween(min, max, 2, 4))
+	require.Equal(t, max, objectIDBetween(min, max, 4, 4))
+
+	var top objectid.ObjectID
+	for i := range top {
+		top[i] = 0xff
+	}
+	mid := objectIDBetween(objectid.NilObjectID, top, 1, 2)
+	require.Equal(t, "7fffffffffffffffffffffff", mid.Hex())
+}
+
+func TestObjectIDBoundaries(t *testing.T) {
+	uniform := make([]objectid.ObjectID, 0, 1000)
+	for i := uint32(0); i < 1000; i++ {
+		uniform = append(uniform, objectIDAt(1000+i*60, i))
+	}
+	// a single old document followed by a burst inserted within the same second
+	skewed := []objectid.ObjectID{objectIDAt(1000, 0)}
+	for i := uint32(0); i < 999; i++ {
+		skewed = append(skewed, objectIDAt(5000000, i))
+	}
+	// most documents inserted early, a few spread over a long time afterwards
+	longTail := make([]objectid.ObjectID, 0, 1000)
+	for i := uint32(0); i < 990; i++ {
+		longTail = append(longTail, objectIDAt(1000, i))
+	}
+	for i := uint32(0); i < 10; i++ {
+		longTail = append(longTail, objectIDAt(2000+i*100000, 0))
+	}
+
+	testCases := []struct {
+		name     string
+		ids      []objectid.ObjectID
+		n        int
+		expected int // the expected number of ranges
+	}{
+		{"uniform", uniform, 4, 4},
+		{"skewed", skewed, 4, 2},
+		{"long tail", longTail, 8, 8},
+		{"single document", uniform[:1], 4, 1},
+		{"two documents", uniform[:2], 8, 2},
+		{"more ranges than documents", uniform[:5], 100, 5},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ids := tc.ids
+			bounds, err := objectIDBoundaries(ids[0], ids[len(ids)-1], tc.n, func(target objectid.ObjectID) (*bson.Value, error) {
+				return firstAtOrAfter(ids, target)
+			})
+			require.NoError(t, err)
+			require.Len(t, bounds, tc.expected-1)
+
+			// every id falls in exactly one of the ranges, and no range is empty
+			counts := make([]int, len(bounds)+1)
+			for _, id := range ids {
+				r := sort.Search(len(bounds), func(i int) bool {
+					b := bounds[i].ObjectID()
+					return bytes.Compare(id[:], b[:]) < 0
+				})
+				counts[r]++
+			}
+			total := 0
+			for i, c := range counts {
+				require.NotZero(t, c, "range %d is empty", i)
+				total += c
+			}
+			require.Equal(t, len(ids), total)
+		})
+	}
+
+	t.Run("documents removed concurrently", func(t *testing.T) {
+		ids := uniform[:10]
+		bounds, err := objectIDBoundaries(ids[0], uniform[500], 4, func(target objectid.ObjectID) (*bson.Value, error) {
+			return firstAtOrAfter(ids, target)
+		})
+		require.NoError(t, err)
+		require.Empty(t, bounds)
+	})
+}
+
+func TestRangeFilters(t *testing.T) {
+	bounds := []*bson.Value{bson.VC.Int32(10), bson.VC.Int32(20)}
+
+	filters := rangeFilters(bson.NewDocument(), bounds)
+	require.Len(t, filters, 3)
+	require.True(t, filters[0].Equal(bson.NewDocument(
+		bson.EC.SubDocumentFromElements("_id", bson.EC.Int32("$lt", 10)))))
+	require.True(t, filters[1].Equal(bson.NewDocument(
+		bson.EC.SubDocumentFromElements("_id", bson.EC.Int32("$gte", 10), bson.EC.Int32("$lt", 20)))))
+	require.True(t, filters[2].Equal(bson.NewDocument(
+		bson.EC.SubDocumentFromElements("_id", bson.EC.Int32("$gte", 20)))))
+
+	f := bson.NewDocument(bson.EC.String("status", "A"))
+	filters = rangeFilters(f, bounds[:1])
+	require.Len(t, filters, 2)
+	require.True(t, filters[1].Equal(bson.NewDocument(bson.EC.ArrayFromElements("$and",
+		bson.VC.DocumentFromElements(bson.EC.String("status", "A")),
+		bson.VC.DocumentFromElements(bson.EC.SubDocumentFromElements("_id", bson.EC.Int32("$gte", 10))),
+	))))
+
+	filters = rangeFilters(f, nil)
+	require.Len(t, filters, 1)
+	require.True(t, filters[0].Equal(f))
+}
+
+func TestParallelFind(t *testing.T) {
+	// rangeFinds returns the filters of the finds that opened the cursors, which are not sorted.
+	rangeFinds := func(t *testing.T, d *mongotest.Deployment) []*bson.Document {
+		var filters []*bson.Document
+		for _, c := range d.CommandsNamed("find") {
+			if _, err := c.Document.LookupErr("sort"); err == nil {
+				continue
+			}
+			require.Equal(t, int32(1), c.Document.Lookup("hint", "_id").Int32())
+			filters = append(filters, c.Document.Lookup("filter").MutableDocument())
+		}
+		return filters
+	}
+	// idDoc returns a find handler that replies {_id: id}.
+	idDoc := func(id *bson.Value) mongotest.Handler {
+		return mongotest.Cursor("db.coll", bson.NewDocument(bson.EC.Interface("_id", id)))
+	}
+
+	t.Run("object ids", func(t *testing.T) {
+		ids := make([]objectid.ObjectID, 0, 100)
+		for i := uint32(0); i < 100; i++ {
+			ids = append(ids, objectIDAt(1000+i, i))
+		}
+
+		d := mongotest.New()
+		d.Handle("find", func(cmd *mongotest.Command) mongotest.Response {
+			dir, err := cmd.Document.LookupErr("sort", "_id")
+			if err != nil {
+				return mongotest.Cursor("db.coll")(cmd)
+			}
+			if dir.Int32() < 0 {
+				return idDoc(bson.VC.ObjectID(ids[len(ids)-1]))(cmd)
+			}
+			target := ids[0]
+			if gte, err := cmd.Document.LookupErr("filter", "_id", "$gte"); err == nil {
+				target = gte.ObjectID()
+			}
+			id, err := firstAtOrAfter(ids, target)
+			require.NoError(t, err)
+			return idDoc(id)(cmd)
+		})
+		coll := newMockClient(t, d).Database("db").Collection("coll")
+
+		cursors, err := coll.ParallelFind(context.Background(), 4, nil, findopt.BatchSize(10))
+		require.NoError(t, err)
+		require.Len(t, cursors, 4)
+
+		filters := rangeFinds(t, d)
+		require.Len(t, filters, 4)
+		require.Equal(t, ids[25], filters[0].Lookup("_id", "$lt").ObjectID())
+		require.Equal(t, ids[25], filters[1].Lookup("_id", "$gte").ObjectID())
+		require.Equal(t, ids[50], filters[1].Lookup("_id", "$lt").ObjectID())
+		require.Equal(t, ids[75], filters[3].Lookup("_id", "$gte").ObjectID())
+		_, err = filters[3].LookupErr("_id", "$lt")
+		require.Error(t, err)
+	})
+	t.Run("bucket auto", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("find", func(cmd *mongotest.Command) mongotest.Response {
+			dir, err := cmd.Document.LookupErr("sort", "_id")
+			switch {
+			case err != nil:
+				return mongotest.Cursor("db.coll")(cmd)
+			case dir.Int32() < 0:
+				return idDoc(bson.VC.String("z"))(cmd)
+			default:
+				return idDoc(bson.VC.String("a"))(cmd)
+			}
+		})
+		bucket := func(min, max string) *bson.Document {
+			return bson.NewDocument(
+				bson.EC.SubDocumentFromElements("_id", bson.EC.String("min", min), bson.EC.String("max", max)),
+				bson.EC.Int32("count", 10),
+			)
+		}
+		d.Handle("aggregate", mongotest.Cursor("db.coll", bucket("a", "h"), bucket("h", "p"), bucket("p", "z")))
+		coll := newMockClient(t, d).Database("db").Collection("coll")
+
+		filter := bson.NewDocument(bson.EC.String("status", "A"))
+		cursors, err := coll.ParallelFind(context.Background(), 3, filter)
+		require.NoError(t, err)
+		require.Len(t, cursors, 3)
+
+		cmd := d.LastCommand("aggregate")
+		require.NotNil(t, cmd)
+		agg := cmd.Document
+		require.True(t, agg.Lookup("allowDiskUse").Boolean())
+		stages := agg.Lookup("pipeline").MutableArray()
+		match, err := stages.Lookup(0)
+		require.NoError(t, err)
+		require.True(t, match.MutableDocument().Lookup("$match").MutableDocument().Equal(filter))
+		buckets, err := stages.Lookup(1)
+		require.NoError(t, err)
+		require.Equal(t, int32(3), buckets.MutableDocument().Lookup("$bucketAuto", "buckets").Int32())
+
+		filters := rangeFinds(t, d)
+		require.Len(t, filters, 3)
+		for i, bounds := range [][2]string{{"", "h"}, {"h", "p"}, {"p", ""}} {
+			and := filters[i].Lookup("$and").MutableArray()
+			require.Equal(t, 2, and.Len())
+			userFilter, err := and.Lookup(0)
+			require.NoError(t, err)
+			require.True(t, userFilter.MutableDocument().Equal(filter))
+			idRange, err := and.Lookup(1)
+			require.NoError(t, err)
+			if bounds[0] != "" {
+				require.Equal(t, bounds[0], idRange.MutableDocument().Lookup("_id", "$gte").StringValue())
+			}
+			if bounds[1] != "" {
+				require.Equal(t, bounds[1], idRange.MutableDocument().Lookup("_id", "$lt").StringValue())
+			}
+		}
+	})
+	t.Run("mixed id types", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("find", func(cmd *mongotest.Command) mongotest.Response {
+			dir, err := cmd.Document.LookupErr("sort", "_id")
+			switch {
+			case err != nil:
+				return mongotest.Cursor("db.coll")(cmd)
+			case dir.Int32() < 0:
+				return idDoc(bson.VC.String("z"))(cmd)
+			default:
+				return idDoc(bson.VC.Int32(1))(cmd)
+			}
+		})
+		coll := newMockClient(t, d).Database("db").Collection("coll")
+
+		cursors, err := coll.ParallelFind(context.Background(), 4, nil)
+		require.NoError(t, err)
+		require.Len(t, cursors, 1)
+		filters := rangeFinds(t, d)
+		require.Len(t, filters, 1)
+		require.Equal(t, 0, filters[0].Len())
+	})
+	t.Run("empty collection", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("find", mongotest.Cursor("db.coll"))
+		coll := newMockClient(t, d).Database("db").Collection("coll")
+
+		cursors, err := coll.ParallelFind(context.Background(), 4, nil)
+		require.NoError(t, err)
+		require.Len(t, cursors, 1)
+	})
+	t.Run("invalid number of cursors", func(t *testing.T) {
+		d := mongotest.New()
+		coll := newMockClient(t, d).Database("db").Collection("coll")
+
+		_, err := coll.ParallelFind(context.Background(), 0, nil)
+		require.Equal(t, ErrInvalidNumCursors, err)
+		require.Empty(t, rangeFinds(t, d))
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/dispatch"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"go.opencensus.io/tag"
+)
+
+// ErrProfilingAllOnMongos is returned by SetProfilingLevel when asked to profile all operations on
+// a mongos, which can only log slow operations.
+var ErrProfilingAllOnMongos = errors.New("mongo: the profiling level of a mongos cannot be set to ProfilingAll")
+
+// ProfilingLevel is the level of the database profiler, which records operations in the
+// system.profile collection of their database.
+type ProfilingLevel int32
+
+const (
+	// ProfilingOff disables the profiler. Slow operations are still written to the server log.
+	ProfilingOff ProfilingLevel = 0
+	// ProfilingSlowOnly profiles the operations that take longer than the slowms threshold.
+	ProfilingSlowOnly ProfilingLevel = 1
+	// ProfilingAll profiles every operation.
+	ProfilingAll ProfilingLevel = 2
+)
+
+func (level ProfilingLevel) String() string {
+	switch level {
+	case ProfilingOff:
+		return "off"
+	case ProfilingSlowOnly:
+		return "slowOnly"
+	case ProfilingAll:
+		return "all"
+	}
+	return fmt.Sprintf("ProfilingLevel(%d)", int32(level))
+}
+
+// ProfilingStatus holds the settings of the database profiler of a server.
+type ProfilingStatus struct {
+	Level ProfilingLevel
+	// SlowMS is the threshold in milliseconds above which operations are considered slow.
+	SlowMS int32
+	// SampleRate is the fraction of slow operations that are profiled.
+	SampleRate float64
+}
+
+// SetProfilingLevel sets the level of the database profiler for this database and returns the
+// settings it replaced. The SlowMS and SampleRate options also change the threshold and sample
+// rate of slow operations, which are shared by all of the databases of the server. A user can
+// supply a custom context to this method, or nil to default to context.Background().
+//
+// The profiler runs on each server separately, so the command runs against the primary, or against
+// the server selected by the ServerSelector option of the database. ErrProfilingAllOnMongos is
+// returned without running the command when level is ProfilingAll and that server is a mongos.
+func (db *Database) SetProfilingLevel(ctx context.Context, level ProfilingLevel,
+	opts ...*options.ProfilingOptions) (ProfilingStatus, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "set_profiling_level"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Database).SetProfilingLevel")
+	defer span.End()
+
+	if level < ProfilingOff || level > ProfilingAll {
+		return ProfilingStatus{}, fmt.Errorf("mongo: invalid profiling level %d", int32(level))
+	}
+
+	cmd := bson.NewDocument(bson.EC.Int32("profile", int32(level)))
+	var slowMS *int32
+	var sampleRate *float64
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.SlowMS != nil {
+			slowMS = opt.SlowMS
+		}
+		if opt.SampleRate != nil {
+			sampleRate = opt.SampleRate
+		}
+	}
+	if slowMS != nil {
+		cmd.Append(bson.EC.Int32("slowms", *slowMS))
+	}
+	if sampleRate != nil {
+		if *sampleRate < 0 || *sampleRate > 1 {
+			return ProfilingStatus{}, fmt.Errorf("mongo: profiling sample rate %v is not between 0 and 1", *sampleRate)
+		}
+		cmd.Append(bson.EC.Double("sampleRate", *sampleRate))
+	}
+
+	return db.profile(ctx, span, cmd, level == ProfilingAll)
+}
+
+// GetProfilingStatus returns the settings of the database profiler for this database. A user can
+// supply a custom context to this method, or nil to default to context.Background().
+//
+// The settings are read from the primary, or from the server selected by the ServerSelector option
+// of the database.
+func (db *Database) GetProfilingStatus(ctx context.Context) (ProfilingStatus, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "get_profiling_status"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Database).GetProfilingStatus")
+	defer span.End()
+
+	return db.profile(ctx, span, bson.NewDocument(bson.EC.Int32("profile", -1)), false)
+}
+
+// profile runs the profile command cmd and returns the settings reported by its reply, which are
+// the settings from before the command ran. If rejectMongos is true, the command fails with
+// ErrProfilingAllOnMongos if the selected server is a mongos.
+func (db *Database) profile(ctx context.Context, span observability.Span, cmd *bson.Document,
+	rejectMongos bool) (ProfilingStatus, error) {
+
+	rp := readpref.Primary()
+	if db.selector != nil {
+		rp = db.readPreference
+	}
+	selector := db.writeSelector
+	if rejectMongos {
+		selector = profilingSelector(selector)
+	}
+
+	rdr, err := dispatch.Read(ctx,
+		command.Read{
+			DB:       db.Name(),
+			Command:  cmd,
+			ReadPref: rp,
+			Clock:    db.client.clock,
+		},
+		db.client.topology,
+		selector,
+		db.client.id,
+		db.client.topology.SessionPool,
+	)
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+		return ProfilingStatus{}, err
+	}
+
+	// servers that predate sampling profile every slow operation
+	reply := struct {
+		Was        int32   `bson:"was"`
+		SlowMS     int32   `bson:"slowms"`
+		SampleRate float64 `bson:"sampleRate"`
+	}{SampleRate: 1}
+	if err = bson.Unmarshal(rdr, &reply); err != nil {
+		return ProfilingStatus{}, err
+	}
+
+	return ProfilingStatus{
+		Level:      ProfilingLevel(reply.Was),
+		SlowMS:     reply.SlowMS,
+		SampleRate: reply.SampleRate,
+	}, nil
+}
+
+// profilingSelector returns a selector that selects the servers ss selects, failing with
+// ErrProfilingAllOnMongos if they are mongoses.
+func profilingSelector(ss description.ServerSelector) description.ServerSelector {
+	return description.ServerSelectorFunc(func(t description.Topology, candidates []description.Server) ([]description.Server, error) {
+		selected, err := ss.SelectServer(t, candidates)
+		if err != nil {
+			return selected, err
+		}
+
+		for _, s := range selected {
+			if s.Kind == description.Mongos {
+				return nil, ErrProfilingAllOnMongos
+			}
+		}
+		return selected, nil
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiling(t *testing.T) {
+	previous := mongotest.OK(
+		bson.EC.Int32("was", 1),
+		bson.EC.Int32("slowms", 100),
+		bson.EC.Double("sampleRate", 0.5),
+	)
+
+	t.Run("set level", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("profile", previous)
+		db := newMockClient(t, d).Database("db")
+
+		status, err := db.SetProfilingLevel(context.Background(), ProfilingAll,
+			options.Profiling().SetSlowMS(50), options.Profiling().SetSampleRate(0.25))
+		require.NoError(t, err)
+		require.Equal(t, ProfilingStatus{Level: ProfilingSlowOnly, SlowMS: 100, SampleRate: 0.5}, status)
+
+		require.Equal(t, 1, d.CountCommands("profile"))
+		cmd := d.LastCommand("profile").Document
+		require.Equal(t, int32(2), cmd.Lookup("profile").Int32())
+		require.Equal(t, int32(50), cmd.Lookup("slowms").Int32())
+		require.Equal(t, 0.25, cmd.Lookup("sampleRate").Double())
+		require.Equal(t, "db", cmd.Lookup("$db").StringValue())
+	})
+	t.Run("set level without options", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("profile", previous)
+		db := newMockClient(t, d).Database("db")
+
+		_, err := db.SetProfilingLevel(context.Background(), ProfilingOff)
+		require.NoError(t, err)
+
+		require.Equal(t, 1, d.CountCommands("profile"))
+		cmd := d.LastCommand("profile").Document
+		require.Equal(t, int32(0), cmd.Lookup("profile").Int32())
+		_, err = cmd.LookupErr("slowms")
+		require.Error(t, err)
+		_, err = cmd.LookupErr("sampleRate")
+		require.Error(t, err)
+	})
+	t.Run("get status", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("profile", mongotest.OK(bson.EC.Int32("was", 0), bson.EC.Int32("slowms", 100)))
+		db := newMockClient(t, d).Database("db")
+
+		status, err := db.GetProfilingStatus(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, ProfilingStatus{Level: ProfilingOff, SlowMS: 100, SampleRate: 1}, status)
+
+		require.Equal(t, 1, d.CountCommands("profile"))
+		cmd := d.LastCommand("profile").Document
+		require.Equal(t, int32(-1), cmd.Lookup("profile").Int32())
+	})
+	t.Run("invalid arguments", func(t *testing.T) {
+		d := mongotest.New()
+		db := newMockClient(t, d).Database("db")
+
+		_, err := db.SetProfilingLevel(context.Background(), ProfilingLevel(3))
+		require.Error(t, err)
+		_, err = db.SetProfilingLevel(context.Background(), ProfilingSlowOnly, options.Profiling().SetSampleRate(1.5))
+		require.Error(t, err)
+		require.Equal(t, 0, d.CountCommands("profile"))
+	})
+	t.Run("mongos", func(t *testing.T) {
+		d := mongotest.New(mongotest.WithMongos())
+		d.Handle("profile", previous)
+		db := newMockClient(t, d).Database("db")
+
+		_, err := db.SetProfilingLevel(context.Background(), ProfilingAll)
+		require.Equal(t, ErrProfilingAllOnMongos, err)
+		require.Equal(t, 0, d.CountCommands("profile"))
+
+		_, err = db.SetProfilingLevel(context.Background(), ProfilingOff, options.Profiling().SetSlowMS(200))
+		require.NoError(t, err)
+		require.Equal(t, 1, d.CountCommands("profile"))
+	})
+	t.Run("command error", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("profile", mongotest.Error(13, "Unauthorized", "not authorized"))
+		db := newMockClient(t, d).Database("db")
+
+		_, err := db.GetProfilingStatus(context.Background())
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,24 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/option"
+)
+
+// ProjectionOf returns a projection that includes exactly the fields a value of the struct type of v
+// is decoded from, so that reads decoding into that type fetch no other fields. The fields are named
+// by the bson struct tags of the type using the rules of the decoder: fields tagged "-" are skipped,
+// inline structs contribute their own fields, and the fields of nested structs are included with
+// dotted paths such as "address.city". The _id field is excluded unless the struct decodes it. A
+// struct with an inline map needs every field and yields an empty projection.
+//
+// The findopt.ProjectStruct option uses the same projection.
+func ProjectionOf(v interface{}) (*bson.Document, error) {
+	return option.StructProjection{Value: v}.MarshalBSONDocument()
+}
@@ -0,0 +1,131 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/mongodb/mongo-go-driver/mongo/findopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+type projAddress struct {
+	City string `bson:"city"`
+	Zip  string
+}
+
+type projAudit struct {
+	Created time.Time `bson:"created"`
+	Author  string    `bson:"author,omitempty"`
+}
+
+type projNode struct {
+	Name     string      `bson:"name"`
+	Children []*projNode `bson:"children"`
+}
+
+type projExtra struct {
+	Name  string                 `bson:"name"`
+	Extra map[string]interface{} `bson:",inline"`
+}
+
+func TestProjectionOf(t *testing.T) {
+	fields := func(elems ...*bson.Element) *bson.Document { return bson.NewDocument(elems...) }
+	in := func(key string) *bson.Element { return bson.EC.Int32(key, 1) }
+	noID := bson.EC.Int32("_id", 0)
+
+	testCases := []struct {
+		name     string
+		v        interface{}
+		expected *bson.Document
+	}{
+		{"tags and defaults", struct {
+			Name    string `bson:"name"`
+			Age     int
+			Ignored string `bson:"-"`
+			hidden  string
+		}{}, fields(in("name"), in("age"), noID)},
+		{"_id", &struct {
+			ID   objectid.ObjectID `bson:"_id"`
+			Name string            `bson:"name"`
+		}{}, fields(in("_id"), in("name"))},
+		{"nested", struct {
+			Home  projAddress    `bson:"home"`
+			Work  *projAddress   `bson:"work"`
+			Past  []projAddress  `bson:"past"`
+			Audit projAudit      `bson:"audit"`
+			When  time.Time      `bson:"when"`
+			Raw   *bson.Document `bson:"raw"`
+		}{}, fields(
+			in("home.city"), in("home.zip"),
+			in("work.city"), in("work.zip"),
+			in("past.city"), in("past.zip"),
+			in("audit.created"), in("audit.author"),
+			in("when"), in("raw"), noID,
+		)},
+		{"inline struct", struct {
+			Address projAddress `bson:",inline"`
+			Name    string      `bson:"name"`
+		}{}, fields(in("city"), in("zip"), in("name"), noID)},
+		{"recursive", projNode{}, fields(in("name"), in("children"), noID)},
+		{"nested inline map", struct {
+			Doc projExtra `bson:"doc"`
+		}{}, fields(in("doc"), noID)},
+		{"inline map", projExtra{}, fields()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := ProjectionOf(tc.v)
+			require.NoError(t, err)
+			require.True(t, tc.expected.Equal(doc), "expected %v, got %v", tc.expected, doc)
+		})
+	}
+
+	t.Run("not a struct", func(t *testing.T) {
+		_, err := ProjectionOf(map[string]interface{}{})
+		require.Error(t, err)
+		_, err = ProjectionOf(nil)
+		require.Error(t, err)
+	})
+}
+
+func TestProjectStructOption(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("find", mongotest.Cursor("db.coll"))
+	d.Handle("findAndModify", mongotest.OK(bson.EC.Null("value")))
+
+	client := newMockClient(t, d)
+	coll := client.Database("db").Collection("coll")
+
+	var doc struct {
+		Home projAddress `bson:"home"`
+	}
+	requireProjection := func(name, key string) {
+		cmd := d.LastCommand(name).Document
+		require.Equal(t, int32(1), cmd.Lookup(key, "home.city").Int32())
+		require.Equal(t, int32(1), cmd.Lookup(key, "home.zip").Int32())
+		require.Equal(t, int32(0), cmd.Lookup(key, "_id").Int32())
+	}
+
+	cur, err := coll.Find(context.Background(), nil, findopt.ProjectStruct(doc))
+	require.NoError(t, err)
+	requireProjection("find", "projection")
+	require.NoError(t, cur.Close(context.Background()))
+
+	err = coll.FindOneAndDelete(context.Background(), bson.NewDocument(), findopt.ProjectStruct(&doc)).Err()
+	require.Equal(t, ErrNoDocuments, err)
+	requireProjection("findAndModify", "fields")
+
+	_, err = coll.Find(context.Background(), nil, findopt.ProjectStruct(42))
+	require.Error(t, err)
+}
@@ -0,0 +1,230 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package prommongo exports the driver's metrics to Prometheus without an OpenCensus agent.
+//
+// Register registers the driver's OpenCensus views and a collector that reads them with a
+// Prometheus registry. Handler does the same with a registry of its own and returns the handler to
+// serve it, which is all a program that only wants a metrics endpoint needs:
+//
+//	h, err := prommongo.Handler()
+//	if err != nil {
+//		return err
+//	}
+//	http.Handle("/metrics", h)
+//
+// Each view in the driver's set of views becomes one metric family named after the view, with
+// the mongo/client/ prefix replaced by the namespace, which is mongo_go by default. Views counting
+// measurements become counters with a _total suffix, distributions become histograms, with an _ms
+// suffix for latencies, and last value views become gauges. For example, mongo/client/calls is
+// exported as mongo_go_calls_total and mongo/client/roundtrip_latency as
+// mongo_go_roundtrip_latency_ms. The tag keys of a view, such as method and database, become its
+// labels.
+//
+// The views are registered with OpenCensus as any other program would register them, so programs
+// that also use OpenCensus, or register the driver's views themselves, can use this package too.
+//
+// This package lives apart from the driver so that programs that do not use it do not depend on
+// Prometheus.
+package prommongo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+	"go.opencensus.io/stats/view"
+)
+
+// viewPrefix is the prefix of the names of the driver's views, which the namespace replaces.
+const viewPrefix = "mongo/client/"
+
+// Option configures the collector registered by Register and Handler.
+type Option func(*config)
+
+type config struct {
+	namespace string
+}
+
+// WithNamespace sets the prefix of the metric names, which is mongo_go by default. An empty
+// namespace exports the metrics without a prefix.
+func WithNamespace(namespace string) Option {
+	return func(c *config) { c.namespace = namespace }
+}
+
+// Register registers the driver's views with OpenCensus and a collector exporting them with reg.
+// It returns an error if a different view with the name of one of the driver's views is
+// registered with OpenCensus, or if reg rejects the collector, e.g. because the driver's metrics
+// are already registered with it.
+func Register(reg prometheus.Registerer, opts ...Option) error {
+	if err := observability.RegisterAllViews(); err != nil {
+		return err
+	}
+
+	return reg.Register(newCollector(opts...))
+}
+
+// Handler returns a handler serving the driver's metrics, and only those, in the Prometheus
+// exposition format. Programs exporting other metrics as well should Register the driver's metrics
+// with their own registry instead.
+func Handler(opts ...Option) (http.Handler, error) {
+	reg := prometheus.NewRegistry()
+	if err := Register(reg, opts...); err != nil {
+		return nil, err
+	}
+
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{}), nil
+}
+
+// collector collects the data of the driver's views from the OpenCensus metric producers.
+type collector struct {
+	families map[string]*family
+}
+
+// family is the metric family of one view.
+type family struct {
+	desc   *prometheus.Desc
+	labels []string
+}
+
+func newCollector(opts ...Option) *collector {
+	cfg := config{namespace: "mongo_go"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	c := &collector{families: make(map[string]*family, len(observability.AllViews))}
+	for _, v := range observability.AllViews {
+		labels := make([]string, 0, len(v.TagKeys))
+		for _, k := range v.TagKeys {
+			labels = append(labels, sanitize(k.Name()))
+		}
+
+		name := metricName(cfg.namespace, v)
+		c.families[v.Name] = &family{
+			desc:   prometheus.NewDesc(name, v.Description, labels, nil),
+			labels: labels,
+		}
+	}
+	return c
+}
+
+// metricName returns the name of the metric family of v.
+func metricName(namespace string, v *view.View) string {
+	name := sanitize(strings.TrimPrefix(v.Name, viewPrefix))
+	if namespace != "" {
+		name = namespace + "_" + name
+	}
+
+	switch v.Aggregation.Type {
+	case view.AggTypeCount, view.AggTypeSum:
+		name += "_total"
+	case view.AggTypeDistribution:
+		if v.Measure.Unit() == "ms" && !strings.HasSuffix(name, "_ms") {
+			name += "_ms"
+		}
+	}
+	return name
+}
+
+// sanitize returns s with the characters Prometheus does not allow in metric and label names
+// replaced by underscores.
+func sanitize(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, s)
+
+	if s != "" && s[0] >= '0' && s[0] <= '9' {
+		s = "_" + s
+	}
+	return s
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, f := range c.families {
+		ch <- f.desc
+	}
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	for _, p := range metricproducer.GlobalManager().GetAll() {
+		for _, m := range p.Read() {
+			f, ok := c.families[m.Descriptor.Name]
+			if !ok {
+				continue
+			}
+			for _, ts := range m.TimeSeries {
+				if len(ts.Points) == 0 {
+					continue
+				}
+				ch <- f.metric(m, ts)
+			}
+		}
+	}
+}
+
+// metric returns the Prometheus metric of the last point of ts.
+func (f *family) metric(m *metricdata.Metric, ts *metricdata.TimeSeries) prometheus.Metric {
+	values := make([]string, len(f.labels))
+	for i, k := range m.Descriptor.LabelKeys {
+		for j, label := range f.labels {
+			if label == sanitize(k.Key) && i < len(ts.LabelValues) {
+				values[j] = ts.LabelValues[i].Value
+			}
+		}
+	}
+
+	valueType := prometheus.GaugeValue
+	switch m.Descriptor.Type {
+	case metricdata.TypeCumulativeInt64, metricdata.TypeCumulativeFloat64:
+		valueType = prometheus.CounterValue
+	}
+
+	var metric prometheus.Metric
+	var err error
+	switch v := ts.Points[len(ts.Points)-1].Value.(type) {
+	case int64:
+		metric, err = prometheus.NewConstMetric(f.desc, valueType, float64(v), values...)
+	case float64:
+		metric, err = prometheus.NewConstMetric(f.desc, valueType, v, values...)
+	case *metricdata.Distribution:
+		metric, err = histogram(f.desc, v, values)
+	default:
+		err = fmt.Errorf("unsupported point value %T", v)
+	}
+	if err != nil {
+		return prometheus.NewInvalidMetric(f.desc, err)
+	}
+	return metric
+}
+
+// histogram returns the Prometheus histogram of d. OpenCensus counts the values in each bucket,
+// while Prometheus counts the values up to the upper bound of each bucket.
+func histogram(desc *prometheus.Desc, d *metricdata.Distribution, values []string) (prometheus.Metric, error) {
+	if d.BucketOptions == nil {
+		return prometheus.NewConstHistogram(desc, uint64(d.Count), d.Sum, nil, values...)
+	}
+
+	buckets := make(map[float64]uint64, len(d.BucketOptions.Bounds))
+	var cumulative uint64
+	for i, bound := range d.BucketOptions.Bounds {
+		if i < len(d.Buckets) {
+			cumulative += uint64(d.Buckets[i].Count)
+		}
+		buckets[bound] = cumulative
+	}
+	return prometheus.NewConstHistogram(desc, uint64(d.Count), d.Sum, buckets, values...)
+}
@@ -0,0 +1,152 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package prommongo
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+)
+
+// eventually fails t unless cond returns true within a second. Measurements are recorded
+// asynchronously, so they are not immediately collected.
+func eventually(t *testing.T, cond func() bool, msgAndArgs ...interface{}) {
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			require.FailNow(t, "condition not met in time", msgAndArgs...)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// gather returns the metric with the given name and labels once it is collected.
+func gather(t *testing.T, g prometheus.Gatherer, name string, labels map[string]string) *dto.Metric {
+	var found *dto.Metric
+	eventually(t, func() bool {
+		families, err := g.Gather()
+		require.NoError(t, err)
+		for _, mf := range families {
+			if mf.GetName() != name {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				if hasLabels(m, labels) {
+					found = m
+					return true
+				}
+			}
+		}
+		return false
+	}, "no %s metric with labels %v", name, labels)
+	return found
+}
+
+func hasLabels(m *dto.Metric, labels map[string]string) bool {
+	for _, lp := range m.GetLabel() {
+		if v, ok := labels[lp.GetName()]; ok && v != lp.GetValue() {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRegister(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	require.NoError(t, Register(reg))
+	defer observability.UnregisterAllViews()
+
+	ctx := observability.TagNamespace(context.Background(), "app", "users", "find")
+	stats.Record(ctx, observability.MCalls.M(1), observability.MCalls.M(1))
+	stats.Record(ctx, observability.MRoundTripLatencyMilliseconds.M(0.3), observability.MRoundTripLatencyMilliseconds.M(7))
+	stats.Record(context.Background(), observability.MSessionsActive.M(3))
+
+	labels := map[string]string{"database": "app", "collection": "users", "command_name": "find", "method": ""}
+
+	t.Run("counter", func(t *testing.T) {
+		m := gather(t, reg, "mongo_go_calls_total", labels)
+		require.Equal(t, 2.0, m.GetCounter().GetValue())
+		require.Len(t, m.GetLabel(), 4)
+	})
+	t.Run("histogram", func(t *testing.T) {
+		m := gather(t, reg, "mongo_go_roundtrip_latency_ms", labels)
+		h := m.GetHistogram()
+		require.Equal(t, uint64(2), h.GetSampleCount())
+		require.InDelta(t, 7.3, h.GetSampleSum(), 1e-9)
+
+		cumulative := make(map[float64]uint64)
+		for _, b := range h.GetBucket() {
+			cumulative[b.GetUpperBound()] = b.GetCumulativeCount()
+		}
+		require.Equal(t, uint64(0), cumulative[0.25])
+		require.Equal(t, uint64(1), cumulative[0.5])
+		require.Equal(t, uint64(1), cumulative[6])
+		require.Equal(t, uint64(2), cumulative[8])
+	})
+	t.Run("gauge", func(t *testing.T) {
+		m := gather(t, reg, "mongo_go_sessions_active", nil)
+		require.Equal(t, 3.0, m.GetGauge().GetValue())
+	})
+	t.Run("registered twice", func(t *testing.T) {
+		err := Register(reg)
+		_, ok := err.(prometheus.AlreadyRegisteredError)
+		require.True(t, ok, "expected an AlreadyRegisteredError, got %v", err)
+
+		// the views are shared with other registries and OpenCensus exporters
+		other := prometheus.NewRegistry()
+		require.NoError(t, Register(other, WithNamespace("db")))
+		gather(t, other, "db_calls_total", labels)
+	})
+	t.Run("conflicting view", func(t *testing.T) {
+		observability.UnregisterAllViews()
+		v := &view.View{Name: "mongo/client/calls", Measure: observability.MCalls, Aggregation: view.Sum()}
+		require.NoError(t, view.Register(v))
+		defer view.Unregister(v)
+
+		require.Error(t, Register(prometheus.NewRegistry()))
+	})
+}
+
+func TestHandler(t *testing.T) {
+	h, err := Handler()
+	require.NoError(t, err)
+	defer observability.UnregisterAllViews()
+
+	stats.Record(context.Background(), observability.MConnectionsNew.M(1))
+
+	eventually(t, func() bool {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		body, err := ioutil.ReadAll(rec.Body)
+		require.NoError(t, err)
+		return strings.Contains(string(body), "mongo_go_connections_new_total 1")
+	})
+}
+
+func TestMetricName(t *testing.T) {
+	names := make(map[string]bool)
+	for _, v := range observability.AllViews {
+		name := metricName("mongo_go", v)
+		require.Equal(t, name, sanitize(name), "%s is exported as an invalid name", v.Name)
+		require.False(t, names[name], "%s is exported under a name already used", v.Name)
+		names[name] = true
+	}
+	require.True(t, names["mongo_go_bytes_read"])
+	require.True(t, names["mongo_go_bytes_read_count_total"])
+	require.True(t, names["mongo_go_connections_open"])
+	require.Equal(t, "_1x", sanitize("1x"))
+}
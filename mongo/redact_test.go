@@ -0,0 +1,65 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/event"
+	"github.com/mongodb/mongo-go-driver/mongo/clientopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactDocumentValues(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("find", mongotest.Cursor("db.coll", bson.NewDocument(bson.EC.String("ssn", "078-05-1120"))))
+	d.Handle("insert", mongotest.Error(11000, "DuplicateKey", "duplicate key: 078-05-1120"))
+
+	var mu sync.Mutex
+	var started, succeeded []*bson.Document
+	var failures []string
+	monitor := &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			started = append(started, e.Command)
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			succeeded = append(succeeded, e.Reply)
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			failures = append(failures, e.Failure)
+		},
+	}
+
+	client := newMockClient(t, d, clientopt.Monitor(monitor), clientopt.RedactDocumentValues(true))
+
+	coll := client.Database("db").Collection("coll")
+	cur, err := coll.Find(context.Background(), bson.NewDocument(bson.EC.String("ssn", "078-05-1120")))
+	require.NoError(t, err)
+	require.NoError(t, cur.Close(context.Background()))
+	_, err = coll.InsertOne(context.Background(), bson.NewDocument(bson.EC.String("ssn", "078-05-1120")))
+	require.Error(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, started)
+	for _, doc := range append(started, succeeded...) {
+		require.NotContains(t, doc.ToExtJSON(false), "078-05-1120")
+	}
+	require.Equal(t, "coll", started[0].Lookup("find").StringValue())
+	require.Equal(t, event.RedactedValue, started[0].Lookup("filter", "ssn").StringValue())
+	require.Equal(t, []string{"Error code 11000: " + event.RedactedValue}, failures)
+}
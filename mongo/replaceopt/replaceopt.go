@@ -81,6 +81,26 @@ func (rb *ReplaceBundle) Collation(c *mongoopt.Collation) *ReplaceBundle {
 	return bundle
 }
 
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (rb *ReplaceBundle) Comment(comment interface{}) *ReplaceBundle {
+	bundle := &ReplaceBundle{
+		option: Comment(comment),
+		next:   rb,
+	}
+
+	return bundle
+}
+
+// Let adds an option to specify a document of variables that can be accessed in the filter.
+func (rb *ReplaceBundle) Let(let interface{}) *ReplaceBundle {
+	bundle := &ReplaceBundle{
+		option: Let(let),
+		next:   rb,
+	}
+
+	return bundle
+}
+
 // Upsert adds an option to specify whether to insert a new document if it does not exist
 func (rb *ReplaceBundle) Upsert(b bool) *ReplaceBundle {
 	bundle := &ReplaceBundle{
@@ -231,6 +251,18 @@ func Collation(c *mongoopt.Collation) OptCollation {
 	return OptCollation{Collation: c.Convert()}
 }
 
+// Comment specifies a value of any BSON type to help trace the operation through the database
+// profiler, currentOp, and logs. It requires server version 4.4 or newer.
+func Comment(comment interface{}) OptComment {
+	return OptComment{Comment: comment}
+}
+
+// Let specifies a document of variables that can be accessed in the filter using $$var. It is set on
+// the update command rather than on each statement and requires server version 5.0 or newer.
+func Let(let interface{}) OptLet {
+	return OptLet{let}
+}
+
 // Upsert specifies whether to insert a new document if it does not exist
 func Upsert(b bool) OptUpsert {
 	return OptUpsert(b)
@@ -256,6 +288,26 @@ func (opt OptCollation) ConvertReplaceOption() option.ReplaceOptioner {
 	return option.OptCollation(opt)
 }
 
+// OptComment specifies a value to help trace the operation through the database profiler, currentOp, and logs.
+type OptComment option.OptComment
+
+func (OptComment) replace() {}
+
+// ConvertReplaceOption implements the Replace interface.
+func (opt OptComment) ConvertReplaceOption() option.ReplaceOptioner {
+	return option.OptComment(opt)
+}
+
+// OptLet specifies a document of variables that can be accessed in the filter.
+type OptLet option.OptLet
+
+func (OptLet) replace() {}
+
+// ConvertReplaceOption implements the Replace interface
+func (opt OptLet) ConvertReplaceOption() option.ReplaceOptioner {
+	return option.OptLet(opt)
+}
+
 // OptUpsert specifies whether to insert a new document if it does not exist
 type OptUpsert option.OptUpsert
 
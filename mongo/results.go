@@ -53,6 +53,41 @@ func (ldr ListDatabasesResult) fromResult(res result.ListDatabases) ListDatabase
 	return ldr
 }
 
+// DropIndexesResult is a result of a DropOne or DropAll operation of an IndexView.
+type DropIndexesResult struct {
+	// The number of indexes the collection had before the drop.
+	NIndexesWas int32
+	// The reply of the server.
+	Raw bson.Reader
+}
+
+func (dir DropIndexesResult) fromResult(res result.DropIndexes) DropIndexesResult {
+	dir.NIndexesWas = res.NIndexesWas
+	dir.Raw = res.Raw
+	return dir
+}
+
+// CollModResult is a result of a Modify operation of a Collection. The old and new values of the
+// properties of an index are only set for the properties that were modified, and not when the new
+// value is the same as the old one.
+type CollModResult struct {
+	ExpireAfterSecondsOld *int64
+	ExpireAfterSecondsNew *int64
+	HiddenOld             *bool
+	HiddenNew             *bool
+	// The reply of the server.
+	Raw bson.Reader
+}
+
+func (cmr CollModResult) fromResult(res result.CollMod) CollModResult {
+	cmr.ExpireAfterSecondsOld = res.ExpireAfterSecondsOld
+	cmr.ExpireAfterSecondsNew = res.ExpireAfterSecondsNew
+	cmr.HiddenOld = res.HiddenOld
+	cmr.HiddenNew = res.HiddenNew
+	cmr.Raw = res.Raw
+	return cmr
+}
+
 // DatabaseSpecification is the information for a single database returned
 // from a ListDatabases operation.
 type DatabaseSpecification struct {
@@ -127,3 +162,9 @@ func (result *UpdateResult) UnmarshalBSON(b []byte) error {
 
 	return nil
 }
+
+// RewrapManyDataKeyResult is a result of a RewrapManyDataKey operation.
+type RewrapManyDataKeyResult struct {
+	// The number of data keys that were rewrapped.
+	RewrappedCount int64
+}
@@ -0,0 +1,81 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCommandCursor(t *testing.T) {
+	d := mongotest.New()
+
+	client := newMockClient(t, d)
+
+	db := client.Database("db")
+	ctx := context.Background()
+	// batch replies the collections named names in the given batch of the cursor id
+	batch := func(id int64, key string, names ...string) mongotest.Handler {
+		docs := bson.NewArray()
+		for _, name := range names {
+			docs.Append(bson.VC.DocumentFromElements(bson.EC.String("name", name)))
+		}
+		return mongotest.OK(bson.EC.SubDocumentFromElements("cursor",
+			bson.EC.Int64("id", id),
+			bson.EC.String("ns", "db.$cmd.listCollections"),
+			bson.EC.Array(key, docs),
+		))
+	}
+
+	t.Run("getMore", func(t *testing.T) {
+		d.Handle("listCollections", batch(7, "firstBatch", "a", "b"))
+		d.Handle("getMore", batch(0, "nextBatch", "c"))
+
+		cur, err := db.RunCommandCursor(ctx, bson.NewDocument(
+			bson.EC.Int32("listCollections", 1),
+			bson.EC.SubDocumentFromElements("cursor", bson.EC.Int32("batchSize", 2)),
+		))
+		require.NoError(t, err)
+
+		var names []string
+		for cur.Next(ctx) {
+			doc, err := cur.DecodeBytes()
+			require.NoError(t, err)
+			elem, err := doc.Lookup("name")
+			require.NoError(t, err)
+			names = append(names, elem.Value().StringValue())
+		}
+		require.NoError(t, cur.Err())
+		require.NoError(t, cur.Close(ctx))
+		require.Equal(t, []string{"a", "b", "c"}, names)
+
+		getMore := d.LastCommand("getMore")
+		require.NotNil(t, getMore, "expected the cursor to be continued")
+		require.Equal(t, int64(7), getMore.Document.Lookup("getMore").Int64())
+		require.Equal(t, "$cmd.listCollections", getMore.Document.Lookup("collection").StringValue())
+	})
+	t.Run("no cursor", func(t *testing.T) {
+		d.Handle("ping", mongotest.OK())
+
+		cur, err := db.RunCommandCursor(ctx, bson.NewDocument(bson.EC.Int32("ping", 1)))
+		require.Equal(t, command.ErrNoCursor, err)
+		require.Nil(t, cur)
+	})
+	t.Run("command error", func(t *testing.T) {
+		d.Handle("listCollections", mongotest.Error(13, "Unauthorized", "not authorized"))
+
+		_, err := db.RunCommandCursor(ctx, bson.NewDocument(bson.EC.Int32("listCollections", 1)))
+		cmdErr, ok := err.(command.Error)
+		require.True(t, ok, "expected a command.Error, got %v", err)
+		require.Equal(t, int32(13), cmdErr.Code)
+	})
+}
@@ -9,6 +9,7 @@ package runcmdopt
 import (
 	"reflect"
 
+	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/readpref"
 	"github.com/mongodb/mongo-go-driver/core/session"
 )
@@ -32,6 +33,7 @@ type optionFunc func(*RunCmd) error
 // RunCmd represents a run command.
 type RunCmd struct {
 	ReadPreference *readpref.ReadPref
+	ServerSelector description.ServerSelector
 }
 
 // RunCmdBundle is a bundle of RunCommand options.
@@ -67,6 +69,14 @@ func (rcb *RunCmdBundle) ReadPreference(rp *readpref.ReadPref) *RunCmdBundle {
 	}
 }
 
+// ServerSelector sets the selector used to select the server the command runs against.
+func (rcb *RunCmdBundle) ServerSelector(ss description.ServerSelector) *RunCmdBundle {
+	return &RunCmdBundle{
+		option: ServerSelector(ss),
+		next:   rcb,
+	}
+}
+
 // Unbundle unbundles the options, returning a RunCmd instance.
 func (rcb *RunCmdBundle) Unbundle() (*RunCmd, *session.Client, error) {
 	database := &RunCmd{}
@@ -139,6 +149,19 @@ func ReadPreference(rp *readpref.ReadPref) Option {
 		})
 }
 
+// ServerSelector sets the selector used to select the server the command runs against, in place of
+// the selector of the database. It can run the command against a single server, such as a secondary,
+// with mongo.HostSelector.
+func ServerSelector(ss description.ServerSelector) Option {
+	return optionFunc(
+		func(rc *RunCmd) error {
+			if rc.ServerSelector == nil {
+				rc.ServerSelector = ss
+			}
+			return nil
+		})
+}
+
 // RunCmdSessionOpt is a RunCommand session option.
 type RunCmdSessionOpt struct{}
 
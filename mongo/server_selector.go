@@ -0,0 +1,93 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+
+	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
+)
+
+// ErrWriteToNonPrimary is returned when a write operation is run against an explicitly selected
+// server that cannot accept writes, such as a secondary.
+var ErrWriteToNonPrimary = errors.New("write operations cannot run against a secondary or arbiter")
+
+// ErrOutReadPreference is returned by Aggregate when a pipeline with a $out or $merge stage, which
+// writes to a collection and so must run on the primary, is run with a read preference other than
+// primary.
+var ErrOutReadPreference = errors.New("an aggregation with a $out or $merge stage cannot have a read preference other than primary")
+
+// HostSelector returns a server selector that selects the server at the given address, e.g.
+// "localhost:27017", whatever its type. It is used with the ServerSelector options of databases,
+// collections and RunCommand to run operations against a specific member of a deployment.
+func HostSelector(addr string) description.ServerSelector {
+	host := address.Address(addr).Canonicalize()
+	return description.ServerSelectorFunc(func(_ description.Topology, candidates []description.Server) ([]description.Server, error) {
+		for _, s := range candidates {
+			if s.Addr.Canonicalize() == host {
+				return []description.Server{s}, nil
+			}
+		}
+		return nil, nil
+	})
+}
+
+// writeSelector returns the selector write operations use in place of the selector ss supplied by
+// the user. Writes fail with ErrWriteToNonPrimary instead of being sent to a server ss selected that
+// is known not to accept them.
+func writeSelector(ss description.ServerSelector) description.ServerSelector {
+	return description.ServerSelectorFunc(func(t description.Topology, candidates []description.Server) ([]description.Server, error) {
+		selected, err := ss.SelectServer(t, candidates)
+		if err != nil || t.Kind == description.Single {
+			return selected, err
+		}
+
+		for _, s := range selected {
+			switch s.Kind {
+			case description.RSSecondary, description.RSArbiter, description.RSMember, description.RSGhost:
+				return nil, ErrWriteToNonPrimary
+			}
+		}
+		return selected, nil
+	})
+}
+
+// failingSelector returns a selector that fails with err, so that operations fail with it before
+// any command is sent.
+func failingSelector(err error) description.ServerSelector {
+	return description.ServerSelectorFunc(func(description.Topology, []description.Server) ([]description.Server, error) {
+		return nil, err
+	})
+}
+
+// selectedReadPref returns the read preference reads run with against a server selected by a
+// selector supplied by the user. Since the server may be a secondary, reads that would require a
+// primary use primaryPreferred instead, as they do against a server connected to directly.
+func selectedReadPref(rp *readpref.ReadPref) *readpref.ReadPref {
+	if rp == nil || rp.Mode() == readpref.PrimaryMode {
+		return readpref.PrimaryPreferred()
+	}
+	return rp
+}
+
+// readSelector returns the read preference and the selector of a read run with the read preference
+// rp of the operation in place of the one of the collection or database it runs against. A selector
+// supplied by the user with the ServerSelector options still selects the server, as it does for
+// RunCommand.
+func readSelector(client *Client, userSelector description.ServerSelector,
+	rp *readpref.ReadPref) (*readpref.ReadPref, description.ServerSelector) {
+
+	if userSelector != nil {
+		return selectedReadPref(rp), userSelector
+	}
+	return rp, description.CompositeSelector([]description.ServerSelector{
+		description.ReadPrefSelector(rp),
+		description.LatencySelector(client.localThreshold),
+	})
+}
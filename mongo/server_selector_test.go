@@ -0,0 +1,73 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/mongo/collectionopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostSelector(t *testing.T) {
+	primary := description.Server{Addr: address.Address("a:27017"), Kind: description.RSPrimary}
+	secondary := description.Server{Addr: address.Address("b:27017"), Kind: description.RSSecondary}
+	topo := description.Topology{
+		Kind:    description.ReplicaSetWithPrimary,
+		Servers: []description.Server{primary, secondary},
+	}
+
+	selected, err := HostSelector("B:27017").SelectServer(topo, topo.Servers)
+	require.NoError(t, err)
+	require.Equal(t, []description.Server{secondary}, selected)
+
+	selected, err = HostSelector("c:27017").SelectServer(topo, topo.Servers)
+	require.NoError(t, err)
+	require.Empty(t, selected)
+}
+
+func TestWriteSelector(t *testing.T) {
+	primary := description.Server{Addr: address.Address("a:27017"), Kind: description.RSPrimary}
+	secondary := description.Server{Addr: address.Address("b:27017"), Kind: description.RSSecondary}
+	rs := description.Topology{
+		Kind:    description.ReplicaSetWithPrimary,
+		Servers: []description.Server{primary, secondary},
+	}
+
+	selected, err := writeSelector(HostSelector("a:27017")).SelectServer(rs, rs.Servers)
+	require.NoError(t, err)
+	require.Equal(t, []description.Server{primary}, selected)
+
+	_, err = writeSelector(HostSelector("b:27017")).SelectServer(rs, rs.Servers)
+	require.Equal(t, ErrWriteToNonPrimary, err)
+
+	// a server connected to directly is trusted to reject the writes it cannot accept
+	single := description.Topology{Kind: description.Single, Servers: []description.Server{secondary}}
+	selected, err = writeSelector(HostSelector("b:27017")).SelectServer(single, single.Servers)
+	require.NoError(t, err)
+	require.Equal(t, []description.Server{secondary}, selected)
+}
+
+func TestServerSelectorOption(t *testing.T) {
+	d := mongotest.New(mongotest.WithReplicaSet("rs"))
+	d.Handle("count", mongotest.OK(bson.EC.Int32("n", 1)))
+
+	client := newMockClient(t, d)
+
+	coll := client.Database("db").Collection("coll", collectionopt.ServerSelector(HostSelector(d.Address())))
+	_, err := coll.Count(context.Background(), nil)
+	require.NoError(t, err)
+
+	count := d.LastCommand("count")
+	require.NotNil(t, count)
+	require.Equal(t, "primaryPreferred", count.Document.Lookup("$readPreference", "mode").StringValue())
+}
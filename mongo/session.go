@@ -18,6 +18,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/topology"
 	"github.com/mongodb/mongo-go-driver/mongo/aggregateopt"
 	"github.com/mongodb/mongo-go-driver/mongo/changestreamopt"
+	"github.com/mongodb/mongo-go-driver/mongo/collmodopt"
 	"github.com/mongodb/mongo-go-driver/mongo/countopt"
 	"github.com/mongodb/mongo-go-driver/mongo/dbopt"
 	"github.com/mongodb/mongo-go-driver/mongo/deleteopt"
@@ -30,6 +31,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/mongo/listdbopt"
 	"github.com/mongodb/mongo-go-driver/mongo/replaceopt"
 	"github.com/mongodb/mongo-go-driver/mongo/runcmdopt"
+	"github.com/mongodb/mongo-go-driver/mongo/statsopt"
 	"github.com/mongodb/mongo-go-driver/mongo/transactionopt"
 	"github.com/mongodb/mongo-go-driver/mongo/updateopt"
 )
@@ -42,6 +44,7 @@ var ErrWrongClient = errors.New("session was not created by this client")
 type Session struct {
 	aggregateopt.AggregateSessionOpt
 	changestreamopt.ChangeStreamSessionOpt
+	collmodopt.CollModSessionOpt
 	countopt.CountSessionOpt
 	deleteopt.DeleteSessionOpt
 	distinctopt.DistinctSessionOpt
@@ -55,6 +58,7 @@ type Session struct {
 	insertopt.InsertSessionOpt
 	runcmdopt.RunCmdSessionOpt
 	listdbopt.ListDatabasesSessionOpt
+	statsopt.StatsSessionOpt
 	*session.Client
 	topo                *topology.Topology
 	didCommitAfterStart bool // true if commit was called after start with no other operations
@@ -64,6 +68,7 @@ var (
 	_ aggregateopt.Aggregate            = (*Session)(nil)
 	_ countopt.Count                    = (*Session)(nil)
 	_ changestreamopt.ChangeStream      = (*Session)(nil)
+	_ collmodopt.Option                 = (*Session)(nil)
 	_ deleteopt.Delete                  = (*Session)(nil)
 	_ distinctopt.Distinct              = (*Session)(nil)
 	_ dbopt.DropDB                      = (*Session)(nil)
@@ -83,6 +88,7 @@ var (
 	_ replaceopt.Replace                = (*Session)(nil)
 	_ runcmdopt.Option                  = (*Session)(nil)
 	_ listdbopt.ListDatabases           = (*Session)(nil)
+	_ statsopt.Option                   = (*Session)(nil)
 )
 
 // EndSession ends the session.
@@ -174,6 +180,11 @@ func (s *Session) ConvertChangeStreamSession() *session.Client {
 	return s.Client
 }
 
+// ConvertCollModSession implements the CollModSession interface.
+func (s *Session) ConvertCollModSession() *session.Client {
+	return s.Client
+}
+
 // ConvertCountSession implements the CountSession interface.
 func (s *Session) ConvertCountSession() *session.Client {
 	return s.Client
@@ -258,3 +269,8 @@ func (s *Session) ConvertRunCmdSession() *session.Client {
 func (s *Session) ConvertListDatabasesSession() *session.Client {
 	return s.Client
 }
+
+// ConvertStatsSession implements the StatsSession interface.
+func (s *Session) ConvertStatsSession() *session.Client {
+	return s.Client
+}
@@ -218,7 +218,7 @@ func createMonitoredTopology(t *testing.T, clock *session.ClusterClock, monitor
 	if err != nil {
 		t.Fatal(err)
 	}
-	s, err := sessionsMonitoredTop.SelectServer(context.Background(), description.WriteSelector())
+	s, err := sessionsMonitoredTop.SelectServerLegacy(context.Background(), description.WriteSelector())
 	if err != nil {
 		t.Fatal(err)
 	}
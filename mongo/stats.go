@@ -0,0 +1,272 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/dispatch"
+	"github.com/mongodb/mongo-go-driver/core/readpref"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/mongodb/mongo-go-driver/mongo/statsopt"
+	"go.opencensus.io/tag"
+)
+
+// CollStats holds the storage statistics of a collection, as reported by the collStats command.
+// Sizes are in bytes divided by the scale, which is ScaleFactor. Statistics the server does not
+// report are left zero: FreeStorageSize and TotalSize are reported from MongoDB 4.4 on, AvgObjSize is
+// not reported for empty collections by older servers, and Max and MaxSize only for capped
+// collections.
+type CollStats struct {
+	Namespace       string
+	Count           int64
+	Size            int64
+	AvgObjSize      float64
+	StorageSize     int64
+	FreeStorageSize int64
+	TotalSize       int64
+	NIndexes        int64
+	TotalIndexSize  int64
+	IndexSizes      map[string]int64
+	Capped          bool
+	Max             int64
+	MaxSize         int64
+	ScaleFactor     int64
+	// Sharded is set when the statistics are reported by a mongos for a sharded collection, in
+	// which case they are the sums of those of every shard.
+	Sharded bool
+	// WiredTiger holds the statistics of the WiredTiger storage engine, such as those of its cache,
+	// whose fields vary across server versions. It is nil with other storage engines.
+	WiredTiger bson.Reader
+}
+
+// DBStats holds the storage statistics of a database, as reported by the dbStats command. Sizes
+// are in bytes divided by the scale, which is ScaleFactor. Statistics the server does not report
+// are left zero: Views is reported from MongoDB 3.4 on, FSUsedSize and FSTotalSize from MongoDB 3.6
+// on and TotalSize from MongoDB 4.4 on.
+type DBStats struct {
+	DB          string
+	Collections int64
+	Views       int64
+	Objects     int64
+	AvgObjSize  float64
+	DataSize    int64
+	StorageSize int64
+	Indexes     int64
+	IndexSize   int64
+	TotalSize   int64
+	ScaleFactor int64
+	FSUsedSize  int64
+	FSTotalSize int64
+}
+
+// Stats returns the storage statistics of the collection with the collStats command. It runs with
+// the read preference of the collection.
+func (coll *Collection) Stats(ctx context.Context, opts ...statsopt.Option) (CollStats, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "coll_stats"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Collection).Stats")
+	defer span.End()
+
+	cmd := bson.NewDocument(bson.EC.String("collStats", coll.name))
+	rdr, err := runStats(ctx, coll.db, cmd, coll.readPreference, coll.readSelector, opts)
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+		return CollStats{}, err
+	}
+
+	return collStatsFromReply(rdr)
+}
+
+// Stats returns the storage statistics of the database with the dbStats command. It runs with the
+// read preference of the database.
+func (db *Database) Stats(ctx context.Context, opts ...statsopt.Option) (DBStats, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "db_stats"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Database).Stats")
+	defer span.End()
+
+	cmd := bson.NewDocument(bson.EC.Int32("dbStats", 1))
+	rdr, err := runStats(ctx, db, cmd, db.readPreference, db.readSelector, opts)
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+		return DBStats{}, err
+	}
+
+	return dbStatsFromReply(rdr)
+}
+
+// runStats runs the statistics command cmd against db with the given options.
+func runStats(ctx context.Context, db *Database, cmd *bson.Document, rp *readpref.ReadPref,
+	selector description.ServerSelector, opts []statsopt.Option) (bson.Reader, error) {
+
+	so, sess, err := statsopt.BundleStats(opts...).Unbundle()
+	if err != nil {
+		observability.RecordError(ctx, "statsopt_bundlestats", err)
+		return nil, err
+	}
+
+	err = db.client.ValidSession(sess)
+	if err != nil {
+		observability.RecordError(ctx, "client_validsession", err)
+		return nil, err
+	}
+
+	if so.Scale != nil {
+		cmd.Append(bson.EC.Int32("scale", *so.Scale))
+	}
+
+	return dispatch.Read(ctx,
+		command.Read{
+			DB:       db.Name(),
+			Command:  cmd,
+			ReadPref: rp,
+			Session:  sess,
+			Clock:    db.client.clock,
+		},
+		db.client.topology,
+		selector,
+		db.client.id,
+		db.client.topology.SessionPool,
+	)
+}
+
+func collStatsFromReply(rdr bson.Reader) (CollStats, error) {
+	var stats CollStats
+	iter, err := rdr.Iterator()
+	if err != nil {
+		return stats, err
+	}
+
+	for iter.Next() {
+		elem := iter.Element()
+		v := elem.Value()
+		switch elem.Key() {
+		case "ns":
+			stats.Namespace, _ = v.StringValueOK()
+		case "count":
+			stats.Count = statsInt(v)
+		case "size":
+			stats.Size = statsInt(v)
+		case "avgObjSize":
+			stats.AvgObjSize = statsFloat(v)
+		case "storageSize":
+			stats.StorageSize = statsInt(v)
+		case "freeStorageSize":
+			stats.FreeStorageSize = statsInt(v)
+		case "totalSize":
+			stats.TotalSize = statsInt(v)
+		case "nindexes":
+			stats.NIndexes = statsInt(v)
+		case "totalIndexSize":
+			stats.TotalIndexSize = statsInt(v)
+		case "indexSizes":
+			sizes, ok := v.ReaderDocumentOK()
+			if !ok {
+				continue
+			}
+			stats.IndexSizes = make(map[string]int64)
+			sizesIter, err := sizes.Iterator()
+			if err != nil {
+				return stats, err
+			}
+			for sizesIter.Next() {
+				stats.IndexSizes[sizesIter.Element().Key()] = statsInt(sizesIter.Element().Value())
+			}
+			if err = sizesIter.Err(); err != nil {
+				return stats, err
+			}
+		case "capped":
+			stats.Capped, _ = v.BooleanOK()
+		case "max":
+			stats.Max = statsInt(v)
+		case "maxSize":
+			stats.MaxSize = statsInt(v)
+		case "scaleFactor":
+			stats.ScaleFactor = statsInt(v)
+		case "sharded":
+			stats.Sharded, _ = v.BooleanOK()
+		case "wiredTiger":
+			if wt, ok := v.ReaderDocumentOK(); ok {
+				stats.WiredTiger = append(bson.Reader(nil), wt...)
+			}
+		}
+	}
+
+	return stats, iter.Err()
+}
+
+func dbStatsFromReply(rdr bson.Reader) (DBStats, error) {
+	var stats DBStats
+	iter, err := rdr.Iterator()
+	if err != nil {
+		return stats, err
+	}
+
+	for iter.Next() {
+		elem := iter.Element()
+		v := elem.Value()
+		switch elem.Key() {
+		case "db":
+			stats.DB, _ = v.StringValueOK()
+		case "collections":
+			stats.Collections = statsInt(v)
+		case "views":
+			stats.Views = statsInt(v)
+		case "objects":
+			stats.Objects = statsInt(v)
+		case "avgObjSize":
+			stats.AvgObjSize = statsFloat(v)
+		case "dataSize":
+			stats.DataSize = statsInt(v)
+		case "storageSize":
+			stats.StorageSize = statsInt(v)
+		case "indexes":
+			stats.Indexes = statsInt(v)
+		case "indexSize":
+			stats.IndexSize = statsInt(v)
+		case "totalSize":
+			stats.TotalSize = statsInt(v)
+		case "scaleFactor":
+			stats.ScaleFactor = statsInt(v)
+		case "fsUsedSize":
+			stats.FSUsedSize = statsInt(v)
+		case "fsTotalSize":
+			stats.FSTotalSize = statsInt(v)
+		}
+	}
+
+	return stats, iter.Err()
+}
+
+// statsInt returns the value of a statistic, which servers report as a 32-bit integer, a 64-bit
+// integer or a double depending on their version and on its magnitude. Doubles are truncated.
+func statsInt(v *bson.Value) int64 {
+	if f, ok := v.DoubleOK(); ok {
+		return int64(f)
+	}
+	n, _ := v.AsInt64()
+	return n
+}
+
+// statsFloat returns the value of a statistic like statsInt, without truncating doubles.
+func statsFloat(v *bson.Value) float64 {
+	if f, ok := v.DoubleOK(); ok {
+		return f
+	}
+	n, _ := v.AsInt64()
+	return float64(n)
+}
@@ -0,0 +1,204 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/mongodb/mongo-go-driver/mongo/statsopt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollStatsFromReply(t *testing.T) {
+	reply := func(elems ...*bson.Element) bson.Reader {
+		b, err := bson.NewDocument(elems...).MarshalBSON()
+		require.NoError(t, err)
+		return b
+	}
+
+	t.Run("3.0 empty collection", func(t *testing.T) {
+		// no avgObjSize on an empty collection, no scaleFactor, and 32-bit integers
+		stats, err := collStatsFromReply(reply(
+			bson.EC.String("ns", "db.coll"),
+			bson.EC.Int32("count", 0),
+			bson.EC.Int32("size", 0),
+			bson.EC.Int32("storageSize", 4096),
+			bson.EC.Boolean("capped", false),
+			bson.EC.Int32("nindexes", 1),
+			bson.EC.Int32("totalIndexSize", 4096),
+			bson.EC.SubDocumentFromElements("indexSizes", bson.EC.Int32("_id_", 4096)),
+			bson.EC.Double("ok", 1),
+		))
+		require.NoError(t, err)
+		require.Equal(t, CollStats{
+			Namespace:      "db.coll",
+			StorageSize:    4096,
+			NIndexes:       1,
+			TotalIndexSize: 4096,
+			IndexSizes:     map[string]int64{"_id_": 4096},
+		}, stats)
+	})
+	t.Run("4.4 scaled capped collection", func(t *testing.T) {
+		// freeStorageSize and totalSize appear, and large sizes are 64-bit integers or doubles
+		stats, err := collStatsFromReply(reply(
+			bson.EC.String("ns", "db.log"),
+			bson.EC.Int64("size", 5000000000),
+			bson.EC.Int32("count", 20000000),
+			bson.EC.Double("avgObjSize", 250.5),
+			bson.EC.Double("storageSize", 1.2e9),
+			bson.EC.Int32("freeStorageSize", 16),
+			bson.EC.Boolean("capped", true),
+			bson.EC.Int32("max", 0),
+			bson.EC.Int64("maxSize", 8000000000),
+			bson.EC.SubDocumentFromElements("wiredTiger",
+				bson.EC.SubDocumentFromElements("cache", bson.EC.Int64("bytes currently in the cache", 524288)),
+			),
+			bson.EC.Int32("nindexes", 2),
+			bson.EC.Int64("totalIndexSize", 300000000),
+			bson.EC.Int64("totalSize", 1500000000),
+			bson.EC.SubDocumentFromElements("indexSizes",
+				bson.EC.Int32("_id_", 100000000),
+				bson.EC.Double("ts_1", 2e8),
+			),
+			bson.EC.Int32("scaleFactor", 1024),
+			bson.EC.Double("ok", 1),
+		))
+		require.NoError(t, err)
+		require.Equal(t, int64(5000000000), stats.Size)
+		require.Equal(t, int64(20000000), stats.Count)
+		require.Equal(t, 250.5, stats.AvgObjSize)
+		require.Equal(t, int64(1200000000), stats.StorageSize)
+		require.Equal(t, int64(16), stats.FreeStorageSize)
+		require.Equal(t, int64(1500000000), stats.TotalSize)
+		require.True(t, stats.Capped)
+		require.Equal(t, int64(8000000000), stats.MaxSize)
+		require.Equal(t, map[string]int64{"_id_": 100000000, "ts_1": 200000000}, stats.IndexSizes)
+		require.Equal(t, int64(1024), stats.ScaleFactor)
+		require.False(t, stats.Sharded)
+
+		cache, err := stats.WiredTiger.Lookup("cache", "bytes currently in the cache")
+		require.NoError(t, err)
+		require.Equal(t, int64(524288), cache.Value().Int64())
+	})
+	t.Run("mongos", func(t *testing.T) {
+		stats, err := collStatsFromReply(reply(
+			bson.EC.Boolean("sharded", true),
+			bson.EC.String("ns", "db.coll"),
+			bson.EC.Int64("count", 10),
+			bson.EC.SubDocumentFromElements("shards", bson.EC.SubDocumentFromElements("sh0", bson.EC.Int32("count", 10))),
+			bson.EC.Int32("ok", 1),
+		))
+		require.NoError(t, err)
+		require.True(t, stats.Sharded)
+		require.Equal(t, int64(10), stats.Count)
+		require.Nil(t, stats.WiredTiger)
+	})
+}
+
+func TestDBStatsFromReply(t *testing.T) {
+	reply := func(elems ...*bson.Element) bson.Reader {
+		b, err := bson.NewDocument(elems...).MarshalBSON()
+		require.NoError(t, err)
+		return b
+	}
+
+	t.Run("3.2", func(t *testing.T) {
+		// no views nor filesystem sizes, and numExtents with MMAPv1
+		stats, err := dbStatsFromReply(reply(
+			bson.EC.String("db", "db"),
+			bson.EC.Int32("collections", 3),
+			bson.EC.Int32("objects", 7),
+			bson.EC.Int32("avgObjSize", 48),
+			bson.EC.Int32("dataSize", 336),
+			bson.EC.Int32("storageSize", 12288),
+			bson.EC.Int32("numExtents", 3),
+			bson.EC.Int32("indexes", 3),
+			bson.EC.Int32("indexSize", 12288),
+			bson.EC.Double("ok", 1),
+		))
+		require.NoError(t, err)
+		require.Equal(t, DBStats{
+			DB:          "db",
+			Collections: 3,
+			Objects:     7,
+			AvgObjSize:  48,
+			DataSize:    336,
+			StorageSize: 12288,
+			Indexes:     3,
+			IndexSize:   12288,
+		}, stats)
+	})
+	t.Run("4.4", func(t *testing.T) {
+		stats, err := dbStatsFromReply(reply(
+			bson.EC.String("db", "db"),
+			bson.EC.Int64("collections", 3),
+			bson.EC.Int64("views", 1),
+			bson.EC.Int64("objects", 7),
+			bson.EC.Double("avgObjSize", 48.25),
+			bson.EC.Double("dataSize", 0.328125),
+			bson.EC.Double("storageSize", 12),
+			bson.EC.Int64("indexes", 3),
+			bson.EC.Double("indexSize", 12),
+			bson.EC.Double("totalSize", 24),
+			bson.EC.Int64("scaleFactor", 1024),
+			bson.EC.Double("fsUsedSize", 1.5e7),
+			bson.EC.Double("fsTotalSize", 6e7),
+			bson.EC.Double("ok", 1),
+		))
+		require.NoError(t, err)
+		require.Equal(t, DBStats{
+			DB:          "db",
+			Collections: 3,
+			Views:       1,
+			Objects:     7,
+			AvgObjSize:  48.25,
+			StorageSize: 12,
+			Indexes:     3,
+			IndexSize:   12,
+			TotalSize:   24,
+			ScaleFactor: 1024,
+			FSUsedSize:  15000000,
+			FSTotalSize: 60000000,
+		}, stats)
+	})
+}
+
+func TestStats(t *testing.T) {
+	d := mongotest.New()
+	d.Handle("collStats", mongotest.OK(bson.EC.String("ns", "db.coll"), bson.EC.Int32("count", 2)))
+	d.Handle("dbStats", mongotest.OK(bson.EC.String("db", "db"), bson.EC.Int32("collections", 1)))
+
+	client := newMockClient(t, d)
+
+	ctx := context.Background()
+	db := client.Database("db")
+
+	collStats, err := db.Collection("coll").Stats(ctx, statsopt.Scale(1024))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), collStats.Count)
+	cmd := d.LastCommand("collStats")
+	require.Equal(t, "coll", cmd.Document.Lookup("collStats").StringValue())
+	require.Equal(t, int32(1024), cmd.Document.Lookup("scale").Int32())
+
+	dbStats, err := db.Stats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), dbStats.Collections)
+	cmd = d.LastCommand("dbStats")
+	require.Equal(t, "db", cmd.Database)
+	_, err = cmd.Document.LookupErr("scale")
+	require.Error(t, err)
+
+	_, err = db.Stats(ctx, statsopt.Scale(0))
+	require.Equal(t, statsopt.ErrInvalidScale, err)
+
+	d.Handle("collStats", mongotest.Error(166, "CommandNotSupportedOnView", "Namespace db.view is a view, not a collection"))
+	_, err = db.Collection("view").Stats(ctx)
+	require.Error(t, err)
+}
@@ -0,0 +1,163 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package statsopt contains the options of Collection.Stats and Database.Stats, which read the
+// storage statistics of a collection or a database with the collStats and dbStats commands.
+package statsopt
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/mongodb/mongo-go-driver/core/session"
+)
+
+// ErrInvalidScale is returned when the scale of the sizes is not positive.
+var ErrInvalidScale = errors.New("the scale of the sizes must be positive")
+
+var statsBundle = new(StatsBundle)
+
+// Option represents a Stats option.
+type Option interface {
+	statsOption()
+}
+
+// StatsSession is the session for the Stats() functions.
+type StatsSession interface {
+	Option
+	ConvertStatsSession() *session.Client
+}
+
+// optionFunc adds the option to the Stats instance.
+type optionFunc func(*Stats) error
+
+// Stats holds the options of Stats.
+type Stats struct {
+	Scale *int32
+}
+
+// StatsBundle is a bundle of Stats options.
+type StatsBundle struct {
+	option Option
+	next   *StatsBundle
+}
+
+func (*StatsBundle) statsOption() {}
+
+func (optionFunc) statsOption() {}
+
+// BundleStats bundles Stats options.
+func BundleStats(opts ...Option) *StatsBundle {
+	head := statsBundle
+
+	for _, opt := range opts {
+		newBundle := StatsBundle{
+			option: opt,
+			next:   head,
+		}
+		head = &newBundle
+	}
+
+	return head
+}
+
+// Scale sets the number of bytes of the unit the sizes are reported in.
+func (sb *StatsBundle) Scale(scale int32) *StatsBundle {
+	return &StatsBundle{
+		option: Scale(scale),
+		next:   sb,
+	}
+}
+
+// Unbundle unbundles the options, returning a Stats instance. It returns ErrInvalidScale if the
+// scale is not positive.
+func (sb *StatsBundle) Unbundle() (*Stats, *session.Client, error) {
+	s := &Stats{}
+	sess, err := sb.unbundle(s)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if s.Scale != nil && *s.Scale <= 0 {
+		return nil, nil, ErrInvalidScale
+	}
+
+	return s, sess, nil
+}
+
+// Helper that recursively unwraps the bundle.
+func (sb *StatsBundle) unbundle(s *Stats) (*session.Client, error) {
+	if sb == nil {
+		return nil, nil
+	}
+
+	var sess *session.Client
+	for head := sb; head != nil && head.option != nil; head = head.next {
+		var err error
+		switch opt := head.option.(type) {
+		case *StatsBundle:
+			ss, e := opt.unbundle(s) // add all bundle's options to s
+			if ss != nil && sess == nil {
+				sess = ss
+			}
+			err = e
+		case optionFunc:
+			err = opt(s) // add option to s
+		case StatsSession:
+			if sess == nil {
+				sess = opt.ConvertStatsSession()
+			}
+		default:
+			return sess, nil
+		}
+		if err != nil {
+			return sess, err
+		}
+	}
+
+	return sess, nil
+}
+
+// String implements the Stringer interface
+func (sb *StatsBundle) String() string {
+	if sb == nil {
+		return ""
+	}
+
+	str := ""
+	for head := sb; head != nil && head.option != nil; head = head.next {
+		switch opt := head.option.(type) {
+		case *StatsBundle:
+			str += opt.String()
+		case optionFunc:
+			str += reflect.TypeOf(opt).String() + "\n"
+		}
+	}
+
+	return str
+}
+
+// Scale sets the number of bytes of the unit the sizes are reported in, such as 1024 for kilobytes.
+// The server rounds the scaled sizes down to integers. The default is 1.
+func Scale(scale int32) Option {
+	return optionFunc(
+		func(s *Stats) error {
+			if s.Scale == nil {
+				s.Scale = &scale
+			}
+			return nil
+		})
+}
+
+// StatsSessionOpt is a Stats session option.
+type StatsSessionOpt struct{}
+
+func (StatsSessionOpt) statsOption() {}
+
+// ConvertStatsSession implements the StatsSession interface.
+func (StatsSessionOpt) ConvertStatsSession() *session.Client {
+	return nil
+}
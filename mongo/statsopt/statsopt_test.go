@@ -0,0 +1,38 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package statsopt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsOpt(t *testing.T) {
+	t.Run("Unbundle", func(t *testing.T) {
+		s, sess, err := BundleStats(Scale(1024), BundleStats(Scale(1))).Unbundle()
+		require.NoError(t, err)
+		require.Nil(t, sess)
+		require.Equal(t, int32(1), *s.Scale)
+
+		s, _, err = BundleStats().Scale(1024).Unbundle()
+		require.NoError(t, err)
+		require.Equal(t, int32(1024), *s.Scale)
+	})
+	t.Run("NilBundle", func(t *testing.T) {
+		var bundle *StatsBundle
+		s, _, err := bundle.Unbundle()
+		require.NoError(t, err)
+		require.Equal(t, &Stats{}, s)
+	})
+	t.Run("InvalidScale", func(t *testing.T) {
+		for _, scale := range []int32{0, -1} {
+			_, _, err := BundleStats(Scale(scale)).Unbundle()
+			require.Equal(t, ErrInvalidScale, err)
+		}
+	})
+}
@@ -91,6 +91,37 @@ func (ub *UpdateBundle) Collation(c *mongoopt.Collation) *UpdateBundle {
 	return bundle
 }
 
+// Comment adds an option to specify a value to help trace the operation through the database profiler, currentOp, and logs.
+func (ub *UpdateBundle) Comment(comment interface{}) *UpdateBundle {
+	bundle := &UpdateBundle{
+		option: Comment(comment),
+		next:   ub,
+	}
+
+	return bundle
+}
+
+// Let adds an option to specify a document of variables that can be accessed in the filter and
+// update.
+func (ub *UpdateBundle) Let(let interface{}) *UpdateBundle {
+	bundle := &UpdateBundle{
+		option: Let(let),
+		next:   ub,
+	}
+
+	return bundle
+}
+
+// Ordered adds an option to specify whether the update statements stop at the first write error.
+func (ub *UpdateBundle) Ordered(b bool) *UpdateBundle {
+	bundle := &UpdateBundle{
+		option: Ordered(b),
+		next:   ub,
+	}
+
+	return bundle
+}
+
 // Upsert adds an option to specify whether to insert the document if it is not present.
 func (ub *UpdateBundle) Upsert(b bool) *UpdateBundle {
 	bundle := &UpdateBundle{
@@ -244,6 +275,25 @@ func Collation(c *mongoopt.Collation) OptCollation {
 	return OptCollation{Collation: c.Convert()}
 }
 
+// Comment specifies a value of any BSON type to help trace the operation through the database
+// profiler, currentOp, and logs. It requires server version 4.4 or newer.
+func Comment(comment interface{}) OptComment {
+	return OptComment{Comment: comment}
+}
+
+// Let specifies a document of variables that can be accessed in the filter and update using $$var.
+// It is set on the update command rather than on each statement and requires server version 5.0 or
+// newer.
+func Let(let interface{}) OptLet {
+	return OptLet{let}
+}
+
+// Ordered specifies whether the update statements stop at the first write error. If false, the
+// remaining statements are executed, including those of later batches.
+func Ordered(b bool) OptOrdered {
+	return OptOrdered(b)
+}
+
 // Upsert specifies whether to insert the document if it is not present.
 func Upsert(b bool) OptUpsert {
 	return OptUpsert(b)
@@ -279,6 +329,36 @@ func (opt OptCollation) ConvertUpdateOption() option.UpdateOptioner {
 	return option.OptCollation(opt)
 }
 
+// OptComment specifies a value to help trace the operation through the database profiler, currentOp, and logs.
+type OptComment option.OptComment
+
+func (OptComment) update() {}
+
+// ConvertUpdateOption implements the Update interface.
+func (opt OptComment) ConvertUpdateOption() option.UpdateOptioner {
+	return option.OptComment(opt)
+}
+
+// OptLet specifies a document of variables that can be accessed in the filter and update.
+type OptLet option.OptLet
+
+func (OptLet) update() {}
+
+// ConvertUpdateOption implements the Update interface.
+func (opt OptLet) ConvertUpdateOption() option.UpdateOptioner {
+	return option.OptLet(opt)
+}
+
+// OptOrdered specifies whether the update statements stop at the first write error.
+type OptOrdered option.OptOrdered
+
+func (OptOrdered) update() {}
+
+// ConvertUpdateOption implements the Update interface.
+func (opt OptOrdered) ConvertUpdateOption() option.UpdateOptioner {
+	return option.OptOrdered(opt)
+}
+
 // OptUpsert specifies whether to insert the document if it is not present.
 type OptUpsert option.OptUpsert
 
@@ -153,6 +153,7 @@ func TestUpdateOpt(t *testing.T) {
 			ArrayFilters(filters),
 			BypassDocumentValidation(true),
 			Collation(c),
+			Ordered(false),
 			Upsert(false),
 		}
 		params := make([]Update, len(opts))
@@ -0,0 +1,470 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/auth"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/dispatch"
+	"github.com/mongodb/mongo-go-driver/internal/observability"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"go.opencensus.io/tag"
+)
+
+// ErrEmptyUserName is returned by the user management methods of Database when given an empty
+// user name.
+var ErrEmptyUserName = errors.New("mongo: the user name cannot be empty")
+
+// Role identifies a role by its name and the database it is defined in.
+type Role = options.Role
+
+// AuthenticationRestriction restricts the addresses a user can authenticate from and to.
+type AuthenticationRestriction = options.AuthenticationRestriction
+
+// Privilege is a set of actions allowed on a resource.
+type Privilege struct {
+	// Resource is the resource the actions are allowed on, such as {db: "test", collection: ""}
+	// or {cluster: true}.
+	Resource *bson.Document `bson:"resource"`
+	Actions  []string       `bson:"actions"`
+}
+
+// UserInfo describes a user as returned by Database.UsersInfo. Credentials are never requested,
+// so they are not included.
+type UserInfo struct {
+	// ID is the name of the user qualified by its database, such as "admin.alice".
+	ID                         string                      `bson:"_id"`
+	User                       string                      `bson:"user"`
+	DB                         string                      `bson:"db"`
+	Roles                      []Role                      `bson:"roles"`
+	Mechanisms                 []string                    `bson:"mechanisms"`
+	AuthenticationRestrictions []AuthenticationRestriction `bson:"authenticationRestrictions"`
+	CustomData                 *bson.Document              `bson:"customData"`
+	// InheritedRoles and InheritedPrivileges are only set when the ShowPrivileges option is.
+	InheritedRoles      []Role      `bson:"inheritedRoles"`
+	InheritedPrivileges []Privilege `bson:"inheritedPrivileges"`
+}
+
+// RoleInfo describes a role as returned by Database.RolesInfo.
+type RoleInfo struct {
+	Role      string `bson:"role"`
+	DB        string `bson:"db"`
+	IsBuiltin bool   `bson:"isBuiltin"`
+	// Roles are the roles this role inherits from directly, and InheritedRoles all of the roles
+	// it inherits from.
+	Roles          []Role `bson:"roles"`
+	InheritedRoles []Role `bson:"inheritedRoles"`
+	// Privileges and InheritedPrivileges are only set when the ShowPrivileges option is.
+	Privileges          []Privilege `bson:"privileges"`
+	InheritedPrivileges []Privilege `bson:"inheritedPrivileges"`
+}
+
+// CreateUser creates a user of the database with the given password and roles. Users of the
+// $external database, who authenticate with x.509 or LDAP, are created with an empty password. A
+// user can supply a custom context to this method, or nil to default to context.Background().
+//
+// MongoDB 4.0 and later digest the password themselves, which they need to do to derive
+// SCRAM-SHA-256 credentials. Older servers are sent the digest the driver computes, so the password
+// itself never leaves the client. Neither is ever reported to command monitors or logged.
+func (db *Database) CreateUser(ctx context.Context, user, password string, roles []Role,
+	opts ...*options.CreateUserOptions) error {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "create_user"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Database).CreateUser")
+	defer span.End()
+
+	if user == "" {
+		return ErrEmptyUserName
+	}
+
+	var mechanisms []string
+	var restrictions []AuthenticationRestriction
+	var customData *bson.Document
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Mechanisms != nil {
+			mechanisms = opt.Mechanisms
+		}
+		if opt.AuthenticationRestrictions != nil {
+			restrictions = opt.AuthenticationRestrictions
+		}
+		if opt.CustomData != nil {
+			data, err := transformDocument(db.registry, "customData", opt.CustomData)
+			if err != nil {
+				return err
+			}
+			customData = data
+		}
+	}
+
+	err := db.runUserCommand(ctx, func(desc description.SelectedServer) (*bson.Document, error) {
+		cmd := bson.NewDocument(bson.EC.String("createUser", user))
+		if password != "" {
+			cmd.Append(passwordElements(desc, user, password)...)
+		}
+		cmd.Append(bson.EC.Array("roles", rolesArray(roles)))
+		return cmd, appendUserOptions(cmd, desc, mechanisms, restrictions, customData)
+	})
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+	}
+	return err
+}
+
+// UpdateUser changes the password, roles, mechanisms, authentication restrictions or custom data
+// of a user of the database, as set in opts. The password is sent as it is by CreateUser. A user
+// can supply a custom context to this method, or nil to default to context.Background().
+func (db *Database) UpdateUser(ctx context.Context, user string, opts ...*options.UpdateUserOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "update_user"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Database).UpdateUser")
+	defer span.End()
+
+	if user == "" {
+		return ErrEmptyUserName
+	}
+
+	var password *string
+	var roles []Role
+	var mechanisms []string
+	var restrictions []AuthenticationRestriction
+	var customData *bson.Document
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Password != nil {
+			password = opt.Password
+		}
+		if opt.Roles != nil {
+			roles = opt.Roles
+		}
+		if opt.Mechanisms != nil {
+			mechanisms = opt.Mechanisms
+		}
+		if opt.AuthenticationRestrictions != nil {
+			restrictions = opt.AuthenticationRestrictions
+		}
+		if opt.CustomData != nil {
+			data, err := transformDocument(db.registry, "customData", opt.CustomData)
+			if err != nil {
+				return err
+			}
+			customData = data
+		}
+	}
+
+	err := db.runUserCommand(ctx, func(desc description.SelectedServer) (*bson.Document, error) {
+		cmd := bson.NewDocument(bson.EC.String("updateUser", user))
+		if password != nil {
+			cmd.Append(passwordElements(desc, user, *password)...)
+		}
+		if roles != nil {
+			cmd.Append(bson.EC.Array("roles", rolesArray(roles)))
+		}
+		return cmd, appendUserOptions(cmd, desc, mechanisms, restrictions, customData)
+	})
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+	}
+	return err
+}
+
+// DropUser removes a user of the database. A user can supply a custom context to this method, or
+// nil to default to context.Background().
+func (db *Database) DropUser(ctx context.Context, user string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "drop_user"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Database).DropUser")
+	defer span.End()
+
+	if user == "" {
+		return ErrEmptyUserName
+	}
+
+	_, err := dispatch.Write(ctx,
+		command.Write{
+			DB:           db.name,
+			Command:      bson.NewDocument(bson.EC.String("dropUser", user)),
+			WriteConcern: db.writeConcern,
+			Clock:        db.client.clock,
+		},
+		db.client.topology,
+		db.writeSelector,
+		db.client.id,
+		db.client.topology.SessionPool,
+	)
+	if err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+	}
+	return err
+}
+
+// UsersInfo returns the users of the database with the given names, or all of them if users is
+// empty. The users authenticated by x.509 or LDAP are those of the $external database. A user can
+// supply a custom context to this method, or nil to default to context.Background().
+func (db *Database) UsersInfo(ctx context.Context, users []string,
+	opts ...*options.UsersInfoOptions) ([]UserInfo, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "users_info"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Database).UsersInfo")
+	defer span.End()
+
+	var showPrivileges, showRestrictions, allDatabases *bool
+	var filter *bson.Document
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.ShowPrivileges != nil {
+			showPrivileges = opt.ShowPrivileges
+		}
+		if opt.ShowAuthenticationRestrictions != nil {
+			showRestrictions = opt.ShowAuthenticationRestrictions
+		}
+		if opt.AllDatabases != nil {
+			allDatabases = opt.AllDatabases
+		}
+		if opt.Filter != nil {
+			f, err := transformDocument(db.registry, "filter", opt.Filter)
+			if err != nil {
+				return nil, err
+			}
+			filter = f
+		}
+	}
+
+	cmd := bson.NewDocument()
+	switch {
+	case allDatabases != nil && *allDatabases:
+		cmd.Append(bson.EC.SubDocumentFromElements("usersInfo", bson.EC.Boolean("forAllDBs", true)))
+	case len(users) == 0:
+		cmd.Append(bson.EC.Int32("usersInfo", 1))
+	default:
+		cmd.Append(bson.EC.Array("usersInfo", stringsArray(users)))
+	}
+	if showPrivileges != nil {
+		cmd.Append(bson.EC.Boolean("showPrivileges", *showPrivileges))
+	}
+	if showRestrictions != nil {
+		cmd.Append(bson.EC.Boolean("showAuthenticationRestrictions", *showRestrictions))
+	}
+	if filter != nil {
+		cmd.Append(bson.EC.SubDocument("filter", filter))
+	}
+
+	var reply struct {
+		Users []UserInfo `bson:"users"`
+	}
+	if err := db.readUserCommand(ctx, cmd, &reply); err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+		return nil, err
+	}
+	return reply.Users, nil
+}
+
+// RolesInfo returns the roles of the database with the given names, or all of the roles defined
+// by users if roles is empty. A user can supply a custom context to this method, or nil to
+// default to context.Background().
+func (db *Database) RolesInfo(ctx context.Context, roles []string,
+	opts ...*options.RolesInfoOptions) ([]RoleInfo, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = observability.Tag(ctx, tag.Insert(observability.KeyMethod, "roles_info"))
+	ctx, span := observability.StartSpan(ctx, "mongo-go/mongo.(*Database).RolesInfo")
+	defer span.End()
+
+	var showPrivileges, showBuiltinRoles *bool
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.ShowPrivileges != nil {
+			showPrivileges = opt.ShowPrivileges
+		}
+		if opt.ShowBuiltinRoles != nil {
+			showBuiltinRoles = opt.ShowBuiltinRoles
+		}
+	}
+
+	cmd := bson.NewDocument()
+	if len(roles) == 0 {
+		cmd.Append(bson.EC.Int32("rolesInfo", 1))
+	} else {
+		cmd.Append(bson.EC.Array("rolesInfo", stringsArray(roles)))
+	}
+	if showPrivileges != nil {
+		cmd.Append(bson.EC.Boolean("showPrivileges", *showPrivileges))
+	}
+	if showBuiltinRoles != nil {
+		cmd.Append(bson.EC.Boolean("showBuiltinRoles", *showBuiltinRoles))
+	}
+
+	var reply struct {
+		Roles []RoleInfo `bson:"roles"`
+	}
+	if err := db.readUserCommand(ctx, cmd, &reply); err != nil {
+		span.SetStatus(observability.SpanStatus(err))
+		return nil, err
+	}
+	return reply.Roles, nil
+}
+
+// runUserCommand runs the command build returns for the server selected to run it, which is
+// needed because how passwords are sent depends on the version of the server.
+func (db *Database) runUserCommand(ctx context.Context,
+	build func(desc description.SelectedServer) (*bson.Document, error)) error {
+
+	if db.nameErr != nil {
+		return db.nameErr
+	}
+
+	ss, err := db.client.topology.SelectServer(ctx, db.writeSelector)
+	if err != nil {
+		return err
+	}
+
+	conn, err := ss.Connection(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	desc := ss.Description()
+	cmd, err := build(desc)
+	if err != nil {
+		return err
+	}
+
+	write := command.Write{
+		DB:           db.name,
+		Command:      cmd,
+		WriteConcern: db.writeConcern,
+		Clock:        db.client.clock,
+	}
+	_, err = write.RoundTrip(ctx, desc, conn)
+	if err != nil {
+		observability.RecordError(ctx, "roundtrip_write", err)
+	}
+	return err
+}
+
+// readUserCommand runs the usersInfo or rolesInfo command cmd and unmarshals its reply into
+// reply.
+func (db *Database) readUserCommand(ctx context.Context, cmd *bson.Document, reply interface{}) error {
+	rdr, err := dispatch.Read(ctx,
+		command.Read{
+			DB:       db.name,
+			Command:  cmd,
+			ReadPref: db.readPreference,
+			Clock:    db.client.clock,
+		},
+		db.client.topology,
+		db.readSelector,
+		db.client.id,
+		db.client.topology.SessionPool,
+	)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(rdr, reply)
+}
+
+// passwordElements returns the elements that set the password of user in a createUser or
+// updateUser command run against the server desc describes.
+func passwordElements(desc description.SelectedServer, user, password string) []*bson.Element {
+	if serverDigestsPasswords(desc) {
+		return []*bson.Element{
+			bson.EC.String("pwd", password),
+			bson.EC.Boolean("digestPassword", true),
+		}
+	}
+	return []*bson.Element{
+		bson.EC.String("pwd", auth.PasswordDigest(user, password)),
+		bson.EC.Boolean("digestPassword", false),
+	}
+}
+
+// serverDigestsPasswords returns true if the server desc describes is MongoDB 4.0 or later, which
+// digest the passwords sent to them and support SCRAM-SHA-256.
+func serverDigestsPasswords(desc description.SelectedServer) bool {
+	return desc.WireVersion != nil && desc.SupportsFeature(description.FeatureSCRAMSHA256)
+}
+
+// appendUserOptions appends the options shared by createUser and updateUser to cmd.
+func appendUserOptions(cmd *bson.Document, desc description.SelectedServer, mechanisms []string,
+	restrictions []AuthenticationRestriction, customData *bson.Document) error {
+
+	if mechanisms != nil {
+		for _, mechanism := range mechanisms {
+			if mechanism == auth.SCRAMSHA256 {
+				if err := desc.CheckFeature(description.FeatureSCRAMSHA256); err != nil {
+					return err
+				}
+			}
+		}
+		cmd.Append(bson.EC.Array("mechanisms", stringsArray(mechanisms)))
+	}
+	if restrictions != nil {
+		arr := bson.NewArray()
+		for _, r := range restrictions {
+			restriction := bson.NewDocument()
+			if len(r.ClientSource) > 0 {
+				restriction.Append(bson.EC.Array("clientSource", stringsArray(r.ClientSource)))
+			}
+			if len(r.ServerAddress) > 0 {
+				restriction.Append(bson.EC.Array("serverAddress", stringsArray(r.ServerAddress)))
+			}
+			arr.Append(bson.VC.Document(restriction))
+		}
+		cmd.Append(bson.EC.Array("authenticationRestrictions", arr))
+	}
+	if customData != nil {
+		cmd.Append(bson.EC.SubDocument("customData", customData))
+	}
+	return nil
+}
+
+func rolesArray(roles []Role) *bson.Array {
+	arr := bson.NewArray()
+	for _, r := range roles {
+		arr.Append(bson.VC.DocumentFromElements(bson.EC.String("role", r.Role), bson.EC.String("db", r.DB)))
+	}
+	return arr
+}
+
+func stringsArray(strs []string) *bson.Array {
+	arr := bson.NewArray()
+	for _, s := range strs {
+		arr.Append(bson.VC.String(s))
+	}
+	return arr
+}
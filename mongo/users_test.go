@@ -0,0 +1,207 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/auth"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/event"
+	"github.com/mongodb/mongo-go-driver/mongo/clientopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserManagement(t *testing.T) {
+	var mu sync.Mutex
+	var monitored []string
+	monitor := &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			monitored = append(monitored, e.Command.ToExtJSON(false))
+		},
+	}
+	connect := func(t *testing.T, d *mongotest.Deployment, dbName string) *Database {
+		return newMockClient(t, d, clientopt.Monitor(monitor)).Database(dbName)
+	}
+	requireNotMonitored := func(t *testing.T, secret string) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, cmd := range monitored {
+			require.False(t, strings.Contains(cmd, secret), "%q was reported to a monitor in %s", secret, cmd)
+		}
+	}
+	roles := []Role{{Role: "readWrite", DB: "app"}}
+
+	t.Run("create user", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("createUser", mongotest.OK())
+		db := connect(t, d, "app")
+
+		err := db.CreateUser(context.Background(), "alice", "s3cret-create", roles,
+			options.CreateUser().
+				SetMechanisms("SCRAM-SHA-256").
+				SetAuthenticationRestrictions(AuthenticationRestriction{ClientSource: []string{"10.0.0.0/8"}}).
+				SetCustomData(bson.NewDocument(bson.EC.String("team", "ops"))))
+		require.NoError(t, err)
+
+		cmd := d.LastCommand("createUser").Document
+		require.Equal(t, "alice", cmd.Lookup("createUser").StringValue())
+		require.Equal(t, "s3cret-create", cmd.Lookup("pwd").StringValue())
+		require.True(t, cmd.Lookup("digestPassword").Boolean())
+		require.Equal(t, "readWrite", cmd.Lookup("roles", "0", "role").StringValue())
+		require.Equal(t, "app", cmd.Lookup("roles", "0", "db").StringValue())
+		require.Equal(t, "SCRAM-SHA-256", cmd.Lookup("mechanisms", "0").StringValue())
+		require.Equal(t, "10.0.0.0/8", cmd.Lookup("authenticationRestrictions", "0", "clientSource", "0").StringValue())
+		require.Equal(t, "ops", cmd.Lookup("customData", "team").StringValue())
+		requireNotMonitored(t, "s3cret-create")
+	})
+	t.Run("create user before 4.0", func(t *testing.T) {
+		d := mongotest.New(mongotest.WithMaxWireVersion(6))
+		d.Handle("createUser", mongotest.OK())
+		db := connect(t, d, "app")
+
+		require.NoError(t, db.CreateUser(context.Background(), "alice", "s3cret-old", nil))
+
+		cmd := d.LastCommand("createUser").Document
+		require.Equal(t, auth.PasswordDigest("alice", "s3cret-old"), cmd.Lookup("pwd").StringValue())
+		require.False(t, cmd.Lookup("digestPassword").Boolean())
+		require.Equal(t, 0, cmd.Lookup("roles").MutableArray().Len())
+
+		err := db.CreateUser(context.Background(), "bob", "pw", nil, options.CreateUser().SetMechanisms("SCRAM-SHA-256"))
+		unsupported, ok := err.(description.ErrUnsupportedServerVersion)
+		require.True(t, ok, "expected an ErrUnsupportedServerVersion, got %v", err)
+		require.Equal(t, description.FeatureSCRAMSHA256, unsupported.Feature)
+		require.Equal(t, int32(6), unsupported.ServerWireVersion)
+	})
+	t.Run("external user", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("createUser", mongotest.OK())
+		db := connect(t, d, "$external")
+
+		require.NoError(t, db.CreateUser(context.Background(), "CN=alice,OU=eng", "", roles))
+
+		cmd := d.LastCommand("createUser").Document
+		require.Equal(t, "$external", cmd.Lookup("$db").StringValue())
+		_, err := cmd.LookupErr("pwd")
+		require.Error(t, err)
+	})
+	t.Run("update user", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("updateUser", mongotest.OK())
+		db := connect(t, d, "app")
+
+		require.NoError(t, db.UpdateUser(context.Background(), "alice",
+			options.UpdateUser().SetPassword("s3cret-update"), options.UpdateUser().SetRoles()))
+
+		cmd := d.LastCommand("updateUser").Document
+		require.Equal(t, "s3cret-update", cmd.Lookup("pwd").StringValue())
+		require.Equal(t, 0, cmd.Lookup("roles").MutableArray().Len())
+		_, err := cmd.LookupErr("mechanisms")
+		require.Error(t, err)
+		requireNotMonitored(t, "s3cret-update")
+	})
+	t.Run("drop user", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("dropUser", mongotest.OK())
+		db := connect(t, d, "app")
+
+		require.NoError(t, db.DropUser(context.Background(), "alice"))
+		require.Equal(t, "alice", d.LastCommand("dropUser").Document.Lookup("dropUser").StringValue())
+		require.Equal(t, ErrEmptyUserName, db.DropUser(context.Background(), ""))
+	})
+	t.Run("users info", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("usersInfo", mongotest.OK(bson.EC.ArrayFromElements("users",
+			bson.VC.DocumentFromElements(
+				bson.EC.String("_id", "app.alice"),
+				bson.EC.String("user", "alice"),
+				bson.EC.String("db", "app"),
+				bson.EC.ArrayFromElements("roles", bson.VC.DocumentFromElements(
+					bson.EC.String("role", "readWrite"),
+					bson.EC.String("db", "app"),
+				)),
+				bson.EC.ArrayFromElements("mechanisms", bson.VC.String("SCRAM-SHA-1")),
+				bson.EC.SubDocumentFromElements("customData", bson.EC.String("team", "ops")),
+				bson.EC.ArrayFromElements("inheritedPrivileges", bson.VC.DocumentFromElements(
+					bson.EC.SubDocumentFromElements("resource",
+						bson.EC.String("db", "app"),
+						bson.EC.String("collection", ""),
+					),
+					bson.EC.ArrayFromElements("actions", bson.VC.String("find")),
+				)),
+			),
+		)))
+		db := connect(t, d, "app")
+
+		users, err := db.UsersInfo(context.Background(), nil, options.UsersInfo().SetShowPrivileges(true))
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.Equal(t, "app.alice", users[0].ID)
+		require.Equal(t, "alice", users[0].User)
+		require.Equal(t, roles, users[0].Roles)
+		require.Equal(t, []string{"SCRAM-SHA-1"}, users[0].Mechanisms)
+		require.Equal(t, "ops", users[0].CustomData.Lookup("team").StringValue())
+		require.Len(t, users[0].InheritedPrivileges, 1)
+		require.Equal(t, []string{"find"}, users[0].InheritedPrivileges[0].Actions)
+
+		cmd := d.LastCommand("usersInfo").Document
+		require.Equal(t, int32(1), cmd.Lookup("usersInfo").Int32())
+		require.True(t, cmd.Lookup("showPrivileges").Boolean())
+
+		_, err = db.UsersInfo(context.Background(), []string{"alice", "bob"})
+		require.NoError(t, err)
+		require.Equal(t, "bob", d.LastCommand("usersInfo").Document.Lookup("usersInfo", "1").StringValue())
+
+		_, err = db.UsersInfo(context.Background(), nil, options.UsersInfo().SetAllDatabases(true))
+		require.NoError(t, err)
+		require.True(t, d.LastCommand("usersInfo").Document.Lookup("usersInfo", "forAllDBs").Boolean())
+	})
+	t.Run("roles info", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("rolesInfo", mongotest.OK(bson.EC.ArrayFromElements("roles",
+			bson.VC.DocumentFromElements(
+				bson.EC.String("role", "reporting"),
+				bson.EC.String("db", "app"),
+				bson.EC.Boolean("isBuiltin", false),
+				bson.EC.ArrayFromElements("roles", bson.VC.DocumentFromElements(
+					bson.EC.String("role", "read"),
+					bson.EC.String("db", "app"),
+				)),
+			),
+		)))
+		db := connect(t, d, "app")
+
+		infos, err := db.RolesInfo(context.Background(), []string{"reporting"}, options.RolesInfo().SetShowBuiltinRoles(true))
+		require.NoError(t, err)
+		require.Equal(t, []RoleInfo{{
+			Role:  "reporting",
+			DB:    "app",
+			Roles: []Role{{Role: "read", DB: "app"}},
+		}}, infos)
+
+		cmd := d.LastCommand("rolesInfo").Document
+		require.Equal(t, "reporting", cmd.Lookup("rolesInfo", "0").StringValue())
+		require.True(t, cmd.Lookup("showBuiltinRoles").Boolean())
+	})
+	t.Run("command error", func(t *testing.T) {
+		d := mongotest.New()
+		d.Handle("createUser", mongotest.Error(51003, "Location51003", "User already exists"))
+		db := connect(t, d, "app")
+
+		err := db.CreateUser(context.Background(), "alice", "s3cret-error", roles)
+		require.Error(t, err)
+		require.NotContains(t, err.Error(), "s3cret-error")
+	})
+}
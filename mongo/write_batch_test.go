@@ -0,0 +1,135 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/dispatch"
+	"github.com/mongodb/mongo-go-driver/mongo/deleteopt"
+	"github.com/mongodb/mongo-go-driver/mongo/mongotest"
+	"github.com/mongodb/mongo-go-driver/mongo/updateopt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBatchRetry(t *testing.T) {
+	// sentBatches returns the statements of each command with the given name, identified by their
+	// q.x value, and the txnNumber each command was sent with.
+	sentBatches := func(d *mongotest.Deployment, name, statements string) ([][]int32, []int64) {
+		var stmts [][]int32
+		var txnNumbers []int64
+		for _, cmd := range d.CommandsNamed(name) {
+			var batch []int32
+			itr, err := cmd.Document.Lookup(statements).MutableArray().Iterator()
+			require.NoError(t, err)
+			for itr.Next() {
+				batch = append(batch, itr.Value().MutableDocument().Lookup("q", "x").Int32())
+			}
+			stmts = append(stmts, batch)
+			txnNumbers = append(txnNumbers, cmd.Document.Lookup("txnNumber").Int64())
+		}
+		return stmts, txnNumbers
+	}
+	statements := func(n int, stmt func(i int32) *bson.Document) []*bson.Document {
+		docs := make([]*bson.Document, 0, n)
+		for i := 0; i < n; i++ {
+			docs = append(docs, stmt(int32(i)))
+		}
+		return docs
+	}
+	newDeployment := func(name string, reply *bson.Document) *mongotest.Deployment {
+		d := mongotest.New(mongotest.WithMongos(), mongotest.WithMaxWriteBatchSize(1))
+		ok := mongotest.Reply(reply)
+		d.Handle(name, mongotest.Sequence(
+			ok,
+			mongotest.Error(91, "ShutdownInProgress", "shutdown in progress"),
+			ok,
+		))
+		return d
+	}
+
+	t.Run("update resumes at failed batch", func(t *testing.T) {
+		d := newDeployment("update", bson.NewDocument(
+			bson.EC.Int32("ok", 1),
+			bson.EC.Int32("n", 1),
+			bson.EC.Int32("nModified", 1),
+		))
+		client := newMockClient(t, d)
+
+		coll := client.Database("db").Collection("coll")
+		cmd := command.Update{
+			NS: coll.namespace(),
+			Docs: statements(3, func(i int32) *bson.Document {
+				return bson.NewDocument(
+					bson.EC.SubDocumentFromElements("q", bson.EC.Int32("x", i)),
+					bson.EC.SubDocumentFromElements("u", bson.EC.SubDocumentFromElements("$set", bson.EC.Int32("y", 1))),
+				)
+			}),
+			WriteConcern: coll.writeConcern,
+			Clock:        client.clock,
+		}
+		res, err := dispatch.Update(context.Background(), cmd, client.topology, coll.writeSelector,
+			client.id, client.topology.SessionPool, true)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), res.MatchedCount)
+		require.Equal(t, int64(3), res.ModifiedCount)
+
+		stmts, txnNumbers := sentBatches(d, "update", "updates")
+		require.Equal(t, [][]int32{{0}, {1}, {1}, {2}}, stmts)
+		require.Equal(t, []int64{1, 2, 2, 3}, txnNumbers)
+	})
+	t.Run("delete resumes at failed batch", func(t *testing.T) {
+		d := newDeployment("delete", bson.NewDocument(
+			bson.EC.Int32("ok", 1),
+			bson.EC.Int32("n", 1),
+		))
+		client := newMockClient(t, d)
+
+		coll := client.Database("db").Collection("coll")
+		cmd := command.Delete{
+			NS: coll.namespace(),
+			Deletes: statements(3, func(i int32) *bson.Document {
+				return bson.NewDocument(
+					bson.EC.SubDocumentFromElements("q", bson.EC.Int32("x", i)),
+					bson.EC.Int32("limit", 0),
+				)
+			}),
+			WriteConcern: coll.writeConcern,
+			Clock:        client.clock,
+		}
+		res, err := dispatch.Delete(context.Background(), cmd, client.topology, coll.writeSelector,
+			client.id, client.topology.SessionPool, true)
+		require.NoError(t, err)
+		require.Equal(t, 3, res.N)
+
+		stmts, txnNumbers := sentBatches(d, "delete", "deletes")
+		require.Equal(t, [][]int32{{0}, {1}, {1}, {2}}, stmts)
+		require.Equal(t, []int64{1, 2, 2, 3}, txnNumbers)
+	})
+	t.Run("ordered option", func(t *testing.T) {
+		d := mongotest.New(mongotest.WithMongos())
+		d.Handle("update", mongotest.OK())
+		d.Handle("delete", mongotest.OK())
+		client := newMockClient(t, d)
+
+		coll := client.Database("db").Collection("coll")
+		_, err := coll.UpdateMany(context.Background(), bson.NewDocument(),
+			bson.NewDocument(bson.EC.SubDocumentFromElements("$set", bson.EC.Int32("y", 1))),
+			updateopt.Ordered(false))
+		require.NoError(t, err)
+		_, err = coll.DeleteMany(context.Background(), bson.NewDocument(), deleteopt.Ordered(false))
+		require.NoError(t, err)
+
+		for _, name := range []string{"update", "delete"} {
+			require.Equal(t, 1, d.CountCommands(name))
+			require.False(t, d.LastCommand(name).Document.Lookup("ordered").Boolean(), "%s is ordered", name)
+		}
+	})
+}